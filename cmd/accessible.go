@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// accessibleEnvVar enables accessible mode without the flag; it is the same
+// variable huh documents for its accessible prompts
+const accessibleEnvVar = "ACCESSIBLE"
+
+// GetAccessible returns whether accessible mode is enabled
+func GetAccessible() bool {
+	return accessible || os.Getenv(accessibleEnvVar) != ""
+}
+
+// plainRedirect routes one of the standard streams through a PlainWriter
+type plainRedirect struct {
+	target   **os.File
+	original *os.File
+	pipe     *os.File
+	done     chan struct{}
+}
+
+// redirectPlain replaces *target with a pipe whose contents are converted
+// to plain text and copied to the original file
+func redirectPlain(target **os.File) (*plainRedirect, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output pipe: %w", err)
+	}
+
+	redirect := &plainRedirect{target: target, original: *target, pipe: w, done: make(chan struct{})}
+	go func() {
+		defer close(redirect.done)
+		if _, err := io.Copy(output.NewPlainWriter(redirect.original), r); err != nil {
+			fmt.Fprintf(redirect.original, "Failed to write output: %v\n", err)
+		}
+		if err := r.Close(); err != nil {
+			fmt.Fprintf(redirect.original, "Failed to close output pipe: %v\n", err)
+		}
+	}()
+
+	*target = w
+	return redirect, nil
+}
+
+// restore puts the original file back and waits until buffered output is written
+func (p *plainRedirect) restore() {
+	*p.target = p.original
+	if err := p.pipe.Close(); err != nil {
+		fmt.Fprintf(p.original, "Failed to close output pipe: %v\n", err)
+	}
+	<-p.done
+}
+
+// activeRedirects holds the streams redirected by startAccessibleOutput
+var activeRedirects []*plainRedirect
+
+// startAccessibleOutput converts everything printed to stderr, and to stdout
+// unless JSON output is requested, into plain text: no emoji, colors or
+// non-ASCII bars, so screen readers read results without noise
+func startAccessibleOutput() error {
+	targets := []**os.File{&os.Stderr}
+	if !GetJSONOutput() {
+		targets = append(targets, &os.Stdout)
+	}
+
+	for _, target := range targets {
+		redirect, err := redirectPlain(target)
+		if err != nil {
+			stopAccessibleOutput()
+			return err
+		}
+		activeRedirects = append(activeRedirects, redirect)
+	}
+	return nil
+}
+
+// stopAccessibleOutput restores the standard streams and flushes pending output
+func stopAccessibleOutput() {
+	for i := len(activeRedirects) - 1; i >= 0; i-- {
+		activeRedirects[i].restore()
+	}
+	activeRedirects = nil
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Create shortcuts for t42 commands",
+	Long: `Aliases are shortcuts for t42 commands and their flags, saved in
+config.yaml. They are expanded before the command runs, and arguments given
+after an alias are appended, or substituted for $1, $2... placeholders.`,
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <expansion>",
+	Short: "Create a shortcut for a t42 command",
+	Long: `Save an alias expanding to a t42 command and its flags. Quote the
+expansion to keep it one argument; $1, $2... take the arguments given after
+the alias in their place, and the others are appended.
+
+An alias cannot shadow a t42 command; --clobber replaces an existing alias.
+
+Examples:
+  t42 alias set bh "user list --blackhole-status upcoming --cursus-id 21"
+  t42 alias set who 'location show $1'
+  t42 bh --campus tokyo
+  t42 who jdoe`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAliasSet,
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List your aliases",
+	Args:    cobra.NoArgs,
+	RunE:    runAliasList,
+}
+
+var aliasDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete an alias",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runAliasDelete,
+	ValidArgsFunction: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		if cfg, err := config.LoadConfig(); err == nil {
+			for name := range cfg.Aliases {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasDeleteCmd)
+	rootCmd.AddCommand(aliasCmd)
+
+	aliasSetCmd.Flags().Bool("clobber", false, "Replace an existing alias of the same name")
+}
+
+// aliasPlaceholder matches the $1, $2... placeholders of an expansion
+var aliasPlaceholder = regexp.MustCompile(`\$(\d+)`)
+
+// commandAlias is an alias as listed
+type commandAlias struct {
+	Name      string `json:"name"`
+	Expansion string `json:"expansion"`
+}
+
+func runAliasSet(cmd *cobra.Command, args []string) error {
+	name, expansion := args[0], strings.TrimSpace(args[1])
+	clobber, _ := cmd.Flags().GetBool("clobber")
+
+	if err := validateAliasName(name); err != nil {
+		return err
+	}
+	if err := validateAliasExpansion(expansion); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if existing, ok := cfg.Aliases[name]; ok && !clobber {
+		return fmt.Errorf("alias %s already expands to %q - use --clobber to replace it", name, existing)
+	}
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[name] = expansion
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if GetJSONOutput() {
+		return writeOutputValue(map[string]interface{}{"success": true, "name": name, "expansion": expansion})
+	}
+	fmt.Printf("✅ t42 %s → t42 %s\n", name, expansion)
+	return nil
+}
+
+func runAliasList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	aliases := make([]commandAlias, 0, len(cfg.Aliases))
+	for name, expansion := range cfg.Aliases {
+		aliases = append(aliases, commandAlias{Name: name, Expansion: expansion})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+
+	if done, err := emitOutputValue(aliases); done || err != nil {
+		return err
+	}
+	if len(aliases) == 0 {
+		fmt.Println("No aliases - create one with 't42 alias set'.")
+		return nil
+	}
+	for _, a := range aliases {
+		fmt.Printf("%-16s %s\n", a.Name+":", a.Expansion)
+	}
+	return nil
+}
+
+func runAliasDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if _, ok := cfg.Aliases[name]; !ok {
+		return fmt.Errorf("no alias named %s", name)
+	}
+	delete(cfg.Aliases, name)
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if GetJSONOutput() {
+		return writeOutputValue(map[string]interface{}{"success": true, "removed": name})
+	}
+	fmt.Printf("✅ Deleted alias %s\n", name)
+	return nil
+}
+
+// validateAliasName refuses names that are not a single word or that a
+// t42 command already answers to
+func validateAliasName(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t\n\"'$") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	if isCommandName(name) {
+		return fmt.Errorf("%s is a t42 command and cannot be an alias", name)
+	}
+	return nil
+}
+
+// validateAliasExpansion checks that an expansion starts with a t42
+// command
+func validateAliasExpansion(expansion string) error {
+	words, err := splitCommandLine(expansion)
+	if err != nil {
+		return fmt.Errorf("invalid expansion: %w", err)
+	}
+	if len(words) > 0 && words[0] == "t42" {
+		words = words[1:]
+	}
+	if len(words) == 0 || !isCommandName(words[0]) {
+		return fmt.Errorf("expansion %q does not start with a t42 command", expansion)
+	}
+	return nil
+}
+
+// isCommandName reports whether a top-level command answers to name,
+// including the help and completion commands cobra adds on execution
+func isCommandName(name string) bool {
+	switch name {
+	case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandAlias rewrites the arguments of a command line starting with an
+// alias into the command it stands for. Arguments fill the $N placeholders
+// of the expansion; the ones no placeholder takes are appended. It reports
+// false when the first argument is not an alias.
+func expandAlias(args []string, aliases map[string]string) ([]string, bool, error) {
+	if len(args) == 0 || isCommandName(args[0]) {
+		return args, false, nil
+	}
+	expansion, ok := aliases[args[0]]
+	if !ok {
+		return args, false, nil
+	}
+	params := args[1:]
+
+	words, err := splitCommandLine(expansion)
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid alias %s: %w", args[0], err)
+	}
+	if len(words) > 0 && words[0] == "t42" {
+		words = words[1:]
+	}
+
+	used := make(map[int]bool)
+	expanded := make([]string, 0, len(words)+len(params))
+	for _, word := range words {
+		var missing string
+		word = aliasPlaceholder.ReplaceAllStringFunc(word, func(m string) string {
+			n, _ := strconv.Atoi(m[1:])
+			if n < 1 || n > len(params) {
+				missing = m
+				return m
+			}
+			used[n] = true
+			return params[n-1]
+		})
+		if missing != "" {
+			return nil, true, fmt.Errorf("alias %s needs an argument for %s", args[0], missing)
+		}
+		expanded = append(expanded, word)
+	}
+	for i, p := range params {
+		if !used[i+1] {
+			expanded = append(expanded, p)
+		}
+	}
+	return expanded, true, nil
+}
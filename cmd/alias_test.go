@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandAlias(t *testing.T) {
+	aliases := map[string]string{
+		"bh":  "user list --blackhole-status upcoming --cursus-id 21",
+		"who": "location show $1",
+		"ev":  `t42 user eligible --project "$1" --campus $2`,
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     []string
+		expanded bool
+		wantErr  bool
+	}{
+		{name: "no args", args: nil},
+		{name: "command", args: []string{"user", "list"}, want: []string{"user", "list"}},
+		{name: "unknown", args: []string{"nope"}, want: []string{"nope"}},
+		{
+			name:     "arguments appended",
+			args:     []string{"bh", "--campus", "tokyo"},
+			want:     []string{"user", "list", "--blackhole-status", "upcoming", "--cursus-id", "21", "--campus", "tokyo"},
+			expanded: true,
+		},
+		{
+			name:     "placeholder",
+			args:     []string{"who", "jdoe", "--json"},
+			want:     []string{"location", "show", "jdoe", "--json"},
+			expanded: true,
+		},
+		{
+			name:     "placeholders keep arguments whole",
+			args:     []string{"ev", "ft_transcendence", "42 tokyo"},
+			want:     []string{"user", "eligible", "--project", "ft_transcendence", "--campus", "42 tokyo"},
+			expanded: true,
+		},
+		{name: "missing argument", args: []string{"who"}, expanded: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, expanded, err := expandAlias(tt.args, aliases)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if expanded != tt.expanded {
+				t.Errorf("expandAlias() expanded = %v, want %v", expanded, tt.expanded)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandAlias() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAlias(t *testing.T) {
+	tests := []struct {
+		name      string
+		alias     string
+		expansion string
+		wantErr   bool
+	}{
+		{name: "valid", alias: "bh", expansion: "user list --blackhole-status upcoming"},
+		{name: "t42 prefix", alias: "bh", expansion: "t42 user list"},
+		{name: "shadows a command", alias: "user", expansion: "user list", wantErr: true},
+		{name: "shadows help", alias: "help", expansion: "user list", wantErr: true},
+		{name: "not one word", alias: "b h", expansion: "user list", wantErr: true},
+		{name: "not a command", alias: "bh", expansion: "ls -la", wantErr: true},
+		{name: "unterminated quote", alias: "bh", expansion: `user show "jdoe`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAliasName(tt.alias)
+			if err == nil {
+				err = validateAliasExpansion(tt.expansion)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
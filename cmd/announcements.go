@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/state"
+)
+
+const (
+	// announcementsStateName is the state document holding read markers
+	announcementsStateName = "announcements"
+
+	// announcementReadRetention is how long read markers are kept; older
+	// announcements have long left the listing window
+	announcementReadRetention = 365 * 24 * time.Hour
+
+	// announcementURLFormat is the intra page of an announcement without a link
+	announcementURLFormat = "https://profile.intra.42.fr/announcements/%d"
+)
+
+var announcementsCmd = &cobra.Command{
+	Use:     "announcements",
+	Aliases: []string{"news"},
+	Short:   "Read campus announcements",
+	Long: `List recent announcements of your campus, newest first.
+
+Announcements you have not opened yet are marked as unread. Read markers
+are stored locally, so 't42 announcements --unread' shows only what is new
+since you last looked. Use --open to read an announcement in your browser
+(which marks it read) or --mark-read to mark everything listed as read.
+
+Without --campus or --campus-id, your primary campus is used.
+
+Examples:
+  t42 announcements
+  t42 announcements --campus tokyo --days 7
+  t42 announcements --unread
+  t42 announcements --open 1234`,
+	RunE: runAnnouncements,
+}
+
+// announcementReadState is the persisted set of read announcements
+type announcementReadState struct {
+	// Read maps announcement IDs to the time they were read
+	Read map[string]time.Time `json:"read"`
+}
+
+// announcementItem is an announcement with its local read status
+type announcementItem struct {
+	api.Announcement
+	Unread bool `json:"unread"`
+}
+
+func init() {
+	rootCmd.AddCommand(announcementsCmd)
+
+	addCampusFlags(announcementsCmd)
+	announcementsCmd.Flags().Int("days", 30, "Show announcements from the last N days")
+	announcementsCmd.Flags().IntP("limit", "l", 20, "Maximum number of announcements to show")
+	announcementsCmd.Flags().Bool("unread", false, "Only show unread announcements")
+	announcementsCmd.Flags().Bool("mark-read", false, "Mark the listed announcements as read")
+	announcementsCmd.Flags().Int("open", 0, "Open the announcement with this ID in the browser and mark it read")
+}
+
+func runAnnouncements(cmd *cobra.Command, args []string) error {
+	days, _ := cmd.Flags().GetInt("days")
+	limit, _ := cmd.Flags().GetInt("limit")
+	unreadOnly, _ := cmd.Flags().GetBool("unread")
+	markRead, _ := cmd.Flags().GetBool("mark-read")
+	openID, _ := cmd.Flags().GetInt("open")
+
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+
+	store, err := state.Open()
+	if err != nil {
+		return err
+	}
+	var readState announcementReadState
+	if _, err := store.Load(announcementsStateName, &readState); err != nil {
+		return err
+	}
+	if readState.Read == nil {
+		readState.Read = make(map[string]time.Time)
+	}
+
+	// Create API client with automatic token refresh
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	campus, err := resolveCampusFlags(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	if campus == nil {
+		me, err := client.GetMe(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get your profile: %w", err)
+		}
+		if campus = primaryCampus(me); campus == nil {
+			return fmt.Errorf("you have no campus - use --campus or --campus-id")
+		}
+	}
+
+	now := time.Now()
+	announcements, err := fetchAnnouncements(ctx, client, campus.ID, now.AddDate(0, 0, -days))
+	if err != nil {
+		return err
+	}
+
+	if openID > 0 {
+		return openAnnouncement(store, &readState, announcements, openID, now)
+	}
+
+	items := announcementItems(announcements, readState, unreadOnly)
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+
+	if markRead {
+		for _, item := range items {
+			readState.Read[strconv.Itoa(item.ID)] = now
+		}
+		pruneReadMarkers(&readState, now)
+		if err := store.Save(announcementsStateName, readState); err != nil {
+			return err
+		}
+	}
+
+	result := map[string]interface{}{
+		"campus":        campus.Name,
+		"announcements": items,
+		"count":         len(items),
+	}
+	if done, err := emitOutputValue(result); done || err != nil {
+		return err
+	}
+
+	printAnnouncements(items, campus.Name, days, unreadOnly, markRead)
+	return nil
+}
+
+// fetchAnnouncements returns all announcements of a campus created since the given time
+func fetchAnnouncements(ctx context.Context, client *api.Client, campusID int, since time.Time) ([]api.Announcement, error) {
+	var all []api.Announcement
+	err := api.FetchAll(ctx, api.DefaultPerPage,
+		func(ctx context.Context, page int) ([]api.Announcement, *api.PaginationMeta, error) {
+			return client.ListAnnouncements(ctx, &api.ListAnnouncementsOptions{
+				Page:     page,
+				PerPage:  api.DefaultPerPage,
+				CampusID: campusID,
+				Since:    since,
+			})
+		},
+		func(announcements []api.Announcement, _ *api.PaginationMeta) error {
+			all = append(all, announcements...)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	return all, nil
+}
+
+// announcementItems attaches read status to announcements, dropping read
+// ones when unreadOnly is set
+func announcementItems(announcements []api.Announcement, readState announcementReadState, unreadOnly bool) []announcementItem {
+	items := make([]announcementItem, 0, len(announcements))
+	for _, a := range announcements {
+		_, read := readState.Read[strconv.Itoa(a.ID)]
+		if unreadOnly && read {
+			continue
+		}
+		items = append(items, announcementItem{Announcement: a, Unread: !read})
+	}
+	return items
+}
+
+// pruneReadMarkers forgets markers older than the retention period
+func pruneReadMarkers(readState *announcementReadState, now time.Time) {
+	for id, readAt := range readState.Read {
+		if now.Sub(readAt) > announcementReadRetention {
+			delete(readState.Read, id)
+		}
+	}
+}
+
+// announcementURL returns the page showing the full announcement
+func announcementURL(a api.Announcement) string {
+	if a.Link != "" {
+		return a.Link
+	}
+	return fmt.Sprintf(announcementURLFormat, a.ID)
+}
+
+// openAnnouncement opens an announcement in the browser and marks it read
+func openAnnouncement(store *state.Store, readState *announcementReadState, announcements []api.Announcement, id int, now time.Time) error {
+	var target *api.Announcement
+	for i := range announcements {
+		if announcements[i].ID == id {
+			target = &announcements[i]
+			break
+		}
+	}
+	// Announcements outside the listing window can still be opened on the intra
+	link := fmt.Sprintf(announcementURLFormat, id)
+	if target != nil {
+		link = announcementURL(*target)
+	}
+
+	readState.Read[strconv.Itoa(id)] = now
+	pruneReadMarkers(readState, now)
+	if err := store.Save(announcementsStateName, readState); err != nil {
+		return err
+	}
+
+	result := map[string]interface{}{
+		"id":  id,
+		"url": link,
+	}
+	if done, err := emitOutputValue(result); done || err != nil {
+		return err
+	}
+
+	if target != nil {
+		fmt.Printf("📰 %s\n", target.Title)
+	}
+	if err := openBrowser(link); err != nil {
+		fmt.Printf("⚠️  Failed to open browser automatically: %v\n", err)
+		fmt.Printf("Please manually open: %s\n", link)
+		return nil
+	}
+	fmt.Printf("🌐 Opened %s\n", link)
+	return nil
+}
+
+func printAnnouncements(items []announcementItem, campus string, days int, unreadOnly, markedRead bool) {
+	fmt.Printf("📰 Announcements at %s (last %d days)\n\n", campus, days)
+
+	if len(items) == 0 {
+		if unreadOnly {
+			fmt.Println("No unread announcements. ✨")
+		} else {
+			fmt.Println("No announcements.")
+		}
+		return
+	}
+
+	unread := 0
+	for _, item := range items {
+		marker := "  "
+		if item.Unread {
+			marker = "● "
+			unread++
+		}
+		fmt.Printf("%s%-8d %s  %s\n", marker, item.ID, item.CreatedAt.Local().Format("2006-01-02"), truncateString(item.Title, 60))
+
+		if summary := announcementSummary(item.Text, 100); summary != "" {
+			fmt.Printf("           %s\n", summary)
+		}
+	}
+
+	fmt.Printf("\n📬 %d unread of %d shown", unread, len(items))
+	if markedRead {
+		fmt.Printf(" - all marked as read")
+	}
+	fmt.Println()
+	if unread > 0 && !markedRead {
+		fmt.Println("   Use --open <id> to read one, or --mark-read to mark them all as read")
+	}
+}
+
+// announcementSummary returns the first line of text, collapsed and truncated
+func announcementSummary(text string, maxLen int) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return truncateString(strings.Join(strings.Fields(line), " "), maxLen)
+}
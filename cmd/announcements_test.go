@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestAnnouncementItems(t *testing.T) {
+	announcements := []api.Announcement{{ID: 1, Title: "new"}, {ID: 2, Title: "seen"}}
+	readState := announcementReadState{Read: map[string]time.Time{"2": time.Now()}}
+
+	tests := []struct {
+		name       string
+		unreadOnly bool
+		wantIDs    []int
+		wantUnread []bool
+	}{
+		{"all", false, []int{1, 2}, []bool{true, false}},
+		{"unread only", true, []int{1}, []bool{true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := announcementItems(announcements, readState, tt.unreadOnly)
+			if len(items) != len(tt.wantIDs) {
+				t.Fatalf("got %d items, want %d", len(items), len(tt.wantIDs))
+			}
+			for i, item := range items {
+				if item.ID != tt.wantIDs[i] || item.Unread != tt.wantUnread[i] {
+					t.Errorf("item %d = {ID: %d, Unread: %v}, want {ID: %d, Unread: %v}",
+						i, item.ID, item.Unread, tt.wantIDs[i], tt.wantUnread[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPruneReadMarkers(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	readState := announcementReadState{Read: map[string]time.Time{
+		"1": now.AddDate(0, 0, -10),
+		"2": now.AddDate(-2, 0, 0),
+	}}
+
+	pruneReadMarkers(&readState, now)
+
+	if _, ok := readState.Read["1"]; !ok {
+		t.Error("recent marker was pruned")
+	}
+	if _, ok := readState.Read["2"]; ok {
+		t.Error("marker older than the retention period was kept")
+	}
+}
+
+func TestAnnouncementURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		announcement api.Announcement
+		want         string
+	}{
+		{"explicit link", api.Announcement{ID: 7, Link: "https://42tokyo.jp/event"}, "https://42tokyo.jp/event"},
+		{"intra page", api.Announcement{ID: 7}, "https://profile.intra.42.fr/announcements/7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := announcementURL(tt.announcement); got != tt.want {
+				t.Errorf("announcementURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnouncementSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"first line only", "  Exam   at 9\nRoom B", "Exam at 9"},
+		{"truncated", "abcdefghijklmnop", "abcdefg..."},
+		{"empty", "\n\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := announcementSummary(tt.text, 10); got != tt.want {
+				t.Errorf("announcementSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrimaryCampus(t *testing.T) {
+	tokyo := api.Campus{ID: 26, Name: "Tokyo"}
+	paris := api.Campus{ID: 1, Name: "Paris"}
+
+	tests := []struct {
+		name string
+		user api.User
+		want string
+	}{
+		{
+			name: "primary flag wins",
+			user: api.User{Campus: []api.Campus{paris, tokyo}, CampusUsers: []api.CampusUser{{CampusID: 1}, {CampusID: 26, IsPrimary: true}}},
+			want: "Tokyo",
+		},
+		{
+			name: "first campus without flags",
+			user: api.User{Campus: []api.Campus{paris, tokyo}},
+			want: "Paris",
+		},
+		{
+			name: "no campus",
+			user: api.User{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if c := primaryCampus(&tt.user); c != nil {
+				got = c.Name
+			}
+			if got != tt.want {
+				t.Errorf("primaryCampus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// apiMaxPages caps --paginate so a mistyped endpoint cannot crawl the whole API
+const apiMaxPages = 100
+
+var apiCmd = &cobra.Command{
+	Use:   "api [method] <path>",
+	Short: "Make an authenticated request to any 42 API endpoint",
+	Long: `Make an authenticated request to the 42 API and print the response.
+
+The path may omit the /v2 prefix. The method defaults to GET, or POST when
+fields are given. For GET requests fields become query parameters; for
+other methods they are sent as a JSON body, where keys such as
+"user[login]" build nested objects and "ids[]" builds arrays.
+
+  -f/--raw-field key=value   adds a string value
+  -F/--field key=value       adds a typed value: true, false, null and
+                             numbers are converted, @file reads a file
+                             and @- reads standard input
+
+With --paginate, every page of a list endpoint is fetched and the items
+are printed as a single array. The global --jq flag filters the response
+with a jq expression, in the full jq language, and --template formats it
+with a Go template.
+
+Examples:
+  t42 api /me
+  t42 api /v2/cursus/21/projects -f 'filter[name]=libft'
+  t42 api campus --paginate --jq '.[] | select(.country == "Japan") | .name'
+  t42 api POST /slots -F 'slot[user_id]=42' -f 'slot[begin_at]=2024-06-01T10:00:00Z'`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runAPI,
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+
+	apiCmd.Flags().StringArrayP("field", "F", nil, "Add a typed parameter in key=value format")
+	apiCmd.Flags().StringArrayP("raw-field", "f", nil, "Add a string parameter in key=value format")
+	apiCmd.Flags().Bool("paginate", false, "Fetch all pages of a list endpoint and print one array")
+}
+
+// apiField is a parameter given with --field or --raw-field
+type apiField struct {
+	Key   string
+	Value interface{}
+}
+
+func runAPI(cmd *cobra.Command, args []string) error {
+	typedFields, _ := cmd.Flags().GetStringArray("field")
+	rawFields, _ := cmd.Flags().GetStringArray("raw-field")
+	paginate, _ := cmd.Flags().GetBool("paginate")
+
+	method := ""
+	path := args[0]
+	if len(args) == 2 {
+		method = strings.ToUpper(args[0])
+		path = args[1]
+	}
+
+	fields, err := parseAPIFields(typedFields, rawFields, os.Stdin)
+	if err != nil {
+		return err
+	}
+	if method == "" {
+		method = http.MethodGet
+		if len(fields) > 0 {
+			method = http.MethodPost
+		}
+	}
+	if paginate && method != http.MethodGet {
+		return fmt.Errorf("--paginate only works with GET requests")
+	}
+	if method != http.MethodGet && readOnlyEnabled() {
+		return fmt.Errorf("%s requests are disabled in read-only mode (readonly in the config file)", method)
+	}
+
+	ctx := context.Background()
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	endpoint := normalizeAPIPath(path)
+	var body []byte
+	if method == http.MethodGet {
+		values := url.Values{}
+		for _, field := range fields {
+			values.Add(field.Key, apiQueryValue(field.Value))
+		}
+		if paginate {
+			body, err = fetchAllAPIPages(ctx, client, endpoint, values)
+		} else {
+			body, err = doAPIRequest(ctx, client, method, endpoint, values, nil)
+		}
+	} else {
+		var payload map[string]interface{}
+		if len(fields) > 0 {
+			if payload, err = buildAPIBody(fields); err != nil {
+				return err
+			}
+		}
+		body, err = doAPIRequest(ctx, client, method, endpoint, nil, payload)
+		if err == nil {
+			// The request may have changed our own data (registrations, slots, ...)
+			invalidateOwnData()
+		}
+	}
+
+	// Show the body of API errors too: it usually explains what was wrong
+	if body != nil {
+		if writeErr := writeAPIResponse(os.Stdout, body); writeErr != nil && err == nil {
+			err = writeErr
+		}
+	}
+	return err
+}
+
+// doAPIRequest sends one request and returns the response body, which is
+// also returned for API errors
+func doAPIRequest(ctx context.Context, client *api.Client, method, endpoint string, query url.Values, payload map[string]interface{}) ([]byte, error) {
+	// Keep body a nil interface without fields, so no empty JSON body is sent
+	var body interface{}
+	if payload != nil {
+		body = payload
+	}
+	resp, err := client.Passthrough(ctx, method, endpoint, query, body)
+	if resp == nil {
+		return nil, err
+	}
+	return resp.Body, err
+}
+
+// fetchAllAPIPages requests every page of a list endpoint and merges the items
+func fetchAllAPIPages(ctx context.Context, client *api.Client, endpoint string, query url.Values) ([]byte, error) {
+	perPage := api.DefaultPerPage
+	if size := query.Get("page[size]"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid page[size]: %q", size)
+		}
+		perPage = n
+	} else {
+		query.Set("page[size]", strconv.Itoa(perPage))
+	}
+
+	// The body of a failed request is returned with the error, to be shown
+	var errBody []byte
+	pages := api.NewPaginator(ctx, perPage, func(ctx context.Context, page int) ([]json.RawMessage, *api.PaginationMeta, error) {
+		query.Set("page[number]", strconv.Itoa(page))
+		resp, err := client.Passthrough(ctx, http.MethodGet, endpoint, query, nil)
+		if err != nil {
+			if resp != nil {
+				errBody = resp.Body
+			}
+			return nil, nil, err
+		}
+
+		var pageItems []json.RawMessage
+		if err := json.Unmarshal(resp.Body, &pageItems); err != nil {
+			return nil, nil, fmt.Errorf("--paginate needs an endpoint that returns a list: %w", err)
+		}
+		return pageItems, resp.Meta, nil
+	})
+
+	items := []json.RawMessage{}
+	for pages.HasNext() {
+		if pages.Page() == apiMaxPages {
+			fmt.Fprintf(os.Stderr, "Stopped after %d pages; narrow the request with filters to get the rest\n", apiMaxPages)
+			break
+		}
+		pageItems, err := pages.Next()
+		if err != nil {
+			return errBody, err
+		}
+		items = append(items, pageItems...)
+
+		if GetVerbose() {
+			fmt.Fprintf(os.Stderr, "Fetched page %d (%d items)\n", pages.Page(), len(pageItems))
+		}
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge pages: %w", err)
+	}
+	return data, nil
+}
+
+// normalizeAPIPath turns "me", "/me" and "/v2/me" into "/v2/me"
+func normalizeAPIPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if path == "/v2" || strings.HasPrefix(path, "/v2/") || strings.HasPrefix(path, "/v2?") || strings.HasPrefix(path, "/oauth/") {
+		return path
+	}
+	return "/v2" + path
+}
+
+// parseAPIFields parses --field (typed) and --raw-field (string) parameters;
+// stdin is read for "@-" values
+func parseAPIFields(typed, raw []string, stdin io.Reader) ([]apiField, error) {
+	var fields []apiField
+	for _, arg := range raw {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid field %q: expected key=value", arg)
+		}
+		fields = append(fields, apiField{Key: key, Value: value})
+	}
+	for _, arg := range typed {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid field %q: expected key=value", arg)
+		}
+		parsed, err := parseTypedFieldValue(value, stdin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", key, err)
+		}
+		fields = append(fields, apiField{Key: key, Value: parsed})
+	}
+	return fields, nil
+}
+
+// parseTypedFieldValue converts literals the way --field documents them
+func parseTypedFieldValue(value string, stdin io.Reader) (interface{}, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return n, nil
+	}
+	if name, ok := strings.CutPrefix(value, "@"); ok {
+		var data []byte
+		var err error
+		if name == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", value, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	return value, nil
+}
+
+// apiQueryValue renders a field value as a query parameter
+func apiQueryValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// buildAPIBody builds a JSON object from fields, expanding "a[b]" into
+// nested objects and "a[]" into arrays
+func buildAPIBody(fields []apiField) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	for _, field := range fields {
+		parts, err := splitFieldKey(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		if err := setNestedField(body, parts, field.Value); err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", field.Key, err)
+		}
+	}
+	return body, nil
+}
+
+// splitFieldKey splits "user[name][first]" into user, name, first; "ids[]"
+// ends with an empty part
+func splitFieldKey(key string) ([]string, error) {
+	head, rest, hasBrackets := strings.Cut(key, "[")
+	parts := []string{head}
+	if !hasBrackets {
+		return parts, nil
+	}
+	rest = "[" + rest
+	for rest != "" {
+		if !strings.HasPrefix(rest, "[") {
+			return nil, fmt.Errorf("invalid field key %q", key)
+		}
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid field key %q: missing ]", key)
+		}
+		parts = append(parts, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return parts, nil
+}
+
+func setNestedField(obj map[string]interface{}, parts []string, value interface{}) error {
+	key := parts[0]
+	if len(parts) == 1 {
+		obj[key] = value
+		return nil
+	}
+
+	if parts[1] == "" {
+		if len(parts) > 2 {
+			return fmt.Errorf("[] must come last")
+		}
+		existing, _ := obj[key].([]interface{})
+		if obj[key] != nil && existing == nil {
+			return fmt.Errorf("%s is not an array", key)
+		}
+		obj[key] = append(existing, value)
+		return nil
+	}
+
+	child, ok := obj[key].(map[string]interface{})
+	if !ok {
+		if obj[key] != nil {
+			return fmt.Errorf("%s is not an object", key)
+		}
+		child = make(map[string]interface{})
+		obj[key] = child
+	}
+	return setNestedField(child, parts[1:], value)
+}
+
+// writeAPIResponse prints the response body indented, or trimmed to --fields
+// and through the --jq or --template filter; non-JSON bodies are printed
+// as they are
+func writeAPIResponse(w io.Writer, body []byte) error {
+	if outputTrimmed() {
+		return writeTrimmedJSON(w, body)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "", "  "); err != nil {
+		_, err := w.Write(body)
+		return err
+	}
+	indented.WriteByte('\n')
+	_, err := indented.WriteTo(w)
+	return err
+}
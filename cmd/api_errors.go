@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// apiErrorHint suggests what to do about an error of the API client,
+// depending on its kind, or returns "" when there is nothing to add
+func apiErrorHint(err error) string {
+	switch {
+	case errors.Is(err, api.ErrUnauthorized):
+		return "🔑 Your session has expired or was revoked: run 't42 auth login' to log in again"
+	case errors.Is(err, api.ErrForbidden):
+		return "🚫 Your token is not allowed to do this: check your token scope with 't42 auth status'"
+	case errors.Is(err, api.ErrRateLimited):
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			return fmt.Sprintf("⏳ The 42 API rate limit is reached: try again in %s", apiErr.RetryAfter.Round(time.Second))
+		}
+		return "⏳ The 42 API rate limit is reached: try again in a moment"
+	case errors.Is(err, api.ErrServer):
+		return "🛠️  The 42 API is having trouble: try again later"
+	}
+	return ""
+}
+
+// printAPIErrorHint prints the hint for err, if any, after the error cobra
+// printed
+func printAPIErrorHint(w io.Writer, err error) {
+	if hint := apiErrorHint(err); hint != "" {
+		fmt.Fprintln(w, hint)
+	}
+}
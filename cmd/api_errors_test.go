@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestAPIErrorHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unauthorized", &api.Error{StatusCode: 401}, "t42 auth login"},
+		{"wrapped unauthorized", fmt.Errorf("failed to get profile: %w", &api.Error{StatusCode: 401}), "t42 auth login"},
+		{"forbidden", &api.Error{StatusCode: 403}, "t42 auth status"},
+		{"rate limited", &api.Error{StatusCode: 429}, "try again in a moment"},
+		{"rate limited with a wait", &api.Error{StatusCode: 429, RetryAfter: 90 * time.Second}, "try again in 1m30s"},
+		{"server", &api.Error{StatusCode: 503}, "try again later"},
+		{"not found", &api.Error{StatusCode: 404}, ""},
+		{"other error", errors.New("boom"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := apiErrorHint(tt.err)
+			if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+				t.Errorf("apiErrorHint() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
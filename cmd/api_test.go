@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+func TestNormalizeAPIPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"me", "/v2/me"},
+		{"/me", "/v2/me"},
+		{"/v2/me", "/v2/me"},
+		{"/v2?x=1", "/v2?x=1"},
+		{"/v2cursus", "/v2/v2cursus"},
+		{"/oauth/token/info", "/oauth/token/info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := normalizeAPIPath(tt.path); got != tt.want {
+				t.Errorf("normalizeAPIPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAPIFields(t *testing.T) {
+	fields, err := parseAPIFields(
+		[]string{"n=42", "ok=true", "none=null", "ratio=1.5", "text=hello", "body=@-"},
+		[]string{"raw=42"},
+		strings.NewReader("from stdin\n"),
+	)
+	if err != nil {
+		t.Fatalf("parseAPIFields() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"raw":   "42",
+		"n":     int64(42),
+		"ok":    true,
+		"none":  nil,
+		"ratio": 1.5,
+		"text":  "hello",
+		"body":  "from stdin",
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(fields), len(want))
+	}
+	for _, field := range fields {
+		if field.Value != want[field.Key] {
+			t.Errorf("field %s = %#v, want %#v", field.Key, field.Value, want[field.Key])
+		}
+	}
+
+	if _, err := parseAPIFields(nil, []string{"novalue"}, nil); err == nil {
+		t.Error("parseAPIFields() accepted a field without =")
+	}
+}
+
+func TestBuildAPIBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []apiField
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "flat",
+			fields: []apiField{{"a", "x"}, {"b", int64(1)}},
+			want:   `{"a":"x","b":1}`,
+		},
+		{
+			name:   "nested and arrays",
+			fields: []apiField{{"slot[user_id]", int64(42)}, {"slot[range][begin]", "9"}, {"ids[]", int64(1)}, {"ids[]", int64(2)}},
+			want:   `{"ids":[1,2],"slot":{"range":{"begin":"9"},"user_id":42}}`,
+		},
+		{
+			name:    "scalar used as object",
+			fields:  []apiField{{"a", "x"}, {"a[b]", "y"}},
+			wantErr: true,
+		},
+		{
+			name:    "unclosed bracket",
+			fields:  []apiField{{"a[b", "x"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := buildAPIBody(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildAPIBody() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			data, _ := json.Marshal(body)
+			if string(data) != tt.want {
+				t.Errorf("buildAPIBody() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteAPIResponse(t *testing.T) {
+	body := []byte(`[{"id":1,"name":"Tokyo"},{"id":2,"name":"Paris"}]`)
+
+	t.Run("indented", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeAPIResponse(&buf, body); err != nil {
+			t.Fatalf("writeAPIResponse() error = %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), "[\n  {\n    \"id\": 1,") {
+			t.Errorf("output not indented:\n%s", buf.String())
+		}
+	})
+
+	t.Run("jq filter", func(t *testing.T) {
+		query, err := output.ParseJQ(`.[] | select(.id > 1) | .name`)
+		if err != nil {
+			t.Fatalf("output.ParseJQ() error = %v", err)
+		}
+		outputQuery = query
+		defer func() { outputQuery = nil }()
+		var buf bytes.Buffer
+		if err := writeAPIResponse(&buf, body); err != nil {
+			t.Fatalf("writeAPIResponse() error = %v", err)
+		}
+		if buf.String() != "Paris\n" {
+			t.Errorf("output = %q, want %q", buf.String(), "Paris\n")
+		}
+	})
+
+	t.Run("non-JSON body", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeAPIResponse(&buf, []byte("plain")); err != nil {
+			t.Fatalf("writeAPIResponse() error = %v", err)
+		}
+		if buf.String() != "plain" {
+			t.Errorf("output = %q, want %q", buf.String(), "plain")
+		}
+	})
+}
@@ -14,14 +14,15 @@ import (
 	"os/exec"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
 	"github.com/naokiiida/t42-cli/internal/api"
 	"github.com/naokiiida/t42-cli/internal/config"
 	"github.com/naokiiida/t42-cli/internal/oauth"
+	"github.com/naokiiida/t42-cli/internal/output"
 )
 
 const (
@@ -29,15 +30,25 @@ const (
 	authorizeURL = "https://api.intra.42.fr/oauth/authorize"
 	tokenURL     = "https://api.intra.42.fr/oauth/token"
 
+	// deviceAuthorizationURL issues the codes of the device authorization
+	// grant (RFC 8628)
+	deviceAuthorizationURL = "https://api.intra.42.fr/oauth/authorize_device"
+
 	// Default redirect URL for local callback server
-	defaultRedirectURL = "http://127.0.0.1:8080/callback"
+	defaultRedirectURL = config.DefaultRedirectURL
+
+	// defaultCallbackPort is the port of defaultRedirectURL
+	defaultCallbackPort = 8080
 
-	// OAuth2 scopes
+	// defaultScope is the scope requested without --scope, and carried by
+	// every token
 	defaultScope = "public"
 )
 
 // Embedded OAuth2 credentials (set via ldflags at build time)
 // These are used as fallback when no environment/file config is found.
+// A client ID embedded without a secret is a public client, which logs in
+// with PKCE alone so that campus builds need no secret in the binary.
 var (
 	embeddedClientID     string
 	embeddedClientSecret string
@@ -59,7 +70,50 @@ var loginCmd = &cobra.Command{
 
 This will open your web browser to the 42 authentication page.
 After you authorize the application, you will be redirected back
-to the CLI and your credentials will be saved securely.`,
+to the CLI and your credentials will be saved securely.
+
+The callback URI (http://127.0.0.1:<port>/callback) must be registered
+as a Redirect URI of your 42 application. When --port is busy another
+port is used, whose URI must be registered as well; --fixed-port fails
+instead. A rejected redirect URI is reported with the exact URI to add.
+
+Binaries built with a client ID embedded log in without any setup, as a
+public client verified by PKCE when no secret was embedded. FT_UID and
+FT_SECRET, or a secrets file, select your own 42 application instead.
+
+With --simulate, the whole flow (callback server, state and PKCE
+validation, token exchange, credential saving and the first API call)
+runs against a built-in fake authorization server. No 42 account or
+client secrets are needed and your real credentials are not touched,
+which makes it suitable for verifying packaged builds in CI.
+
+With --device, no browser or callback server is needed on this machine,
+which suits SSH sessions and cluster computers: a URL and a code are
+printed, you enter the code from any device where you are logged in to
+the intra, and t42 waits for the authorization. This uses the device
+authorization grant, which your 42 application has to allow.
+
+With --client-credentials, an application token is stored instead, apart
+from your own credentials: it reads data as your 42 application (such as
+project session rules), needs FT_UID and FT_SECRET, and is renewed
+automatically when it expires. Run commands with it using --as-app.
+
+--scope requests more than the public scope, for commands that need it:
+projects, profile, elearning, tig or forum, comma separated. Your 42
+application has to allow them; 't42 auth status' shows the requested
+scopes that were not granted.
+
+--encrypt encrypts the stored credentials with a passphrase, for machines
+whose admins are shared, like cluster machines. The passphrase is asked
+once per command that needs the credentials, or read from T42_PASSPHRASE
+(e.g. for scheduled jobs); log in with --encrypt=false to store them in
+plain text again.
+
+Examples:
+  t42 auth login
+  t42 auth login --scope public,projects,profile
+  t42 auth login --device --scope projects
+  t42 auth login --encrypt`,
 	RunE: runLogin,
 }
 
@@ -88,13 +142,20 @@ func init() {
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(logoutCmd)
 	authCmd.AddCommand(statusCmd)
+	authCmd.AddCommand(refreshCmd)
 
 	// Add auth command to root
 	rootCmd.AddCommand(authCmd)
 
 	// Login command flags
-	loginCmd.Flags().StringP("port", "p", "8080", "Port for local callback server")
+	loginCmd.Flags().StringP("port", "p", strconv.Itoa(defaultCallbackPort), "Port for local callback server")
+	loginCmd.Flags().Bool("fixed-port", false, "Fail if --port is taken instead of falling back to another port")
 	loginCmd.Flags().Bool("no-browser", false, "Don't automatically open browser")
+	loginCmd.Flags().Bool("simulate", false, "Run the login flow against a built-in fake authorization server")
+	loginCmd.Flags().Bool("client-credentials", false, "Store an application token (client credentials grant) for --as-app, renewed automatically")
+	loginCmd.Flags().Bool("device", false, "Log in by entering a code on another device, for SSH sessions and machines without a browser")
+	loginCmd.Flags().StringSlice("scope", []string{defaultScope}, "Scopes to request, comma separated: "+strings.Join(knownScopes, ", "))
+	loginCmd.Flags().Bool("encrypt", false, "Encrypt the stored credentials with a passphrase (--encrypt=false to stop)")
 }
 
 // tryListen attempts to bind to the given address and port, returns net.Listener and error
@@ -114,106 +175,181 @@ func findFreePort(addr string) (net.Listener, int, error) {
 	return nil, 0, fmt.Errorf("no free port found on %s", addr)
 }
 
-func runLogin(cmd *cobra.Command, args []string) error {
-	var ln net.Listener
+// oauthEndpoints are the authorization server URLs used by the login flow
+type oauthEndpoints struct {
+	AuthorizeURL string
+	TokenURL     string
+	DeviceURL    string
+}
 
-	// --- Loopback binding logic ---
-	requestedPortStr, _ := cmd.Flags().GetString("port")
-	requestedPort, err := strconv.Atoi(requestedPortStr)
+// intraEndpoints are the endpoints of the real 42 authorization server
+var intraEndpoints = oauthEndpoints{AuthorizeURL: authorizeURL, TokenURL: tokenURL, DeviceURL: deviceAuthorizationURL}
+
+// loginFlow holds the per-attempt parameters of an authorization code flow
+type loginFlow struct {
+	endpoints   oauthEndpoints
+	secrets     *config.DevelopmentSecrets
+	redirectURL string
+	scope       string
+	state       string
+	pkce        *oauth.PKCEParams
+}
+
+// newLoginFlow generates the state and PKCE parameters for a login attempt
+func newLoginFlow(endpoints oauthEndpoints, secrets *config.DevelopmentSecrets, redirectURL, scope string) (*loginFlow, error) {
+	state, err := generateState()
 	if err != nil {
-		return fmt.Errorf("invalid port: %w", err)
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	pkce, err := oauth.GeneratePKCEParams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PKCE parameters: %w", err)
+	}
+
+	if GetVerbose() {
+		fmt.Printf("[DEBUG] PKCE generated:\n")
+		fmt.Printf("  Code Verifier: %s...\n", pkce.CodeVerifier[:min(len(pkce.CodeVerifier), 20)])
+		fmt.Printf("  Code Challenge: %s...\n", pkce.CodeChallenge[:min(len(pkce.CodeChallenge), 20)])
 	}
+
+	return &loginFlow{
+		endpoints:   endpoints,
+		secrets:     secrets,
+		redirectURL: redirectURL,
+		scope:       scope,
+		state:       state,
+		pkce:        pkce,
+	}, nil
+}
+
+// authorizationURL returns the URL the user has to open to authorize the CLI
+func (f *loginFlow) authorizationURL() string {
+	return buildAuthorizationURL(f.endpoints.AuthorizeURL, f.secrets.ClientID, f.redirectURL, f.state, f.scope, f.pkce.CodeChallenge)
+}
+
+// serveCallback starts the callback server on ln. The credentials obtained
+// from the authorization code, or the first error, are sent on the channels.
+func (f *loginFlow) serveCallback(ln net.Listener) (<-chan *config.Credentials, <-chan error) {
+	tokenChan := make(chan *config.Credentials, 1)
+	errorChan := make(chan error, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		handleCallback(w, r, f.endpoints.TokenURL, f.secrets, f.redirectURL, f.state, f.pkce.CodeVerifier, tokenChan, errorChan)
+	})
+
+	go func() {
+		serveErr := http.Serve(ln, mux)
+		if serveErr != nil {
+			select {
+			case errorChan <- fmt.Errorf("callback server error: %w", serveErr):
+			default:
+			}
+		}
+	}()
+
+	return tokenChan, errorChan
+}
+
+// bindCallbackListener binds the loopback callback server, falling back to a
+// free port and then to IPv6 when the requested port is unavailable. With
+// fixed set it fails instead, since a fallback port changes the redirect URI.
+func bindCallbackListener(requestedPort int, fixed bool) (net.Listener, string, error) {
 	bindAddr := "127.0.0.1"
-	port := requestedPort
-	ln, err = tryListen(bindAddr, port)
+	ln, err := tryListen(bindAddr, requestedPort)
+	if err != nil && fixed {
+		return nil, "", fmt.Errorf("port %d is not available (%v) - free it or pass another registered port with --port", requestedPort, err)
+	}
 	if err != nil {
 		// Try to find a free port
-		ln, port, err = findFreePort(bindAddr)
+		ln, _, err = findFreePort(bindAddr)
 		if err != nil {
 			// Fallback to IPv6
 			bindAddr = "::1"
-			ln, port, err = findFreePort(bindAddr)
+			ln, _, err = findFreePort(bindAddr)
 			if err != nil {
-				return fmt.Errorf("failed to bind to any loopback address: %w", err)
+				return nil, "", fmt.Errorf("failed to bind to any loopback address: %w", err)
 			}
 		}
 	}
-	redirectURL := fmt.Sprintf("http://%s:%d/callback", bindAddr, port)
-	// --- End loopback binding logic ---
-
-	// Check if already logged in
-	if config.HasValidCredentials() {
-		if !GetJSONOutput() {
-			fmt.Println("You are already logged in!")
-
-			// Ask if user wants to re-authenticate
-			var reauth bool
-			err := huh.NewConfirm().
-				Title("Do you want to log in again?").
-				Description("This will replace your current credentials.").
-				Value(&reauth).
-				Run()
 
-			if err != nil {
-				return fmt.Errorf("failed to get user confirmation: %w", err)
-			}
+	// Use the bound port, which differs from the requested one after a
+	// fallback or when port 0 was requested
+	port := ln.Addr().(*net.TCPAddr).Port
+	return ln, fmt.Sprintf("http://%s/callback", net.JoinHostPort(bindAddr, strconv.Itoa(port))), nil
+}
 
-			if !reauth {
-				return nil
-			}
+func runLogin(cmd *cobra.Command, args []string) error {
+	simulate, _ := cmd.Flags().GetBool("simulate")
+	device, _ := cmd.Flags().GetBool("device")
+	clientCredentials, _ := cmd.Flags().GetBool("client-credentials")
+	scopes, _ := cmd.Flags().GetStringSlice("scope")
+	scope, err := parseScopes(scopes)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("encrypt") {
+		if simulate || clientCredentials {
+			return fmt.Errorf("--encrypt cannot be combined with --simulate or --client-credentials")
+		}
+		if err := configureCredentialsEncryption(cmd); err != nil {
+			return err
 		}
 	}
-
-	// Get OAuth2 configuration
-	secrets, err := getOAuth2Config()
-	if err != nil {
-		return fmt.Errorf("failed to get OAuth2 configuration: %w", err)
+	switch {
+	case simulate && device:
+		return fmt.Errorf("--device cannot be combined with --simulate")
+	case clientCredentials && (simulate || device):
+		return fmt.Errorf("--client-credentials cannot be combined with --simulate or --device")
+	case cmd.Flags().Changed("scope") && (simulate || clientCredentials):
+		return fmt.Errorf("--scope cannot be combined with --simulate or --client-credentials")
+	case simulate:
+		return runLoginSimulation(cmd)
+	case device:
+		return runDeviceLogin(intraEndpoints, scope)
+	case clientCredentials:
+		return runClientCredentialsLogin()
 	}
 
-	// Get port from flag
-	portStr, _ := cmd.Flags().GetString("port")
-	port, err = strconv.Atoi(portStr)
+	requestedPortStr, _ := cmd.Flags().GetString("port")
+	requestedPort, err := strconv.Atoi(requestedPortStr)
 	if err != nil {
 		return fmt.Errorf("invalid port: %w", err)
 	}
-
-	// Generate state for security
-	state, err := generateState()
+	fixedPort, _ := cmd.Flags().GetBool("fixed-port")
+	if fixedPort && requestedPort == 0 {
+		return fmt.Errorf("--fixed-port needs a port - pass the one of your registered redirect URI with --port")
+	}
+	ln, redirectURL, err := bindCallbackListener(requestedPort, fixedPort)
 	if err != nil {
-		return fmt.Errorf("failed to generate state: %w", err)
+		return err
+	}
+	if port := ln.Addr().(*net.TCPAddr).Port; port != requestedPort && requestedPort != 0 && !GetJSONOutput() {
+		fmt.Fprintf(os.Stderr, "⚠️  Port %d is busy, using %s instead.\n", requestedPort, redirectURL)
+		fmt.Fprintf(os.Stderr, "   Login fails unless this URI is registered for your 42 application; use --fixed-port to stop here instead.\n\n")
 	}
 
-	// Generate PKCE parameters
-	pkce, err := oauth.GeneratePKCEParams()
-	if err != nil {
-		return fmt.Errorf("failed to generate PKCE parameters: %w", err)
+	// Check if already logged in
+	if reauth, err := confirmRelogin(); err != nil || !reauth {
+		return err
 	}
 
-	if GetVerbose() {
-		fmt.Printf("[DEBUG] PKCE generated:\n")
-		fmt.Printf("  Code Verifier: %s...\n", pkce.CodeVerifier[:min(len(pkce.CodeVerifier), 20)])
-		fmt.Printf("  Code Challenge: %s...\n", pkce.CodeChallenge[:min(len(pkce.CodeChallenge), 20)])
+	// Get OAuth2 configuration
+	secrets, err := getOAuth2Config()
+	if err != nil {
+		return fmt.Errorf("failed to get OAuth2 configuration: %w", err)
 	}
 
-	// Build authorization URL with PKCE
-	authURL := buildAuthorizationURL(secrets.ClientID, redirectURL, state, defaultScope, pkce.CodeChallenge)
+	// Generate state and PKCE parameters for security
+	flow, err := newLoginFlow(intraEndpoints, secrets, redirectURL, scope)
+	if err != nil {
+		return err
+	}
+	authURL := flow.authorizationURL()
 
 	// Start local callback server
-	tokenChan := make(chan *config.Credentials, 1)
-	errorChan := make(chan error, 1)
-
-	// Update callback handler to pass PKCE verifier
-	http.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
-		handleCallback(w, r, secrets, redirectURL, state, pkce.CodeVerifier, tokenChan, errorChan)
-	})
-
-	// Start server in goroutine
-	go func() {
-		serveErr := http.Serve(ln, nil)
-		if serveErr != nil {
-			errorChan <- fmt.Errorf("callback server error: %w", serveErr)
-		}
-	}()
+	tokenChan, errorChan := flow.serveCallback(ln)
 
 	// Wait a bit for server to start
 	time.Sleep(100 * time.Millisecond)
@@ -221,7 +357,7 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	if !GetJSONOutput() {
 		fmt.Printf("🔐 Starting OAuth2 flow...\n")
 		fmt.Printf("📱 Opening browser to: %s\n", authURL)
-		fmt.Printf("🌐 Waiting for callback on http://127.0.0.1:%d\n", port)
+		fmt.Printf("🌐 Waiting for callback on %s\n", redirectURL)
 		fmt.Printf("⏰ This will timeout in 5 minutes...\n\n")
 	}
 
@@ -250,7 +386,9 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	case err := <-errorChan:
 		return err
 	case <-ctx.Done():
-		return fmt.Errorf("authentication timeout - no response received within 5 minutes")
+		// The intra shows a mismatched redirect URI in the browser and never
+		// calls back, so a timeout is often that
+		return fmt.Errorf("authentication timeout - no response received within 5 minutes\n\nIf the browser showed an invalid redirect URI error, the callback URI is not registered.\n%s", redirectURIHelp(redirectURL))
 	}
 
 	// Shutdown server
@@ -258,10 +396,38 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Failed to close listener: %v\n", err)
 	}
 
+	credentials.RequestedScope = scope
+	return finishLogin(credentials)
+}
+
+// confirmRelogin asks whether to replace the credentials of a user who is
+// already logged in, and reports whether to go on with the login
+func confirmRelogin() (bool, error) {
+	// Without prompts, running login again is the answer
+	if !config.HasValidCredentials() || GetJSONOutput() || !promptsAvailable {
+		return true, nil
+	}
+	fmt.Println("You are already logged in!")
+
+	// Ask if user wants to re-authenticate
+	reauth, err := confirmPrompt(
+		"Do you want to log in again?",
+		"This will replace your current credentials.")
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get user confirmation: %w", err)
+	}
+	return reauth, nil
+}
+
+// finishLogin saves the credentials obtained by a login flow and greets the
+// user they belong to
+func finishLogin(credentials *config.Credentials) error {
 	// Save credentials
 	if err := config.SaveCredentials(credentials); err != nil {
 		return fmt.Errorf("failed to save credentials: %w", err)
 	}
+	invalidateOwnData()
 
 	// Get user info to confirm authentication
 	client := api.NewClient(credentials.AccessToken)
@@ -278,6 +444,9 @@ func runLogin(cmd *cobra.Command, args []string) error {
 			"scope":      credentials.Scope,
 			"expires_in": credentials.ExpiresIn,
 		}
+		if missing := missingScopes(credentials.RequestedScope, credentials.Scope); len(missing) > 0 {
+			result["missing_scopes"] = missing
+		}
 		if user != nil {
 			result["user"] = map[string]interface{}{
 				"id":    user.ID,
@@ -285,14 +454,18 @@ func runLogin(cmd *cobra.Command, args []string) error {
 				"email": user.Email,
 			}
 		}
-		output, _ := json.Marshal(result)
-		fmt.Println(string(output))
+		if err := writeOutputValue(result); err != nil {
+			return err
+		}
 	} else {
 		fmt.Printf("✅ Successfully logged in!\n")
 		if user != nil {
 			fmt.Printf("👋 Welcome, %s (%s)!\n", user.Login, user.Email)
 		}
 		fmt.Printf("🔑 Token scope: %s\n", credentials.Scope)
+		if missing := missingScopes(credentials.RequestedScope, credentials.Scope); len(missing) > 0 {
+			fmt.Printf("⚠️  Scopes not granted: %s - your 42 application may not allow them\n", strings.Join(missing, ", "))
+		}
 		fmt.Printf("⏰ Token expires in: %d seconds\n", credentials.ExpiresIn)
 	}
 
@@ -301,23 +474,22 @@ func runLogin(cmd *cobra.Command, args []string) error {
 
 func runLogout(cmd *cobra.Command, args []string) error {
 	// Check if logged in
-	if !config.HasValidCredentials() {
+	if !config.HasValidCredentials() && !config.HasAppCredentials() {
 		if GetJSONOutput() {
-			fmt.Println(`{"success":true,"message":"Already logged out"}`)
-		} else {
-			fmt.Println("You are not currently logged in.")
+			return writeOutput(
+				output.Field{Key: "success", Value: true},
+				output.Field{Key: "message", Value: "Already logged out"},
+			)
 		}
+		fmt.Println("You are not currently logged in.")
 		return nil
 	}
 
-	// Confirm logout unless JSON output
-	if !GetJSONOutput() {
-		var confirm bool
-		err := huh.NewConfirm().
-			Title("Are you sure you want to log out?").
-			Description("This will remove your stored credentials.").
-			Value(&confirm).
-			Run()
+	// Confirm logout unless JSON output, or in a build without prompts
+	if !GetJSONOutput() && promptsAvailable {
+		confirm, err := confirmPrompt(
+			"Are you sure you want to log out?",
+			"This will remove your stored credentials.")
 
 		if err != nil {
 			return fmt.Errorf("failed to get user confirmation: %w", err)
@@ -333,25 +505,41 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	if err := config.DeleteCredentials(); err != nil {
 		return fmt.Errorf("failed to delete credentials: %w", err)
 	}
+	if err := config.DeleteAppCredentials(); err != nil {
+		return fmt.Errorf("failed to delete application token: %w", err)
+	}
+	invalidateOwnData()
 
 	if GetJSONOutput() {
-		fmt.Println(`{"success":true,"message":"Logged out successfully"}`)
-	} else {
-		fmt.Println("✅ Successfully logged out!")
+		return writeOutput(
+			output.Field{Key: "success", Value: true},
+			output.Field{Key: "message", Value: "Logged out successfully"},
+		)
 	}
-
+	fmt.Println("✅ Successfully logged out!")
 	return nil
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
 	// Check if logged in
 	if !config.HasValidCredentials() {
+		session, _ := config.ActiveSession()
 		if GetJSONOutput() {
-			fmt.Println(`{"authenticated":false,"message":"Not logged in"}`)
-		} else {
-			fmt.Println("❌ Not logged in")
-			fmt.Println("Run 't42 auth login' to authenticate.")
+			result := map[string]interface{}{"authenticated": false, "message": "Not logged in"}
+			if session != nil {
+				result["session"] = describeSession(session)
+			}
+			if app := appCredentialsStatus(); app != nil {
+				result["app"] = app
+			}
+			return writeOutputValue(result)
+		}
+		fmt.Println("❌ Not logged in")
+		if session != nil {
+			fmt.Println("🧹 Shared-machine session: credentials will be wiped when this shell exits" + describeIdleTimeout(session.IdleTimeout))
 		}
+		printAppCredentialsStatus()
+		fmt.Println("Run 't42 auth login' to authenticate.")
 		return nil
 	}
 
@@ -389,6 +577,18 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			"expires_in":    credentials.ExpiresIn,
 			"expires_at":    expiresAt.Unix(),
 			"expired":       isExpired,
+			"storage":       config.StoredCredentialsBackend(),
+			"encrypted":     credentialsEncrypted(),
+			"clock_skew":    credentials.ClockSkew,
+		}
+		if session, _ := config.ActiveSession(); session != nil {
+			result["session"] = describeSession(session)
+		}
+		if app := appCredentialsStatus(); app != nil {
+			result["app"] = app
+		}
+		if missing := missingScopes(credentials.RequestedScope, credentials.Scope); len(missing) > 0 {
+			result["missing_scopes"] = missing
 		}
 
 		if !isExpired {
@@ -405,8 +605,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			result["user_error"] = err.Error()
 		}
 
-		output, _ := json.Marshal(result)
-		fmt.Println(string(output))
+		if err := writeOutputValue(result); err != nil {
+			return err
+		}
 	} else {
 		fmt.Println("✅ Authenticated")
 
@@ -418,7 +619,22 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		}
 
 		fmt.Printf("🔑 Token scope: %s\n", credentials.Scope)
+		if missing := missingScopes(credentials.RequestedScope, credentials.Scope); len(missing) > 0 {
+			fmt.Printf("⚠️  Scopes not granted: %s (requested at login) - your 42 application may not allow them\n", strings.Join(missing, ", "))
+		}
 		fmt.Printf("📅 Token created: %s\n", time.Unix(credentials.CreatedAt, 0).Format(time.RFC3339))
+		storage := describeCredentialsStorage(config.StoredCredentialsBackend())
+		if credentialsEncrypted() {
+			storage += " (encrypted)"
+		}
+		fmt.Printf("🔐 Stored in: %s\n", storage)
+		if session, _ := config.ActiveSession(); session != nil {
+			fmt.Println("🧹 Shared-machine session: EPHEMERAL - wiped when this shell exits" + describeIdleTimeout(session.IdleTimeout))
+		}
+
+		if skew := time.Duration(credentials.ClockSkew) * time.Second; exceedsClockSkewThreshold(skew) {
+			fmt.Printf("🕰️  Clock skew: %s (expiry adjusted)\n", describeClockSkew(skew))
+		}
 
 		if isExpired {
 			fmt.Printf("⏰ Token status: ❌ EXPIRED (%s ago)\n", (-timeUntilExpiry).Truncate(time.Second))
@@ -427,17 +643,45 @@ func runStatus(cmd *cobra.Command, args []string) error {
 				expiresAt.Format(time.RFC3339),
 				timeUntilExpiry.Truncate(time.Second))
 		}
+		printAppCredentialsStatus()
 	}
 
 	return nil
 }
 
+// credentialsEncrypted reports whether the stored credentials are
+// encrypted with a passphrase
+func credentialsEncrypted() bool {
+	if config.StoredCredentialsBackend() == config.CredentialsBackendSession {
+		return false
+	}
+	encrypt, err := config.CredentialsEncryptionEnabled()
+	return err == nil && encrypt
+}
+
+// describeCredentialsStorage names where credentials stored in backend live
+func describeCredentialsStorage(backend string) string {
+	switch backend {
+	case config.CredentialsBackendKeyring:
+		return "system keyring"
+	case config.CredentialsBackendSession:
+		return "shared-machine session (temporary file)"
+	}
+	path, err := config.GetCredentialsFilePath()
+	if err != nil {
+		return "credentials file"
+	}
+	return path
+}
+
 func getOAuth2Config() (*config.DevelopmentSecrets, error) {
 	// Fallback chain for loading OAuth2 client secrets:
 	// 1. Environment variables (FT_UID, FT_SECRET) - highest priority override
 	// 2. Development secrets (secret/.env) - for local development
-	// 3. XDG config directory (e.g., ~/.config/t42/secrets.env) - for user config
-	// 4. Build-time embedded credentials - for production release binaries
+	// 3. secret_command in the config file - for password managers
+	// 4. XDG config directory (e.g., ~/.config/t42/secrets.env) - for user config
+	// 5. Build-time embedded credentials - for production release binaries,
+	//    with or without a secret
 
 	// Try environment variables first (allows user override)
 	clientID := os.Getenv("FT_UID")
@@ -446,7 +690,7 @@ func getOAuth2Config() (*config.DevelopmentSecrets, error) {
 		return &config.DevelopmentSecrets{
 			ClientID:     clientID,
 			ClientSecret: clientSecret,
-			RedirectURL:  defaultRedirectURL,
+			RedirectURL:  config.RedirectURLFromEnv(),
 		}, nil
 	}
 
@@ -455,13 +699,24 @@ func getOAuth2Config() (*config.DevelopmentSecrets, error) {
 		return secrets, nil
 	}
 
+	// Try secret commands; a configured command that fails is an error rather
+	// than a reason to silently use another source
+	if cfg, err := config.LoadConfig(); err == nil && cfg.SecretCommand.IsSet() {
+		secrets, err := config.LoadSecretsFromCommand(context.Background(), cfg.SecretCommand)
+		if err != nil {
+			return nil, err
+		}
+		return secrets, nil
+	}
+
 	// Try XDG config directory secrets
 	if secrets, err := config.LoadSecretsFromConfigDir(); err == nil {
 		return secrets, nil
 	}
 
-	// Try build-time embedded credentials
-	if embeddedClientID != "" && embeddedClientSecret != "" {
+	// Try build-time embedded credentials; without a secret the client is
+	// public and relies on PKCE
+	if embeddedClientID != "" {
 		return &config.DevelopmentSecrets{
 			ClientID:     embeddedClientID,
 			ClientSecret: embeddedClientSecret,
@@ -481,9 +736,20 @@ func getOAuth2Config() (*config.DevelopmentSecrets, error) {
    FT_UID=your_client_id
    FT_SECRET=your_client_secret
 
+3. Read them from a password manager with secret_command in config.yaml:
+   secret_command:
+     client_id: pass show 42/uid
+     client_secret: pass show 42/secret
+
 Get your OAuth2 credentials from: https://profile.intra.42.fr/oauth/applications`, secretsPath)
 }
 
+// publicClientHint explains how to leave the built-in public client when
+// the 42 API refuses it
+const publicClientHint = `This build logs in with a built-in public client (no secret), which the
+42 API refused. Use your own 42 application instead by setting FT_UID and
+FT_SECRET (see 't42 auth login --help').`
+
 func generateState() (string, error) {
 	bytes := make([]byte, 32)
 	if _, err := rand.Read(bytes); err != nil {
@@ -492,7 +758,7 @@ func generateState() (string, error) {
 	return base64.URLEncoding.EncodeToString(bytes), nil
 }
 
-func buildAuthorizationURL(clientID, redirectURL, state, scope string, pkceChallenge string) string {
+func buildAuthorizationURL(endpoint, clientID, redirectURL, state, scope string, pkceChallenge string) string {
 	params := url.Values{}
 	params.Set("client_id", clientID)
 	params.Set("redirect_uri", redirectURL)
@@ -506,10 +772,10 @@ func buildAuthorizationURL(clientID, redirectURL, state, scope string, pkceChall
 		params.Set("code_challenge_method", "S256")
 	}
 
-	return authorizeURL + "?" + params.Encode()
+	return endpoint + "?" + params.Encode()
 }
 
-func handleCallback(w http.ResponseWriter, r *http.Request, secrets *config.DevelopmentSecrets, redirectURL, expectedState, pkceVerifier string, tokenChan chan<- *config.Credentials, errorChan chan<- error) {
+func handleCallback(w http.ResponseWriter, r *http.Request, tokenEndpoint string, secrets *config.DevelopmentSecrets, redirectURL, expectedState, pkceVerifier string, tokenChan chan<- *config.Credentials, errorChan chan<- error) {
 	// Parse query parameters
 	code := r.URL.Query().Get("code")
 	state := r.URL.Query().Get("state")
@@ -531,6 +797,10 @@ func handleCallback(w http.ResponseWriter, r *http.Request, secrets *config.Deve
 		}
 
 		http.Error(w, msg, http.StatusBadRequest)
+		if isRedirectMismatch(errorParam, errorDesc) {
+			errorChan <- &redirectMismatchError{RedirectURL: redirectURL, Detail: msg}
+			return
+		}
 		errorChan <- fmt.Errorf("%s", msg)
 		return
 	}
@@ -553,7 +823,7 @@ func handleCallback(w http.ResponseWriter, r *http.Request, secrets *config.Deve
 	if GetVerbose() {
 		fmt.Printf("[DEBUG] Exchanging authorization code for token with PKCE...\n")
 	}
-	credentials, err := exchangeCodeForToken(code, redirectURL, secrets, pkceVerifier)
+	credentials, err := exchangeCodeForToken(tokenEndpoint, code, redirectURL, secrets, pkceVerifier)
 	if err != nil {
 		errorMsg := fmt.Sprintf("Failed to exchange code for token: %v", err)
 		http.Error(w, errorMsg, http.StatusInternalServerError)
@@ -602,12 +872,14 @@ func handleCallback(w http.ResponseWriter, r *http.Request, secrets *config.Deve
 	tokenChan <- credentials
 }
 
-func exchangeCodeForToken(code, redirectURL string, secrets *config.DevelopmentSecrets, pkceVerifier string) (*config.Credentials, error) {
+func exchangeCodeForToken(tokenEndpoint, code, redirectURL string, secrets *config.DevelopmentSecrets, pkceVerifier string) (*config.Credentials, error) {
 	// Prepare token request
 	data := url.Values{}
 	data.Set("grant_type", "authorization_code")
 	data.Set("client_id", secrets.ClientID)
-	data.Set("client_secret", secrets.ClientSecret)
+	if !secrets.IsPublic() {
+		data.Set("client_secret", secrets.ClientSecret)
+	}
 	data.Set("code", code)
 	data.Set("redirect_uri", redirectURL)
 
@@ -618,9 +890,12 @@ func exchangeCodeForToken(code, redirectURL string, secrets *config.DevelopmentS
 
 	if GetVerbose() {
 		fmt.Printf("[DEBUG] Token exchange request:\n")
-		fmt.Printf("  URL: %s\n", tokenURL)
+		fmt.Printf("  URL: %s\n", tokenEndpoint)
 		fmt.Printf("  Grant Type: %s\n", data.Get("grant_type"))
 		fmt.Printf("  Client ID: %s\n", secrets.ClientID)
+		if secrets.IsPublic() {
+			fmt.Printf("  Client: public (no secret)\n")
+		}
 		fmt.Printf("  Redirect URI: %s\n", redirectURL)
 		fmt.Printf("  Code: %s...\n", code[:min(len(code), 20)])
 		if pkceVerifier != "" {
@@ -629,10 +904,12 @@ func exchangeCodeForToken(code, redirectURL string, secrets *config.DevelopmentS
 	}
 
 	// Make token request
-	resp, err := http.PostForm(tokenURL, data)
+	sent := time.Now()
+	resp, err := http.PostForm(tokenEndpoint, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make token request: %w", err)
 	}
+	received := time.Now()
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
@@ -656,6 +933,12 @@ func exchangeCodeForToken(code, redirectURL string, secrets *config.DevelopmentS
 	if resp.StatusCode != http.StatusOK {
 		var errorResp api.ErrorResponse
 		if err := json.Unmarshal(body, &errorResp); err == nil {
+			if isRedirectMismatch(errorResp.Error, errorResp.ErrorDescription) {
+				return nil, &redirectMismatchError{RedirectURL: redirectURL, Detail: errorResp.ErrorDescription}
+			}
+			if errorResp.Error == "invalid_client" && secrets.IsPublic() {
+				return nil, fmt.Errorf("token request failed (status %d): %s - %s\n\n%s", resp.StatusCode, errorResp.Error, errorResp.ErrorDescription, publicClientHint)
+			}
 			return nil, fmt.Errorf("token request failed (status %d): %s - %s", resp.StatusCode, errorResp.Error, errorResp.ErrorDescription)
 		}
 		return nil, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
@@ -677,26 +960,29 @@ func exchangeCodeForToken(code, redirectURL string, secrets *config.DevelopmentS
 		CreatedAt:        tokenResp.CreatedAt,
 		SecretValidUntil: tokenResp.SecretValidUntil,
 	}
+	recordClockSkew(credentials, resp.Header, sent, received)
 
 	return credentials, nil
 }
 
 func openBrowser(url string) error {
-	var cmd string
-	var args []string
+	name, args := browserCommand(runtime.GOOS, url)
+	return exec.Command(name, args...).Start()
+}
 
-	switch runtime.GOOS {
+// browserCommand returns the command that opens url in the default browser
+// on goos. On Windows, "cmd /c start" would treat the "&" separating query
+// parameters as a command separator and truncate the URL, so the URL is
+// handed to the protocol handler directly instead.
+func browserCommand(goos, url string) (string, []string) {
+	switch goos {
 	case "windows":
-		cmd = "cmd"
-		args = []string{"/c", "start"}
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
 	case "darwin":
-		cmd = "open"
+		return "open", []string{url}
 	default: // "linux", "freebsd", "openbsd", "netbsd"
-		cmd = "xdg-open"
+		return "xdg-open", []string{url}
 	}
-
-	args = append(args, url)
-	return exec.Command(cmd, args...).Start()
 }
 
 // refreshAccessToken refreshes the access token using the refresh token
@@ -710,14 +996,18 @@ func refreshAccessToken(refreshToken string) (*config.Credentials, error) {
 	data := url.Values{}
 	data.Set("grant_type", "refresh_token")
 	data.Set("client_id", secrets.ClientID)
-	data.Set("client_secret", secrets.ClientSecret)
+	if !secrets.IsPublic() {
+		data.Set("client_secret", secrets.ClientSecret)
+	}
 	data.Set("refresh_token", refreshToken)
 
 	// Make token request
+	sent := time.Now()
 	resp, err := http.PostForm(tokenURL, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make token refresh request: %w", err)
 	}
+	received := time.Now()
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
@@ -754,6 +1044,7 @@ func refreshAccessToken(refreshToken string) (*config.Credentials, error) {
 		CreatedAt:        tokenResp.CreatedAt,
 		SecretValidUntil: tokenResp.SecretValidUntil,
 	}
+	recordClockSkew(credentials, resp.Header, sent, received)
 
 	return credentials, nil
 }
@@ -765,12 +1056,9 @@ func RefreshTokenIfNeeded() error {
 		return err // No credentials to refresh
 	}
 
-	// Check if token is expired or will expire in the next 5 minutes
-	expiresAt := time.Unix(credentials.CreatedAt, 0).Add(time.Duration(credentials.ExpiresIn) * time.Second)
-	timeUntilExpiry := time.Until(expiresAt)
-
-	// If token is valid for more than 5 minutes, no need to refresh
-	if timeUntilExpiry > 5*time.Minute {
+	// If token is valid for more than 5 minutes (by the skew-corrected
+	// expiry), no need to refresh
+	if !config.NeedsRefresh(credentials) {
 		return nil
 	}
 
@@ -779,18 +1067,25 @@ func RefreshTokenIfNeeded() error {
 		return fmt.Errorf("access token expired and no refresh token available - please log in again")
 	}
 
-	// Refresh the token
+	_, err = refreshStoredCredentials(credentials)
+	return err
+}
+
+// refreshStoredCredentials refreshes credentials with their refresh token
+// and saves the new ones, keeping the scopes requested at login
+func refreshStoredCredentials(credentials *config.Credentials) (*config.Credentials, error) {
 	newCredentials, err := refreshAccessToken(credentials.RefreshToken)
 	if err != nil {
-		return fmt.Errorf("failed to refresh access token: %w", err)
+		return nil, fmt.Errorf("failed to refresh access token: %w", err)
 	}
 
 	// Save the new credentials
+	newCredentials.RequestedScope = credentials.RequestedScope
 	if err := config.SaveCredentials(newCredentials); err != nil {
-		return fmt.Errorf("failed to save refreshed credentials: %w", err)
+		return nil, fmt.Errorf("failed to save refreshed credentials: %w", err)
 	}
 
-	return nil
+	return newCredentials, nil
 }
 
 // Helper function to return minimum of two integers
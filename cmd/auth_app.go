@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// runClientCredentialsLogin gets an application token with the
+// client_credentials grant and stores it apart from the user's credentials
+func runClientCredentialsLogin() error {
+	secrets, err := appSecrets()
+	if err != nil {
+		return err
+	}
+
+	credentials, err := renewAppCredentials(context.Background(), secrets)
+	if err != nil {
+		return err
+	}
+	expiresAt := config.GetTokenExpiryTime(credentials)
+
+	if GetJSONOutput() {
+		return writeOutput(
+			output.Field{Key: "success", Value: true},
+			output.Field{Key: "type", Value: "application"},
+			output.Field{Key: "scope", Value: credentials.Scope},
+			output.Field{Key: "expires_in", Value: credentials.ExpiresIn},
+			output.Field{Key: "storage", Value: config.StoredAppCredentialsBackend()},
+		)
+	}
+	fmt.Printf("✅ Application token saved!\n")
+	fmt.Printf("🔑 Token scope: %s\n", credentials.Scope)
+	fmt.Printf("🔐 Stored in: %s\n", describeAppCredentialsStorage(config.StoredAppCredentialsBackend()))
+	fmt.Printf("⏰ Token expires: %s (renewed automatically)\n", expiresAt.Format(time.RFC3339))
+	fmt.Printf("Use --as-app to run commands with it.\n")
+	return nil
+}
+
+// appSecrets returns the OAuth2 secrets application tokens are requested
+// with, which must include a client secret
+func appSecrets() (*config.DevelopmentSecrets, error) {
+	secrets, err := getOAuth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OAuth2 configuration: %w", err)
+	}
+	if secrets.IsPublic() {
+		return nil, fmt.Errorf("application tokens need a client secret, and this build's built-in client has none - set FT_UID and FT_SECRET of your own 42 application")
+	}
+	return secrets, nil
+}
+
+// loadAppSecrets loads the secrets of application tokens (a variable so
+// tests can replace it)
+var loadAppSecrets = appSecrets
+
+// lazyAppSecrets returns a getter calling load once, on first use. The
+// secrets may come from secret_command, which can prompt to unlock a
+// password manager, so they are only loaded when a token is requested.
+func lazyAppSecrets(load func() (*config.DevelopmentSecrets, error)) func() (*config.DevelopmentSecrets, error) {
+	var (
+		once    sync.Once
+		secrets *config.DevelopmentSecrets
+		err     error
+	)
+	return func() (*config.DevelopmentSecrets, error) {
+		once.Do(func() { secrets, err = load() })
+		return secrets, err
+	}
+}
+
+// appAccessToken returns the stored application token, renewed when it has
+// expired. Without a stored token, one is requested for this run only.
+// Secrets are only loaded when a token is requested.
+func appAccessToken(ctx context.Context, secrets func() (*config.DevelopmentSecrets, error)) (string, error) {
+	if stored, err := config.LoadAppCredentials(); err == nil && config.IsTokenValid(stored) {
+		return stored.AccessToken, nil
+	}
+	return renewAppToken(ctx, secrets)
+}
+
+// renewAppToken replaces an application token the API rejected, keeping
+// the new one when the old one was stored
+func renewAppToken(ctx context.Context, secrets func() (*config.DevelopmentSecrets, error)) (string, error) {
+	s, err := secrets()
+	if err != nil {
+		return "", err
+	}
+	if !config.HasAppCredentials() {
+		return api.GetClientCredentialsToken(ctx, s.ClientID, s.ClientSecret)
+	}
+	credentials, err := renewAppCredentials(ctx, s)
+	if err != nil {
+		return "", err
+	}
+	return credentials.AccessToken, nil
+}
+
+// renewAppCredentials requests a new application token and stores it
+func renewAppCredentials(ctx context.Context, secrets *config.DevelopmentSecrets) (*config.Credentials, error) {
+	token, err := api.GetClientCredentials(ctx, secrets.ClientID, secrets.ClientSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app token: %w", err)
+	}
+	credentials := appCredentialsFromToken(token, time.Now())
+	if err := config.SaveAppCredentials(credentials); err != nil {
+		return nil, fmt.Errorf("failed to save app token: %w", err)
+	}
+	return credentials, nil
+}
+
+// appCredentialsFromToken converts a client_credentials token response.
+// Application tokens have no refresh token: they are renewed with the
+// client secret instead.
+func appCredentialsFromToken(token *api.Token, now time.Time) *config.Credentials {
+	createdAt := token.CreatedAt
+	if createdAt == 0 {
+		createdAt = now.Unix()
+	}
+	return &config.Credentials{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		ExpiresIn:   token.ExpiresIn,
+		Scope:       token.Scope,
+		CreatedAt:   createdAt,
+	}
+}
+
+// describeAppCredentialsStorage names where an application token stored in
+// backend lives
+func describeAppCredentialsStorage(backend string) string {
+	if backend == config.CredentialsBackendKeyring {
+		return "system keyring"
+	}
+	path, err := config.GetAppCredentialsFilePath()
+	if err != nil {
+		return "application token file"
+	}
+	return path
+}
+
+// appCredentialsStatus describes the stored application token for
+// 't42 auth status', nil when there is none
+func appCredentialsStatus() map[string]interface{} {
+	credentials, err := config.LoadAppCredentials()
+	if err != nil {
+		return nil
+	}
+	expiresAt := config.GetTokenExpiryTime(credentials)
+	return map[string]interface{}{
+		"scope":      credentials.Scope,
+		"created_at": credentials.CreatedAt,
+		"expires_at": expiresAt.Unix(),
+		"expired":    !config.IsTokenValid(credentials),
+		"storage":    config.StoredAppCredentialsBackend(),
+	}
+}
+
+// printAppCredentialsStatus prints the stored application token, if any
+func printAppCredentialsStatus() {
+	credentials, err := config.LoadAppCredentials()
+	if err != nil {
+		return
+	}
+	state := "valid"
+	if !config.IsTokenValid(credentials) {
+		state = "expired, renewed on next use"
+	}
+	fmt.Printf("🤖 Application token: %s, scope %s, in %s\n", state, credentials.Scope, describeAppCredentialsStorage(config.StoredAppCredentialsBackend()))
+}
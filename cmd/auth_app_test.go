@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestAppCredentialsFromToken(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name          string
+		token         api.Token
+		wantCreatedAt int64
+	}{
+		{"server time", api.Token{AccessToken: "app", ExpiresIn: 7200, CreatedAt: 1699999990}, 1699999990},
+		{"no created_at", api.Token{AccessToken: "app", ExpiresIn: 7200}, now.Unix()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appCredentialsFromToken(&tt.token, now)
+			if got.AccessToken != "app" || got.ExpiresIn != 7200 || got.CreatedAt != tt.wantCreatedAt || got.RefreshToken != "" {
+				t.Errorf("appCredentialsFromToken() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestAppAccessTokenReusesStoredToken(t *testing.T) {
+	t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+	t.Setenv(config.CredentialsBackendEnvVar, config.CredentialsBackendFile)
+
+	stored := &config.Credentials{AccessToken: "stored", ExpiresIn: 7200, CreatedAt: time.Now().Unix()}
+	if err := config.SaveAppCredentials(stored); err != nil {
+		t.Fatalf("SaveAppCredentials() error = %v", err)
+	}
+
+	// A valid stored token is used without loading the secrets, which may
+	// run secret_command, or asking the API for a new one
+	loads := 0
+	original := loadAppSecrets
+	loadAppSecrets = func() (*config.DevelopmentSecrets, error) {
+		loads++
+		return &config.DevelopmentSecrets{ClientID: "uid", ClientSecret: "secret"}, nil
+	}
+	defer func() { loadAppSecrets = original }()
+
+	token, err := appAccessToken(context.Background(), lazyAppSecrets(loadAppSecrets))
+	if err != nil || token != "stored" {
+		t.Errorf("appAccessToken() = %q, %v; want the stored token", token, err)
+	}
+	if _, err := newAppClient(context.Background()); err != nil {
+		t.Errorf("newAppClient() error = %v", err)
+	}
+	if loads != 0 {
+		t.Errorf("secrets loaded %d times, want none with a valid stored token", loads)
+	}
+}
+
+func TestLazyAppSecrets(t *testing.T) {
+	loads := 0
+	secrets := lazyAppSecrets(func() (*config.DevelopmentSecrets, error) {
+		loads++
+		return &config.DevelopmentSecrets{ClientID: "uid"}, nil
+	})
+	for i := 0; i < 3; i++ {
+		if s, err := secrets(); err != nil || s.ClientID != "uid" {
+			t.Fatalf("secrets() = %+v, %v", s, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("secrets loaded %d times, want 1", loads)
+	}
+}
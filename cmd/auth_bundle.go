@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// passphraseEnvVar supplies the bundle passphrase non-interactively
+const passphraseEnvVar = "T42_BUNDLE_PASSPHRASE"
+
+var exportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export credentials and settings as an encrypted bundle",
+	Long: `Export your credentials, config file and OAuth2 client secrets as a
+passphrase-protected bundle, to move your login to another machine
+(e.g. from a school iMac to your laptop).
+
+The bundle is encrypted with AES-256-GCM using a key derived from your
+passphrase. It is written to the given file, or to stdout when no file
+(or "-") is given. Set T42_BUNDLE_PASSPHRASE to skip the prompt.
+
+Examples:
+  t42 auth export t42-bundle.json
+  t42 auth export --no-secrets > bundle.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import credentials and settings from an encrypted bundle",
+	Long: `Import a bundle created with 't42 auth export' (use "-" to read stdin).
+
+The imported credentials are validated right away: an expired access
+token is refreshed and your profile is fetched to confirm the login works
+on this machine. Set T42_BUNDLE_PASSPHRASE to skip the prompt.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	authCmd.AddCommand(exportCmd)
+	authCmd.AddCommand(importCmd)
+
+	exportCmd.Flags().Bool("no-secrets", false, "Do not include the OAuth2 client secrets file")
+	importCmd.Flags().Bool("force", false, "Replace existing credentials without asking")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	noSecrets, _ := cmd.Flags().GetBool("no-secrets")
+
+	if !config.HasValidCredentials() {
+		return fmt.Errorf("not logged in - run 't42 auth login' first")
+	}
+
+	bundle, err := config.NewBundle(!noSecrets)
+	if err != nil {
+		return fmt.Errorf("failed to collect credentials: %w", err)
+	}
+
+	passphrase, err := readPassphrase(true)
+	if err != nil {
+		return err
+	}
+
+	data, err := config.EncryptBundle(bundle, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt bundle: %w", err)
+	}
+
+	if len(args) == 0 || args[0] == "-" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+		return nil
+	}
+
+	// Never overwrite an unrelated file with the bundle
+	target := args[0]
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("file %s already exists", target)
+	}
+	if err := os.WriteFile(target, data, 0600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	if GetJSONOutput() {
+		result := map[string]interface{}{
+			"success":          true,
+			"file":             target,
+			"includes_config":  bundle.ConfigYAML != "",
+			"includes_secrets": bundle.SecretsEnv != "",
+		}
+		if err := writeOutputValue(result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("✅ Exported credentials to %s\n", target)
+		if bundle.SecretsEnv != "" {
+			fmt.Println("🔑 The bundle includes your OAuth2 client secret - keep it private.")
+		}
+	}
+
+	return nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	passphrase, err := readPassphrase(false)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := config.DecryptBundle(data, passphrase)
+	if err != nil {
+		if errors.Is(err, config.ErrWrongPassphrase) {
+			return fmt.Errorf("failed to decrypt bundle: %w", err)
+		}
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	if config.HasValidCredentials() && !force {
+		if GetJSONOutput() {
+			return fmt.Errorf("already logged in - use --force to replace the current credentials")
+		}
+		replace, err := confirmPrompt(
+			"Replace your current credentials?",
+			fmt.Sprintf("The bundle was exported on %s.", bundle.ExportedAt.Local().Format("2006-01-02 15:04")))
+		if err != nil {
+			return fmt.Errorf("failed to get user confirmation: %w", err)
+		}
+		if !replace {
+			fmt.Println("Import cancelled.")
+			return nil
+		}
+	}
+
+	// A bundle with revoked or expired tokens must not replace a working
+	// login: what it overwrites is put back unless it validates
+	backup, err := config.BackupLogin()
+	if err != nil {
+		return fmt.Errorf("failed to back up the current login: %w", err)
+	}
+	if err := bundle.Apply(); err != nil {
+		return restoreLogin(backup, fmt.Errorf("failed to import bundle: %w", err))
+	}
+	invalidateOwnData()
+
+	// Validate the imported login: refresh an expired token, then fetch the profile
+	if err := RefreshTokenIfNeeded(); err != nil {
+		return restoreLogin(backup, fmt.Errorf("imported credentials could not be refreshed: %w", err))
+	}
+	client, err := NewAPIClient()
+	if err != nil {
+		return restoreLogin(backup, fmt.Errorf("imported credentials are unusable: %w", err))
+	}
+	user, err := client.GetMe(context.Background())
+	if err != nil {
+		return restoreLogin(backup, fmt.Errorf("imported credentials were rejected by the API: %w", err))
+	}
+
+	// Commands from another machine's config run through the shell here
+	if settings := bundle.CommandSettings(); len(settings) > 0 {
+		fmt.Fprintln(os.Stderr, "⚠️  The imported config runs these commands - check them before use:")
+		for _, s := range settings {
+			fmt.Fprintf(os.Stderr, "   %s\n", s)
+		}
+	}
+
+	if GetJSONOutput() {
+		result := map[string]interface{}{
+			"success": true,
+			"user": map[string]interface{}{
+				"id":    user.ID,
+				"login": user.Login,
+			},
+			"imported_config":  bundle.ConfigYAML != "",
+			"imported_secrets": bundle.SecretsEnv != "",
+			"command_settings": bundle.CommandSettings(),
+		}
+		if err := writeOutputValue(result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("✅ Imported credentials for %s\n", user.Login)
+		if bundle.ConfigYAML != "" {
+			fmt.Println("⚙️  Imported config file")
+		}
+		if bundle.SecretsEnv != "" {
+			fmt.Println("🔑 Imported OAuth2 client secrets")
+		}
+	}
+
+	return nil
+}
+
+// restoreLogin undoes a failed import and returns its error
+func restoreLogin(backup *config.LoginBackup, importErr error) error {
+	invalidateOwnData()
+	if err := backup.Restore(); err != nil {
+		return fmt.Errorf("%w; restoring the previous login also failed: %v", importErr, err)
+	}
+	return fmt.Errorf("%w - your previous login was kept", importErr)
+}
+
+// readPassphrase returns the bundle passphrase from the environment or an
+// interactive prompt; confirm asks for it twice (used when exporting)
+func readPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(passphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphrase, err := passwordPrompt("Bundle passphrase", func(s string) error {
+		if confirm && len(s) < config.MinPassphraseLength {
+			return fmt.Errorf("use at least %d characters", config.MinPassphraseLength)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if !confirm {
+		return passphrase, nil
+	}
+
+	again, err := passwordPrompt("Repeat passphrase", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if passphrase != again {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+const (
+	// deviceCodeGrantType is the grant type of the device token request
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultDevicePollInterval is the time between two token requests when
+	// the authorization server does not set one
+	defaultDevicePollInterval = 5 * time.Second
+
+	// devicePollSlowDown is added to the interval each time the
+	// authorization server asks to slow down
+	devicePollSlowDown = 5 * time.Second
+)
+
+var (
+	// errAuthorizationPending means the user has not entered the code yet
+	errAuthorizationPending = errors.New("authorization pending")
+
+	// errSlowDown means the token endpoint was polled too often
+	errSlowDown = errors.New("slow down")
+)
+
+// deviceAuthorization is the response of the device authorization endpoint
+type deviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// pollInterval returns the time to wait between two token requests
+func (d *deviceAuthorization) pollInterval() time.Duration {
+	if d.Interval <= 0 {
+		return defaultDevicePollInterval
+	}
+	return time.Duration(d.Interval) * time.Second
+}
+
+// runDeviceLogin logs in with the device authorization grant: the user
+// enters a code on another device while t42 polls the token endpoint
+func runDeviceLogin(endpoints oauthEndpoints, scope string) error {
+	if reauth, err := confirmRelogin(); err != nil || !reauth {
+		return err
+	}
+
+	secrets, err := getOAuth2Config()
+	if err != nil {
+		return fmt.Errorf("failed to get OAuth2 configuration: %w", err)
+	}
+
+	authorization, err := requestDeviceAuthorization(endpoints.DeviceURL, secrets, scope)
+	if err != nil {
+		return err
+	}
+
+	// The instructions go to stderr with --json, to keep stdout parseable
+	w := os.Stdout
+	if GetJSONOutput() {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, "🔐 On any device, open: %s\n", authorization.VerificationURI)
+	fmt.Fprintf(w, "🔢 And enter the code:  %s\n", authorization.UserCode)
+	if authorization.VerificationURIComplete != "" {
+		fmt.Fprintf(w, "   (or open %s)\n", authorization.VerificationURIComplete)
+	}
+	expiry := 5 * time.Minute
+	if authorization.ExpiresIn > 0 {
+		expiry = time.Duration(authorization.ExpiresIn) * time.Second
+	}
+	fmt.Fprintf(w, "⏰ Waiting for the authorization, the code expires %s...\n\n", formatTimeUntil(expiry))
+
+	ctx, cancel := context.WithTimeout(context.Background(), expiry)
+	defer cancel()
+
+	credentials, err := waitForDeviceToken(ctx, endpoints.TokenURL, secrets, authorization.DeviceCode, authorization.pollInterval())
+	if err != nil {
+		return err
+	}
+	credentials.RequestedScope = scope
+	return finishLogin(credentials)
+}
+
+// requestDeviceAuthorization asks the authorization server for a device
+// code and the code the user has to enter
+func requestDeviceAuthorization(endpoint string, secrets *config.DevelopmentSecrets, scope string) (*deviceAuthorization, error) {
+	data := url.Values{}
+	data.Set("client_id", secrets.ClientID)
+	if !secrets.IsPublic() {
+		data.Set("client_secret", secrets.ClientSecret)
+	}
+	data.Set("scope", scope)
+
+	resp, err := http.PostForm(endpoint, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make device authorization request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device authorization response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("the authorization server does not support device login - log in without --device, e.g. with --no-browser over an SSH tunnel (ssh -L %d:127.0.0.1:%d)", defaultCallbackPort, defaultCallbackPort)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errorResp api.ErrorResponse
+		if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
+			if errorResp.Error == "unauthorized_client" {
+				return nil, fmt.Errorf("your 42 application is not allowed to use device login (%s)", errorResp.ErrorDescription)
+			}
+			return nil, fmt.Errorf("device authorization failed (status %d): %s - %s", resp.StatusCode, errorResp.Error, errorResp.ErrorDescription)
+		}
+		return nil, fmt.Errorf("device authorization failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var authorization deviceAuthorization
+	if err := json.Unmarshal(body, &authorization); err != nil {
+		return nil, fmt.Errorf("failed to parse device authorization response: %w", err)
+	}
+	if authorization.DeviceCode == "" || authorization.UserCode == "" || authorization.VerificationURI == "" {
+		return nil, fmt.Errorf("incomplete device authorization response: %s", string(body))
+	}
+	return &authorization, nil
+}
+
+// waitForDeviceToken polls the token endpoint until the user authorizes
+// the device code, denies it, or ctx is done
+func waitForDeviceToken(ctx context.Context, tokenEndpoint string, secrets *config.DevelopmentSecrets, deviceCode string, interval time.Duration) (*config.Credentials, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("authentication timeout - the code expired before it was entered")
+		case <-time.After(interval):
+		}
+
+		credentials, err := requestDeviceToken(tokenEndpoint, secrets, deviceCode)
+		switch {
+		case errors.Is(err, errAuthorizationPending):
+			continue
+		case errors.Is(err, errSlowDown):
+			interval += devicePollSlowDown
+			continue
+		case err != nil:
+			return nil, err
+		}
+		return credentials, nil
+	}
+}
+
+// requestDeviceToken makes one token request for a device code. It returns
+// errAuthorizationPending or errSlowDown while the user has not entered it.
+func requestDeviceToken(tokenEndpoint string, secrets *config.DevelopmentSecrets, deviceCode string) (*config.Credentials, error) {
+	data := url.Values{}
+	data.Set("grant_type", deviceCodeGrantType)
+	data.Set("client_id", secrets.ClientID)
+	if !secrets.IsPublic() {
+		data.Set("client_secret", secrets.ClientSecret)
+	}
+	data.Set("device_code", deviceCode)
+
+	sent := time.Now()
+	resp, err := http.PostForm(tokenEndpoint, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make token request: %w", err)
+	}
+	received := time.Now()
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errorResp api.ErrorResponse
+		if err := json.Unmarshal(body, &errorResp); err == nil {
+			switch errorResp.Error {
+			case "authorization_pending":
+				return nil, errAuthorizationPending
+			case "slow_down":
+				return nil, errSlowDown
+			case "access_denied":
+				return nil, fmt.Errorf("the authorization was denied")
+			case "expired_token":
+				return nil, fmt.Errorf("the code expired before it was entered - run 't42 auth login --device' again")
+			}
+			if errorResp.Error != "" {
+				return nil, fmt.Errorf("token request failed (status %d): %s - %s", resp.StatusCode, errorResp.Error, errorResp.ErrorDescription)
+			}
+		}
+		return nil, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp api.Token
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	credentials := &config.Credentials{
+		AccessToken:      tokenResp.AccessToken,
+		TokenType:        tokenResp.TokenType,
+		ExpiresIn:        tokenResp.ExpiresIn,
+		RefreshToken:     tokenResp.RefreshToken,
+		Scope:            tokenResp.Scope,
+		CreatedAt:        tokenResp.CreatedAt,
+		SecretValidUntil: tokenResp.SecretValidUntil,
+	}
+	recordClockSkew(credentials, resp.Header, sent, received)
+
+	return credentials, nil
+}
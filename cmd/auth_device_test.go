@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestRequestDeviceAuthorization(t *testing.T) {
+	secrets := &config.DevelopmentSecrets{ClientID: "uid", ClientSecret: "secret"}
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"ok", http.StatusOK, `{"device_code":"dc","user_code":"ABCD-EFGH","verification_uri":"https://intra/device","expires_in":600,"interval":5}`, ""},
+		{"unsupported", http.StatusNotFound, `Not Found`, "does not support device login"},
+		{"not allowed", http.StatusUnauthorized, `{"error":"unauthorized_client","error_description":"grant not allowed"}`, "not allowed to use device login"},
+		{"incomplete", http.StatusOK, `{"device_code":"dc"}`, "incomplete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil || r.Form.Get("client_id") != "uid" || r.Form.Get("client_secret") != "secret" {
+					t.Errorf("unexpected form %v (%v)", r.Form, err)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			got, err := requestDeviceAuthorization(server.URL, secrets, defaultScope)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("requestDeviceAuthorization() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("requestDeviceAuthorization() error = %v", err)
+			}
+			if got.UserCode != "ABCD-EFGH" || got.pollInterval() != 5*time.Second {
+				t.Errorf("requestDeviceAuthorization() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestWaitForDeviceToken(t *testing.T) {
+	public := &config.DevelopmentSecrets{ClientID: "uid"}
+
+	tests := []struct {
+		name      string
+		responses []string
+		wantErr   string
+		wantCalls int32
+	}{
+		{"pending then granted", []string{`{"error":"authorization_pending"}`, `{"error":"authorization_pending"}`, `{"access_token":"token","expires_in":7200}`}, "", 3},
+		{"denied", []string{`{"error":"authorization_pending"}`, `{"error":"access_denied"}`}, "denied", 2},
+		{"expired", []string{`{"error":"expired_token"}`}, "expired", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != deviceCodeGrantType || r.Form.Get("device_code") != "dc" || r.Form.Has("client_secret") {
+					t.Errorf("unexpected form %v (%v)", r.Form, err)
+				}
+				n := atomic.AddInt32(&calls, 1)
+				body := tt.responses[min(int(n), len(tt.responses))-1]
+				if strings.Contains(body, `"error"`) {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			got, err := waitForDeviceToken(ctx, server.URL, public, "dc", time.Millisecond)
+			if calls != tt.wantCalls {
+				t.Errorf("token requests = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("waitForDeviceToken() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got.AccessToken != "token" {
+				t.Fatalf("waitForDeviceToken() = %+v, %v", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func init() {
+	// Encrypted credentials are decrypted when first needed, asking for the
+	// passphrase unless T42_PASSPHRASE is set
+	config.PassphrasePrompt = promptCredentialsPassphrase
+}
+
+// promptCredentialsPassphrase asks for the passphrase of the encrypted
+// credentials, when there is a terminal to ask in
+func promptCredentialsPassphrase() (string, error) {
+	if !promptsAvailable || !isTerminal(os.Stdin) {
+		return "", config.ErrPassphraseRequired
+	}
+	passphrase, err := passwordPrompt("Credentials passphrase", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return passphrase, nil
+}
+
+// configureCredentialsEncryption applies auth login --encrypt: with it, a
+// new passphrase is chosen and encrypt_credentials turned on; with
+// --encrypt=false, credentials are saved in plain text again
+func configureCredentialsEncryption(cmd *cobra.Command) error {
+	encrypt, _ := cmd.Flags().GetBool("encrypt")
+	if encrypt {
+		passphrase, err := newCredentialsPassphrase()
+		if err != nil {
+			return err
+		}
+		if err := config.SetCredentialsPassphrase(passphrase); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg.EncryptCredentials = encrypt
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// newCredentialsPassphrase returns the passphrase to encrypt the
+// credentials with, from T42_PASSPHRASE or asked twice
+func newCredentialsPassphrase() (string, error) {
+	if passphrase := os.Getenv(config.PassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	if !promptsAvailable || !isTerminal(os.Stdin) {
+		return "", fmt.Errorf("--encrypt needs a passphrase - set %s or run t42 in a terminal", config.PassphraseEnvVar)
+	}
+
+	passphrase, err := passwordPrompt("New credentials passphrase", func(s string) error {
+		if len(s) < config.MinPassphraseLength {
+			return fmt.Errorf("use at least %d characters", config.MinPassphraseLength)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	again, err := passwordPrompt("Repeat passphrase", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if passphrase != again {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
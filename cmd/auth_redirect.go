@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// oauthApplicationsURL is where 42 applications and their redirect URIs
+// are managed
+const oauthApplicationsURL = "https://profile.intra.42.fr/oauth/applications"
+
+// redirectMismatchError reports that the authorization server rejected the
+// redirect URI, because it is not registered for the application
+type redirectMismatchError struct {
+	RedirectURL string
+	Detail      string
+}
+
+func (e *redirectMismatchError) Error() string {
+	var b strings.Builder
+	b.WriteString("the redirect URI is not registered for your 42 application")
+	if e.Detail != "" {
+		fmt.Fprintf(&b, " (%s)", e.Detail)
+	}
+	b.WriteString("\n\n")
+	b.WriteString(redirectURIHelp(e.RedirectURL))
+	return b.String()
+}
+
+// isRedirectMismatch reports whether an OAuth2 error code and description
+// returned by the authorization server mean the redirect URI was rejected
+func isRedirectMismatch(code, description string) bool {
+	switch code {
+	case "redirect_uri_mismatch", "invalid_redirect_uri":
+		return true
+	case "invalid_grant", "invalid_request":
+		return strings.Contains(strings.ToLower(description), "redirect")
+	}
+	return false
+}
+
+// redirectURIHelp explains how to fix a redirect URI mismatch for the
+// callback URL of this login attempt
+func redirectURIHelp(redirectURL string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Add this exact URI to the Redirect URI list of your application at\n%s:\n\n  %s\n", oauthApplicationsURL, redirectURL)
+	if port := redirectURLPort(redirectURL); port != "" {
+		fmt.Fprintf(&b, "\nor log in on a port whose URI is already registered, failing fast if it is taken:\n\n  t42 auth login --port <port> --fixed-port\n")
+		if port != strconv.Itoa(defaultCallbackPort) {
+			fmt.Fprintf(&b, "\n(this attempt used port %s, not the default %d)\n", port, defaultCallbackPort)
+		}
+	}
+	return b.String()
+}
+
+// redirectURLPort returns the port of a callback URL, or "" if it has none
+func redirectURLPort(redirectURL string) string {
+	hostPort := strings.TrimPrefix(redirectURL, "http://")
+	hostPort, _, _ = strings.Cut(hostPort, "/")
+	_, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return ""
+	}
+	return port
+}
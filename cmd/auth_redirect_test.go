@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestIsRedirectMismatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		description string
+		want        bool
+	}{
+		{"mismatch code", "redirect_uri_mismatch", "", true},
+		{"doorkeeper code", "invalid_redirect_uri", "The redirect uri included is not valid.", true},
+		{"invalid grant about redirect", "invalid_grant", "The provided authorization grant is invalid, expired, revoked, does not match the redirection URI used in the authorization request", true},
+		{"invalid grant otherwise", "invalid_grant", "The authorization code has expired", false},
+		{"access denied", "access_denied", "The resource owner denied the request", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRedirectMismatch(tt.code, tt.description); got != tt.want {
+				t.Errorf("isRedirectMismatch(%q, %q) = %v, want %v", tt.code, tt.description, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedirectMismatchError(t *testing.T) {
+	var err error = &redirectMismatchError{RedirectURL: "http://127.0.0.1:49152/callback", Detail: "invalid_redirect_uri"}
+
+	var mismatch *redirectMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatal("errors.As() did not find the redirect mismatch")
+	}
+	msg := err.Error()
+	for _, want := range []string{"http://127.0.0.1:49152/callback", oauthApplicationsURL, "--fixed-port", "port 49152"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Error() = %q, want it to mention %q", msg, want)
+		}
+	}
+	if strings.Contains(redirectURIHelp(defaultRedirectURL), "not the default") {
+		t.Error("redirectURIHelp() of the default URI mentions a non-default port")
+	}
+}
+
+func TestBindCallbackListenerFixedPort(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taken.Close()
+	port := taken.Addr().(*net.TCPAddr).Port
+
+	if ln, _, err := bindCallbackListener(port, true); err == nil {
+		ln.Close()
+		t.Fatal("bindCallbackListener() with a fixed, taken port succeeded")
+	}
+
+	ln, redirectURL, err := bindCallbackListener(port, false)
+	if err != nil {
+		t.Fatalf("bindCallbackListener() error = %v", err)
+	}
+	defer ln.Close()
+	if redirectURLPort(redirectURL) == redirectURLPort("http://"+taken.Addr().String()+"/callback") {
+		t.Errorf("bindCallbackListener() fell back to the taken port: %s", redirectURL)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var refreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Refresh the access token now",
+	Long: `Refresh the access token now, even while it is still valid, and print
+its new expiry.
+
+Commands refresh the token on their own when it is about to expire; this
+is for debugging token problems, and for cron jobs that pre-warm the token
+before a long scripted run so it cannot expire midway.
+
+With --as-app, a new application token is requested with the client
+credentials grant instead, since application tokens have no refresh token.
+
+Examples:
+  t42 auth refresh
+  t42 auth refresh --as-app
+  t42 auth refresh --json`,
+	Args: cobra.NoArgs,
+	RunE: runRefresh,
+}
+
+func runRefresh(cmd *cobra.Command, args []string) error {
+	tokenType := "user"
+	var credentials *config.Credentials
+	if asApp {
+		secrets, err := appSecrets()
+		if err != nil {
+			return err
+		}
+		tokenType = "application"
+		credentials, err = renewAppCredentials(context.Background(), secrets)
+		if err != nil {
+			return err
+		}
+	} else {
+		stored, err := config.LoadCredentials()
+		if err != nil {
+			return fmt.Errorf("not authenticated - please run 't42 auth login' first: %w", err)
+		}
+		if stored.RefreshToken == "" {
+			return fmt.Errorf("no refresh token available - please log in again")
+		}
+		credentials, err = refreshStoredCredentials(stored)
+		if err != nil {
+			return err
+		}
+	}
+
+	expiresAt := config.GetTokenExpiryTime(credentials)
+	if GetJSONOutput() {
+		return writeOutput(
+			output.Field{Key: "success", Value: true},
+			output.Field{Key: "type", Value: tokenType},
+			output.Field{Key: "scope", Value: credentials.Scope},
+			output.Field{Key: "expires_in", Value: credentials.ExpiresIn},
+			output.Field{Key: "expires_at", Value: expiresAt.Unix()},
+		)
+	}
+
+	if asApp {
+		fmt.Println("✅ Application token renewed!")
+	} else {
+		fmt.Println("✅ Token refreshed!")
+	}
+	fmt.Printf("🔑 Token scope: %s\n", credentials.Scope)
+	fmt.Printf("⏰ Token expires: %s (in %s)\n",
+		expiresAt.Format(time.RFC3339),
+		time.Until(expiresAt).Truncate(time.Second))
+	return nil
+}
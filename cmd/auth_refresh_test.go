@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestRunRefreshWithoutRefreshToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		stored  *config.Credentials
+		wantErr string
+	}{
+		{name: "not logged in", wantErr: "not authenticated"},
+		{name: "no refresh token", stored: &config.Credentials{AccessToken: "token", ExpiresIn: 7200, CreatedAt: time.Now().Unix()}, wantErr: "no refresh token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			t.Setenv(config.CredentialsBackendEnvVar, config.CredentialsBackendFile)
+			if tt.stored != nil {
+				if err := config.SaveCredentials(tt.stored); err != nil {
+					t.Fatalf("SaveCredentials() error = %v", err)
+				}
+			}
+
+			err := runRefresh(refreshCmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runRefresh() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
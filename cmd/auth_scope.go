@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// knownScopes are the scopes a 42 application can request, in the order
+// they are listed
+var knownScopes = []string{"public", "projects", "profile", "elearning", "tig", "forum"}
+
+// parseScopes validates the scopes given to --scope, as a list or comma
+// separated, and returns them space separated for the authorization
+// request. The public scope every token carries is always included.
+func parseScopes(values []string) (string, error) {
+	requested := map[string]bool{defaultScope: true}
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			scope = strings.ToLower(strings.TrimSpace(scope))
+			if scope == "" {
+				continue
+			}
+			if !isKnownScope(scope) {
+				return "", fmt.Errorf("unknown scope %q (available: %s)", scope, strings.Join(knownScopes, ", "))
+			}
+			requested[scope] = true
+		}
+	}
+
+	var scopes []string
+	for _, scope := range knownScopes {
+		if requested[scope] {
+			scopes = append(scopes, scope)
+		}
+	}
+	return strings.Join(scopes, " "), nil
+}
+
+// isKnownScope reports whether scope can be requested from the 42 API
+func isKnownScope(scope string) bool {
+	for _, known := range knownScopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
+
+// missingScopes returns the requested scopes the token was not granted.
+// Scopes are separated by spaces, or by commas in older responses.
+func missingScopes(requested, granted string) []string {
+	split := func(s string) []string {
+		return strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+	have := make(map[string]bool)
+	for _, scope := range split(granted) {
+		have[scope] = true
+	}
+	var missing []string
+	for _, scope := range split(requested) {
+		if !have[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    string
+		wantErr bool
+	}{
+		{"default", []string{"public"}, "public", false},
+		{"none", nil, "public", false},
+		{"comma separated", []string{"public,projects,profile"}, "public projects profile", false},
+		{"public added", []string{"forum"}, "public forum", false},
+		{"known order and duplicates", []string{"forum, Projects", "projects", "elearning"}, "public projects elearning forum", false},
+		{"unknown", []string{"public,admin"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseScopes(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseScopes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseScopes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		granted   string
+		want      []string
+	}{
+		{"all granted", "public projects", "public projects", nil},
+		{"not requested at login", "", "public", nil},
+		{"some missing", "public projects forum", "public", []string{"projects", "forum"}},
+		{"comma separated grant", "public projects", "public,projects", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingScopes(tt.requested, tt.granted); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingScopes(%q, %q) = %v, want %v", tt.requested, tt.granted, got, tt.want)
+			}
+		})
+	}
+}
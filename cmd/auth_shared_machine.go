@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+var sharedMachineCmd = &cobra.Command{
+	Use:   "shared-machine",
+	Short: "Keep credentials only for this shell session on a shared computer",
+	Long: `Start a shared-machine session for campus computers shared between
+students. Credentials are then kept in a temporary file private to you,
+instead of the keyring or the credentials file, and wiped when the shell
+exits or after --idle-timeout without any t42 command. Your cached
+profile data is wiped with them.
+
+The command prints shell code to evaluate in bash or zsh:
+
+  eval "$(t42 auth shared-machine)"
+  t42 auth login
+
+'t42 auth status' shows when a session is active. 't42 auth logout' wipes
+the credentials but keeps the session, so you can log in again in the
+same shell. The session takes over the shell's EXIT trap.`,
+	Args: cobra.NoArgs,
+	RunE: runSharedMachine,
+}
+
+func init() {
+	authCmd.AddCommand(sharedMachineCmd)
+
+	sharedMachineCmd.Flags().Duration("idle-timeout", config.DefaultSessionIdleTimeout, "Wipe the credentials after this long without a t42 command (0 to only wipe on exit)")
+	// Run by the shell code: --end from the EXIT trap, --reap in the background
+	sharedMachineCmd.Flags().Bool("end", false, "End the shared-machine session of this shell")
+	sharedMachineCmd.Flags().Int("reap", 0, "Wipe idle credentials while the shell with this PID runs")
+	_ = sharedMachineCmd.Flags().MarkHidden("end")
+	_ = sharedMachineCmd.Flags().MarkHidden("reap")
+	sharedMachineCmd.MarkFlagsMutuallyExclusive("end", "reap")
+}
+
+func runSharedMachine(cmd *cobra.Command, args []string) error {
+	if end, _ := cmd.Flags().GetBool("end"); end {
+		return endSharedMachineSession()
+	}
+	if shellPID, _ := cmd.Flags().GetInt("reap"); shellPID > 0 {
+		return reapSharedMachineSession(shellPID)
+	}
+
+	idleTimeout, _ := cmd.Flags().GetDuration("idle-timeout")
+	if idleTimeout < 0 {
+		return fmt.Errorf("--idle-timeout cannot be negative")
+	}
+	if os.Getenv(config.SessionEnvVar) != "" {
+		return fmt.Errorf("this shell is already in a shared-machine session - exit it to end the session")
+	}
+	if isTerminal(os.Stdout) {
+		fmt.Println("The shared-machine session has to be started by your shell. Run:")
+		fmt.Println()
+		fmt.Println(`  eval "$(t42 auth shared-machine)"`)
+		return nil
+	}
+
+	id, err := config.NewSessionID()
+	if err != nil {
+		return err
+	}
+	session := &config.Session{ID: id, IdleTimeout: idleTimeout}
+	if err := session.Start(); err != nil {
+		return fmt.Errorf("failed to start the shared-machine session: %w", err)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the t42 executable: %w", err)
+	}
+
+	if err := writeSessionShellCode(os.Stdout, executable, session); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "🔒 Shared-machine session started: credentials are wiped when this shell exits%s.\n", describeIdleTimeout(idleTimeout))
+	return nil
+}
+
+// writeSessionShellCode writes the POSIX shell code starting a session:
+// the session variables, the EXIT trap ending it and the idle reaper
+func writeSessionShellCode(w io.Writer, executable string, session *config.Session) error {
+	exe := posixQuote(executable)
+	lines := []string{
+		fmt.Sprintf("export %s=%s", config.SessionEnvVar, session.ID),
+		fmt.Sprintf("export %s=%s", config.SessionIdleTimeoutEnvVar, session.IdleTimeout),
+		fmt.Sprintf("trap '%s auth shared-machine --end' EXIT", strings.ReplaceAll(exe, "'", `'\''`)),
+		fmt.Sprintf("(%s auth shared-machine --reap $$ >/dev/null 2>&1 &)", exe),
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// endSharedMachineSession wipes the credentials of the shell's session
+// and its cached profile data
+func endSharedMachineSession() error {
+	session, err := config.ActiveSession()
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return fmt.Errorf("no shared-machine session in this shell")
+	}
+	invalidateOwnData()
+	return session.End()
+}
+
+// reapSharedMachineSession wipes the credentials of the session when it
+// is left idle, until the session ends or its shell is gone
+func reapSharedMachineSession(shellPID int) error {
+	session, err := config.ActiveSession()
+	if err != nil || session == nil {
+		return err
+	}
+
+	interval := 30 * time.Second
+	if session.IdleTimeout > 0 && session.IdleTimeout/10 < interval {
+		interval = max(session.IdleTimeout/10, time.Second)
+	}
+	for {
+		if !processAlive(shellPID) {
+			// The shell was killed without running its EXIT trap
+			return endSharedMachineSession()
+		}
+		wiped, err := session.ExpireIfIdle(time.Now())
+		if errors.Is(err, config.ErrSessionEnded) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if wiped {
+			invalidateOwnData()
+		}
+		time.Sleep(interval)
+	}
+}
+
+// describeSession returns the JSON status of a shared-machine session
+func describeSession(session *config.Session) map[string]interface{} {
+	status := map[string]interface{}{
+		"ephemeral":            true,
+		"idle_timeout_seconds": int64(session.IdleTimeout.Seconds()),
+	}
+	if state, err := session.State(); err == nil {
+		status["last_used"] = state.LastUsed.Unix()
+		if session.IdleTimeout > 0 {
+			status["wipes_at"] = state.LastUsed.Add(session.IdleTimeout).Unix()
+		}
+	} else {
+		status["error"] = err.Error()
+	}
+	return status
+}
+
+// describeIdleTimeout describes when idle credentials are wiped, as a
+// sentence suffix
+func describeIdleTimeout(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" or after %s without a t42 command", formatIdleTimeout(d))
+}
+
+// formatIdleTimeout formats a timeout without its zero seconds ("30m")
+func formatIdleTimeout(d time.Duration) string {
+	if s := d.String(); strings.HasSuffix(s, "m0s") {
+		return strings.TrimSuffix(s, "0s")
+	}
+	return d.String()
+}
+
+// posixQuote quotes a word for a POSIX shell
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestWriteSessionShellCode(t *testing.T) {
+	session := &config.Session{ID: "0123456789abcdef0123456789abcdef", IdleTimeout: 30 * time.Minute}
+	var b strings.Builder
+	if err := writeSessionShellCode(&b, "/Users/o'brien/bin/t42", session); err != nil {
+		t.Fatalf("writeSessionShellCode() error = %v", err)
+	}
+
+	want := `export T42_SESSION=0123456789abcdef0123456789abcdef
+export T42_SESSION_IDLE_TIMEOUT=30m0s
+trap ''\''/Users/o'\''\'\'''\''brien/bin/t42'\'' auth shared-machine --end' EXIT
+('/Users/o'\''brien/bin/t42' auth shared-machine --reap $$ >/dev/null 2>&1 &)
+`
+	if b.String() != want {
+		t.Errorf("writeSessionShellCode() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestFormatIdleTimeout(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Minute, "30m"},
+		{90 * time.Minute, "1h30m"},
+		{45 * time.Second, "45s"},
+		{90 * time.Second, "1m30s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatIdleTimeout(tt.d); got != tt.want {
+				t.Errorf("formatIdleTimeout(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/oauth"
+)
+
+// simulationTimeout bounds the whole simulated login
+const simulationTimeout = 30 * time.Second
+
+// simulationStep is one verified stage of a simulated login
+type simulationStep struct {
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// runLoginSimulation runs the complete login pipeline against a local fake
+// authorization server instead of the 42 intra: callback server binding,
+// state and PKCE validation, token exchange, credential storage and the
+// first authenticated API call. Credentials are written to a temporary
+// config directory, so an existing login is left untouched.
+func runLoginSimulation(cmd *cobra.Command) error {
+	var steps []simulationStep
+	pass := func(name, detail string) {
+		steps = append(steps, simulationStep{Name: name, Detail: detail})
+		if !GetJSONOutput() {
+			fmt.Printf("✅ %s: %s\n", name, detail)
+		}
+	}
+
+	server, err := oauth.NewFakeServer()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := server.Close(); err != nil && GetVerbose() {
+			fmt.Fprintf(os.Stderr, "Failed to stop fake authorization server: %v\n", err)
+		}
+	}()
+	if !GetJSONOutput() {
+		fmt.Printf("🧪 Simulating login against a built-in fake authorization server\n\n")
+	}
+	pass("authorization server", server.URL())
+
+	// Redirect all config writes to a scratch directory for the simulation
+	configDir, err := os.MkdirTemp("", "t42-simulate-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary config directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(configDir); err != nil && GetVerbose() {
+			fmt.Fprintf(os.Stderr, "Failed to remove %s: %v\n", configDir, err)
+		}
+	}()
+	restoreConfigDir := setEnvTemporarily(config.ConfigDirEnvVar, configDir)
+	defer restoreConfigDir()
+	// Keep the simulated token out of the system keyring and out of any
+	// shared-machine session
+	restoreBackend := setEnvTemporarily(config.CredentialsBackendEnvVar, config.CredentialsBackendFile)
+	defer restoreBackend()
+	restoreSession := setEnvTemporarily(config.SessionEnvVar, "")
+	defer restoreSession()
+
+	requestedPortStr, _ := cmd.Flags().GetString("port")
+	requestedPort, err := strconv.Atoi(requestedPortStr)
+	if err != nil {
+		return fmt.Errorf("invalid port: %w", err)
+	}
+	ln, redirectURL, err := bindCallbackListener(requestedPort, false)
+	if err != nil {
+		return fmt.Errorf("callback server: %w", err)
+	}
+	defer func() {
+		_ = ln.Close()
+	}()
+	pass("callback server", redirectURL)
+
+	// A build with a built-in public client simulates a public client too
+	if embeddedClientID != "" && embeddedClientSecret == "" {
+		server.ClientSecret = ""
+		pass("client", "public (PKCE only), like this build's built-in client")
+	}
+	secrets := &config.DevelopmentSecrets{
+		ClientID:     server.ClientID,
+		ClientSecret: server.ClientSecret,
+		RedirectURL:  redirectURL,
+	}
+	endpoints := oauthEndpoints{AuthorizeURL: server.AuthorizeURL(), TokenURL: server.TokenURL()}
+	flow, err := newLoginFlow(endpoints, secrets, redirectURL, defaultScope)
+	if err != nil {
+		return err
+	}
+	pass("state and PKCE", "S256 code challenge generated")
+
+	tokenChan, errorChan := flow.serveCallback(ln)
+
+	// Play the browser: request the authorization page and follow its
+	// redirect to the callback server
+	ctx, cancel := context.WithTimeout(context.Background(), simulationTimeout)
+	defer cancel()
+	if err := simulateBrowser(ctx, flow.authorizationURL()); err != nil {
+		select {
+		case callbackErr := <-errorChan:
+			return fmt.Errorf("callback: %w", callbackErr)
+		default:
+		}
+		return fmt.Errorf("browser redirect: %w", err)
+	}
+	pass("authorization redirect", "callback received the code and a matching state")
+
+	var credentials *config.Credentials
+	select {
+	case credentials = <-tokenChan:
+	case err := <-errorChan:
+		return fmt.Errorf("token exchange: %w", err)
+	case <-ctx.Done():
+		return fmt.Errorf("simulated login timed out after %s", simulationTimeout)
+	}
+	pass("token exchange", "code verifier accepted by the token endpoint")
+
+	if err := config.SaveCredentials(credentials); err != nil {
+		return fmt.Errorf("credential storage: failed to save credentials: %w", err)
+	}
+	saved, err := config.LoadCredentials()
+	if err != nil {
+		return fmt.Errorf("credential storage: failed to read back credentials: %w", err)
+	}
+	if saved.AccessToken != credentials.AccessToken || saved.RefreshToken != credentials.RefreshToken {
+		return fmt.Errorf("credential storage: saved credentials do not match the issued token")
+	}
+	credentialsPath, err := config.GetCredentialsFilePath()
+	if err != nil {
+		return fmt.Errorf("credential storage: %w", err)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(credentialsPath)
+		if err != nil {
+			return fmt.Errorf("credential storage: %w", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			return fmt.Errorf("credential storage: credentials file has mode %o, want 600", info.Mode().Perm())
+		}
+	}
+	pass("credential storage", "credentials written, read back and private to the user")
+
+	client := api.NewClient(saved.AccessToken, api.WithBaseURL(server.URL()))
+	user, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("API call: %w", err)
+	}
+	if user.Login != oauth.FakeLogin {
+		return fmt.Errorf("API call: unexpected user %q", user.Login)
+	}
+	pass("API call", fmt.Sprintf("/v2/me returned %s", user.Login))
+
+	if GetJSONOutput() {
+		result := map[string]interface{}{
+			"success":   true,
+			"simulated": true,
+			"steps":     steps,
+		}
+		if err := writeOutputValue(result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("\n🎉 Simulated login succeeded - the login flow works on this platform.")
+		fmt.Println("Your real credentials were not modified.")
+	}
+
+	return nil
+}
+
+// simulateBrowser opens authURL like a browser would, following redirects
+// to the callback server and checking that the callback page succeeded
+func simulateBrowser(ctx context.Context, authURL string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", authURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned status %d: %s", resp.Request.URL.Path, resp.StatusCode, body)
+	}
+	return nil
+}
+
+// setEnvTemporarily sets an environment variable and returns a function
+// restoring its previous value
+func setEnvTemporarily(key, value string) func() {
+	previous, existed := os.LookupEnv(key)
+	_ = os.Setenv(key, value)
+	return func() {
+		if existed {
+			_ = os.Setenv(key, previous)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	}
+}
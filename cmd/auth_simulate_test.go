@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestRunLoginSimulation(t *testing.T) {
+	tests := []struct {
+		name             string
+		embeddedClientID string
+	}{
+		{name: "confidential client"},
+		{name: "embedded public client", embeddedClientID: "campus-client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Any real credentials must survive the simulation untouched
+			realDir := t.TempDir()
+			t.Setenv(config.ConfigDirEnvVar, realDir)
+
+			if err := loginCmd.Flags().Set("port", "0"); err != nil {
+				t.Fatalf("failed to set port flag: %v", err)
+			}
+			defer func() {
+				_ = loginCmd.Flags().Set("port", "8080")
+			}()
+
+			jsonOutput = true
+			embeddedClientID, embeddedClientSecret = tt.embeddedClientID, ""
+			defer func() {
+				jsonOutput = false
+				embeddedClientID = ""
+			}()
+
+			if err := runLoginSimulation(loginCmd); err != nil {
+				t.Fatalf("runLoginSimulation() error = %v", err)
+			}
+
+			if _, err := os.Stat(filepath.Join(realDir, config.CredentialsFileName)); !os.IsNotExist(err) {
+				t.Errorf("simulation wrote credentials to the real config directory (stat err = %v)", err)
+			}
+			if got := os.Getenv(config.ConfigDirEnvVar); got != realDir {
+				t.Errorf("%s = %q after simulation, want %q", config.ConfigDirEnvVar, got, realDir)
+			}
+		})
+	}
+}
+
+func TestBindCallbackListener(t *testing.T) {
+	ln, redirectURL, err := bindCallbackListener(0, false)
+	if err != nil {
+		t.Fatalf("bindCallbackListener() error = %v", err)
+	}
+	defer ln.Close()
+
+	// Port 0 must be replaced by the port actually bound
+	if want := "http://" + ln.Addr().String() + "/callback"; redirectURL != want {
+		t.Errorf("redirectURL = %q, want %q", redirectURL, want)
+	}
+}
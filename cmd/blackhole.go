@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// blackholeWarnExitCode is the exit status of 't42 blackhole --warn-days'
+// when the blackhole is that close, told apart from errors (1)
+const blackholeWarnExitCode = 2
+
+var blackholeCmd = &cobra.Command{
+	Use:   "blackhole [login]",
+	Short: "Show the days left before a blackhole",
+	Long: `Show how many days are left before your blackhole, or another user's,
+in their current cursus or the one given with --cursus-id.
+
+With --warn-days N, t42 exits with status 2 when the blackhole is at most N
+days away or already passed, and 0 otherwise, so that the check can drive a
+shell prompt or a cron alert. Errors exit with status 1. --short prints only
+the number of days, or nothing without a blackhole date.
+
+Examples:
+  t42 blackhole
+  t42 blackhole jdoe --cursus-id 21
+  t42 blackhole --short
+  t42 blackhole --warn-days 14 >/dev/null || notify-send "Blackhole in less than 14 days"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBlackhole,
+}
+
+func init() {
+	rootCmd.AddCommand(blackholeCmd)
+
+	blackholeCmd.Flags().Int("cursus-id", 0, "Cursus ID (default: the user's current cursus)")
+	blackholeCmd.Flags().Int("warn-days", -1, "Exit with status 2 when the blackhole is at most this many days away")
+	blackholeCmd.Flags().Bool("short", false, "Print only the number of days left")
+}
+
+// blackholeCountdown is the time left before a user's blackhole
+type blackholeCountdown struct {
+	Login        string     `json:"login"`
+	CursusID     int        `json:"cursus_id"`
+	Cursus       string     `json:"cursus"`
+	BlackholedAt *time.Time `json:"blackholed_at"`
+	// DaysLeft is negative once the blackhole has passed, and nil without
+	// a blackhole date
+	DaysLeft *int `json:"days_left"`
+	Warning  bool `json:"warning"`
+}
+
+func runBlackhole(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+	warnDays, _ := cmd.Flags().GetInt("warn-days")
+	short, _ := cmd.Flags().GetBool("short")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	user, err := resolveUserArg(ctx, client, args)
+	if err != nil {
+		return err
+	}
+
+	cu := findCursusUser(user.CursusUsers, cursusID)
+	if cu == nil {
+		if cursusID != 0 {
+			return fmt.Errorf("%s is not in cursus %d", user.Login, cursusID)
+		}
+		return fmt.Errorf("%s has no cursus", user.Login)
+	}
+	countdown := buildBlackholeCountdown(user.Login, cu, warnDays, time.Now())
+
+	done, err := emitOutputValue(countdown)
+	if err != nil {
+		return err
+	}
+	if !done {
+		printBlackholeCountdown(countdown, short)
+	}
+	if countdown.Warning {
+		return exitWithCode(cmd, blackholeWarnExitCode)
+	}
+	return nil
+}
+
+// buildBlackholeCountdown computes the days left before the blackhole of a
+// cursus user at now. warnDays below zero never warns.
+func buildBlackholeCountdown(login string, cu *api.CursusUser, warnDays int, now time.Time) blackholeCountdown {
+	countdown := blackholeCountdown{
+		Login:        login,
+		CursusID:     cu.Cursus.ID,
+		Cursus:       cu.Cursus.Name,
+		BlackholedAt: cu.BlackholedAt,
+	}
+	if cu.BlackholedAt == nil {
+		return countdown
+	}
+	days := int(cu.BlackholedAt.Sub(now).Hours() / 24)
+	if cu.BlackholedAt.Before(now) && days == 0 {
+		// Passed less than a day ago: still negative
+		days = -1
+	}
+	countdown.DaysLeft = &days
+	countdown.Warning = warnDays >= 0 && days <= warnDays
+	return countdown
+}
+
+// printBlackholeCountdown prints the days left, or only their number with
+// short
+func printBlackholeCountdown(c blackholeCountdown, short bool) {
+	if short {
+		if c.DaysLeft != nil {
+			fmt.Println(*c.DaysLeft)
+		}
+		return
+	}
+	switch {
+	case c.DaysLeft == nil:
+		fmt.Printf("✅ %s has no blackhole date in %s\n", c.Login, c.Cursus)
+	case *c.DaysLeft < 0:
+		fmt.Printf("🕳️  %s was blackholed from %s on %s\n", c.Login, c.Cursus, c.BlackholedAt.Local().Format("Jan 02 2006"))
+	default:
+		fmt.Printf("%s %s: %d days left in %s (%s)\n", blackholeUrgency(*c.DaysLeft), c.Login, *c.DaysLeft, c.Cursus,
+			c.BlackholedAt.Local().Format("Jan 02 2006"))
+	}
+}
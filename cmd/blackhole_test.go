@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestBuildBlackholeCountdown(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		bh := now.Add(d)
+		return &bh
+	}
+
+	tests := []struct {
+		name        string
+		blackhole   *time.Time
+		warnDays    int
+		wantDays    *int
+		wantWarning bool
+	}{
+		{name: "no blackhole date", blackhole: nil, warnDays: 14},
+		{name: "far away", blackhole: at(40*24*time.Hour + time.Hour), warnDays: 14, wantDays: intPtr(40)},
+		{name: "within --warn-days", blackhole: at(14*24*time.Hour + time.Hour), warnDays: 14, wantDays: intPtr(14), wantWarning: true},
+		{name: "without --warn-days", blackhole: at(2 * 24 * time.Hour), warnDays: -1, wantDays: intPtr(2)},
+		{name: "passed an hour ago", blackhole: at(-time.Hour), warnDays: 0, wantDays: intPtr(-1), wantWarning: true},
+		{name: "passed three days ago", blackhole: at(-3 * 24 * time.Hour), warnDays: 7, wantDays: intPtr(-3), wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cu := &api.CursusUser{BlackholedAt: tt.blackhole, Cursus: api.Cursus{ID: 21, Name: "42cursus"}}
+			got := buildBlackholeCountdown("jdoe", cu, tt.warnDays, now)
+
+			if (got.DaysLeft == nil) != (tt.wantDays == nil) || (got.DaysLeft != nil && *got.DaysLeft != *tt.wantDays) {
+				t.Errorf("DaysLeft = %v, want %v", derefInt(got.DaysLeft), derefInt(tt.wantDays))
+			}
+			if got.Warning != tt.wantWarning {
+				t.Errorf("Warning = %v, want %v", got.Warning, tt.wantWarning)
+			}
+			if got.CursusID != 21 || got.Login != "jdoe" {
+				t.Errorf("countdown = %+v", got)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+// derefInt formats an optional number for test messages
+func derefInt(n *int) interface{} {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// browseTable is a list of results to browse interactively
+type browseTable struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+	// Show prints the details of a row once the browser is closed with enter
+	Show func(row int) error
+	// URL returns the intra page of a row, opened in the web browser with o
+	URL func(row int) string
+}
+
+// addInteractiveFlag adds --interactive to a list command
+func addInteractiveFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP("interactive", "i", false, "Browse the results in an interactive table: / filter, 1-9 sort, enter show, o open")
+}
+
+// useBrowser reports whether a list command should open the interactive
+// browser: --interactive was given and the output is a terminal
+func useBrowser(cmd *cobra.Command) (bool, error) {
+	interactive, _ := cmd.Flags().GetBool("interactive")
+	if !interactive || GetJSONOutput() || GetPlainOutput() {
+		return false, nil
+	}
+	if !promptsAvailable {
+		return false, fmt.Errorf("--interactive is not available in this build (built with -tags nogui)")
+	}
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return false, fmt.Errorf("--interactive needs a terminal")
+	}
+	return true, nil
+}
+
+// browseRows returns the indices of the rows containing the filter text in
+// any cell, ignoring case, sorted by a column when sortBy is not -1
+func browseRows(rows [][]string, filter string, sortBy int, desc bool) []int {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	var visible []int
+	for i, row := range rows {
+		if filter == "" || strings.Contains(strings.ToLower(strings.Join(row, "\x00")), filter) {
+			visible = append(visible, i)
+		}
+	}
+
+	if sortBy >= 0 {
+		cell := func(i int) string {
+			if sortBy < len(rows[i]) {
+				return rows[i][sortBy]
+			}
+			return ""
+		}
+		sort.SliceStable(visible, func(a, b int) bool {
+			x, y := cell(visible[a]), cell(visible[b])
+			if desc {
+				x, y = y, x
+			}
+			return browseLess(x, y)
+		})
+	}
+	return visible
+}
+
+// browseLess compares cells as numbers when both are, and in natural
+// order otherwise
+func browseLess(a, b string) bool {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	if errX == nil && errY == nil {
+		return x < y
+	}
+	return naturalLess(strings.ToLower(a), strings.ToLower(b))
+}
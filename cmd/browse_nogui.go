@@ -0,0 +1,9 @@
+//go:build nogui
+
+package cmd
+
+// runBrowser is unavailable without the interactive stack; useBrowser
+// refuses --interactive before it is reached
+func runBrowser(t browseTable) error {
+	return errPromptsUnavailable
+}
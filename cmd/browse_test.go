@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+var browseTestRows = [][]string{
+	{"jdoe", "John Doe", "9.50"},
+	{"asmith", "Alice Smith", "12.10"},
+	{"bdoe", "Bob Doe", "3.00"},
+}
+
+func TestBrowseRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		sortBy int
+		desc   bool
+		want   []int
+	}{
+		{"all", "", -1, false, []int{0, 1, 2}},
+		{"filter ignores case", "DOE", -1, false, []int{0, 2}},
+		{"filter matches any cell", "alice", -1, false, []int{1}},
+		{"sort text", "", 0, false, []int{1, 2, 0}},
+		{"sort numbers", "", 2, false, []int{2, 0, 1}},
+		{"sort numbers descending", "", 2, true, []int{1, 0, 2}},
+		{"filter and sort", "doe", 2, false, []int{2, 0}},
+		{"no match", "zzz", -1, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := browseRows(browseTestRows, tt.filter, tt.sortBy, tt.desc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("browseRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+//go:build !nogui
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// browseMaxColumnWidth caps the width of a browser column
+const browseMaxColumnWidth = 40
+
+var (
+	browseTitleStyle = lipgloss.NewStyle().Bold(true)
+	browseMutedStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// runBrowser shows the rows in the interactive browser, then the details
+// of the row picked with enter, if any
+func runBrowser(t browseTable) error {
+	final, err := tea.NewProgram(newBrowseModel(t), tea.WithAltScreen()).Run()
+	if err != nil {
+		return fmt.Errorf("failed to run the results browser: %w", err)
+	}
+	m := final.(browseModel)
+	if m.picked < 0 || t.Show == nil {
+		return nil
+	}
+	return t.Show(m.picked)
+}
+
+// browseModel is the bubbletea model of the results browser
+type browseModel struct {
+	data      browseTable
+	table     table.Model
+	filter    textinput.Model
+	filtering bool
+	visible   []int // indices of the rows shown, in display order
+	sortBy    int   // column sorted by, or -1
+	sortDesc  bool
+	picked    int // row chosen with enter, or -1
+	status    string
+}
+
+func newBrowseModel(t browseTable) browseModel {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter"
+
+	columns := make([]table.Column, len(t.Columns))
+	for i, title := range t.Columns {
+		width := len(title)
+		for _, row := range t.Rows {
+			if i < len(row) {
+				width = max(width, lipgloss.Width(row[i]))
+			}
+		}
+		columns[i] = table.Column{Title: title, Width: min(width, browseMaxColumnWidth)}
+	}
+
+	m := browseModel{
+		data:   t,
+		table:  table.New(table.WithColumns(columns), table.WithFocused(true), table.WithHeight(20)),
+		filter: filter,
+		sortBy: -1,
+		picked: -1,
+	}
+	m.refresh()
+	return m
+}
+
+func (m browseModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.table.SetWidth(msg.Width)
+		m.table.SetHeight(max(msg.Height-4, 3)) // title, filter and help lines
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+		m.status = ""
+		switch key := msg.String(); key {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			if m.filter.Value() != "" {
+				m.filter.SetValue("")
+				m.refresh()
+				return m, nil
+			}
+			return m, tea.Quit
+		case "/":
+			m.filtering = true
+			return m, m.filter.Focus()
+		case "enter":
+			if row, ok := m.selected(); ok {
+				m.picked = row
+				return m, tea.Quit
+			}
+			return m, nil
+		case "o":
+			if row, ok := m.selected(); ok && m.data.URL != nil {
+				url := m.data.URL(row)
+				if err := openBrowser(url); err != nil {
+					m.status = "⚠️  " + err.Error()
+				} else {
+					m.status = "Opened " + url
+				}
+			}
+			return m, nil
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			column, _ := strconv.Atoi(key)
+			m.toggleSort(column - 1)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// updateFilter edits the filter, updating the rows as the user types
+func (m browseModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "enter":
+		m.filtering = false
+		m.filter.Blur()
+		return m, nil
+	case "esc":
+		m.filtering = false
+		m.filter.Blur()
+		m.filter.SetValue("")
+		m.refresh()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	m.refresh()
+	return m, cmd
+}
+
+func (m browseModel) View() string {
+	var b strings.Builder
+	b.WriteString(browseTitleStyle.Render(m.data.Title))
+	b.WriteString(browseMutedStyle.Render(fmt.Sprintf("  %d of %d", len(m.visible), len(m.data.Rows))))
+	b.WriteString("\n")
+	b.WriteString(m.table.View())
+	b.WriteString("\n")
+	if m.filtering || m.filter.Value() != "" {
+		b.WriteString(m.filter.View())
+		b.WriteString("\n")
+	}
+
+	help := "↑/↓ move • f/b page • / filter • 1-9 sort • enter show • q quit"
+	if m.data.URL != nil {
+		help = "↑/↓ move • f/b page • / filter • 1-9 sort • enter show • o open • q quit"
+	}
+	if m.status != "" {
+		help = m.status
+	}
+	b.WriteString(browseMutedStyle.Render(help))
+	return b.String()
+}
+
+// selected returns the index of the row under the cursor
+func (m browseModel) selected() (int, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return 0, false
+	}
+	return m.visible[cursor], true
+}
+
+// toggleSort sorts by a column, reversing the order when it already is
+func (m *browseModel) toggleSort(column int) {
+	if column >= len(m.data.Columns) {
+		return
+	}
+	if m.sortBy == column {
+		m.sortDesc = !m.sortDesc
+	} else {
+		m.sortBy, m.sortDesc = column, false
+	}
+	m.refresh()
+}
+
+// refresh applies the filter and the sort order to the table
+func (m *browseModel) refresh() {
+	m.visible = browseRows(m.data.Rows, m.filter.Value(), m.sortBy, m.sortDesc)
+
+	rows := make([]table.Row, len(m.visible))
+	for i, index := range m.visible {
+		rows[i] = m.data.Rows[index]
+	}
+	m.table.SetRows(rows)
+	m.table.SetCursor(min(max(m.table.Cursor(), 0), len(rows)-1))
+
+	if m.sortBy >= 0 {
+		columns := m.table.Columns()
+		for i := range columns {
+			columns[i].Title = m.data.Columns[i]
+		}
+		arrow := " ▲"
+		if m.sortDesc {
+			arrow = " ▼"
+		}
+		columns[m.sortBy].Title += arrow
+		m.table.SetColumns(columns)
+	}
+}
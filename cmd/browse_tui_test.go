@@ -0,0 +1,39 @@
+//go:build !nogui
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBrowseModel(t *testing.T) {
+	var m tea.Model = newBrowseModel(browseTable{
+		Title:   "Users",
+		Columns: []string{"LOGIN", "NAME", "LEVEL"},
+		Rows:    browseTestRows,
+	})
+	keys := func(s string) {
+		for _, r := range s {
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	// Filter on "doe", then sort by level: bdoe (3.00) comes first
+	keys("/doe")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	keys("3")
+	if got := m.(browseModel).visible; !reflect.DeepEqual(got, []int{2, 0}) {
+		t.Fatalf("visible rows = %v, want [2 0]", got)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.(browseModel).picked; got != 2 {
+		t.Errorf("picked = %d, want row 2 (bdoe)", got)
+	}
+	if cmd == nil {
+		t.Error("enter did not quit the browser")
+	}
+}
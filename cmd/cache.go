@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/cache"
+	"github.com/naokiiida/t42-cli/internal/campus"
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// cacheResources lists the cached resources with their default TTL.
+// The keys are the names accepted by cache_ttl and 'cache invalidate'.
+var cacheResources = map[string]time.Duration{
+	"campus":   campus.TableTTL,
+	"cursus":   cursusCacheTTL,
+	"profile":  profileCacheTTL,
+	"project":  projectIndexTTL,
+	"response": 0, // other GET responses: only cached with --cache-ttl or cache_ttl
+}
+
+// cursusCacheTTL is how long cursus responses are reused; cursuses rarely change
+const cursusCacheTTL = 24 * time.Hour
+
+// ownDataResources are the cached resources that contain data about the
+// logged-in user and go stale when a command changes that data
+var ownDataResources = []string{"profile", "response"}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and invalidate the local cache",
+	Long: `Inspect and invalidate the local API response cache.
+
+Cached data is grouped by resource. The default lifetime of each resource
+can be overridden in the config file:
+
+  # config.yaml
+  cache_ttl:
+    campus: 48h
+    profile: 1m
+
+API responses for campuses, cursuses and projects are cached too; other
+GET responses are only cached with a "response" TTL or --cache-ttl.
+--no-cache refetches everything for one command, and --cache-ttl
+overrides the lifetime of every resource for one command.`,
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show cache size, entries and hit rate per resource",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStatus,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete every cached entry and the hit counters",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClear,
+}
+
+var cacheInvalidateCmd = &cobra.Command{
+	Use:   "invalidate [resource]...",
+	Short: "Drop cached data of the given resources",
+	Long: `Drop cached data of the given resources so the next command refetches it.
+
+Examples:
+  t42 cache invalidate profile
+  t42 cache invalidate campus profile
+  t42 cache invalidate --all`,
+	ValidArgs: cacheResourceNames(),
+	RunE:      runCacheInvalidate,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheInvalidateCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+
+	cacheInvalidateCmd.Flags().Bool("all", false, "Invalidate every resource")
+}
+
+// cacheResourceNames returns the known resource names in alphabetical order
+func cacheResourceNames() []string {
+	names := make([]string, 0, len(cacheResources))
+	for name := range cacheResources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// openCache opens the cache store with the TTL overrides from the config
+// file and the --cache-ttl and --no-cache flags
+func openCache() (*cache.Store, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	ttls, err := parseCacheTTLs(cfg.CacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	if rootCmd.PersistentFlags().Changed("cache-ttl") {
+		for resource := range cacheResources {
+			ttls[resource] = cacheTTL
+		}
+	}
+
+	options := []cache.Option{cache.WithTTLs(ttls)}
+	if noCache {
+		options = append(options, cache.WithBypass())
+	}
+	return cache.Open(options...)
+}
+
+// responseCachePolicy gives API endpoints the TTL of their cache resource
+func responseCachePolicy(store *cache.Store) api.CachePolicy {
+	return func(endpoint string) (string, time.Duration) {
+		resource := responseResource(endpoint)
+		return resource, store.TTL(resource, cacheResources[resource])
+	}
+}
+
+// responseResource returns the cache resource of a GET endpoint: campuses,
+// cursuses (with their levels) and the project catalog have their own, everything else is
+// "response"
+func responseResource(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v2" {
+		return "response"
+	}
+	switch {
+	case parts[1] == "campus" && len(parts) <= 3:
+		return "campus"
+	case parts[1] == "cursus" && len(parts) <= 3:
+		return "cursus"
+	case parts[1] == "cursus" && len(parts) == 4 && parts[3] == "levels":
+		return "cursus"
+	case parts[1] == "cursus" && len(parts) == 4 && parts[3] == "projects":
+		return "project"
+	case parts[1] == "projects" && len(parts) <= 3:
+		return "project"
+	}
+	return "response"
+}
+
+// parseCacheTTLs validates the cache_ttl setting
+func parseCacheTTLs(raw map[string]string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration, len(raw))
+	for resource, value := range raw {
+		if _, ok := cacheResources[resource]; !ok {
+			return nil, fmt.Errorf("unknown cache resource %q in cache_ttl (known: %s)",
+				resource, strings.Join(cacheResourceNames(), ", "))
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid cache_ttl for %s: %q (use a duration such as 10m or 48h)", resource, value)
+		}
+		ttls[resource] = ttl
+	}
+	return ttls, nil
+}
+
+// invalidateOwnData drops cached data about the logged-in user, so commands
+// that change it (login, registrations, team changes) are followed by fresh
+// reads. Failures only leave stale data behind and are reported in verbose mode.
+func invalidateOwnData() {
+	store, err := cache.Open()
+	if err == nil {
+		_, err = store.Invalidate(ownDataResources...)
+	}
+	if err != nil && GetVerbose() {
+		fmt.Fprintf(os.Stderr, "Failed to invalidate cached user data: %v\n", err)
+	}
+}
+
+// cacheResourceReport is the status of one resource as shown by 'cache status'
+type cacheResourceReport struct {
+	cache.ResourceStatus
+	TTL     string  `json:"ttl,omitempty"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	store, err := openCache()
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	statuses, err := store.Status()
+	if err != nil {
+		return fmt.Errorf("failed to read cache status: %w", err)
+	}
+
+	reports := buildCacheReports(store, statuses)
+
+	if done, err := emitOutput(
+		output.Field{Key: "dir", Value: store.Dir()},
+		output.Field{Key: "resources", Value: reports},
+	); done || err != nil {
+		return err
+	}
+
+	printCacheStatus(store.Dir(), reports)
+	return nil
+}
+
+// buildCacheReports adds every known resource (even when empty) and its
+// effective TTL to the statuses
+func buildCacheReports(store *cache.Store, statuses []cache.ResourceStatus) []cacheResourceReport {
+	byResource := make(map[string]cache.ResourceStatus, len(statuses))
+	for _, status := range statuses {
+		byResource[status.Resource] = status
+	}
+	for resource := range cacheResources {
+		if _, ok := byResource[resource]; !ok {
+			byResource[resource] = cache.ResourceStatus{Resource: resource}
+		}
+	}
+
+	reports := make([]cacheResourceReport, 0, len(byResource))
+	for _, status := range byResource {
+		report := cacheResourceReport{ResourceStatus: status, HitRate: status.HitRate()}
+		if fallback, ok := cacheResources[status.Resource]; ok {
+			report.TTL = store.TTL(status.Resource, fallback).String()
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Resource < reports[j].Resource
+	})
+	return reports
+}
+
+func printCacheStatus(dir string, reports []cacheResourceReport) {
+	fmt.Printf("🗄️  Cache: %s\n\n", dir)
+	fmt.Printf("%-12s %8s %10s %10s %10s\n", "RESOURCE", "ENTRIES", "SIZE", "HIT RATE", "TTL")
+	fmt.Println(strings.Repeat("-", 54))
+
+	var entries int
+	var bytes int64
+	for _, report := range reports {
+		hitRate := "-"
+		if report.Hits+report.Misses > 0 {
+			hitRate = fmt.Sprintf("%.0f%%", report.HitRate*100)
+		}
+		ttl := report.TTL
+		if ttl == "" {
+			ttl = "-"
+		}
+		fmt.Printf("%-12s %8d %10s %10s %10s\n",
+			truncateString(report.Resource, 12), report.Entries, formatBytes(report.Bytes), hitRate, ttl)
+		entries += report.Entries
+		bytes += report.Bytes
+	}
+
+	fmt.Printf("\n📦 %d entries, %s total\n", entries, formatBytes(bytes))
+}
+
+// formatBytes renders a size in B, KB or MB
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+func runCacheInvalidate(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+
+	resources := args
+	switch {
+	case all && len(args) > 0:
+		return fmt.Errorf("--all cannot be combined with resource names")
+	case all:
+		resources = cacheResourceNames()
+	case len(args) == 0:
+		return fmt.Errorf("specify resources to invalidate (%s) or --all", strings.Join(cacheResourceNames(), ", "))
+	}
+
+	for _, resource := range resources {
+		if _, ok := cacheResources[resource]; !ok {
+			return fmt.Errorf("unknown cache resource %q (known: %s)", resource, strings.Join(cacheResourceNames(), ", "))
+		}
+	}
+
+	store, err := cache.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+	removed, err := store.Invalidate(resources...)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate cache: %w", err)
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "success", Value: true},
+		output.Field{Key: "resources", Value: resources},
+		output.Field{Key: "removed", Value: removed},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("🧹 Removed %d cached entries (%s)\n", removed, strings.Join(resources, ", "))
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	store, err := cache.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+	removed, err := store.Clear()
+	if err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "success", Value: true},
+		output.Field{Key: "removed", Value: removed},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("🧹 Removed %d cached entries from %s\n", removed, store.Dir())
+	return nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCacheTTLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     map[string]string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{"empty", nil, map[string]time.Duration{}, false},
+		{"valid", map[string]string{"campus": "48h", "profile": "0s"}, map[string]time.Duration{"campus": 48 * time.Hour, "profile": 0}, false},
+		{"unknown resource", map[string]string{"projects": "1h"}, nil, true},
+		{"bad duration", map[string]string{"campus": "two days"}, nil, true},
+		{"negative", map[string]string{"campus": "-1h"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCacheTTLs(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCacheTTLs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseCacheTTLs() = %v, want %v", got, tt.want)
+			}
+			for resource, ttl := range tt.want {
+				if got[resource] != ttl {
+					t.Errorf("TTL of %s = %v, want %v", resource, got[resource], ttl)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KB"},
+		{3 << 20, "3.0 MB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatBytes(tt.n); got != tt.want {
+				t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseResource(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"/v2/campus?page=1&per_page=100", "campus"},
+		{"/v2/campus/26", "campus"},
+		{"/v2/campus/26/users", "response"},
+		{"/v2/cursus", "cursus"},
+		{"/v2/cursus/21/projects?page=2", "project"},
+		{"/v2/cursus/21/cursus_users", "response"},
+		{"/v2/cursus/21/levels?per_page=100", "cursus"},
+		{"/v2/projects/libft", "project"},
+		{"/v2/projects/1314/project_sessions", "response"},
+		{"/v2/me", "response"},
+		{"/oauth/token/info", "response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			if got := responseResource(tt.endpoint); got != tt.want {
+				t.Errorf("responseResource(%q) = %q, want %q", tt.endpoint, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// apiCalls records the API requests of the running command for the
+// summary printed with --verbose
+var apiCalls = api.NewCallRecorder()
+
+// callSummaryEndpoints is how many of the most expensive endpoints the
+// summary lists
+const callSummaryEndpoints = 3
+
+// printCallSummary prints how many API requests a command made, how much
+// they transferred and how long they took
+func printCallSummary(w io.Writer, s api.CallSummary) {
+	if s.Count == 0 {
+		return
+	}
+
+	calls := fmt.Sprintf("%d API calls", s.Count)
+	if s.Count == 1 {
+		calls = "1 API call"
+	}
+	if s.Cached > 0 {
+		calls += fmt.Sprintf(" (%d cached)", s.Cached)
+	}
+	line := fmt.Sprintf("\n📡 %s, %s", calls, formatKB(s.Bytes))
+	if s.Count > s.Cached {
+		line += fmt.Sprintf(", latency p50 %s / p95 %s", formatLatency(s.P50), formatLatency(s.P95))
+	}
+	fmt.Fprintln(w, line)
+	if s.Retries > 0 {
+		retries := fmt.Sprintf("%d retries", s.Retries)
+		if s.Retries == 1 {
+			retries = "1 retry"
+		}
+		fmt.Fprintf(w, "🔁 %s, %s waited\n", retries, formatLatency(s.RetryWait))
+	}
+
+	for i, e := range s.Endpoints {
+		if i == callSummaryEndpoints {
+			break
+		}
+		fmt.Fprintf(w, "   %-6s %-40s %3d× %9s %8s\n",
+			e.Method, truncateString(e.Endpoint, 40), e.Count, formatKB(e.Bytes), formatLatency(e.Latency))
+	}
+}
+
+// retryOptions returns the client options showing each retry, its wait
+// and why, with --verbose
+func retryOptions() []api.ClientOption {
+	if !GetVerbose() {
+		return nil
+	}
+	return []api.ClientOption{api.WithRetryObserver(func(r api.Retry) {
+		printRetry(os.Stderr, r)
+	})}
+}
+
+// printRetry prints a retry as it happens
+func printRetry(w io.Writer, r api.Retry) {
+	failure := fmt.Sprintf("HTTP %d", r.Status)
+	if r.Err != nil {
+		failure = r.Err.Error()
+	}
+	fmt.Fprintf(w, "⏳ %s %s: %s, attempt %d/%d in %s (%s)\n",
+		r.Method, r.Endpoint, failure, r.Attempt, api.MaxRetries+1, formatLatency(r.Wait), r.Reason)
+}
+
+// formatKB formats a byte count in kilobytes
+func formatKB(bytes int64) string {
+	return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+}
+
+// formatLatency formats a duration to the millisecond
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
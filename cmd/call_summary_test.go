@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestPrintCallSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary api.CallSummary
+		want    []string
+	}{
+		{
+			name:    "no calls",
+			summary: api.CallSummary{},
+		},
+		{
+			name: "network and cache",
+			summary: api.CallSummary{
+				Count: 4, Cached: 1, Bytes: 3072, P50: 120 * time.Millisecond, P95: 1500 * time.Millisecond,
+				Endpoints: []api.EndpointCost{
+					{Method: "GET", Endpoint: "/v2/users/:id", Count: 3, Bytes: 2048, Latency: 2 * time.Second},
+				},
+			},
+			want: []string{"4 API calls (1 cached), 3.0 KB", "p50 120ms / p95 1.50s", "/v2/users/:id", "3×"},
+		},
+		{
+			name:    "retries",
+			summary: api.CallSummary{Count: 3, Bytes: 1024, Retries: 2, RetryWait: 2500 * time.Millisecond},
+			want:    []string{"🔁 2 retries, 2.50s waited"},
+		},
+		{
+			name:    "only cached",
+			summary: api.CallSummary{Count: 1, Cached: 1, Bytes: 512},
+			want:    []string{"1 API call (1 cached), 0.5 KB"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printCallSummary(&buf, tt.summary)
+			got := buf.String()
+			if len(tt.want) == 0 && got != "" {
+				t.Errorf("printCallSummary() = %q, want nothing", got)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("printCallSummary() = %q, want it to contain %q", got, want)
+				}
+			}
+			if tt.summary.Count == tt.summary.Cached && strings.Contains(got, "latency") {
+				t.Errorf("printCallSummary() = %q, want no latency without network calls", got)
+			}
+		})
+	}
+}
+
+func TestPrintRetry(t *testing.T) {
+	var buf bytes.Buffer
+	printRetry(&buf, api.Retry{Method: "GET", Endpoint: "/v2/users/:id", Attempt: 2, Status: 429, Wait: 1200 * time.Millisecond, Reason: "Retry-After"})
+	if want := "⏳ GET /v2/users/:id: HTTP 429, attempt 2/4 in 1.20s (Retry-After)\n"; buf.String() != want {
+		t.Errorf("printRetry() = %q, want %q", buf.String(), want)
+	}
+}
@@ -2,14 +2,18 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/campus"
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
 )
 
 var campusCmd = &cobra.Command{
@@ -43,7 +47,8 @@ var showCampusCmd = &cobra.Command{
 	Short: "Show campus details",
 	Long: `Show detailed information about a specific campus.
 
-You can specify a campus by ID or name.`,
+You can specify a campus by ID, name, city or alias (e.g. '42tokyo').
+Aliases can be defined under campus_aliases in the config file.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runShowCampus,
 }
@@ -102,17 +107,14 @@ func runListCampuses(cmd *cobra.Command, args []string) error {
 		filtered = append(filtered, c)
 	}
 
-	if GetJSONOutput() {
-		output := map[string]interface{}{
-			"campuses": filtered,
-			"count":    len(filtered),
-		}
-		jsonData, err := json.MarshalIndent(output, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to marshal JSON output: %w", err)
-		}
-		fmt.Println(string(jsonData))
-	} else {
+	done, err := emitOutput(
+		output.Field{Key: "campuses", Value: filtered},
+		output.Field{Key: "count", Value: len(filtered)},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	if !done {
 		if len(filtered) == 0 {
 			fmt.Println("No campuses found matching criteria.")
 			return nil
@@ -147,50 +149,128 @@ func runShowCampus(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	query := args[0]
 
-	campuses, err := client.ListCampuses(ctx)
+	found, err := newCampusResolver(client).Resolve(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to list campuses: %w", err)
+		return err
+	}
+
+	if done, err := emitOutputValue(found); done || err != nil {
+		return err
+	}
+	printCampusDetails(found)
+
+	return nil
+}
+
+// addCampusFlags registers the --campus and --campus-id flags shared by every
+// command that can be scoped to a campus
+func addCampusFlags(cmd *cobra.Command) {
+	cmd.Flags().String("campus", "", "Campus name, city, ID or alias (e.g., 'tokyo', '42paris')")
+	cmd.Flags().Int("campus-id", 0, "Campus ID")
+}
+
+// newCampusResolver creates a campus resolver backed by the cached campus
+// table and the aliases from the config file
+func newCampusResolver(client *api.Client) *campus.Resolver {
+	var opts []campus.Option
+	if store, err := openCache(); err == nil {
+		opts = append(opts, campus.WithCache(store))
 	}
+	if cfg, err := config.LoadConfig(); err == nil && len(cfg.CampusAliases) > 0 {
+		opts = append(opts, campus.WithAliases(cfg.CampusAliases))
+	}
+	return campus.NewResolver(client, opts...)
+}
+
+// resolveCampusFlags returns the campus selected with --campus or --campus-id,
+// or nil when neither flag is set
+func resolveCampusFlags(ctx context.Context, cmd *cobra.Command, client *api.Client) (*api.Campus, error) {
+	query, _ := cmd.Flags().GetString("campus")
+	campusID, _ := cmd.Flags().GetInt("campus-id")
 
+	if query != "" && campusID > 0 {
+		return nil, fmt.Errorf("--campus and --campus-id are mutually exclusive")
+	}
+
+	resolver := newCampusResolver(client)
 	var found *api.Campus
+	var err error
+	switch {
+	case query != "":
+		found, err = resolver.Resolve(ctx, query)
+	case campusID > 0:
+		found, err = resolver.ResolveID(ctx, campusID)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	recordUsageValue("campus", strconv.Itoa(found.ID))
+	return found, nil
+}
 
-	// Try to find by ID first
-	if id, err := strconv.Atoi(query); err == nil {
-		for i := range campuses {
-			if campuses[i].ID == id {
-				found = &campuses[i]
-				break
-			}
-		}
+// requireCampus is resolveCampusFlags for commands that need a campus. When
+// neither flag is set it asks for one in a terminal, pre-selecting the
+// campus used most (with usage stats on), and fails otherwise.
+func requireCampus(ctx context.Context, cmd *cobra.Command, client *api.Client) (*api.Campus, error) {
+	found, err := resolveCampusFlags(ctx, cmd, client)
+	if err != nil || found != nil {
+		return found, err
 	}
+	if GetJSONOutput() || !isTerminal(os.Stdin) {
+		return nil, fmt.Errorf("a campus is required - use --campus or --campus-id")
+	}
+	return pickCampus(ctx, client)
+}
 
-	// If not found by ID, search by name
-	if found == nil {
-		queryLower := strings.ToLower(query)
-		for i := range campuses {
-			if strings.ToLower(campuses[i].Name) == queryLower ||
-				strings.ToLower(campuses[i].City) == queryLower {
-				found = &campuses[i]
-				break
-			}
+// pickCampus lets the user choose an active campus
+func pickCampus(ctx context.Context, client *api.Client) (*api.Campus, error) {
+	campuses, err := newCampusResolver(client).Campuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []api.Campus
+	for _, c := range campuses {
+		if c.Active {
+			active = append(active, c)
 		}
 	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].Name < active[j].Name
+	})
+	if len(active) == 0 {
+		return nil, fmt.Errorf("no active campus found")
+	}
 
-	if found == nil {
-		return fmt.Errorf("campus %q not found", query)
+	labels := make([]string, len(active))
+	for i := range active {
+		labels[i] = campus.Label(&active[i])
+	}
+	choice, err := selectPrompt(selection{
+		Title:   "Campus",
+		Options: labels,
+		Default: preferredCampusIndex(active, preferredUsageValue("campus")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campus selection: %w", err)
 	}
 
-	if GetJSONOutput() {
-		jsonData, err := json.MarshalIndent(found, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to marshal JSON output: %w", err)
+	picked := active[choice]
+	recordUsageValue("campus", strconv.Itoa(picked.ID))
+	return &picked, nil
+}
+
+// preferredCampusIndex returns the index of the campus with the given ID,
+// or 0 when it is not listed
+func preferredCampusIndex(campuses []api.Campus, id string) int {
+	for i, c := range campuses {
+		if strconv.Itoa(c.ID) == id {
+			return i
 		}
-		fmt.Println(string(jsonData))
-	} else {
-		printCampusDetails(found)
 	}
-
-	return nil
+	return 0
 }
 
 func printCampusDetails(c *api.Campus) {
@@ -216,3 +296,22 @@ func printCampusDetails(c *api.Campus) {
 		fmt.Printf("Website:    %s\n", c.Website)
 	}
 }
+
+// primaryCampus returns the user's primary campus, falling back to the first
+// one listed; nil if the user has no campus
+func primaryCampus(user *api.User) *api.Campus {
+	for _, cu := range user.CampusUsers {
+		if !cu.IsPrimary {
+			continue
+		}
+		for i := range user.Campus {
+			if user.Campus[i].ID == cu.CampusID {
+				return &user.Campus[i]
+			}
+		}
+	}
+	if len(user.Campus) > 0 {
+		return &user.Campus[0]
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var campusStatsCmd = &cobra.Command{
+	Use:   "stats <id-or-name>",
+	Short: "Show user counts, levels and cluster occupancy of a campus",
+	Long: `Show a snapshot of a campus: its users by cursus, how many are active,
+blackholed or done with the cursus, their average and highest level, and
+how many are logged in at the cluster right now.
+
+A cursus user is active while their cursus has not ended and they are not
+blackholed; the average level is that of the active ones. A user counts as
+an alumnus once alumnized, else as active with at least one active cursus.
+
+Every cursus user of the campus is fetched, which takes a request per 100;
+--cursus-id narrows the snapshot to one cursus.
+
+Examples:
+  t42 campus stats tokyo
+  t42 campus stats 26 --cursus-id 21
+  t42 campus stats paris --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCampusStats,
+}
+
+func init() {
+	campusCmd.AddCommand(campusStatsCmd)
+
+	campusStatsCmd.Flags().Int("cursus-id", 0, "Only this cursus (default: every cursus)")
+}
+
+// cursusStats counts the users of one cursus at a campus
+type cursusStats struct {
+	CursusID     int     `json:"cursus_id"`
+	Cursus       string  `json:"cursus"`
+	Users        int     `json:"users"`
+	Active       int     `json:"active"`
+	Blackholed   int     `json:"blackholed"`
+	Ended        int     `json:"ended"`
+	AverageLevel float64 `json:"average_level"` // of the active users
+	MaxLevel     float64 `json:"max_level"`
+}
+
+// campusStats is the snapshot 't42 campus stats' shows
+type campusStats struct {
+	Users     int           `json:"users"`
+	Active    int           `json:"active"`
+	Alumni    int           `json:"alumni"`
+	Inactive  int           `json:"inactive"`
+	AtCluster *int          `json:"at_cluster"` // nil when unknown
+	Cursuses  []cursusStats `json:"cursuses"`
+}
+
+func runCampusStats(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	campus, err := newCampusResolver(client).Resolve(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	cursusUsers, err := client.CursusUsersPaginator(ctx, cursusID, &api.ListCursusUsersOptions{CampusID: campus.ID}).All()
+	if err != nil {
+		return fmt.Errorf("failed to list the cursus users of %s: %w", campus.Name, err)
+	}
+	stats := buildCampusStats(cursusUsers, time.Now())
+
+	if locations, err := fetchCampusLocations(ctx, client, campus.ID, &api.ListLocationsOptions{Active: true}); err != nil {
+		warnf("locations_unavailable", "cluster occupancy unknown: %v", err)
+	} else {
+		// Pages shift while sessions open, so users are counted once
+		loggedIn := make(map[int]bool)
+		for _, l := range locations {
+			loggedIn[l.User.ID] = true
+		}
+		atCluster := len(loggedIn)
+		stats.AtCluster = &atCluster
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "campus_id", Value: campus.ID},
+		output.Field{Key: "campus", Value: campus.Name},
+		output.Field{Key: "stats", Value: stats},
+	); done || err != nil {
+		return err
+	}
+
+	printCampusStats(campus.Name, stats)
+	return nil
+}
+
+// buildCampusStats aggregates the cursus users of a campus by cursus, the
+// largest first, and by user
+func buildCampusStats(cursusUsers []api.CursusUser, now time.Time) campusStats {
+	var stats campusStats
+	byCursus := make(map[int]*cursusStats)
+	levelSums := make(map[int]float64)
+	userStatus := make(map[int]string)
+
+	for _, cu := range cursusUsers {
+		cs, ok := byCursus[cu.Cursus.ID]
+		if !ok {
+			cs = &cursusStats{CursusID: cu.Cursus.ID, Cursus: cu.Cursus.Name}
+			byCursus[cu.Cursus.ID] = cs
+		}
+		cs.Users++
+		cs.MaxLevel = max(cs.MaxLevel, cu.Level)
+
+		active := false
+		switch {
+		case cu.BlackholedAt != nil && cu.BlackholedAt.Before(now):
+			cs.Blackholed++
+		case cu.EndAt != nil && cu.EndAt.Before(now):
+			cs.Ended++
+		default:
+			cs.Active++
+			levelSums[cu.Cursus.ID] += cu.Level
+			active = true
+		}
+
+		switch {
+		case cu.User.Alumni || cu.User.AlumnizedAt != nil:
+			userStatus[cu.User.ID] = "alumni"
+		case active:
+			userStatus[cu.User.ID] = "active"
+		case userStatus[cu.User.ID] == "":
+			userStatus[cu.User.ID] = "inactive"
+		}
+	}
+
+	for _, status := range userStatus {
+		switch status {
+		case "alumni":
+			stats.Alumni++
+		case "active":
+			stats.Active++
+		default:
+			stats.Inactive++
+		}
+	}
+	stats.Users = len(userStatus)
+
+	for id, cs := range byCursus {
+		if cs.Active > 0 {
+			cs.AverageLevel = levelSums[id] / float64(cs.Active)
+		}
+		stats.Cursuses = append(stats.Cursuses, *cs)
+	}
+	sort.Slice(stats.Cursuses, func(i, j int) bool {
+		if stats.Cursuses[i].Users != stats.Cursuses[j].Users {
+			return stats.Cursuses[i].Users > stats.Cursuses[j].Users
+		}
+		return stats.Cursuses[i].CursusID < stats.Cursuses[j].CursusID
+	})
+	return stats
+}
+
+func printCampusStats(campus string, stats campusStats) {
+	fmt.Printf("📊 %s\n\n", campus)
+	fmt.Printf("Users       %d (%d active, %d alumni, %d inactive)\n", stats.Users, stats.Active, stats.Alumni, stats.Inactive)
+	if stats.AtCluster != nil {
+		fmt.Printf("At cluster  %d\n", *stats.AtCluster)
+	} else {
+		fmt.Println("At cluster  unknown")
+	}
+
+	if len(stats.Cursuses) == 0 {
+		fmt.Println("\nNo cursus users.")
+		return
+	}
+	fmt.Printf("\n%-28s %6s %6s %10s %6s %7s %7s\n", "CURSUS", "USERS", "ACTIVE", "BLACKHOLED", "ENDED", "AVG LVL", "MAX LVL")
+	fmt.Println(strings.Repeat("-", 78))
+	for _, cs := range stats.Cursuses {
+		fmt.Printf("%-28s %6d %6d %10d %6d %7.2f %7.2f\n", truncateString(cs.Cursus, 28),
+			cs.Users, cs.Active, cs.Blackholed, cs.Ended, cs.AverageLevel, cs.MaxLevel)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestBuildCampusStats(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	past, future := now.AddDate(0, -1, 0), now.AddDate(0, 6, 0)
+	common := api.Cursus{ID: 21, Name: "42cursus"}
+	piscine := api.Cursus{ID: 9, Name: "C Piscine"}
+	cursusUser := func(userID int, cursus api.Cursus, level float64, blackholedAt, endAt *time.Time) api.CursusUser {
+		return api.CursusUser{User: api.User{ID: userID}, Cursus: cursus, Level: level, BlackholedAt: blackholedAt, EndAt: endAt}
+	}
+	alumnus := cursusUser(5, common, 21, nil, &past)
+	alumnus.User.AlumnizedAt = &past
+
+	cursusUsers := []api.CursusUser{
+		cursusUser(1, common, 8, &future, nil),
+		cursusUser(1, piscine, 12, nil, &past),
+		cursusUser(2, common, 4, nil, nil),
+		cursusUser(2, piscine, 9, nil, &past),
+		cursusUser(3, common, 2.5, &past, nil),
+		cursusUser(3, piscine, 6, nil, &past),
+		cursusUser(4, piscine, 3, nil, &past),
+		alumnus,
+	}
+
+	stats := buildCampusStats(cursusUsers, now)
+
+	if stats.Users != 5 || stats.Active != 2 || stats.Alumni != 1 || stats.Inactive != 2 {
+		t.Errorf("users = %d (%d active, %d alumni, %d inactive), want 5 (2, 1, 2)",
+			stats.Users, stats.Active, stats.Alumni, stats.Inactive)
+	}
+	if len(stats.Cursuses) != 2 {
+		t.Fatalf("cursuses = %+v, want 2", stats.Cursuses)
+	}
+	// As many users in both: by cursus ID
+	want := []cursusStats{
+		{CursusID: 9, Cursus: "C Piscine", Users: 4, Ended: 4, MaxLevel: 12},
+		{CursusID: 21, Cursus: "42cursus", Users: 4, Active: 2, Blackholed: 1, Ended: 1, AverageLevel: 6, MaxLevel: 21},
+	}
+	for i := range want {
+		if stats.Cursuses[i] != want[i] {
+			t.Errorf("cursus %d = %+v, want %+v", i, stats.Cursuses[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// clockSkewWarnThreshold is how far the local clock may drift from the API
+// server before t42 warns; smaller differences only nudge the expiry math
+const clockSkewWarnThreshold = time.Minute
+
+// recordClockSkew stores in credentials how far the server clock, as seen in
+// the Date header of the token response, is off the local clock, so token
+// expiry is computed correctly on machines with a wrong clock
+func recordClockSkew(credentials *config.Credentials, header http.Header, sent, received time.Time) {
+	skew, ok := api.ClockSkew(header, sent, received)
+	if !ok {
+		return
+	}
+	credentials.ClockSkew = int64(skew.Seconds())
+
+	if exceedsClockSkewThreshold(skew) {
+		fmt.Fprintf(os.Stderr, "⚠️  Your clock is %s the 42 API server; token expiry is adjusted, but consider syncing it (e.g. enable NTP)\n",
+			describeClockSkew(skew))
+	}
+}
+
+// exceedsClockSkewThreshold reports whether skew is worth a warning
+func exceedsClockSkewThreshold(skew time.Duration) bool {
+	return skew >= clockSkewWarnThreshold || skew <= -clockSkewWarnThreshold
+}
+
+// describeClockSkew tells how the local clock compares to the server's
+func describeClockSkew(skew time.Duration) string {
+	if skew > 0 {
+		return fmt.Sprintf("%s behind", skew)
+	}
+	return fmt.Sprintf("%s ahead of", -skew)
+}
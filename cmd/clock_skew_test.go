@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestRecordClockSkew(t *testing.T) {
+	sent := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		date      string
+		wantSkew  int64
+		wantWarn  bool
+		wantLabel string
+	}{
+		{name: "in sync", date: "Sat, 01 Jun 2024 12:00:00 GMT"},
+		{name: "local clock behind", date: "Sat, 01 Jun 2024 12:05:00 GMT", wantSkew: 300, wantWarn: true, wantLabel: "5m0s behind"},
+		{name: "local clock ahead", date: "Sat, 01 Jun 2024 10:00:00 GMT", wantSkew: -7200, wantWarn: true, wantLabel: "2h0m0s ahead of"},
+		{name: "small drift", date: "Sat, 01 Jun 2024 12:00:20 GMT", wantSkew: 20},
+		{name: "no date header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.date != "" {
+				header.Set("Date", tt.date)
+			}
+			credentials := &config.Credentials{}
+			recordClockSkew(credentials, header, sent, sent)
+
+			if credentials.ClockSkew != tt.wantSkew {
+				t.Errorf("ClockSkew = %d, want %d", credentials.ClockSkew, tt.wantSkew)
+			}
+			skew := time.Duration(credentials.ClockSkew) * time.Second
+			if got := exceedsClockSkewThreshold(skew); got != tt.wantWarn {
+				t.Errorf("exceedsClockSkewThreshold(%v) = %v, want %v", skew, got, tt.wantWarn)
+			}
+			if tt.wantLabel != "" && describeClockSkew(skew) != tt.wantLabel {
+				t.Errorf("describeClockSkew(%v) = %q, want %q", skew, describeClockSkew(skew), tt.wantLabel)
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// coalitionMemberWorkers bounds the concurrent profile lookups of 'coalition show'
+const coalitionMemberWorkers = 4
+
+var coalitionCmd = &cobra.Command{
+	Use:     "coalition",
+	Aliases: []string{"coalitions"},
+	Short:   "Coalitions of your campus and their scores",
+	Long: `Browse the coalitions competing at a campus, their scores and your points.
+
+The coalitions of a campus form a bloc per cursus. Without --campus or
+--campus-id, your primary campus is used.`,
+}
+
+var coalitionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the coalitions of a campus",
+	Long: `List the coalitions of a campus; yours is marked with a star.
+
+Examples:
+  t42 coalition list
+  t42 coalition list --campus paris`,
+	Args: cobra.NoArgs,
+	RunE: runCoalitionList,
+}
+
+var coalitionShowCmd = &cobra.Command{
+	Use:   "show <id|name>",
+	Short: "Show a coalition, its top members and your points",
+	Long: `Show a coalition by ID, or by name or slug among the coalitions of the
+campus, with its top members and your points if you belong to it.
+
+Examples:
+  t42 coalition show 45
+  t42 coalition show "The Order"
+  t42 coalition show federation --top 10`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCoalitionShow,
+}
+
+var coalitionRankCmd = &cobra.Command{
+	Use:     "rank",
+	Aliases: []string{"leaderboard"},
+	Short:   "Leaderboard of the coalitions of a campus",
+	Long: `Rank the coalitions of a campus by score, with the gap to the leader
+and the points you earned for yours.
+
+Examples:
+  t42 coalition rank
+  t42 coalition rank --campus tokyo`,
+	Args: cobra.NoArgs,
+	RunE: runCoalitionRank,
+}
+
+func init() {
+	coalitionCmd.AddCommand(coalitionListCmd)
+	coalitionCmd.AddCommand(coalitionShowCmd)
+	coalitionCmd.AddCommand(coalitionRankCmd)
+	rootCmd.AddCommand(coalitionCmd)
+
+	for _, cmd := range []*cobra.Command{coalitionListCmd, coalitionShowCmd, coalitionRankCmd} {
+		addCampusFlags(cmd)
+		cmd.Flags().Int("cursus-id", 21, "Cursus of the bloc (21 for 42cursus)")
+	}
+	coalitionShowCmd.Flags().Int("top", 5, "Number of top members to show (0 for none)")
+}
+
+// coalitionStanding is a coalition's place in its bloc
+type coalitionStanding struct {
+	Rank int `json:"rank"`
+	api.Coalition
+	// Gap is how many points the coalition is behind the leader
+	Gap  int  `json:"gap"`
+	Mine bool `json:"mine"`
+}
+
+// coalitionMember is a top member of a coalition
+type coalitionMember struct {
+	Rank   int    `json:"rank"`
+	UserID int    `json:"user_id"`
+	Login  string `json:"login,omitempty"`
+	Score  int    `json:"score"`
+}
+
+// campusCoalitions is the bloc of a campus and the logged-in user's
+// membership in it, if any
+type campusCoalitions struct {
+	Campus     *api.Campus
+	Bloc       *api.Bloc
+	Membership *api.CoalitionsUser
+}
+
+func runCoalitionList(cmd *cobra.Command, args []string) error {
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	found, err := fetchCampusCoalitions(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	coalitions := append([]api.Coalition(nil), found.Bloc.Coalitions...)
+	sort.Slice(coalitions, func(i, j int) bool {
+		return strings.ToLower(coalitions[i].Name) < strings.ToLower(coalitions[j].Name)
+	})
+
+	if done, err := emitOutput(
+		output.Field{Key: "campus", Value: found.Campus.Name},
+		output.Field{Key: "bloc_id", Value: found.Bloc.ID},
+		output.Field{Key: "coalitions", Value: coalitions},
+		output.Field{Key: "my_coalition_id", Value: found.myCoalitionID()},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("🛡️  Coalitions at %s\n\n", found.Campus.Name)
+	fmt.Printf("   %-8s %-28s %-20s %s\n", "ID", "NAME", "SLUG", "SCORE")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, c := range coalitions {
+		marker := "  "
+		if c.ID == found.myCoalitionID() {
+			marker = "⭐"
+		}
+		fmt.Printf("%s %-8d %-28s %-20s %d\n", marker, c.ID, truncateString(c.Name, 28), truncateString(c.Slug, 20), c.Score)
+	}
+	return nil
+}
+
+func runCoalitionRank(cmd *cobra.Command, args []string) error {
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	found, err := fetchCampusCoalitions(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	standings := rankCoalitions(found.Bloc.Coalitions, found.myCoalitionID())
+
+	if done, err := emitOutput(
+		output.Field{Key: "campus", Value: found.Campus.Name},
+		output.Field{Key: "standings", Value: standings},
+		output.Field{Key: "my_points", Value: found.Membership},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("🏆 Coalition leaderboard at %s\n\n", found.Campus.Name)
+	if len(standings) == 0 {
+		fmt.Println("No coalitions.")
+		return nil
+	}
+	leader := standings[0].Score
+	fmt.Printf("%-5s %-24s %-10s %-22s %s\n", "RANK", "COALITION", "SCORE", "", "GAP")
+	fmt.Println(strings.Repeat("-", 72))
+	for _, s := range standings {
+		name := truncateString(s.Name, 22)
+		if s.Mine {
+			name += " ⭐"
+		}
+		fraction := 0.0
+		if leader > 0 {
+			fraction = float64(s.Score) / float64(leader)
+		}
+		gap := "-"
+		if s.Gap > 0 {
+			gap = fmt.Sprintf("-%d", s.Gap)
+		}
+		fmt.Printf("#%-4d %-24s %-10d %s  %s\n", s.Rank, name, s.Score, renderBar(fraction, 20), gap)
+	}
+
+	if m := found.Membership; m != nil {
+		fmt.Printf("\n🎖️  Your points: %d", m.Score)
+		if m.Rank > 0 {
+			fmt.Printf(" (#%d in your coalition)", m.Rank)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func runCoalitionShow(cmd *cobra.Command, args []string) error {
+	top, _ := cmd.Flags().GetInt("top")
+	if top < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var coalition *api.Coalition
+	if id, err := strconv.Atoi(args[0]); err == nil {
+		if coalition, err = client.GetCoalition(ctx, id); err != nil {
+			return fmt.Errorf("failed to get coalition %d: %w", id, err)
+		}
+	} else {
+		found, err := fetchCampusCoalitions(ctx, cmd, client)
+		if err != nil {
+			return err
+		}
+		if coalition = findCoalition(found.Bloc.Coalitions, args[0]); coalition == nil {
+			return fmt.Errorf("no coalition %q at %s - see 't42 coalition list'", args[0], found.Campus.Name)
+		}
+	}
+
+	members, err := fetchTopCoalitionMembers(ctx, client, coalition.ID, top)
+	if err != nil {
+		return err
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	memberships, _, err := client.ListCoalitionsUsers(ctx, &api.ListCoalitionsUsersOptions{CoalitionID: coalition.ID, UserID: me.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get your coalition points: %w", err)
+	}
+	var mine *api.CoalitionsUser
+	if len(memberships) > 0 {
+		mine = &memberships[0]
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "coalition", Value: coalition},
+		output.Field{Key: "top_members", Value: members},
+		output.Field{Key: "my_points", Value: mine},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("🛡️  %s (ID: %d)\n", coalition.Name, coalition.ID)
+	fmt.Println(strings.Repeat("=", 40))
+	fmt.Printf("Slug:       %s\n", coalition.Slug)
+	if coalition.Color != "" {
+		fmt.Printf("Color:      %s\n", coalition.Color)
+	}
+	fmt.Printf("Score:      %d\n", coalition.Score)
+	if mine != nil {
+		fmt.Printf("You:        %d points", mine.Score)
+		if mine.Rank > 0 {
+			fmt.Printf(" (#%d)", mine.Rank)
+		}
+		fmt.Println()
+	}
+
+	if len(members) > 0 {
+		fmt.Println("\nTop members:")
+		for _, m := range members {
+			login := m.Login
+			if login == "" {
+				login = fmt.Sprintf("user %d", m.UserID)
+			}
+			fmt.Printf("  #%-3d %-16s %d\n", m.Rank, login, m.Score)
+		}
+	}
+	return nil
+}
+
+// fetchCampusCoalitions finds the bloc of the campus selected with the
+// campus flags (default: your primary campus) for --cursus-id, and your
+// membership in it
+func fetchCampusCoalitions(ctx context.Context, cmd *cobra.Command, client *api.Client) (*campusCoalitions, error) {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get your profile: %w", err)
+	}
+
+	campus, err := resolveCampusFlags(ctx, cmd, client)
+	if err != nil {
+		return nil, err
+	}
+	if campus == nil {
+		if campus = primaryCampus(me); campus == nil {
+			return nil, fmt.Errorf("you have no campus - use --campus or --campus-id")
+		}
+	}
+
+	blocs, err := client.ListBlocs(ctx, &api.ListBlocsOptions{CampusID: campus.ID, CursusID: cursusID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coalition blocs: %w", err)
+	}
+	if len(blocs) == 0 {
+		return nil, fmt.Errorf("%s has no coalitions for cursus %d", campus.Name, cursusID)
+	}
+	found := &campusCoalitions{Campus: campus, Bloc: &blocs[0]}
+
+	memberships, _, err := client.ListCoalitionsUsers(ctx, &api.ListCoalitionsUsersOptions{UserID: me.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get your coalition points: %w", err)
+	}
+	found.Membership = blocMembership(found.Bloc, memberships)
+	return found, nil
+}
+
+// myCoalitionID returns the ID of the user's coalition in the bloc, or 0
+func (c *campusCoalitions) myCoalitionID() int {
+	if c.Membership == nil {
+		return 0
+	}
+	return c.Membership.CoalitionID
+}
+
+// blocMembership returns the membership in one of the bloc's coalitions
+func blocMembership(bloc *api.Bloc, memberships []api.CoalitionsUser) *api.CoalitionsUser {
+	for i := range memberships {
+		for _, c := range bloc.Coalitions {
+			if memberships[i].CoalitionID == c.ID {
+				return &memberships[i]
+			}
+		}
+	}
+	return nil
+}
+
+// rankCoalitions sorts coalitions by score, ties by name, and computes
+// each one's gap to the leader
+func rankCoalitions(coalitions []api.Coalition, mineID int) []coalitionStanding {
+	standings := make([]coalitionStanding, len(coalitions))
+	for i, c := range coalitions {
+		standings[i] = coalitionStanding{Coalition: c, Mine: c.ID == mineID}
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Score != standings[j].Score {
+			return standings[i].Score > standings[j].Score
+		}
+		return standings[i].Name < standings[j].Name
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+		standings[i].Gap = standings[0].Score - standings[i].Score
+	}
+	return standings
+}
+
+// findCoalition matches a coalition by slug or name, ignoring case
+func findCoalition(coalitions []api.Coalition, query string) *api.Coalition {
+	query = strings.ToLower(strings.TrimSpace(query))
+	for i := range coalitions {
+		if strings.ToLower(coalitions[i].Slug) == query || strings.ToLower(coalitions[i].Name) == query {
+			return &coalitions[i]
+		}
+	}
+	return nil
+}
+
+// fetchTopCoalitionMembers returns the n highest scoring members of a
+// coalition with their logins
+func fetchTopCoalitionMembers(ctx context.Context, client *api.Client, coalitionID, n int) ([]coalitionMember, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	memberships, _, err := client.ListCoalitionsUsers(ctx, &api.ListCoalitionsUsersOptions{
+		CoalitionID: coalitionID,
+		Sort:        "-score",
+		PerPage:     n,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coalition members: %w", err)
+	}
+
+	// Memberships only carry user IDs; a missing login is not worth failing for
+	members := runWorkers(memberships, coalitionMemberWorkers, func(m api.CoalitionsUser) coalitionMember {
+		member := coalitionMember{Rank: m.Rank, UserID: m.UserID, Score: m.Score}
+		if user, err := client.GetUser(ctx, m.UserID); err == nil {
+			member.Login = user.Login
+		} else {
+			warnf("user_unnamed", "failed to get the login of user %d: %v", m.UserID, err)
+		}
+		return member
+	})
+	for i := range members {
+		if members[i].Rank == 0 {
+			members[i].Rank = i + 1
+		}
+	}
+	return members, nil
+}
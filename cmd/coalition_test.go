@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestRankCoalitions(t *testing.T) {
+	coalitions := []api.Coalition{
+		{ID: 1, Name: "Air", Score: 900},
+		{ID: 2, Name: "Water", Score: 1200},
+		{ID: 3, Name: "Earth", Score: 900},
+		{ID: 4, Name: "Fire", Score: 1500},
+	}
+
+	standings := rankCoalitions(coalitions, 3)
+
+	want := []struct {
+		id, rank, gap int
+		mine          bool
+	}{
+		{4, 1, 0, false},
+		{2, 2, 300, false},
+		{1, 3, 600, false}, // ties by name: Air before Earth
+		{3, 4, 600, true},
+	}
+	if len(standings) != len(want) {
+		t.Fatalf("rankCoalitions() returned %d standings, want %d", len(standings), len(want))
+	}
+	for i, w := range want {
+		s := standings[i]
+		if s.ID != w.id || s.Rank != w.rank || s.Gap != w.gap || s.Mine != w.mine {
+			t.Errorf("standing %d = {id %d rank %d gap %d mine %v}, want %+v", i, s.ID, s.Rank, s.Gap, s.Mine, w)
+		}
+	}
+}
+
+func TestFindCoalition(t *testing.T) {
+	coalitions := []api.Coalition{
+		{ID: 1, Name: "The Order", Slug: "the-order"},
+		{ID: 2, Name: "The Federation", Slug: "federation"},
+	}
+
+	tests := []struct {
+		query  string
+		wantID int
+	}{
+		{"the order", 1},
+		{"FEDERATION", 2},
+		{" the-order ", 1},
+		{"alliance", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := findCoalition(coalitions, tt.query)
+			gotID := 0
+			if got != nil {
+				gotID = got.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("findCoalition(%q) = %d, want %d", tt.query, gotID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestBlocMembership(t *testing.T) {
+	bloc := &api.Bloc{Coalitions: []api.Coalition{{ID: 1}, {ID: 2}}}
+	memberships := []api.CoalitionsUser{{CoalitionID: 9, Score: 5}, {CoalitionID: 2, Score: 120}}
+
+	if got := blocMembership(bloc, memberships); got == nil || got.Score != 120 {
+		t.Errorf("blocMembership() = %+v, want the membership in coalition 2", got)
+	}
+	if got := blocMembership(bloc, memberships[:1]); got != nil {
+		t.Errorf("blocMembership() = %+v, want nil for another bloc", got)
+	}
+}
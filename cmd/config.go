@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration commands",
+	Long:  `Manage the t42 configuration file (config.yaml).`,
+}
+
+var configAliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage shorthand aliases",
+	Long:  `Manage shorthand aliases resolved by other commands.`,
+}
+
+var configAliasProjectCmd = &cobra.Command{
+	Use:   "project [alias] [slug]",
+	Short: "Manage project slug aliases",
+	Long: `Manage project slug aliases used by project show, clone, clone-mine,
+feedbacks and user eligible.
+
+Without arguments, all aliases (configured and built-in) are listed.
+With an alias only, the slug it resolves to is shown. With an alias and
+a slug, the alias is saved to the config file; configured aliases take
+precedence over the built-in shorthands.
+
+Examples:
+  t42 config alias project
+  t42 config alias project gnl get_next_line
+  t42 config alias project tc --remove`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: runConfigAliasProject,
+}
+
+func init() {
+	configAliasCmd.AddCommand(configAliasProjectCmd)
+	configCmd.AddCommand(configAliasCmd)
+
+	// Add config command to root
+	rootCmd.AddCommand(configCmd)
+
+	configAliasProjectCmd.Flags().Bool("remove", false, "Remove the alias from the config file")
+}
+
+func runConfigAliasProject(cmd *cobra.Command, args []string) error {
+	remove, _ := cmd.Flags().GetBool("remove")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	switch {
+	case remove:
+		if len(args) != 1 {
+			return fmt.Errorf("--remove takes exactly one alias")
+		}
+		alias := strings.ToLower(args[0])
+		if _, ok := cfg.ProjectAliases[alias]; !ok {
+			return fmt.Errorf("project alias %q is not defined in the config file", alias)
+		}
+		delete(cfg.ProjectAliases, alias)
+		if err := config.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		if GetJSONOutput() {
+			if err := writeOutputValue(map[string]interface{}{"success": true, "removed": alias}); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("✅ Removed project alias %s\n", alias)
+		}
+		return nil
+
+	case len(args) == 2:
+		alias := strings.ToLower(args[0])
+		slug := strings.TrimSpace(args[1])
+		if strings.ContainsAny(alias, " \t") || alias == "" {
+			return fmt.Errorf("invalid alias %q", args[0])
+		}
+		if slug == "" {
+			return fmt.Errorf("slug must not be empty")
+		}
+		if cfg.ProjectAliases == nil {
+			cfg.ProjectAliases = make(map[string]string)
+		}
+		cfg.ProjectAliases[alias] = slug
+		if err := config.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		if GetJSONOutput() {
+			if err := writeOutputValue(map[string]interface{}{"success": true, "alias": alias, "slug": slug}); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("✅ %s → %s\n", alias, slug)
+		}
+		return nil
+
+	case len(args) == 1:
+		slug := lookupProjectAlias(args[0], cfg.ProjectAliases)
+		if GetJSONOutput() {
+			if err := writeOutputValue(map[string]string{"alias": args[0], "slug": slug}); err != nil {
+				return err
+			}
+		} else if slug == args[0] {
+			fmt.Printf("%s is not an alias\n", args[0])
+		} else {
+			fmt.Println(slug)
+		}
+		return nil
+	}
+
+	aliases := listProjectAliases(cfg.ProjectAliases)
+	if done, err := emitOutputValue(aliases); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("%-16s %-24s %s\n", "ALIAS", "SLUG", "SOURCE")
+	fmt.Println(strings.Repeat("-", 50))
+	for _, a := range aliases {
+		fmt.Printf("%-16s %-24s %s\n", a.Alias, truncateString(a.Slug, 24), a.Source)
+	}
+	return nil
+}
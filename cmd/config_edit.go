@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in your editor",
+	Long: `Open config.yaml in $VISUAL or $EDITOR (vi by default, notepad on
+Windows), creating it with the defaults when missing. The file is checked
+once the editor exits.
+
+Examples:
+  t42 config edit
+  EDITOR=nano t42 config edit`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEdit,
+}
+
+func init() {
+	configCmd.AddCommand(configEditCmd)
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	path, err := config.GetConfigFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get config file path: %w", err)
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := config.SaveConfig(config.DefaultConfig()); err != nil {
+			return fmt.Errorf("failed to create config file: %w", err)
+		}
+	}
+
+	editor, err := fileEditorCommand(runtime.GOOS, os.Getenv("VISUAL"), os.Getenv("EDITOR"))
+	if err != nil {
+		return err
+	}
+	edit := exec.Command(editor[0], append(editor[1:], path)...)
+	edit.Stdin, edit.Stdout, edit.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := edit.Run(); err != nil {
+		return fmt.Errorf("failed to run editor %s: %w", editor[0], err)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("%s is not valid anymore: %w", path, err)
+	}
+	for _, e := range cfg.Entries() {
+		if err := validateConfigValue(e.Key, e.Value); err != nil {
+			return fmt.Errorf("%s is not valid anymore: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// fileEditorCommand splits $VISUAL, else $EDITOR, into a command and its
+// arguments, falling back to the platform's basic editor
+func fileEditorCommand(goos, visual, editor string) ([]string, error) {
+	for _, env := range []struct{ name, value string }{{"$VISUAL", visual}, {"$EDITOR", editor}} {
+		if env.value == "" {
+			continue
+		}
+		args, err := splitCommandLine(env.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", env.name, err)
+		}
+		if len(args) > 0 {
+			return args, nil
+		}
+	}
+	if goos == "windows" {
+		return []string{"notepad"}, nil
+	}
+	return []string{"vi"}, nil
+}
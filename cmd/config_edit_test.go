@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFileEditorCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		goos    string
+		visual  string
+		editor  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "visual first", goos: "linux", visual: "code --wait", editor: "nano", want: []string{"code", "--wait"}},
+		{name: "editor", goos: "linux", editor: "nano", want: []string{"nano"}},
+		{name: "default", goos: "darwin", want: []string{"vi"}},
+		{name: "windows default", goos: "windows", want: []string{"notepad"}},
+		{name: "invalid", goos: "linux", visual: `code "--wait`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fileEditorCommand(tt.goos, tt.visual, tt.editor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fileEditorCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fileEditorCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
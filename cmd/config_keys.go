@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a value of the config file",
+	Long: `Print the value of a key of config.yaml. Nested keys are joined with
+dots, such as rate_limit.per_hour or campus_aliases.home; a key holding
+several values prints each as key=value.
+
+Exits with status 1 when the key is not set.
+
+Examples:
+  t42 config get default_format
+  t42 config get rate_limit`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKeys,
+	RunE:              runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a value in the config file",
+	Long: `Set a key of config.yaml, like git config. Values are checked against
+the type of the key: true or false, numbers, formats and durations.
+
+Examples:
+  t42 config set default_format yaml
+  t42 config set pager "less -R"
+  t42 config set rate_limit.per_hour 2400
+  t42 config set campus_aliases.home tokyo`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeConfigKeys,
+	RunE:              runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a value from the config file",
+	Long: `Remove a key from config.yaml, so its default applies again.
+
+Examples:
+  t42 config unset default_format
+  t42 config unset campus_aliases.home`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKeys,
+	RunE:              runConfigUnset,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the values of the config file",
+	Long: `List the values set in config.yaml as key=value, defaults included.
+With --keys, list the keys that can be set instead; * stands for a name of
+your own, such as an alias.
+
+Examples:
+  t42 config list
+  t42 config list --keys`,
+	Args: cobra.NoArgs,
+	RunE: runConfigList,
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+
+	configListCmd.Flags().Bool("keys", false, "List the keys that can be set")
+}
+
+// configValidators check the values of keys beyond their type; * matches
+// any name
+var configValidators = map[string]func(string) error{
+	"default_format": func(s string) error {
+		_, err := output.ParseFormat(s)
+		return err
+	},
+	"profiles.*.format": func(s string) error {
+		_, err := output.ParseFormat(s)
+		return err
+	},
+	"api_base_url": func(s string) error {
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid URL %q", s)
+		}
+		return nil
+	},
+	"credentials_backend":      oneOf(config.CredentialsBackendAuto, config.CredentialsBackendKeyring, config.CredentialsBackendFile),
+	"logtime_goal":             validDuration,
+	"cache_ttl.*":              validDuration,
+	"notify.interval":          validDuration,
+	"notify.evaluation_within": validDuration,
+	"notify.webhook_format":    oneOf("auto", "slack", "discord", "json"),
+	"pager": func(s string) error {
+		_, err := splitCommandLine(s)
+		return err
+	},
+}
+
+func validDuration(s string) error {
+	if _, err := time.ParseDuration(s); err != nil {
+		return fmt.Errorf("invalid duration %q (e.g. 30m or 48h)", s)
+	}
+	return nil
+}
+
+func oneOf(values ...string) func(string) error {
+	return func(s string) error {
+		for _, v := range values {
+			if s == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid value %q (use %s)", s, strings.Join(values, ", "))
+	}
+}
+
+// validateConfigValue checks a value about to be set under key
+func validateConfigValue(key, value string) error {
+	segments := strings.Split(key, ".")
+	for pattern, validate := range configValidators {
+		if matchConfigKey(strings.Split(pattern, "."), segments) {
+			if err := validate(value); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matchConfigKey reports whether key segments match a pattern of
+// config.Keys
+func matchConfigKey(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var keys []string
+	for _, k := range config.Keys() {
+		if !strings.Contains(k, "*") {
+			keys = append(keys, k)
+		}
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	entries, err := cfg.Get(args[0])
+	if err != nil {
+		return err
+	}
+	if done, err := emitOutputValue(entries); done || err != nil {
+		return err
+	}
+	if len(entries) == 0 || (len(entries) == 1 && entries[0].Value == "") {
+		return exitWithCode(cmd, 1)
+	}
+
+	if len(entries) == 1 && entries[0].Key == args[0] {
+		fmt.Println(entries[0].Value)
+		return nil
+	}
+	printConfigEntries(entries)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+	if err := validateConfigValue(key, value); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.Set(key, value); err != nil {
+		return err
+	}
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if GetJSONOutput() {
+		return writeOutputValue(map[string]interface{}{"success": true, "key": key, "value": value})
+	}
+	fmt.Printf("✅ %s = %s\n", key, value)
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.Unset(key); err != nil {
+		return err
+	}
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if GetJSONOutput() {
+		return writeOutputValue(map[string]interface{}{"success": true, "removed": key})
+	}
+	fmt.Printf("✅ Unset %s\n", key)
+	return nil
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	if keysOnly, _ := cmd.Flags().GetBool("keys"); keysOnly {
+		keys := config.Keys()
+		if done, err := emitOutputValue(keys); done || err != nil {
+			return err
+		}
+		for _, k := range keys {
+			fmt.Println(k)
+		}
+		return nil
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	entries := cfg.Entries()
+	if done, err := emitOutputValue(entries); done || err != nil {
+		return err
+	}
+	printConfigEntries(entries)
+	return nil
+}
+
+func printConfigEntries(entries []config.Entry) {
+	for _, e := range entries {
+		fmt.Printf("%s=%s\n", e.Key, e.Value)
+	}
+}
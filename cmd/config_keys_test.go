@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestValidateConfigValue(t *testing.T) {
+	tests := []struct {
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{key: "default_format", value: "yaml"},
+		{key: "default_format", value: "xml", wantErr: true},
+		{key: "profiles.script.format", value: "tsv"},
+		{key: "profiles.script.format", value: "html", wantErr: true},
+		{key: "api_base_url", value: "https://api.intra.42.fr"},
+		{key: "api_base_url", value: "api.intra.42.fr", wantErr: true},
+		{key: "credentials_backend", value: "keyring"},
+		{key: "credentials_backend", value: "vault", wantErr: true},
+		{key: "cache_ttl.campus", value: "48h"},
+		{key: "cache_ttl.campus", value: "2 days", wantErr: true},
+		{key: "notify.webhook_format", value: "slack"},
+		{key: "campus_aliases.home", value: "anything goes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			err := validateConfigValue(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfigValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigSetUnset(t *testing.T) {
+	t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+
+	var err error
+	captureStdout(t, func() { err = runConfigSet(configSetCmd, []string{"rate_limit.per_hour", "2400"}) })
+	if err != nil {
+		t.Fatalf("config set error = %v", err)
+	}
+	got := captureStdout(t, func() { err = runConfigGet(configGetCmd, []string{"rate_limit.per_hour"}) })
+	if err != nil {
+		t.Fatalf("config get error = %v", err)
+	}
+	if got != "2400\n" {
+		t.Errorf("config get = %q, want %q", got, "2400\n")
+	}
+
+	captureStdout(t, func() { err = runConfigUnset(configUnsetCmd, []string{"rate_limit.per_hour"}) })
+	if err != nil {
+		t.Fatalf("config unset error = %v", err)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.RateLimit.PerHour != 0 {
+		t.Errorf("rate_limit.per_hour = %d after unset", cfg.RateLimit.PerHour)
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var cursusCmd = &cobra.Command{
+	Use:   "cursus",
+	Short: "Information about cursuses",
+	Long:  `Information about cursuses, such as the experience needed for each level.`,
+}
+
+var cursusLevelsCmd = &cobra.Command{
+	Use:   "levels",
+	Short: "Show the XP required for each level of a cursus",
+	Long: `Show the experience required to reach each level of a cursus, and where
+you stand: your level, the XP you have and the XP left to the next level.
+
+Without --cursus-id, your current cursus is used.
+
+Examples:
+  t42 cursus levels
+  t42 cursus levels --cursus-id 9
+  t42 cursus levels --json`,
+	Args: cobra.NoArgs,
+	RunE: runCursusLevels,
+}
+
+func init() {
+	cursusCmd.AddCommand(cursusLevelsCmd)
+	rootCmd.AddCommand(cursusCmd)
+
+	cursusLevelsCmd.Flags().Int("cursus-id", 0, "Cursus to show (default: your current cursus)")
+}
+
+// levelPosition is where a user stands in the levels of a cursus
+type levelPosition struct {
+	Level      float64 `json:"level"`
+	XP         int     `json:"xp"`
+	NextLevel  int     `json:"next_level"`
+	NextXP     int     `json:"next_level_xp"`
+	XPToNext   int     `json:"xp_to_next"`
+	MaxReached bool    `json:"max_reached,omitempty"`
+}
+
+func runCursusLevels(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	cursusUser := findCursusUser(me.CursusUsers, cursusID)
+	if cursusID == 0 {
+		if cursusUser == nil {
+			return fmt.Errorf("you have no cursus - use --cursus-id")
+		}
+		cursusID = cursusUser.Cursus.ID
+	}
+
+	levels, err := client.ListCursusLevels(ctx, cursusID)
+	if err != nil {
+		return fmt.Errorf("failed to get the levels of cursus %d: %w", cursusID, err)
+	}
+	if len(levels) == 0 {
+		return fmt.Errorf("cursus %d has no levels", cursusID)
+	}
+
+	var position *levelPosition
+	if cursusUser != nil {
+		position = positionInLevels(levels, cursusUser.Level)
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "cursus_id", Value: cursusID},
+		output.Field{Key: "levels", Value: levels},
+		output.Field{Key: "you", Value: position},
+	); done || err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("cursus %d", cursusID)
+	if cursusUser != nil && cursusUser.Cursus.Name != "" {
+		name = cursusUser.Cursus.Name
+	}
+	fmt.Printf("📈 Levels of %s\n\n", name)
+
+	fmt.Printf("%-6s %10s %10s\n", "LEVEL", "XP", "TO NEXT")
+	fmt.Println(strings.Repeat("-", 28))
+	for i, l := range levels {
+		toNext := "-"
+		if i+1 < len(levels) {
+			toNext = fmt.Sprintf("%d", levels[i+1].XP-l.XP)
+		}
+		line := fmt.Sprintf("%-6d %10d %10s", l.Lvl, l.XP, toNext)
+		if position != nil && int(position.Level) == l.Lvl {
+			line += "  ◀ " + formatLevelPosition(position)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// formatLevelPosition describes a position as "you: 12.45 (45% to 13, 4210 XP left)"
+func formatLevelPosition(p *levelPosition) string {
+	if p.MaxReached {
+		return fmt.Sprintf("you: %.2f (highest level)", p.Level)
+	}
+	percent := int(math.Round((p.Level - math.Floor(p.Level)) * 100))
+	return fmt.Sprintf("you: %.2f (%d%% to %d, %d XP left)", p.Level, percent, p.NextLevel, p.XPToNext)
+}
+
+// levelThreshold returns the XP needed to reach a level
+func levelThreshold(levels []api.Level, lvl int) (int, bool) {
+	for _, l := range levels {
+		if l.Lvl == lvl {
+			return l.XP, true
+		}
+	}
+	return 0, false
+}
+
+// levelXP converts a fractional level such as 12.45 into experience
+// points, interpolating between the thresholds of levels 12 and 13 like
+// the intra does
+func levelXP(levels []api.Level, level float64) (int, bool) {
+	whole := int(math.Floor(level))
+	base, ok := levelThreshold(levels, whole)
+	if !ok {
+		return 0, false
+	}
+	next, ok := levelThreshold(levels, whole+1)
+	if !ok {
+		return base, true
+	}
+	return base + int(math.Round((level-float64(whole))*float64(next-base))), true
+}
+
+// positionInLevels returns where a level stands among the thresholds of
+// a cursus, or nil when the level is outside of them
+func positionInLevels(levels []api.Level, level float64) *levelPosition {
+	xp, ok := levelXP(levels, level)
+	if !ok {
+		return nil
+	}
+	position := &levelPosition{Level: level, XP: xp, NextLevel: int(math.Floor(level)) + 1}
+	next, ok := levelThreshold(levels, position.NextLevel)
+	if !ok {
+		position.MaxReached = true
+		position.NextLevel = 0
+		return position
+	}
+	position.NextXP = next
+	position.XPToNext = next - xp
+	return position
+}
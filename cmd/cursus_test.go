@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+var testLevels = []api.Level{
+	{Lvl: 0, XP: 0},
+	{Lvl: 1, XP: 462},
+	{Lvl: 2, XP: 2688},
+	{Lvl: 3, XP: 5885},
+}
+
+func TestLevelXP(t *testing.T) {
+	tests := []struct {
+		level  float64
+		want   int
+		wantOK bool
+	}{
+		{0, 0, true},
+		{1, 462, true},
+		{1.5, 1575, true},
+		{3.2, 5885, true}, // highest level: no next threshold
+		{7, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.level), func(t *testing.T) {
+			got, ok := levelXP(testLevels, tt.level)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("levelXP(%v) = %d, %v, want %d, %v", tt.level, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPositionInLevels(t *testing.T) {
+	p := positionInLevels(testLevels, 1.5)
+	if p == nil || p.XP != 1575 || p.NextLevel != 2 || p.NextXP != 2688 || p.XPToNext != 1113 {
+		t.Fatalf("positionInLevels(1.5) = %+v", p)
+	}
+	if got := formatLevelPosition(p); got != "you: 1.50 (50% to 2, 1113 XP left)" {
+		t.Errorf("formatLevelPosition() = %q", got)
+	}
+
+	top := positionInLevels(testLevels, 3.2)
+	if top == nil || !top.MaxReached {
+		t.Fatalf("positionInLevels(3.2) = %+v, want the highest level reached", top)
+	}
+	if positionInLevels(testLevels, 9) != nil {
+		t.Error("positionInLevels() of an unknown level is not nil")
+	}
+}
@@ -0,0 +1,343 @@
+//go:build !nogui
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+const (
+	// dashboardMinRefresh keeps the dashboard well inside the API rate limit
+	dashboardMinRefresh = time.Minute
+
+	// dashboardClock re-renders countdowns between two refreshes
+	dashboardClock = 30 * time.Second
+
+	// dashboardMaxEvaluations is how many upcoming evaluations are shown
+	dashboardMaxEvaluations = 5
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Full-screen overview of your level, blackhole, projects and evaluations",
+	Long: `Open a full-screen dashboard showing your level, blackhole countdown,
+active projects, upcoming evaluations and correction points. The data is
+refreshed periodically; press r to refresh now and q to quit.
+
+With --json, or with plain output (--accessible, --no-color), a single
+snapshot is printed instead.
+
+Examples:
+  t42 dashboard
+  t42 dashboard --refresh 10m
+  t42 dashboard --json`,
+	Args: cobra.NoArgs,
+	RunE: runDashboard,
+}
+
+// dashboardProject is an active project on the dashboard
+type dashboardProject struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// dashboardData is everything the dashboard shows
+type dashboardData struct {
+	Login            string             `json:"login"`
+	Cursus           string             `json:"cursus"`
+	Level            float64            `json:"level"`
+	BlackholedAt     *time.Time         `json:"blackholed_at"`
+	CorrectionPoints int                `json:"correction_points"`
+	Wallet           int                `json:"wallet"`
+	Projects         []dashboardProject `json:"projects"`
+	Evaluations      []evalEntry        `json:"evaluations"`
+	FetchedAt        time.Time          `json:"fetched_at"`
+}
+
+func init() {
+	rootCmd.AddCommand(dashboardCmd)
+
+	dashboardCmd.Flags().Duration("refresh", 5*time.Minute, "Time between two refreshes (at least 1m)")
+	dashboardCmd.Flags().Int("cursus-id", 0, "Cursus to show (default: your current cursus)")
+}
+
+func runDashboard(cmd *cobra.Command, args []string) error {
+	refresh, _ := cmd.Flags().GetDuration("refresh")
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+
+	if refresh < dashboardMinRefresh {
+		return fmt.Errorf("--refresh must be at least %s", dashboardMinRefresh)
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	fetch := func(ctx context.Context) (*dashboardData, error) {
+		return fetchDashboard(ctx, client, cursusID)
+	}
+
+	if GetJSONOutput() || GetPlainOutput() || outFile != "" {
+		data, err := fetch(context.Background())
+		if err != nil {
+			return err
+		}
+		if done, err := emitOutput(output.Field{Key: "dashboard", Value: data}); done || err != nil {
+			return err
+		}
+		fmt.Println(renderDashboard(data, time.Now(), 0))
+		return nil
+	}
+
+	model := newDashboardModel(fetch, refresh)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("failed to run dashboard: %w", err)
+	}
+	return nil
+}
+
+// fetchDashboard collects the dashboard data of the logged-in user
+func fetchDashboard(ctx context.Context, client *api.Client, cursusID int) (*dashboardData, error) {
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	now := time.Now()
+	scaleTeams, err := fetchMyScaleTeams(ctx, client, now, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	projects := resolveProjectNames(ctx, client, scaleTeamProjectIDs(scaleTeams))
+	evaluations := buildEvalEntries(scaleTeams, me.ID, projects, now)
+
+	return buildDashboardData(me, cursusID, evaluations, now), nil
+}
+
+// buildDashboardData assembles the dashboard from the user and their
+// upcoming evaluations
+func buildDashboardData(me *api.User, cursusID int, evaluations []evalEntry, now time.Time) *dashboardData {
+	data := &dashboardData{
+		Login:            me.Login,
+		CorrectionPoints: me.CorrectionPoint,
+		Wallet:           me.Wallet,
+		Projects:         make([]dashboardProject, 0),
+		Evaluations:      make([]evalEntry, 0),
+		FetchedAt:        now,
+	}
+
+	if cu := findCursusUser(me.CursusUsers, cursusID); cu != nil {
+		data.Cursus = cu.Cursus.Name
+		data.Level = cu.Level
+		data.BlackholedAt = cu.BlackholedAt
+	}
+
+	for _, pu := range me.ProjectsUsers {
+		switch pu.Status {
+		case "in_progress", "waiting_for_correction", "creating_group", "searching_a_group":
+			data.Projects = append(data.Projects, dashboardProject{
+				Name:      pu.Project.Name,
+				Status:    pu.Status,
+				UpdatedAt: pu.UpdatedAt,
+			})
+		}
+	}
+	sort.Slice(data.Projects, func(i, j int) bool {
+		return data.Projects[i].UpdatedAt.After(data.Projects[j].UpdatedAt)
+	})
+
+	for _, e := range evaluations {
+		if e.Status == "upcoming" && len(data.Evaluations) < dashboardMaxEvaluations {
+			data.Evaluations = append(data.Evaluations, e)
+		}
+	}
+	return data
+}
+
+// dashboardLoadedMsg carries the result of a fetch
+type dashboardLoadedMsg struct {
+	data *dashboardData
+	err  error
+}
+
+// dashboardRefreshMsg asks for a new fetch
+type dashboardRefreshMsg struct{}
+
+// dashboardClockMsg re-renders the countdowns
+type dashboardClockMsg struct{}
+
+// dashboardModel is the bubbletea model of the dashboard
+type dashboardModel struct {
+	fetch    func(ctx context.Context) (*dashboardData, error)
+	interval time.Duration
+	now      func() time.Time
+
+	data    *dashboardData
+	err     error // error of the last fetch; older data stays on screen
+	loading bool
+	width   int
+}
+
+func newDashboardModel(fetch func(ctx context.Context) (*dashboardData, error), interval time.Duration) dashboardModel {
+	return dashboardModel{fetch: fetch, interval: interval, now: time.Now, loading: true}
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.load(), dashboardTick(dashboardClock, dashboardClockMsg{}))
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "r":
+			if !m.loading {
+				m.loading = true
+				return m, m.load()
+			}
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case dashboardLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.data = msg.data
+		}
+		return m, dashboardTick(m.interval, dashboardRefreshMsg{})
+	case dashboardRefreshMsg:
+		if !m.loading {
+			m.loading = true
+			return m, m.load()
+		}
+	case dashboardClockMsg:
+		return m, dashboardTick(dashboardClock, dashboardClockMsg{})
+	}
+	return m, nil
+}
+
+func (m dashboardModel) View() string {
+	var b strings.Builder
+	if m.data != nil {
+		b.WriteString(renderDashboard(m.data, m.now(), m.width))
+		b.WriteString("\n")
+	}
+
+	status := dashboardMutedStyle
+	switch {
+	case m.loading:
+		b.WriteString(status.Render("⏳ Loading..."))
+	case m.err != nil:
+		b.WriteString(dashboardAlertStyle.Render("⚠️  Refresh failed: " + m.err.Error()))
+	case m.data != nil:
+		b.WriteString(status.Render("Updated " + m.data.FetchedAt.Local().Format("15:04:05")))
+	}
+	b.WriteString(status.Render("  •  r refresh  •  q quit"))
+	return b.String()
+}
+
+// load fetches the dashboard data in the background
+func (m dashboardModel) load() tea.Cmd {
+	return func() tea.Msg {
+		data, err := m.fetch(context.Background())
+		return dashboardLoadedMsg{data: data, err: err}
+	}
+}
+
+// dashboardTick sends msg after d
+func dashboardTick(d time.Duration, msg tea.Msg) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return msg })
+}
+
+var (
+	dashboardTitleStyle = lipgloss.NewStyle().Bold(true)
+	dashboardMutedStyle = lipgloss.NewStyle().Faint(true)
+	dashboardAlertStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	dashboardPanelStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+)
+
+// renderDashboard lays out the dashboard panels; panels are stacked when
+// width is too small to put the summary side by side (0 means unknown)
+func renderDashboard(d *dashboardData, now time.Time, width int) string {
+	title := dashboardTitleStyle.Render(fmt.Sprintf("📊 %s", d.Login))
+	if d.Cursus != "" {
+		title += dashboardMutedStyle.Render(" · " + d.Cursus)
+	}
+
+	level := dashboardPanel("🎓 Level", fmt.Sprintf("%.2f\n%s", d.Level, renderBar(d.Level-float64(int(d.Level)), 16)))
+	blackhole := dashboardPanel("🕳️  Blackhole", formatBlackholeCountdown(d.BlackholedAt, now))
+	points := dashboardPanel("⚡ Points", fmt.Sprintf("%d correction\n%d wallet", d.CorrectionPoints, d.Wallet))
+
+	summary := lipgloss.JoinHorizontal(lipgloss.Top, level, blackhole, points)
+	if width > 0 && lipgloss.Width(summary) > width {
+		summary = lipgloss.JoinVertical(lipgloss.Left, level, blackhole, points)
+	}
+
+	var projects []string
+	for _, p := range d.Projects {
+		projects = append(projects, fmt.Sprintf("%-28s %s", truncateString(p.Name, 28), strings.ReplaceAll(p.Status, "_", " ")))
+	}
+	if len(projects) == 0 {
+		projects = append(projects, dashboardMutedStyle.Render("No active projects"))
+	}
+
+	var evaluations []string
+	for _, e := range d.Evaluations {
+		role := "giving"
+		if e.Role == "corrected" {
+			role = "receiving"
+		}
+		startsIn := "now"
+		if e.BeginAt.After(now) {
+			startsIn = formatTimeUntil(e.BeginAt.Sub(now))
+		}
+		evaluations = append(evaluations, fmt.Sprintf("%-10s %-16s %-9s %s",
+			startsIn, e.BeginAt.Local().Format("Mon Jan 02 15:04"), role, truncateString(e.Project, 24)))
+	}
+	if len(evaluations) == 0 {
+		evaluations = append(evaluations, dashboardMutedStyle.Render("No upcoming evaluations"))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		summary,
+		dashboardPanel("🎯 Active projects", strings.Join(projects, "\n")),
+		dashboardPanel("📝 Upcoming evaluations", strings.Join(evaluations, "\n")),
+	)
+}
+
+// dashboardPanel renders a titled, bordered panel
+func dashboardPanel(title, body string) string {
+	return dashboardPanelStyle.Render(dashboardTitleStyle.Render(title) + "\n" + body)
+}
+
+// formatBlackholeCountdown describes the time left before the blackhole
+func formatBlackholeCountdown(blackholedAt *time.Time, now time.Time) string {
+	if blackholedAt == nil {
+		return "none"
+	}
+	left := blackholedAt.Sub(now)
+	if left <= 0 {
+		return dashboardAlertStyle.Render("blackholed")
+	}
+	days := int(left.Hours() / 24)
+	text := fmt.Sprintf("%s %d days\n%s", blackholeUrgency(days), days, blackholedAt.Local().Format("Jan 02 2006"))
+	if days <= 7 {
+		return dashboardAlertStyle.Render(text)
+	}
+	return text
+}
@@ -0,0 +1,96 @@
+//go:build !nogui
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestBuildDashboardData(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	blackhole := now.Add(20 * 24 * time.Hour)
+	me := &api.User{
+		Login:           "me",
+		CorrectionPoint: 4,
+		Wallet:          120,
+		CursusUsers: []api.CursusUser{
+			{Level: 3.5, BlackholedAt: &blackhole, Cursus: api.Cursus{ID: 21, Name: "42cursus"}},
+		},
+		ProjectsUsers: []api.ProjectUser{
+			{Status: "finished", Project: api.Project{Name: "libft"}},
+			{Status: "in_progress", Project: api.Project{Name: "minishell"}, UpdatedAt: now.Add(-time.Hour)},
+			{Status: "waiting_for_correction", Project: api.Project{Name: "philosophers"}, UpdatedAt: now},
+		},
+	}
+	var evaluations []evalEntry
+	for i := 0; i < dashboardMaxEvaluations+2; i++ {
+		evaluations = append(evaluations, evalEntry{ID: i, Status: "upcoming"})
+	}
+	evaluations = append(evaluations, evalEntry{ID: 99, Status: "done"})
+
+	data := buildDashboardData(me, 21, evaluations, now)
+
+	if data.Cursus != "42cursus" || data.Level != 3.5 || data.BlackholedAt == nil {
+		t.Errorf("cursus = %q, level = %v, blackhole = %v", data.Cursus, data.Level, data.BlackholedAt)
+	}
+	if len(data.Projects) != 2 || data.Projects[0].Name != "philosophers" {
+		t.Errorf("projects = %+v, want philosophers then minishell", data.Projects)
+	}
+	if len(data.Evaluations) != dashboardMaxEvaluations {
+		t.Errorf("got %d evaluations, want %d", len(data.Evaluations), dashboardMaxEvaluations)
+	}
+
+	view := renderDashboard(data, now, 0)
+	for _, want := range []string{"me", "3.50", "20 days", "minishell", "4 correction"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("dashboard view does not contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestDashboardModelUpdate(t *testing.T) {
+	data := &dashboardData{Login: "me", FetchedAt: time.Now()}
+	m := newDashboardModel(func(ctx context.Context) (*dashboardData, error) { return data, nil }, time.Minute)
+
+	tests := []struct {
+		name        string
+		msg         tea.Msg
+		wantLoading bool
+		wantData    bool
+		wantErr     bool
+	}{
+		{"loaded", dashboardLoadedMsg{data: data}, false, true, false},
+		{"refresh key", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}, true, true, false},
+		{"failed refresh keeps data", dashboardLoadedMsg{err: errors.New("boom")}, false, true, true},
+		{"timer", dashboardRefreshMsg{}, true, true, true},
+	}
+
+	var model tea.Model = m
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cmd tea.Cmd
+			model, cmd = model.Update(tt.msg)
+			got := model.(dashboardModel)
+			if got.loading != tt.wantLoading || (got.data != nil) != tt.wantData || (got.err != nil) != tt.wantErr {
+				t.Errorf("loading = %v, data = %v, err = %v", got.loading, got.data != nil, got.err)
+			}
+			if cmd == nil {
+				t.Error("expected a follow-up command")
+			}
+		})
+	}
+
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd == nil {
+		t.Error("q did not quit")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q did not quit")
+	}
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// debugHTTP logs every API request and response on stderr
+var debugHTTP bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&debugHTTP, "debug-http", false, "Log every API request and response on stderr: URL, status, timings and headers, with the token redacted")
+}
+
+// debugHTTPOptions returns the request logging of --debug-http. It goes
+// last, so that it logs the failures T42_CHAOS injects too.
+func debugHTTPOptions() []api.ClientOption {
+	if !debugHTTP {
+		return nil
+	}
+	return []api.ClientOption{api.WithHTTPDebug(os.Stderr)}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// resolveCampusOrDefault is resolveCampusFlags falling back to
+// default_campus from the config file; it returns nil when neither is set
+func resolveCampusOrDefault(ctx context.Context, cmd *cobra.Command, client *api.Client) (*api.Campus, error) {
+	found, err := resolveCampusFlags(ctx, cmd, client)
+	if err != nil || found != nil {
+		return found, err
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil || cfg.DefaultCampus == "" {
+		return nil, nil
+	}
+	found, err = newCampusResolver(client).Resolve(ctx, cfg.DefaultCampus)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default_campus in the config file: %w", err)
+	}
+	return found, nil
+}
+
+// cursusIDOrDefault returns --cursus-id when given, else default_cursus_id
+// from the config file when set, else fallback. The flag is registered with
+// a zero default so that its help does not show a default contradicting
+// default_cursus_id.
+func cursusIDOrDefault(cmd *cobra.Command, fallback int) int {
+	if cmd.Flags().Changed("cursus-id") {
+		cursusID, _ := cmd.Flags().GetInt("cursus-id")
+		return cursusID
+	}
+	if cfg, err := config.LoadConfig(); err == nil && cfg.DefaultCursusID > 0 {
+		return cfg.DefaultCursusID
+	}
+	return fallback
+}
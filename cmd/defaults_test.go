@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestCursusIDOrDefault(t *testing.T) {
+	tests := []struct {
+		name      string
+		flag      string
+		defaultID int
+		want      int
+	}{
+		{name: "flag default", want: 21},
+		{name: "config default", defaultID: 9, want: 9},
+		{name: "flag wins", flag: "42", defaultID: 9, want: 42},
+		{name: "explicit zero wins", flag: "0", defaultID: 9, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			if tt.defaultID != 0 {
+				cfg := config.DefaultConfig()
+				cfg.DefaultCursusID = tt.defaultID
+				if err := config.SaveConfig(cfg); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cmd := &cobra.Command{}
+			cmd.Flags().Int("cursus-id", 0, "")
+			if tt.flag != "" {
+				if err := cmd.Flags().Set("cursus-id", tt.flag); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := cursusIDOrDefault(cmd, 21); got != tt.want {
+				t.Errorf("cursusIDOrDefault() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveCampusOrDefaultUnset(t *testing.T) {
+	t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+	cmd := &cobra.Command{}
+	addCampusFlags(cmd)
+
+	campus, err := resolveCampusOrDefault(context.Background(), cmd, nil)
+	if err != nil || campus != nil {
+		t.Errorf("resolveCampusOrDefault() = %v, %v; want nil without flags or default_campus", campus, err)
+	}
+}
@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"math"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -19,10 +22,33 @@ var eligibleCmd = &cobra.Command{
 
 This command reads the project's session rules from the API and checks
 each user against the inscription requirements (quests validated, quests
-not validated, projects not ongoing/validated).
+not validated, projects not ongoing/validated). Without --campus or
+--campus-id, default_campus from the config file is used, and without
+--cursus-id, default_cursus_id.
 
 By default, blackholed users are excluded. Users must have an active
-cursus (not ended) to be considered eligible.
+cursus (not ended) to be considered eligible. --pool keeps the users of a
+pool (2023-09, or a whole year: 2023), --grade those of a grade (member,
+cadet) and --exclude-logins leaves out the logins of a file, one per line
+or the first column of a CSV export, such as people already contacted.
+
+Candidates are scanned in level bands (--band-width levels wide), highest
+first. Levels below what the required quests imply are never fetched, and
+the scan stops as soon as --limit users are found. With --strategy breadth
+every band contributes a page per round instead.
+
+Up to --workers candidates are checked concurrently; all workers share the
+client's rate limiter. A progress bar is shown on stderr in a terminal.
+
+--max-pages stops the scan after that many pages of candidates. With
+--checkpoint, the position of the scan and the users found are saved to a
+file as it goes, and a rerun with the same file and search resumes where
+the last one stopped, after a network error or --max-pages. The file is
+removed once a scan completes.
+
+With --output csv or tsv, there is a row per eligible user, with the
+columns of the table; --fields picks them among login, name, email, level,
+bh_days, campus, pool_month, pool_year, location and quests.
 
 Examples:
   # Find users eligible for ft_transcendence at Tokyo campus
@@ -34,19 +60,40 @@ Examples:
   # Show more results
   t42 user eligible --project ft_transcendence --campus tokyo --limit 10
 
+  # Members of the September 2023 pool not contacted yet
+  t42 user eligible --project ft_transcendence --campus tokyo --pool 2023-09 --grade member --exclude-logins contacted.csv
+
+  # Scan at most 20 pages per run, resuming from the last run
+  t42 user eligible --project ft_transcendence --campus tokyo --limit 50 --max-pages 20 --checkpoint scan.json
+
+  # Spread results over all levels instead of taking the highest first
+  t42 user eligible --project ft_transcendence --campus tokyo --strategy breadth
+
   # JSON output
-  t42 user eligible --project ft_transcendence --campus tokyo --json`,
+  t42 user eligible --project ft_transcendence --campus tokyo --json
+
+  # Spreadsheet of candidates to contact
+  t42 user eligible --project ft_transcendence --campus tokyo -o csv --fields login,email,level,bh_days`,
 	RunE: runEligible,
 }
 
 func init() {
 	eligibleCmd.Flags().String("project", "", "Project slug (required, e.g., ft_transcendence)")
-	eligibleCmd.Flags().String("campus", "", "Campus name (e.g., tokyo)")
-	eligibleCmd.Flags().Int("campus-id", 0, "Campus ID")
-	eligibleCmd.Flags().Int("cursus-id", 21, "Cursus ID (default: 21 for 42cursus)")
+	addCampusFlags(eligibleCmd)
+	eligibleCmd.Flags().Int("cursus-id", 0, "Cursus ID (default: default_cursus_id from the config file, else 21 for 42cursus)")
 	eligibleCmd.Flags().Float64("min-level", 0, "Minimum cursus level")
 	eligibleCmd.Flags().Float64("max-level", 0, "Maximum cursus level")
-	eligibleCmd.Flags().IntP("limit", "l", 5, "Maximum number of eligible users to find")
+	eligibleCmd.Flags().String("pool", "", "Only users of this pool: YYYY-MM or YYYY")
+	eligibleCmd.Flags().String("grade", "", "Only users of this grade (member, cadet)")
+	eligibleCmd.Flags().String("exclude-logins", "", "File of logins to leave out, one per line (or a CSV with login first)")
+	addLimitFlag(eligibleCmd, 5, "Maximum number of eligible users to find")
+	eligibleCmd.Flags().String("strategy", strategyDepth, "Scan order over level bands: depth (highest levels first) or breadth (spread over all levels)")
+	eligibleCmd.Flags().Float64("band-width", 1, "Width of the level bands candidates are scanned in")
+	eligibleCmd.Flags().Int("workers", 4, "Candidates checked concurrently (requests still respect the rate limit)")
+	eligibleCmd.Flags().String("checkpoint", "", "File saving the scan as it goes, to resume it with a rerun")
+	eligibleCmd.Flags().Int("max-pages", 0, "Stop after this many pages of candidates (0 for no cap)")
+	registerColumns(eligibleCmd, "eligible_users", eligibleColumns...)
+	registerFieldColumns(eligibleCmd, eligibleFieldColumns...)
 
 	if err := eligibleCmd.MarkFlagRequired("project"); err != nil {
 		panic(fmt.Sprintf("failed to mark project flag required: %v", err))
@@ -172,6 +219,10 @@ type questInfo struct {
 	ValidatedAt string `json:"validated_at"`
 }
 
+// maxEligibleWorkers caps --workers: past a few workers the rate limiter,
+// not latency, bounds the throughput
+const maxEligibleWorkers = 16
+
 func runEligible(cmd *cobra.Command, args []string) error {
 	client, err := NewAPIClient()
 	if err != nil {
@@ -182,59 +233,70 @@ func runEligible(cmd *cobra.Command, args []string) error {
 
 	// Get flags
 	projectSlug, _ := cmd.Flags().GetString("project")
-	campusName, _ := cmd.Flags().GetString("campus")
-	campusID, _ := cmd.Flags().GetInt("campus-id")
-	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+	projectSlug = expandProjectSlug(projectSlug)
+	cursusID := cursusIDOrDefault(cmd, 21)
 	minLevel, _ := cmd.Flags().GetFloat64("min-level")
 	maxLevel, _ := cmd.Flags().GetFloat64("max-level")
-	limit, _ := cmd.Flags().GetInt("limit")
-
-	// Resolve campus name to ID
-	var resolvedCampus *api.Campus
-	if campusName != "" {
-		campuses, campusErr := client.ListCampuses(ctx)
-		if campusErr != nil {
-			return fmt.Errorf("failed to list campuses: %w", campusErr)
+	limits, err := limitOptions(cmd)
+	if err != nil {
+		return err
+	}
+	limit := limits.Limit
+	if limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	strategy, _ := cmd.Flags().GetString("strategy")
+	bandWidth, _ := cmd.Flags().GetFloat64("band-width")
+	workers, _ := cmd.Flags().GetInt("workers")
+	if workers < 1 || workers > maxEligibleWorkers {
+		return fmt.Errorf("--workers must be between 1 and %d", maxEligibleWorkers)
+	}
+	if strategy != strategyDepth && strategy != strategyBreadth {
+		return fmt.Errorf("invalid --strategy %q (use depth or breadth)", strategy)
+	}
+	var filters eligibleFilters
+	if pool, _ := cmd.Flags().GetString("pool"); pool != "" {
+		if filters.PoolYear, filters.PoolMonth, err = parsePool(pool); err != nil {
+			return err
 		}
-
-		campusNameLower := strings.ToLower(campusName)
-		for i := range campuses {
-			if strings.ToLower(campuses[i].Name) == campusNameLower ||
-				strings.ToLower(campuses[i].City) == campusNameLower {
-				campusID = campuses[i].ID
-				resolvedCampus = &campuses[i]
-				break
-			}
+	}
+	grade, _ := cmd.Flags().GetString("grade")
+	filters.Grade = strings.ToLower(strings.TrimSpace(grade))
+	if path, _ := cmd.Flags().GetString("exclude-logins"); path != "" {
+		logins, err := readLoginsFile(path)
+		if err != nil {
+			return err
 		}
-
-		if campusID == 0 {
-			var campusOptions []string
-			for _, campus := range campuses {
-				label := campus.Name
-				cityLower := strings.ToLower(campus.City)
-				nameLower := strings.ToLower(campus.Name)
-				if campus.City != "" && cityLower != nameLower {
-					label = fmt.Sprintf("%s (%s)", campus.Name, campus.City)
-				}
-				campusOptions = append(campusOptions, label)
-			}
-			if len(campusOptions) > 10 {
-				return fmt.Errorf("campus %q not found; some available campuses: %s",
-					campusName, strings.Join(campusOptions[:10], ", "))
-			}
-			return fmt.Errorf("campus %q not found. Available campuses: %s",
-				campusName, strings.Join(campusOptions, ", "))
+		filters.Excluded = make(map[string]bool, len(logins))
+		for _, login := range logins {
+			filters.Excluded[login] = true
 		}
 	}
+	checkpointPath, _ := cmd.Flags().GetString("checkpoint")
+	maxPages, _ := cmd.Flags().GetInt("max-pages")
+	if maxPages < 0 {
+		return fmt.Errorf("--max-pages must be positive")
+	}
+
+	// Resolve campus name or ID
+	resolvedCampus, err := resolveCampusOrDefault(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	campusID := 0
+	if resolvedCampus != nil {
+		campusID = resolvedCampus.ID
+	}
 
 	// Resolve project slug → project ID + find campus session
 	if GetVerbose() {
 		fmt.Printf("Looking up project: %s\n", projectSlug)
 	}
-	project, err := client.GetProjectBySlug(ctx, projectSlug)
+	project, err := resolveProject(ctx, client, projectSlug)
 	if err != nil {
-		return fmt.Errorf("failed to find project %q: %w", projectSlug, err)
+		return err
 	}
+	projectSlug = project.Slug
 
 	// Get full project detail to find the campus-specific session ID
 	projectDetail, err := client.GetProject(ctx, project.ID)
@@ -260,17 +322,10 @@ func runEligible(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Getting session detail for session %d (using app credentials)\n", sessionID)
 	}
 
-	secrets, err := getOAuth2Config()
-	if err != nil {
-		return fmt.Errorf("failed to load app credentials (needed for session rules): %w", err)
-	}
-
-	appToken, err := api.GetClientCredentialsToken(ctx, secrets.ClientID, secrets.ClientSecret)
+	appClient, err := newAppClient(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get app token: %w", err)
+		return fmt.Errorf("%w (needed for session rules)", err)
 	}
-
-	appClient := api.NewClient(appToken)
 	session, err := appClient.GetProjectSessionDetail(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to get session detail: %w", err)
@@ -285,176 +340,282 @@ func runEligible(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Forbidden projects: %v\n", reqs.forbiddenProjects)
 	}
 
-	// Fetch cursus users with level range (server-side filtering)
+	// Never scan levels the required quests rule out
+	levelFloor := math.Max(minLevel, impliedLevelFloor(reqs.requiredQuests))
+	if GetVerbose() && levelFloor > minLevel {
+		fmt.Printf("Required quests imply level >= %.0f, skipping lower levels\n", levelFloor)
+	}
+
 	now := time.Now()
 	var eligible []eligibleUser
-	currentPage := 1
-	totalChecked := 0
-	totalAPIPages := 0
-
-	for len(eligible) < limit {
-		cursusOpts := &api.ListCursusUsersOptions{
-			Page:     currentPage,
-			PerPage:  100,
-			CampusID: campusID,
-			Sort:     "-level",
-			MinLevel: minLevel,
-			MaxLevel: maxLevel,
-		}
 
-		cursusUsers, meta, fetchErr := client.ListCursusUsers(ctx, cursusID, cursusOpts)
-		if fetchErr != nil {
-			return fmt.Errorf("failed to list cursus users: %w", fetchErr)
-		}
-		totalAPIPages++
+	scan := eligibleScan{
+		CursusID:  cursusID,
+		CampusID:  campusID,
+		MinLevel:  levelFloor,
+		MaxLevel:  maxLevel,
+		BandWidth: bandWidth,
+		Strategy:  strategy,
+		Limit:     limit,
+		Workers:   workers,
+		MaxPages:  maxPages,
+	}
 
-		if GetVerbose() && currentPage == 1 && meta != nil {
-			fmt.Printf("Total candidates in level range: %d\n", meta.TotalCount)
+	var checkpoint *eligibleCheckpoint
+	if checkpointPath != "" {
+		search := eligibleSearchKey(projectSlug, scan, filters)
+		if checkpoint, err = loadEligibleCheckpoint(checkpointPath, search); err != nil {
+			return err
 		}
-
-		for _, cu := range cursusUsers {
-			totalChecked++
-
-			// Skip blackholed users (BH date in the past)
-			if cu.BlackholedAt != nil && cu.BlackholedAt.Before(now) {
-				continue
+		if checkpoint != nil {
+			// Logins excluded since the last run are left out too
+			for _, eu := range checkpoint.Eligible {
+				if !filters.Excluded[strings.ToLower(eu.User.Login)] {
+					eligible = append(eligible, eu)
+				}
 			}
+			scan.Resume = &checkpoint.Progress
+			scan.Limit = limit - len(eligible)
+			fmt.Fprintf(os.Stderr, "⏯️  Resuming from %s: %d eligible users, %d candidates checked\n",
+				checkpointPath, len(eligible), len(checkpoint.Progress.Seen))
+		} else {
+			checkpoint = &eligibleCheckpoint{Search: search}
+		}
+	}
 
-			// Skip users whose cursus has ended (graduated/exited)
-			if cu.EndAt != nil {
-				continue
+	quests := newQuestBatcher(client)
+	scan.Prefetch = func(page []api.CursusUser) {
+		var ids []int
+		for _, cu := range page {
+			if skip := precheckEligibility(cu, filters, now); skip == "" {
+				ids = append(ids, cu.User.ID)
 			}
-
-			if GetVerbose() {
-				fmt.Printf("  Checking %s (level %.2f)...\n", cu.User.Login, cu.Level)
+		}
+		quests.prefetch(ctx, ids)
+	}
+	progress := newEligibleProgress(limit)
+	var mu sync.Mutex
+	if checkpoint != nil {
+		scan.Progress = func(p scanProgress) {
+			mu.Lock()
+			checkpoint.Progress = p
+			checkpoint.Eligible = append([]eligibleUser(nil), eligible...)
+			mu.Unlock()
+			checkpoint.UpdatedAt = time.Now()
+			if err := saveEligibleCheckpoint(checkpointPath, checkpoint); err != nil {
+				warnf("checkpoint_unsaved", "%v", err)
 			}
-
-			// Get full user profile for projects_users
-			fullUser, userErr := client.GetUser(ctx, cu.User.ID)
-			if userErr != nil {
-				if GetVerbose() {
-					fmt.Printf("    Skip: failed to get user: %v\n", userErr)
-				}
-				continue
+		}
+	}
+	checked := 0
+	stats := &eligibleScanStats{LevelFloor: levelFloor, Strategy: strategy}
+	if scan.Limit > 0 {
+		stats, err = scanCandidates(ctx, client, scan, func(cu api.CursusUser) bool {
+			eu, skip := checkEligibility(ctx, client, quests, cu, reqs, filters, resolvedCampus, now)
+
+			mu.Lock()
+			defer mu.Unlock()
+			checked++
+			if eu != nil {
+				eligible = append(eligible, *eu)
 			}
-
-			// Check forbidden projects (e.g., project not already ongoing/validated)
-			if !checkForbiddenProjects(fullUser.ProjectsUsers, reqs.forbiddenProjects) {
-				if GetVerbose() {
-					fmt.Printf("    Skip: forbidden project active/validated\n")
+			if GetVerbose() {
+				outcome := fmt.Sprintf("ELIGIBLE (%d/%d)", len(eligible), limit)
+				if eu == nil {
+					outcome = "Skip: " + skip
 				}
-				continue
+				fmt.Printf("  %s (level %.2f): %s\n", cu.User.Login, cu.Level, outcome)
 			}
+			progress.update(len(eligible), checked)
+			return eu != nil
+		})
+	}
+	progress.done()
+	if err != nil {
+		if checkpoint != nil {
+			fmt.Fprintf(os.Stderr, "💾 Progress saved to %s: rerun the same command to resume\n", checkpointPath)
+		}
+		return err
+	}
+	if checkpoint != nil && !stats.MaxPagesReached {
+		if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+			warnf("checkpoint_unsaved", "failed to remove checkpoint: %v", err)
+		}
+	}
+	totalChecked := stats.Checked
+	questRequests, questsBatched := quests.stats()
+	if GetVerbose() {
+		fmt.Printf("Quests: %d requests, %d users served by batches\n", questRequests, questsBatched)
+	}
 
-			// Check quest requirements
-			questUsers, questErr := client.ListUserQuestUsers(ctx, cu.User.ID)
-			if questErr != nil {
-				if GetVerbose() {
-					fmt.Printf("    Skip: failed to get quests: %v\n", questErr)
-				}
-				continue
-			}
+	// Breadth scans find users in band order, show them by level
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return eligible[i].Level > eligible[j].Level
+	})
+	// The last batch of concurrent checks may overshoot the limit
+	if len(eligible) > limit {
+		eligible = eligible[:limit]
+	}
 
-			if !checkRequiredQuests(questUsers, reqs.requiredQuests) {
-				if GetVerbose() {
-					fmt.Printf("    Skip: required quest not validated\n")
-				}
-				continue
-			}
+	// Output
+	result := map[string]interface{}{
+		"eligible_users": eligible,
+		"criteria": map[string]interface{}{
+			"project":           projectSlug,
+			"campus_id":         campusID,
+			"cursus_id":         cursusID,
+			"min_level":         minLevel,
+			"max_level":         maxLevel,
+			"pool":              strings.TrimSpace(filters.PoolMonth + " " + filters.PoolYear),
+			"grade":             filters.Grade,
+			"excluded_logins":   len(filters.Excluded),
+			"required_quests":   reqs.requiredQuests,
+			"forbidden_quests":  reqs.forbiddenQuests,
+			"forbidden_projects": reqs.forbiddenProjects,
+		},
+		"stats": map[string]interface{}{
+			"eligible_found":  len(eligible),
+			"total_checked":   totalChecked,
+			"api_pages_used":  stats.APIPages,
+			"level_bands":     stats.Bands,
+			"level_floor":     stats.LevelFloor,
+			"max_pages_reached": stats.MaxPagesReached,
+			"resumed":         scan.Resume != nil,
+			"strategy":        stats.Strategy,
+			"quest_requests":  questRequests,
+			"limit":           limit,
+		},
+	}
+	if done, err := emitOutputValue(result); done || err != nil {
+		return err
+	}
+	printEligibleTable(eligible, project.Name, resolvedCampus, cursusID, reqs, totalChecked, limit)
+	if stats.MaxPagesReached {
+		fmt.Printf("\nStopped after --max-pages %d", maxPages)
+		if checkpoint != nil {
+			fmt.Printf("; rerun to continue from %s", checkpointPath)
+		}
+		fmt.Println()
+	}
 
-			if !checkForbiddenQuests(questUsers, reqs.forbiddenQuests) {
-				if GetVerbose() {
-					fmt.Printf("    Skip: forbidden quest validated\n")
-				}
-				continue
-			}
+	return nil
+}
 
-			// Embed campus and cursus info into the full user
-			if resolvedCampus != nil && len(fullUser.Campus) == 0 {
-				fullUser.Campus = []api.Campus{*resolvedCampus}
-			}
-			fullUser.CursusUsers = []api.CursusUser{{
-				ID:           cu.ID,
-				BeginAt:      cu.BeginAt,
-				EndAt:        cu.EndAt,
-				Grade:        cu.Grade,
-				Level:        cu.Level,
-				Skills:       cu.Skills,
-				BlackholedAt: cu.BlackholedAt,
-				Cursus:       cu.Cursus,
-				HasCoalition: cu.HasCoalition,
-			}}
-
-			// Build quest info for display
-			var qInfo []questInfo
-			for _, qu := range questUsers {
-				if qu.ValidatedAt != nil {
-					qInfo = append(qInfo, questInfo{
-						Slug:        qu.Quest.Slug,
-						ValidatedAt: qu.ValidatedAt.Format("2006-01-02"),
-					})
-				}
-			}
+// precheckEligibility returns why a candidate is skipped from its cursus
+// data alone, or "" when its profile and quests need checking
+func precheckEligibility(cu api.CursusUser, filters eligibleFilters, now time.Time) string {
+	// Skip blackholed users (BH date in the past)
+	if cu.BlackholedAt != nil && cu.BlackholedAt.Before(now) {
+		return "blackholed"
+	}
 
-			bhDays := 0
-			if cu.BlackholedAt != nil {
-				bhDays = int(time.Until(*cu.BlackholedAt).Hours() / 24)
-			}
+	// Skip users whose cursus has ended (graduated/exited)
+	if cu.EndAt != nil {
+		return "cursus ended"
+	}
+	return filters.skip(cu)
+}
 
-			eligible = append(eligible, eligibleUser{
-				User:       *fullUser,
-				Level:      cu.Level,
-				BlackholeD: bhDays,
-				QuestsInfo: qInfo,
-			})
+// checkEligibility fetches a candidate's profile and quests and checks them
+// against the inscription rules. It returns the eligible user, or nil and
+// the reason the candidate was skipped. It is safe for concurrent use.
+func checkEligibility(ctx context.Context, client *api.Client, quests *questBatcher, cu api.CursusUser, reqs inscriptionRequirements, filters eligibleFilters, campus *api.Campus, now time.Time) (*eligibleUser, string) {
+	if skip := precheckEligibility(cu, filters, now); skip != "" {
+		return nil, skip
+	}
 
-			if GetVerbose() {
-				fmt.Printf("    ELIGIBLE (%d/%d)\n", len(eligible), limit)
-			}
+	// Get full user profile for projects_users
+	fullUser, err := client.GetUser(ctx, cu.User.ID)
+	if err != nil {
+		warnf("user_skipped", "skipped %s: failed to get user: %v", cu.User.Login, err)
+		return nil, fmt.Sprintf("failed to get user: %v", err)
+	}
 
-			if len(eligible) >= limit {
-				break
-			}
-		}
+	// Check forbidden projects (e.g., project not already ongoing/validated)
+	if !checkForbiddenProjects(fullUser.ProjectsUsers, reqs.forbiddenProjects) {
+		return nil, "forbidden project active/validated"
+	}
 
-		// Stop if no more pages
-		if len(cursusUsers) < 100 || (meta != nil && currentPage >= meta.TotalPages) {
-			break
-		}
-		currentPage++
+	// Check quest requirements
+	questUsers, err := quests.questsOf(ctx, cu.User.ID)
+	if err != nil {
+		warnf("user_skipped", "skipped %s: failed to get quests: %v", cu.User.Login, err)
+		return nil, fmt.Sprintf("failed to get quests: %v", err)
+	}
+	if !checkRequiredQuests(questUsers, reqs.requiredQuests) {
+		return nil, "required quest not validated"
+	}
+	if !checkForbiddenQuests(questUsers, reqs.forbiddenQuests) {
+		return nil, "forbidden quest validated"
 	}
 
-	// Output
-	if GetJSONOutput() {
-		output := map[string]interface{}{
-			"eligible_users": eligible,
-			"criteria": map[string]interface{}{
-				"project":           projectSlug,
-				"campus_id":         campusID,
-				"cursus_id":         cursusID,
-				"min_level":         minLevel,
-				"max_level":         maxLevel,
-				"required_quests":   reqs.requiredQuests,
-				"forbidden_quests":  reqs.forbiddenQuests,
-				"forbidden_projects": reqs.forbiddenProjects,
-			},
-			"stats": map[string]interface{}{
-				"eligible_found":  len(eligible),
-				"total_checked":   totalChecked,
-				"api_pages_used":  totalAPIPages,
-				"limit":           limit,
-			},
-		}
-		jsonData, jsonErr := json.MarshalIndent(output, "", "  ")
-		if jsonErr != nil {
-			return fmt.Errorf("failed to marshal JSON output: %w", jsonErr)
+	// Embed campus and cursus info into the full user
+	if campus != nil && len(fullUser.Campus) == 0 {
+		fullUser.Campus = []api.Campus{*campus}
+	}
+	fullUser.CursusUsers = []api.CursusUser{{
+		ID:           cu.ID,
+		BeginAt:      cu.BeginAt,
+		EndAt:        cu.EndAt,
+		Grade:        cu.Grade,
+		Level:        cu.Level,
+		Skills:       cu.Skills,
+		BlackholedAt: cu.BlackholedAt,
+		Cursus:       cu.Cursus,
+		HasCoalition: cu.HasCoalition,
+	}}
+
+	// Build quest info for display
+	var qInfo []questInfo
+	for _, qu := range questUsers {
+		if qu.ValidatedAt != nil {
+			qInfo = append(qInfo, questInfo{
+				Slug:        qu.Quest.Slug,
+				ValidatedAt: qu.ValidatedAt.Format("2006-01-02"),
+			})
 		}
-		fmt.Println(string(jsonData))
-	} else {
-		printEligibleTable(eligible, project.Name, resolvedCampus, cursusID, reqs, totalChecked, limit)
 	}
 
-	return nil
+	bhDays := 0
+	if cu.BlackholedAt != nil {
+		bhDays = int(cu.BlackholedAt.Sub(now).Hours() / 24)
+	}
+
+	return &eligibleUser{
+		User:       *fullUser,
+		Level:      cu.Level,
+		BlackholeD: bhDays,
+		QuestsInfo: qInfo,
+	}, ""
+}
+
+// eligibleProgress draws a progress bar of the eligible users found on
+// stderr. It stays silent when stderr is not a terminal (pipes, plain
+// output) and in verbose mode, which logs every candidate instead.
+type eligibleProgress struct {
+	enabled bool
+	limit   int
+}
+
+func newEligibleProgress(limit int) *eligibleProgress {
+	return &eligibleProgress{enabled: limit > 0 && !GetVerbose() && isTerminal(os.Stderr), limit: limit}
+}
+
+// update redraws the progress line
+func (p *eligibleProgress) update(found, checked int) {
+	if !p.enabled {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r⏳ %s %d/%d eligible, %d candidates checked",
+		renderBar(float64(found)/float64(p.limit), 20), min(found, p.limit), p.limit, checked)
+}
+
+// done clears the progress line
+func (p *eligibleProgress) done() {
+	if !p.enabled {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r%s\r", strings.Repeat(" ", 80))
 }
 
 func printEligibleTable(users []eligibleUser, projectName string, campus *api.Campus, cursusID int, reqs inscriptionRequirements, totalChecked int, limit int) {
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// eligibleCheckpoint is the --checkpoint file of 't42 user eligible': where
+// the scan stands and the users found so far
+type eligibleCheckpoint struct {
+	Search    string         `json:"search"` // the search it belongs to
+	Progress  scanProgress   `json:"progress"`
+	Eligible  []eligibleUser `json:"eligible_users"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// eligibleSearchKey identifies a search, so a checkpoint is never resumed
+// by a different one
+func eligibleSearchKey(project string, scan eligibleScan, filters eligibleFilters) string {
+	return fmt.Sprintf("project=%s campus=%d cursus=%d min=%g max=%g band=%g strategy=%s pool=%s-%s grade=%s",
+		project, scan.CampusID, scan.CursusID, scan.MinLevel, scan.MaxLevel, scan.BandWidth, scan.Strategy,
+		filters.PoolYear, filters.PoolMonth, filters.Grade)
+}
+
+// loadEligibleCheckpoint reads the checkpoint of a search. It returns nil
+// when there is none yet, and an error when the file belongs to another
+// search.
+func loadEligibleCheckpoint(path, search string) (*eligibleCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	var cp eligibleCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if cp.Search != search {
+		return nil, fmt.Errorf("checkpoint %s belongs to another search (%s) - remove it or use another file", path, cp.Search)
+	}
+	return &cp, nil
+}
+
+// saveEligibleCheckpoint writes a checkpoint atomically, so an interrupted
+// write never loses the previous one
+func saveEligibleCheckpoint(path string, cp *eligibleCheckpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
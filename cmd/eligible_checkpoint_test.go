@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestEligibleCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scans", "transcendence.json")
+	scan := eligibleScan{CursusID: 21, CampusID: 26, MinLevel: 5, BandWidth: 1, Strategy: strategyDepth}
+	search := eligibleSearchKey("ft_transcendence", scan, eligibleFilters{Grade: "member"})
+
+	cp, err := loadEligibleCheckpoint(path, search)
+	if err != nil || cp != nil {
+		t.Fatalf("loadEligibleCheckpoint() of a missing file = %v, %v, want nil, nil", cp, err)
+	}
+
+	saved := &eligibleCheckpoint{
+		Search: search,
+		Progress: scanProgress{
+			Bands: []bandProgress{{Band: levelBand{9, 0}, Page: 2, Done: true}, {Band: levelBand{8, 9}, Page: 1}},
+			Seen:  []int{3, 5, 8},
+		},
+		Eligible: []eligibleUser{{User: api.User{Login: "alice"}, Level: 9.2}},
+	}
+	if err := saveEligibleCheckpoint(path, saved); err != nil {
+		t.Fatalf("saveEligibleCheckpoint() error = %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	cp, err = loadEligibleCheckpoint(path, search)
+	if err != nil {
+		t.Fatalf("loadEligibleCheckpoint() error = %v", err)
+	}
+	if len(cp.Progress.Bands) != 2 || cp.Progress.Bands[0] != saved.Progress.Bands[0] ||
+		len(cp.Progress.Seen) != 3 || len(cp.Eligible) != 1 || cp.Eligible[0].User.Login != "alice" {
+		t.Errorf("loaded checkpoint = %+v, want %+v", cp, saved)
+	}
+
+	scan.Strategy = strategyBreadth
+	if _, err := loadEligibleCheckpoint(path, eligibleSearchKey("ft_transcendence", scan, eligibleFilters{Grade: "member"})); err == nil {
+		t.Error("loadEligibleCheckpoint() resumed the checkpoint of another search")
+	}
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// eligibleColumns are the CSV/TSV columns of 't42 user eligible', those of
+// the table; rows are eligibleUser values
+var eligibleColumns = []output.Column{
+	{Header: "login", Query: ".user.login"},
+	{Header: "name", Query: ".user.displayname"},
+	{Header: "level", Query: ".level"},
+	{Header: "bh_days", Query: ".blackhole_days"},
+}
+
+// eligibleFieldColumns are the other columns --fields can name
+var eligibleFieldColumns = []output.Column{
+	{Header: "email", Query: ".user.email"},
+	{Header: "campus", Query: ".user.campus[0].name"},
+	{Header: "pool_month", Query: ".user.pool_month"},
+	{Header: "pool_year", Query: ".user.pool_year"},
+	{Header: "location", Query: ".user.location"},
+	{Header: "quests", Query: ".quests_validated[]?.slug"},
+}
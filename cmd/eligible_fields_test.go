@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+func TestEligibleCSV(t *testing.T) {
+	result := map[string]interface{}{
+		"eligible_users": []eligibleUser{
+			{
+				User:       api.User{Login: "alice", DisplayName: "Alice A", Email: "alice@student.42tokyo.jp", Campus: []api.Campus{{Name: "Tokyo"}}},
+				Level:      7.42,
+				BlackholeD: 30,
+				QuestsInfo: []questInfo{{Slug: "common-core-rank-04"}, {Slug: "common-core-rank-05"}},
+			},
+			{User: api.User{Login: "bob"}, Level: 6},
+		},
+		"criteria": map[string]interface{}{"project": "ft_transcendence"},
+	}
+
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{
+			name: "table columns",
+			want: "login,name,level,bh_days\n" +
+				"alice,Alice A,7.42,30\n" +
+				"bob,,6,0\n",
+		},
+		{
+			name:   "named fields",
+			fields: "login,email,level,bh_days,campus,quests",
+			want: "login,email,level,bh_days,campus,quests\n" +
+				"alice,alice@student.42tokyo.jp,7.42,30,Tokyo,common-core-rank-04;common-core-rank-05\n" +
+				"bob,,6,0,,\n",
+		},
+		{
+			name:   "dotted paths",
+			fields: "user.login,eligible_users.blackhole_days",
+			want: "user.login,eligible_users.blackhole_days\n" +
+				"alice,30\n" +
+				"bob,0\n",
+		},
+	}
+
+	defer func(command *cobra.Command, fields *output.Fields) {
+		outputCommand, outputFields = command, fields
+	}(outputCommand, outputFields)
+	outputCommand = eligibleCmd
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFields = nil
+			if tt.fields != "" {
+				fields, err := output.ParseFields(tt.fields)
+				if err != nil {
+					t.Fatal(err)
+				}
+				outputFields = fields
+			}
+
+			var buf bytes.Buffer
+			if err := output.WriteValue(&buf, output.FormatCSV, fieldColumns(commandColumns[eligibleCmd]), result); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// eligibleFilters narrows the candidates of 't42 user eligible' by what the
+// cursus users list already tells, before any profile is fetched
+type eligibleFilters struct {
+	PoolYear  string          // "" for any
+	PoolMonth string          // lowercase English month name, "" for any
+	Grade     string          // lowercase, "" for any
+	Excluded  map[string]bool // lowercase logins
+}
+
+// parsePool parses --pool: a year and month (2023-09) or a year (2023). It
+// returns the year and the month as the API names it ("september").
+func parsePool(value string) (year, month string, err error) {
+	if t, err := time.Parse("2006-01", value); err == nil {
+		return t.Format("2006"), strings.ToLower(t.Month().String()), nil
+	}
+	if t, err := time.Parse("2006", value); err == nil {
+		return t.Format("2006"), "", nil
+	}
+	return "", "", fmt.Errorf("invalid --pool %q (use YYYY-MM or YYYY)", value)
+}
+
+// skip returns why a candidate is filtered out, or ""
+func (f eligibleFilters) skip(cu api.CursusUser) string {
+	if f.Excluded[strings.ToLower(cu.User.Login)] {
+		return "excluded"
+	}
+	if f.PoolYear != "" && cu.User.PoolYear != f.PoolYear {
+		return "other pool"
+	}
+	if f.PoolMonth != "" && strings.ToLower(cu.User.PoolMonth) != f.PoolMonth {
+		return "other pool"
+	}
+	if f.Grade != "" && (cu.Grade == nil || strings.ToLower(*cu.Grade) != f.Grade) {
+		return "other grade"
+	}
+	return ""
+}
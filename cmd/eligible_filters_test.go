@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestParsePool(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantYear  string
+		wantMonth string
+		wantErr   bool
+	}{
+		{"2023-09", "2023", "september", false},
+		{"2024", "2024", "", false},
+		{"2023-13", "", "", true},
+		{"september", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			year, month, err := parsePool(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if year != tt.wantYear || month != tt.wantMonth {
+				t.Errorf("parsePool(%q) = %q, %q, want %q, %q", tt.value, year, month, tt.wantYear, tt.wantMonth)
+			}
+		})
+	}
+}
+
+func TestPrecheckEligibilityFilters(t *testing.T) {
+	now := time.Now()
+	member, cadet := "Member", "Cadet"
+	past := now.Add(-24 * time.Hour)
+	candidate := func(login, poolMonth, poolYear string, grade *string) api.CursusUser {
+		return api.CursusUser{
+			User:  api.User{Login: login, PoolMonth: poolMonth, PoolYear: poolYear},
+			Grade: grade,
+		}
+	}
+	filters := eligibleFilters{
+		PoolYear:  "2023",
+		PoolMonth: "september",
+		Grade:     "member",
+		Excluded:  map[string]bool{"carol": true},
+	}
+
+	tests := []struct {
+		name string
+		cu   api.CursusUser
+		want string
+	}{
+		{"matches", candidate("alice", "September", "2023", &member), ""},
+		{"excluded", candidate("Carol", "september", "2023", &member), "excluded"},
+		{"other year", candidate("bob", "september", "2022", &member), "other pool"},
+		{"other month", candidate("bob", "july", "2023", &member), "other pool"},
+		{"other grade", candidate("bob", "september", "2023", &cadet), "other grade"},
+		{"no grade", candidate("bob", "september", "2023", nil), "other grade"},
+		{"blackholed first", api.CursusUser{BlackholedAt: &past, User: api.User{Login: "carol"}}, "blackholed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := precheckEligibility(tt.cu, filters, now); got != tt.want {
+				t.Errorf("precheckEligibility() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := precheckEligibility(candidate("dave", "", "", nil), eligibleFilters{}, now); got != "" {
+		t.Errorf("precheckEligibility() without filters = %q, want none", got)
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// questBatchSize is the number of users whose quests one batched request
+// asks for; it keeps the filter short enough for the URL
+const questBatchSize = 30
+
+// errQuestsWithoutUser is returned for batched quest records that do not
+// say whose they are
+var errQuestsWithoutUser = errors.New("quest records without their user")
+
+// questLister is the part of the API client the quest batcher needs
+type questLister interface {
+	ListQuestsUsers(ctx context.Context, opts *api.ListQuestsUsersOptions) ([]api.QuestUser, *api.PaginationMeta, error)
+	ListUserQuestUsers(ctx context.Context, userID int) ([]api.QuestUser, error)
+}
+
+// questBatcher fetches the quests of user eligible's candidates a page at a
+// time. Quest lookups are most of a scan's requests: prefetch asks for the
+// quests of up to questBatchSize users in one filtered request, and questsOf
+// serves them from there, or falls back to the per-user endpoint for the
+// users whose batch failed. A batch refused by the API (e.g. a filter it
+// does not support) turns batching off for the rest of the scan; one rate
+// limited or failing on the server side only leaves its users to the
+// fallback, the client having already backed off and retried it.
+type questBatcher struct {
+	lister questLister
+
+	mu       sync.Mutex
+	quests   map[int][]api.QuestUser // prefetched, by user ID
+	disabled bool
+	requests int // requests made, batched or not
+	batched  int // users whose quests came from a batch
+}
+
+func newQuestBatcher(lister questLister) *questBatcher {
+	return &questBatcher{lister: lister, quests: make(map[int][]api.QuestUser)}
+}
+
+// prefetch fetches the quests of userIDs in batches, for questsOf
+func (b *questBatcher) prefetch(ctx context.Context, userIDs []int) {
+	for start := 0; start < len(userIDs); start += questBatchSize {
+		b.mu.Lock()
+		disabled := b.disabled
+		b.mu.Unlock()
+		if disabled {
+			return
+		}
+
+		batch := userIDs[start:min(start+questBatchSize, len(userIDs))]
+		quests, requests, err := b.fetchBatch(ctx, batch)
+
+		b.mu.Lock()
+		b.requests += requests
+		if err != nil {
+			b.disabled = batchingUnsupported(err)
+			if GetVerbose() {
+				fmt.Fprintf(os.Stderr, "Batched quest lookup failed, asking user by user: %v\n", err)
+			}
+		} else {
+			for _, id := range batch {
+				b.quests[id] = quests[id]
+			}
+			b.batched += len(batch)
+		}
+		b.mu.Unlock()
+	}
+}
+
+// fetchBatch fetches every page of the quests of userIDs, and returns them
+// by user with the number of requests made
+func (b *questBatcher) fetchBatch(ctx context.Context, userIDs []int) (map[int][]api.QuestUser, int, error) {
+	quests := make(map[int][]api.QuestUser, len(userIDs))
+	requests := 0
+	fetch := func(ctx context.Context, page int) ([]api.QuestUser, *api.PaginationMeta, error) {
+		requests++
+		return b.lister.ListQuestsUsers(ctx, &api.ListQuestsUsersOptions{Page: page, PerPage: api.DefaultPerPage, UserIDs: userIDs})
+	}
+	err := api.FetchAll(ctx, api.DefaultPerPage, fetch, func(page []api.QuestUser, _ *api.PaginationMeta) error {
+		for _, qu := range page {
+			// Records that cannot be told apart make the whole batch useless
+			if qu.User == nil {
+				return errQuestsWithoutUser
+			}
+			quests[qu.User.ID] = append(quests[qu.User.ID], qu)
+		}
+		return nil
+	})
+	return quests, requests, err
+}
+
+// batchingUnsupported reports whether a failed batch means the API does not
+// support batched quest lookups, rather than a passing failure
+func batchingUnsupported(err error) bool {
+	if errors.Is(err, errQuestsWithoutUser) {
+		return true
+	}
+	if errors.Is(err, api.ErrRateLimited) || errors.Is(err, api.ErrServer) {
+		return false
+	}
+	return api.StatusCode(err) != 0
+}
+
+// questsOf returns the quests of a user, prefetched or fetched on its own.
+// It is safe for concurrent use.
+func (b *questBatcher) questsOf(ctx context.Context, userID int) ([]api.QuestUser, error) {
+	b.mu.Lock()
+	quests, ok := b.quests[userID]
+	if ok {
+		// Every candidate is checked once
+		delete(b.quests, userID)
+	} else {
+		b.requests++
+	}
+	b.mu.Unlock()
+	if ok {
+		return quests, nil
+	}
+	return b.lister.ListUserQuestUsers(ctx, userID)
+}
+
+// stats returns the requests made and the users served from batches
+func (b *questBatcher) stats() (requests, batched int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.requests, b.batched
+}
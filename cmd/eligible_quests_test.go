@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// fakeQuestLister serves quest records of users, batched or one user at a time
+type fakeQuestLister struct {
+	mu         sync.Mutex
+	quests     map[int][]api.QuestUser
+	batchErr   error // returned by every batched request
+	noUsers    bool  // leave the user out of batched records
+	batchCalls int
+	userCalls  int
+}
+
+func (f *fakeQuestLister) ListQuestsUsers(ctx context.Context, opts *api.ListQuestsUsersOptions) ([]api.QuestUser, *api.PaginationMeta, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batchCalls++
+	if f.batchErr != nil {
+		return nil, nil, f.batchErr
+	}
+	var records []api.QuestUser
+	for _, id := range opts.UserIDs {
+		for _, qu := range f.quests[id] {
+			if !f.noUsers {
+				qu.User = &api.User{ID: id}
+			}
+			records = append(records, qu)
+		}
+	}
+	return records, &api.PaginationMeta{TotalPages: 1}, nil
+}
+
+func (f *fakeQuestLister) ListUserQuestUsers(ctx context.Context, userID int) ([]api.QuestUser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.userCalls++
+	return f.quests[userID], nil
+}
+
+func TestQuestBatcher(t *testing.T) {
+	quests := map[int][]api.QuestUser{
+		1: {{ID: 10, Quest: api.Quest{Slug: "common-core-rank-01"}}},
+		2: {{ID: 20, Quest: api.Quest{Slug: "common-core-rank-01"}}, {ID: 21, Quest: api.Quest{Slug: "common-core-rank-02"}}},
+		// User 3 has no quests
+	}
+
+	tests := []struct {
+		name          string
+		batchErr      error
+		noUsers       bool
+		wantBatched   int
+		wantUserCalls int
+		wantDisabled  bool
+	}{
+		{name: "batched", wantBatched: 3},
+		{name: "filter refused", batchErr: &api.Error{StatusCode: 400}, wantUserCalls: 3, wantDisabled: true},
+		{name: "rate limited", batchErr: &api.Error{StatusCode: 429}, wantUserCalls: 3},
+		{name: "network error", batchErr: errors.New("connection reset"), wantUserCalls: 3},
+		{name: "records without users", noUsers: true, wantUserCalls: 3, wantDisabled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lister := &fakeQuestLister{quests: quests, batchErr: tt.batchErr, noUsers: tt.noUsers}
+			b := newQuestBatcher(lister)
+			b.prefetch(context.Background(), []int{1, 2, 3})
+
+			for id := 1; id <= 3; id++ {
+				got, err := b.questsOf(context.Background(), id)
+				if err != nil {
+					t.Fatalf("questsOf(%d) error = %v", id, err)
+				}
+				if len(got) != len(quests[id]) {
+					t.Errorf("questsOf(%d) = %d records, want %d", id, len(got), len(quests[id]))
+				}
+			}
+
+			requests, batched := b.stats()
+			if batched != tt.wantBatched || lister.userCalls != tt.wantUserCalls {
+				t.Errorf("batched %d users and made %d user calls, want %d and %d", batched, lister.userCalls, tt.wantBatched, tt.wantUserCalls)
+			}
+			if requests != lister.batchCalls+lister.userCalls {
+				t.Errorf("stats() requests = %d, want %d", requests, lister.batchCalls+lister.userCalls)
+			}
+			if b.disabled != tt.wantDisabled {
+				t.Errorf("disabled = %v, want %v", b.disabled, tt.wantDisabled)
+			}
+		})
+	}
+
+	t.Run("splits batches", func(t *testing.T) {
+		lister := &fakeQuestLister{quests: quests}
+		ids := make([]int, questBatchSize+5)
+		for i := range ids {
+			ids[i] = i + 1
+		}
+		newQuestBatcher(lister).prefetch(context.Background(), ids)
+		if lister.batchCalls != 2 {
+			t.Errorf("made %d batched requests, want 2", lister.batchCalls)
+		}
+	})
+
+	t.Run("disabled batching stops prefetching", func(t *testing.T) {
+		lister := &fakeQuestLister{quests: quests, batchErr: &api.Error{StatusCode: 403}}
+		b := newQuestBatcher(lister)
+		b.prefetch(context.Background(), []int{1})
+		b.prefetch(context.Background(), []int{2})
+		if lister.batchCalls != 1 {
+			t.Errorf("made %d batched requests, want 1", lister.batchCalls)
+		}
+	})
+}
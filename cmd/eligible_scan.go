@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// Scan strategies of user eligible
+const (
+	// strategyDepth exhausts the highest level band before moving down,
+	// which finds the most advanced candidates with the fewest API calls
+	strategyDepth = "depth"
+
+	// strategyBreadth lets every band contribute its share of the limit per
+	// round, so results are spread over the whole level range
+	strategyBreadth = "breadth"
+)
+
+// breadthPageSize is the page size of breadth mode, which fetches at most
+// one page per band and round
+const breadthPageSize = 20
+
+// questLevelFloors are conservative minimum 42cursus levels implied by a
+// validated quest: nobody validates common core rank N below level N, so
+// the bands below can be skipped when the quest is required
+var questLevelFloors = map[string]float64{
+	"common-core-rank-01": 1,
+	"common-core-rank-02": 2,
+	"common-core-rank-03": 3,
+	"common-core-rank-04": 4,
+	"common-core-rank-05": 5,
+	"common-core-rank-06": 6,
+}
+
+// impliedLevelFloor returns the lowest level a user can have while meeting
+// the required quests
+func impliedLevelFloor(requiredQuests []string) float64 {
+	floor := 0.0
+	for _, slug := range requiredQuests {
+		floor = math.Max(floor, questLevelFloors[slug])
+	}
+	return floor
+}
+
+// cursusUserLister is the part of the API client the scanner needs
+type cursusUserLister interface {
+	ListCursusUsers(ctx context.Context, cursusID int, opts *api.ListCursusUsersOptions) ([]api.CursusUser, *api.PaginationMeta, error)
+}
+
+// levelBand is an inclusive level range; Max 0 means no upper bound
+type levelBand struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max,omitempty"`
+}
+
+// levelBands splits [floor, top] into bands of the given width, highest
+// first. The top band keeps the caller's upper bound (maxLevel, 0 for none).
+func levelBands(top, floor, width, maxLevel float64) []levelBand {
+	if width <= 0 || top < floor {
+		return []levelBand{{Min: floor, Max: maxLevel}}
+	}
+
+	var bands []levelBand
+	upper := maxLevel
+	for start := math.Floor(top/width) * width; ; start -= width {
+		band := levelBand{Min: math.Max(start, floor), Max: upper}
+		bands = append(bands, band)
+		if band.Min <= floor {
+			return bands
+		}
+		upper = start
+	}
+}
+
+// eligibleScan configures how user eligible walks the candidates
+type eligibleScan struct {
+	CursusID  int
+	CampusID  int
+	MinLevel  float64
+	MaxLevel  float64 // 0 for no upper bound
+	BandWidth float64
+	Strategy  string
+	Limit     int
+	Workers   int // candidates checked concurrently, 1 when unset
+	MaxPages  int // pages of candidates fetched at most, 0 for no cap
+
+	// Prefetch, if set, gets each page of candidates before they are
+	// checked, e.g. to fetch their data in batches
+	Prefetch func(page []api.CursusUser)
+
+	// Resume, if set, continues an earlier scan where it stopped: in the
+	// same bands, from the same pages, skipping the candidates it checked
+	Resume *scanProgress
+
+	// Progress, if set, gets the position of the scan after each batch of
+	// candidates is checked, e.g. to save it for a later Resume
+	Progress func(scanProgress)
+}
+
+// scanProgress is the position of a scan, enough to resume it
+type scanProgress struct {
+	Bands []bandProgress `json:"bands"`
+	Seen  []int          `json:"seen"` // IDs of the cursus users checked
+}
+
+// bandProgress is the position of a scan within one band
+type bandProgress struct {
+	Band levelBand `json:"band"`
+	Page int       `json:"page"` // next page to fetch
+	Done bool      `json:"done"`
+}
+
+// eligibleScanStats reports the work done by a scan
+type eligibleScanStats struct {
+	Checked    int     `json:"total_checked"`
+	APIPages   int     `json:"api_pages_used"`
+	Bands      int     `json:"bands"`
+	LevelFloor float64 `json:"level_floor"`
+	Strategy   string  `json:"strategy"`
+	// MaxPagesReached is set when the scan stopped at MaxPages
+	MaxPagesReached bool `json:"max_pages_reached"`
+}
+
+// bandCursor tracks the scan position within one band
+type bandCursor struct {
+	band      levelBand
+	page      int                            // next page to fetch
+	pages     *api.Paginator[api.CursusUser] // created on the first load
+	pending   []api.CursusUser               // fetched but not yet visited
+	exhausted bool                           // no pages left to fetch
+}
+
+func (c *bandCursor) finished() bool {
+	return c.exhausted && len(c.pending) == 0
+}
+
+// scanCandidates passes cursus users to accept, highest level bands first,
+// until accept has returned true limit times or the bands are exhausted.
+// Depth mode drains each band in turn; breadth mode runs rounds in which
+// every band contributes its share of the limit, fetching at most one page.
+// Up to scan.Workers candidates are checked at once, so accept must be safe
+// for concurrent use and may be called up to Workers-1 times past the limit.
+func scanCandidates(ctx context.Context, lister cursusUserLister, scan eligibleScan, accept func(api.CursusUser) bool) (*eligibleScanStats, error) {
+	stats := &eligibleScanStats{LevelFloor: scan.MinLevel, Strategy: scan.Strategy}
+
+	// Band bounds are inclusive, so users exactly on a boundary show up twice
+	seen := make(map[int]bool)
+	var cursors []*bandCursor
+	if scan.Resume != nil && len(scan.Resume.Bands) > 0 {
+		for _, b := range scan.Resume.Bands {
+			cursors = append(cursors, &bandCursor{band: b.Band, page: b.Page, exhausted: b.Done})
+		}
+		for _, id := range scan.Resume.Seen {
+			seen[id] = true
+		}
+	} else {
+		// One cheap request finds the highest level, so empty bands above
+		// it are never requested
+		top, _, err := lister.ListCursusUsers(ctx, scan.CursusID, &api.ListCursusUsersOptions{
+			Page:     1,
+			PerPage:  1,
+			CampusID: scan.CampusID,
+			Sort:     "-level",
+			MinLevel: scan.MinLevel,
+			MaxLevel: scan.MaxLevel,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cursus users: %w", err)
+		}
+		stats.APIPages++
+		if len(top) == 0 {
+			return stats, nil
+		}
+		for _, band := range levelBands(top[0].Level, scan.MinLevel, scan.BandWidth, scan.MaxLevel) {
+			cursors = append(cursors, &bandCursor{band: band, page: 1})
+		}
+	}
+	stats.Bands = len(cursors)
+
+	perPage := api.DefaultPerPage
+	if scan.Strategy == strategyBreadth {
+		perPage = breadthPageSize
+	}
+
+	pages := 0
+	load := func(cursor *bandCursor) error {
+		if cursor.pages == nil {
+			band := cursor.band
+			cursor.pages = api.NewPaginator(ctx, perPage, func(ctx context.Context, page int) ([]api.CursusUser, *api.PaginationMeta, error) {
+				return lister.ListCursusUsers(ctx, scan.CursusID, &api.ListCursusUsersOptions{
+					Page:     page,
+					PerPage:  perPage,
+					CampusID: scan.CampusID,
+					Sort:     "-level",
+					MinLevel: band.Min,
+					MaxLevel: band.Max,
+				})
+			}).From(cursor.page)
+		}
+		cursusUsers, err := cursor.pages.Next()
+		if err != nil {
+			return fmt.Errorf("failed to list cursus users: %w", err)
+		}
+		stats.APIPages++
+		pages++
+
+		if GetVerbose() {
+			fmt.Fprintf(os.Stderr, "Band %.0f-%s, page %d: %d candidates\n",
+				cursor.band.Min, formatBandMax(cursor.band.Max), cursor.pages.Page(), len(cursusUsers))
+		}
+
+		if scan.Prefetch != nil {
+			scan.Prefetch(cursusUsers)
+		}
+		cursor.pending = cursusUsers
+		cursor.exhausted = !cursor.pages.HasNext()
+		cursor.page = cursor.pages.Page() + 1
+		return nil
+	}
+
+	// progress reports the position of the scan; a band whose page is
+	// partly checked resumes from that page
+	progress := func() {
+		if scan.Progress == nil {
+			return
+		}
+		var p scanProgress
+		for _, cursor := range cursors {
+			page := cursor.page
+			if len(cursor.pending) > 0 {
+				page--
+			}
+			p.Bands = append(p.Bands, bandProgress{Band: cursor.band, Page: page, Done: cursor.finished()})
+		}
+		for id := range seen {
+			p.Seen = append(p.Seen, id)
+		}
+		sort.Ints(p.Seen)
+		scan.Progress(p)
+	}
+
+	found := 0
+	workers := max(scan.Workers, 1)
+
+	// visit walks a band until quota users are accepted (0: no quota) or
+	// maxLoads pages were fetched (0: no cap), and reports whether the scan
+	// is over: the limit or scan.MaxPages is reached
+	visit := func(cursor *bandCursor, quota, maxLoads int) (bool, error) {
+		accepted, loads := 0, 0
+		for quota == 0 || accepted < quota {
+			if len(cursor.pending) == 0 {
+				if cursor.exhausted || (maxLoads > 0 && loads >= maxLoads) {
+					return false, nil
+				}
+				if scan.MaxPages > 0 && pages >= scan.MaxPages {
+					stats.MaxPagesReached = true
+					progress()
+					return true, nil
+				}
+				if err := load(cursor); err != nil {
+					return false, err
+				}
+				loads++
+				continue
+			}
+
+			// Check the next unseen candidates of the page together
+			var batch []api.CursusUser
+			for len(batch) < workers && len(cursor.pending) > 0 {
+				cu := cursor.pending[0]
+				cursor.pending = cursor.pending[1:]
+				if seen[cu.ID] {
+					continue
+				}
+				seen[cu.ID] = true
+				batch = append(batch, cu)
+			}
+			stats.Checked += len(batch)
+
+			for _, ok := range runWorkers(batch, workers, accept) {
+				if ok {
+					accepted++
+					found++
+				}
+			}
+			progress()
+			if found >= scan.Limit {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	if scan.Strategy == strategyBreadth {
+		quota := (scan.Limit + len(cursors) - 1) / len(cursors)
+		for {
+			active := false
+			for _, cursor := range cursors {
+				if cursor.finished() {
+					continue
+				}
+				active = true
+				if full, err := visit(cursor, quota, 1); err != nil || full {
+					return stats, err
+				}
+			}
+			if !active {
+				return stats, nil
+			}
+		}
+	}
+
+	for _, cursor := range cursors {
+		if full, err := visit(cursor, 0, 0); err != nil || full {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// formatBandMax renders the upper bound of a band for messages
+func formatBandMax(max float64) string {
+	if max == 0 {
+		return "max"
+	}
+	return fmt.Sprintf("%.0f", max)
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestLevelBands(t *testing.T) {
+	tests := []struct {
+		name                   string
+		top, floor, width, max float64
+		want                   []levelBand
+	}{
+		{
+			name: "open top", top: 12.4, floor: 9, width: 1,
+			want: []levelBand{{12, 0}, {11, 12}, {10, 11}, {9, 10}},
+		},
+		{
+			name: "floor inside a band", top: 7.2, floor: 4.5, width: 2, max: 8,
+			want: []levelBand{{6, 8}, {4.5, 6}},
+		},
+		{
+			name: "single band", top: 0.5, floor: 0, width: 1,
+			want: []levelBand{{0, 0}},
+		},
+		{
+			name: "no banding", top: 10, floor: 3, width: 0,
+			want: []levelBand{{3, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := levelBands(tt.top, tt.floor, tt.width, tt.max)
+			if len(got) != len(tt.want) {
+				t.Fatalf("levelBands() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("band %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestImpliedLevelFloor(t *testing.T) {
+	if got := impliedLevelFloor([]string{"common-core-rank-02", "common-core-rank-05", "unknown"}); got != 5 {
+		t.Errorf("impliedLevelFloor() = %v, want 5", got)
+	}
+	if got := impliedLevelFloor(nil); got != 0 {
+		t.Errorf("impliedLevelFloor(nil) = %v, want 0", got)
+	}
+}
+
+// fakeCursusUsers serves cursus users sorted by level, honouring the level range
+type fakeCursusUsers struct {
+	users []api.CursusUser
+	calls int
+}
+
+func (f *fakeCursusUsers) ListCursusUsers(ctx context.Context, cursusID int, opts *api.ListCursusUsersOptions) ([]api.CursusUser, *api.PaginationMeta, error) {
+	f.calls++
+	var matching []api.CursusUser
+	for _, cu := range f.users {
+		if cu.Level >= opts.MinLevel && (opts.MaxLevel == 0 || cu.Level <= opts.MaxLevel) {
+			matching = append(matching, cu)
+		}
+	}
+	start := (opts.Page - 1) * opts.PerPage
+	if start >= len(matching) {
+		return nil, &api.PaginationMeta{}, nil
+	}
+	end := min(start+opts.PerPage, len(matching))
+	return matching[start:end], &api.PaginationMeta{}, nil
+}
+
+func TestScanCandidates(t *testing.T) {
+	// 10 users per level from 9.95 down to 0.05, sorted by level descending
+	var users []api.CursusUser
+	for level := 9; level >= 0; level-- {
+		for i := 9; i >= 0; i-- {
+			id := level*10 + i
+			users = append(users, api.CursusUser{ID: id, Level: float64(level) + float64(i)/10 + 0.05})
+		}
+	}
+
+	t.Run("depth stops at the limit in the top band", func(t *testing.T) {
+		lister := &fakeCursusUsers{users: users}
+		var accepted []float64
+		stats, err := scanCandidates(context.Background(), lister, eligibleScan{BandWidth: 1, Strategy: strategyDepth, Limit: 3},
+			func(cu api.CursusUser) bool {
+				accepted = append(accepted, cu.Level)
+				return true
+			})
+		if err != nil {
+			t.Fatalf("scanCandidates() error = %v", err)
+		}
+		if len(accepted) != 3 || accepted[0] < 9 {
+			t.Errorf("accepted %v, want the 3 highest levels", accepted)
+		}
+		if lister.calls != 2 || stats.APIPages != 2 {
+			t.Errorf("used %d calls (stats %d), want probe + one page", lister.calls, stats.APIPages)
+		}
+	})
+
+	t.Run("floor skips lower bands", func(t *testing.T) {
+		lister := &fakeCursusUsers{users: users}
+		stats, err := scanCandidates(context.Background(), lister, eligibleScan{MinLevel: 7, BandWidth: 1, Strategy: strategyDepth, Limit: 100},
+			func(cu api.CursusUser) bool {
+				if cu.Level < 7 {
+					t.Errorf("candidate at level %.2f is below the floor", cu.Level)
+				}
+				return false
+			})
+		if err != nil {
+			t.Fatalf("scanCandidates() error = %v", err)
+		}
+		if stats.Bands != 3 || stats.Checked != 30 {
+			t.Errorf("stats = %+v, want 3 bands and 30 candidates", stats)
+		}
+	})
+
+	t.Run("breadth visits every band", func(t *testing.T) {
+		lister := &fakeCursusUsers{users: users}
+		bands := make(map[int]bool)
+		_, err := scanCandidates(context.Background(), lister, eligibleScan{BandWidth: 2, Strategy: strategyBreadth, Limit: 5},
+			func(cu api.CursusUser) bool {
+				bands[int(cu.Level)/2] = true
+				return cu.Level < 8 // nobody in the top band qualifies
+			})
+		if err != nil {
+			t.Fatalf("scanCandidates() error = %v", err)
+		}
+		if len(bands) != 5 {
+			t.Errorf("breadth scan visited %d bands, want 5", len(bands))
+		}
+	})
+
+	t.Run("workers check candidates concurrently", func(t *testing.T) {
+		lister := &fakeCursusUsers{users: users}
+		var mu sync.Mutex
+		var accepted int
+		stats, err := scanCandidates(context.Background(), lister, eligibleScan{BandWidth: 1, Strategy: strategyDepth, Limit: 3, Workers: 4},
+			func(cu api.CursusUser) bool {
+				mu.Lock()
+				defer mu.Unlock()
+				accepted++
+				return true
+			})
+		if err != nil {
+			t.Fatalf("scanCandidates() error = %v", err)
+		}
+		// The first batch of 4 already reaches the limit
+		if stats.Checked != 4 || accepted != 4 {
+			t.Errorf("checked %d (accepted %d), want one batch of 4", stats.Checked, accepted)
+		}
+	})
+
+	t.Run("prefetch gets each page before its candidates", func(t *testing.T) {
+		lister := &fakeCursusUsers{users: users}
+		prefetched := make(map[int]bool)
+		scan := eligibleScan{MinLevel: 8, BandWidth: 1, Strategy: strategyDepth, Limit: 100}
+		scan.Prefetch = func(page []api.CursusUser) {
+			for _, cu := range page {
+				prefetched[cu.ID] = true
+			}
+		}
+		_, err := scanCandidates(context.Background(), lister, scan, func(cu api.CursusUser) bool {
+			if !prefetched[cu.ID] {
+				t.Errorf("candidate %d checked before its page was prefetched", cu.ID)
+			}
+			return false
+		})
+		if err != nil {
+			t.Fatalf("scanCandidates() error = %v", err)
+		}
+		if len(prefetched) != 20 {
+			t.Errorf("prefetched %d candidates, want 20", len(prefetched))
+		}
+	})
+
+	t.Run("max pages stops the scan and resume continues it", func(t *testing.T) {
+		// Two and a half pages of candidates in a single band
+		var many []api.CursusUser
+		for i := 0; i < 2*api.DefaultPerPage+api.DefaultPerPage/2; i++ {
+			many = append(many, api.CursusUser{ID: 1000 + i, Level: 9.5})
+		}
+		lister := &fakeCursusUsers{users: many}
+		var last scanProgress
+		checked := make(map[int]int)
+		scan := eligibleScan{MinLevel: 9, BandWidth: 1, Strategy: strategyDepth, Limit: 1000, MaxPages: 2}
+		scan.Progress = func(p scanProgress) { last = p }
+		accept := func(cu api.CursusUser) bool {
+			checked[cu.ID]++
+			return false
+		}
+
+		stats, err := scanCandidates(context.Background(), lister, scan, accept)
+		if err != nil {
+			t.Fatalf("scanCandidates() error = %v", err)
+		}
+		if !stats.MaxPagesReached || stats.Checked != 2*api.DefaultPerPage {
+			t.Fatalf("stats = %+v, want two pages checked and max pages reached", stats)
+		}
+		want := []bandProgress{{Band: levelBand{9, 0}, Page: 3}}
+		if len(last.Seen) != 2*api.DefaultPerPage || len(last.Bands) != 1 || last.Bands[0] != want[0] {
+			t.Fatalf("progress = %+v (%d seen), want %+v", last.Bands, len(last.Seen), want)
+		}
+
+		scan.Resume = &last
+		scan.MaxPages = 0
+		calls := lister.calls
+		stats, err = scanCandidates(context.Background(), lister, scan, accept)
+		if err != nil {
+			t.Fatalf("resumed scanCandidates() error = %v", err)
+		}
+		if stats.Checked != api.DefaultPerPage/2 || stats.MaxPagesReached {
+			t.Errorf("resumed stats = %+v, want the last half page", stats)
+		}
+		// No probe, only the last page
+		if lister.calls-calls != 1 {
+			t.Errorf("resumed scan made %d calls, want 1", lister.calls-calls)
+		}
+		if len(checked) != len(many) {
+			t.Errorf("checked %d candidates over both runs, want %d", len(checked), len(many))
+		}
+		for id, n := range checked {
+			if n != 1 {
+				t.Errorf("candidate %d checked %d times", id, n)
+			}
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var evalCmd = &cobra.Command{
+	Use:     "eval",
+	Aliases: []string{"evaluation"},
+	Short:   "Evaluation (scale team) commands",
+	Long: `Inspect 42 evaluations (scale teams).
+
+This command group covers evaluations you gave as a corrector and
+evaluations of your teams.`,
+}
+
+func init() {
+	// Add eval command to root
+	rootCmd.AddCommand(evalCmd)
+}
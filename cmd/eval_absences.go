@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+var evalAbsencesCmd = &cobra.Command{
+	Use:   "absences",
+	Short: "List evaluation no-shows",
+	Long: `List evaluations where someone was marked absent (truant).
+
+By default this lists your own absences and those of the members of your
+recent teams. With --corrector, it instead computes how often a corrector
+missed the evaluations they booked, and warns when the rate is high -
+check it before accepting an evaluation with that corrector.
+
+Examples:
+  t42 eval absences
+  t42 eval absences --days 30 --no-team
+  t42 eval absences --corrector jdoe`,
+	RunE: runEvalAbsences,
+}
+
+// evalAbsence is an evaluation where one participant did not show up
+type evalAbsence struct {
+	ScaleTeamID int       `json:"scale_team_id"`
+	BeginAt     time.Time `json:"begin_at"`
+	Login       string    `json:"login"`
+	Role        string    `json:"role"` // "corrector" or "corrected"
+	Team        string    `json:"team"`
+	Scale       string    `json:"scale"`
+}
+
+// absenceStats summarizes a corrector's attendance over past evaluations
+type absenceStats struct {
+	Evaluations int     `json:"evaluations"`
+	Absences    int     `json:"absences"`
+	Rate        float64 `json:"rate"`
+}
+
+func init() {
+	evalCmd.AddCommand(evalAbsencesCmd)
+
+	evalAbsencesCmd.Flags().Int("days", 90, "Look back this many days")
+	evalAbsencesCmd.Flags().Bool("no-team", false, "Only list your own absences, not your teammates'")
+	evalAbsencesCmd.Flags().String("corrector", "", "Show the absence rate of a corrector instead")
+	evalAbsencesCmd.Flags().Float64("warn-rate", 0.2, "Warn when a corrector's absence rate reaches this fraction")
+}
+
+func runEvalAbsences(cmd *cobra.Command, args []string) error {
+	days, _ := cmd.Flags().GetInt("days")
+	noTeam, _ := cmd.Flags().GetBool("no-team")
+	corrector, _ := cmd.Flags().GetString("corrector")
+	warnRate, _ := cmd.Flags().GetFloat64("warn-rate")
+
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+
+	// Create API client with automatic token refresh
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	until := time.Now()
+	since := until.AddDate(0, 0, -days)
+
+	if corrector != "" {
+		return runCorrectorAbsences(ctx, client, corrector, since, until, warnRate)
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	// Collect the users to check: me, then teammates from teams formed in the window
+	subjects := map[int]string{me.ID: me.Login}
+	if !noTeam {
+		teams, _, err := client.ListUserTeams(ctx, me.ID, &api.ListTeamsOptions{Since: since, Sort: "-created_at"})
+		if err != nil {
+			return fmt.Errorf("failed to list teams: %w", err)
+		}
+		for _, team := range teams {
+			for _, member := range team.Users {
+				subjects[member.ID] = member.Login
+			}
+		}
+	}
+
+	var scaleTeams []api.ScaleTeam
+	for userID, login := range subjects {
+		fetched, err := fetchAllUserScaleTeams(ctx, client, userID, api.ScaleTeamAnyRole, since, until)
+		if err != nil {
+			return fmt.Errorf("failed to list evaluations of %s: %w", login, err)
+		}
+		scaleTeams = append(scaleTeams, fetched...)
+	}
+
+	absences := findAbsences(scaleTeams, subjects)
+
+	result := map[string]interface{}{
+		"since":    since,
+		"users":    sortedLogins(subjects),
+		"absences": absences,
+	}
+	if done, err := emitOutputValue(result); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("🙈 Evaluation absences since %s (%s)\n", since.Format("2006-01-02"), strings.Join(sortedLogins(subjects), ", "))
+	if len(absences) == 0 {
+		fmt.Println("No absences found.")
+		return nil
+	}
+
+	fmt.Printf("\n%-17s %-15s %-10s %s\n", "DATE", "LOGIN", "ROLE", "TEAM")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, a := range absences {
+		fmt.Printf("%-17s %-15s %-10s %s\n",
+			a.BeginAt.Local().Format("2006-01-02 15:04"),
+			truncateString(a.Login, 15),
+			a.Role,
+			truncateString(a.Team, 30))
+	}
+	fmt.Printf("\nTotal: %d absences\n", len(absences))
+
+	return nil
+}
+
+// runCorrectorAbsences prints a corrector's absence rate over past evaluations
+func runCorrectorAbsences(ctx context.Context, client *api.Client, login string, since, until time.Time, warnRate float64) error {
+	user, err := client.GetUserByLogin(ctx, login)
+	if err != nil {
+		return fmt.Errorf("failed to get user '%s': %w", login, err)
+	}
+
+	scaleTeams, err := fetchAllUserScaleTeams(ctx, client, user.ID, api.ScaleTeamAsCorrector, since, until)
+	if err != nil {
+		return fmt.Errorf("failed to list evaluations of %s: %w", login, err)
+	}
+
+	stats := correctorAbsenceStats(scaleTeams, user.ID, until)
+	warn := stats.Evaluations > 0 && stats.Rate >= warnRate
+
+	result := map[string]interface{}{
+		"login":   user.Login,
+		"since":   since,
+		"stats":   stats,
+		"warning": warn,
+	}
+	if done, err := emitOutputValue(result); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("👤 %s as corrector since %s\n", user.Login, since.Format("2006-01-02"))
+	fmt.Printf("📝 Evaluations: %d\n", stats.Evaluations)
+	fmt.Printf("🙈 Absences:    %d (%.0f%%)\n", stats.Absences, stats.Rate*100)
+	if warn {
+		fmt.Printf("⚠️  %s missed %.0f%% of recent evaluations - consider another corrector\n", user.Login, stats.Rate*100)
+	}
+
+	return nil
+}
+
+// fetchAllUserScaleTeams follows pagination to collect every evaluation scheduled in [since, until]
+func fetchAllUserScaleTeams(ctx context.Context, client *api.Client, userID int, role api.ScaleTeamRole, since, until time.Time) ([]api.ScaleTeam, error) {
+	var all []api.ScaleTeam
+	err := api.FetchAll(ctx, api.DefaultPerPage,
+		func(ctx context.Context, page int) ([]api.ScaleTeam, *api.PaginationMeta, error) {
+			return client.ListUserScaleTeams(ctx, userID, role, &api.ListScaleTeamsOptions{
+				Page:    page,
+				PerPage: api.DefaultPerPage,
+				Sort:    "-begin_at",
+				Since:   since,
+				Until:   until,
+			})
+		},
+		func(scaleTeams []api.ScaleTeam, _ *api.PaginationMeta) error {
+			all = append(all, scaleTeams...)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// findAbsences returns the evaluations where one of the given users was truant,
+// most recent first. Evaluations shared by several users are reported once.
+func findAbsences(scaleTeams []api.ScaleTeam, users map[int]string) []evalAbsence {
+	seen := make(map[int]bool)
+	absences := make([]evalAbsence, 0)
+
+	for _, st := range scaleTeams {
+		if st.Truant == nil || seen[st.ID] {
+			continue
+		}
+		login, ok := users[st.Truant.ID]
+		if !ok {
+			continue
+		}
+		seen[st.ID] = true
+
+		role := "corrected"
+		if st.Corrector != nil && st.Corrector.ID == st.Truant.ID {
+			role = "corrector"
+		}
+
+		absences = append(absences, evalAbsence{
+			ScaleTeamID: st.ID,
+			BeginAt:     st.BeginAt,
+			Login:       login,
+			Role:        role,
+			Team:        st.Team.Name,
+			Scale:       st.Scale.Name,
+		})
+	}
+
+	sort.Slice(absences, func(i, j int) bool {
+		return absences[i].BeginAt.After(absences[j].BeginAt)
+	})
+	return absences
+}
+
+// correctorAbsenceStats counts the evaluations a corrector was expected at
+// before now and how many of them they missed
+func correctorAbsenceStats(scaleTeams []api.ScaleTeam, correctorID int, now time.Time) absenceStats {
+	var stats absenceStats
+	seen := make(map[int]bool)
+
+	for _, st := range scaleTeams {
+		if seen[st.ID] || !st.BeginAt.Before(now) {
+			continue
+		}
+		if st.Corrector != nil && st.Corrector.ID != correctorID {
+			continue
+		}
+		seen[st.ID] = true
+
+		stats.Evaluations++
+		if st.Truant != nil && st.Truant.ID == correctorID {
+			stats.Absences++
+		}
+	}
+
+	if stats.Evaluations > 0 {
+		stats.Rate = float64(stats.Absences) / float64(stats.Evaluations)
+	}
+	return stats
+}
+
+// sortedLogins returns the logins of a user map in alphabetical order
+func sortedLogins(users map[int]string) []string {
+	logins := make([]string, 0, len(users))
+	for _, login := range users {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	return logins
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestFindAbsences(t *testing.T) {
+	base := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	me := api.User{ID: 1, Login: "me"}
+	mate := api.User{ID: 2, Login: "mate"}
+	other := api.User{ID: 3, Login: "other"}
+
+	scaleTeams := []api.ScaleTeam{
+		{ID: 10, BeginAt: base, Corrector: &other, Truant: &me, Team: api.Team{Name: "me's group"}},
+		{ID: 11, BeginAt: base.Add(time.Hour), Corrector: &mate, Truant: &mate},
+		{ID: 12, BeginAt: base.Add(2 * time.Hour), Corrector: &other, Truant: &other},
+		{ID: 13, BeginAt: base.Add(3 * time.Hour), Corrector: &other},
+		// Same evaluation fetched through a teammate
+		{ID: 10, BeginAt: base, Corrector: &other, Truant: &me, Team: api.Team{Name: "me's group"}},
+	}
+
+	got := findAbsences(scaleTeams, map[int]string{me.ID: me.Login, mate.ID: mate.Login})
+
+	if len(got) != 2 {
+		t.Fatalf("findAbsences() returned %d absences, want 2: %+v", len(got), got)
+	}
+
+	tests := []struct {
+		index     int
+		wantID    int
+		wantLogin string
+		wantRole  string
+	}{
+		{0, 11, "mate", "corrector"},
+		{1, 10, "me", "corrected"},
+	}
+	for _, tt := range tests {
+		a := got[tt.index]
+		if a.ScaleTeamID != tt.wantID || a.Login != tt.wantLogin || a.Role != tt.wantRole {
+			t.Errorf("absence[%d] = %+v, want id=%d login=%s role=%s", tt.index, a, tt.wantID, tt.wantLogin, tt.wantRole)
+		}
+	}
+}
+
+func TestCorrectorAbsenceStats(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	corrector := api.User{ID: 7, Login: "eval"}
+	past := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		scaleTeams []api.ScaleTeam
+		want       absenceStats
+	}{
+		{
+			name:       "no evaluations",
+			scaleTeams: nil,
+			want:       absenceStats{},
+		},
+		{
+			name: "absences over past evaluations",
+			scaleTeams: []api.ScaleTeam{
+				{ID: 1, BeginAt: past, Corrector: &corrector, Truant: &corrector},
+				{ID: 2, BeginAt: past, Corrector: &corrector},
+				{ID: 3, BeginAt: past, Corrector: &corrector},
+				{ID: 4, BeginAt: past, Corrector: &corrector, Truant: &corrector},
+			},
+			want: absenceStats{Evaluations: 4, Absences: 2, Rate: 0.5},
+		},
+		{
+			name: "upcoming and duplicate evaluations are ignored",
+			scaleTeams: []api.ScaleTeam{
+				{ID: 1, BeginAt: past, Corrector: &corrector, Truant: &corrector},
+				{ID: 1, BeginAt: past, Corrector: &corrector, Truant: &corrector},
+				{ID: 2, BeginAt: now.Add(time.Hour), Corrector: &corrector},
+			},
+			want: absenceStats{Evaluations: 1, Absences: 1, Rate: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := correctorAbsenceStats(tt.scaleTeams, corrector.ID, now); got != tt.want {
+				t.Errorf("correctorAbsenceStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
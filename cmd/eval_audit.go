@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var evalAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Staff report: correctors whose marks deviate from final outcomes",
+	Long: `Fairness audit for campus staff. For every evaluation of the campus in
+the date range, the mark the corrector gave is compared with the final
+mark of the team. Correctors whose marks are on average at least
+--threshold points below (harsh) or above (generous) the outcome, over at
+least --min-evaluations evaluations, are reported.
+
+Evaluations without marks, with a negative flag (cheat, no show, ...) or
+with an absent participant are left out. Correctors are only visible to
+the application, so this report uses an app token (client credentials).
+
+Output is anonymized by default: correctors are numbered instead of
+named. Use --reveal to show logins.
+
+Examples:
+  t42 eval audit --campus tokyo
+  t42 eval audit --campus tokyo --since 2024-01-01 --until 2024-03-31
+  t42 eval audit --campus tokyo --threshold 10 --all --reveal`,
+	Args: cobra.NoArgs,
+	RunE: runEvalAudit,
+}
+
+// correctorAudit is the marking record of one corrector
+type correctorAudit struct {
+	Corrector        string  `json:"corrector"` // login, or a pseudonym unless --reveal
+	Evaluations      int     `json:"evaluations"`
+	MeanGiven        float64 `json:"mean_given"`
+	MeanFinal        float64 `json:"mean_final"`
+	MeanDeviation    float64 `json:"mean_deviation"` // given - final; negative is harsher
+	MeanAbsDeviation float64 `json:"mean_abs_deviation"`
+	Verdict          string  `json:"verdict,omitempty"` // "harsh" or "generous"
+}
+
+// fairnessAudit is the result of the audit
+type fairnessAudit struct {
+	Evaluations   int              `json:"evaluations"` // evaluations analyzed
+	Excluded      int              `json:"excluded"`
+	Correctors    int              `json:"correctors"` // correctors with enough evaluations
+	MeanDeviation float64          `json:"mean_deviation"`
+	Audits        []correctorAudit `json:"audits"`
+}
+
+func init() {
+	evalCmd.AddCommand(evalAuditCmd)
+
+	addCampusFlags(evalAuditCmd)
+	evalAuditCmd.Flags().String("since", "", "Start date, YYYY-MM-DD (default: --days ago)")
+	evalAuditCmd.Flags().String("until", "", "End date, YYYY-MM-DD (default: now)")
+	evalAuditCmd.Flags().Int("days", 30, "Look back this many days when --since is not set")
+	evalAuditCmd.Flags().Float64("threshold", 15, "Flag correctors whose mean deviation reaches this many points")
+	evalAuditCmd.Flags().Int("min-evaluations", 5, "Only judge correctors with at least this many evaluations")
+	evalAuditCmd.Flags().Bool("all", false, "List every corrector, not only outliers")
+	evalAuditCmd.Flags().Bool("reveal", false, "Show corrector logins instead of pseudonyms")
+}
+
+func runEvalAudit(cmd *cobra.Command, args []string) error {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+	days, _ := cmd.Flags().GetInt("days")
+	threshold, _ := cmd.Flags().GetFloat64("threshold")
+	minEvaluations, _ := cmd.Flags().GetInt("min-evaluations")
+	all, _ := cmd.Flags().GetBool("all")
+	reveal, _ := cmd.Flags().GetBool("reveal")
+
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	if threshold <= 0 {
+		return fmt.Errorf("--threshold must be positive")
+	}
+	if minEvaluations < 1 {
+		return fmt.Errorf("--min-evaluations must be at least 1")
+	}
+
+	until := time.Now()
+	if untilFlag != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", untilFlag, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --until %q (use YYYY-MM-DD)", untilFlag)
+		}
+		until = parsed.AddDate(0, 0, 1) // include the whole day
+	}
+	since := until.AddDate(0, 0, -days)
+	if sinceFlag != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", sinceFlag, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since %q (use YYYY-MM-DD)", sinceFlag)
+		}
+		since = parsed
+	}
+	if !since.Before(until) {
+		return fmt.Errorf("--since must be before --until")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	campus, err := requireCampus(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+
+	appClient, err := newAppClient(ctx)
+	if err != nil {
+		return fmt.Errorf("%w (needed to see correctors)", err)
+	}
+
+	scaleTeams, err := fetchCampusScaleTeams(ctx, appClient, campus.ID, since, until)
+	if err != nil {
+		return err
+	}
+
+	audit := buildFairnessAudit(scaleTeams, minEvaluations, threshold, reveal)
+	if !all {
+		outliers := make([]correctorAudit, 0)
+		for _, a := range audit.Audits {
+			if a.Verdict != "" {
+				outliers = append(outliers, a)
+			}
+		}
+		audit.Audits = outliers
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "campus", Value: campus.Name},
+		output.Field{Key: "since", Value: since},
+		output.Field{Key: "until", Value: until},
+		output.Field{Key: "threshold", Value: threshold},
+		output.Field{Key: "anonymized", Value: !reveal},
+		output.Field{Key: "audit", Value: audit},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("⚖️  Evaluation fairness at %s, %s to %s\n", campus.Name, since.Format("2006-01-02"), until.AddDate(0, 0, -1).Format("2006-01-02"))
+	fmt.Printf("📝 %d evaluations analyzed (%d excluded), %d correctors with %d+ evaluations\n",
+		audit.Evaluations, audit.Excluded, audit.Correctors, minEvaluations)
+	fmt.Printf("📊 Campus mean deviation: %+.1f points\n\n", audit.MeanDeviation)
+
+	if len(audit.Audits) == 0 {
+		fmt.Printf("No corrector deviates by %.0f points or more.\n", threshold)
+		return nil
+	}
+
+	fmt.Printf("%-18s %6s %8s %8s %10s %9s  %s\n", "CORRECTOR", "EVALS", "GIVEN", "FINAL", "DEVIATION", "ABS DEV", "VERDICT")
+	fmt.Println(strings.Repeat("-", 78))
+	for _, a := range audit.Audits {
+		verdict := ""
+		switch a.Verdict {
+		case "harsh":
+			verdict = "🥶 harsh"
+		case "generous":
+			verdict = "🎁 generous"
+		}
+		fmt.Printf("%-18s %6d %8.1f %8.1f %+10.1f %9.1f  %s\n",
+			truncateString(a.Corrector, 18), a.Evaluations, a.MeanGiven, a.MeanFinal,
+			a.MeanDeviation, a.MeanAbsDeviation, verdict)
+	}
+	if !reveal {
+		fmt.Println("\nCorrectors are anonymized; use --reveal to show logins.")
+	}
+	return nil
+}
+
+// fetchCampusScaleTeams collects the filled evaluations of a campus in [since, until)
+func fetchCampusScaleTeams(ctx context.Context, client *api.Client, campusID int, since, until time.Time) ([]api.ScaleTeam, error) {
+	filled := true
+	var all []api.ScaleTeam
+	pages := client.ScaleTeamsPaginator(ctx, &api.ListScaleTeamsOptions{
+		Sort:     "begin_at",
+		CampusID: campusID,
+		Filled:   &filled,
+		Since:    since,
+		Until:    until,
+	})
+	for pages.HasNext() {
+		scaleTeams, err := pages.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list evaluations: %w", err)
+		}
+		all = append(all, scaleTeams...)
+		if GetVerbose() {
+			fmt.Fprintf(os.Stderr, "Fetched %d evaluations (page %d)\n", len(all), pages.Page())
+		}
+	}
+	return all, nil
+}
+
+// buildFairnessAudit compares each corrector's marks with the teams' final
+// marks. Audits are sorted harshest first; pseudonyms follow that order.
+func buildFairnessAudit(scaleTeams []api.ScaleTeam, minEvaluations int, threshold float64, reveal bool) fairnessAudit {
+	type record struct {
+		login        string
+		given, final []float64
+	}
+	records := make(map[int]*record)
+	seen := make(map[int]bool)
+
+	var audit fairnessAudit
+	var totalDeviation float64
+	for _, st := range scaleTeams {
+		if seen[st.ID] {
+			continue
+		}
+		seen[st.ID] = true
+		if !auditable(st) {
+			audit.Excluded++
+			continue
+		}
+
+		r, ok := records[st.Corrector.ID]
+		if !ok {
+			r = &record{login: st.Corrector.Login}
+			records[st.Corrector.ID] = r
+		}
+		given, final := float64(*st.FinalMark), float64(*st.Team.FinalMark)
+		r.given = append(r.given, given)
+		r.final = append(r.final, final)
+		audit.Evaluations++
+		totalDeviation += given - final
+	}
+	if audit.Evaluations > 0 {
+		audit.MeanDeviation = totalDeviation / float64(audit.Evaluations)
+	}
+
+	audits := make([]correctorAudit, 0)
+	for _, r := range records {
+		n := len(r.given)
+		if n < minEvaluations {
+			continue
+		}
+		a := correctorAudit{Corrector: r.login, Evaluations: n}
+		for i := range r.given {
+			deviation := r.given[i] - r.final[i]
+			a.MeanGiven += r.given[i]
+			a.MeanFinal += r.final[i]
+			a.MeanDeviation += deviation
+			a.MeanAbsDeviation += math.Abs(deviation)
+		}
+		a.MeanGiven /= float64(n)
+		a.MeanFinal /= float64(n)
+		a.MeanDeviation /= float64(n)
+		a.MeanAbsDeviation /= float64(n)
+
+		switch {
+		case a.MeanDeviation <= -threshold:
+			a.Verdict = "harsh"
+		case a.MeanDeviation >= threshold:
+			a.Verdict = "generous"
+		}
+		audits = append(audits, a)
+	}
+
+	sort.Slice(audits, func(i, j int) bool {
+		if audits[i].MeanDeviation != audits[j].MeanDeviation {
+			return audits[i].MeanDeviation < audits[j].MeanDeviation
+		}
+		return audits[i].Corrector < audits[j].Corrector
+	})
+	if !reveal {
+		width := len(fmt.Sprint(len(audits)))
+		for i := range audits {
+			audits[i].Corrector = fmt.Sprintf("corrector-%0*d", max(width, 2), i+1)
+		}
+	}
+
+	audit.Correctors = len(audits)
+	audit.Audits = audits
+	return audit
+}
+
+// auditable reports whether an evaluation's mark reflects the corrector's judgment
+func auditable(st api.ScaleTeam) bool {
+	switch {
+	case st.FinalMark == nil || st.Team.FinalMark == nil:
+		return false
+	case st.Corrector == nil || st.Corrector.ID == 0:
+		return false
+	case st.Flag != nil && !st.Flag.Positive:
+		return false
+	case st.Truant != nil:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestBuildFairnessAudit(t *testing.T) {
+	mark := func(n int) *int { return &n }
+	eval := func(id, corrector, given, final int) api.ScaleTeam {
+		return api.ScaleTeam{
+			ID:        id,
+			FinalMark: mark(given),
+			Corrector: &api.User{ID: corrector, Login: map[int]string{1: "harsh", 2: "fair", 3: "kind", 4: "rare"}[corrector]},
+			Team:      api.Team{FinalMark: mark(final)},
+		}
+	}
+
+	var scaleTeams []api.ScaleTeam
+	id := 0
+	for i := 0; i < 3; i++ {
+		id++
+		scaleTeams = append(scaleTeams, eval(id, 1, 60, 100))
+		id++
+		scaleTeams = append(scaleTeams, eval(id, 2, 100, 100))
+		id++
+		scaleTeams = append(scaleTeams, eval(id, 3, 100, 80))
+	}
+	scaleTeams = append(scaleTeams,
+		eval(100, 4, 0, 100), // too few evaluations to judge
+		eval(1, 1, 60, 100),  // duplicate
+		api.ScaleTeam{ID: 101, Corrector: &api.User{ID: 2}, Team: api.Team{FinalMark: mark(100)}}, // no mark
+		func() api.ScaleTeam { st := eval(102, 2, 0, 100); st.Flag = &api.Flag{Name: "Cheat"}; return st }(),
+		func() api.ScaleTeam { st := eval(103, 2, 0, 100); st.Truant = &api.User{ID: 9}; return st }(),
+	)
+
+	tests := []struct {
+		name      string
+		threshold float64
+		reveal    bool
+		want      []correctorAudit
+	}{
+		{
+			name:      "revealed",
+			threshold: 15,
+			reveal:    true,
+			want: []correctorAudit{
+				{Corrector: "harsh", Evaluations: 3, MeanDeviation: -40, Verdict: "harsh"},
+				{Corrector: "fair", Evaluations: 3, MeanDeviation: 0},
+				{Corrector: "kind", Evaluations: 3, MeanDeviation: 20, Verdict: "generous"},
+			},
+		},
+		{
+			name:      "anonymized with a higher threshold",
+			threshold: 30,
+			want: []correctorAudit{
+				{Corrector: "corrector-01", Evaluations: 3, MeanDeviation: -40, Verdict: "harsh"},
+				{Corrector: "corrector-02", Evaluations: 3, MeanDeviation: 0},
+				{Corrector: "corrector-03", Evaluations: 3, MeanDeviation: 20},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := buildFairnessAudit(scaleTeams, 3, tt.threshold, tt.reveal)
+			if audit.Evaluations != 10 || audit.Excluded != 3 || audit.Correctors != 3 {
+				t.Errorf("evaluations/excluded/correctors = %d/%d/%d, want 10/3/3",
+					audit.Evaluations, audit.Excluded, audit.Correctors)
+			}
+			if len(audit.Audits) != len(tt.want) {
+				t.Fatalf("got %d audits, want %d: %+v", len(audit.Audits), len(tt.want), audit.Audits)
+			}
+			for i, want := range tt.want {
+				got := audit.Audits[i]
+				if got.Corrector != want.Corrector || got.Evaluations != want.Evaluations ||
+					got.MeanDeviation != want.MeanDeviation || got.Verdict != want.Verdict {
+					t.Errorf("audit %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
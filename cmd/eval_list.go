@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var evalListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your upcoming and past evaluations",
+	Long: `List the evaluations you give (as corrector) and receive (as corrected):
+upcoming ones first, soonest at the top, then past ones, most recent first.
+
+Examples:
+  t42 eval list
+  t42 eval list --upcoming
+  t42 eval list --past --days 90
+  t42 eval list --upcoming --watch=1m   # Refresh until Ctrl-C`,
+	Args: cobra.NoArgs,
+	RunE: watchable(runEvalList),
+}
+
+var evalShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show the details of an evaluation",
+	Long: `Show an evaluation: project, time, corrector, team members, and once it
+is filled in, the mark, flag, comment and feedback.
+
+Examples:
+  t42 eval show 6543210`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEvalShow,
+}
+
+// evalEntry is an evaluation as seen by the logged-in user
+type evalEntry struct {
+	ID        int       `json:"id"`
+	BeginAt   time.Time `json:"begin_at"`
+	StartsIn  string    `json:"starts_in,omitempty"` // upcoming evaluations only
+	Role      string    `json:"role"`                // "corrector" or "corrected"
+	Status    string    `json:"status"`              // "upcoming", "pending" or "done"
+	ProjectID int       `json:"project_id"`
+	Project   string    `json:"project"`
+	Team      string    `json:"team"`
+	Members   []string  `json:"members"`
+	Corrector string    `json:"corrector,omitempty"` // empty while the API hides it
+	FinalMark *int      `json:"final_mark,omitempty"`
+	Flag      string    `json:"flag,omitempty"`
+}
+
+func init() {
+	evalCmd.AddCommand(evalListCmd)
+	evalCmd.AddCommand(evalShowCmd)
+
+	evalListCmd.Flags().Int("days", 30, "Include past evaluations from this many days back")
+	evalListCmd.Flags().Bool("upcoming", false, "Only list upcoming evaluations")
+	evalListCmd.Flags().Bool("past", false, "Only list past evaluations")
+	evalListCmd.MarkFlagsMutuallyExclusive("upcoming", "past")
+	addWatchModeFlag(evalListCmd)
+}
+
+func runEvalList(cmd *cobra.Command, args []string) error {
+	days, _ := cmd.Flags().GetInt("days")
+	upcoming, _ := cmd.Flags().GetBool("upcoming")
+	past, _ := cmd.Flags().GetBool("past")
+
+	if days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	now := time.Now()
+	since := now.AddDate(0, 0, -days)
+	until := time.Time{}
+	switch {
+	case upcoming:
+		since = now
+	case past:
+		until = now
+	}
+
+	scaleTeams, err := fetchMyScaleTeams(ctx, client, since, until)
+	if err != nil {
+		return err
+	}
+	projects := resolveProjectNames(ctx, client, scaleTeamProjectIDs(scaleTeams))
+	entries := buildEvalEntries(scaleTeams, me.ID, projects, now)
+
+	if done, err := emitOutput(
+		output.Field{Key: "evaluations", Value: entries},
+		output.Field{Key: "count", Value: len(entries)},
+	); done || err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No evaluations found in the last %d days or upcoming.\n", days)
+		return nil
+	}
+
+	fmt.Printf("%-9s %-17s %-11s %-11s %-20s %-24s %s\n", "ID", "WHEN", "IN", "ROLE", "PROJECT", "TEAM", "STATUS")
+	fmt.Println(strings.Repeat("-", 110))
+	for _, e := range entries {
+		role := "🧐 corrector"
+		if e.Role == "corrected" {
+			role = "🎓 corrected"
+		}
+		fmt.Printf("%-9d %-17s %-11s %-11s %-20s %-24s %s\n",
+			e.ID, e.BeginAt.Local().Format("Mon Jan 02 15:04"), e.StartsIn, role,
+			truncateString(e.Project, 20), truncateString(strings.Join(e.Members, ", "), 24), evalStatusLabel(e))
+	}
+	fmt.Printf("\nTotal: %d evaluations\n", len(entries))
+	return nil
+}
+
+func runEvalShow(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid evaluation ID %q", args[0])
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	st, err := client.GetScaleTeam(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get evaluation %d: %w", id, err)
+	}
+
+	projects := resolveProjectNames(ctx, client, scaleTeamProjectIDs([]api.ScaleTeam{*st}))
+	entry := newEvalEntry(*st, me.ID, projects, time.Now())
+
+	if done, err := emitOutput(
+		output.Field{Key: "evaluation", Value: entry},
+		output.Field{Key: "scale", Value: st.Scale.Name},
+		output.Field{Key: "comment", Value: st.Comment},
+		output.Field{Key: "feedback", Value: st.Feedback},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("📝 Evaluation %d: %s\n", entry.ID, entry.Project)
+	fmt.Printf("📅 When:      %s", entry.BeginAt.Local().Format("Mon Jan 02 2006 15:04"))
+	if entry.StartsIn != "" {
+		fmt.Printf(" (%s)", entry.StartsIn)
+	}
+	fmt.Println()
+	if st.Scale.Name != "" {
+		fmt.Printf("📏 Scale:     %s\n", st.Scale.Name)
+	}
+	fmt.Printf("👤 Your role: %s\n", entry.Role)
+	corrector := entry.Corrector
+	if corrector == "" {
+		corrector = "(hidden until the evaluation)"
+	}
+	fmt.Printf("🧐 Corrector: %s\n", corrector)
+	fmt.Printf("👥 Team:      %s (%s)\n", entry.Team, strings.Join(entry.Members, ", "))
+	fmt.Printf("📊 Status:    %s\n", evalStatusLabel(entry))
+
+	if st.Comment != "" {
+		fmt.Printf("\n💬 Comment:\n%s\n", strings.TrimSpace(st.Comment))
+	}
+	if st.Feedback != "" {
+		fmt.Printf("\n🗣️  Feedback:\n%s\n", strings.TrimSpace(st.Feedback))
+	}
+	return nil
+}
+
+// fetchMyScaleTeams follows pagination to collect the logged-in user's
+// evaluations scheduled in [since, until]; a zero bound is left open
+func fetchMyScaleTeams(ctx context.Context, client *api.Client, since, until time.Time) ([]api.ScaleTeam, error) {
+	all, err := client.MyScaleTeamsPaginator(ctx, &api.ListScaleTeamsOptions{
+		Sort:  "begin_at",
+		Since: since,
+		Until: until,
+	}).All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list evaluations: %w", err)
+	}
+	return all, nil
+}
+
+// scaleTeamProjectIDs returns the distinct project IDs of evaluations
+func scaleTeamProjectIDs(scaleTeams []api.ScaleTeam) []int {
+	seen := make(map[int]bool)
+	var ids []int
+	for _, st := range scaleTeams {
+		if id := st.Team.ProjectID; id > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// resolveProjectNames maps project IDs to names, from the cached project
+// index first. Lookup failures leave the project out of the map.
+func resolveProjectNames(ctx context.Context, client *api.Client, ids []int) map[int]string {
+	names := make(map[int]string)
+	if len(ids) == 0 {
+		return names
+	}
+
+	index, err := loadProjectIndex(ctx, client)
+	if err != nil {
+		warnf("project_index_unavailable", "project index unavailable, fetching project names one by one: %v", err)
+	}
+	for _, p := range index {
+		names[p.ID] = p.Name
+	}
+
+	for _, id := range ids {
+		if _, ok := names[id]; ok {
+			continue
+		}
+		project, err := client.GetProject(ctx, id)
+		if err != nil {
+			warnf("project_unnamed", "failed to get the name of project %d: %v", id, err)
+			continue
+		}
+		names[id] = project.Name
+	}
+	return names
+}
+
+// buildEvalEntries returns upcoming evaluations soonest first, then past
+// ones most recent first
+func buildEvalEntries(scaleTeams []api.ScaleTeam, myID int, projects map[int]string, now time.Time) []evalEntry {
+	seen := make(map[int]bool)
+	entries := make([]evalEntry, 0, len(scaleTeams))
+	for _, st := range scaleTeams {
+		if seen[st.ID] {
+			continue
+		}
+		seen[st.ID] = true
+		entries = append(entries, newEvalEntry(st, myID, projects, now))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		aUpcoming, bUpcoming := a.BeginAt.After(now), b.BeginAt.After(now)
+		if aUpcoming != bUpcoming {
+			return aUpcoming
+		}
+		if aUpcoming {
+			return a.BeginAt.Before(b.BeginAt)
+		}
+		return a.BeginAt.After(b.BeginAt)
+	})
+	return entries
+}
+
+// newEvalEntry describes an evaluation from the point of view of user myID
+func newEvalEntry(st api.ScaleTeam, myID int, projects map[int]string, now time.Time) evalEntry {
+	e := evalEntry{
+		ID:        st.ID,
+		BeginAt:   st.BeginAt,
+		Role:      "corrected",
+		ProjectID: st.Team.ProjectID,
+		Project:   projects[st.Team.ProjectID],
+		Team:      st.Team.Name,
+		Members:   make([]string, 0),
+		FinalMark: st.FinalMark,
+	}
+	if e.Project == "" {
+		e.Project = st.Scale.Name
+	}
+	if e.Project == "" && e.ProjectID > 0 {
+		e.Project = fmt.Sprintf("project #%d", e.ProjectID)
+	}
+
+	if st.Corrector != nil {
+		e.Corrector = st.Corrector.Login
+		if st.Corrector.ID == myID {
+			e.Role = "corrector"
+		}
+	}
+
+	members := st.Team.Users
+	if len(members) == 0 {
+		members = st.Correcteds
+	}
+	for _, u := range members {
+		if u.Login != "" {
+			e.Members = append(e.Members, u.Login)
+		}
+	}
+
+	switch {
+	case st.BeginAt.After(now):
+		e.Status = "upcoming"
+		e.StartsIn = formatTimeUntil(st.BeginAt.Sub(now))
+	case st.FilledAt != nil:
+		e.Status = "done"
+	default:
+		e.Status = "pending"
+	}
+	if st.Flag != nil {
+		e.Flag = st.Flag.Name
+	}
+	return e
+}
+
+// evalStatusLabel formats the status of an evaluation for tables
+func evalStatusLabel(e evalEntry) string {
+	switch e.Status {
+	case "upcoming":
+		return "⏳ upcoming"
+	case "pending":
+		return "✍️  not filled in"
+	}
+	label := "✅ done"
+	if e.FinalMark != nil {
+		label = fmt.Sprintf("✅ %d", *e.FinalMark)
+	}
+	if e.Flag != "" && e.Flag != "Ok" {
+		label += " (" + e.Flag + ")"
+	}
+	return label
+}
+
+// formatTimeUntil formats a positive duration as "in 2d 3h", "in 1h30" or
+// "in 12m"
+func formatTimeUntil(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d >= 24*time.Hour:
+		days := int(d.Hours()) / 24
+		if hours := int(d.Hours()) % 24; hours > 0 {
+			return fmt.Sprintf("in %dd %dh", days, hours)
+		}
+		return fmt.Sprintf("in %dd", days)
+	}
+	return "in " + formatSlotLength(d.Truncate(time.Minute))
+}
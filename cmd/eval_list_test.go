@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestBuildEvalEntries(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	filled := now.Add(-23 * time.Hour)
+	mark := 100
+
+	scaleTeams := []api.ScaleTeam{
+		{ID: 1, BeginAt: now.Add(-24 * time.Hour), FilledAt: &filled, FinalMark: &mark,
+			Corrector: &api.User{ID: 7, Login: "me"},
+			Team:      api.Team{Name: "a's group", ProjectID: 1314, Users: []api.User{{Login: "a"}, {Login: "b"}}}},
+		{ID: 2, BeginAt: now.Add(26 * time.Hour), Team: api.Team{Name: "my group", ProjectID: 99},
+			Correcteds: []api.User{{ID: 7, Login: "me"}}},
+		{ID: 3, BeginAt: now.Add(90 * time.Minute), Scale: api.Scale{Name: "scale v2"},
+			Corrector: &api.User{ID: 7, Login: "me"}, Team: api.Team{ProjectID: 42}},
+		{ID: 4, BeginAt: now.Add(-time.Hour), Corrector: &api.User{ID: 8, Login: "eval"},
+			Team: api.Team{ProjectID: 1314}},
+		{ID: 2, BeginAt: now.Add(26 * time.Hour)}, // duplicate
+	}
+	projects := map[int]string{1314: "libft"}
+
+	entries := buildEvalEntries(scaleTeams, 7, projects, now)
+
+	tests := []struct {
+		id       int
+		role     string
+		status   string
+		project  string
+		startsIn string
+		members  int
+	}{
+		{3, "corrector", "upcoming", "scale v2", "in 1h30", 0},
+		{2, "corrected", "upcoming", "project #99", "in 1d 2h", 1},
+		{4, "corrected", "pending", "libft", "", 0},
+		{1, "corrector", "done", "libft", "", 2},
+	}
+	if len(entries) != len(tests) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(tests), entries)
+	}
+	for i, tt := range tests {
+		t.Run(tt.project, func(t *testing.T) {
+			e := entries[i]
+			if e.ID != tt.id || e.Role != tt.role || e.Status != tt.status ||
+				e.Project != tt.project || e.StartsIn != tt.startsIn || len(e.Members) != tt.members {
+				t.Errorf("entry %d = %+v, want %+v", i, e, tt)
+			}
+		})
+	}
+}
+
+func TestFormatTimeUntil(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "now"},
+		{12 * time.Minute, "in 12m"},
+		{2 * time.Hour, "in 2h"},
+		{3*24*time.Hour + 5*time.Hour + 10*time.Minute, "in 3d 5h"},
+		{48 * time.Hour, "in 2d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatTimeUntil(tt.d); got != tt.want {
+				t.Errorf("formatTimeUntil(%s) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
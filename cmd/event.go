@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// eventKinds are the kinds of event the intra knows, for --kind completion
+var eventKinds = []string{
+	"association", "atelier", "challenge", "conference", "event", "extern",
+	"hackathon", "meet_up", "other", "partnership", "workshop",
+}
+
+var eventCmd = &cobra.Command{
+	Use:     "event",
+	Aliases: []string{"events"},
+	Short:   "Browse and register to campus events",
+	Long:    `Browse the upcoming events of a campus and register to them.`,
+}
+
+var eventListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List upcoming campus events",
+	Long: `List upcoming events of a campus, soonest first.
+
+Without --campus or --campus-id, default_campus from the config file is
+used, else your primary campus.
+
+Examples:
+  t42 event list
+  t42 event list --kind hackathon
+  t42 event list --campus paris --days 60`,
+	Args: cobra.NoArgs,
+	RunE: runEventList,
+}
+
+var eventShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show an event and whether you are registered",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runEventShow,
+}
+
+var eventSubscribeCmd = &cobra.Command{
+	Use:     "subscribe <id>",
+	Aliases: []string{"register"},
+	Short:   "Register to an event",
+	Long: `Register to an event by the ID shown in 't42 event list'.
+
+Examples:
+  t42 event subscribe 21345`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEventSubscribe,
+}
+
+var eventUnsubscribeCmd = &cobra.Command{
+	Use:     "unsubscribe <id>",
+	Aliases: []string{"unregister"},
+	Short:   "Cancel your registration to an event",
+	Long: `Cancel your registration to an event. Some events no longer accept
+cancellations shortly before they begin.
+
+Examples:
+  t42 event unsubscribe 21345`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEventUnsubscribe,
+}
+
+func init() {
+	eventCmd.AddCommand(eventListCmd)
+	eventCmd.AddCommand(eventShowCmd)
+	eventCmd.AddCommand(eventSubscribeCmd)
+	eventCmd.AddCommand(eventUnsubscribeCmd)
+	rootCmd.AddCommand(eventCmd)
+
+	addCampusFlags(eventListCmd)
+	eventListCmd.Flags().String("kind", "", "Only show events of this kind (e.g. hackathon, conference, workshop)")
+	eventListCmd.Flags().Int("days", 30, "Show events beginning within this many days")
+	eventListCmd.Flags().IntP("limit", "l", 0, "Maximum number of events to show (0 for all)")
+	_ = eventListCmd.RegisterFlagCompletionFunc("kind", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return eventKinds, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+func runEventList(cmd *cobra.Command, args []string) error {
+	kind, _ := cmd.Flags().GetString("kind")
+	days, _ := cmd.Flags().GetInt("days")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+	kind = normalizeEventKind(kind)
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	campus, err := resolveCampusOrDefault(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	if campus == nil {
+		me, err := client.GetMe(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get your profile: %w", err)
+		}
+		if campus = primaryCampus(me); campus == nil {
+			return fmt.Errorf("you have no campus - use --campus or --campus-id")
+		}
+	}
+
+	now := time.Now()
+	events, err := fetchCampusEvents(ctx, client, campus.ID, &api.ListEventsOptions{
+		Kind:  kind,
+		Since: now,
+		Until: now.AddDate(0, 0, days),
+	})
+	if err != nil {
+		return err
+	}
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "campus", Value: campus.Name},
+		output.Field{Key: "events", Value: events},
+		output.Field{Key: "count", Value: len(events)},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("🎪 Events at %s (next %d days)\n\n", campus.Name, days)
+	if len(events) == 0 {
+		if kind != "" {
+			fmt.Printf("No %s events.\n", kind)
+		} else {
+			fmt.Println("No events.")
+		}
+		return nil
+	}
+
+	fmt.Printf("%-8s %-28s %-12s %-36s %s\n", "ID", "WHEN", "KIND", "NAME", "SEATS")
+	fmt.Println(strings.Repeat("-", 95))
+	for _, e := range events {
+		fmt.Printf("%-8d %-28s %-12s %-36s %s\n",
+			e.ID,
+			formatSlotWhen(e.BeginAt, e.EndAt),
+			truncateString(e.Kind, 12),
+			truncateString(e.Name, 36),
+			eventSeats(&e),
+		)
+	}
+	fmt.Printf("\nTotal: %d events. Register with 't42 event subscribe <id>'.\n", len(events))
+	return nil
+}
+
+func runEventShow(cmd *cobra.Command, args []string) error {
+	eventID, err := parseEventID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	event, err := client.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event %d: %w", eventID, err)
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	registration, err := findEventRegistration(ctx, client, eventID, me.ID)
+	if err != nil {
+		return err
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "event", Value: event},
+		output.Field{Key: "subscribed", Value: registration != nil},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("🎪 %s (ID: %d)\n", event.Name, event.ID)
+	fmt.Println(strings.Repeat("=", 40))
+	fmt.Printf("Kind:       %s\n", event.Kind)
+	fmt.Printf("When:       %s\n", formatSlotWhen(event.BeginAt, event.EndAt))
+	if event.Location != "" {
+		fmt.Printf("Location:   %s\n", event.Location)
+	}
+	fmt.Printf("Seats:      %s\n", eventSeats(event))
+
+	status := "not registered"
+	if registration != nil {
+		status = "✅ registered"
+	} else if eventFull(event) {
+		status = "not registered (full)"
+	}
+	fmt.Printf("You:        %s\n", status)
+
+	if description := strings.TrimSpace(event.Description); description != "" {
+		fmt.Printf("\n%s\n", description)
+	}
+	return nil
+}
+
+func runEventSubscribe(cmd *cobra.Command, args []string) error {
+	eventID, err := parseEventID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	event, err := client.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event %d: %w", eventID, err)
+	}
+	if !event.EndAt.After(time.Now()) {
+		return fmt.Errorf("event %d is over", eventID)
+	}
+	if eventFull(event) {
+		return fmt.Errorf("event %d is full (%s)", eventID, eventSeats(event))
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+
+	registration, err := client.CreateEventsUser(ctx, eventID, me.ID)
+	if err != nil {
+		return fmt.Errorf("failed to register to event %d: %w", eventID, err)
+	}
+	invalidateOwnData()
+
+	if done, err := emitOutput(
+		output.Field{Key: "subscribed", Value: registration},
+		output.Field{Key: "event", Value: event},
+	); done || err != nil {
+		return err
+	}
+	fmt.Printf("✅ Registered to %s: %s\n", event.Name, formatSlotWhen(event.BeginAt, event.EndAt))
+	return nil
+}
+
+func runEventUnsubscribe(cmd *cobra.Command, args []string) error {
+	eventID, err := parseEventID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	event, err := client.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event %d: %w", eventID, err)
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	registration, err := findEventRegistration(ctx, client, eventID, me.ID)
+	if err != nil {
+		return err
+	}
+	if registration == nil {
+		return fmt.Errorf("you are not registered to event %d", eventID)
+	}
+
+	if err := client.DeleteEventsUser(ctx, registration.ID); err != nil {
+		return fmt.Errorf("failed to cancel your registration to event %d: %w", eventID, err)
+	}
+	invalidateOwnData()
+
+	if done, err := emitOutput(output.Field{Key: "unsubscribed", Value: registration}); done || err != nil {
+		return err
+	}
+	fmt.Printf("🗑️  Cancelled your registration to %s\n", event.Name)
+	return nil
+}
+
+// fetchCampusEvents fetches every page of a campus's events
+func fetchCampusEvents(ctx context.Context, client *api.Client, campusID int, opts *api.ListEventsOptions) ([]api.Event, error) {
+	var all []api.Event
+	pageOpts := *opts
+	err := api.FetchAll(ctx, api.DefaultPerPage,
+		func(ctx context.Context, page int) ([]api.Event, *api.PaginationMeta, error) {
+			pageOpts.Page, pageOpts.PerPage = page, api.DefaultPerPage
+			return client.ListCampusEvents(ctx, campusID, &pageOpts)
+		},
+		func(events []api.Event, _ *api.PaginationMeta) error {
+			all = append(all, events...)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	return all, nil
+}
+
+// findEventRegistration returns the user's registration to an event, or
+// nil when they are not registered
+func findEventRegistration(ctx context.Context, client *api.Client, eventID, userID int) (*api.EventsUser, error) {
+	registrations, _, err := client.ListEventsUsers(ctx, &api.ListEventsUsersOptions{EventID: eventID, UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check your registration: %w", err)
+	}
+	for i := range registrations {
+		if registrations[i].EventID == eventID && registrations[i].UserID == userID {
+			return &registrations[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// parseEventID parses an event ID argument
+func parseEventID(arg string) (int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid event ID %q", arg)
+	}
+	return id, nil
+}
+
+// normalizeEventKind turns spellings like "Meet-up" into the API's "meet_up"
+func normalizeEventKind(kind string) string {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	return strings.NewReplacer("-", "_", " ", "_").Replace(kind)
+}
+
+// eventSeats formats the subscribers of an event as "12/50", or "12" when
+// the number of attendees is not limited
+func eventSeats(e *api.Event) string {
+	if e.MaxPeople == nil || *e.MaxPeople <= 0 {
+		return strconv.Itoa(e.NbrSubscribers)
+	}
+	return fmt.Sprintf("%d/%d", e.NbrSubscribers, *e.MaxPeople)
+}
+
+// eventFull reports whether an event has no seat left
+func eventFull(e *api.Event) bool {
+	return e.MaxPeople != nil && *e.MaxPeople > 0 && e.NbrSubscribers >= *e.MaxPeople
+}
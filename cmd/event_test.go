@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestEventSeats(t *testing.T) {
+	limit := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		event    api.Event
+		wantText string
+		wantFull bool
+	}{
+		{"unlimited", api.Event{NbrSubscribers: 12}, "12", false},
+		{"zero limit is unlimited", api.Event{NbrSubscribers: 3, MaxPeople: limit(0)}, "3", false},
+		{"seats left", api.Event{NbrSubscribers: 12, MaxPeople: limit(50)}, "12/50", false},
+		{"full", api.Event{NbrSubscribers: 50, MaxPeople: limit(50)}, "50/50", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventSeats(&tt.event); got != tt.wantText {
+				t.Errorf("eventSeats() = %q, want %q", got, tt.wantText)
+			}
+			if got := eventFull(&tt.event); got != tt.wantFull {
+				t.Errorf("eventFull() = %v, want %v", got, tt.wantFull)
+			}
+		})
+	}
+}
+
+func TestNormalizeEventKind(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"hackathon", "hackathon"},
+		{"Conference", "conference"},
+		{"meet-up", "meet_up"},
+		{" Meet up ", "meet_up"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := normalizeEventKind(tt.in); got != tt.want {
+				t.Errorf("normalizeEventKind(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
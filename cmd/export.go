@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+	"github.com/naokiiida/t42-cli/internal/sqlite"
+)
+
+// exportBatchSize is the number of users whose projects are listed per
+// request
+const exportBatchSize = 50
+
+var exportDataCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export campus data to a SQLite database",
+	Long: `Export the users of a campus, their projects and teams, and the
+workstation sessions of the campus to a SQLite database written to --db,
+for ad-hoc analytics in SQL.
+
+The database has normalized tables: users, projects, projects_users,
+teams, team_users and locations, joined on their id columns. Times are
+UTC, in ISO 8601, for SQLite's date functions. The file is replaced if it
+exists.
+
+Without --campus or --campus-id, your primary campus is used. --cursus-id
+only exports the projects of one cursus, and --since sets how far back the
+sessions go: days (30d), weeks (4w) or a date (YYYY-MM-DD).
+
+Exporting a large campus takes many requests, one per 100 users and at
+least one per 50 users for their projects: expect a few minutes.
+
+Examples:
+  t42 export --format sqlite --db t42.db
+  t42 export --campus tokyo --cursus-id 21 --since 2025-01-01 --db tokyo.db
+  sqlite3 t42.db 'SELECT status, COUNT(*) FROM projects_users GROUP BY status'`,
+	Args: cobra.NoArgs,
+	RunE: runExportData,
+}
+
+func init() {
+	rootCmd.AddCommand(exportDataCmd)
+
+	exportDataCmd.Flags().String("format", "sqlite", "Export format: sqlite")
+	exportDataCmd.Flags().String("db", "", "Database file to write, replaced if it exists")
+	_ = exportDataCmd.MarkFlagRequired("db")
+	addCampusFlags(exportDataCmd)
+	exportDataCmd.Flags().Int("cursus-id", 0, "Only export the projects of this cursus (0 for all)")
+	exportDataCmd.Flags().String("since", "30d", "Export the workstation sessions since this day: 30d, 4w or YYYY-MM-DD")
+}
+
+func runExportData(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	dbFile, _ := cmd.Flags().GetString("db")
+	if format != "sqlite" {
+		return fmt.Errorf("unsupported --format %q (supported: sqlite)", format)
+	}
+	now := time.Now()
+	since, err := parseSince(sinceFlag, now)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	campus, err := resolveCampusFlags(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	if campus == nil {
+		me, err := client.GetMe(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get your profile: %w", err)
+		}
+		if campus = primaryCampus(me); campus == nil {
+			return fmt.Errorf("you have no campus - use --campus or --campus-id")
+		}
+	}
+
+	// Progress goes to stderr, to keep stdout for the summary
+	fmt.Fprintf(os.Stderr, "📥 Fetching the users of %s...\n", campus.Name)
+	var users []api.User
+	for user, err := range client.ListCampusUsersIter(ctx, campus.ID, &api.ListUsersOptions{PerPage: api.DefaultPerPage}) {
+		if err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	fmt.Fprintf(os.Stderr, "📥 Fetching the projects of %d users...\n", len(users))
+	userIDs := make([]int, len(users))
+	for i, u := range users {
+		userIDs[i] = u.ID
+	}
+	projectsUsers, err := fetchExportProjectsUsers(ctx, client, userIDs, cursusID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "📥 Fetching the sessions since %s...\n", since.Format("2006-01-02"))
+	locations, err := fetchCampusLocations(ctx, client, campus.ID, &api.ListLocationsOptions{Since: since, Until: now})
+	if err != nil {
+		return err
+	}
+
+	tables := exportTables(users, projectsUsers, locations)
+	var db bytes.Buffer
+	if err := sqlite.Write(&db, tables); err != nil {
+		return fmt.Errorf("failed to build the database: %w", err)
+	}
+	if err := os.WriteFile(dbFile, db.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dbFile, err)
+	}
+
+	counts := make(map[string]int, len(tables))
+	for _, t := range tables {
+		counts[t.Name] = len(t.Rows)
+	}
+	if GetJSONOutput() {
+		return writeOutput(
+			output.Field{Key: "campus", Value: campus.Name},
+			output.Field{Key: "database", Value: dbFile},
+			output.Field{Key: "tables", Value: counts},
+		)
+	}
+
+	fmt.Printf("💾 Exported %s to %s\n", campus.Name, dbFile)
+	for _, t := range tables {
+		fmt.Printf("   %-15s %6d rows\n", t.Name, len(t.Rows))
+	}
+	fmt.Printf("\nQuery it with: sqlite3 %s\n", dbFile)
+	return nil
+}
+
+// fetchExportProjectsUsers returns the projects of the users, fetched for
+// batches of users at a time
+func fetchExportProjectsUsers(ctx context.Context, client *api.Client, userIDs []int, cursusID int) ([]api.ProjectUser, error) {
+	var all []api.ProjectUser
+	for start := 0; start < len(userIDs); start += exportBatchSize {
+		batch := userIDs[start:min(start+exportBatchSize, len(userIDs))]
+		err := api.FetchAll(ctx, api.DefaultPerPage,
+			func(ctx context.Context, page int) ([]api.ProjectUser, *api.PaginationMeta, error) {
+				return client.ListProjectsUsers(ctx, &api.ListProjectsUsersOptions{
+					Page:     page,
+					PerPage:  api.DefaultPerPage,
+					UserIDs:  batch,
+					CursusID: cursusID,
+				})
+			},
+			func(projectsUsers []api.ProjectUser, _ *api.PaginationMeta) error {
+				all = append(all, projectsUsers...)
+				return nil
+			})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+	}
+	return all, nil
+}
+
+// exportTables builds the normalized tables of an export. Projects and
+// teams come embedded in the projects of the users, once per member, and
+// are written once.
+func exportTables(users []api.User, projectsUsers []api.ProjectUser, locations []api.Location) []sqlite.Table {
+	usersTable := sqlite.Table{
+		Name: "users",
+		Key:  "id",
+		Columns: []sqlite.Column{
+			{Name: "id", Type: "INTEGER"},
+			{Name: "login", Type: "TEXT"},
+			{Name: "displayname", Type: "TEXT"},
+			{Name: "email", Type: "TEXT"},
+			{Name: "staff", Type: "INTEGER"},
+			{Name: "alumni", Type: "INTEGER"},
+			{Name: "active", Type: "INTEGER"},
+			{Name: "pool_month", Type: "TEXT"},
+			{Name: "pool_year", Type: "TEXT"},
+			{Name: "correction_point", Type: "INTEGER"},
+			{Name: "wallet", Type: "INTEGER"},
+			{Name: "location", Type: "TEXT"},
+			{Name: "created_at", Type: "TEXT"},
+		},
+	}
+	seenUsers := make(map[int]bool)
+	for _, u := range users {
+		if seenUsers[u.ID] {
+			continue
+		}
+		seenUsers[u.ID] = true
+		usersTable.Rows = append(usersTable.Rows, []interface{}{
+			u.ID, u.Login, u.DisplayName, sqlText(u.Email), u.Staff, u.Alumni, u.Active,
+			sqlText(u.PoolMonth), sqlText(u.PoolYear), u.CorrectionPoint, u.Wallet,
+			sqlText(u.Location), sqlTime(&u.CreatedAt),
+		})
+	}
+
+	projectsTable := sqlite.Table{
+		Name: "projects",
+		Key:  "id",
+		Columns: []sqlite.Column{
+			{Name: "id", Type: "INTEGER"},
+			{Name: "name", Type: "TEXT"},
+			{Name: "slug", Type: "TEXT"},
+		},
+	}
+	projectsUsersTable := sqlite.Table{
+		Name: "projects_users",
+		Key:  "id",
+		Columns: []sqlite.Column{
+			{Name: "id", Type: "INTEGER"},
+			{Name: "user_id", Type: "INTEGER"},
+			{Name: "project_id", Type: "INTEGER"},
+			{Name: "status", Type: "TEXT"},
+			{Name: "final_mark", Type: "INTEGER"},
+			{Name: "validated", Type: "INTEGER"},
+			{Name: "occurrence", Type: "INTEGER"},
+			{Name: "marked_at", Type: "TEXT"},
+			{Name: "created_at", Type: "TEXT"},
+			{Name: "updated_at", Type: "TEXT"},
+		},
+	}
+	teamsTable := sqlite.Table{
+		Name: "teams",
+		Key:  "id",
+		Columns: []sqlite.Column{
+			{Name: "id", Type: "INTEGER"},
+			{Name: "project_id", Type: "INTEGER"},
+			{Name: "name", Type: "TEXT"},
+			{Name: "status", Type: "TEXT"},
+			{Name: "final_mark", Type: "INTEGER"},
+			{Name: "validated", Type: "INTEGER"},
+			{Name: "locked", Type: "INTEGER"},
+			{Name: "closed", Type: "INTEGER"},
+			{Name: "created_at", Type: "TEXT"},
+			{Name: "updated_at", Type: "TEXT"},
+		},
+	}
+	teamUsersTable := sqlite.Table{
+		Name: "team_users",
+		Columns: []sqlite.Column{
+			{Name: "team_id", Type: "INTEGER"},
+			{Name: "user_id", Type: "INTEGER"},
+		},
+	}
+
+	seenProjects := make(map[int]bool)
+	seenProjectsUsers := make(map[int]bool)
+	seenTeams := make(map[int]bool)
+	seenTeamUsers := make(map[[2]int]bool)
+	for _, pu := range projectsUsers {
+		if seenProjectsUsers[pu.ID] {
+			continue
+		}
+		seenProjectsUsers[pu.ID] = true
+		if !seenProjects[pu.Project.ID] {
+			seenProjects[pu.Project.ID] = true
+			projectsTable.Rows = append(projectsTable.Rows, []interface{}{pu.Project.ID, pu.Project.Name, pu.Project.Slug})
+		}
+		projectsUsersTable.Rows = append(projectsUsersTable.Rows, []interface{}{
+			pu.ID, pu.User.ID, pu.Project.ID, pu.Status, sqlInt(pu.FinalMark), sqlBool(pu.Validated),
+			pu.Occurrence, sqlTime(pu.MarkedAt), sqlTime(&pu.CreatedAt), sqlTime(&pu.UpdatedAt),
+		})
+
+		for _, team := range pu.Teams {
+			if !seenTeams[team.ID] {
+				seenTeams[team.ID] = true
+				projectID := team.ProjectID
+				if projectID == 0 {
+					projectID = pu.Project.ID
+				}
+				teamsTable.Rows = append(teamsTable.Rows, []interface{}{
+					team.ID, projectID, team.Name, team.Status, sqlInt(team.FinalMark), sqlBool(team.Validated),
+					team.Locked, team.Closed, sqlTime(&team.CreatedAt), sqlTime(&team.UpdatedAt),
+				})
+			}
+			for _, member := range team.Users {
+				key := [2]int{team.ID, member.ID}
+				if !seenTeamUsers[key] {
+					seenTeamUsers[key] = true
+					teamUsersTable.Rows = append(teamUsersTable.Rows, []interface{}{team.ID, member.ID})
+				}
+			}
+		}
+	}
+
+	locationsTable := sqlite.Table{
+		Name: "locations",
+		Key:  "id",
+		Columns: []sqlite.Column{
+			{Name: "id", Type: "INTEGER"},
+			{Name: "user_id", Type: "INTEGER"},
+			{Name: "host", Type: "TEXT"},
+			{Name: "campus_id", Type: "INTEGER"},
+			{Name: "begin_at", Type: "TEXT"},
+			{Name: "end_at", Type: "TEXT"},
+		},
+	}
+	seenLocations := make(map[int]bool)
+	for _, l := range locations {
+		if seenLocations[l.ID] {
+			continue
+		}
+		seenLocations[l.ID] = true
+		locationsTable.Rows = append(locationsTable.Rows, []interface{}{
+			l.ID, l.User.ID, l.Host, l.CampusID, sqlTime(&l.BeginAt), sqlTime(l.EndAt),
+		})
+	}
+
+	return []sqlite.Table{usersTable, projectsTable, projectsUsersTable, teamsTable, teamUsersTable, locationsTable}
+}
+
+// sqlText returns a string column value, NULL when empty
+func sqlText(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// sqlInt returns an optional integer column value
+func sqlInt(n *int) interface{} {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
+
+// sqlBool returns an optional boolean column value
+func sqlBool(b *bool) interface{} {
+	if b == nil {
+		return nil
+	}
+	return *b
+}
+
+// sqlTime returns a time column value in UTC, NULL when unset
+func sqlTime(t *time.Time) interface{} {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
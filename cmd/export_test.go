@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/sqlite"
+)
+
+func TestExportTables(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*3600))
+	mark := 100
+	validated := true
+	team := api.Team{
+		ID:        7,
+		Name:      "jdoe's group",
+		Status:    "finished",
+		FinalMark: &mark,
+		Validated: &validated,
+		Users:     []api.User{{ID: 1}, {ID: 2}},
+	}
+	libft := api.Project{ID: 1314, Name: "Libft", Slug: "libft"}
+	users := []api.User{{ID: 1, Login: "jdoe", CreatedAt: created}, {ID: 2, Login: "asmith", Staff: true}}
+	projectsUsers := []api.ProjectUser{
+		{ID: 10, User: api.User{ID: 1}, Project: libft, Status: "finished", FinalMark: &mark, Validated: &validated, Teams: []api.Team{team}},
+		{ID: 11, User: api.User{ID: 2}, Project: libft, Status: "finished", Teams: []api.Team{team}},
+		{ID: 11, User: api.User{ID: 2}, Project: libft, Status: "finished", Teams: []api.Team{team}},
+	}
+	locations := []api.Location{{ID: 5, User: api.User{ID: 1}, Host: "c1r2p3", CampusID: 26, BeginAt: created}}
+
+	tables := exportTables(users, projectsUsers, locations)
+
+	wantRows := map[string]int{
+		"users":          2,
+		"projects":       1,
+		"projects_users": 2,
+		"teams":          1,
+		"team_users":     2,
+		"locations":      1,
+	}
+	byName := make(map[string]sqlite.Table)
+	for _, table := range tables {
+		byName[table.Name] = table
+		want, ok := wantRows[table.Name]
+		if !ok {
+			t.Errorf("unexpected table %s", table.Name)
+			continue
+		}
+		if len(table.Rows) != want {
+			t.Errorf("table %s has %d rows, want %d", table.Name, len(table.Rows), want)
+		}
+	}
+
+	if got := byName["users"].Rows[0][12]; got != "2024-06-01T03:00:00Z" {
+		t.Errorf("users.created_at = %v, want UTC time", got)
+	}
+	if got := byName["users"].Rows[1][12]; got != nil {
+		t.Errorf("unset users.created_at = %v, want NULL", got)
+	}
+	if got := byName["teams"].Rows[0][1]; got != libft.ID {
+		t.Errorf("teams.project_id = %v, want the project of the registration %d", got, libft.ID)
+	}
+	if got := byName["projects_users"].Rows[1][4]; got != nil {
+		t.Errorf("unset projects_users.final_mark = %v, want NULL", got)
+	}
+
+	if err := sqlite.Write(&bytes.Buffer{}, tables); err != nil {
+		t.Errorf("sqlite.Write() error = %v", err)
+	}
+}
+
+func TestSQLTime(t *testing.T) {
+	at := time.Date(2024, 6, 1, 9, 30, 0, 0, time.FixedZone("JST", 9*3600))
+	tests := []struct {
+		name string
+		time *time.Time
+		want interface{}
+	}{
+		{"nil", nil, nil},
+		{"zero", &time.Time{}, nil},
+		{"converted to UTC", &at, "2024-06-01T00:30:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sqlTime(tt.time); got != tt.want {
+				t.Errorf("sqlTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// extensionPrefix starts the name of every extension executable:
+// 't42 foo' runs t42-foo
+const extensionPrefix = "t42-"
+
+var extensionCmd = &cobra.Command{
+	Use:     "extension",
+	Aliases: []string{"extensions", "ext"},
+	Short:   "Manage t42 extensions",
+	Long: `Extensions add commands to t42 without changing it. 't42 foo ...' runs
+an executable named t42-foo with the remaining arguments when no t42
+command is named foo: one installed with 't42 extension install', else the
+first found on your PATH.
+
+Extensions get the config directory as T42_CONFIG_DIR, and the t42 binary
+as T42_BIN to call back into it, such as 't42 api' for API requests.`,
+}
+
+var extensionListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List installed extensions and those on your PATH",
+	Args:    cobra.NoArgs,
+	RunE:    runExtensionList,
+}
+
+var extensionInstallCmd = &cobra.Command{
+	Use:   "install <repo>",
+	Short: "Install an extension from a git repository",
+	Long: `Clone an extension into the extensions directory of the config
+directory. The repository is owner/t42-name on GitHub, or any git URL; its
+name must start with t42- and it must hold an executable of the same name
+at its root.
+
+Examples:
+  t42 extension install jdoe/t42-peers
+  t42 extension install https://gitlab.com/jdoe/t42-peers.git`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExtensionInstall,
+}
+
+var extensionRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove an installed extension",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runExtensionRemove,
+}
+
+func init() {
+	extensionCmd.AddCommand(extensionListCmd)
+	extensionCmd.AddCommand(extensionInstallCmd)
+	extensionCmd.AddCommand(extensionRemoveCmd)
+	rootCmd.AddCommand(extensionCmd)
+}
+
+// extension is an executable 't42 <name>' runs
+type extension struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Source string `json:"source"` // "installed" or "path"
+}
+
+// installedExtensionPath returns the executable of an extension installed
+// in dir
+func installedExtensionPath(dir, name string) string {
+	return filepath.Join(dir, extensionPrefix+name, extensionPrefix+name)
+}
+
+// findExtension returns the executable of the extension named name:
+// installed first, then on the PATH
+func findExtension(name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	if dir, err := config.GetExtensionsDir(); err == nil {
+		if path, err := exec.LookPath(installedExtensionPath(dir, name)); err == nil {
+			return path, true
+		}
+	}
+	if path, err := exec.LookPath(extensionPrefix + name); err == nil {
+		return path, true
+	}
+	return "", false
+}
+
+// listExtensions returns the extensions installed in dir, then those in
+// the directories of path not shadowed, by name
+func listExtensions(dir, path string) []extension {
+	var found []extension
+	seen := make(map[string]bool)
+	add := func(name, file, source string) {
+		if seen[name] || isCommandName(name) {
+			return
+		}
+		if info, err := os.Stat(file); err != nil || !isExecutable(info) {
+			return
+		}
+		seen[name] = true
+		found = append(found, extension{Name: name, Path: file, Source: source})
+	}
+
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, e := range entries {
+			name, ok := strings.CutPrefix(e.Name(), extensionPrefix)
+			if !ok || !e.IsDir() {
+				continue
+			}
+			if file, err := exec.LookPath(installedExtensionPath(dir, name)); err == nil {
+				add(name, file, "installed")
+			}
+		}
+	}
+	for _, pathDir := range filepath.SplitList(path) {
+		entries, err := os.ReadDir(pathDir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := strings.CutPrefix(e.Name(), extensionPrefix)
+			if !ok || e.IsDir() {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			add(name, filepath.Join(pathDir, e.Name()), "path")
+		}
+	}
+	return found
+}
+
+// isExecutable reports whether a file can be run: by its mode on Unix, by
+// its extension on Windows
+func isExecutable(info os.FileInfo) bool {
+	if info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(info.Name())) {
+		case ".exe", ".bat", ".cmd":
+			return true
+		}
+		return false
+	}
+	return info.Mode()&0111 != 0
+}
+
+// runExtension runs an extension with the arguments after its name and
+// returns its exit code
+func runExtension(path string, args []string) (int, error) {
+	ext := exec.Command(path, args...)
+	ext.Stdin, ext.Stdout, ext.Stderr = os.Stdin, os.Stdout, os.Stderr
+	ext.Env = os.Environ()
+	if dir, err := config.GetConfigDir(); err == nil {
+		ext.Env = append(ext.Env, config.ConfigDirEnvVar+"="+dir)
+	}
+	if self, err := os.Executable(); err == nil {
+		ext.Env = append(ext.Env, "T42_BIN="+self)
+	}
+
+	err := ext.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, fmt.Errorf("failed to run extension %s: %w", filepath.Base(path), err)
+	}
+	return 0, nil
+}
+
+// extensionRepoURL returns the clone URL of an extension repository, given
+// as owner/name on GitHub or as a git URL, and the extension's name
+func extensionRepoURL(repo string) (string, string, error) {
+	url := repo
+	if !strings.Contains(repo, "://") && !strings.HasPrefix(repo, "git@") {
+		parts := strings.Split(repo, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", "", fmt.Errorf("invalid repository %q (use owner/t42-name or a git URL)", repo)
+		}
+		url = "https://github.com/" + repo + ".git"
+	}
+
+	base := strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+	if i := strings.LastIndexAny(base, "/:"); i >= 0 {
+		base = base[i+1:]
+	}
+	name, ok := strings.CutPrefix(base, extensionPrefix)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("extension repositories are named %sname, not %q", extensionPrefix, base)
+	}
+	if isCommandName(name) {
+		return "", "", fmt.Errorf("%s would shadow the t42 command %s", base, name)
+	}
+	return url, name, nil
+}
+
+func runExtensionList(cmd *cobra.Command, args []string) error {
+	dir, err := config.GetExtensionsDir()
+	if err != nil {
+		return fmt.Errorf("failed to get extensions directory: %w", err)
+	}
+	extensions := listExtensions(dir, os.Getenv("PATH"))
+	sort.SliceStable(extensions, func(i, j int) bool { return extensions[i].Name < extensions[j].Name })
+
+	if done, err := emitOutputValue(extensions); done || err != nil {
+		return err
+	}
+	if len(extensions) == 0 {
+		fmt.Println("No extensions - install one with 't42 extension install <repo>'.")
+		return nil
+	}
+	fmt.Printf("%-20s %-10s %s\n", "NAME", "SOURCE", "PATH")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, e := range extensions {
+		fmt.Printf("%-20s %-10s %s\n", e.Name, e.Source, e.Path)
+	}
+	return nil
+}
+
+func runExtensionInstall(cmd *cobra.Command, args []string) error {
+	url, name, err := extensionRepoURL(args[0])
+	if err != nil {
+		return err
+	}
+	dir, err := config.GetExtensionsDir()
+	if err != nil {
+		return fmt.Errorf("failed to get extensions directory: %w", err)
+	}
+	target := filepath.Join(dir, extensionPrefix+name)
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("extension %s is already installed in %s", name, target)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create extensions directory: %w", err)
+	}
+
+	if !GetJSONOutput() {
+		fmt.Printf("📦 Cloning %s\n", url)
+	}
+	clone := exec.Command("git", "clone", "--depth", "1", url, target)
+	clone.Stdout, clone.Stderr = os.Stderr, os.Stderr
+	if err := clone.Run(); err != nil {
+		return fmt.Errorf("failed to clone %s: %w", url, err)
+	}
+	path, err := exec.LookPath(installedExtensionPath(dir, name))
+	if err != nil {
+		_ = os.RemoveAll(target)
+		return fmt.Errorf("%s has no executable %s%s at its root", args[0], extensionPrefix, name)
+	}
+
+	if GetJSONOutput() {
+		return writeOutputValue(map[string]interface{}{"success": true, "name": name, "path": path})
+	}
+	fmt.Printf("✅ Installed extension %s: run it with 't42 %s'\n", name, name)
+	return nil
+}
+
+func runExtensionRemove(cmd *cobra.Command, args []string) error {
+	name := strings.TrimPrefix(args[0], extensionPrefix)
+	dir, err := config.GetExtensionsDir()
+	if err != nil {
+		return fmt.Errorf("failed to get extensions directory: %w", err)
+	}
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid extension name %q", args[0])
+	}
+	target := filepath.Join(dir, extensionPrefix+name)
+	if _, err := os.Stat(target); err != nil {
+		return fmt.Errorf("extension %s is not installed", name)
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to remove extension %s: %w", name, err)
+	}
+
+	if GetJSONOutput() {
+		return writeOutputValue(map[string]interface{}{"success": true, "removed": name})
+	}
+	fmt.Printf("✅ Removed extension %s\n", name)
+	return nil
+}
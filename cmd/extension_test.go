@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestExtensionRepoURL(t *testing.T) {
+	tests := []struct {
+		repo     string
+		wantURL  string
+		wantName string
+		wantErr  bool
+	}{
+		{repo: "jdoe/t42-peers", wantURL: "https://github.com/jdoe/t42-peers.git", wantName: "peers"},
+		{repo: "https://gitlab.com/jdoe/t42-peers.git", wantURL: "https://gitlab.com/jdoe/t42-peers.git", wantName: "peers"},
+		{repo: "git@github.com:jdoe/t42-peers.git", wantURL: "git@github.com:jdoe/t42-peers.git", wantName: "peers"},
+		{repo: "jdoe/peers", wantErr: true},
+		{repo: "t42-peers", wantErr: true},
+		{repo: "jdoe/t42-user", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			url, name, err := extensionRepoURL(tt.repo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extensionRepoURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if url != tt.wantURL || name != tt.wantName {
+				t.Errorf("extensionRepoURL() = %q, %q; want %q, %q", url, name, tt.wantURL, tt.wantName)
+			}
+		})
+	}
+}
+
+// writeExtension writes an executable shell script exiting with code
+func writeExtension(t *testing.T, path string, code string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit "+code+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("extensions are shell scripts here")
+	}
+	configDir, pathDir := t.TempDir(), t.TempDir()
+	t.Setenv(config.ConfigDirEnvVar, configDir)
+	t.Setenv("PATH", pathDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	extDir := filepath.Join(configDir, config.ExtensionsDirName)
+
+	writeExtension(t, installedExtensionPath(extDir, "peers"), "3")
+	writeExtension(t, filepath.Join(pathDir, "t42-peers"), "4")
+	writeExtension(t, filepath.Join(pathDir, "t42-peerbook"), "0")
+	writeExtension(t, filepath.Join(pathDir, "t42-user"), "0")
+	if err := os.WriteFile(filepath.Join(pathDir, "t42-notes"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		got := listExtensions(extDir, pathDir)
+		want := []extension{
+			{Name: "peers", Path: installedExtensionPath(extDir, "peers"), Source: "installed"},
+			{Name: "peerbook", Path: filepath.Join(pathDir, "t42-peerbook"), Source: "path"},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("listExtensions() = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("listExtensions()[%d] = %v, want %v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("installed first", func(t *testing.T) {
+		path, ok := findExtension("peers")
+		if !ok {
+			t.Fatal("findExtension(peers) found nothing")
+		}
+		code, err := runExtension(path, []string{"--flag"})
+		if err != nil || code != 3 {
+			t.Errorf("runExtension() = %d, %v; want the installed one's 3", code, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		for _, name := range []string{"notes", "missing", "../peers"} {
+			if path, ok := findExtension(name); ok {
+				t.Errorf("findExtension(%s) = %s, want nothing", name, path)
+			}
+		}
+	})
+}
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/naokiiida/t42-cli/internal/guides"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// guideWidth is the width guides are wrapped to in the terminal
+const guideWidth = 80
+
+// helpCmd replaces cobra's help command, to hold the guides and man pages
+// under 't42 help'
+var helpCmd = &cobra.Command{
+	Use:   "help [command]",
+	Short: "Help about any command",
+	Long: `Help provides help for any command in the application.
+Simply type t42 help [path to command] for full details.
+
+'t42 help guides' lists the long-form guides built into t42, and
+'t42 help man' writes man pages of every command, for offline reference.`,
+	ValidArgsFunction: func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		cmd, _, err := c.Root().Find(args)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var completions []string
+		for _, sub := range cmd.Commands() {
+			if sub.IsAvailableCommand() && strings.HasPrefix(sub.Name(), toComplete) {
+				completions = append(completions, fmt.Sprintf("%s\t%s", sub.Name(), sub.Short))
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(c *cobra.Command, args []string) error {
+		cmd, _, err := c.Root().Find(args)
+		if cmd == nil || err != nil {
+			c.Printf("Unknown help topic %#q\n", args)
+			return c.Root().Usage()
+		}
+		cmd.InitDefaultHelpFlag()
+		cmd.InitDefaultVersionFlag()
+		return cmd.Help()
+	},
+}
+
+var helpGuidesCmd = &cobra.Command{
+	Use:   "guides [topic]",
+	Short: "Read the guides built into t42",
+	Long: `Read the long-form guides built into t42: authentication setup, rate
+limits and quota, and scripting recipes. They need no network access,
+for campus machines without the online documentation.
+
+Without a topic, the guides are listed.
+
+Examples:
+  t42 help guides
+  t42 help guides auth
+  t42 help guides scripting | less -R`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var names []string
+		for _, g := range guides.List() {
+			names = append(names, fmt.Sprintf("%s\t%s", g.Name, g.Title))
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: runHelpGuides,
+}
+
+var helpManCmd = &cobra.Command{
+	Use:   "man <directory>",
+	Short: "Write man pages of every command and guide",
+	Long: `Write a man page for every t42 command (section 1) and every guide
+(section 7) to a directory, which is created if needed.
+
+Examples:
+  t42 help man ~/.local/share/man/man1
+  t42 help man ./man && man ./man/t42-user-list.1
+  man ./man/t42-guide-auth.7`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHelpMan,
+}
+
+func init() {
+	helpCmd.AddCommand(helpGuidesCmd)
+	helpCmd.AddCommand(helpManCmd)
+	rootCmd.SetHelpCommand(helpCmd)
+}
+
+func runHelpGuides(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		list := guides.List()
+		if done, err := emitOutput(output.Field{Key: "guides", Value: guideSummaries(list)}); done || err != nil {
+			return err
+		}
+		fmt.Println("📚 Guides")
+		for _, g := range list {
+			fmt.Printf("   %-12s %s\n", g.Name, g.Summary)
+		}
+		fmt.Println("\nRead one with 't42 help guides <topic>'.")
+		return nil
+	}
+
+	guide, ok := guides.Get(args[0])
+	if !ok {
+		var names []string
+		for _, g := range guides.List() {
+			names = append(names, g.Name)
+		}
+		return fmt.Errorf("unknown guide %q (available: %s)", args[0], strings.Join(names, ", "))
+	}
+	if done, err := emitOutput(
+		output.Field{Key: "name", Value: guide.Name},
+		output.Field{Key: "title", Value: guide.Title},
+		output.Field{Key: "content", Value: guide.Content},
+	); done || err != nil {
+		return err
+	}
+	return writePaged(guides.Render(guide.Content, guideWidth, isTerminal(os.Stdout) && !GetPlainOutput()))
+}
+
+// guideSummary is a guide as listed by 't42 help guides'
+type guideSummary struct {
+	Name    string `json:"name"`
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+}
+
+// guideSummaries lists guides without their content
+func guideSummaries(list []guides.Guide) []guideSummary {
+	summaries := make([]guideSummary, len(list))
+	for i, g := range list {
+		summaries[i] = guideSummary{Name: g.Name, Title: g.Title, Summary: g.Summary}
+	}
+	return summaries
+}
+
+func runHelpMan(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var written []string
+	write := func(name, page string) error {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(page), 0o644); err != nil {
+			return fmt.Errorf("failed to write man page: %w", err)
+		}
+		written = append(written, path)
+		return nil
+	}
+
+	var walk func(c *cobra.Command) error
+	walk = func(c *cobra.Command) error {
+		if !c.IsAvailableCommand() && c != c.Root() {
+			return nil
+		}
+		if err := write(manPageName(c)+".1", commandManPage(c)); err != nil {
+			return err
+		}
+		for _, sub := range c.Commands() {
+			if err := walk(sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(cmd.Root()); err != nil {
+		return err
+	}
+	for _, g := range guides.List() {
+		if err := write(guideManPageName(g)+".7", guideManPage(g)); err != nil {
+			return err
+		}
+	}
+
+	if done, err := emitOutput(output.Field{Key: "written", Value: written}); done || err != nil {
+		return err
+	}
+	fmt.Printf("📖 Wrote %d man pages to %s\n", len(written), dir)
+	return nil
+}
+
+// manPageName returns the name of a command's man page, e.g. t42-user-list
+func manPageName(c *cobra.Command) string {
+	return strings.ReplaceAll(c.CommandPath(), " ", "-")
+}
+
+// guideManPageName returns the name of a guide's man page, e.g.
+// t42-guide-auth
+func guideManPageName(g guides.Guide) string {
+	return "t42-guide-" + g.Name
+}
+
+// manHeader returns the .TH line of a man page
+func manHeader(name string, section int) string {
+	return fmt.Sprintf(".TH %q %q \"\" \"t42 %s\" \"t42 Manual\"\n", strings.ToUpper(name), fmt.Sprint(section), version)
+}
+
+// commandManPage renders the man page of a command
+func commandManPage(c *cobra.Command) string {
+	var b strings.Builder
+	b.WriteString(manHeader(manPageName(c), 1))
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", guides.RoffEscape(manPageName(c)), guides.RoffEscape(c.Short))
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, ".B %s\n", guides.RoffEscape(c.UseLine()))
+
+	description := c.Long
+	if description == "" {
+		description = c.Short
+	}
+	b.WriteString(".SH DESCRIPTION\n")
+	b.WriteString(roffDescription(description))
+
+	if flags := c.NonInheritedFlags(); flags.HasAvailableFlags() {
+		b.WriteString(".SH OPTIONS\n")
+		b.WriteString(roffFlags(flags))
+	}
+	if flags := c.InheritedFlags(); flags.HasAvailableFlags() {
+		b.WriteString(".SH GLOBAL OPTIONS\n")
+		b.WriteString(roffFlags(flags))
+	}
+
+	var related []string
+	if c.HasParent() {
+		related = append(related, manPageName(c.Parent()))
+	}
+	for _, sub := range c.Commands() {
+		if sub.IsAvailableCommand() {
+			related = append(related, manPageName(sub))
+		}
+	}
+	if len(related) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		for i, name := range related {
+			sep := ","
+			if i == len(related)-1 {
+				sep = ""
+			}
+			fmt.Fprintf(&b, ".BR %s (1)%s\n", guides.RoffEscape(name), sep)
+		}
+	}
+	return b.String()
+}
+
+// guideManPage renders the man page of a guide
+func guideManPage(g guides.Guide) string {
+	var b strings.Builder
+	b.WriteString(manHeader(guideManPageName(g), 7))
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", guides.RoffEscape(guideManPageName(g)), guides.RoffEscape(g.Title))
+	b.WriteString(".SH DESCRIPTION\n")
+	b.WriteString(guides.Roff(g.Content))
+	return b.String()
+}
+
+// roffDescription renders the long description of a command. Indented
+// paragraphs, such as examples, keep their lines.
+func roffDescription(text string) string {
+	var b strings.Builder
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		lines := strings.Split(paragraph, "\n")
+		preformatted := false
+		for _, line := range lines[1:] {
+			if strings.HasPrefix(line, "  ") {
+				preformatted = true
+			}
+		}
+		if preformatted {
+			b.WriteString(".PP\n.nf\n" + guides.RoffEscape(paragraph) + "\n.fi\n")
+		} else {
+			b.WriteString(".PP\n" + guides.RoffEscape(paragraph) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// roffFlags renders flags as a tagged list
+func roffFlags(flags *pflag.FlagSet) string {
+	var b strings.Builder
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		name, usage := pflag.UnquoteUsage(f)
+		tag := fmt.Sprintf(`\fB\-\-%s\fP`, guides.RoffEscape(f.Name))
+		if f.Shorthand != "" {
+			tag = fmt.Sprintf(`\fB\-%s\fP, %s`, f.Shorthand, tag)
+		}
+		if name != "" {
+			tag += " " + guides.RoffEscape(name)
+		}
+		b.WriteString(".TP\n" + tag + "\n" + guides.RoffEscape(usage) + "\n")
+	})
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCommandManPage(t *testing.T) {
+	root := &cobra.Command{Use: "t42"}
+	root.PersistentFlags().Bool("json", false, "Output in JSON format")
+	parent := &cobra.Command{Use: "user", Short: "User commands"}
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		Long:  "List users of a campus.\n\nExamples:\n  t42 user list --campus tokyo",
+		Run:   func(*cobra.Command, []string) {},
+	}
+	list.Flags().StringP("campus", "c", "", "Campus `name`")
+	root.AddCommand(parent)
+	parent.AddCommand(list)
+
+	page := commandManPage(list)
+	for _, want := range []string{
+		`.TH "T42-USER-LIST" "1"`,
+		"t42\\-user\\-list \\- List users",
+		".B t42 user list [flags]",
+		".PP\nList users of a campus.\n",
+		".nf\nExamples:\n  t42 user list \\-\\-campus tokyo\n.fi",
+		".SH OPTIONS\n.TP\n\\fB\\-c\\fP, \\fB\\-\\-campus\\fP name\nCampus name\n",
+		".SH GLOBAL OPTIONS\n.TP\n\\fB\\-\\-json\\fP\n",
+		".BR t42\\-user (1)\n",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("man page misses %q:\n%s", want, page)
+		}
+	}
+}
+
+func TestHelpCommandHoldsGuides(t *testing.T) {
+	rootCmd.InitDefaultHelpCmd()
+	cmd, _, err := rootCmd.Find([]string{"help", "guides"})
+	if err != nil || cmd != helpGuidesCmd {
+		t.Errorf("Find(help guides) = %v, %v; want the guides command", cmd.CommandPath(), err)
+	}
+}
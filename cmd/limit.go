@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// maxPerPage is the largest page size the 42 API serves
+const maxPerPage = 100
+
+// addLimitFlag adds --limit, the number of results a command shows. It
+// counts results after client-side filtering, and the command fetches as
+// many API pages as it takes to reach it.
+func addLimitFlag(cmd *cobra.Command, defaultLimit int, usage string) {
+	cmd.Flags().IntP("limit", "l", defaultLimit, usage)
+}
+
+// addPerPageFlag adds --per-page, the page size of the API requests behind
+// --limit. It changes how many requests are made, never how many results
+// are shown.
+func addPerPageFlag(cmd *cobra.Command, defaultPerPage int) {
+	cmd.Flags().Int("per-page", defaultPerPage, fmt.Sprintf("Items per API request, 1-%d (does not change how many results are shown)", maxPerPage))
+}
+
+// limitOptions reads --limit, --per-page and --page, where the command has
+// them, into the options of api.FetchLimit
+func limitOptions(cmd *cobra.Command) (api.LimitOptions, error) {
+	opts := api.LimitOptions{}
+	if cmd.Flags().Lookup("limit") != nil {
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+		if opts.Limit < 0 {
+			return opts, fmt.Errorf("--limit must be 0 (no limit) or more, got %d", opts.Limit)
+		}
+	}
+	if cmd.Flags().Lookup("per-page") != nil {
+		opts.PerPage, _ = cmd.Flags().GetInt("per-page")
+		if opts.PerPage < 1 || opts.PerPage > maxPerPage {
+			return opts, fmt.Errorf("--per-page must be between 1 and %d, got %d", maxPerPage, opts.PerPage)
+		}
+	}
+	if cmd.Flags().Lookup("page") != nil {
+		opts.Page, _ = cmd.Flags().GetInt("page")
+		if opts.Page < 1 {
+			return opts, fmt.Errorf("--page must be 1 or more, got %d", opts.Page)
+		}
+	}
+	return opts, nil
+}
+
+// moreResultsHint tells how to see the results --limit left out
+func moreResultsHint(limit int) string {
+	if limit == 0 {
+		return ""
+	}
+	return fmt.Sprintf("   Use --limit %d to see more results, or --limit 0 for all", limit*2)
+}
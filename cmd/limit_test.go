@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestLimitOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    api.LimitOptions
+		wantErr bool
+	}{
+		{name: "defaults", want: api.LimitOptions{Page: 1, PerPage: 100, Limit: 20}},
+		{name: "set", args: []string{"--limit", "250", "--per-page", "50", "--page", "3"}, want: api.LimitOptions{Page: 3, PerPage: 50, Limit: 250}},
+		{name: "no limit", args: []string{"-l", "0"}, want: api.LimitOptions{Page: 1, PerPage: 100}},
+		{name: "negative limit", args: []string{"--limit", "-1"}, wantErr: true},
+		{name: "per-page too large", args: []string{"--per-page", "101"}, wantErr: true},
+		{name: "per-page zero", args: []string{"--per-page", "0"}, wantErr: true},
+		{name: "page zero", args: []string{"--page", "0"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test"}
+			addLimitFlag(cmd, 20, "")
+			addPerPageFlag(cmd, api.DefaultPerPage)
+			cmd.Flags().Int("page", 1, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			got, err := limitOptions(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("limitOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("limitOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("flags the command lacks", func(t *testing.T) {
+		cmd := &cobra.Command{Use: "test"}
+		addLimitFlag(cmd, 5, "")
+		got, err := limitOptions(cmd)
+		if err != nil || got != (api.LimitOptions{Limit: 5}) {
+			t.Errorf("limitOptions() = %+v, %v, want only the limit", got, err)
+		}
+	})
+}
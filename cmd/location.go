@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var locationCmd = &cobra.Command{
+	Use:     "location",
+	Aliases: []string{"locations", "loc"},
+	Short:   "See who is logged in at which workstation",
+	Long:    `See who is logged in at the workstations of a campus, and where a user sits.`,
+}
+
+var locationListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List workstation sessions at a campus",
+	Long: `List the workstation sessions of a campus, sorted by host.
+
+Without --active, sessions that began in the last --hours are listed,
+including the ones that have ended. --host takes a glob pattern matched
+against the whole host name, such as c1r2* or *p1.
+
+Without --campus or --campus-id, default_campus from the config file is
+used, else your primary campus.
+
+Examples:
+  t42 location list --campus tokyo --active
+  t42 location list --active --host 'c1r2*'
+  t42 location list --hours 4
+  t42 location list --active --host 'c1r2*' --watch   # Refresh every 30s`,
+	Args: cobra.NoArgs,
+	RunE: watchable(runLocationList),
+}
+
+var locationShowCmd = &cobra.Command{
+	Use:   "show <login>",
+	Short: "Show where a user is logged in and their recent sessions",
+	Long: `Show the workstation a user is logged in at, if any, and their most
+recent sessions.
+
+Examples:
+  t42 location show jdoe
+  t42 location show jdoe --limit 20`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLocationShow,
+}
+
+func init() {
+	locationCmd.AddCommand(locationListCmd)
+	locationCmd.AddCommand(locationShowCmd)
+	rootCmd.AddCommand(locationCmd)
+
+	addCampusFlags(locationListCmd)
+	locationListCmd.Flags().Bool("active", false, "Only show sessions that have not ended")
+	locationListCmd.Flags().String("host", "", "Only show hosts matching this glob pattern (e.g. c1r2*)")
+	locationListCmd.Flags().Int("hours", 24, "Without --active, show sessions that began within this many hours")
+	locationListCmd.Flags().IntP("limit", "l", 0, "Maximum number of sessions to show (0 for all)")
+	registerColumns(locationListCmd, "locations",
+		output.Column{Header: "host", Query: ".host"},
+		output.Column{Header: "login", Query: ".user.login"},
+		output.Column{Header: "begin_at", Query: ".begin_at"},
+		output.Column{Header: "end_at", Query: ".end_at"},
+	)
+
+	addWatchModeFlag(locationListCmd)
+
+	locationShowCmd.Flags().IntP("limit", "l", 10, "Number of recent sessions to show")
+}
+
+func runLocationList(cmd *cobra.Command, args []string) error {
+	active, _ := cmd.Flags().GetBool("active")
+	host, _ := cmd.Flags().GetString("host")
+	hours, _ := cmd.Flags().GetInt("hours")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if hours < 1 {
+		return fmt.Errorf("--hours must be at least 1")
+	}
+	if _, err := path.Match(host, ""); err != nil {
+		return fmt.Errorf("invalid --host pattern %q: %w", host, err)
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	campus, err := resolveCampusOrDefault(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	if campus == nil {
+		me, err := client.GetMe(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get your profile: %w", err)
+		}
+		if campus = primaryCampus(me); campus == nil {
+			return fmt.Errorf("you have no campus - use --campus or --campus-id")
+		}
+	}
+
+	now := time.Now()
+	opts := &api.ListLocationsOptions{Active: active}
+	if !active {
+		opts.Since, opts.Until = now.Add(-time.Duration(hours)*time.Hour), now
+	}
+	locations, err := fetchCampusLocations(ctx, client, campus.ID, opts)
+	if err != nil {
+		return err
+	}
+	locations = filterLocationsByHost(locations, host)
+	sortLocationsByHost(locations)
+	if limit > 0 && len(locations) > limit {
+		locations = locations[:limit]
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "campus", Value: campus.Name},
+		output.Field{Key: "locations", Value: locations},
+		output.Field{Key: "count", Value: len(locations)},
+	); done || err != nil {
+		return err
+	}
+
+	if active {
+		fmt.Printf("🖥️  Logged in at %s\n\n", campus.Name)
+	} else {
+		fmt.Printf("🖥️  Sessions at %s (last %dh)\n\n", campus.Name, hours)
+	}
+	if len(locations) == 0 {
+		fmt.Println("No sessions.")
+		return nil
+	}
+
+	fmt.Printf("%-12s %-15s %-17s %s\n", "HOST", "LOGIN", "SINCE", "DURATION")
+	fmt.Println(strings.Repeat("-", 60))
+	for _, l := range locations {
+		fmt.Printf("%-12s %-15s %-17s %s\n",
+			truncateString(l.Host, 12),
+			truncateString(l.User.Login, 15),
+			l.BeginAt.Local().Format("Jan 02 15:04"),
+			locationDuration(l, now),
+		)
+	}
+	fmt.Printf("\nTotal: %d sessions\n", len(locations))
+	return nil
+}
+
+func runLocationShow(cmd *cobra.Command, args []string) error {
+	login := args[0]
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	user, err := client.GetUserByLogin(ctx, login)
+	if err != nil {
+		return fmt.Errorf("failed to get user %s: %w", login, err)
+	}
+
+	locations, _, err := client.ListUserLocations(ctx, user.ID, &api.ListLocationsOptions{
+		PerPage: min(limit, api.DefaultPerPage),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list locations: %w", err)
+	}
+	if len(locations) > limit {
+		locations = locations[:limit]
+	}
+
+	var current *api.Location
+	if len(locations) > 0 && locations[0].EndAt == nil {
+		current = &locations[0]
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "login", Value: user.Login},
+		output.Field{Key: "current", Value: current},
+		output.Field{Key: "locations", Value: locations},
+	); done || err != nil {
+		return err
+	}
+
+	now := time.Now()
+	fmt.Printf("🖥️  %s\n", user.Login)
+	fmt.Println(strings.Repeat("=", 40))
+	if current != nil {
+		fmt.Printf("Now:        %s (for %s)\n", current.Host, formatHours(now.Sub(current.BeginAt)))
+	} else {
+		fmt.Println("Now:        not logged in")
+	}
+
+	if len(locations) == 0 {
+		fmt.Println("\nNo sessions.")
+		return nil
+	}
+	fmt.Println("\nRecent sessions:")
+	for _, l := range locations {
+		fmt.Printf("  %-12s %-17s %s\n", truncateString(l.Host, 12), l.BeginAt.Local().Format("Jan 02 15:04"), locationDuration(l, now))
+	}
+	return nil
+}
+
+// fetchCampusLocations fetches every page of a campus's sessions
+func fetchCampusLocations(ctx context.Context, client *api.Client, campusID int, opts *api.ListLocationsOptions) ([]api.Location, error) {
+	var all []api.Location
+	pageOpts := *opts
+	err := api.FetchAll(ctx, api.DefaultPerPage,
+		func(ctx context.Context, page int) ([]api.Location, *api.PaginationMeta, error) {
+			pageOpts.Page, pageOpts.PerPage = page, api.DefaultPerPage
+			return client.ListCampusLocations(ctx, campusID, &pageOpts)
+		},
+		func(locations []api.Location, _ *api.PaginationMeta) error {
+			all = append(all, locations...)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+	return all, nil
+}
+
+// filterLocationsByHost keeps the sessions whose host matches a glob
+// pattern; an empty pattern keeps them all
+func filterLocationsByHost(locations []api.Location, pattern string) []api.Location {
+	if pattern == "" {
+		return locations
+	}
+	var matched []api.Location
+	for _, l := range locations {
+		if ok, _ := path.Match(pattern, l.Host); ok {
+			matched = append(matched, l)
+		}
+	}
+	return matched
+}
+
+// sortLocationsByHost sorts sessions by host in natural order, so that
+// c1r2p10 comes after c1r2p9, and by begin time within a host
+func sortLocationsByHost(locations []api.Location) {
+	sort.SliceStable(locations, func(i, j int) bool {
+		if locations[i].Host != locations[j].Host {
+			return naturalLess(locations[i].Host, locations[j].Host)
+		}
+		return locations[i].BeginAt.Before(locations[j].BeginAt)
+	})
+}
+
+// naturalLess compares strings with runs of digits compared by value
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		da, db := leadingDigits(a), leadingDigits(b)
+		if da != "" && db != "" {
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// leadingDigits returns the digits at the start of s
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+// locationDuration formats how long a session lasted, or how long it has
+// been running
+func locationDuration(l api.Location, now time.Time) string {
+	if l.EndAt == nil {
+		return formatHours(now.Sub(l.BeginAt)) + " (active)"
+	}
+	return formatHours(l.EndAt.Sub(l.BeginAt))
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestFilterLocationsByHost(t *testing.T) {
+	locations := []api.Location{{Host: "c1r2p3"}, {Host: "c1r12p1"}, {Host: "c2r2p3"}}
+	tests := []struct {
+		pattern string
+		want    int
+	}{
+		{"", 3},
+		{"c1r2*", 1},
+		{"c1*", 2},
+		{"*p3", 2},
+		{"c3*", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if got := filterLocationsByHost(locations, tt.pattern); len(got) != tt.want {
+				t.Errorf("filterLocationsByHost(%q) = %+v, want %d sessions", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortLocationsByHost(t *testing.T) {
+	locations := []api.Location{{Host: "c1r2p10"}, {Host: "c2r1p1"}, {Host: "c1r2p9"}, {Host: "c1r10p1"}}
+	sortLocationsByHost(locations)
+
+	want := []string{"c1r2p9", "c1r2p10", "c1r10p1", "c2r1p1"}
+	for i, host := range want {
+		if locations[i].Host != host {
+			t.Fatalf("sortLocationsByHost() = %+v, want hosts %v", locations, want)
+		}
+	}
+}
+
+func TestLocationDuration(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	end := now.Add(-time.Hour)
+	tests := []struct {
+		name     string
+		location api.Location
+		want     string
+	}{
+		{"active", api.Location{BeginAt: now.Add(-90 * time.Minute)}, "1h30m (active)"},
+		{"ended", api.Location{BeginAt: now.Add(-3 * time.Hour), EndAt: &end}, "2h00m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := locationDuration(tt.location, now); got != tt.want {
+				t.Errorf("locationDuration() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
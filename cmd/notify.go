@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+	"github.com/naokiiida/t42-cli/internal/state"
+)
+
+const (
+	// notifyStateName is the state document remembering sent reminders
+	notifyStateName = "notify"
+
+	// notifyRetention is how long sent reminders are remembered
+	notifyRetention = 30 * 24 * time.Hour
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Reminders for blackholes, evaluations and booked slots",
+	Long:  `Check for upcoming deadlines, evaluations and booked slots and send reminders.`,
+}
+
+var notifyCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check once for upcoming blackholes, evaluations and booked slots",
+	Long: `Check once for an approaching blackhole, evaluations scheduled soon and
+evaluation slots of yours that were just booked, print them and show a
+desktop notification (notify-send on Linux, osascript on macOS, a toast on
+Windows).
+
+A booked slot is announced with the project, the time and the team you
+are going to evaluate, as soon as the check sees the booking, however far
+ahead the evaluation is.
+
+Each evaluation and booking is reminded once and the blackhole once a day,
+so the check can run as often as you like. 't42 remind install' runs it
+daily from your system scheduler; run it more often, e.g. every 10 minutes
+from cron, to hear of bookings quickly.
+
+Examples:
+  t42 notify check
+  t42 notify check --blackhole-days 30 --within 48h
+  t42 notify check --no-desktop --all`,
+	Args: cobra.NoArgs,
+	RunE: runNotifyCheck,
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyCheckCmd)
+	rootCmd.AddCommand(notifyCmd)
+
+	notifyCheckCmd.Flags().Int("blackhole-days", 14, "Remind when the blackhole is at most this many days away")
+	notifyCheckCmd.Flags().Duration("within", 24*time.Hour, "Remind of evaluations starting within this time")
+	notifyCheckCmd.Flags().Bool("no-desktop", false, "Only print reminders, without desktop notifications")
+	notifyCheckCmd.Flags().Bool("all", false, "Include reminders that were already sent")
+}
+
+// reminder is something the user should be told about
+type reminder struct {
+	Key     string    `json:"key"` // stable identity, used to send each reminder once
+	Kind    string    `json:"kind"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+}
+
+// notifyState remembers which reminders were sent and when
+type notifyState struct {
+	Sent map[string]time.Time `json:"sent"`
+}
+
+func runNotifyCheck(cmd *cobra.Command, args []string) error {
+	blackholeDays, _ := cmd.Flags().GetInt("blackhole-days")
+	within, _ := cmd.Flags().GetDuration("within")
+	noDesktop, _ := cmd.Flags().GetBool("no-desktop")
+	all, _ := cmd.Flags().GetBool("all")
+
+	store, err := state.Open()
+	if err != nil {
+		return err
+	}
+	sent, err := loadNotifyState(store)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	now := time.Now()
+	reminders, err := collectReminders(ctx, client, now, blackholeDays, within)
+	if err != nil {
+		return err
+	}
+	if !all {
+		reminders = unsentReminders(reminders, sent)
+	}
+
+	if !noDesktop {
+		for _, r := range reminders {
+			if err := sendDesktopNotification(r.Title, r.Message); err != nil && GetVerbose() {
+				fmt.Fprintf(os.Stderr, "Desktop notification failed: %v\n", err)
+			}
+		}
+	}
+
+	if err := recordSent(store, sent, reminders, now); err != nil {
+		return err
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "checked_at", Value: now},
+		output.Field{Key: "reminders", Value: reminders},
+	); done || err != nil {
+		return err
+	}
+
+	if len(reminders) == 0 {
+		fmt.Println("✅ Nothing to remind you of.")
+		return nil
+	}
+	for _, r := range reminders {
+		fmt.Printf("🔔 %s: %s\n", r.Title, r.Message)
+	}
+	return nil
+}
+
+// loadNotifyState loads the reminders sent so far
+func loadNotifyState(store *state.Store) (notifyState, error) {
+	var sent notifyState
+	if _, err := store.Load(notifyStateName, &sent); err != nil {
+		return sent, err
+	}
+	if sent.Sent == nil {
+		sent.Sent = make(map[string]time.Time)
+	}
+	return sent, nil
+}
+
+// recordSent remembers reminders as sent at now, forgets the ones older
+// than notifyRetention and saves the state
+func recordSent(store *state.Store, sent notifyState, reminders []reminder, now time.Time) error {
+	for _, r := range reminders {
+		sent.Sent[r.Key] = now
+	}
+	for key, at := range sent.Sent {
+		if now.Sub(at) > notifyRetention {
+			delete(sent.Sent, key)
+		}
+	}
+	return store.Save(notifyStateName, sent)
+}
+
+// collectReminders fetches your profile, evaluations and slots and returns
+// the reminders due at now, soonest first, whether sent before or not
+func collectReminders(ctx context.Context, client *api.Client, now time.Time, blackholeDays int, within time.Duration) ([]reminder, error) {
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get your profile: %w", err)
+	}
+
+	future := true
+	opts := &api.ListScaleTeamsOptions{
+		PerPage: api.DefaultPerPage,
+		Sort:    "begin_at",
+		Future:  &future,
+		Until:   now.Add(within),
+	}
+	// Evaluations to give are listed without --within, to match bookings
+	// of slots further ahead
+	correctorOpts := *opts
+	correctorOpts.Until = time.Time{}
+	asCorrector, _, err := client.ListUserScaleTeams(ctx, me.ID, api.ScaleTeamAsCorrector, &correctorOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list your upcoming evaluations: %w", err)
+	}
+	asCorrected, _, err := client.ListUserScaleTeams(ctx, me.ID, api.ScaleTeamAsCorrected, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list your upcoming evaluations: %w", err)
+	}
+	slots, err := fetchMySlots(ctx, client, now, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := buildReminders(me, asCorrector, asCorrected, now, blackholeDays, within)
+	if bookings := bookedSlotEvaluations(slots, asCorrector); len(bookings) > 0 {
+		projects := resolveProjectNames(ctx, client, scaleTeamProjectIDs(asCorrector))
+		reminders = append(reminders, bookedSlotReminders(bookings, projects)...)
+		sortReminders(reminders)
+	}
+	return reminders, nil
+}
+
+// buildReminders collects the blackhole and evaluation reminders due at now,
+// soonest first
+func buildReminders(me *api.User, asCorrector, asCorrected []api.ScaleTeam, now time.Time, blackholeDays int, within time.Duration) []reminder {
+	var reminders []reminder
+
+	for _, cu := range me.CursusUsers {
+		if cu.BlackholedAt == nil || cu.EndAt != nil || cu.BlackholedAt.Before(now) {
+			continue
+		}
+		daysLeft := int(cu.BlackholedAt.Sub(now).Hours() / 24)
+		if daysLeft > blackholeDays {
+			continue
+		}
+		reminders = append(reminders, reminder{
+			// Once a day while the blackhole is close
+			Key:     fmt.Sprintf("blackhole/%d/%s", cu.Cursus.ID, now.Format("2006-01-02")),
+			Kind:    "blackhole",
+			Title:   "Blackhole approaching",
+			Message: fmt.Sprintf("%d days left in %s (%s)", daysLeft, cu.Cursus.Name, cu.BlackholedAt.Local().Format("Jan 2")),
+			At:      *cu.BlackholedAt,
+		})
+	}
+
+	addEvaluations := func(scaleTeams []api.ScaleTeam, corrector bool) {
+		for _, st := range scaleTeams {
+			if st.BeginAt.Before(now) || st.BeginAt.After(now.Add(within)) {
+				continue
+			}
+			title := "Evaluation to give"
+			message := fmt.Sprintf("%s at %s", st.Team.Name, st.BeginAt.Local().Format("Mon 15:04"))
+			if !corrector {
+				title = "Evaluation of your team"
+				if st.Corrector != nil && st.Corrector.Login != "" {
+					message += " with " + st.Corrector.Login
+				}
+			}
+			reminders = append(reminders, reminder{
+				Key:     "evaluation/" + strconv.Itoa(st.ID),
+				Kind:    "evaluation",
+				Title:   title,
+				Message: message,
+				At:      st.BeginAt,
+			})
+		}
+	}
+	addEvaluations(asCorrector, true)
+	addEvaluations(asCorrected, false)
+
+	sortReminders(reminders)
+	return reminders
+}
+
+// sortReminders orders reminders soonest first
+func sortReminders(reminders []reminder) {
+	sort.SliceStable(reminders, func(i, j int) bool {
+		return reminders[i].At.Before(reminders[j].At)
+	})
+}
+
+// slotBooking is a booked range of the user's slots, with the evaluation
+// scheduled in it when the API shows it
+type slotBooking struct {
+	Slot       slotRange
+	Evaluation *api.ScaleTeam
+}
+
+// bookedSlotEvaluations returns the evaluations booked in the user's slots.
+// A slot piece carries its scale team when the API shows it; otherwise the
+// evaluations to give starting in a booked range are matched to it. A
+// booked range without any is returned alone.
+func bookedSlotEvaluations(slots []api.Slot, asCorrector []api.ScaleTeam) []slotBooking {
+	scaleTeams := make(map[int]api.ScaleTeam)
+	for _, s := range slots {
+		if s.Booked && s.ScaleTeam != nil && s.ScaleTeam.ID > 0 {
+			scaleTeams[s.ScaleTeam.ID] = *s.ScaleTeam
+		}
+	}
+	for _, st := range asCorrector {
+		// The listed evaluation has the team, the pieces often only its ID
+		scaleTeams[st.ID] = st
+	}
+
+	var bookings []slotBooking
+	for _, r := range mergeSlots(slots) {
+		if !r.Booked {
+			continue
+		}
+		var matched []api.ScaleTeam
+		for _, st := range scaleTeams {
+			if !st.BeginAt.Before(r.BeginAt) && st.BeginAt.Before(r.EndAt) {
+				matched = append(matched, st)
+			}
+		}
+		if len(matched) == 0 {
+			bookings = append(bookings, slotBooking{Slot: r})
+			continue
+		}
+		sort.Slice(matched, func(i, j int) bool {
+			if !matched[i].BeginAt.Equal(matched[j].BeginAt) {
+				return matched[i].BeginAt.Before(matched[j].BeginAt)
+			}
+			return matched[i].ID < matched[j].ID
+		})
+		for i := range matched {
+			bookings = append(bookings, slotBooking{Slot: r, Evaluation: &matched[i]})
+		}
+	}
+	return bookings
+}
+
+// bookedSlotReminders announces bookings with the project, the time and
+// the team to evaluate. A booking is reminded once per evaluation, or per
+// slot while the evaluation is hidden.
+func bookedSlotReminders(bookings []slotBooking, projects map[int]string) []reminder {
+	var reminders []reminder
+	for _, b := range bookings {
+		if b.Evaluation == nil {
+			reminders = append(reminders, reminder{
+				Key:     "booking/slot/" + strconv.Itoa(b.Slot.ID),
+				Kind:    "booking",
+				Title:   "Slot booked",
+				Message: fmt.Sprintf("Evaluation to give at %s", b.Slot.BeginAt.Local().Format("Mon Jan 2 15:04")),
+				At:      b.Slot.BeginAt,
+			})
+			continue
+		}
+
+		st := b.Evaluation
+		project := projects[st.Team.ProjectID]
+		if project == "" {
+			project = st.Scale.Name
+		}
+		if project == "" {
+			project = "An evaluation"
+		}
+		message := fmt.Sprintf("%s at %s", project, st.BeginAt.Local().Format("Mon Jan 2 15:04"))
+		if st.Team.Name != "" {
+			message += ", team " + st.Team.Name
+		}
+		var logins []string
+		for _, u := range st.Correcteds {
+			if u.Login != "" {
+				logins = append(logins, u.Login)
+			}
+		}
+		if len(logins) > 0 {
+			message += " (" + strings.Join(logins, ", ") + ")"
+		}
+		reminders = append(reminders, reminder{
+			Key:     "booking/" + strconv.Itoa(st.ID),
+			Kind:    "booking",
+			Title:   "Slot booked",
+			Message: message,
+			At:      st.BeginAt,
+		})
+	}
+	return reminders
+}
+
+// unsentReminders drops the reminders that were sent before
+func unsentReminders(reminders []reminder, sent notifyState) []reminder {
+	var unsent []reminder
+	for _, r := range reminders {
+		if _, ok := sent.Sent[r.Key]; !ok {
+			unsent = append(unsent, r)
+		}
+	}
+	return unsent
+}
+
+func sendDesktopNotification(title, message string) error {
+	name, args, ok := notificationCommand(runtime.GOOS, title, message)
+	if !ok {
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	if out, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// notificationCommand returns the command that shows a desktop notification on goos
+func notificationCommand(goos, title, message string) (string, []string, bool) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		return "osascript", []string{"-e", script}, true
+	case "windows":
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", windowsToastScript(title, message)}, true
+	default: // "linux", "freebsd", "openbsd", "netbsd"
+		return "notify-send", []string{"--app-name=t42", title, message}, true
+	}
+}
+
+// windowsToastScript is a PowerShell script showing a toast notification,
+// attributed to PowerShell so that no application has to be registered
+func windowsToastScript(title, message string) string {
+	return strings.Join([]string{
+		"[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null",
+		"$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)",
+		"$text = $xml.GetElementsByTagName('text')",
+		"$text.Item(0).AppendChild($xml.CreateTextNode(" + powerShellString(title) + ")) > $null",
+		"$text.Item(1).AppendChild($xml.CreateTextNode(" + powerShellString(message) + ")) > $null",
+		"$app = '{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\\WindowsPowerShell\\v1.0\\powershell.exe'",
+		"[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($app).Show([Windows.UI.Notifications.ToastNotification]::new($xml))",
+	}, "; ")
+}
+
+// powerShellString quotes s as a verbatim PowerShell string literal
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/state"
+)
+
+const (
+	// notifyDaemonMinInterval keeps the daemon, which makes a handful of
+	// requests per check, well inside the API rate limit
+	notifyDaemonMinInterval = time.Minute
+
+	// notifyDaemonDefaultInterval is the default time between two checks
+	notifyDaemonDefaultInterval = 5 * time.Minute
+)
+
+var notifyDaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Keep checking for reminders and send them as they come",
+	Long: `Run the checks of 't42 notify check' every --interval until stopped: an
+approaching blackhole, evaluations starting soon and evaluation slots of
+yours that were just booked. Each reminder is printed, shown as a desktop
+notification (notify-send on Linux, osascript on macOS, a toast on Windows)
+and posted to --webhook when one is given.
+
+The defaults can be set in the config file; flags override them:
+
+  notify:
+    interval: 5m
+    blackhole_days: 14
+    evaluation_within: 24h
+    desktop: true
+    webhook: https://hooks.slack.com/services/...
+    webhook_format: auto
+
+Reminders are sent once, shared with 't42 notify check'. A failed check is
+retried at the next one. Run the daemon from a terminal multiplexer or as a
+service of your session manager; stop it with Ctrl-C.
+
+Examples:
+  t42 notify daemon
+  t42 notify daemon --interval 10m --blackhole-days 30
+  t42 notify daemon --no-desktop --webhook https://discord.com/api/webhooks/...`,
+	Args: cobra.NoArgs,
+	RunE: runNotifyDaemon,
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyDaemonCmd)
+	addNotifyDaemonFlags(notifyDaemonCmd)
+}
+
+// addNotifyDaemonFlags adds the flags of 't42 notify daemon'
+func addNotifyDaemonFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("interval", notifyDaemonDefaultInterval, "Time between checks (at least 1m)")
+	cmd.Flags().Int("blackhole-days", 14, "Remind when the blackhole is at most this many days away")
+	cmd.Flags().Duration("within", 24*time.Hour, "Remind of evaluations starting within this time")
+	cmd.Flags().Bool("no-desktop", false, "Do not show desktop notifications")
+	cmd.Flags().String("webhook", "", "Also post each reminder to this webhook URL")
+	cmd.Flags().String("format", digestFormatAuto, "Webhook payload: auto, slack, discord or json")
+}
+
+// daemonSettings are the resolved options of the notify daemon
+type daemonSettings struct {
+	interval      time.Duration
+	blackholeDays int
+	within        time.Duration
+	desktop       bool
+	webhook       string
+	format        string // resolved webhook format
+}
+
+func runNotifyDaemon(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	settings, err := notifyDaemonSettings(cmd, cfg.Notify)
+	if err != nil {
+		return err
+	}
+
+	store, err := state.Open()
+	if err != nil {
+		return err
+	}
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	collect := func(ctx context.Context, now time.Time) ([]reminder, error) {
+		return collectReminders(ctx, client, now, settings.blackholeDays, settings.within)
+	}
+
+	if !GetJSONOutput() {
+		fmt.Printf("👀 Checking every %s for a blackhole within %d days, evaluations within %s and booked slots - Ctrl-C to stop\n",
+			settings.interval, settings.blackholeDays, settings.within)
+	}
+
+	for {
+		now := time.Now()
+		if err := notifyDaemonCheck(ctx, collect, store, &settings, now); err != nil {
+			// A failed check is retried at the next one
+			fmt.Fprintf(os.Stderr, "⚠️  [%s] check failed: %v\n", now.Format("15:04:05"), err)
+		}
+		time.Sleep(settings.interval)
+	}
+}
+
+// notifyDaemonSettings resolves the daemon options: flags given on the
+// command line, then the notify section of the config file, then the
+// flag defaults
+func notifyDaemonSettings(cmd *cobra.Command, cfg config.NotifySettings) (daemonSettings, error) {
+	flags := cmd.Flags()
+	var s daemonSettings
+	s.interval, _ = flags.GetDuration("interval")
+	s.blackholeDays, _ = flags.GetInt("blackhole-days")
+	s.within, _ = flags.GetDuration("within")
+	noDesktop, _ := flags.GetBool("no-desktop")
+	s.desktop = !noDesktop
+	s.webhook, _ = flags.GetString("webhook")
+	s.format, _ = flags.GetString("format")
+
+	if !flags.Changed("interval") && cfg.Interval != "" {
+		d, err := time.ParseDuration(cfg.Interval)
+		if err != nil {
+			return s, fmt.Errorf("invalid notify.interval %q in the config file (use a duration such as 5m)", cfg.Interval)
+		}
+		s.interval = d
+	}
+	if !flags.Changed("blackhole-days") && cfg.BlackholeDays != 0 {
+		s.blackholeDays = cfg.BlackholeDays
+	}
+	if !flags.Changed("within") && cfg.EvaluationWithin != "" {
+		d, err := time.ParseDuration(cfg.EvaluationWithin)
+		if err != nil {
+			return s, fmt.Errorf("invalid notify.evaluation_within %q in the config file (use a duration such as 24h)", cfg.EvaluationWithin)
+		}
+		s.within = d
+	}
+	if !flags.Changed("no-desktop") && cfg.Desktop != nil {
+		s.desktop = *cfg.Desktop
+	}
+	if !flags.Changed("webhook") && cfg.Webhook != "" {
+		s.webhook = cfg.Webhook
+	}
+	if !flags.Changed("format") && cfg.WebhookFormat != "" {
+		s.format = cfg.WebhookFormat
+	}
+
+	if s.interval < notifyDaemonMinInterval {
+		return s, fmt.Errorf("the check interval must be at least %s", notifyDaemonMinInterval)
+	}
+	if s.blackholeDays < 0 {
+		return s, fmt.Errorf("--blackhole-days must not be negative")
+	}
+	if s.within <= 0 {
+		return s, fmt.Errorf("--within must be positive")
+	}
+	if s.webhook != "" {
+		format, err := digestWebhookFormat(s.webhook, s.format)
+		if err != nil {
+			return s, err
+		}
+		s.format = format
+	}
+	return s, nil
+}
+
+// notifyDaemonCheck checks once and sends the reminders not sent before.
+// A reminder is remembered as sent unless every channel it was sent to
+// failed, so that it is retried at the next check.
+func notifyDaemonCheck(ctx context.Context, collect func(context.Context, time.Time) ([]reminder, error), store *state.Store, s *daemonSettings, now time.Time) error {
+	reminders, err := collect(ctx, now)
+	if err != nil {
+		return err
+	}
+	// Reloaded at every check, to see what 't42 notify check' sent meanwhile
+	sent, err := loadNotifyState(store)
+	if err != nil {
+		return err
+	}
+
+	var delivered []reminder
+	for _, r := range unsentReminders(reminders, sent) {
+		if GetJSONOutput() {
+			data, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		} else {
+			fmt.Printf("🔔 [%s] %s: %s\n", now.Format("15:04:05"), r.Title, r.Message)
+		}
+		if deliverReminder(r, s) {
+			delivered = append(delivered, r)
+		}
+	}
+	return recordSent(store, sent, delivered, now)
+}
+
+// deliverReminder sends r to the desktop and the webhook, and reports
+// whether it reached at least one of the channels, or there were none.
+// Desktop notifications are turned off after their first failure, as if
+// they had never been on.
+func deliverReminder(r reminder, s *daemonSettings) bool {
+	channels, reached := 0, 0
+	if s.desktop {
+		if err := sendDesktopNotification(r.Title, r.Message); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Desktop notifications disabled: %v\n", err)
+			s.desktop = false
+		} else {
+			channels++
+			reached++
+		}
+	}
+	if s.webhook != "" {
+		channels++
+		if err := postReminder(s.webhook, s.format, r); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		} else {
+			reached++
+		}
+	}
+	return channels == 0 || reached > 0
+}
+
+// reminderPayload builds the webhook request body of a reminder
+func reminderPayload(format string, r reminder) ([]byte, error) {
+	switch format {
+	case digestFormatSlack:
+		return json.Marshal(map[string]string{"text": "*" + r.Title + "*: " + r.Message})
+	case digestFormatDiscord:
+		return json.Marshal(map[string]string{"content": "**" + r.Title + "**: " + r.Message})
+	default:
+		return json.Marshal(map[string]interface{}{
+			"subject":  r.Title,
+			"text":     r.Message,
+			"reminder": r,
+		})
+	}
+}
+
+// postReminder posts a reminder to a webhook
+func postReminder(webhook, format string, r reminder) error {
+	body, err := reminderPayload(format, r)
+	if err != nil {
+		return fmt.Errorf("failed to encode reminder: %w", err)
+	}
+	return postWebhook(webhook, "reminder", body)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/state"
+)
+
+func TestNotifyDaemonSettings(t *testing.T) {
+	off := false
+	tests := []struct {
+		name    string
+		args    []string
+		cfg     config.NotifySettings
+		want    daemonSettings
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: daemonSettings{interval: 5 * time.Minute, blackholeDays: 14, within: 24 * time.Hour, desktop: true, format: "auto"},
+		},
+		{
+			name: "config file",
+			cfg:  config.NotifySettings{Interval: "10m", BlackholeDays: 30, EvaluationWithin: "2h", Desktop: &off, Webhook: "https://hooks.slack.com/services/x"},
+			want: daemonSettings{interval: 10 * time.Minute, blackholeDays: 30, within: 2 * time.Hour, webhook: "https://hooks.slack.com/services/x", format: "slack"},
+		},
+		{
+			name: "flags override the config file",
+			args: []string{"--interval", "2m", "--blackhole-days", "7", "--webhook", "https://example.com/hook"},
+			cfg:  config.NotifySettings{Interval: "10m", BlackholeDays: 30, Webhook: "https://hooks.slack.com/services/x", WebhookFormat: "discord"},
+			want: daemonSettings{interval: 2 * time.Minute, blackholeDays: 7, within: 24 * time.Hour, desktop: true, webhook: "https://example.com/hook", format: "discord"},
+		},
+		{name: "interval too short", args: []string{"--interval", "30s"}, wantErr: true},
+		{name: "invalid config duration", cfg: config.NotifySettings{EvaluationWithin: "tomorrow"}, wantErr: true},
+		{name: "invalid webhook", args: []string{"--webhook", "ftp://example.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test"}
+			addNotifyDaemonFlags(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			got, err := notifyDaemonSettings(cmd, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("notifyDaemonSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("notifyDaemonSettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotifyDaemonCheck(t *testing.T) {
+	status := http.StatusOK
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Subject string `json:"subject"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		posted = append(posted, body.Subject)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	reminders := []reminder{
+		{Key: "evaluation/1", Kind: "evaluation", Title: "Evaluation to give", Message: "team at 10:00", At: now.Add(time.Hour)},
+		{Key: "booking/2", Kind: "booking", Title: "Slot booked", Message: "libft at 14:00", At: now.Add(5 * time.Hour)},
+	}
+	collect := func(context.Context, time.Time) ([]reminder, error) { return reminders, nil }
+	store := state.New(t.TempDir())
+	settings := &daemonSettings{webhook: server.URL, format: digestFormatJSON}
+
+	// The webhook is down: nothing is remembered, so the next check retries
+	status = http.StatusServiceUnavailable
+	if err := notifyDaemonCheck(context.Background(), collect, store, settings, now); err != nil {
+		t.Fatalf("notifyDaemonCheck() error = %v", err)
+	}
+	status = http.StatusOK
+	if err := notifyDaemonCheck(context.Background(), collect, store, settings, now.Add(time.Minute)); err != nil {
+		t.Fatalf("notifyDaemonCheck() error = %v", err)
+	}
+	// Both were delivered: the third check sends nothing
+	if err := notifyDaemonCheck(context.Background(), collect, store, settings, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("notifyDaemonCheck() error = %v", err)
+	}
+
+	want := []string{"Evaluation to give", "Slot booked", "Evaluation to give", "Slot booked"}
+	if len(posted) != len(want) {
+		t.Fatalf("posted %v, want %v", posted, want)
+	}
+	for i := range want {
+		if posted[i] != want[i] {
+			t.Errorf("post %d = %q, want %q", i, posted[i], want[i])
+		}
+	}
+}
+
+func TestReminderPayload(t *testing.T) {
+	r := reminder{Title: "Slot booked", Message: "libft at 14:00"}
+	tests := []struct {
+		format string
+		key    string
+		want   string
+	}{
+		{digestFormatSlack, "text", "*Slot booked*: libft at 14:00"},
+		{digestFormatDiscord, "content", "**Slot booked**: libft at 14:00"},
+		{digestFormatJSON, "text", "libft at 14:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			body, err := reminderPayload(tt.format, r)
+			if err != nil {
+				t.Fatalf("reminderPayload() error = %v", err)
+			}
+			var payload map[string]interface{}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("invalid payload %s: %v", body, err)
+			}
+			if payload[tt.key] != tt.want {
+				t.Errorf("payload[%q] = %v, want %q", tt.key, payload[tt.key], tt.want)
+			}
+		})
+	}
+}
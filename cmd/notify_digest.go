@@ -0,0 +1,481 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+	"github.com/naokiiida/t42-cli/internal/remind"
+)
+
+const (
+	// digestJobName identifies the scheduled 't42 notify digest' job
+	digestJobName = "digest"
+
+	// digestWebhookTimeout bounds the request posting a digest
+	digestWebhookTimeout = 15 * time.Second
+
+	// discordMessageLimit is the longest message a Discord webhook accepts
+	discordMessageLimit = 2000
+)
+
+// Webhook formats of 't42 notify digest'
+const (
+	digestFormatAuto    = "auto"
+	digestFormatSlack   = "slack"
+	digestFormatDiscord = "discord"
+	digestFormatJSON    = "json"
+)
+
+var notifyDigestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Send a daily digest of evaluations, events and deadlines",
+	Long: `Compile today's evaluations (to give and of your team), your campus's
+events and imminent deadlines (team deadlines and your blackhole) into one
+message, for people who don't live in the terminal all day.
+
+With --webhook the digest is posted to a Slack or Discord incoming webhook,
+or as JSON (subject, text and the digest itself) to any other URL, such as
+an email gateway; --format overrides the detection from the URL. Without
+it the digest is printed, e.g. for cron to mail it.
+
+--daily HH:MM schedules the digest instead of sending it: a job running
+the same command every day at that time is installed in your system
+scheduler, like 't42 remind install' does. 't42 remind list' shows it and
+'t42 remind remove --job digest' removes it.
+
+Examples:
+  t42 notify digest
+  t42 notify digest --webhook https://hooks.slack.com/services/T000/B000/XXXX
+  t42 notify digest --webhook https://discord.com/api/webhooks/1/abc --daily 08:00
+  t42 notify digest --webhook https://mail.example.com/hook --format json --skip-empty`,
+	Args: cobra.NoArgs,
+	RunE: runNotifyDigest,
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyDigestCmd)
+
+	notifyDigestCmd.Flags().String("webhook", "", "Post the digest to this webhook URL instead of printing it")
+	notifyDigestCmd.Flags().String("format", digestFormatAuto, "Webhook payload: auto, slack, discord or json")
+	notifyDigestCmd.Flags().String("daily", "", "Schedule the digest every day at this time (HH:MM) instead of sending it now")
+	notifyDigestCmd.Flags().String("scheduler", "", "With --daily, scheduler to use: systemd, launchd or cron (default: detected)")
+	notifyDigestCmd.Flags().Int("blackhole-days", 14, "Include the blackhole when it is at most this many days away")
+	notifyDigestCmd.Flags().Int("deadline-days", 3, "Include team deadlines at most this many days away")
+	notifyDigestCmd.Flags().Bool("skip-empty", false, "Send nothing when there is nothing to report")
+}
+
+// digest is the daily summary sent by 't42 notify digest'
+type digest struct {
+	Login    string          `json:"login"`
+	Date     string          `json:"date"` // local day, YYYY-MM-DD
+	Sections []digestSection `json:"sections"`
+}
+
+// digestSection is one titled group of the digest
+type digestSection struct {
+	Title string     `json:"title"`
+	Items []reminder `json:"items"`
+}
+
+// empty reports whether the digest has nothing to report
+func (d digest) empty() bool {
+	for _, s := range d.Sections {
+		if len(s.Items) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func runNotifyDigest(cmd *cobra.Command, args []string) error {
+	webhook, _ := cmd.Flags().GetString("webhook")
+	format, _ := cmd.Flags().GetString("format")
+	daily, _ := cmd.Flags().GetString("daily")
+	blackholeDays, _ := cmd.Flags().GetInt("blackhole-days")
+	deadlineDays, _ := cmd.Flags().GetInt("deadline-days")
+	skipEmpty, _ := cmd.Flags().GetBool("skip-empty")
+
+	if webhook != "" {
+		var err error
+		if format, err = digestWebhookFormat(webhook, format); err != nil {
+			return err
+		}
+	}
+	if daily != "" {
+		return scheduleDigest(cmd, daily)
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+
+	now := time.Now()
+	year, month, day := now.Date()
+	endOfDay := time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+
+	future := true
+	opts := &api.ListScaleTeamsOptions{
+		PerPage: api.DefaultPerPage,
+		Sort:    "begin_at",
+		Future:  &future,
+		Until:   endOfDay,
+	}
+	asCorrector, _, err := client.ListUserScaleTeams(ctx, me.ID, api.ScaleTeamAsCorrector, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list your evaluations: %w", err)
+	}
+	asCorrected, _, err := client.ListUserScaleTeams(ctx, me.ID, api.ScaleTeamAsCorrected, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list your evaluations: %w", err)
+	}
+
+	var events []api.Event
+	if campus := primaryCampus(me); campus != nil {
+		events, err = fetchCampusEvents(ctx, client, campus.ID, &api.ListEventsOptions{Since: now, Until: endOfDay})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Teams still in progress are among the most recent ones
+	teams, _, err := client.ListUserTeams(ctx, me.ID, &api.ListTeamsOptions{PerPage: api.DefaultPerPage, Sort: "-created_at"})
+	if err != nil {
+		return fmt.Errorf("failed to list your teams: %w", err)
+	}
+
+	projectIDs := scaleTeamProjectIDs(append(append([]api.ScaleTeam(nil), asCorrector...), asCorrected...))
+	for _, t := range teams {
+		projectIDs = append(projectIDs, t.ProjectID)
+	}
+	projects := resolveProjectNames(ctx, client, projectIDs)
+
+	d := buildDigest(me, asCorrector, asCorrected, events, teams, projects, now, blackholeDays, time.Duration(deadlineDays)*24*time.Hour)
+
+	if webhook == "" {
+		if done, err := emitOutput(output.Field{Key: "digest", Value: d}); done || err != nil {
+			return err
+		}
+		fmt.Print(renderDigest(d, plainBold))
+		return nil
+	}
+
+	if skipEmpty && d.empty() {
+		if !GetJSONOutput() {
+			fmt.Println("✅ Nothing to report today, no digest sent.")
+		}
+		return nil
+	}
+	if err := postDigest(webhook, format, d); err != nil {
+		return err
+	}
+	if done, err := emitOutput(
+		output.Field{Key: "success", Value: true},
+		output.Field{Key: "format", Value: format},
+		output.Field{Key: "digest", Value: d},
+	); done || err != nil {
+		return err
+	}
+	fmt.Printf("📬 Digest sent (%s)\n", format)
+	return nil
+}
+
+// buildDigest groups today's evaluations and events, and the deadlines due
+// within their windows, soonest first in each section
+func buildDigest(me *api.User, asCorrector, asCorrected []api.ScaleTeam, events []api.Event, teams []api.Team, projects map[int]string, now time.Time, blackholeDays int, deadlineWithin time.Duration) digest {
+	year, month, day := now.Date()
+	endOfDay := time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+
+	var evaluations, deadlines []reminder
+	for _, r := range buildReminders(me, asCorrector, asCorrected, now, blackholeDays, endOfDay.Sub(now)) {
+		if r.Kind == "blackhole" {
+			deadlines = append(deadlines, r)
+			continue
+		}
+		evaluations = append(evaluations, r)
+	}
+	for i := range evaluations {
+		if project := projects[scaleTeamProjectID(evaluations[i].Key, asCorrector, asCorrected)]; project != "" {
+			evaluations[i].Message = project + ", " + evaluations[i].Message
+		}
+	}
+
+	var todaysEvents []reminder
+	for _, e := range events {
+		if (!e.EndAt.IsZero() && e.EndAt.Before(now)) || !e.BeginAt.Before(endOfDay) {
+			continue
+		}
+		message := strings.ReplaceAll(normalizeEventKind(e.Kind), "_", " ")
+		if e.Location != "" {
+			message += " in " + e.Location
+		}
+		todaysEvents = append(todaysEvents, reminder{
+			Key:     "event/" + strconv.Itoa(e.ID),
+			Kind:    "event",
+			Title:   e.Name,
+			Message: message,
+			At:      e.BeginAt,
+		})
+	}
+
+	for _, t := range teams {
+		if t.Closed || t.TerminatingAt == nil || t.TerminatingAt.Before(now) || t.TerminatingAt.After(now.Add(deadlineWithin)) {
+			continue
+		}
+		project := projects[t.ProjectID]
+		if project == "" {
+			project = t.Name
+		}
+		deadlines = append(deadlines, reminder{
+			Key:     "deadline/" + strconv.Itoa(t.ID),
+			Kind:    "deadline",
+			Title:   "Project deadline",
+			Message: fmt.Sprintf("%s, %s left", project, formatTimeLeft(t.TerminatingAt.Sub(now))),
+			At:      *t.TerminatingAt,
+		})
+	}
+
+	sortReminders(evaluations)
+	sortReminders(todaysEvents)
+	sortReminders(deadlines)
+	return digest{
+		Login: me.Login,
+		Date:  now.Format("2006-01-02"),
+		Sections: []digestSection{
+			{Title: "Evaluations", Items: evaluations},
+			{Title: "Events", Items: todaysEvents},
+			{Title: "Deadlines", Items: deadlines},
+		},
+	}
+}
+
+// scaleTeamProjectID returns the project of the evaluation an
+// "evaluation/<id>" reminder key refers to, or 0
+func scaleTeamProjectID(key string, lists ...[]api.ScaleTeam) int {
+	id, err := strconv.Atoi(strings.TrimPrefix(key, "evaluation/"))
+	if err != nil {
+		return 0
+	}
+	for _, list := range lists {
+		for _, st := range list {
+			if st.ID == id {
+				return st.Team.ProjectID
+			}
+		}
+	}
+	return 0
+}
+
+// formatTimeLeft formats a remaining duration as "2 days" or "5h"
+func formatTimeLeft(d time.Duration) string {
+	if days := int(d.Hours() / 24); days >= 1 {
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+// plainBold leaves section titles unmarked, for printed digests
+func plainBold(s string) string {
+	return s
+}
+
+// renderDigest formats the digest as a message, section titles marked up
+// with bold
+func renderDigest(d digest, bold func(string) string) string {
+	var b strings.Builder
+	date, err := time.ParseInLocation("2006-01-02", d.Date, time.Local)
+	title := d.Date
+	if err == nil {
+		title = date.Format("Mon Jan 2")
+	}
+	fmt.Fprintf(&b, "📬 %s\n", bold(fmt.Sprintf("t42 digest for %s, %s", d.Login, title)))
+	if d.empty() {
+		b.WriteString("Nothing scheduled today.\n")
+		return b.String()
+	}
+
+	for _, s := range d.Sections {
+		if len(s.Items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s\n", bold(s.Title))
+		for _, item := range s.Items {
+			at := item.At.Local()
+			when := at.Format("15:04")
+			if at.Format("2006-01-02") != d.Date {
+				when = at.Format("Mon Jan 2 15:04")
+			}
+			fmt.Fprintf(&b, "• %s %s: %s\n", when, item.Title, item.Message)
+		}
+	}
+	return b.String()
+}
+
+// digestWebhookFormat validates a webhook URL and resolves the auto format
+// from its host
+func digestWebhookFormat(webhook, format string) (string, error) {
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("invalid --webhook %q: use an http(s) URL", webhook)
+	}
+
+	switch format {
+	case digestFormatSlack, digestFormatDiscord, digestFormatJSON:
+		return format, nil
+	case digestFormatAuto:
+	default:
+		return "", fmt.Errorf("invalid --format %q (use auto, slack, discord or json)", format)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return digestFormatSlack, nil
+	case (host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")) && strings.HasPrefix(u.Path, "/api/webhooks/"):
+		return digestFormatDiscord, nil
+	}
+	return digestFormatJSON, nil
+}
+
+// digestPayload builds the webhook request body of a digest
+func digestPayload(format string, d digest) ([]byte, error) {
+	switch format {
+	case digestFormatSlack:
+		return json.Marshal(map[string]string{"text": renderDigest(d, func(s string) string { return "*" + s + "*" })})
+	case digestFormatDiscord:
+		text := renderDigest(d, func(s string) string { return "**" + s + "**" })
+		if runes := []rune(text); len(runes) > discordMessageLimit {
+			text = string(runes[:discordMessageLimit-1]) + "…"
+		}
+		return json.Marshal(map[string]string{"content": text})
+	default:
+		return json.Marshal(map[string]interface{}{
+			"subject": fmt.Sprintf("t42 digest for %s, %s", d.Login, d.Date),
+			"text":    renderDigest(d, plainBold),
+			"digest":  d,
+		})
+	}
+}
+
+// postDigest posts a digest to a webhook
+func postDigest(webhook, format string, d digest) error {
+	body, err := digestPayload(format, d)
+	if err != nil {
+		return fmt.Errorf("failed to encode digest: %w", err)
+	}
+	return postWebhook(webhook, "digest", body)
+}
+
+// postWebhook posts a JSON body to a webhook; what names the body in errors
+func postWebhook(webhook, what string, body []byte) error {
+	httpClient := &http.Client{Timeout: digestWebhookTimeout}
+	resp, err := httpClient.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post %s: %w", what, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook rejected the %s with status %d: %s", what, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// scheduleDigest installs a daily job running this digest command, with
+// the same options, at the given time
+func scheduleDigest(cmd *cobra.Command, at string) error {
+	schedulerName, _ := cmd.Flags().GetString("scheduler")
+
+	hour, minute, err := remind.ParseTime(at)
+	if err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the t42 executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	env, err := remind.DefaultEnv()
+	if err != nil {
+		return err
+	}
+	var scheduler remind.Scheduler
+	if schedulerName != "" {
+		scheduler, err = remind.New(schedulerName, env)
+	} else {
+		scheduler, err = remind.Detect(runtime.GOOS, env)
+	}
+	if err != nil {
+		return err
+	}
+
+	job := remind.Job{
+		Name:    digestJobName,
+		Command: append([]string{executable, "notify", "digest"}, digestJobArgs(cmd)...),
+		Hour:    hour,
+		Minute:  minute,
+	}
+	location, err := scheduler.Install(job)
+	if err != nil {
+		return fmt.Errorf("failed to install digest job: %w", err)
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "success", Value: true},
+		output.Field{Key: "scheduler", Value: scheduler.Name()},
+		output.Field{Key: "location", Value: location},
+		output.Field{Key: "at", Value: fmt.Sprintf("%02d:%02d", hour, minute)},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("⏰ Scheduled 't42 notify digest' daily at %02d:%02d (%s: %s)\n", hour, minute, scheduler.Name(), location)
+	return nil
+}
+
+// digestJobArgs returns the digest flags given on the command line, to be
+// passed on by the scheduled job; --daily and --scheduler only schedule
+func digestJobArgs(cmd *cobra.Command) []string {
+	var args []string
+	for _, name := range []string{"webhook", "format", "blackhole-days", "deadline-days", "skip-empty"} {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		args = append(args, "--"+name+"="+flag.Value.String())
+	}
+	return args
+}
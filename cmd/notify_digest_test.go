@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestDigestWebhookFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"slack", "https://hooks.slack.com/services/T0/B0/x", digestFormatAuto, digestFormatSlack, false},
+		{"discord", "https://discord.com/api/webhooks/1/abc", digestFormatAuto, digestFormatDiscord, false},
+		{"legacy discord", "https://discordapp.com/api/webhooks/1/abc", digestFormatAuto, digestFormatDiscord, false},
+		{"discord page is not a webhook", "https://discord.com/channels/1", digestFormatAuto, digestFormatJSON, false},
+		{"other host", "https://mail.example.com/hook", digestFormatAuto, digestFormatJSON, false},
+		{"explicit format", "https://mail.example.com/hook", digestFormatSlack, digestFormatSlack, false},
+		{"unknown format", "https://mail.example.com/hook", "teams", "", true},
+		{"not http", "ftp://example.com/hook", digestFormatAuto, "", true},
+		{"no host", "https:///hook", digestFormatAuto, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := digestWebhookFormat(tt.webhook, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("digestWebhookFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("digestWebhookFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	now := time.Date(2024, 6, 1, 8, 0, 0, 0, time.Local)
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	me := &api.User{
+		ID:          1,
+		Login:       "jdoe",
+		CursusUsers: []api.CursusUser{{BlackholedAt: at(10 * 24 * time.Hour), Cursus: api.Cursus{ID: 21, Name: "42cursus"}}},
+	}
+	asCorrector := []api.ScaleTeam{
+		{ID: 10, BeginAt: now.Add(3 * time.Hour), Team: api.Team{Name: "libft team", ProjectID: 1}},
+		{ID: 11, BeginAt: now.Add(20 * time.Hour), Team: api.Team{Name: "tomorrow"}},
+	}
+	asCorrected := []api.ScaleTeam{
+		{ID: 20, BeginAt: now.Add(time.Hour), Team: api.Team{Name: "my team", ProjectID: 2}, Corrector: &api.User{Login: "eval"}},
+	}
+	events := []api.Event{
+		{ID: 30, Name: "Go workshop", Kind: "workshop", Location: "Cluster 1", BeginAt: now.Add(9 * time.Hour), EndAt: now.Add(11 * time.Hour)},
+		{ID: 31, Name: "Over", Kind: "talk", BeginAt: now.Add(-3 * time.Hour), EndAt: now.Add(-time.Hour)},
+		{ID: 32, Name: "Tomorrow", Kind: "talk", BeginAt: now.Add(26 * time.Hour)},
+	}
+	teams := []api.Team{
+		{ID: 40, Name: "jdoe's group", ProjectID: 3, TerminatingAt: at(2 * 24 * time.Hour)},
+		{ID: 41, Name: "later", ProjectID: 3, TerminatingAt: at(20 * 24 * time.Hour)},
+		{ID: 42, Name: "closed", ProjectID: 3, Closed: true, TerminatingAt: at(24 * time.Hour)},
+		{ID: 43, Name: "no deadline", ProjectID: 3},
+	}
+	projects := map[int]string{1: "Libft", 2: "ft_printf", 3: "minishell"}
+
+	d := buildDigest(me, asCorrector, asCorrected, events, teams, projects, now, 14, 3*24*time.Hour)
+
+	wantKeys := map[string][]string{
+		"Evaluations": {"evaluation/20", "evaluation/10"},
+		"Events":      {"event/30"},
+		"Deadlines":   {"deadline/40", "blackhole/21/2024-06-01"},
+	}
+	if d.Login != "jdoe" || d.Date != "2024-06-01" || len(d.Sections) != len(wantKeys) {
+		t.Fatalf("unexpected digest: %+v", d)
+	}
+	for _, s := range d.Sections {
+		var keys []string
+		for _, item := range s.Items {
+			keys = append(keys, item.Key)
+		}
+		if strings.Join(keys, " ") != strings.Join(wantKeys[s.Title], " ") {
+			t.Errorf("%s = %v, want %v", s.Title, keys, wantKeys[s.Title])
+		}
+	}
+	if got := d.Sections[0].Items[0].Message; !strings.HasPrefix(got, "ft_printf, ") {
+		t.Errorf("evaluation message = %q, want the project first", got)
+	}
+	if got := d.Sections[2].Items[0].Message; got != "minishell, 2 days left" {
+		t.Errorf("deadline message = %q", got)
+	}
+
+	text := renderDigest(d, func(s string) string { return "*" + s + "*" })
+	for _, want := range []string{"*Evaluations*", "• 17:00 Go workshop: workshop in Cluster 1", "Mon Jun 3 08:00 Project deadline"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("renderDigest() missing %q:\n%s", want, text)
+		}
+	}
+
+	empty := buildDigest(&api.User{Login: "jdoe"}, nil, nil, nil, nil, nil, now, 14, 24*time.Hour)
+	if !empty.empty() || !strings.Contains(renderDigest(empty, plainBold), "Nothing scheduled today.") {
+		t.Errorf("empty digest rendered as:\n%s", renderDigest(empty, plainBold))
+	}
+}
+
+func TestPostDigest(t *testing.T) {
+	d := digest{Login: "jdoe", Date: "2024-06-01", Sections: []digestSection{{Title: "Events"}}}
+
+	tests := []struct {
+		name    string
+		format  string
+		status  int
+		wantKey string
+		wantErr bool
+	}{
+		{"slack", digestFormatSlack, http.StatusOK, "text", false},
+		{"discord", digestFormatDiscord, http.StatusNoContent, "content", false},
+		{"json", digestFormatJSON, http.StatusAccepted, "digest", false},
+		{"rejected", digestFormatSlack, http.StatusNotFound, "text", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Errorf("payload is not JSON: %v", err)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("no_service"))
+			}))
+			defer server.Close()
+
+			err := postDigest(server.URL, tt.format, d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("postDigest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "no_service") {
+				t.Errorf("error %q does not include the response", err)
+			}
+			if _, ok := payload[tt.wantKey]; !ok {
+				t.Errorf("payload %v has no %q", payload, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestDigestPayloadDiscordLimit(t *testing.T) {
+	items := make([]reminder, 200)
+	for i := range items {
+		items[i] = reminder{Title: "Project deadline", Message: strings.Repeat("x", 50)}
+	}
+	d := digest{Login: "jdoe", Date: "2024-06-01", Sections: []digestSection{{Title: "Deadlines", Items: items}}}
+
+	body, err := digestPayload(digestFormatDiscord, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if n := len([]rune(payload["content"])); n != discordMessageLimit {
+		t.Errorf("content has %d characters, want %d", n, discordMessageLimit)
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestBuildReminders(t *testing.T) {
+	now := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	me := &api.User{
+		ID: 1,
+		CursusUsers: []api.CursusUser{
+			{BlackholedAt: at(10 * 24 * time.Hour), Cursus: api.Cursus{ID: 21, Name: "42cursus"}},
+			{BlackholedAt: at(-24 * time.Hour), Cursus: api.Cursus{ID: 9, Name: "C Piscine"}},
+		},
+	}
+	asCorrector := []api.ScaleTeam{
+		{ID: 10, BeginAt: now.Add(3 * time.Hour), Team: api.Team{Name: "libft team"}},
+		{ID: 11, BeginAt: now.Add(48 * time.Hour), Team: api.Team{Name: "too late"}},
+	}
+	asCorrected := []api.ScaleTeam{
+		{ID: 20, BeginAt: now.Add(time.Hour), Team: api.Team{Name: "my team"}, Corrector: &api.User{Login: "eval"}},
+	}
+
+	reminders := buildReminders(me, asCorrector, asCorrected, now, 14, 24*time.Hour)
+
+	wantKeys := []string{"evaluation/20", "evaluation/10", "blackhole/21/2024-06-01"}
+	if len(reminders) != len(wantKeys) {
+		t.Fatalf("got %d reminders, want %d: %+v", len(reminders), len(wantKeys), reminders)
+	}
+	for i, key := range wantKeys {
+		if reminders[i].Key != key {
+			t.Errorf("reminder %d key = %q, want %q", i, reminders[i].Key, key)
+		}
+	}
+	if reminders[0].Title != "Evaluation of your team" {
+		t.Errorf("corrected reminder title = %q", reminders[0].Title)
+	}
+
+	if got := buildReminders(me, nil, nil, now, 5, time.Hour); len(got) != 0 {
+		t.Errorf("blackhole beyond the threshold was reminded: %+v", got)
+	}
+
+	sent := notifyState{Sent: map[string]time.Time{"evaluation/10": now}}
+	if got := unsentReminders(reminders, sent); len(got) != 2 {
+		t.Errorf("unsentReminders() kept %d reminders, want 2", len(got))
+	}
+}
+
+func TestBookedSlotReminders(t *testing.T) {
+	now := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	piece := func(id int, begin time.Duration, booked bool, st *api.ScaleTeam) api.Slot {
+		return api.Slot{ID: id, BeginAt: now.Add(begin), EndAt: now.Add(begin + 15*time.Minute), Booked: booked, ScaleTeam: st}
+	}
+	slots := []api.Slot{
+		// Booked, the evaluation only known from the evaluations to give
+		piece(1, 72*time.Hour, true, nil),
+		piece(2, 72*time.Hour+15*time.Minute, true, nil),
+		piece(3, 72*time.Hour+30*time.Minute, false, nil),
+		// Booked, with the scale team on the piece
+		piece(4, 96*time.Hour, true, &api.ScaleTeam{ID: 41, BeginAt: now.Add(96 * time.Hour), Team: api.Team{Name: "hidden group", ProjectID: 2}}),
+		// Booked, evaluation still hidden
+		piece(5, 120*time.Hour, true, nil),
+		// Open
+		piece(6, 144*time.Hour, false, nil),
+	}
+	asCorrector := []api.ScaleTeam{
+		{ID: 40, BeginAt: now.Add(72 * time.Hour), Team: api.Team{Name: "jdoe's group", ProjectID: 1}, Correcteds: []api.User{{Login: "jdoe"}, {Login: "asmith"}}},
+	}
+	projects := map[int]string{1: "Libft"}
+
+	bookings := bookedSlotEvaluations(slots, asCorrector)
+	reminders := bookedSlotReminders(bookings, projects)
+
+	wantKeys := []string{"booking/40", "booking/41", "booking/slot/5"}
+	if len(reminders) != len(wantKeys) {
+		t.Fatalf("got %d reminders, want %d: %+v", len(reminders), len(wantKeys), reminders)
+	}
+	for i, key := range wantKeys {
+		if reminders[i].Key != key {
+			t.Errorf("reminder %d key = %q, want %q", i, reminders[i].Key, key)
+		}
+	}
+
+	wantMessage := "Libft at " + now.Add(72*time.Hour).Local().Format("Mon Jan 2 15:04") + ", team jdoe's group (jdoe, asmith)"
+	if reminders[0].Message != wantMessage {
+		t.Errorf("message = %q, want %q", reminders[0].Message, wantMessage)
+	}
+	if !strings.HasPrefix(reminders[1].Message, "An evaluation at ") {
+		t.Errorf("message without a project name = %q", reminders[1].Message)
+	}
+
+	if got := bookedSlotEvaluations([]api.Slot{piece(6, time.Hour, false, nil)}, asCorrector); len(got) != 0 {
+		t.Errorf("open slots gave bookings: %+v", got)
+	}
+}
+
+func TestNotificationCommand(t *testing.T) {
+	tests := []struct {
+		goos   string
+		want   string
+		wantOK bool
+	}{
+		{"linux", "notify-send", true},
+		{"darwin", "osascript", true},
+		{"windows", "powershell", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, _, ok := notificationCommand(tt.goos, "t", "m")
+			if name != tt.want || ok != tt.wantOK {
+				t.Errorf("notificationCommand(%q) = %q, %v, want %q, %v", tt.goos, name, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if got := powerShellString("it's"); got != "'it''s'" {
+		t.Errorf("powerShellString() = %s", got)
+	}
+	if got := appleScriptString(`say "hi" \o/`); got != `"say \"hi\" \\o/"` {
+		t.Errorf("appleScriptString() = %s", got)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var (
+	// fieldsFlag is the --fields flag
+	fieldsFlag string
+
+	// outputFields are the parsed --fields of the running command, nil when
+	// not given
+	outputFields *output.Fields
+
+	// commandFieldColumns holds the CSV/TSV columns commands let --fields
+	// pick by name besides their registered ones
+	commandFieldColumns = map[*cobra.Command][]output.Column{}
+)
+
+// registerFieldColumns defines columns --fields can name in a command's CSV
+// and TSV output, on top of those of registerColumns
+func registerFieldColumns(cmd *cobra.Command, columns ...output.Column) {
+	commandFieldColumns[cmd] = columns
+}
+
+// resolveOutputFields parses --fields. It trims JSON and YAML output to the
+// dotted paths given, and picks the columns of CSV and TSV; table output
+// switches to JSON.
+func resolveOutputFields() error {
+	outputFields = nil
+	if fieldsFlag == "" {
+		return nil
+	}
+	fields, err := output.ParseFields(fieldsFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --fields: %w", err)
+	}
+	if outputFormat == output.FormatTable {
+		if outputFormatFlag != "" {
+			return fmt.Errorf("--fields selects from structured output and cannot be used with --output table")
+		}
+		outputFormat = output.FormatJSON
+	}
+	outputFields = fields
+	return nil
+}
+
+// outputTrimmed reports whether the JSON output is rewritten before it is
+// shown, by --jq, --template or --fields; CSV and TSV take --fields as
+// columns instead
+func outputTrimmed() bool {
+	if outputFiltered() {
+		return true
+	}
+	format := GetOutputFormat()
+	return outputFields != nil && format != output.FormatCSV && format != output.FormatTSV
+}
+
+// writeTrimmedJSON writes a JSON document trimmed to --fields, through --jq
+// or --template, in the selected format
+func writeTrimmedJSON(w io.Writer, data []byte) error {
+	if outputFields != nil {
+		trimmed, err := outputFields.TrimJSON(data)
+		if err != nil {
+			return err
+		}
+		data = trimmed
+	}
+	if outputFiltered() {
+		return writeFilteredJSON(w, data)
+	}
+	return output.WriteData(w, GetOutputFormat(), data)
+}
+
+// fieldColumns returns the CSV/TSV columns of the running command, those
+// named by --fields when given
+func fieldColumns(columns *output.Columns) *output.Columns {
+	if outputFields == nil {
+		return columns
+	}
+	selected := &output.Columns{}
+	var named []output.Column
+	if columns != nil {
+		selected.Field = columns.Field
+		named = append(named, columns.Columns...)
+	}
+	named = append(named, commandFieldColumns[outputCommand]...)
+	selected.Columns = outputFields.Columns(named)
+	return selected
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+func TestResolveOutputFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  string
+		flag    string
+		want    output.Format
+		wantErr bool
+	}{
+		{name: "no fields", want: output.FormatTable},
+		{name: "fields select json", fields: "login", want: output.FormatJSON},
+		{name: "fields with yaml output", fields: "login", flag: "yaml", want: output.FormatYAML},
+		{name: "fields with csv output", fields: "login", flag: "csv", want: output.FormatCSV},
+		{name: "fields with table output", fields: "login", flag: "table", wantErr: true},
+		{name: "invalid fields", fields: "cursus_users..level", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			fieldsFlag, outputFormatFlag = tt.fields, tt.flag
+			defer func() {
+				fieldsFlag, outputFormatFlag, outputFormat = "", "", ""
+				outputFields = nil
+			}()
+
+			err := resolveOutputFormat(rootCmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveOutputFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && GetOutputFormat() != tt.want {
+				t.Errorf("GetOutputFormat() = %q, want %q", GetOutputFormat(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteTrimmedJSON(t *testing.T) {
+	data := []byte(`{"login":"jdoe","id":1234567,"cursus_users":[{"level":7.42,"grade":"Member"}]}`)
+	tests := []struct {
+		name   string
+		fields string
+		jq     string
+		flag   string
+		want   string
+	}{
+		{name: "fields", fields: "id,cursus_users.level", want: "{\n  \"id\": 1234567,\n  \"cursus_users\": [\n    {\n      \"level\": 7.42\n    }\n  ]\n}\n"},
+		{name: "fields as yaml", fields: "login", flag: "yaml", want: "login: jdoe\n"},
+		{name: "fields then jq", fields: "login,id", jq: "keys", want: "[\"id\",\"login\"]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			fieldsFlag, jqFilter, outputFormatFlag = tt.fields, tt.jq, tt.flag
+			defer func() {
+				fieldsFlag, jqFilter, outputFormatFlag, outputFormat = "", "", "", ""
+				outputFields, outputQuery = nil, nil
+			}()
+			if err := resolveOutputFormat(rootCmd); err != nil {
+				t.Fatalf("resolveOutputFormat() error = %v", err)
+			}
+			if !outputTrimmed() {
+				t.Fatal("outputTrimmed() = false with --fields")
+			}
+
+			var buf bytes.Buffer
+			if err := writeTrimmedJSON(&buf, data); err != nil {
+				t.Fatalf("writeTrimmedJSON() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
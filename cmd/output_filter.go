@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/itchyny/gojq"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var (
+	// jqFilter and templateFilter are the --jq and --template flags
+	jqFilter       string
+	templateFilter string
+
+	// outputQuery and outputTemplate are the parsed filters of the
+	// running command, nil when not given
+	outputQuery    *gojq.Code
+	outputTemplate *template.Template
+)
+
+// outputTemplateFuncs are the functions available to --template besides
+// Go's builtin ones
+var outputTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(sep string, values []interface{}) string {
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = fmt.Sprint(v)
+		}
+		return strings.Join(parts, sep)
+	},
+}
+
+// resolveOutputFilter parses --jq or --template. Both work on the JSON
+// output, which they select when no format is given.
+func resolveOutputFilter() error {
+	outputQuery, outputTemplate = nil, nil
+	if jqFilter == "" && templateFilter == "" {
+		return nil
+	}
+	if jqFilter != "" && templateFilter != "" {
+		return fmt.Errorf("--jq and --template cannot be used together")
+	}
+	if outputFormatFlag != "" && outputFormat != output.FormatJSON {
+		return fmt.Errorf("--jq and --template filter JSON output and cannot be used with --output %s", outputFormat)
+	}
+	outputFormat = output.FormatJSON
+
+	if jqFilter != "" {
+		query, err := output.ParseJQ(jqFilter)
+		if err != nil {
+			return fmt.Errorf("invalid --jq expression: %w", err)
+		}
+		outputQuery = query
+		return nil
+	}
+	tmpl, err := template.New("output").Funcs(outputTemplateFuncs).Option("missingkey=zero").Parse(templateFilter)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %w", err)
+	}
+	outputTemplate = tmpl
+	return nil
+}
+
+// outputFiltered reports whether --jq or --template rewrites the output
+func outputFiltered() bool {
+	return outputQuery != nil || outputTemplate != nil
+}
+
+// writeFilteredJSON writes a JSON document through the --jq or --template
+// filter, or as it is without one
+func writeFilteredJSON(w io.Writer, data []byte) error {
+	switch {
+	case outputQuery != nil:
+		results, err := output.RunJQJSON(outputQuery, data)
+		if err != nil {
+			return err
+		}
+		return output.WriteJQ(w, results)
+	case outputTemplate != nil:
+		// Numbers stay as written: IDs would otherwise print as 1.23e+06
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("failed to decode output for --template: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := outputTemplate.Execute(&buf, value); err != nil {
+			return fmt.Errorf("failed to execute --template: %w", err)
+		}
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		_, err := buf.WriteTo(w)
+		return err
+	default:
+		_, err := w.Write(data)
+		return err
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+func TestResolveOutputFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		jq       string
+		template string
+		flag     string
+		want     output.Format
+		wantErr  bool
+	}{
+		{name: "no filter", want: output.FormatTable},
+		{name: "jq selects json", jq: ".login", want: output.FormatJSON},
+		{name: "template selects json", template: "{{.login}}", want: output.FormatJSON},
+		{name: "jq with json output", jq: ".login", flag: "json", want: output.FormatJSON},
+		{name: "jq with yaml output", jq: ".login", flag: "yaml", wantErr: true},
+		{name: "jq and template", jq: ".login", template: "{{.login}}", wantErr: true},
+		{name: "invalid jq", jq: ".login |", wantErr: true},
+		{name: "invalid template", template: "{{.login", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			jqFilter, templateFilter, outputFormatFlag = tt.jq, tt.template, tt.flag
+			defer func() {
+				jqFilter, templateFilter, outputFormatFlag, outputFormat = "", "", "", ""
+				outputQuery, outputTemplate = nil, nil
+			}()
+
+			err := resolveOutputFormat(rootCmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveOutputFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && GetOutputFormat() != tt.want {
+				t.Errorf("GetOutputFormat() = %q, want %q", GetOutputFormat(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFilteredJSON(t *testing.T) {
+	data := []byte(`{"login":"jdoe","id":1234567,"cursus_users":[{"level":7.42}],"titles":["a","b"]}`)
+	tests := []struct {
+		name     string
+		jq       string
+		template string
+		want     string
+	}{
+		{name: "no filter", want: string(data)},
+		{name: "jq path", jq: ".cursus_users[0].level", want: "7.42\n"},
+		{name: "jq string", jq: ".login", want: "jdoe\n"},
+		{name: "template", template: "{{.login}} {{.id}}", want: "jdoe 1234567\n"},
+		{name: "template keeps its newline", template: "{{.login}}\n", want: "jdoe\n"},
+		{name: "template functions", template: `{{join ", " .titles}} {{json .cursus_users}}`, want: `a, b [{"level":7.42}]` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			jqFilter, templateFilter = tt.jq, tt.template
+			defer func() {
+				jqFilter, templateFilter, outputFormat = "", "", ""
+				outputQuery, outputTemplate = nil, nil
+			}()
+			if err := resolveOutputFilter(); err != nil {
+				t.Fatalf("resolveOutputFilter() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := writeFilteredJSON(&buf, data); err != nil {
+				t.Fatalf("writeFilteredJSON() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var (
+	// outputFormat is the format resolved from --output, --json and the
+	// config's default_format before a command runs
+	outputFormat output.Format
+
+	// outputCommand is the command being run, whose columns CSV and TSV
+	// output uses
+	outputCommand *cobra.Command
+
+	// commandColumns holds the CSV/TSV columns registered by commands
+	commandColumns = map[*cobra.Command]*output.Columns{}
+)
+
+// registerColumns defines the CSV/TSV columns of a command: one row per
+// element of the field, one column per jq query. Commands without
+// columns get one column per scalar key of their rows.
+func registerColumns(cmd *cobra.Command, field string, columns ...output.Column) {
+	commandColumns[cmd] = &output.Columns{Field: field, Columns: columns}
+}
+
+// resolveOutputFormat sets the output format of the command about to run.
+// --output wins over --json, which wins over default_format in the config.
+func resolveOutputFormat(cmd *cobra.Command) error {
+	outputCommand = cmd
+	name := outputFormatFlag
+	if name == "" && !jsonOutput {
+		if cfg, err := config.LoadConfig(); err == nil {
+			name = cfg.DefaultFormat
+		}
+	}
+
+	switch {
+	case name != "":
+		format, err := output.ParseFormat(name)
+		if err != nil {
+			if outputFormatFlag == "" {
+				return fmt.Errorf("invalid default_format in the config file: %w", err)
+			}
+			return err
+		}
+		outputFormat = format
+	case jsonOutput:
+		outputFormat = output.FormatJSON
+	default:
+		outputFormat = output.FormatTable
+	}
+	if err := resolveOutputFilter(); err != nil {
+		return err
+	}
+	if err := resolveOutputFields(); err != nil {
+		return err
+	}
+	return resolveOutSink()
+}
+
+// GetOutputFormat returns the output format of the running command
+func GetOutputFormat() output.Format {
+	if outputFormat == "" {
+		if jsonOutput {
+			return output.FormatJSON
+		}
+		return output.FormatTable
+	}
+	return outputFormat
+}
+
+// writeOutput writes a command's structured output to stdout in the
+// selected format, and as JSON to the --out file if any
+func writeOutput(fields ...output.Field) error {
+	return writeOutputColumns(commandColumns[outputCommand], fields...)
+}
+
+// writeOutputColumns writes structured output like writeOutput, with CSV
+// and TSV columns of its own for output shaped unlike the command's usual
+// one
+func writeOutputColumns(columns *output.Columns, fields ...output.Field) error {
+	fields = withWarnings(fields)
+	if err := writeOutSink(func(w io.Writer) error { return output.WriteJSON(w, fields...) }); err != nil {
+		return err
+	}
+	if outputTrimmed() {
+		var buf bytes.Buffer
+		if err := output.WriteJSON(&buf, fields...); err != nil {
+			return err
+		}
+		if err := writeTrimmedJSON(os.Stdout, buf.Bytes()); err != nil {
+			return err
+		}
+	} else if err := output.Write(os.Stdout, GetOutputFormat(), fieldColumns(columns), fields...); err != nil {
+		return err
+	}
+	if warningsShownInOutput() {
+		commandWarnings.Reset()
+	}
+	return nil
+}
+
+// writeOutputValue writes a single value to stdout in the selected format
+func writeOutputValue(v interface{}) error {
+	if err := writeOutSink(func(w io.Writer) error { return output.WriteValue(w, output.FormatJSON, nil, v) }); err != nil {
+		return err
+	}
+	if outputTrimmed() {
+		var buf bytes.Buffer
+		if err := output.WriteValue(&buf, output.FormatJSON, nil, v); err != nil {
+			return err
+		}
+		return writeTrimmedJSON(os.Stdout, buf.Bytes())
+	}
+	return output.WriteValue(os.Stdout, GetOutputFormat(), fieldColumns(commandColumns[outputCommand]), v)
+}
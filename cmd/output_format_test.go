@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+func TestResolveOutputFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultFormat string
+		flag          string
+		json          bool
+		want          output.Format
+		wantErr       bool
+	}{
+		{name: "no config", want: output.FormatTable},
+		{name: "config default", defaultFormat: "csv", want: output.FormatCSV},
+		{name: "json flag wins over config", defaultFormat: "yaml", json: true, want: output.FormatJSON},
+		{name: "output flag wins over config", defaultFormat: "json", flag: "tsv", want: output.FormatTSV},
+		{name: "output flag wins over json", flag: "yml", json: true, want: output.FormatYAML},
+		{name: "invalid output flag", flag: "xml", wantErr: true},
+		{name: "invalid config default", defaultFormat: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			if tt.defaultFormat != "" {
+				if err := config.SaveConfig(&config.Config{DefaultFormat: tt.defaultFormat}); err != nil {
+					t.Fatalf("failed to save config: %v", err)
+				}
+			}
+			outputFormatFlag, jsonOutput = tt.flag, tt.json
+			defer func() {
+				outputFormatFlag, jsonOutput, outputFormat = "", false, ""
+			}()
+
+			err := resolveOutputFormat(rootCmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveOutputFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && GetOutputFormat() != tt.want {
+				t.Errorf("GetOutputFormat() = %q, want %q", GetOutputFormat(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// outputProfileEnvVar selects an output profile without the flag, e.g. in
+// the environment of a script
+const outputProfileEnvVar = "T42_OUTPUT_PROFILE"
+
+// noColorEnvVar disables colors and emoji, see https://no-color.org
+const noColorEnvVar = "NO_COLOR"
+
+// GetNoColor returns whether colored output is disabled
+func GetNoColor() bool {
+	return noColor || os.Getenv(noColorEnvVar) != ""
+}
+
+// GetPlainOutput returns whether output is converted to plain text
+func GetPlainOutput() bool {
+	return GetAccessible() || GetNoColor() || !ansiSupported
+}
+
+// selectedOutputProfile returns the name of the output profile to apply
+func selectedOutputProfile() string {
+	if outputProfile != "" {
+		return outputProfile
+	}
+	return os.Getenv(outputProfileEnvVar)
+}
+
+// applySelectedOutputProfile applies the output profile chosen with
+// --profile-output or T42_OUTPUT_PROFILE to the command's flags
+func applySelectedOutputProfile(flags *pflag.FlagSet) error {
+	name := selectedOutputProfile()
+	if name == "" {
+		return nil
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	profile, ok := cfg.OutputProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown output profile %q (%s)", name, describeOutputProfiles(cfg.OutputProfiles))
+	}
+	return applyOutputProfile(flags, profile)
+}
+
+// applyOutputProfile sets the flags a profile defines. Flags given on the
+// command line take precedence over the profile.
+func applyOutputProfile(flags *pflag.FlagSet, profile config.OutputProfile) error {
+	settings := map[string]string{}
+	switch strings.ToLower(profile.Format) {
+	case "":
+	case "json":
+		settings["json"] = "true"
+	case "table":
+		settings["json"] = "false"
+	case "yaml", "yml", "csv", "tsv":
+		settings["output"] = strings.ToLower(profile.Format)
+	default:
+		return fmt.Errorf("invalid output profile format %q (use table, json, yaml, csv or tsv)", profile.Format)
+	}
+	for flag, value := range map[string]*bool{
+		"json":       profile.JSON,
+		"no-color":   profile.NoColor,
+		"verbose":    profile.Verbose,
+		"accessible": profile.Accessible,
+	} {
+		if value != nil {
+			settings[flag] = strconv.FormatBool(*value)
+		}
+	}
+
+	// --json on the command line also wins over a profile's yaml, csv or
+	// tsv format, which would otherwise take precedence through --output
+	jsonGiven := flags.Changed("json")
+	for flag, value := range settings {
+		if flags.Lookup(flag) == nil || flags.Changed(flag) || (flag == "output" && jsonGiven) {
+			continue
+		}
+		if err := flags.Set(flag, value); err != nil {
+			return fmt.Errorf("failed to apply output profile: %w", err)
+		}
+	}
+	return nil
+}
+
+// describeOutputProfiles lists the defined profile names for error messages
+func describeOutputProfiles(profiles map[string]config.OutputProfile) string {
+	if len(profiles) == 0 {
+		return "no profiles are defined in the config file"
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "defined: " + strings.Join(names, ", ")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestApplyOutputProfile(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name        string
+		profile     config.OutputProfile
+		args        []string
+		wantJSON    bool
+		wantNoColor bool
+		wantVerbose bool
+		wantOutput  string
+		wantErr     bool
+	}{
+		{name: "empty profile", profile: config.OutputProfile{}},
+		{name: "script", profile: config.OutputProfile{JSON: &yes, NoColor: &yes}, wantJSON: true, wantNoColor: true},
+		{name: "format json", profile: config.OutputProfile{Format: "JSON"}, wantJSON: true},
+		{name: "format table", profile: config.OutputProfile{Format: "table", Verbose: &yes}, wantVerbose: true},
+		{name: "command line wins", profile: config.OutputProfile{JSON: &yes, NoColor: &yes}, args: []string{"--json=false"}, wantNoColor: true},
+		{name: "command line wins over false", profile: config.OutputProfile{JSON: &no}, args: []string{"--json"}, wantJSON: true},
+		{name: "format yaml", profile: config.OutputProfile{Format: "YAML"}, wantOutput: "yaml"},
+		{name: "format csv", profile: config.OutputProfile{Format: "csv"}, wantOutput: "csv"},
+		{name: "command line json wins over format", profile: config.OutputProfile{Format: "tsv"}, args: []string{"--json"}, wantJSON: true},
+		{name: "invalid format", profile: config.OutputProfile{Format: "xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jsonFlag, noColorFlag, verboseFlag bool
+			var outputFlag string
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.BoolVar(&jsonFlag, "json", false, "")
+			flags.BoolVar(&noColorFlag, "no-color", false, "")
+			flags.BoolVar(&verboseFlag, "verbose", false, "")
+			flags.StringVar(&outputFlag, "output", "", "")
+			if err := flags.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			err := applyOutputProfile(flags, tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyOutputProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if jsonFlag != tt.wantJSON || noColorFlag != tt.wantNoColor || verboseFlag != tt.wantVerbose {
+				t.Errorf("json = %v, no-color = %v, verbose = %v, want %v, %v, %v",
+					jsonFlag, noColorFlag, verboseFlag, tt.wantJSON, tt.wantNoColor, tt.wantVerbose)
+			}
+			if outputFlag != tt.wantOutput {
+				t.Errorf("output = %q, want %q", outputFlag, tt.wantOutput)
+			}
+		})
+	}
+}
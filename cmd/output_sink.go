@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var (
+	// outFile is the --out flag: a file the full JSON output is written
+	// to, whatever stdout shows
+	outFile string
+
+	// outWritten records whether the running command wrote to outFile
+	outWritten bool
+)
+
+// resolveOutSink checks --out before a command runs
+func resolveOutSink() error {
+	outWritten = false
+	if outFile == "" {
+		return nil
+	}
+	if outFile == "-" {
+		return fmt.Errorf("--out needs a file; use --json to write JSON to stdout")
+	}
+	return nil
+}
+
+// writeOutSink writes a JSON document to the --out file, if any. The file
+// is replaced by the first document of a run; later ones are appended.
+func writeOutSink(write func(w io.Writer) error) error {
+	if outFile == "" {
+		return nil
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if outWritten {
+		flags = os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(outFile, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open --out file: %w", err)
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write --out file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write --out file: %w", err)
+	}
+	outWritten = true
+	return nil
+}
+
+// collectOutput reports whether a command printing table rows as they
+// arrive must still collect them, for --out
+func collectOutput() bool {
+	return GetJSONOutput() || outFile != ""
+}
+
+// checkOutSink fails commands given --out that wrote nothing to it, which
+// happens with commands still rendering tables on their own
+func checkOutSink(path string) error {
+	if outFile == "" || outWritten {
+		return nil
+	}
+	return fmt.Errorf("'t42 %s' wrote nothing to %s: it only supports --out with --json or --output", path, outFile)
+}
+
+// emitOutput writes a command's structured output. In a structured format
+// it goes to stdout and emitOutput reports true; for table output it only
+// goes to the --out file, if any, and emitOutput reports false for the
+// caller to print its table:
+//
+//	if done, err := emitOutput(fields...); done || err != nil {
+//		return err
+//	}
+//	printTable(...)
+func emitOutput(fields ...output.Field) (bool, error) {
+	return emitOutputColumns(commandColumns[outputCommand], fields...)
+}
+
+// emitOutputColumns is emitOutput with CSV and TSV columns of its own, like
+// writeOutputColumns
+func emitOutputColumns(columns *output.Columns, fields ...output.Field) (bool, error) {
+	if GetJSONOutput() {
+		return true, writeOutputColumns(columns, fields...)
+	}
+	return false, writeOutSink(func(w io.Writer) error { return output.WriteJSON(w, withWarnings(fields)...) })
+}
+
+// emitOutputValue is emitOutput for a single value
+func emitOutputValue(v interface{}) (bool, error) {
+	if GetJSONOutput() {
+		return true, writeOutputValue(v)
+	}
+	return false, writeOutSink(func(w io.Writer) error { return output.WriteValue(w, output.FormatJSON, nil, v) })
+}
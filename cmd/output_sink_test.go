@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+func TestEmitOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   output.Format
+		out      bool
+		wantDone bool
+		wantFile string
+	}{
+		{name: "table", format: output.FormatTable},
+		{name: "table with --out", format: output.FormatTable, out: true, wantFile: "{\n  \"login\": \"jdoe\"\n}\n"},
+		{name: "json", format: output.FormatJSON, wantDone: true},
+		{name: "json with --out", format: output.FormatJSON, out: true, wantDone: true, wantFile: "{\n  \"login\": \"jdoe\"\n}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "out.json")
+			outputFormat, outWritten = tt.format, false
+			outFile = ""
+			if tt.out {
+				outFile = path
+			}
+			stdout := os.Stdout
+			devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			os.Stdout = devNull
+			defer func() {
+				os.Stdout = stdout
+				_ = devNull.Close()
+				outputFormat, outFile, outWritten = "", "", false
+			}()
+
+			done, err := emitOutput(output.Field{Key: "login", Value: "jdoe"})
+			if err != nil {
+				t.Fatalf("emitOutput() error = %v", err)
+			}
+			if done != tt.wantDone {
+				t.Errorf("emitOutput() done = %v, want %v", done, tt.wantDone)
+			}
+
+			data, err := os.ReadFile(path)
+			if tt.wantFile == "" {
+				if err == nil {
+					t.Errorf("--out file written without --out: %s", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("--out file not written: %v", err)
+			}
+			if string(data) != tt.wantFile {
+				t.Errorf("--out file = %q, want %q", data, tt.wantFile)
+			}
+			if err := checkOutSink("user show"); err != nil {
+				t.Errorf("checkOutSink() error = %v after writing", err)
+			}
+		})
+	}
+}
+
+func TestCheckOutSink(t *testing.T) {
+	outFile, outWritten = filepath.Join(t.TempDir(), "out.json"), false
+	defer func() { outFile, outWritten = "", false }()
+
+	if err := checkOutSink("auth login"); err == nil {
+		t.Error("checkOutSink() = nil for a command that wrote nothing")
+	}
+}
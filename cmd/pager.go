@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// writePaged prints long output through the pager of the config file or
+// $PAGER when standard output is a terminal, and as it is otherwise
+func writePaged(text string) error {
+	var pager []string
+	if isTerminal(os.Stdout) {
+		configured := ""
+		if cfg, err := config.LoadConfig(); err == nil {
+			configured = cfg.Pager
+		}
+		var err error
+		if pager, err = pagerCommand(configured, os.Getenv("PAGER")); err != nil {
+			return err
+		}
+	}
+	if pager == nil {
+		fmt.Print(text)
+		return nil
+	}
+
+	page := exec.Command(pager[0], pager[1:]...)
+	page.Stdin = strings.NewReader(text)
+	page.Stdout, page.Stderr = os.Stdout, os.Stderr
+	// Like git: keep colors and quit when the text fits on one screen
+	page.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		page.Env = append(page.Env, "LESS=FRX")
+	}
+	if err := page.Run(); err != nil {
+		return fmt.Errorf("failed to run pager %s: %w", pager[0], err)
+	}
+	return nil
+}
+
+// pagerCommand splits the pager setting, else $PAGER, into a command and
+// its arguments; nil means no paging, when neither is set or it is cat
+func pagerCommand(configured, env string) ([]string, error) {
+	for _, src := range []struct{ name, value string }{{"pager", configured}, {"$PAGER", env}} {
+		if src.value == "" {
+			continue
+		}
+		args, err := splitCommandLine(src.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", src.name, err)
+		}
+		if len(args) == 0 || args[0] == "cat" {
+			return nil, nil
+		}
+		return args, nil
+	}
+	return nil, nil
+}
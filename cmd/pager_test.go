@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPagerCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		env        string
+		want       []string
+		wantErr    bool
+	}{
+		{name: "config first", configured: "less -R", env: "more", want: []string{"less", "-R"}},
+		{name: "environment", env: "more", want: []string{"more"}},
+		{name: "none", want: nil},
+		{name: "cat turns paging off", configured: "cat", env: "less", want: nil},
+		{name: "invalid", configured: `less "-R`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pagerCommand(tt.configured, tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pagerCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pagerCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// presenceBarWidth is the width of the weekly goal bar
+const presenceBarWidth = 30
+
+var presenceCmd = &cobra.Command{
+	Use:   "presence",
+	Short: "Show whether you are logged in at campus and your logtime this week",
+	Long: `Show whether the API currently sees you logged in at a campus
+workstation, how long your current session and today's sessions last, and
+your total this week (weeks start on Monday).
+
+With a weekly goal, from --goal or logtime_goal in the config file, a
+progress bar shows how far along you are and what is left per remaining
+day.
+
+Examples:
+  t42 presence
+  t42 presence --goal 30h
+  t42 watch presence --until '.presence.logged_in'`,
+	Args: cobra.NoArgs,
+	RunE: runPresence,
+}
+
+func init() {
+	rootCmd.AddCommand(presenceCmd)
+
+	presenceCmd.Flags().String("goal", "", "Weekly logtime goal, e.g. 30h (default: logtime_goal from the config file)")
+}
+
+// presenceReport is the current session and logtime of a user
+type presenceReport struct {
+	LoggedIn       bool          `json:"logged_in"`
+	Session        *api.Location `json:"session"`
+	SessionSeconds int64         `json:"session_seconds"`
+	TodaySeconds   int64         `json:"today_seconds"`
+	WeekStart      time.Time     `json:"week_start"`
+	WeekSeconds    int64         `json:"week_seconds"`
+	GoalSeconds    int64         `json:"goal_seconds,omitempty"`
+	// DaysLeft counts today and the remaining days of the week
+	DaysLeft int `json:"days_left"`
+
+	session, today, week, goal time.Duration
+}
+
+func runPresence(cmd *cobra.Command, args []string) error {
+	goalFlag, _ := cmd.Flags().GetString("goal")
+	goal, err := presenceGoal(goalFlag)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+
+	now := time.Now()
+	weekStart := startOfWeek(now)
+	locations, err := fetchAllUserLocations(ctx, client, me.ID, weekStart, now)
+	if err != nil {
+		return fmt.Errorf("failed to list locations: %w", err)
+	}
+	// A session begun before Monday is missing from the week's sessions
+	active, _, err := client.ListUserLocations(ctx, me.ID, &api.ListLocationsOptions{Active: true})
+	if err != nil {
+		return fmt.Errorf("failed to get your current session: %w", err)
+	}
+	locations = mergeLocations(locations, active)
+
+	report := buildPresence(locations, goal, now)
+
+	if done, err := emitOutput(
+		output.Field{Key: "login", Value: me.Login},
+		output.Field{Key: "presence", Value: report},
+	); done || err != nil {
+		return err
+	}
+	printPresence(report)
+	return nil
+}
+
+// presenceGoal returns the weekly goal given with --goal or in the config
+// file; zero when there is none
+func presenceGoal(flag string) (time.Duration, error) {
+	value, source := flag, "--goal"
+	if value == "" {
+		if cfg, err := config.LoadConfig(); err == nil {
+			value, source = cfg.LogtimeGoal, "logtime_goal in the config file"
+		}
+	}
+	if value == "" {
+		return 0, nil
+	}
+	goal, err := time.ParseDuration(value)
+	if err != nil || goal <= 0 {
+		return 0, fmt.Errorf("invalid %s %q (use a duration such as 30h)", source, value)
+	}
+	return goal, nil
+}
+
+// startOfWeek returns local midnight of the Monday of now's week
+func startOfWeek(now time.Time) time.Time {
+	offset := (int(now.Weekday()) + 6) % 7 // days since Monday
+	return time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, now.Location())
+}
+
+// mergeLocations adds the sessions of extra missing from locations
+func mergeLocations(locations, extra []api.Location) []api.Location {
+	seen := make(map[int]bool, len(locations))
+	for _, loc := range locations {
+		seen[loc.ID] = true
+	}
+	for _, loc := range extra {
+		if !seen[loc.ID] {
+			locations = append(locations, loc)
+		}
+	}
+	return locations
+}
+
+// buildPresence computes the current session, today's and this week's
+// logtime from the sessions of the week
+func buildPresence(locations []api.Location, goal time.Duration, now time.Time) presenceReport {
+	weekStart := startOfWeek(now)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	report := presenceReport{
+		WeekStart: weekStart,
+		DaysLeft:  7 - (int(now.Weekday())+6)%7,
+		today:     sumLogtime(locations, today, now),
+		week:      sumLogtime(locations, weekStart, now),
+		goal:      goal,
+	}
+	for i := range locations {
+		loc := &locations[i]
+		if loc.EndAt != nil {
+			continue
+		}
+		if report.Session == nil || loc.BeginAt.After(report.Session.BeginAt) {
+			report.Session = loc
+		}
+	}
+	if report.Session != nil {
+		report.LoggedIn = true
+		report.session = now.Sub(report.Session.BeginAt)
+	}
+
+	report.SessionSeconds = int64(report.session.Seconds())
+	report.TodaySeconds = int64(report.today.Seconds())
+	report.WeekSeconds = int64(report.week.Seconds())
+	report.GoalSeconds = int64(goal.Seconds())
+	return report
+}
+
+func printPresence(report presenceReport) {
+	if report.LoggedIn {
+		fmt.Printf("🟢 Logged in at %s since %s (%s)\n",
+			report.Session.Host, report.Session.BeginAt.Local().Format("15:04"), formatHours(report.session))
+	} else {
+		fmt.Println("⚪ Not logged in")
+	}
+	fmt.Printf("📅 Today:     %s\n", formatHours(report.today))
+	fmt.Printf("🗓️  This week: %s (since %s)\n", formatHours(report.week), report.WeekStart.Format("Mon Jan 02"))
+
+	if report.goal <= 0 {
+		return
+	}
+	fraction := float64(report.week) / float64(report.goal)
+	fmt.Printf("\n🎯 %s %.0f%% of %s\n", renderBar(fraction, presenceBarWidth), fraction*100, formatHours(report.goal))
+	if left := report.goal - report.week; left > 0 {
+		fmt.Printf("   %s left, %s per day over the %d remaining days\n",
+			formatHours(left), formatHours(left/time.Duration(report.DaysLeft)), report.DaysLeft)
+	} else {
+		fmt.Println("   Goal reached ✅")
+	}
+}
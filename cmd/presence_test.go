@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestStartOfWeek(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"wednesday", time.Date(2024, 6, 26, 15, 0, 0, 0, time.UTC), time.Date(2024, 6, 24, 0, 0, 0, 0, time.UTC)},
+		{"monday", time.Date(2024, 6, 24, 0, 30, 0, 0, time.UTC), time.Date(2024, 6, 24, 0, 0, 0, 0, time.UTC)},
+		{"sunday", time.Date(2024, 6, 30, 23, 0, 0, 0, time.UTC), time.Date(2024, 6, 24, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := startOfWeek(tt.now); !got.Equal(tt.want) {
+				t.Errorf("startOfWeek() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPresence(t *testing.T) {
+	now := time.Date(2024, 6, 26, 15, 0, 0, 0, time.UTC) // Wednesday
+	at := func(day, hour int) time.Time { return time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC) }
+	ended := func(day, from, to int) api.Location {
+		end := at(day, to)
+		return api.Location{ID: day*100 + from, BeginAt: at(day, from), EndAt: &end, Host: "c1r1s1"}
+	}
+
+	tests := []struct {
+		name         string
+		locations    []api.Location
+		wantLoggedIn bool
+		wantSession  time.Duration
+		wantToday    time.Duration
+		wantWeek     time.Duration
+	}{
+		{
+			name:      "logged out",
+			locations: []api.Location{ended(24, 9, 17), ended(26, 9, 12)},
+			wantToday: 3 * time.Hour,
+			wantWeek:  11 * time.Hour,
+		},
+		{
+			name:         "logged in",
+			locations:    []api.Location{ended(26, 8, 10), {ID: 1, BeginAt: at(26, 13), Host: "c2r3s4"}},
+			wantLoggedIn: true,
+			wantSession:  2 * time.Hour,
+			wantToday:    4 * time.Hour,
+			wantWeek:     4 * time.Hour,
+		},
+		{
+			name:         "session begun last week",
+			locations:    []api.Location{{ID: 1, BeginAt: at(23, 20), Host: "c2r3s4"}},
+			wantLoggedIn: true,
+			wantSession:  67 * time.Hour,
+			wantToday:    15 * time.Hour,
+			wantWeek:     63 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPresence(tt.locations, 30*time.Hour, now)
+			if got.LoggedIn != tt.wantLoggedIn || got.session != tt.wantSession {
+				t.Errorf("session = %v, %v, want %v, %v", got.LoggedIn, got.session, tt.wantLoggedIn, tt.wantSession)
+			}
+			if got.today != tt.wantToday || got.week != tt.wantWeek {
+				t.Errorf("today, week = %v, %v, want %v, %v", got.today, got.week, tt.wantToday, tt.wantWeek)
+			}
+			if got.DaysLeft != 5 || got.GoalSeconds != 30*3600 {
+				t.Errorf("days left = %d, goal = %d", got.DaysLeft, got.GoalSeconds)
+			}
+		})
+	}
+}
+
+func TestPresenceGoal(t *testing.T) {
+	tests := []struct {
+		flag    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30h", 30 * time.Hour, false},
+		{"24h30m", 24*time.Hour + 30*time.Minute, false},
+		{"thirty", 0, true},
+		{"-2h", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			got, err := presenceGoal(tt.flag)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("presenceGoal(%q) = %v, %v, want %v, wantErr %v", tt.flag, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
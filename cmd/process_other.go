@@ -0,0 +1,12 @@
+//go:build !windows
+
+package cmd
+
+import "syscall"
+
+// processAlive reports whether a process is running, by sending it the
+// null signal
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
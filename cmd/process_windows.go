@@ -0,0 +1,9 @@
+//go:build windows
+
+package cmd
+
+// processAlive cannot tell whether a shell is running on Windows, so it
+// reports true and sessions end through the shell's EXIT trap
+func processAlive(pid int) bool {
+	return true
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,10 +9,10 @@ import (
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
 	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
 )
 
 var projectCmd = &cobra.Command{
@@ -31,29 +30,39 @@ var listProjectsCmd = &cobra.Command{
 	Short: "List projects",
 	Long: `List projects from the 42 API.
 
-You can filter projects by cursus and control pagination options.
-Use --mine to show only your projects.`,
+You can filter projects by cursus. --limit is the number of projects
+shown, fetched over as many API pages of --per-page as it takes. Use
+--mine to show only your projects, and --all to fetch every page with
+rows printed as the pages arrive. --mine --by-cursus
+groups all your projects by cursus (piscine, common core, ...) with
+validated and in-progress subtotals and the XP of each cursus.`,
 	RunE: runListProjects,
 }
 
 var showProjectCmd = &cobra.Command{
-	Use:   "show <project-slug>",
+	Use:   "show [project-slug]",
 	Short: "Show project details",
 	Long: `Show detailed information about a specific project.
 
-You can specify a project by its slug (e.g., 'libft', 'get_next_line').`,
-	Args: cobra.ExactArgs(1),
+You can specify a project by its slug (e.g., 'libft', 'get_next_line').
+Without a slug, pick one of your projects interactively, or one of every
+project of your cursus with --all.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runShowProject,
 }
 
 var cloneProjectCmd = &cobra.Command{
-	Use:   "clone <project-slug> [directory]",
+	Use:   "clone [project-slug] [directory]",
 	Short: "Clone a project repository",
 	Long: `Clone a project's Git repository to your local machine.
 
 If no directory is specified, the project will be cloned into a
-directory named after the project slug.`,
-	Args: cobra.RangeArgs(1, 2),
+directory named after the project slug. Without a slug, pick one of your
+projects interactively, or one of every project of your cursus with --all.
+
+After cloning, the post_clone_hook from the config file runs in the
+clone, and --open-editor opens it in $VISUAL (or VS Code).`,
+	Args: cobra.RangeArgs(0, 2),
 	RunE: runCloneProject,
 }
 
@@ -67,7 +76,10 @@ and clones it using the repo_url from your team data. If you have
 multiple teams for the same project, it will use the most recent one.
 
 If no directory is specified, the project will be cloned into a
-directory named after the project slug with your login as suffix.`,
+directory named after the project slug with your login as suffix.
+
+After cloning, the post_clone_hook from the config file runs in the
+clone, and --open-editor opens it in $VISUAL (or VS Code).`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runCloneMine,
 }
@@ -84,19 +96,41 @@ func init() {
 	
 	// List command flags
 	listProjectsCmd.Flags().Bool("mine", false, "Show only my projects")
-	listProjectsCmd.Flags().IntP("page", "p", 1, "Page number")
-	listProjectsCmd.Flags().Int("per-page", 20, "Number of projects per page")
+	addLimitFlag(listProjectsCmd, 20, "Maximum number of projects to display (0 for all)")
+	listProjectsCmd.Flags().IntP("page", "p", 1, "API page to start fetching from")
+	addPerPageFlag(listProjectsCmd, api.DefaultPerPage)
 	listProjectsCmd.Flags().Int("cursus", 0, "Filter by cursus ID")
 	listProjectsCmd.Flags().StringP("sort", "s", "", "Sort by field (name, id, created_at)")
-	
+	listProjectsCmd.Flags().Bool("all", false, "Fetch every page and print rows as they arrive (ignores --limit and --page)")
+	listProjectsCmd.Flags().Bool("by-cursus", false, "With --mine, group all your projects by cursus with subtotals")
+	listProjectsCmd.MarkFlagsMutuallyExclusive("all", "page")
+	listProjectsCmd.MarkFlagsMutuallyExclusive("all", "limit")
+	listProjectsCmd.MarkFlagsMutuallyExclusive("by-cursus", "page")
+	listProjectsCmd.MarkFlagsMutuallyExclusive("by-cursus", "limit")
+	addInteractiveFlag(listProjectsCmd)
+	listProjectsCmd.MarkFlagsMutuallyExclusive("all", "interactive")
+	listProjectsCmd.MarkFlagsMutuallyExclusive("by-cursus", "interactive")
+	// Rows are projects, or your project users with --mine
+	registerColumns(listProjectsCmd, "projects",
+		output.Column{Header: "slug", Query: ".project.slug // .slug"},
+		output.Column{Header: "name", Query: ".project.name // .name"},
+		output.Column{Header: "status", Query: ".status"},
+		output.Column{Header: "final_mark", Query: ".final_mark"},
+	)
+	
+	addProjectPickerFlag(showProjectCmd)
+
 	// Clone command flags
 	cloneProjectCmd.Flags().Bool("no-clone", false, "Show clone command without executing")
-	cloneProjectCmd.Flags().Bool("force", false, "Force clone even if directory exists")
+	addCloneGuardFlags(cloneProjectCmd)
+	addPostCloneFlags(cloneProjectCmd)
+	addProjectPickerFlag(cloneProjectCmd)
 	
 	// Clone mine command flags
 	cloneMineCmd.Flags().Bool("no-clone", false, "Show clone command without executing")
-	cloneMineCmd.Flags().Bool("force", false, "Force clone even if directory exists")
+	addCloneGuardFlags(cloneMineCmd)
 	cloneMineCmd.Flags().Bool("latest", true, "Use the latest team (default: true)")
+	addPostCloneFlags(cloneMineCmd)
 }
 
 func runListProjects(cmd *cobra.Command, args []string) error {
@@ -110,10 +144,30 @@ func runListProjects(cmd *cobra.Command, args []string) error {
 	
 	// Get flags
 	mine, _ := cmd.Flags().GetBool("mine")
-	page, _ := cmd.Flags().GetInt("page")
-	perPage, _ := cmd.Flags().GetInt("per-page")
+	limits, err := limitOptions(cmd)
+	if err != nil {
+		return err
+	}
 	cursusID, _ := cmd.Flags().GetInt("cursus")
 	sort, _ := cmd.Flags().GetString("sort")
+	all, _ := cmd.Flags().GetBool("all")
+	byCursus, _ := cmd.Flags().GetBool("by-cursus")
+
+	browse, err := useBrowser(cmd)
+	if err != nil {
+		return err
+	}
+
+	if byCursus {
+		if !mine {
+			return fmt.Errorf("--by-cursus only works with --mine")
+		}
+		return listProjectsByCursus(ctx, client)
+	}
+
+	if all {
+		return listAllProjects(ctx, client, mine, limits.PerPage, cursusID, sort)
+	}
 	
 	if mine {
 		// List user's projects
@@ -123,104 +177,226 @@ func runListProjects(cmd *cobra.Command, args []string) error {
 		}
 		
 		opts := &api.ListUserProjectsOptions{
-			Page:    page,
-			PerPage: perPage,
+			PerPage: limits.PerPage,
 			Sort:    sort,
 		}
+		fetch := func(ctx context.Context, page int) ([]api.ProjectUser, *api.PaginationMeta, error) {
+			opts.Page = page
+			return client.ListUserProjects(ctx, user.ID, opts)
+		}
 		
-		projectUsers, meta, err := client.ListUserProjects(ctx, user.ID, opts)
+		result, err := api.FetchLimit(ctx, limits, fetch, nil)
 		if err != nil {
 			return fmt.Errorf("failed to list user projects: %w", err)
 		}
+		projectUsers, meta := result.Items, result.Meta
 		
-		if GetJSONOutput() {
-			output := map[string]interface{}{
-				"projects": projectUsers,
-				"meta":     meta,
+		done, err := emitOutput(
+			output.Field{Key: "meta", Value: meta},
+			output.Field{Key: "projects", Value: projectUsers},
+		)
+		switch {
+		case err != nil:
+			return fmt.Errorf("failed to write JSON output: %w", err)
+		case done:
+		case browse:
+			return browseUserProjects(client, projectUsers)
+		default:
+			printUserProjectsTable(projectUsers)
+			if result.More {
+				fmt.Println(moreResultsHint(limits.Limit))
 			}
-			jsonData, _ := json.MarshalIndent(output, "", "  ")
-			fmt.Println(string(jsonData))
-		} else {
-			printUserProjectsTable(projectUsers, meta)
 		}
 	} else {
 		// List all projects
 		opts := &api.ListProjectsOptions{
-			Page:     page,
-			PerPage:  perPage,
+			PerPage:  limits.PerPage,
 			CursusID: cursusID,
 			Sort:     sort,
 		}
+		fetch := func(ctx context.Context, page int) ([]api.Project, *api.PaginationMeta, error) {
+			opts.Page = page
+			return client.ListProjects(ctx, opts)
+		}
 		
-		projects, meta, err := client.ListProjects(ctx, opts)
+		result, err := api.FetchLimit(ctx, limits, fetch, nil)
 		if err != nil {
 			return fmt.Errorf("failed to list projects: %w", err)
 		}
+		projects, meta := result.Items, result.Meta
 		
-		if GetJSONOutput() {
-			output := map[string]interface{}{
-				"projects": projects,
-				"meta":     meta,
-			}
-			jsonData, _ := json.MarshalIndent(output, "", "  ")
-			fmt.Println(string(jsonData))
-		} else {
+		done, err := emitOutput(
+			output.Field{Key: "meta", Value: meta},
+			output.Field{Key: "projects", Value: projects},
+		)
+		switch {
+		case err != nil:
+			return fmt.Errorf("failed to write JSON output: %w", err)
+		case done:
+		case browse:
+			return browseProjects(client, projects)
+		default:
 			printProjectsTable(projects, meta)
+			if result.More {
+				fmt.Println(moreResultsHint(limits.Limit))
+			}
 		}
 	}
 	
 	return nil
 }
 
-func runShowProject(cmd *cobra.Command, args []string) error {
-	projectSlug := args[0]
+// listAllProjects fetches every page of projects and prints the rows as the
+// pages arrive; JSON output is written once all pages are in
+func listAllProjects(ctx context.Context, client *api.Client, mine bool, perPage, cursusID int, sort string) error {
+	var meta *api.PaginationMeta
+	count := 0
+	progress := func(pageMeta *api.PaginationMeta, n int) {
+		meta = pageMeta
+		if count == 0 && n > 0 && !GetJSONOutput() {
+			if mine {
+				printUserProjectsHeader()
+			} else {
+				printProjectsHeader()
+			}
+		}
+		count += n
+		if GetVerbose() {
+			fmt.Fprintf(os.Stderr, "Fetched page %d of %d (%d projects)\n", pageMeta.Page, pageMeta.TotalPages, count)
+		}
+	}
+
+	var results interface{}
+	if mine {
+		user, err := client.GetMe(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get user info: %w", err)
+		}
 
+		projectUsers := []api.ProjectUser{}
+		opts := &api.ListUserProjectsOptions{PerPage: perPage, Sort: sort}
+		err = client.FetchAllUserProjects(ctx, user.ID, opts, func(page []api.ProjectUser, pageMeta *api.PaginationMeta) error {
+			progress(pageMeta, len(page))
+			if collectOutput() {
+				projectUsers = append(projectUsers, page...)
+			}
+			if GetJSONOutput() {
+				return nil
+			}
+			for _, pu := range page {
+				printUserProjectRow(pu)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list user projects: %w", err)
+		}
+		results = projectUsers
+	} else {
+		projects := []api.Project{}
+		opts := &api.ListProjectsOptions{PerPage: perPage, CursusID: cursusID, Sort: sort}
+		err := client.FetchAllProjects(ctx, opts, func(page []api.Project, pageMeta *api.PaginationMeta) error {
+			progress(pageMeta, len(page))
+			if collectOutput() {
+				projects = append(projects, page...)
+			}
+			if GetJSONOutput() {
+				return nil
+			}
+			for _, project := range page {
+				printProjectRow(project)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list projects: %w", err)
+		}
+		results = projects
+	}
+
+	done, err := emitOutput(
+		output.Field{Key: "meta", Value: meta},
+		output.Field{Key: "projects", Value: results},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	if done {
+		return nil
+	}
+
+	if count == 0 {
+		fmt.Println("No projects found.")
+		return nil
+	}
+	fmt.Printf("\n📄 All %d projects shown\n", count)
+	return nil
+}
+
+func runShowProject(cmd *cobra.Command, args []string) error {
 	// Create API client with automatic token refresh
 	client, err := NewAPIClient()
 	if err != nil {
 		return err
 	}
 
+	projectSlug, err := projectSlugArg(cmd, args, client)
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
 	
-	// Get project by slug
-	project, err := client.GetProjectBySlug(ctx, projectSlug)
+	// Get project by slug, name or alias
+	project, err := resolveProject(ctx, client, projectSlug)
 	if err != nil {
-		return fmt.Errorf("failed to get project '%s': %w", projectSlug, err)
+		return err
 	}
 	
-	if GetJSONOutput() {
-		jsonData, _ := json.MarshalIndent(project, "", "  ")
-		fmt.Println(string(jsonData))
-	} else {
-		printProjectDetails(project)
+	if done, err := emitOutputValue(project); done || err != nil {
+		return err
 	}
+	printProjectDetails(project)
 	
 	return nil
 }
 
-func runCloneProject(cmd *cobra.Command, args []string) error {
-	projectSlug := args[0]
-	var targetDir string
-
-	if len(args) > 1 {
-		targetDir = args[1]
-	} else {
-		targetDir = projectSlug
+// showProjectDetails fetches a project and prints its details
+func showProjectDetails(client *api.Client, slug string) error {
+	project, err := client.GetProjectBySlug(context.Background(), slug)
+	if err != nil {
+		return fmt.Errorf("failed to get project '%s': %w", slug, err)
 	}
+	printProjectDetails(project)
+	return nil
+}
 
+func runCloneProject(cmd *cobra.Command, args []string) error {
 	// Create API client with automatic token refresh
 	client, err := NewAPIClient()
 	if err != nil {
 		return err
 	}
 
+	projectSlug, err := projectSlugArg(cmd, args, client)
+	if err != nil {
+		return err
+	}
 	ctx := context.Background()
 	
 	// Get project details
-	project, err := client.GetProjectBySlug(ctx, projectSlug)
+	project, err := resolveProject(ctx, client, projectSlug)
 	if err != nil {
-		return fmt.Errorf("failed to get project '%s': %w", projectSlug, err)
+		return err
+	}
+	projectSlug = project.Slug
+
+	var targetDir string
+
+	if len(args) > 1 {
+		targetDir = args[1]
+	} else {
+		targetDir = projectSlug
 	}
 	
 	// Check if project has a Git URL
@@ -230,35 +406,10 @@ func runCloneProject(cmd *cobra.Command, args []string) error {
 	
 	// Get flags
 	noClone, _ := cmd.Flags().GetBool("no-clone")
-	force, _ := cmd.Flags().GetBool("force")
 	
-	// Check if directory exists
-	if _, err := os.Stat(targetDir); err == nil && !force {
-		if GetJSONOutput() {
-			fmt.Printf(`{"error":"Directory '%s' already exists. Use --force to override."}%s`, targetDir, "\n")
-			return nil
-		} else {
-			var overwrite bool
-			err := huh.NewConfirm().
-				Title(fmt.Sprintf("Directory '%s' already exists", targetDir)).
-				Description("Do you want to remove it and clone fresh?").
-				Value(&overwrite).
-				Run()
-			
-			if err != nil {
-				return fmt.Errorf("failed to get user confirmation: %w", err)
-			}
-			
-			if !overwrite {
-				fmt.Println("Clone cancelled.")
-				return nil
-			}
-			
-			// Remove existing directory
-			if err := os.RemoveAll(targetDir); err != nil {
-				return fmt.Errorf("failed to remove existing directory: %w", err)
-			}
-		}
+	// Make room for the clone if the directory exists
+	if proceed, err := prepareCloneTarget(cmd, targetDir, project.GitURL); !proceed || err != nil {
+		return err
 	}
 	
 	// Prepare git clone command
@@ -277,8 +428,9 @@ func runCloneProject(cmd *cobra.Command, args []string) error {
 			result["executed"] = false
 		}
 		
-		jsonData, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(jsonData))
+		if err := writeOutputValue(result); err != nil {
+			return err
+		}
 		
 		if noClone {
 			return nil
@@ -298,6 +450,10 @@ func runCloneProject(cmd *cobra.Command, args []string) error {
 	if err := cmd_exec.Run(); err != nil {
 		return fmt.Errorf("failed to clone repository: %w", err)
 	}
+
+	if err := runPostClone(cmd, targetDir, project.Slug); err != nil {
+		return err
+	}
 	
 	if !GetJSONOutput() {
 		fmt.Printf("\n✅ Successfully cloned %s to %s!\n", project.Name, targetDir)
@@ -317,69 +473,112 @@ func printProjectsTable(projects []api.Project, meta *api.PaginationMeta) {
 		return
 	}
 	
-	// Header
-	fmt.Printf("%-40s %-20s %-10s %s\n", "NAME", "SLUG", "TIER", "DESCRIPTION")
-	fmt.Printf("%s\n", strings.Repeat("-", 100))
-	
-	// Projects
+	printProjectsHeader()
 	for _, project := range projects {
-		name := truncateString(project.Name, 38)
-		slug := truncateString(project.Slug, 18)
-		description := truncateString(project.Description, 30)
-		
-		fmt.Printf("%-40s %-20s %-10d %s\n", name, slug, project.Tier, description)
+		printProjectRow(project)
 	}
 	
 	// Pagination info
-	if meta != nil {
-		fmt.Printf("\n📄 Page %d of %d (%d total projects)\n", meta.Page, meta.TotalPages, meta.TotalCount)
-		if meta.Page < meta.TotalPages {
-			fmt.Printf("   Use --page %d to see the next page\n", meta.Page+1)
-		}
+	if meta != nil && meta.TotalCount > 0 {
+		fmt.Printf("\n📊 Showing %d of %d projects\n", len(projects), meta.TotalCount)
 	}
 }
 
-func printUserProjectsTable(projectUsers []api.ProjectUser, meta *api.PaginationMeta) {
+// printProjectsHeader prints the column headers of the project table
+func printProjectsHeader() {
+	fmt.Printf("%-40s %-20s %-10s %s\n", "NAME", "SLUG", "TIER", "DESCRIPTION")
+	fmt.Printf("%s\n", strings.Repeat("-", 100))
+}
+
+// printProjectRow prints one row of the project table
+func printProjectRow(project api.Project) {
+	name := truncateString(project.Name, 38)
+	slug := truncateString(project.Slug, 18)
+	description := truncateString(project.Description, 30)
+
+	fmt.Printf("%-40s %-20s %-10d %s\n", name, slug, project.Tier, description)
+}
+
+// browseProjects opens the interactive browser on a list of projects
+func browseProjects(client *api.Client, projects []api.Project) error {
+	rows := make([][]string, len(projects))
+	for i, p := range projects {
+		rows[i] = []string{p.Name, p.Slug, strconv.Itoa(p.Tier), p.Description}
+	}
+	return runBrowser(browseTable{
+		Title:   "📚 Projects",
+		Columns: []string{"NAME", "SLUG", "TIER", "DESCRIPTION"},
+		Rows:    rows,
+		Show:    func(row int) error { return showProjectDetails(client, projects[row].Slug) },
+		URL:     func(row int) string { return fmt.Sprintf(projectPageURLFormat, projects[row].Slug) },
+	})
+}
+
+func printUserProjectsTable(projectUsers []api.ProjectUser) {
 	if len(projectUsers) == 0 {
 		fmt.Println("No projects found.")
 		return
 	}
 	
-	// Header
+	printUserProjectsHeader()
+	for _, pu := range projectUsers {
+		printUserProjectRow(pu)
+	}
+	
+	fmt.Printf("\n📊 %d projects shown\n", len(projectUsers))
+}
+
+// printUserProjectsHeader prints the column headers of the --mine table
+func printUserProjectsHeader() {
 	fmt.Printf("%-30s %-15s %-10s %-15s %s\n", "PROJECT", "STATUS", "MARK", "VALIDATED", "MARKED AT")
 	fmt.Printf("%s\n", strings.Repeat("-", 100))
-	
-	// Projects
-	for _, pu := range projectUsers {
-		name := truncateString(pu.Project.Name, 28)
-		status := truncateString(pu.Status, 13)
-		
-		mark := "N/A"
-		if pu.FinalMark != nil {
-			mark = strconv.Itoa(*pu.FinalMark)
-		}
-		
-		validated := "N/A"
-		if pu.Validated != nil {
-			if *pu.Validated {
-				validated = "✅ Yes"
-			} else {
-				validated = "❌ No"
-			}
-		}
-		
-		markedAt := "N/A"
-		if pu.MarkedAt != nil {
-			markedAt = pu.MarkedAt.Format("2006-01-02")
+}
+
+// printUserProjectRow prints one row of the --mine table
+func printUserProjectRow(pu api.ProjectUser) {
+	cells := userProjectRowCells(pu)
+	fmt.Printf("%-30s %-15s %-10s %-15s %s\n",
+		truncateString(cells[0], 28), truncateString(cells[1], 13), cells[2], cells[3], cells[4])
+}
+
+// userProjectRowCells returns the cells of a --mine table row, in the
+// order of printUserProjectsHeader
+func userProjectRowCells(pu api.ProjectUser) []string {
+	mark := "N/A"
+	if pu.FinalMark != nil {
+		mark = strconv.Itoa(*pu.FinalMark)
+	}
+
+	validated := "N/A"
+	if pu.Validated != nil {
+		if *pu.Validated {
+			validated = "✅ Yes"
+		} else {
+			validated = "❌ No"
 		}
-		
-		fmt.Printf("%-30s %-15s %-10s %-15s %s\n", name, status, mark, validated, markedAt)
 	}
-	
-	// Pagination info
-	if meta != nil {
-		fmt.Printf("\n📄 Page %d (%d projects shown)\n", meta.Page, len(projectUsers))
+
+	markedAt := "N/A"
+	if pu.MarkedAt != nil {
+		markedAt = pu.MarkedAt.Format("2006-01-02")
 	}
+
+	return []string{pu.Project.Name, pu.Status, mark, validated, markedAt}
+}
+
+// browseUserProjects opens the interactive browser on the --mine list
+func browseUserProjects(client *api.Client, projectUsers []api.ProjectUser) error {
+	rows := make([][]string, len(projectUsers))
+	for i, pu := range projectUsers {
+		rows[i] = userProjectRowCells(pu)
+	}
+	return runBrowser(browseTable{
+		Title:   "📚 My projects",
+		Columns: []string{"PROJECT", "STATUS", "MARK", "VALIDATED", "MARKED AT"},
+		Rows:    rows,
+		Show:    func(row int) error { return showProjectDetails(client, projectUsers[row].Project.Slug) },
+		URL:     func(row int) string { return fmt.Sprintf(projectPageURLFormat, projectUsers[row].Project.Slug) },
+	})
 }
 
 func printProjectDetails(project *api.Project) {
@@ -430,7 +629,7 @@ func printProjectDetails(project *api.Project) {
 }
 
 func runCloneMine(cmd *cobra.Command, args []string) error {
-	projectSlug := args[0]
+	projectSlug := expandProjectSlug(args[0])
 
 	// Create API client with automatic token refresh
 	client, err := NewAPIClient()
@@ -513,35 +712,10 @@ func runCloneMine(cmd *cobra.Command, args []string) error {
 	
 	// Get flags
 	noClone, _ := cmd.Flags().GetBool("no-clone")
-	force, _ := cmd.Flags().GetBool("force")
 	
-	// Check if directory exists
-	if _, err := os.Stat(targetDir); err == nil && !force {
-		if GetJSONOutput() {
-			fmt.Printf(`{"error":"Directory '%s' already exists. Use --force to override."}%s`, targetDir, "\n")
-			return nil
-		} else {
-			var overwrite bool
-			err := huh.NewConfirm().
-				Title(fmt.Sprintf("Directory '%s' already exists", targetDir)).
-				Description("Do you want to remove it and clone fresh?").
-				Value(&overwrite).
-				Run()
-			
-			if err != nil {
-				return fmt.Errorf("failed to get user confirmation: %w", err)
-			}
-			
-			if !overwrite {
-				fmt.Println("Clone cancelled.")
-				return nil
-			}
-			
-			// Remove existing directory
-			if err := os.RemoveAll(targetDir); err != nil {
-				return fmt.Errorf("failed to remove existing directory: %w", err)
-			}
-		}
+	// Make room for the clone if the directory exists
+	if proceed, err := prepareCloneTarget(cmd, targetDir, repoURL); !proceed || err != nil {
+		return err
 	}
 	
 	// Prepare git clone command
@@ -569,8 +743,9 @@ func runCloneMine(cmd *cobra.Command, args []string) error {
 			result["executed"] = false
 		}
 		
-		jsonData, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(jsonData))
+		if err := writeOutputValue(result); err != nil {
+			return err
+		}
 		
 		if noClone {
 			return nil
@@ -602,6 +777,10 @@ func runCloneMine(cmd *cobra.Command, args []string) error {
 	if err := cmd_exec.Run(); err != nil {
 		return fmt.Errorf("failed to clone repository: %w", err)
 	}
+
+	if err := runPostClone(cmd, targetDir, fullProjectUser.Project.Slug); err != nil {
+		return err
+	}
 	
 	if !GetJSONOutput() {
 		fmt.Printf("\n✅ Successfully cloned your %s repository to %s!\n", fullProjectUser.Project.Name, targetDir)
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// builtinProjectAliases are common shorthands for 42cursus project slugs
+var builtinProjectAliases = map[string]string{
+	"gnl":           "get_next_line",
+	"printf":        "ft_printf",
+	"b2br":          "born2beroot",
+	"ps":            "push_swap",
+	"px":            "pipex",
+	"sl":            "so_long",
+	"philo":         "philosophers",
+	"ms":            "minishell",
+	"np":            "netpractice",
+	"cub":           "cub3d",
+	"rt":            "minirt",
+	"irc":           "ft_irc",
+	"ws":            "webserv",
+	"tc":            "ft_transcendence",
+	"transcendence": "ft_transcendence",
+}
+
+// projectAlias is an alias together with where it is defined
+type projectAlias struct {
+	Alias  string `json:"alias"`
+	Slug   string `json:"slug"`
+	Source string `json:"source"` // "config" or "built-in"
+}
+
+// expandProjectSlug replaces a project alias with the slug it stands for.
+// Config aliases take precedence over built-in shorthands; anything that
+// is not an alias is returned unchanged.
+func expandProjectSlug(input string) string {
+	return lookupProjectAlias(input, loadProjectAliases())
+}
+
+// lookupProjectAlias resolves input against the configured aliases, then the built-in ones
+func lookupProjectAlias(input string, configured map[string]string) string {
+	key := strings.ToLower(strings.TrimSpace(input))
+	if slug, ok := configured[key]; ok {
+		return slug
+	}
+	if slug, ok := builtinProjectAliases[key]; ok {
+		return slug
+	}
+	return input
+}
+
+// loadProjectAliases returns the aliases from the config file, if any
+func loadProjectAliases() map[string]string {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil
+	}
+	return cfg.ProjectAliases
+}
+
+// listProjectAliases merges configured and built-in aliases, sorted by alias
+func listProjectAliases(configured map[string]string) []projectAlias {
+	aliases := make([]projectAlias, 0, len(builtinProjectAliases)+len(configured))
+	for alias, slug := range builtinProjectAliases {
+		if _, overridden := configured[alias]; overridden {
+			continue
+		}
+		aliases = append(aliases, projectAlias{Alias: alias, Slug: slug, Source: "built-in"})
+	}
+	for alias, slug := range configured {
+		aliases = append(aliases, projectAlias{Alias: alias, Slug: slug, Source: "config"})
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Alias < aliases[j].Alias
+	})
+	return aliases
+}
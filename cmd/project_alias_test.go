@@ -0,0 +1,46 @@
+package cmd
+
+import "testing"
+
+func TestLookupProjectAlias(t *testing.T) {
+	configured := map[string]string{
+		"gnl":  "get_next_line_v2",
+		"mini": "minishell",
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"configured alias", "mini", "minishell"},
+		{"configured alias overrides built-in", "gnl", "get_next_line_v2"},
+		{"built-in alias", "tc", "ft_transcendence"},
+		{"alias is case insensitive", "TC", "ft_transcendence"},
+		{"plain slug is unchanged", "libft", "libft"},
+		{"unknown input keeps its case", "Libft", "Libft"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lookupProjectAlias(tt.input, configured); got != tt.want {
+				t.Errorf("lookupProjectAlias(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListProjectAliases(t *testing.T) {
+	aliases := listProjectAliases(map[string]string{"gnl": "custom"})
+
+	for i := 1; i < len(aliases); i++ {
+		if aliases[i-1].Alias >= aliases[i].Alias {
+			t.Fatalf("aliases not sorted or duplicated at %d: %q >= %q", i, aliases[i-1].Alias, aliases[i].Alias)
+		}
+	}
+	for _, a := range aliases {
+		if a.Alias == "gnl" && (a.Slug != "custom" || a.Source != "config") {
+			t.Errorf("gnl = %+v, want configured override", a)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// cursusProjects is a user's projects in one cursus, with subtotals
+type cursusProjects struct {
+	CursusID   int               `json:"cursus_id"`
+	Name       string            `json:"name"`
+	Level      *float64          `json:"level,omitempty"`
+	XP         *int              `json:"xp,omitempty"`
+	Validated  int               `json:"validated"`
+	InProgress int               `json:"in_progress"`
+	Failed     int               `json:"failed"`
+	Projects   []api.ProjectUser `json:"projects"`
+}
+
+// cursusProjectsSummary totals the groups of --by-cursus
+type cursusProjectsSummary struct {
+	Projects   int `json:"projects"`
+	Validated  int `json:"validated"`
+	InProgress int `json:"in_progress"`
+	Failed     int `json:"failed"`
+	XP         int `json:"xp"`
+}
+
+// cursusProjectsColumns are the CSV/TSV columns of --by-cursus: one row
+// per cursus with its subtotals
+var cursusProjectsColumns = &output.Columns{
+	Field: "cursus",
+	Columns: []output.Column{
+		{Header: "cursus_id", Query: ".cursus_id"},
+		{Header: "name", Query: ".name"},
+		{Header: "level", Query: ".level"},
+		{Header: "xp", Query: ".xp"},
+		{Header: "validated", Query: ".validated"},
+		{Header: "in_progress", Query: ".in_progress"},
+		{Header: "failed", Query: ".failed"},
+		{Header: "projects", Query: ".projects | length"},
+	},
+}
+
+// listProjectsByCursus prints every project of the user grouped by cursus
+func listProjectsByCursus(ctx context.Context, client *api.Client) error {
+	user, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	var projectUsers []api.ProjectUser
+	err = client.FetchAllUserProjects(ctx, user.ID, &api.ListUserProjectsOptions{}, func(page []api.ProjectUser, _ *api.PaginationMeta) error {
+		projectUsers = append(projectUsers, page...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list user projects: %w", err)
+	}
+
+	groups := groupProjectsByCursus(projectUsers, user.CursusUsers)
+	for i := range groups {
+		if groups[i].Level == nil {
+			continue
+		}
+		levels, err := client.ListCursusLevels(ctx, groups[i].CursusID)
+		if err != nil {
+			if GetVerbose() {
+				fmt.Fprintf(os.Stderr, "No XP for cursus %d: %v\n", groups[i].CursusID, err)
+			}
+			continue
+		}
+		if xp, ok := levelXP(levels, *groups[i].Level); ok {
+			groups[i].XP = &xp
+		}
+	}
+	summary := summarizeCursusProjects(groups)
+
+	if done, err := emitOutputColumns(cursusProjectsColumns,
+		output.Field{Key: "cursus", Value: groups},
+		output.Field{Key: "summary", Value: summary},
+	); done || err != nil {
+		return err
+	}
+	printProjectsByCursus(groups, summary)
+	return nil
+}
+
+// groupProjectsByCursus groups project users by the first of their cursus
+// the user is enrolled in, cursus in the order the user began them.
+// Projects outside of those cursus are grouped last.
+func groupProjectsByCursus(projectUsers []api.ProjectUser, cursusUsers []api.CursusUser) []cursusProjects {
+	enrolled := append([]api.CursusUser(nil), cursusUsers...)
+	sort.SliceStable(enrolled, func(i, j int) bool {
+		return enrolled[i].BeginAt.Before(enrolled[j].BeginAt)
+	})
+
+	groups := make([]cursusProjects, 0, len(enrolled)+1)
+	index := make(map[int]int)
+	for _, cu := range enrolled {
+		if _, ok := index[cu.Cursus.ID]; ok {
+			continue
+		}
+		level := cu.Level
+		index[cu.Cursus.ID] = len(groups)
+		groups = append(groups, cursusProjects{CursusID: cu.Cursus.ID, Name: cu.Cursus.Name, Level: &level})
+	}
+
+	var other cursusProjects
+	other.Name = "Other"
+	for _, pu := range projectUsers {
+		group := &other
+		for _, id := range pu.CursusIds {
+			if i, ok := index[id]; ok {
+				group = &groups[i]
+				break
+			}
+		}
+		group.Projects = append(group.Projects, pu)
+		switch {
+		case pu.Status != "finished":
+			group.InProgress++
+		case pu.Validated != nil && *pu.Validated:
+			group.Validated++
+		default:
+			group.Failed++
+		}
+	}
+
+	result := make([]cursusProjects, 0, len(groups)+1)
+	for _, g := range groups {
+		if len(g.Projects) > 0 {
+			sortCursusProjects(g.Projects)
+			result = append(result, g)
+		}
+	}
+	if len(other.Projects) > 0 {
+		sortCursusProjects(other.Projects)
+		result = append(result, other)
+	}
+	return result
+}
+
+// sortCursusProjects puts the projects in progress first, then the most
+// recently marked ones
+func sortCursusProjects(projectUsers []api.ProjectUser) {
+	sort.SliceStable(projectUsers, func(i, j int) bool {
+		a, b := projectUsers[i], projectUsers[j]
+		if aDone, bDone := a.Status == "finished", b.Status == "finished"; aDone != bDone {
+			return !aDone
+		}
+		switch {
+		case a.MarkedAt == nil || b.MarkedAt == nil:
+			return a.MarkedAt != nil
+		default:
+			return a.MarkedAt.After(*b.MarkedAt)
+		}
+	})
+}
+
+// summarizeCursusProjects totals the groups
+func summarizeCursusProjects(groups []cursusProjects) cursusProjectsSummary {
+	var summary cursusProjectsSummary
+	for _, g := range groups {
+		summary.Projects += len(g.Projects)
+		summary.Validated += g.Validated
+		summary.InProgress += g.InProgress
+		summary.Failed += g.Failed
+		if g.XP != nil {
+			summary.XP += *g.XP
+		}
+	}
+	return summary
+}
+
+func printProjectsByCursus(groups []cursusProjects, summary cursusProjectsSummary) {
+	if len(groups) == 0 {
+		fmt.Println("No projects found.")
+		return
+	}
+
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		title := fmt.Sprintf("🎓 %s", g.Name)
+		if g.Level != nil {
+			title += fmt.Sprintf(" (level %.2f)", *g.Level)
+		}
+		fmt.Println(title)
+		printUserProjectsHeader()
+		for _, pu := range g.Projects {
+			printUserProjectRow(pu)
+		}
+
+		subtotal := []string{
+			fmt.Sprintf("%d validated", g.Validated),
+			fmt.Sprintf("%d in progress", g.InProgress),
+		}
+		if g.Failed > 0 {
+			subtotal = append(subtotal, fmt.Sprintf("%d failed", g.Failed))
+		}
+		if g.XP != nil {
+			subtotal = append(subtotal, fmt.Sprintf("%d XP", *g.XP))
+		}
+		fmt.Printf("   Subtotal: %s\n", strings.Join(subtotal, ", "))
+	}
+
+	fmt.Printf("\n📊 %d projects in %d cursus: %d validated, %d in progress, %d failed, %d XP\n",
+		summary.Projects, len(groups), summary.Validated, summary.InProgress, summary.Failed, summary.XP)
+}
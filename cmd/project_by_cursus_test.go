@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestGroupProjectsByCursus(t *testing.T) {
+	yes, no := true, false
+	day := func(d int) *time.Time {
+		t := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	cursusUsers := []api.CursusUser{
+		{Level: 7.5, BeginAt: *day(20), Cursus: api.Cursus{ID: 21, Name: "42cursus"}},
+		{Level: 9.1, BeginAt: *day(1), Cursus: api.Cursus{ID: 9, Name: "C Piscine"}},
+	}
+	projectUsers := []api.ProjectUser{
+		{Status: "finished", Validated: &yes, MarkedAt: day(25), CursusIds: []int{21}, Project: api.Project{Slug: "libft"}},
+		{Status: "in_progress", CursusIds: []int{21}, Project: api.Project{Slug: "minishell"}},
+		{Status: "finished", Validated: &no, MarkedAt: day(5), CursusIds: []int{9}, Project: api.Project{Slug: "c-piscine-rush-00"}},
+		{Status: "finished", Validated: &yes, MarkedAt: day(28), CursusIds: []int{21}, Project: api.Project{Slug: "ft_printf"}},
+		{Status: "finished", Validated: &yes, MarkedAt: day(3), CursusIds: []int{3}, Project: api.Project{Slug: "discovery"}},
+	}
+
+	groups := groupProjectsByCursus(projectUsers, cursusUsers)
+	want := []struct {
+		name                          string
+		slugs                         []string
+		validated, inProgress, failed int
+	}{
+		{name: "C Piscine", slugs: []string{"c-piscine-rush-00"}, failed: 1},
+		{name: "42cursus", slugs: []string{"minishell", "ft_printf", "libft"}, validated: 2, inProgress: 1},
+		{name: "Other", slugs: []string{"discovery"}, validated: 1},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d: %+v", len(groups), len(want), groups)
+	}
+	for i, w := range want {
+		g := groups[i]
+		var slugs []string
+		for _, pu := range g.Projects {
+			slugs = append(slugs, pu.Project.Slug)
+		}
+		if g.Name != w.name || strings.Join(slugs, ",") != strings.Join(w.slugs, ",") {
+			t.Errorf("group %d = %s %v, want %s %v", i, g.Name, slugs, w.name, w.slugs)
+		}
+		if g.Validated != w.validated || g.InProgress != w.inProgress || g.Failed != w.failed {
+			t.Errorf("group %s subtotals = %d/%d/%d, want %d/%d/%d", g.Name,
+				g.Validated, g.InProgress, g.Failed, w.validated, w.inProgress, w.failed)
+		}
+	}
+
+	xp := 1200
+	groups[1].XP = &xp
+	summary := summarizeCursusProjects(groups)
+	if want := (cursusProjectsSummary{Projects: 5, Validated: 3, InProgress: 1, Failed: 1, XP: 1200}); summary != want {
+		t.Errorf("summarizeCursusProjects() = %+v, want %+v", summary, want)
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// staleRegistrationStatuses are the statuses of registrations that never
+// got past forming a group
+var staleRegistrationStatuses = map[string]bool{
+	"searching_a_group": true,
+	"creating_group":    true,
+}
+
+var projectCleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Unregister from projects stuck looking for a group",
+	Long: `List your project registrations still searching for or creating a group
+after --days days, and unregister from the ones you pick. Unregistering
+keeps your profile tidy and frees your spot in the project.
+
+In a terminal every stale registration is pre-selected in a multi-select
+list, followed by a confirmation. Use --yes to unregister from all of them
+without asking, or --dry-run to only list them.
+
+Examples:
+  t42 project cleanup
+  t42 project cleanup --days 30 --dry-run
+  t42 project cleanup --yes`,
+	Args: cobra.NoArgs,
+	RunE: runProjectCleanup,
+}
+
+func init() {
+	projectCmd.AddCommand(projectCleanupCmd)
+
+	projectCleanupCmd.Flags().Int("days", 14, "Only registrations older than this many days")
+	projectCleanupCmd.Flags().Bool("dry-run", false, "Only list the stale registrations")
+	projectCleanupCmd.Flags().BoolP("yes", "y", false, "Unregister from every stale registration without asking")
+	projectCleanupCmd.MarkFlagsMutuallyExclusive("dry-run", "yes")
+}
+
+// staleRegistration is a registration offered for cleanup
+type staleRegistration struct {
+	ID        int       `json:"id"`
+	Project   string    `json:"project"`
+	Slug      string    `json:"slug"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func runProjectCleanup(cmd *cobra.Command, args []string) error {
+	days, _ := cmd.Flags().GetInt("days")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+	if days < 0 {
+		return fmt.Errorf("--days must not be negative")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+
+	var registrations []api.ProjectUser
+	err = client.FetchAllUserProjects(ctx, me.ID, nil, func(page []api.ProjectUser, _ *api.PaginationMeta) error {
+		registrations = append(registrations, page...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list your projects: %w", err)
+	}
+	stale := staleRegistrations(registrations, time.Now().AddDate(0, 0, -days))
+
+	interactive := !yes && !dryRun && !GetJSONOutput() && isTerminal(os.Stdin)
+	if len(stale) == 0 || (!yes && !interactive) {
+		if GetJSONOutput() {
+			return writeOutput(output.Field{Key: "stale", Value: stale})
+		}
+		printStaleRegistrations(stale, days)
+		if len(stale) > 0 && !dryRun {
+			fmt.Println("\nRun in a terminal to pick registrations, or pass --yes to unregister from all of them.")
+		}
+		return nil
+	}
+
+	selected := stale
+	if interactive {
+		if selected, err = pickStaleRegistrations(stale); err != nil || len(selected) == 0 {
+			if err == nil {
+				fmt.Println("Nothing unregistered.")
+			}
+			return err
+		}
+	}
+
+	var removed []staleRegistration
+	var failures []string
+	for _, r := range selected {
+		if err := client.DeleteProjectUser(ctx, r.ID); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", r.Project, err))
+			continue
+		}
+		removed = append(removed, r)
+	}
+	if len(removed) > 0 {
+		invalidateOwnData()
+	}
+
+	if GetJSONOutput() {
+		if err := writeOutput(
+			output.Field{Key: "unregistered", Value: removed},
+			output.Field{Key: "errors", Value: failures},
+		); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range removed {
+			fmt.Printf("🗑️  Unregistered from %s\n", r.Project)
+		}
+		for _, f := range failures {
+			fmt.Printf("❌ %s\n", f)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to unregister from %d of %d projects", len(failures), len(selected))
+	}
+	return nil
+}
+
+// staleRegistrations returns the registrations created before cutoff that
+// are still searching for or creating a group, oldest first
+func staleRegistrations(registrations []api.ProjectUser, cutoff time.Time) []staleRegistration {
+	var stale []staleRegistration
+	for _, pu := range registrations {
+		if !staleRegistrationStatuses[pu.Status] || !pu.CreatedAt.Before(cutoff) {
+			continue
+		}
+		stale = append(stale, staleRegistration{
+			ID:        pu.ID,
+			Project:   pu.Project.Name,
+			Slug:      pu.Project.Slug,
+			Status:    pu.Status,
+			CreatedAt: pu.CreatedAt,
+		})
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].CreatedAt.Before(stale[j].CreatedAt)
+	})
+	return stale
+}
+
+// pickStaleRegistrations lets the user choose the registrations to remove,
+// all pre-selected, and confirm
+func pickStaleRegistrations(stale []staleRegistration) ([]staleRegistration, error) {
+	labels := make([]string, len(stale))
+	for i, r := range stale {
+		labels[i] = fmt.Sprintf("%s (%s since %s)", r.Project, strings.ReplaceAll(r.Status, "_", " "), r.CreatedAt.Local().Format("2006-01-02"))
+	}
+
+	chosen, err := multiSelectPrompt("Unregister from", labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get selection: %w", err)
+	}
+	if len(chosen) == 0 {
+		return nil, nil
+	}
+
+	confirmed, err := confirmPrompt(
+		fmt.Sprintf("Unregister from %d project(s)?", len(chosen)),
+		"Your registration and any group you formed are removed.")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user confirmation: %w", err)
+	}
+	if !confirmed {
+		return nil, nil
+	}
+
+	selected := make([]staleRegistration, len(chosen))
+	for i, index := range chosen {
+		selected[i] = stale[index]
+	}
+	return selected, nil
+}
+
+func printStaleRegistrations(stale []staleRegistration, days int) {
+	if len(stale) == 0 {
+		fmt.Printf("✨ No registrations waiting for a group for more than %d days.\n", days)
+		return
+	}
+
+	fmt.Printf("🧹 Registrations waiting for a group for more than %d days\n\n", days)
+	fmt.Printf("%-30s %-20s %s\n", "PROJECT", "STATUS", "SINCE")
+	fmt.Println(strings.Repeat("-", 65))
+	now := time.Now()
+	for _, r := range stale {
+		fmt.Printf("%-30s %-20s %s (%s ago)\n",
+			truncateString(r.Project, 30), r.Status, r.CreatedAt.Local().Format("2006-01-02"), formatAge(now.Sub(r.CreatedAt)))
+	}
+}
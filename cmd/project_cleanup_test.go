@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestStaleRegistrations(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	registration := func(id int, status string, created time.Time) api.ProjectUser {
+		return api.ProjectUser{ID: id, Status: status, CreatedAt: created, Project: api.Project{Name: "p"}}
+	}
+
+	registrations := []api.ProjectUser{
+		registration(1, "searching_a_group", daysAgo(20)),
+		registration(2, "creating_group", daysAgo(40)),
+		registration(3, "searching_a_group", daysAgo(3)), // too recent
+		registration(4, "in_progress", daysAgo(60)),      // has a group
+		registration(5, "finished", daysAgo(90)),
+	}
+
+	tests := []struct {
+		name string
+		days int
+		want []int
+	}{
+		{"older than two weeks", 14, []int{2, 1}},
+		{"older than a month", 30, []int{2}},
+		{"any age", 0, []int{2, 1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := staleRegistrations(registrations, now.AddDate(0, 0, -tt.days))
+			if len(got) != len(tt.want) {
+				t.Fatalf("staleRegistrations() = %+v, want IDs %v", got, tt.want)
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("staleRegistrations()[%d].ID = %d, want %d (oldest first)", i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// trashDirName is the subdirectory of the state directory that receives the
+// directories replaced by a fresh clone
+const trashDirName = "trash"
+
+// addCloneGuardFlags adds the flags that control how an existing clone
+// directory is replaced
+func addCloneGuardFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("force", false, "Replace the directory if it exists (moved to the trash)")
+	cmd.Flags().Bool("force-dirty", false, "Also replace a clone with uncommitted changes")
+}
+
+// prepareCloneTarget makes room for a clone of remote in dir. An existing
+// directory is only replaced after --force or a confirmation, and only if it
+// is empty or a clone of remote without uncommitted changes (unless
+// --force-dirty); it is moved to the trash rather than deleted. It reports
+// false when the clone should not go ahead.
+func prepareCloneTarget(cmd *cobra.Command, dir, remote string) (bool, error) {
+	noClone, _ := cmd.Flags().GetBool("no-clone")
+	force, _ := cmd.Flags().GetBool("force")
+	forceDirty, _ := cmd.Flags().GetBool("force-dirty")
+
+	if _, err := os.Stat(dir); err != nil {
+		return true, nil
+	}
+
+	if !force {
+		if GetJSONOutput() {
+			fmt.Printf(`{"error":"Directory '%s' already exists. Use --force to override."}%s`, dir, "\n")
+			return false, nil
+		}
+		overwrite, err := confirmPrompt(
+			fmt.Sprintf("Directory '%s' already exists", dir),
+			"Do you want to move it to the trash and clone fresh?")
+		if err != nil {
+			return false, fmt.Errorf("failed to get user confirmation: %w", err)
+		}
+		if !overwrite {
+			fmt.Println("Clone cancelled.")
+			return false, nil
+		}
+	}
+	if noClone {
+		// Nothing is cloned, so nothing is replaced
+		return true, nil
+	}
+
+	if err := checkCloneTarget(dir, remote, forceDirty); err != nil {
+		return false, err
+	}
+	trashDir, err := cloneTrashDir()
+	if err != nil {
+		return false, err
+	}
+	moved, err := moveToTrash(dir, trashDir, time.Now())
+	if err != nil {
+		return false, err
+	}
+	if moved != "" {
+		fmt.Fprintf(os.Stderr, "🗑️  Moved the existing '%s' to %s\n", dir, moved)
+	}
+	return true, nil
+}
+
+// checkCloneTarget reports why dir may not be replaced by a clone of remote:
+// it must be empty, or the top of a git repository whose origin is remote,
+// without uncommitted changes unless forceDirty is set
+func checkCloneTarget(dir, remote string, forceDirty bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory '%s': %w", dir, err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	top, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil || !samePath(strings.TrimSpace(string(top)), dir) {
+		return fmt.Errorf("'%s' is not a git repository; refusing to replace it", dir)
+	}
+
+	origin, err := exec.Command("git", "-C", dir, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return fmt.Errorf("'%s' has no origin remote; refusing to replace it", dir)
+	}
+	if normalizeRepoURL(string(origin)) != normalizeRepoURL(remote) {
+		return fmt.Errorf("'%s' is a clone of %s, not %s; refusing to replace it",
+			dir, strings.TrimSpace(string(origin)), remote)
+	}
+
+	if forceDirty {
+		return nil
+	}
+	status, err := exec.Command("git", "-C", dir, "status", "--porcelain").Output()
+	if err != nil {
+		return fmt.Errorf("failed to check '%s' for uncommitted changes: %w", dir, err)
+	}
+	if len(strings.TrimSpace(string(status))) > 0 {
+		return fmt.Errorf("'%s' has uncommitted changes; commit them or use --force-dirty", dir)
+	}
+	return nil
+}
+
+// samePath reports whether a and b are the same directory
+func samePath(a, b string) bool {
+	resolve := func(p string) string {
+		if abs, err := filepath.Abs(p); err == nil {
+			p = abs
+		}
+		if real, err := filepath.EvalSymlinks(p); err == nil {
+			p = real
+		}
+		return filepath.Clean(p)
+	}
+	return resolve(a) == resolve(b)
+}
+
+// cloneTrashDir returns the directory replaced clones are moved to
+func cloneTrashDir() (string, error) {
+	stateDir, err := config.GetStateDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get state directory: %w", err)
+	}
+	return filepath.Join(stateDir, trashDirName), nil
+}
+
+// moveToTrash moves dir into trashDir under a timestamped name and returns
+// its new path. An empty dir is simply removed, and "" returned.
+func moveToTrash(dir, trashDir string, now time.Time) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read directory '%s': %w", dir, err)
+	}
+	if len(entries) == 0 {
+		if err := os.Remove(dir); err != nil {
+			return "", fmt.Errorf("failed to remove existing directory: %w", err)
+		}
+		return "", nil
+	}
+
+	if err := os.MkdirAll(trashDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve directory '%s': %w", dir, err)
+	}
+	dest := filepath.Join(trashDir, now.Format("20060102-150405")+"-"+filepath.Base(abs))
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(dest); errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		dest = filepath.Join(trashDir, fmt.Sprintf("%s-%s-%d", now.Format("20060102-150405"), filepath.Base(abs), n))
+	}
+
+	if err := os.Rename(abs, dest); err == nil {
+		return dest, nil
+	}
+	// The trash may be on another file system: copy, then remove
+	if err := copyTree(abs, dest); err != nil {
+		_ = os.RemoveAll(dest)
+		return "", fmt.Errorf("failed to move '%s' to the trash: %w", dir, err)
+	}
+	if err := os.RemoveAll(abs); err != nil {
+		return "", fmt.Errorf("failed to remove existing directory after copying it to the trash: %w", err)
+	}
+	return dest, nil
+}
+
+// copyTree copies the directory src to dst, keeping modes and symlinks
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		// Sockets, pipes and devices are not worth keeping
+		return nil
+	})
+}
+
+// copyFile copies the regular file src to dst with mode perm
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const guardRemote = "git@vogsphere.42tokyo.jp:vogsphere/intra-uuid-abc.git"
+
+// initClone makes dir a git repository with origin set to remote and one
+// commit, like a fresh clone
+func initClone(t *testing.T, dir, remote string) {
+	t.Helper()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"remote", "add", "origin", remote},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+}
+
+func TestCheckCloneTarget(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("needs git")
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T, dir string)
+		sub        string // checks this subdirectory of dir instead
+		forceDirty bool
+		wantErr    string
+	}{
+		{name: "empty directory", setup: func(t *testing.T, dir string) {}},
+		{name: "clean clone of the remote", setup: func(t *testing.T, dir string) {
+			initClone(t, dir, guardRemote)
+		}},
+		{name: "same remote over https", setup: func(t *testing.T, dir string) {
+			initClone(t, dir, "https://vogsphere.42tokyo.jp/vogsphere/intra-uuid-abc")
+		}},
+		{name: "not a git repository", wantErr: "not a git repository", setup: func(t *testing.T, dir string) {
+			writeTestFile(t, filepath.Join(dir, "notes.txt"))
+		}},
+		{name: "subdirectory of a repository", sub: "src", wantErr: "not a git repository", setup: func(t *testing.T, dir string) {
+			initClone(t, dir, guardRemote)
+			writeTestFile(t, filepath.Join(dir, "src", "main.c"))
+		}},
+		{name: "other remote", wantErr: "refusing to replace it", setup: func(t *testing.T, dir string) {
+			initClone(t, dir, "git@github.com:someone/dotfiles.git")
+		}},
+		{name: "uncommitted changes", wantErr: "--force-dirty", setup: func(t *testing.T, dir string) {
+			initClone(t, dir, guardRemote)
+			writeTestFile(t, filepath.Join(dir, "main.c"))
+		}},
+		{name: "uncommitted changes with --force-dirty", forceDirty: true, setup: func(t *testing.T, dir string) {
+			initClone(t, dir, guardRemote)
+			writeTestFile(t, filepath.Join(dir, "main.c"))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.setup(t, dir)
+			err := checkCloneTarget(filepath.Join(dir, tt.sub), guardRemote, tt.forceDirty)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkCloneTarget() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkCloneTarget() error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMoveToTrash(t *testing.T) {
+	root := t.TempDir()
+	trash := filepath.Join(root, "state", "trash")
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	t.Run("empty directory is removed", func(t *testing.T) {
+		dir := filepath.Join(root, "empty")
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		moved, err := moveToTrash(dir, trash, now)
+		if err != nil || moved != "" {
+			t.Fatalf("moveToTrash() = %q, %v, want it removed", moved, err)
+		}
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("empty directory still exists: %v", err)
+		}
+	})
+
+	for i, want := range []string{"20260304-050607-libft", "20260304-050607-libft-2"} {
+		dir := filepath.Join(root, "libft")
+		writeTestFile(t, filepath.Join(dir, "src", "ft_strlen.c"))
+
+		moved, err := moveToTrash(dir, trash, now)
+		if err != nil {
+			t.Fatalf("moveToTrash() #%d error = %v", i+1, err)
+		}
+		if moved != filepath.Join(trash, want) {
+			t.Errorf("moveToTrash() #%d = %q, want %q", i+1, moved, filepath.Join(trash, want))
+		}
+		if _, err := os.Stat(filepath.Join(moved, "src", "ft_strlen.c")); err != nil {
+			t.Errorf("content not in the trash: %v", err)
+		}
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("directory still exists after the move: %v", err)
+		}
+	}
+}
+
+func TestCopyTree(t *testing.T) {
+	src := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "a", "b.txt"))
+	if err := os.Symlink("a/b.txt", filepath.Join(src, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "copy")
+	if err := copyTree(src, dst); err != nil {
+		t.Fatalf("copyTree() error = %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dst, "a", "b.txt")); err != nil || string(data) != "content" {
+		t.Errorf("copied file = %q, %v", data, err)
+	}
+	if link, err := os.Readlink(filepath.Join(dst, "link")); err != nil || link != "a/b.txt" {
+		t.Errorf("copied symlink = %q, %v", link, err)
+	}
+}
+
+// writeTestFile creates path and its parents with some content
+func writeTestFile(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// defaultEditor is opened by --open-editor when $VISUAL is not set
+const defaultEditor = "code"
+
+// addPostCloneFlags adds the flags that control what happens after a clone
+func addPostCloneFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("open-editor", false, "Open the clone in $VISUAL (default: code)")
+	cmd.Flags().Bool("no-hook", false, "Skip the post_clone_hook from the config file")
+}
+
+// runPostClone runs the configured post-clone hook in the clone, then opens
+// the editor when --open-editor is set
+func runPostClone(cmd *cobra.Command, dir, slug string) error {
+	openEditor, _ := cmd.Flags().GetBool("open-editor")
+	noHook, _ := cmd.Flags().GetBool("no-hook")
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve clone directory: %w", err)
+	}
+
+	// Keep --json output parseable: everything the hook and editor print goes to stderr
+	stdout := os.Stdout
+	if GetJSONOutput() {
+		stdout = os.Stderr
+	}
+
+	if !noHook {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if cfg.PostCloneHook != "" {
+			if !GetJSONOutput() {
+				fmt.Printf("🪝 Running post-clone hook: %s\n", cfg.PostCloneHook)
+			}
+			name, args := postCloneHookCommand(runtime.GOOS, cfg.PostCloneHook, absDir, slug)
+			hook := exec.Command(name, args...)
+			hook.Dir = absDir
+			hook.Env = append(os.Environ(), "T42_CLONE_PATH="+absDir, "T42_PROJECT_SLUG="+slug)
+			hook.Stdin, hook.Stdout, hook.Stderr = os.Stdin, stdout, os.Stderr
+			if err := hook.Run(); err != nil {
+				return fmt.Errorf("post-clone hook failed: %w", err)
+			}
+		}
+	}
+
+	if openEditor {
+		editor, err := editorCommand(os.Getenv("VISUAL"))
+		if err != nil {
+			return err
+		}
+		if !GetJSONOutput() {
+			fmt.Printf("📝 Opening %s in %s\n", dir, editor[0])
+		}
+		open := exec.Command(editor[0], append(editor[1:], ".")...)
+		open.Dir = absDir
+		open.Stdin, open.Stdout, open.Stderr = os.Stdin, stdout, os.Stderr
+		if err := open.Run(); err != nil {
+			return fmt.Errorf("failed to open editor %s: %w", editor[0], err)
+		}
+	}
+	return nil
+}
+
+// postCloneHookCommand builds the shell invocation of a hook. On Unix the
+// clone path and slug are also passed as $1 and $2.
+func postCloneHookCommand(goos, hook, dir, slug string) (string, []string) {
+	if goos == "windows" {
+		return "cmd", []string{"/C", hook}
+	}
+	return "sh", []string{"-c", hook, "t42-post-clone", dir, slug}
+}
+
+// editorCommand splits $VISUAL into a command and its arguments, falling
+// back to the default editor
+func editorCommand(visual string) ([]string, error) {
+	if visual == "" {
+		return []string{defaultEditor}, nil
+	}
+	args, err := splitCommandLine(visual)
+	if err != nil {
+		return nil, fmt.Errorf("invalid $VISUAL: %w", err)
+	}
+	if len(args) == 0 {
+		return []string{defaultEditor}, nil
+	}
+	return args, nil
+}
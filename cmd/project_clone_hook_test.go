@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPostCloneHookCommand(t *testing.T) {
+	name, args := postCloneHookCommand("windows", "make setup", `C:\libft`, "libft")
+	if name != "cmd" || strings.Join(args, " ") != "/C make setup" {
+		t.Errorf("windows hook = %s %v", name, args)
+	}
+
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	name, args = postCloneHookCommand("linux", `echo "$1:$2"`, "/tmp/libft", "libft")
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		t.Fatalf("hook failed: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "/tmp/libft:libft" {
+		t.Errorf("hook printed %q, want the path and slug", got)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		visual  string
+		want    string
+		wantErr bool
+	}{
+		{"", "code", false},
+		{"   ", "code", false},
+		{"vim", "vim", false},
+		{"code --wait", "code --wait", false},
+		{`"/Applications/Sublime Text/subl" -n`, "/Applications/Sublime Text/subl -n", false},
+		{`"unterminated`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.visual, func(t *testing.T) {
+			got, err := editorCommand(tt.visual)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("editorCommand(%q) error = %v, wantErr %v", tt.visual, err, tt.wantErr)
+			}
+			if !tt.wantErr && strings.Join(got, " ") != tt.want {
+				t.Errorf("editorCommand(%q) = %q, want %q", tt.visual, got, tt.want)
+			}
+		})
+	}
+}
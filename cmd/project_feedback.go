@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// feedbackCommentWidth is the width corrector comments are wrapped to
+const feedbackCommentWidth = 76
+
+var projectFeedbackCmd = &cobra.Command{
+	Use:   "feedback <slug>",
+	Short: "Show the evaluation sheets and your past evaluations of a project",
+	Long: `Show the evaluation sheets (scales) of a project at your campus, and
+every evaluation of your teams on that project with the corrector's
+comment, the mark and flag given, and the feedback you left in return.
+
+Unlike 'project feedbacks', which summarizes the evaluations of everyone,
+this only shows your own.
+
+Examples:
+  t42 project feedback libft
+  t42 project feedback minishell --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectFeedback,
+}
+
+func init() {
+	projectCmd.AddCommand(projectFeedbackCmd)
+}
+
+func runProjectFeedback(cmd *cobra.Command, args []string) error {
+	slug := expandProjectSlug(args[0])
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	project, err := client.GetProjectBySlug(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("failed to find project %q: %w", slug, err)
+	}
+	detail, err := client.GetProject(ctx, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get project detail: %w", err)
+	}
+
+	evaluations, err := fetchOwnProjectEvaluations(ctx, client, me.ID, project.ID)
+	if err != nil {
+		return err
+	}
+
+	var campusID, cursusID int
+	if campus := primaryCampus(me); campus != nil {
+		campusID = campus.ID
+	}
+	if cursusUser := findCursusUser(me.CursusUsers, 0); cursusUser != nil {
+		cursusID = cursusUser.Cursus.ID
+	}
+	scales := projectScales(findProjectSession(detail.ProjectSessions, campusID, cursusID), evaluations)
+
+	if done, err := emitOutput(
+		output.Field{Key: "project", Value: project},
+		output.Field{Key: "scales", Value: scales},
+		output.Field{Key: "evaluations", Value: evaluations},
+	); done || err != nil {
+		return err
+	}
+	printProjectFeedback(project.Name, scales, evaluations)
+	return nil
+}
+
+// fetchOwnProjectEvaluations returns the evaluations of the user's teams on
+// a project, most recent first
+func fetchOwnProjectEvaluations(ctx context.Context, client *api.Client, userID, projectID int) ([]api.ScaleTeam, error) {
+	scaleTeams, err := fetchAllUserScaleTeams(ctx, client, userID, api.ScaleTeamAsCorrected, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list your evaluations: %w", err)
+	}
+	return filterProjectScaleTeams(scaleTeams, projectID), nil
+}
+
+// filterProjectScaleTeams keeps the evaluations of teams on a project
+func filterProjectScaleTeams(scaleTeams []api.ScaleTeam, projectID int) []api.ScaleTeam {
+	filtered := make([]api.ScaleTeam, 0)
+	for _, st := range scaleTeams {
+		if st.Team.ProjectID == projectID {
+			filtered = append(filtered, st)
+		}
+	}
+	return filtered
+}
+
+// projectScales returns the scales of a session followed by the older ones
+// the evaluations were graded with, each once
+func projectScales(session *api.ProjectSession, evaluations []api.ScaleTeam) []api.Scale {
+	scales := make([]api.Scale, 0)
+	seen := make(map[int]bool)
+	add := func(scale api.Scale) {
+		if scale.ID == 0 || seen[scale.ID] {
+			return
+		}
+		seen[scale.ID] = true
+		scales = append(scales, scale)
+	}
+	if session != nil {
+		for _, scale := range session.Scales {
+			add(scale)
+		}
+	}
+	for _, st := range evaluations {
+		add(st.Scale)
+	}
+	return scales
+}
+
+// describeScale formats a scale's name with its correction count and
+// duration
+func describeScale(scale api.Scale) string {
+	var details []string
+	if scale.CorrectionNumber > 0 {
+		details = append(details, fmt.Sprintf("%d evaluations", scale.CorrectionNumber))
+	}
+	if scale.Duration > 0 {
+		details = append(details, fmt.Sprintf("%d min", scale.Duration/60))
+	}
+	if scale.IsIntroduction {
+		details = append(details, "introduction")
+	}
+	name := scale.Name
+	if name == "" {
+		name = fmt.Sprintf("Scale #%d", scale.ID)
+	}
+	if len(details) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, strings.Join(details, ", "))
+}
+
+// indentText indents every line of text
+func indentText(text, prefix string) string {
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
+
+func printProjectFeedback(projectName string, scales []api.Scale, evaluations []api.ScaleTeam) {
+	fmt.Printf("📋 Evaluation sheets for %s\n", projectName)
+	if len(scales) == 0 {
+		fmt.Println("   No evaluation sheet found.")
+	}
+	for _, scale := range scales {
+		fmt.Printf("   • %s\n", describeScale(scale))
+	}
+
+	fmt.Printf("\n💬 Your evaluations (%d)\n", len(evaluations))
+	if len(evaluations) == 0 {
+		fmt.Println("   No evaluation yet.")
+		return
+	}
+	for _, st := range evaluations {
+		corrector := "hidden"
+		if st.Corrector != nil {
+			corrector = st.Corrector.Login
+		}
+		line := fmt.Sprintf("\n%s  by %s", st.BeginAt.Local().Format("2006-01-02 15:04"), corrector)
+		if st.FinalMark != nil {
+			line += fmt.Sprintf("  mark %d", *st.FinalMark)
+		}
+		if st.Flag != nil && st.Flag.Name != "" {
+			icon := "✅"
+			if !st.Flag.Positive {
+				icon = "🚩"
+			}
+			line += fmt.Sprintf("  %s %s", icon, st.Flag.Name)
+		}
+		if st.FilledAt == nil {
+			line += "  (not filled yet)"
+		}
+		fmt.Println(line)
+
+		if comment := strings.TrimSpace(st.Comment); comment != "" {
+			fmt.Println("   Corrector:")
+			fmt.Println(indentText(wrapText(comment, feedbackCommentWidth), "     "))
+		}
+		if feedback := strings.TrimSpace(st.Feedback); feedback != "" {
+			title := "   Your feedback"
+			if st.FeedbackRating != nil {
+				title += fmt.Sprintf(" (%d/5)", *st.FeedbackRating)
+			}
+			fmt.Println(title + ":")
+			fmt.Println(indentText(wrapText(feedback, feedbackCommentWidth), "     "))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestFilterProjectScaleTeams(t *testing.T) {
+	scaleTeams := []api.ScaleTeam{
+		{ID: 1, Team: api.Team{ProjectID: 1314}},
+		{ID: 2, Team: api.Team{ProjectID: 1316}},
+		{ID: 3, Team: api.Team{ProjectID: 1314}},
+	}
+
+	got := filterProjectScaleTeams(scaleTeams, 1314)
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Errorf("filterProjectScaleTeams() = %+v, want evaluations 1 and 3", got)
+	}
+	if got := filterProjectScaleTeams(scaleTeams, 42); got == nil || len(got) != 0 {
+		t.Errorf("filterProjectScaleTeams() = %#v, want an empty slice", got)
+	}
+}
+
+func TestProjectScales(t *testing.T) {
+	session := &api.ProjectSession{Scales: []api.Scale{{ID: 12, Name: "current"}, {ID: 10, Name: "shared"}}}
+	evaluations := []api.ScaleTeam{
+		{Scale: api.Scale{ID: 10, Name: "shared"}},
+		{Scale: api.Scale{ID: 7, Name: "old"}},
+		{Scale: api.Scale{ID: 7, Name: "old"}},
+		{},
+	}
+
+	tests := []struct {
+		name    string
+		session *api.ProjectSession
+		want    []int
+	}{
+		{"session first", session, []int{12, 10, 7}},
+		{"no session", nil, []int{10, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := projectScales(tt.session, evaluations)
+			if len(got) != len(tt.want) {
+				t.Fatalf("projectScales() = %+v, want ids %v", got, tt.want)
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("projectScales()[%d].ID = %d, want %d", i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribeScale(t *testing.T) {
+	tests := []struct {
+		name  string
+		scale api.Scale
+		want  string
+	}{
+		{"full", api.Scale{Name: "Libft", CorrectionNumber: 3, Duration: 900}, "Libft (3 evaluations, 15 min)"},
+		{"introduction", api.Scale{Name: "Intro", IsIntroduction: true}, "Intro (introduction)"},
+		{"no name", api.Scale{ID: 5}, "Scale #5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeScale(tt.scale); got != tt.want {
+				t.Errorf("describeScale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
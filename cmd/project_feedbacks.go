@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+var projectFeedbacksCmd = &cobra.Command{
+	Use:   "feedbacks <project-slug>",
+	Short: "Summarize evaluation feedback for a project",
+	Long: `Summarize the comments and ratings left on a project's evaluations.
+
+Recent filled-in evaluations are fetched and aggregated into an average
+mark, a failure rate, a difficulty rating (1-5), the distribution of
+evaluation flags and the themes correctors mention most often
+(norm, memory leaks, error handling, ...). Only evaluations the API
+exposes to your application are taken into account.
+
+Examples:
+  t42 project feedbacks libft
+  t42 project feedbacks minishell --campus tokyo --limit 300`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectFeedbacks,
+}
+
+// feedbackTheme is a recurring topic detected in evaluation comments by keyword
+type feedbackTheme struct {
+	Name     string
+	Keywords []string
+}
+
+// feedbackThemes lists the topics looked for in evaluation comments.
+// Keywords are matched case-insensitively as substrings.
+var feedbackThemes = []feedbackTheme{
+	{Name: "Norm", Keywords: []string{"norm", "norminette"}},
+	{Name: "Memory leaks", Keywords: []string{"leak", "valgrind", "free("}},
+	{Name: "Crashes", Keywords: []string{"segfault", "segmentation", "crash", "bus error", "double free"}},
+	{Name: "Error handling", Keywords: []string{"error handling", "protect", "malloc fail", "return value"}},
+	{Name: "Edge cases", Keywords: []string{"edge case", "corner case", "empty", "null", "overflow", "int_min", "int_max"}},
+	{Name: "Tests", Keywords: []string{"test", "tester"}},
+	{Name: "Explanation", Keywords: []string{"explain", "explanation", "understand", "clear", "knowledge"}},
+	{Name: "Makefile", Keywords: []string{"makefile", "relink", "re-link"}},
+	{Name: "Code quality", Keywords: []string{"clean", "readable", "structure", "well organized", "well-organized"}},
+	{Name: "Bonus", Keywords: []string{"bonus"}},
+}
+
+// feedbackCount pairs a label with the number of evaluations it appeared in
+type feedbackCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// feedbackSummary is the aggregated view of a project's evaluations
+type feedbackSummary struct {
+	Evaluations   int             `json:"evaluations"`
+	Marked        int             `json:"marked"`
+	AverageMark   *float64        `json:"average_mark"`
+	FailRate      *float64        `json:"fail_rate"`
+	Difficulty    *float64        `json:"difficulty"`
+	AverageRating *float64        `json:"average_feedback_rating"`
+	Ratings       int             `json:"feedback_ratings"`
+	Flags         []feedbackCount `json:"flags"`
+	Themes        []feedbackCount `json:"themes"`
+}
+
+func init() {
+	projectCmd.AddCommand(projectFeedbacksCmd)
+
+	addCampusFlags(projectFeedbacksCmd)
+	projectFeedbacksCmd.Flags().Int("limit", 100, "Maximum number of evaluations to analyze")
+}
+
+func runProjectFeedbacks(cmd *cobra.Command, args []string) error {
+	projectSlug := expandProjectSlug(args[0])
+
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	// Create API client with automatic token refresh
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	campusID := 0
+	selectedCampus, err := resolveCampusFlags(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	if selectedCampus != nil {
+		campusID = selectedCampus.ID
+	}
+
+	project, err := client.GetProjectBySlug(ctx, projectSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get project '%s': %w", projectSlug, err)
+	}
+
+	scaleTeams, err := fetchProjectScaleTeams(ctx, client, project.ID, campusID, limit)
+	if err != nil {
+		return fmt.Errorf("failed to get evaluations for '%s': %w", projectSlug, err)
+	}
+
+	summary := aggregateFeedback(scaleTeams)
+
+	result := map[string]interface{}{
+		"project": map[string]interface{}{
+			"id":   project.ID,
+			"name": project.Name,
+			"slug": project.Slug,
+		},
+		"summary": summary,
+	}
+	if done, err := emitOutputValue(result); done || err != nil {
+		return err
+	}
+
+	printFeedbackSummary(project, &summary)
+	return nil
+}
+
+// fetchProjectScaleTeams collects up to limit filled-in evaluations, most recent first
+func fetchProjectScaleTeams(ctx context.Context, client *api.Client, projectID, campusID, limit int) ([]api.ScaleTeam, error) {
+	filled := true
+	result, err := api.FetchLimit(ctx, api.LimitOptions{Limit: limit},
+		func(ctx context.Context, page int) ([]api.ScaleTeam, *api.PaginationMeta, error) {
+			return client.ListProjectScaleTeams(ctx, projectID, &api.ListScaleTeamsOptions{
+				Page:     page,
+				PerPage:  api.DefaultPerPage,
+				Sort:     "-begin_at",
+				CampusID: campusID,
+				Filled:   &filled,
+			})
+		}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// aggregateFeedback summarizes marks, ratings, flags and comment themes of evaluations
+func aggregateFeedback(scaleTeams []api.ScaleTeam) feedbackSummary {
+	summary := feedbackSummary{
+		Evaluations: len(scaleTeams),
+		Flags:       []feedbackCount{},
+		Themes:      []feedbackCount{},
+	}
+
+	var markTotal, ratingTotal float64
+	failed := 0
+	flagCounts := make(map[string]int)
+	themeCounts := make(map[string]int)
+
+	for _, st := range scaleTeams {
+		if st.FinalMark != nil {
+			summary.Marked++
+			markTotal += float64(*st.FinalMark)
+
+			passed := *st.FinalMark > 0
+			if st.Flag != nil && !st.Flag.Positive {
+				passed = false
+			}
+			if !passed {
+				failed++
+			}
+		}
+
+		if st.Flag != nil && st.Flag.Name != "" {
+			flagCounts[st.Flag.Name]++
+		}
+
+		if st.FeedbackRating != nil {
+			summary.Ratings++
+			ratingTotal += float64(*st.FeedbackRating)
+		}
+		for _, fb := range st.Feedbacks {
+			if fb.Rating != nil {
+				summary.Ratings++
+				ratingTotal += float64(*fb.Rating)
+			}
+		}
+
+		// Count each theme at most once per evaluation
+		text := strings.ToLower(evaluationText(st))
+		for _, theme := range feedbackThemes {
+			if containsAny(text, theme.Keywords) {
+				themeCounts[theme.Name]++
+			}
+		}
+	}
+
+	if summary.Marked > 0 {
+		avgMark := markTotal / float64(summary.Marked)
+		failRate := float64(failed) / float64(summary.Marked)
+		difficulty := difficultyRating(avgMark, failRate)
+		summary.AverageMark = &avgMark
+		summary.FailRate = &failRate
+		summary.Difficulty = &difficulty
+	}
+	if summary.Ratings > 0 {
+		avgRating := ratingTotal / float64(summary.Ratings)
+		summary.AverageRating = &avgRating
+	}
+
+	summary.Flags = sortedCounts(flagCounts)
+	summary.Themes = sortedCounts(themeCounts)
+
+	return summary
+}
+
+// evaluationText joins every free-text comment attached to an evaluation
+func evaluationText(st api.ScaleTeam) string {
+	parts := []string{st.Comment, st.Feedback}
+	for _, fb := range st.Feedbacks {
+		parts = append(parts, fb.Comment)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// containsAny reports whether text contains any of the keywords
+func containsAny(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// difficultyRating maps the average mark and failure rate to a 1-5 scale,
+// weighting both equally. Marks above 100 (bonus) count as 100.
+func difficultyRating(avgMark, failRate float64) float64 {
+	markScore := 1 - avgMark/100
+	if markScore < 0 {
+		markScore = 0
+	}
+	if markScore > 1 {
+		markScore = 1
+	}
+	return 1 + 4*(markScore+failRate)/2
+}
+
+// sortedCounts converts a count map to a slice sorted by count, then name
+func sortedCounts(counts map[string]int) []feedbackCount {
+	result := make([]feedbackCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, feedbackCount{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func printFeedbackSummary(project *api.Project, summary *feedbackSummary) {
+	fmt.Printf("💬 Evaluation feedback for %s (%s)\n", project.Name, project.Slug)
+	fmt.Println(strings.Repeat("=", 60))
+
+	if summary.Evaluations == 0 {
+		fmt.Println("No evaluations found.")
+		return
+	}
+
+	fmt.Printf("📝 Evaluations analyzed: %d (%d marked)\n", summary.Evaluations, summary.Marked)
+	if summary.AverageMark != nil {
+		fmt.Printf("🎯 Average mark: %.1f\n", *summary.AverageMark)
+		fmt.Printf("❌ Fail rate: %.0f%%\n", *summary.FailRate*100)
+		fmt.Printf("🧗 Difficulty: %.1f/5 %s\n", *summary.Difficulty, renderBar((*summary.Difficulty-1)/4, 20))
+	}
+	if summary.AverageRating != nil {
+		fmt.Printf("⭐ Average feedback rating: %.2f (%d ratings)\n", *summary.AverageRating, summary.Ratings)
+	}
+
+	if len(summary.Flags) > 0 {
+		fmt.Printf("\n🚩 Flags:\n")
+		for _, flag := range summary.Flags {
+			fmt.Printf("   %-24s %d\n", truncateString(flag.Name, 24), flag.Count)
+		}
+	}
+
+	if len(summary.Themes) > 0 {
+		fmt.Printf("\n🔎 Common themes:\n")
+		for _, theme := range summary.Themes {
+			share := float64(theme.Count) / float64(summary.Evaluations)
+			fmt.Printf("   %-16s %s %3.0f%%\n", theme.Name, renderBar(share, 20), share*100)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestAggregateFeedback(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	ok := &api.Flag{Name: "Ok", Positive: true}
+	cheat := &api.Flag{Name: "Cheat", Positive: false}
+
+	tests := []struct {
+		name           string
+		scaleTeams     []api.ScaleTeam
+		wantMarked     int
+		wantAvgMark    float64
+		wantFailRate   float64
+		wantAvgRating  float64
+		wantRatings    int
+		wantTopTheme   string
+		wantThemeCount int
+	}{
+		{
+			name: "marks, ratings and themes are aggregated",
+			scaleTeams: []api.ScaleTeam{
+				{FinalMark: intPtr(100), Flag: ok, FeedbackRating: intPtr(4), Comment: "Clean code, check the Norm next time"},
+				{FinalMark: intPtr(0), Flag: ok, Comment: "Memory leak in ft_split, norm error too"},
+				{FinalMark: intPtr(80), Flag: cheat, Feedbacks: []api.Feedback{{Rating: intPtr(2), Comment: "Good explanation"}}},
+			},
+			wantMarked:     3,
+			wantAvgMark:    60,
+			wantFailRate:   2.0 / 3.0,
+			wantAvgRating:  3,
+			wantRatings:    2,
+			wantTopTheme:   "Norm",
+			wantThemeCount: 2,
+		},
+		{
+			name:           "unmarked evaluations are not averaged",
+			scaleTeams:     []api.ScaleTeam{{Comment: "valgrind shows leaks"}},
+			wantMarked:     0,
+			wantTopTheme:   "Memory leaks",
+			wantThemeCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := aggregateFeedback(tt.scaleTeams)
+
+			if summary.Evaluations != len(tt.scaleTeams) {
+				t.Errorf("Evaluations = %d, want %d", summary.Evaluations, len(tt.scaleTeams))
+			}
+			if summary.Marked != tt.wantMarked {
+				t.Errorf("Marked = %d, want %d", summary.Marked, tt.wantMarked)
+			}
+			if tt.wantMarked == 0 {
+				if summary.AverageMark != nil || summary.Difficulty != nil {
+					t.Errorf("AverageMark/Difficulty should be nil without marks")
+				}
+			} else {
+				if math.Abs(*summary.AverageMark-tt.wantAvgMark) > 1e-9 {
+					t.Errorf("AverageMark = %v, want %v", *summary.AverageMark, tt.wantAvgMark)
+				}
+				if math.Abs(*summary.FailRate-tt.wantFailRate) > 1e-9 {
+					t.Errorf("FailRate = %v, want %v", *summary.FailRate, tt.wantFailRate)
+				}
+			}
+			if summary.Ratings != tt.wantRatings {
+				t.Errorf("Ratings = %d, want %d", summary.Ratings, tt.wantRatings)
+			}
+			if tt.wantRatings > 0 && math.Abs(*summary.AverageRating-tt.wantAvgRating) > 1e-9 {
+				t.Errorf("AverageRating = %v, want %v", *summary.AverageRating, tt.wantAvgRating)
+			}
+			if len(summary.Themes) == 0 {
+				t.Fatalf("Themes is empty, want %q first", tt.wantTopTheme)
+			}
+			if summary.Themes[0].Name != tt.wantTopTheme || summary.Themes[0].Count != tt.wantThemeCount {
+				t.Errorf("top theme = %+v, want %s (%d)", summary.Themes[0], tt.wantTopTheme, tt.wantThemeCount)
+			}
+		})
+	}
+}
+
+func TestDifficultyRating(t *testing.T) {
+	tests := []struct {
+		name     string
+		avgMark  float64
+		failRate float64
+		want     float64
+	}{
+		{"perfect marks and no failures is easiest", 100, 0, 1},
+		{"bonus marks are capped", 125, 0, 1},
+		{"zero marks and all failures is hardest", 0, 1, 5},
+		{"halfway", 50, 0.5, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := difficultyRating(tt.avgMark, tt.failRate); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("difficultyRating(%v, %v) = %v, want %v", tt.avgMark, tt.failRate, got, tt.want)
+			}
+		})
+	}
+}
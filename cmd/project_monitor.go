@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+const (
+	// monitorMinInterval keeps the monitor, which makes a few requests
+	// per check, well inside the API rate limit
+	monitorMinInterval = 30 * time.Second
+
+	// monitorDefaultInterval is the default time between two checks
+	monitorDefaultInterval = 2 * time.Minute
+)
+
+var projectMonitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Follow the teams of all your projects in progress",
+	Long: `Poll the teams of all your projects in progress and report their
+changes as they happen: group formed, members joining, team locked,
+correction scheduled, mark received and team closed.
+
+The monitor starts with one status line per project, then prints each
+change with the time it was seen, and sends it as a desktop notification
+unless --no-desktop is given. --log appends the changes to a file as well,
+one line each. With --json, every change is printed as one JSON object per
+line.
+
+--once checks a single time and prints the status lines (or, with --json,
+the teams) instead of monitoring. Stop the monitor with Ctrl-C.
+
+Examples:
+  t42 project monitor
+  t42 project monitor --interval 5m --log ~/t42-teams.log
+  t42 project monitor --once`,
+	Args: cobra.NoArgs,
+	RunE: runProjectMonitor,
+}
+
+func init() {
+	projectCmd.AddCommand(projectMonitorCmd)
+
+	projectMonitorCmd.Flags().Duration("interval", monitorDefaultInterval, "Time between checks (at least 30s)")
+	projectMonitorCmd.Flags().Bool("no-desktop", false, "Only print changes, without desktop notifications")
+	projectMonitorCmd.Flags().String("log", "", "Also append the changes to this file")
+	projectMonitorCmd.Flags().Bool("once", false, "Check once and print the status of each project")
+}
+
+// teamSnapshot is the state of one of your teams at one check
+type teamSnapshot struct {
+	TeamID     int        `json:"team_id"`
+	Project    string     `json:"project"`
+	Team       string     `json:"team"`
+	Status     string     `json:"status"`
+	Members    int        `json:"members"`
+	Locked     bool       `json:"locked"`
+	Closed     bool       `json:"closed"`
+	FinalMark  *int       `json:"final_mark"`
+	Validated  *bool      `json:"validated"`
+	Correction *time.Time `json:"next_correction"`
+}
+
+// teamTransition is a change of a team between two checks
+type teamTransition struct {
+	At      time.Time `json:"at"`
+	TeamID  int       `json:"team_id"`
+	Project string    `json:"project"`
+	Kind    string    `json:"kind"` // "team_created", "group_formed", "member_joined", "locked", "correction_scheduled", "status", "mark_received" or "closed"
+	Message string    `json:"message"`
+}
+
+func runProjectMonitor(cmd *cobra.Command, args []string) error {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	noDesktop, _ := cmd.Flags().GetBool("no-desktop")
+	logPath, _ := cmd.Flags().GetString("log")
+	once, _ := cmd.Flags().GetBool("once")
+	if interval < monitorMinInterval {
+		return fmt.Errorf("--interval must be at least %s", monitorMinInterval)
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+
+	current, err := fetchTeamSnapshots(ctx, client, me.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	if once {
+		if done, err := emitOutput(output.Field{Key: "teams", Value: current}); done || err != nil {
+			return err
+		}
+		printTeamStatusLines(os.Stdout, current, time.Now())
+		return nil
+	}
+
+	var logFile io.Writer
+	if logPath != "" {
+		f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to close log file: %v\n", err)
+			}
+		}()
+		logFile = f
+	}
+
+	if !GetJSONOutput() {
+		printTeamStatusLines(os.Stdout, current, time.Now())
+		fmt.Printf("\n👀 Monitoring %d team(s) every %s - Ctrl-C to stop\n", len(current), interval)
+	}
+
+	desktop := !noDesktop
+	for {
+		time.Sleep(interval)
+
+		next, err := fetchTeamSnapshots(ctx, client, me.ID, current)
+		if err != nil {
+			// A failed check is retried at the next one
+			fmt.Fprintf(os.Stderr, "⚠️  [%s] check failed: %v\n", time.Now().Format("15:04:05"), err)
+			continue
+		}
+
+		for _, t := range teamTransitions(current, next, time.Now()) {
+			if GetJSONOutput() {
+				data, err := json.Marshal(t)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+			} else {
+				fmt.Printf("🔔 [%s] %s: %s\n", t.At.Format("15:04:05"), t.Project, t.Message)
+			}
+			if logFile != nil {
+				if _, err := fmt.Fprintf(logFile, "%s %s: %s\n", t.At.Format(time.RFC3339), t.Project, t.Message); err != nil {
+					return fmt.Errorf("failed to write log file: %w", err)
+				}
+			}
+			if desktop {
+				if err := sendDesktopNotification("t42: "+t.Project, t.Message); err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  Desktop notifications disabled: %v\n", err)
+					desktop = false
+				}
+			}
+		}
+		current = next
+	}
+}
+
+// fetchTeamSnapshots returns your teams in progress, with their next
+// correction. Teams in previous stay followed until they are closed, so
+// that their mark is seen.
+func fetchTeamSnapshots(ctx context.Context, client *api.Client, userID int, previous []teamSnapshot) ([]teamSnapshot, error) {
+	teams, _, err := client.ListUserTeams(ctx, userID, &api.ListTeamsOptions{PerPage: api.DefaultPerPage, Sort: "-created_at"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list your teams: %w", err)
+	}
+
+	future := true
+	corrections, _, err := client.ListUserScaleTeams(ctx, userID, api.ScaleTeamAsCorrected, &api.ListScaleTeamsOptions{
+		PerPage: api.DefaultPerPage,
+		Sort:    "begin_at",
+		Future:  &future,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list your evaluations: %w", err)
+	}
+
+	followed := make(map[int]bool, len(previous))
+	for _, s := range previous {
+		followed[s.TeamID] = !s.Closed
+	}
+	var active []api.Team
+	var projectIDs []int
+	for _, t := range teams {
+		if t.Closed && !followed[t.ID] {
+			continue
+		}
+		active = append(active, t)
+		projectIDs = append(projectIDs, t.ProjectID)
+	}
+
+	return teamSnapshots(active, corrections, resolveProjectNames(ctx, client, projectIDs)), nil
+}
+
+// teamSnapshots builds the snapshots of teams, with the earliest upcoming
+// correction of each, sorted by project
+func teamSnapshots(teams []api.Team, corrections []api.ScaleTeam, projects map[int]string) []teamSnapshot {
+	next := make(map[int]time.Time)
+	for _, st := range corrections {
+		if at, ok := next[st.Team.ID]; !ok || st.BeginAt.Before(at) {
+			next[st.Team.ID] = st.BeginAt
+		}
+	}
+
+	snapshots := make([]teamSnapshot, 0, len(teams))
+	for _, t := range teams {
+		s := teamSnapshot{
+			TeamID:    t.ID,
+			Project:   projects[t.ProjectID],
+			Team:      t.Name,
+			Status:    t.Status,
+			Members:   len(t.Users),
+			Locked:    t.Locked,
+			Closed:    t.Closed,
+			FinalMark: t.FinalMark,
+			Validated: t.Validated,
+		}
+		if s.Project == "" {
+			s.Project = t.Name
+		}
+		if at, ok := next[t.ID]; ok {
+			s.Correction = &at
+		}
+		snapshots = append(snapshots, s)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Project < snapshots[j].Project
+	})
+	return snapshots
+}
+
+// teamTransitions lists the changes from previous to current snapshots
+func teamTransitions(previous, current []teamSnapshot, now time.Time) []teamTransition {
+	before := make(map[int]teamSnapshot, len(previous))
+	for _, s := range previous {
+		before[s.TeamID] = s
+	}
+
+	var transitions []teamTransition
+	for _, cur := range current {
+		add := func(kind, message string) {
+			transitions = append(transitions, teamTransition{At: now, TeamID: cur.TeamID, Project: cur.Project, Kind: kind, Message: message})
+		}
+
+		prev, seen := before[cur.TeamID]
+		if !seen {
+			add("team_created", fmt.Sprintf("team %s created", cur.Team))
+			continue
+		}
+
+		statusReported := false
+		switch {
+		case prev.Status == "creating_group" && cur.Status != "creating_group":
+			add("group_formed", fmt.Sprintf("group formed (%d members)", cur.Members))
+			statusReported = true
+		case cur.Members > prev.Members:
+			add("member_joined", fmt.Sprintf("%d member(s) joined, %d in the team", cur.Members-prev.Members, cur.Members))
+		}
+		if cur.Locked && !prev.Locked {
+			add("locked", "team locked")
+		}
+		if cur.Correction != nil && (prev.Correction == nil || !cur.Correction.Equal(*prev.Correction)) {
+			add("correction_scheduled", "correction scheduled for "+cur.Correction.Local().Format("Mon Jan 2 15:04"))
+		}
+		if cur.Status != prev.Status && !statusReported {
+			add("status", "now "+strings.ReplaceAll(cur.Status, "_", " "))
+		}
+		if cur.FinalMark != nil && (prev.FinalMark == nil || *cur.FinalMark != *prev.FinalMark) {
+			add("mark_received", "mark received: "+snapshotMark(cur))
+		}
+		if cur.Closed && !prev.Closed {
+			add("closed", "team closed")
+		}
+	}
+	return transitions
+}
+
+// snapshotMark formats the mark of a snapshot like the team commands do
+func snapshotMark(s teamSnapshot) string {
+	return formatTeamMark(api.Team{FinalMark: s.FinalMark, Validated: s.Validated})
+}
+
+// printTeamStatusLines prints one compact line per team
+func printTeamStatusLines(w io.Writer, snapshots []teamSnapshot, now time.Time) {
+	if len(snapshots) == 0 {
+		fmt.Fprintln(w, "No projects in progress.")
+		return
+	}
+	for _, s := range snapshots {
+		parts := []string{strings.ReplaceAll(s.Status, "_", " ")}
+		if s.Locked {
+			parts = append(parts, "locked")
+		}
+		if s.Correction != nil {
+			parts = append(parts, "correction in "+formatTimeLeft(s.Correction.Sub(now)))
+		}
+		if s.FinalMark != nil {
+			parts = append(parts, "mark "+snapshotMark(s))
+		}
+		fmt.Fprintf(w, "📦 %-24s %s\n", truncateString(s.Project, 24), strings.Join(parts, " · "))
+	}
+}
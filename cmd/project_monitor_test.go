@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestTeamSnapshots(t *testing.T) {
+	soon := time.Date(2024, 6, 1, 14, 0, 0, 0, time.UTC)
+	later := soon.Add(2 * time.Hour)
+	teams := []api.Team{
+		{ID: 1, Name: "minishell team", ProjectID: 10, Status: "in_progress", Users: make([]api.User, 2)},
+		{ID: 2, Name: "libft team", ProjectID: 20, Status: "waiting_for_correction"},
+	}
+	corrections := []api.ScaleTeam{
+		{ID: 100, BeginAt: later},
+		{ID: 101, BeginAt: soon},
+	}
+	corrections[0].Team.ID = 2
+	corrections[1].Team.ID = 2
+
+	got := teamSnapshots(teams, corrections, map[int]string{20: "libft"})
+	if len(got) != 2 {
+		t.Fatalf("teamSnapshots() returned %d snapshots, want 2", len(got))
+	}
+	if got[0].Project != "libft" || got[1].Project != "minishell team" {
+		t.Errorf("projects = %q, %q, want libft first and the team name without a project name", got[0].Project, got[1].Project)
+	}
+	if got[0].Correction == nil || !got[0].Correction.Equal(soon) {
+		t.Errorf("correction = %v, want the earliest one %v", got[0].Correction, soon)
+	}
+	if got[1].Members != 2 || got[1].Correction != nil {
+		t.Errorf("snapshot = %+v, want 2 members and no correction", got[1])
+	}
+}
+
+func TestTeamTransitions(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	slot := now.Add(24 * time.Hour)
+	mark := 100
+	validated := true
+	base := teamSnapshot{TeamID: 1, Project: "minishell", Team: "team", Status: "in_progress", Members: 2}
+
+	tests := []struct {
+		name string
+		prev []teamSnapshot
+		cur  func(s teamSnapshot) teamSnapshot
+		want []string
+	}{
+		{
+			name: "unchanged",
+			prev: []teamSnapshot{base},
+			cur:  func(s teamSnapshot) teamSnapshot { return s },
+		},
+		{
+			name: "new team",
+			cur:  func(s teamSnapshot) teamSnapshot { return s },
+			want: []string{"team_created"},
+		},
+		{
+			name: "group formed",
+			prev: []teamSnapshot{{TeamID: 1, Status: "creating_group", Members: 1}},
+			cur:  func(s teamSnapshot) teamSnapshot { return s },
+			want: []string{"group_formed"},
+		},
+		{
+			name: "member joined",
+			prev: []teamSnapshot{{TeamID: 1, Status: "creating_group", Members: 1}},
+			cur: func(s teamSnapshot) teamSnapshot {
+				s.Status = "creating_group"
+				return s
+			},
+			want: []string{"member_joined"},
+		},
+		{
+			name: "locked and correction scheduled",
+			prev: []teamSnapshot{base},
+			cur: func(s teamSnapshot) teamSnapshot {
+				s.Locked = true
+				s.Status = "waiting_for_correction"
+				s.Correction = &slot
+				return s
+			},
+			want: []string{"locked", "correction_scheduled", "status"},
+		},
+		{
+			name: "mark received and closed",
+			prev: []teamSnapshot{base},
+			cur: func(s teamSnapshot) teamSnapshot {
+				s.Status = "finished"
+				s.FinalMark = &mark
+				s.Validated = &validated
+				s.Closed = true
+				return s
+			},
+			want: []string{"status", "mark_received", "closed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := teamTransitions(tt.prev, []teamSnapshot{tt.cur(base)}, now)
+			var kinds []string
+			for _, tr := range got {
+				kinds = append(kinds, tr.Kind)
+				if tr.Project != "minishell" || !tr.At.Equal(now) {
+					t.Errorf("transition = %+v, want project minishell at %v", tr, now)
+				}
+			}
+			if strings.Join(kinds, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("teamTransitions() kinds = %v, want %v", kinds, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintTeamStatusLines(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	slot := now.Add(5 * time.Hour)
+	mark := 85
+	var buf bytes.Buffer
+	printTeamStatusLines(&buf, []teamSnapshot{
+		{TeamID: 1, Project: "minishell", Status: "waiting_for_correction", Locked: true, Correction: &slot},
+		{TeamID: 2, Project: "libft", Status: "finished", FinalMark: &mark},
+	}, now)
+
+	got := buf.String()
+	for _, want := range []string{"waiting for correction · locked · correction in 5h", "finished · mark 85"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("printTeamStatusLines() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	buf.Reset()
+	printTeamStatusLines(&buf, nil, now)
+	if buf.String() != "No projects in progress.\n" {
+		t.Errorf("printTeamStatusLines(nil) = %q", buf.String())
+	}
+}
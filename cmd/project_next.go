@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// projectNextWorkers is how many projects have their rules checked at once
+const projectNextWorkers = 4
+
+var projectNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "List the projects you can register for now, by experience",
+	Long: `List the projects of your cursus you are allowed to register for now:
+those you have neither validated nor in progress, with a session open on
+your campus whose inscription rules you meet (quests validated or not,
+projects neither ongoing nor validated, as 't42 user eligible' checks them).
+
+Projects are sorted by the experience they give at 100, highest first, with
+the level you would reach by validating each.
+
+Reading session rules needs the app credentials, like 't42 user eligible'.
+With --verbose, the reason each other project is left out is shown.
+
+Examples:
+  t42 project next
+  t42 project next --limit 5
+  t42 project next --cursus-id 21 --json`,
+	Args: cobra.NoArgs,
+	RunE: runProjectNext,
+}
+
+func init() {
+	projectCmd.AddCommand(projectNextCmd)
+
+	projectNextCmd.Flags().Int("cursus-id", 0, "Cursus ID (default: your current cursus)")
+	addLimitFlag(projectNextCmd, 0, "Maximum number of projects to show (0 for all)")
+}
+
+// nextProject is a project the user can register for
+type nextProject struct {
+	ID         int     `json:"id"`
+	Name       string  `json:"name"`
+	Slug       string  `json:"slug"`
+	Tier       int     `json:"tier"`
+	XP         *int    `json:"xp"` // nil when unknown
+	LevelAfter float64 `json:"level_after"`
+	Retry      bool    `json:"retry"` // failed before
+}
+
+func runProjectNext(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+	limits, err := limitOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	cu := findCursusUser(me.CursusUsers, cursusID)
+	if cu == nil {
+		if cursusID != 0 {
+			return fmt.Errorf("you are not in cursus %d", cursusID)
+		}
+		return fmt.Errorf("you have no cursus - use --cursus-id")
+	}
+	campus := primaryCampus(me)
+	if campus == nil {
+		return fmt.Errorf("you have no campus")
+	}
+
+	levels, err := client.ListCursusLevels(ctx, cu.Cursus.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get the levels of cursus %d: %w", cu.Cursus.ID, err)
+	}
+	position := positionInLevels(levels, cu.Level)
+	if position == nil {
+		return fmt.Errorf("level %.2f is outside the levels of %s", cu.Level, cu.Cursus.Name)
+	}
+
+	projects, err := client.ProjectsPaginator(ctx, &api.ListProjectsOptions{CursusID: cu.Cursus.ID}).All()
+	if err != nil {
+		return fmt.Errorf("failed to list the projects of %s: %w", cu.Cursus.Name, err)
+	}
+	questUsers, err := client.ListUserQuestUsers(ctx, me.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get your quests: %w", err)
+	}
+	appClient, err := newAppClient(ctx)
+	if err != nil {
+		return fmt.Errorf("%w (needed for session rules)", err)
+	}
+
+	statuses := projectStatuses(me.ProjectsUsers)
+	candidates := nextCandidates(projects, statuses)
+	type checked struct {
+		next *nextProject
+		skip string
+	}
+	results := runWorkers(candidates, projectNextWorkers, func(p api.Project) checked {
+		detail, err := client.GetProject(ctx, p.ID)
+		if err != nil {
+			warnf("project_rules_unknown", "skipped %s: failed to get the project: %v", p.Name, err)
+			return checked{skip: "project unavailable"}
+		}
+		session := registrationSession(detail, campus.ID, cu.Cursus.ID)
+		if session == nil {
+			return checked{skip: "no open session on your campus"}
+		}
+		sessionDetail, err := appClient.GetProjectSessionDetail(ctx, session.ID)
+		if err != nil {
+			warnf("project_rules_unknown", "skipped %s: failed to get its session rules: %v", p.Name, err)
+			return checked{skip: "session rules unavailable"}
+		}
+		reqs := parseInscriptionRules(sessionDetail.ProjectSessionsRules)
+		// Registering again after failing is fine, that one project aside
+		if reasons := inscriptionBlockers(reqs, otherProjectUsers(me.ProjectsUsers, p.ID), questUsers); len(reasons) > 0 {
+			return checked{skip: strings.Join(reasons, ", ")}
+		}
+
+		next := &nextProject{
+			ID:    p.ID,
+			Name:  p.Name,
+			Slug:  p.Slug,
+			Tier:  p.Tier,
+			XP:    projectXP(detail, campus.ID, cu.Cursus.ID),
+			Retry: statuses[p.ID] == treeFailed,
+		}
+		next.LevelAfter = position.Level
+		if next.XP != nil {
+			next.LevelAfter = levelFromXP(levels, position.XP+*next.XP)
+		}
+		return checked{next: next}
+	})
+
+	var next []nextProject
+	for i, r := range results {
+		if r.next != nil {
+			next = append(next, *r.next)
+		} else if GetVerbose() {
+			fmt.Printf("  %s: %s\n", candidates[i].Name, r.skip)
+		}
+	}
+	sortNextProjects(next)
+	if limits.Limit > 0 && len(next) > limits.Limit {
+		next = next[:limits.Limit]
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "projects", Value: next},
+		output.Field{Key: "cursus", Value: cu.Cursus.Name},
+		output.Field{Key: "campus", Value: campus.Name},
+		output.Field{Key: "level", Value: position.Level},
+	); done || err != nil {
+		return err
+	}
+
+	printNextProjects(cu.Cursus.Name, position.Level, next)
+	return nil
+}
+
+// nextCandidates returns the top-level projects the user has neither
+// validated nor in progress; sub-projects come with their parent
+func nextCandidates(projects []api.Project, statuses map[int]string) []api.Project {
+	var candidates []api.Project
+	for _, p := range projects {
+		if p.Parent != nil {
+			continue
+		}
+		if status := statuses[p.ID]; status == treeValidated || status == treeInProgress {
+			continue
+		}
+		candidates = append(candidates, p)
+	}
+	return candidates
+}
+
+// registrationSession returns the session of a project users of a campus
+// register to in a cursus: the campus's own when open, else the cursus's
+// open one. It returns nil when neither is.
+func registrationSession(project *api.Project, campusID, cursusID int) *api.ProjectSession {
+	var cursusSession *api.ProjectSession
+	for i, session := range project.ProjectSessions {
+		if !session.IsSubscriptable || session.CursusID != cursusID {
+			continue
+		}
+		if session.CampusID == campusID {
+			return &project.ProjectSessions[i]
+		}
+		if cursusSession == nil && session.CampusID == 0 {
+			cursusSession = &project.ProjectSessions[i]
+		}
+	}
+	return cursusSession
+}
+
+// otherProjectUsers returns the registrations of a user but those to one
+// project
+func otherProjectUsers(projectUsers []api.ProjectUser, projectID int) []api.ProjectUser {
+	var others []api.ProjectUser
+	for _, pu := range projectUsers {
+		if pu.Project.ID != projectID {
+			others = append(others, pu)
+		}
+	}
+	return others
+}
+
+// inscriptionBlockers returns why a user may not register under reqs, or
+// nothing when they may
+func inscriptionBlockers(reqs inscriptionRequirements, projectUsers []api.ProjectUser, questUsers []api.QuestUser) []string {
+	var reasons []string
+	if !checkRequiredQuests(questUsers, reqs.requiredQuests) {
+		reasons = append(reasons, "required quest not validated")
+	}
+	if !checkForbiddenQuests(questUsers, reqs.forbiddenQuests) {
+		reasons = append(reasons, "forbidden quest validated")
+	}
+	if !checkForbiddenProjects(projectUsers, reqs.forbiddenProjects) {
+		reasons = append(reasons, "forbidden project active/validated")
+	}
+	return reasons
+}
+
+// sortNextProjects sorts projects by experience, highest first and unknown
+// last, then by tier and name
+func sortNextProjects(projects []nextProject) {
+	xp := func(p nextProject) int {
+		if p.XP == nil {
+			return -1
+		}
+		return *p.XP
+	}
+	sort.SliceStable(projects, func(i, j int) bool {
+		if xp(projects[i]) != xp(projects[j]) {
+			return xp(projects[i]) > xp(projects[j])
+		}
+		if projects[i].Tier != projects[j].Tier {
+			return projects[i].Tier < projects[j].Tier
+		}
+		return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
+	})
+}
+
+func printNextProjects(cursus string, level float64, projects []nextProject) {
+	fmt.Printf("🧭 Projects you can register for in %s (level %.2f)\n\n", cursus, level)
+	if len(projects) == 0 {
+		fmt.Println("None right now.")
+		return
+	}
+	fmt.Printf("%-28s %-28s %4s %6s %7s\n", "NAME", "SLUG", "TIER", "XP", "LEVEL")
+	fmt.Println(strings.Repeat("-", 77))
+	for _, p := range projects {
+		xp, after := "-", "-"
+		if p.XP != nil {
+			xp = fmt.Sprint(*p.XP)
+			after = fmt.Sprintf("%.2f", p.LevelAfter)
+		}
+		name := p.Name
+		if p.Retry {
+			name += " (retry)"
+		}
+		fmt.Printf("%-28s %-28s %4d %6s %7s\n", truncateString(name, 28), truncateString(p.Slug, 28), p.Tier, xp, after)
+	}
+	fmt.Printf("\nLEVEL is the level you would reach by validating the project at 100.\n")
+}
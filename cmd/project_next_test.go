@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestNextCandidates(t *testing.T) {
+	projects := []api.Project{
+		{ID: 1, Name: "Libft"},
+		{ID: 2, Name: "minishell"},
+		{ID: 3, Name: "Philosophers"},
+		{ID: 4, Name: "cub3d"},
+		{ID: 5, Name: "CPP Module 00", Parent: &api.Project{ID: 6}},
+	}
+	statuses := map[int]string{1: treeValidated, 2: treeInProgress, 3: treeFailed}
+
+	var names []string
+	for _, p := range nextCandidates(projects, statuses) {
+		names = append(names, p.Name)
+	}
+	want := []string{"Philosophers", "cub3d"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] {
+		t.Errorf("candidates = %v, want %v", names, want)
+	}
+}
+
+func TestRegistrationSession(t *testing.T) {
+	project := &api.Project{ProjectSessions: []api.ProjectSession{
+		{ID: 1, CampusID: 26, CursusID: 21, IsSubscriptable: false},
+		{ID: 2, CampusID: 0, CursusID: 21, IsSubscriptable: true},
+		{ID: 3, CampusID: 26, CursusID: 9, IsSubscriptable: true},
+		{ID: 4, CampusID: 1, CursusID: 21, IsSubscriptable: true},
+	}}
+
+	tests := []struct {
+		name     string
+		campusID int
+		cursusID int
+		want     int // session ID, 0 for none
+	}{
+		{"campus session", 1, 21, 4},
+		{"closed campus session falls back to cursus", 26, 21, 2},
+		{"campus session of another cursus", 26, 9, 3},
+		{"no session", 26, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := registrationSession(project, tt.campusID, tt.cursusID)
+			if tt.want == 0 {
+				if got != nil {
+					t.Errorf("got session %d, want none", got.ID)
+				}
+				return
+			}
+			if got == nil || got.ID != tt.want {
+				t.Errorf("got %+v, want session %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInscriptionBlockers(t *testing.T) {
+	validated := time.Now()
+	questUsers := []api.QuestUser{
+		{Quest: api.Quest{Slug: "common-core-rank-02"}, ValidatedAt: &validated},
+		{Quest: api.Quest{Slug: "common-core-rank-03"}},
+	}
+	projectUsers := []api.ProjectUser{
+		{Project: api.Project{Slug: "minishell"}, Status: "in_progress"},
+	}
+
+	tests := []struct {
+		name string
+		reqs inscriptionRequirements
+		want int
+	}{
+		{"no rules", inscriptionRequirements{}, 0},
+		{"required quest validated", inscriptionRequirements{requiredQuests: []string{"common-core-rank-02"}}, 0},
+		{"required quest missing", inscriptionRequirements{requiredQuests: []string{"common-core-rank-03"}}, 1},
+		{"forbidden quest validated", inscriptionRequirements{forbiddenQuests: []string{"common-core-rank-02"}}, 1},
+		{"forbidden project ongoing", inscriptionRequirements{
+			requiredQuests:    []string{"common-core-rank-03"},
+			forbiddenProjects: []string{"minishell"},
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inscriptionBlockers(tt.reqs, projectUsers, questUsers); len(got) != tt.want {
+				t.Errorf("blockers = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOtherProjectUsers(t *testing.T) {
+	projectUsers := []api.ProjectUser{
+		{Project: api.Project{ID: 1, Slug: "philosophers"}, Status: "finished"},
+		{Project: api.Project{ID: 2, Slug: "minishell"}, Status: "in_progress"},
+	}
+	others := otherProjectUsers(projectUsers, 1)
+	if len(others) != 1 || others[0].Project.ID != 2 {
+		t.Errorf("others = %+v, want only project 2", others)
+	}
+}
+
+func TestSortNextProjects(t *testing.T) {
+	projects := []nextProject{
+		{Name: "unknown"},
+		{Name: "small", XP: intPtr(462)},
+		{Name: "b big", Tier: 3, XP: intPtr(2100)},
+		{Name: "a big", Tier: 3, XP: intPtr(2100)},
+		{Name: "big early", Tier: 2, XP: intPtr(2100)},
+	}
+	sortNextProjects(projects)
+
+	want := []string{"big early", "a big", "b big", "small", "unknown"}
+	for i, name := range want {
+		if projects[i].Name != name {
+			t.Errorf("projects[%d] = %s, want %s", i, projects[i].Name, name)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// projectPickerHeight is the number of projects the picker shows at once
+const projectPickerHeight = 15
+
+// addProjectPickerFlag adds --all to a command whose project slug can be
+// picked interactively
+func addProjectPickerFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "Without a project slug, pick from every project of your cursus instead of yours")
+}
+
+// projectSlugArg returns the project slug given as the first argument, or
+// lets the user pick one when there is none
+func projectSlugArg(cmd *cobra.Command, args []string, client *api.Client) (string, error) {
+	if len(args) > 0 {
+		return expandProjectSlug(args[0]), nil
+	}
+	if GetJSONOutput() || !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return "", fmt.Errorf("a project slug is required when not running in a terminal")
+	}
+	all, _ := cmd.Flags().GetBool("all")
+	return pickProject(context.Background(), client, all)
+}
+
+// projectChoice is one entry of the project picker
+type projectChoice struct {
+	Label string
+	Slug  string
+}
+
+// pickProject asks the user to pick one of their projects, or one of the
+// projects of their cursus with all, filtering as they type
+func pickProject(ctx context.Context, client *api.Client, all bool) (string, error) {
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	var choices []projectChoice
+	if all {
+		choices, err = cursusProjectChoices(ctx, client, me)
+	} else {
+		choices, err = userProjectChoices(ctx, client, me.ID)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(choices) == 0 {
+		return "", fmt.Errorf("no projects to pick from")
+	}
+
+	labels := make([]string, len(choices))
+	for i, c := range choices {
+		labels[i] = c.Label
+	}
+	choice, err := selectPrompt(selection{
+		Title:       "Project",
+		Description: "Type / to search",
+		Options:     labels,
+		Height:      projectPickerHeight,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get project selection: %w", err)
+	}
+	return choices[choice].Slug, nil
+}
+
+// userProjectChoices lists the projects of a user, the ones in progress
+// first and then the most recently updated
+func userProjectChoices(ctx context.Context, client *api.Client, userID int) ([]projectChoice, error) {
+	var projectUsers []api.ProjectUser
+	err := client.FetchAllUserProjects(ctx, userID, &api.ListUserProjectsOptions{}, func(page []api.ProjectUser, _ *api.PaginationMeta) error {
+		projectUsers = append(projectUsers, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list your projects: %w", err)
+	}
+	return sortUserProjectChoices(projectUsers), nil
+}
+
+// sortUserProjectChoices turns project users into picker entries, the
+// ones in progress first and then the most recently updated
+func sortUserProjectChoices(projectUsers []api.ProjectUser) []projectChoice {
+	sort.SliceStable(projectUsers, func(i, j int) bool {
+		iDone, jDone := projectUsers[i].Status == "finished", projectUsers[j].Status == "finished"
+		if iDone != jDone {
+			return !iDone
+		}
+		return projectUsers[i].UpdatedAt.After(projectUsers[j].UpdatedAt)
+	})
+
+	choices := make([]projectChoice, 0, len(projectUsers))
+	for _, pu := range projectUsers {
+		label := fmt.Sprintf("%s (%s) - %s", pu.Project.Name, pu.Project.Slug, pu.Status)
+		if pu.FinalMark != nil {
+			label += fmt.Sprintf(", %d", *pu.FinalMark)
+		}
+		choices = append(choices, projectChoice{Label: label, Slug: pu.Project.Slug})
+	}
+	return choices
+}
+
+// cursusProjectChoices lists the projects of the user's current cursus,
+// or every project when they have none, by name
+func cursusProjectChoices(ctx context.Context, client *api.Client, me *api.User) ([]projectChoice, error) {
+	opts := &api.ListProjectsOptions{Sort: "name"}
+	if cursusUser := findCursusUser(me.CursusUsers, 0); cursusUser != nil {
+		opts.CursusID = cursusUser.Cursus.ID
+	}
+
+	var choices []projectChoice
+	err := client.FetchAllProjects(ctx, opts, func(page []api.Project, _ *api.PaginationMeta) error {
+		for _, p := range page {
+			choices = append(choices, projectChoice{Label: fmt.Sprintf("%s (%s)", p.Name, p.Slug), Slug: p.Slug})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return choices, nil
+}
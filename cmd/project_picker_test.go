@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestSortUserProjectChoices(t *testing.T) {
+	now := time.Now()
+	mark := 125
+	projectUsers := []api.ProjectUser{
+		{Status: "finished", FinalMark: &mark, UpdatedAt: now, Project: api.Project{Name: "Libft", Slug: "libft"}},
+		{Status: "in_progress", UpdatedAt: now.Add(-48 * time.Hour), Project: api.Project{Name: "minishell", Slug: "minishell"}},
+		{Status: "searching_a_group", UpdatedAt: now.Add(-time.Hour), Project: api.Project{Name: "webserv", Slug: "webserv"}},
+		{Status: "finished", UpdatedAt: now.Add(-time.Hour), Project: api.Project{Name: "ft_printf", Slug: "ft_printf"}},
+	}
+
+	got := sortUserProjectChoices(projectUsers)
+	want := []projectChoice{
+		{Label: "webserv (webserv) - searching_a_group", Slug: "webserv"},
+		{Label: "minishell (minishell) - in_progress", Slug: "minishell"},
+		{Label: "Libft (libft) - finished, 125", Slug: "libft"},
+		{Label: "ft_printf (ft_printf) - finished", Slug: "ft_printf"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortUserProjectChoices() = %+v, want %+v", got, want)
+	}
+}
+
+func TestProjectSlugArg(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "slug given", args: []string{"libft"}, want: "libft"},
+		{name: "slug and directory given", args: []string{"libft", "dir"}, want: "libft"},
+		// Tests do not run in a terminal, so there is nothing to pick in
+		{name: "no slug outside a terminal", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			got, err := projectSlugArg(showProjectCmd, tt.args, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("projectSlugArg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("projectSlugArg() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
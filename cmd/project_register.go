@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// registerRuleKinds are the kinds of session rules shown before
+// registering; retry rules are only shown when retrying
+var registerRuleKinds = map[string]bool{
+	"inscription": true,
+	"group":       true,
+}
+
+var projectRegisterCmd = &cobra.Command{
+	Use:   "register <slug>",
+	Short: "Register to a project, or start a retry",
+	Long: `Register to a project, or start a new attempt of a project you have
+finished. The session rules of your campus that apply (inscription and
+group rules, plus retry rules for a retry) are shown in a confirmation
+before registering.
+
+Reading the session rules needs the application credentials (FT_UID and
+FT_SECRET); without them you are asked to confirm without the rules.
+Use --yes to register without asking.
+
+Examples:
+  t42 project register libft
+  t42 project register ft_printf --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectRegister,
+}
+
+var projectUnregisterCmd = &cobra.Command{
+	Use:   "unregister <slug>",
+	Short: "Cancel your registration to a project",
+	Long: `Cancel your current registration to a project. The API only allows it
+while the registration has not started, typically while searching for or
+creating a group.
+
+Examples:
+  t42 project unregister libft
+  t42 project unregister ft_printf --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectUnregister,
+}
+
+func init() {
+	projectCmd.AddCommand(projectRegisterCmd)
+	projectCmd.AddCommand(projectUnregisterCmd)
+
+	projectRegisterCmd.Flags().BoolP("yes", "y", false, "Register without asking for confirmation")
+	projectUnregisterCmd.Flags().BoolP("yes", "y", false, "Unregister without asking for confirmation")
+}
+
+func runProjectRegister(cmd *cobra.Command, args []string) error {
+	slug := expandProjectSlug(args[0])
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes && (GetJSONOutput() || !isTerminal(os.Stdin)) {
+		return fmt.Errorf("pass --yes to register when not running in a terminal")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	project, err := resolveProject(ctx, client, slug)
+	if err != nil {
+		return err
+	}
+	detail, err := client.GetProject(ctx, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get project detail: %w", err)
+	}
+
+	registrations, err := listOwnRegistrations(ctx, client, me.ID, project.ID)
+	if err != nil {
+		return err
+	}
+	retry, err := checkRegistrable(registrations, time.Now())
+	if err != nil {
+		return fmt.Errorf("cannot register to %s: %w", project.Name, err)
+	}
+
+	var campusID, cursusID int
+	if campus := primaryCampus(me); campus != nil {
+		campusID = campus.ID
+	}
+	if cursusUser := findCursusUser(me.CursusUsers, 0); cursusUser != nil {
+		cursusID = cursusUser.Cursus.ID
+	}
+	session := findProjectSession(detail.ProjectSessions, campusID, cursusID)
+	if session != nil && !session.IsSubscriptable {
+		return fmt.Errorf("registration to %s is closed at your campus", project.Name)
+	}
+	rules, rulesErr := fetchRegisterRules(ctx, session, retry)
+
+	if !yes {
+		confirmed, err := confirmRegistration(project.Name, retry, rules, rulesErr)
+		if err != nil || !confirmed {
+			if err == nil {
+				fmt.Println("Not registered.")
+			}
+			return err
+		}
+	}
+
+	registration, err := client.CreateProjectUser(ctx, project.ID, me.ID)
+	if err != nil {
+		return fmt.Errorf("failed to register to %s: %w", project.Name, err)
+	}
+	invalidateOwnData()
+
+	if done, err := emitOutput(
+		output.Field{Key: "registered", Value: registration},
+		output.Field{Key: "retry", Value: retry},
+		output.Field{Key: "rules", Value: rules},
+	); done || err != nil {
+		return err
+	}
+	if retry {
+		fmt.Printf("🔁 Started attempt #%d of %s\n", registration.Occurrence+1, project.Name)
+	} else {
+		fmt.Printf("✅ Registered to %s\n", project.Name)
+	}
+	return nil
+}
+
+func runProjectUnregister(cmd *cobra.Command, args []string) error {
+	slug := expandProjectSlug(args[0])
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes && (GetJSONOutput() || !isTerminal(os.Stdin)) {
+		return fmt.Errorf("pass --yes to unregister when not running in a terminal")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	project, err := resolveProject(ctx, client, slug)
+	if err != nil {
+		return err
+	}
+
+	registrations, err := listOwnRegistrations(ctx, client, me.ID, project.ID)
+	if err != nil {
+		return err
+	}
+	current := currentRegistration(registrations)
+	if current == nil {
+		return fmt.Errorf("you are not registered to %s", project.Name)
+	}
+
+	if !yes {
+		confirmed, err := confirmPrompt(
+			fmt.Sprintf("Unregister from %s?", project.Name),
+			fmt.Sprintf("Your registration (%s) and any group you formed are removed.", strings.ReplaceAll(current.Status, "_", " ")))
+		if err != nil {
+			return fmt.Errorf("failed to get user confirmation: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Still registered.")
+			return nil
+		}
+	}
+
+	if err := client.DeleteProjectUser(ctx, current.ID); err != nil {
+		return fmt.Errorf("failed to unregister from %s: %w", project.Name, err)
+	}
+	invalidateOwnData()
+
+	if done, err := emitOutput(output.Field{Key: "unregistered", Value: current}); done || err != nil {
+		return err
+	}
+	fmt.Printf("🗑️  Unregistered from %s\n", project.Name)
+	return nil
+}
+
+// listOwnRegistrations returns every registration of the user to a
+// project, one per attempt
+func listOwnRegistrations(ctx context.Context, client *api.Client, userID, projectID int) ([]api.ProjectUser, error) {
+	registrations, _, err := client.ListProjectsUsers(ctx, &api.ListProjectsUsersOptions{
+		UserIDs:   []int{userID},
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get your registrations: %w", err)
+	}
+	return registrations, nil
+}
+
+// currentRegistration returns the registration that is not finished yet,
+// nil if there is none
+func currentRegistration(registrations []api.ProjectUser) *api.ProjectUser {
+	for i := range registrations {
+		if registrations[i].Status != "finished" {
+			return &registrations[i]
+		}
+	}
+	return nil
+}
+
+// checkRegistrable reports whether registering starts a retry, or why the
+// user cannot register yet
+func checkRegistrable(registrations []api.ProjectUser, now time.Time) (bool, error) {
+	if current := currentRegistration(registrations); current != nil {
+		return false, fmt.Errorf("already registered (%s)", strings.ReplaceAll(current.Status, "_", " "))
+	}
+	for _, r := range registrations {
+		if r.RetriableAt != nil && r.RetriableAt.After(now) {
+			return false, fmt.Errorf("retry possible in %s", formatTimeUntil(r.RetriableAt.Sub(now)))
+		}
+	}
+	return len(registrations) > 0, nil
+}
+
+// findProjectSession returns the session of a project for a campus and
+// cursus, falling back to a session of the cursus shared by every campus
+func findProjectSession(sessions []api.ProjectSession, campusID, cursusID int) *api.ProjectSession {
+	var shared *api.ProjectSession
+	for i := range sessions {
+		s := &sessions[i]
+		if s.CursusID != cursusID {
+			continue
+		}
+		if s.CampusID == campusID {
+			return s
+		}
+		if s.CampusID == 0 && shared == nil {
+			shared = s
+		}
+	}
+	return shared
+}
+
+// fetchRegisterRules reads the rules of a session that apply to a
+// registration. Session rules need an application token.
+func fetchRegisterRules(ctx context.Context, session *api.ProjectSession, retry bool) ([]string, error) {
+	if session == nil {
+		return nil, fmt.Errorf("no session of this project for your campus and cursus")
+	}
+	appClient, err := newAppClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	detail, err := appClient.GetProjectSessionDetail(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session detail: %w", err)
+	}
+	return describeSessionRules(detail.ProjectSessionsRules, retry), nil
+}
+
+// describeSessionRules formats the rules that apply to a registration, in
+// the order of the session
+func describeSessionRules(rules []api.ProjectSessionRule, retry bool) []string {
+	var lines []string
+	for _, rule := range rules {
+		if !registerRuleKinds[rule.Rule.Kind] && !(retry && rule.Rule.Kind == "retry") {
+			continue
+		}
+		name := rule.Rule.Name
+		if name == "" {
+			name = rule.Rule.InternalName
+		}
+		var params []string
+		for _, p := range rule.Params {
+			params = append(params, p.Value)
+		}
+		if len(params) > 0 {
+			name += ": " + strings.Join(params, ", ")
+		}
+		lines = append(lines, name)
+	}
+	return lines
+}
+
+// confirmRegistration shows the rules that apply and asks the user to
+// confirm the registration
+func confirmRegistration(projectName string, retry bool, rules []string, rulesErr error) (bool, error) {
+	title := fmt.Sprintf("Register to %s?", projectName)
+	if retry {
+		title = fmt.Sprintf("Start a retry of %s?", projectName)
+	}
+
+	var description string
+	switch {
+	case rulesErr != nil:
+		description = fmt.Sprintf("Session rules unavailable: %v", rulesErr)
+	case len(rules) == 0:
+		description = "No session rules apply."
+	default:
+		description = "Session rules:\n  • " + strings.Join(rules, "\n  • ")
+	}
+
+	confirmed, err := confirmPrompt(title, description)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user confirmation: %w", err)
+	}
+	return confirmed, nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestCheckRegistrable(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	later := now.Add(48 * time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		registrations []api.ProjectUser
+		wantRetry     bool
+		wantErr       bool
+	}{
+		{"never registered", nil, false, false},
+		{"finished attempt", []api.ProjectUser{{Status: "finished", RetriableAt: &earlier}}, true, false},
+		{"retry not yet possible", []api.ProjectUser{{Status: "finished", RetriableAt: &later}}, false, true},
+		{"already registered", []api.ProjectUser{{Status: "finished"}, {Status: "searching_a_group"}}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry, err := checkRegistrable(tt.registrations, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkRegistrable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if retry != tt.wantRetry {
+				t.Errorf("checkRegistrable() retry = %v, want %v", retry, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestFindProjectSession(t *testing.T) {
+	sessions := []api.ProjectSession{
+		{ID: 1, CampusID: 0, CursusID: 21},
+		{ID: 2, CampusID: 26, CursusID: 9},
+		{ID: 3, CampusID: 26, CursusID: 21},
+	}
+
+	tests := []struct {
+		name     string
+		campusID int
+		cursusID int
+		want     int
+	}{
+		{"campus session", 26, 21, 3},
+		{"shared session", 1, 21, 1},
+		{"no session", 1, 9, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := 0
+			if s := findProjectSession(sessions, tt.campusID, tt.cursusID); s != nil {
+				got = s.ID
+			}
+			if got != tt.want {
+				t.Errorf("findProjectSession() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeSessionRules(t *testing.T) {
+	rule := func(kind, name string, params ...string) api.ProjectSessionRule {
+		r := api.ProjectSessionRule{Rule: api.RuleDefinition{Kind: kind, Name: name}}
+		for _, p := range params {
+			r.Params = append(r.Params, api.ProjectSessionRuleParam{Value: p})
+		}
+		return r
+	}
+	rules := []api.ProjectSessionRule{
+		rule("inscription", "Quests validated", "common-core"),
+		rule("correction", "Corrections needed", "3"),
+		rule("group", "Group size", "2", "3"),
+		rule("retry", "Retry delay", "7"),
+	}
+
+	tests := []struct {
+		name  string
+		retry bool
+		want  []string
+	}{
+		{"first registration", false, []string{"Quests validated: common-core", "Group size: 2, 3"}},
+		{"retry", true, []string{"Quests validated: common-core", "Group size: 2, 3", "Retry delay: 7"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeSessionRules(rules, tt.retry); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("describeSessionRules() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// resolveProject finds the project input refers to: a project alias, a
+// slug, or a project name, typos included (see api.ResolveProjectSlug).
+// Every command taking a project identifier resolves it through here.
+func resolveProject(ctx context.Context, client *api.Client, input string) (*api.Project, error) {
+	resolution, err := client.ResolveProjectSlug(ctx, expandProjectSlug(input))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up project %q: %w", input, err)
+	}
+	project, err := resolution.Project()
+	if err != nil {
+		return nil, err
+	}
+
+	best := resolution.Candidates[0]
+	if best.Source == "index" {
+		// Index entries only hold the name and slug
+		if project, err = client.GetProject(ctx, project.ID); err != nil {
+			return nil, fmt.Errorf("failed to get project %q: %w", best.Project.Slug, err)
+		}
+	}
+
+	// Say which project a name or a typo was taken for
+	if best.Source != "slug" && !GetJSONOutput() {
+		fmt.Fprintf(os.Stderr, "🔎 Using project %s (%s) for %q\n", project.Name, project.Slug, input)
+	}
+	return project, nil
+}
+
+// cachedProjectIndex is the project index of API clients: the cached
+// 42cursus projects (see api.WithProjectIndex)
+func cachedProjectIndex(ctx context.Context, client *api.Client) ([]api.Project, error) {
+	index, err := loadProjectIndex(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]api.Project, len(index))
+	for i, p := range index {
+		projects[i] = api.Project{ID: p.ID, Name: p.Name, Slug: p.Slug}
+	}
+	return projects, nil
+}
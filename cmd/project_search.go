@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// minFuzzyQuery is the shortest query matched as scattered letters; shorter
+// ones would match most projects
+const minFuzzyQuery = 3
+
+var projectSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Find projects by name or slug, with their tier, XP and estimated time",
+	Long: `Find projects whose name or slug matches a query, to learn the slug the
+other project commands take.
+
+The cached 42cursus project list is matched loosely: exact names first,
+then prefixes, substrings and names containing the query's letters in
+order ("mnshl" finds minishell). Project aliases count too. The API's
+search[name] adds projects of other cursuses, such as the piscines.
+
+Each project is shown with its tier, the experience it gives at 100, its
+estimated time and its cursus.
+
+Examples:
+  t42 project search shell
+  t42 project search "get next"
+  t42 project search mnshl --limit 3
+  t42 project search piscine --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runProjectSearch,
+}
+
+func init() {
+	projectCmd.AddCommand(projectSearchCmd)
+
+	addLimitFlag(projectSearchCmd, 10, "Maximum number of projects to show (0 for all)")
+}
+
+// projectMatch is a project found by 't42 project search'
+type projectMatch struct {
+	projectIndexEntry
+	Source string `json:"source"` // "index" or "api"
+	Score  int    `json:"score"`
+}
+
+func runProjectSearch(cmd *cobra.Command, args []string) error {
+	limits, err := limitOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query := strings.Join(args, " ")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	matches, err := findProjects(ctx, client, query)
+	if err != nil {
+		return err
+	}
+	if limits.Limit > 0 && len(matches) > limits.Limit {
+		matches = matches[:limits.Limit]
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "projects", Value: matches},
+		output.Field{Key: "query", Value: query},
+	); done || err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No projects matching %q.\n", query)
+		return nil
+	}
+	printProjectMatches(query, matches)
+	return nil
+}
+
+// findProjects matches query against the project index and asks the API's
+// name search, best matches first. Without the index, the API's matches
+// are enough, and the other way around.
+func findProjects(ctx context.Context, client *api.Client, query string) ([]projectMatch, error) {
+	index, indexErr := loadProjectIndex(ctx, client)
+	projects, _, apiErr := client.ListProjects(ctx, &api.ListProjectsOptions{
+		PerPage: 30,
+		Search:  map[string]string{"name": query},
+	})
+	if indexErr != nil && apiErr != nil {
+		return nil, fmt.Errorf("failed to search projects: %w", apiErr)
+	}
+	if indexErr != nil {
+		warnf("project_index_unavailable", "project index unavailable, only the API was searched: %v", indexErr)
+	}
+	if apiErr != nil {
+		warnf("search_incomplete", "API project search failed, only the 42cursus index was searched: %v", apiErr)
+	}
+
+	aliased := expandProjectSlug(query)
+	seen := make(map[int]bool)
+	var matches []projectMatch
+	for _, p := range index {
+		score := projectMatchScore(query, p)
+		if aliased != query && strings.EqualFold(aliased, p.Slug) {
+			score = 5
+		}
+		if score > 0 {
+			seen[p.ID] = true
+			matches = append(matches, projectMatch{projectIndexEntry: p, Source: "index", Score: score})
+		}
+	}
+	for _, p := range projects {
+		if seen[p.ID] {
+			continue
+		}
+		seen[p.ID] = true
+		entry := newProjectIndexEntry(p)
+		// The server found it, however loosely
+		score := max(projectMatchScore(query, entry), 1)
+		matches = append(matches, projectMatch{projectIndexEntry: entry, Source: "api", Score: score})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return strings.ToLower(matches[i].Name) < strings.ToLower(matches[j].Name)
+	})
+	return matches, nil
+}
+
+// projectMatchScore rates how well a project matches query: one more than
+// the matchScore of its name or slug, or 1 when the query's letters appear
+// in order in one of them
+func projectMatchScore(query string, p projectIndexEntry) int {
+	best := max(matchScore(query, p.Name), matchScore(query, p.Slug),
+		matchScore(strings.ReplaceAll(query, " ", "_"), p.Slug))
+	if best > 0 {
+		return best + 1
+	}
+	if fuzzyContains(p.Name, query) || fuzzyContains(p.Slug, query) {
+		return 1
+	}
+	return 0
+}
+
+// fuzzyContains reports whether the letters and digits of query appear in
+// value in the same order, ignoring case
+func fuzzyContains(value, query string) bool {
+	var wanted []rune
+	for _, r := range strings.ToLower(query) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			wanted = append(wanted, r)
+		}
+	}
+	if len(wanted) < minFuzzyQuery {
+		return false
+	}
+	for _, r := range strings.ToLower(value) {
+		if r == wanted[0] {
+			wanted = wanted[1:]
+			if len(wanted) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func printProjectMatches(query string, matches []projectMatch) {
+	fmt.Printf("📁 Projects matching %q\n\n", query)
+	fmt.Printf("%-28s %-28s %4s %6s %-12s %s\n", "NAME", "SLUG", "TIER", "XP", "TIME", "CURSUS")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, m := range matches {
+		xp := "-"
+		if m.XP != nil {
+			xp = fmt.Sprint(*m.XP)
+		}
+		fmt.Printf("%-28s %-28s %4d %6s %-12s %s\n",
+			truncateString(m.Name, 28), truncateString(m.Slug, 28), m.Tier, xp,
+			truncateString(m.EstimateTime, 12), truncateString(strings.Join(m.Cursus, ", "), 30))
+	}
+	fmt.Printf("\nShow one with: t42 project show <slug>\n")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestFuzzyContains(t *testing.T) {
+	tests := []struct {
+		value, query string
+		want         bool
+	}{
+		{"minishell", "mnshl", true},
+		{"ft_transcendence", "ft trans", true},
+		{"get_next_line", "GNL", true},
+		{"minishell", "mshn", false},
+		{"minishell", "ms", false}, // too short to be matched loosely
+		{"libft", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+"/"+tt.query, func(t *testing.T) {
+			if got := fuzzyContains(tt.value, tt.query); got != tt.want {
+				t.Errorf("fuzzyContains(%q, %q) = %v, want %v", tt.value, tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectMatchScore(t *testing.T) {
+	minishell := projectIndexEntry{Name: "minishell", Slug: "42cursus-minishell"}
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"minishell", 4},
+		{"mini", 3},
+		{"shell", 2},
+		{"mnshl", 1},
+		{"webserv", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := projectMatchScore(tt.query, minishell); got != tt.want {
+				t.Errorf("projectMatchScore(%q) = %d, want %d", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProjectIndexEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		project api.Project
+		want    projectIndexEntry
+	}{
+		{
+			name: "42cursus session",
+			project: api.Project{ID: 1, Name: "minishell", Slug: "minishell", Tier: 3,
+				Cursus: []api.Cursus{{ID: 21, Name: "42cursus"}},
+				ProjectSessions: []api.ProjectSession{
+					{CursusID: 21, CampusID: 26, Difficulty: intPtr(3000), EstimateTime: "3 weeks"},
+					{CursusID: 21, Difficulty: intPtr(2814), EstimateTime: "210 hours"},
+				}},
+			want: projectIndexEntry{ID: 1, Name: "minishell", Slug: "minishell", Tier: 3,
+				XP: intPtr(2814), EstimateTime: "210 hours", Cursus: []string{"42cursus"}},
+		},
+		{
+			name: "other cursus",
+			project: api.Project{ID: 2, Name: "C Piscine Shell 00", Slug: "c-piscine-shell-00", Difficulty: 0,
+				Cursus: []api.Cursus{{ID: 9, Name: "C Piscine"}},
+				ProjectSessions: []api.ProjectSession{
+					{CursusID: 9, Difficulty: intPtr(0), EstimateTime: "1 day"},
+				}},
+			want: projectIndexEntry{ID: 2, Name: "C Piscine Shell 00", Slug: "c-piscine-shell-00",
+				XP: intPtr(0), EstimateTime: "1 day", Cursus: []string{"C Piscine"}},
+		},
+		{
+			name:    "no details",
+			project: api.Project{ID: 3, Name: "Exam", Slug: "exam"},
+			want:    projectIndexEntry{ID: 3, Name: "Exam", Slug: "exam"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newProjectIndexEntry(tt.project); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newProjectIndexEntry() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// Status of a project in the tree, from the current user's point of view
+const (
+	treeValidated  = "validated"
+	treeInProgress = "in_progress"
+	treeFailed     = "failed"
+	treeAvailable  = "available"
+	treeLocked     = "locked"
+)
+
+var projectTreeCmd = &cobra.Command{
+	Use:   "tree [slug]",
+	Short: "Show the projects of your cursus as a tree, with your progress",
+	Long: `Show the projects of a cursus rank by rank (tier), each with its
+sub-projects, such as the modules of a piscine, and mark where you stand:
+
+  ✅ validated   🚧 in progress   ❌ failed   ⬜ available   🔒 locked
+
+A rank is unlocked once you validated a project of the rank below; a
+sub-project shares the lock of its parent. With a slug, only that project
+and its sub-projects are shown.
+
+Without --cursus-id, your current cursus is used.
+
+Examples:
+  t42 project tree
+  t42 project tree cpp-modules
+  t42 project tree --cursus-id 9
+  t42 project tree --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectTree,
+}
+
+func init() {
+	projectCmd.AddCommand(projectTreeCmd)
+
+	projectTreeCmd.Flags().Int("cursus-id", 0, "Cursus ID (default: your current cursus)")
+}
+
+// projectNode is a project in the tree and its sub-projects
+type projectNode struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Slug     string        `json:"slug"`
+	Tier     int           `json:"tier"`
+	Status   string        `json:"status"`
+	Children []projectNode `json:"children,omitempty"`
+}
+
+func runProjectTree(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	cursusName := fmt.Sprintf("cursus %d", cursusID)
+	if cursusID == 0 {
+		cu := findCursusUser(me.CursusUsers, 0)
+		if cu == nil {
+			return fmt.Errorf("you have no cursus - use --cursus-id")
+		}
+		cursusID, cursusName = cu.Cursus.ID, cu.Cursus.Name
+	} else if cu := findCursusUser(me.CursusUsers, cursusID); cu != nil {
+		cursusName = cu.Cursus.Name
+	}
+
+	projects, err := client.ProjectsPaginator(ctx, &api.ListProjectsOptions{CursusID: cursusID}).All()
+	if err != nil {
+		return fmt.Errorf("failed to list the projects of %s: %w", cursusName, err)
+	}
+	tree := buildProjectTree(projects, projectStatuses(me.ProjectsUsers))
+
+	if len(args) == 1 {
+		project, err := resolveProject(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		node := findProjectNode(tree, project.ID)
+		if node == nil {
+			return fmt.Errorf("%s is not a project of %s", project.Name, cursusName)
+		}
+		tree = []projectNode{*node}
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "cursus_id", Value: cursusID},
+		output.Field{Key: "cursus", Value: cursusName},
+		output.Field{Key: "projects", Value: tree},
+	); done || err != nil {
+		return err
+	}
+
+	printProjectTree(cursusName, tree)
+	return nil
+}
+
+// projectStatuses returns the status of each project a user registered to,
+// by project ID; the latest registration wins
+func projectStatuses(projectUsers []api.ProjectUser) map[int]string {
+	statuses := make(map[int]string)
+	latest := make(map[int]api.ProjectUser)
+	for _, pu := range projectUsers {
+		if prev, ok := latest[pu.Project.ID]; ok && prev.UpdatedAt.After(pu.UpdatedAt) {
+			continue
+		}
+		latest[pu.Project.ID] = pu
+	}
+	for id, pu := range latest {
+		switch {
+		case pu.Validated != nil && *pu.Validated:
+			statuses[id] = treeValidated
+		case pu.Status == "finished":
+			statuses[id] = treeFailed
+		default:
+			statuses[id] = treeInProgress
+		}
+	}
+	return statuses
+}
+
+// buildProjectTree arranges the projects of a cursus under their parents,
+// sorted by tier, then name, and gives each a status from statuses. A
+// project without a status is available when its rank is unlocked: rank 0,
+// or the rank after one with a validated project.
+func buildProjectTree(projects []api.Project, statuses map[int]string) []projectNode {
+	byID := make(map[int]api.Project, len(projects))
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+	childIDs := make(map[int][]int)
+	isChild := make(map[int]bool)
+	addChild := func(parent, child int) {
+		if !isChild[child] {
+			isChild[child] = true
+			childIDs[parent] = append(childIDs[parent], child)
+		}
+	}
+	for _, p := range projects {
+		if p.Parent != nil {
+			if _, ok := byID[p.Parent.ID]; ok {
+				addChild(p.Parent.ID, p.ID)
+			}
+		}
+		for _, c := range p.Children {
+			if _, ok := byID[c.ID]; !ok {
+				// Sub-projects missing from the cursus list still show
+				byID[c.ID] = api.Project{ID: c.ID, Name: c.Name, Slug: c.Slug, Tier: p.Tier}
+			}
+			addChild(p.ID, c.ID)
+		}
+	}
+
+	unlocked := map[int]bool{0: true}
+	for id, status := range statuses {
+		if p, ok := byID[id]; ok && status == treeValidated {
+			unlocked[p.Tier+1] = true
+		}
+	}
+
+	var build func(id int, locked bool) projectNode
+	build = func(id int, locked bool) projectNode {
+		p := byID[id]
+		node := projectNode{ID: p.ID, Name: p.Name, Slug: p.Slug, Tier: p.Tier, Status: statuses[p.ID]}
+		if node.Status == "" {
+			node.Status = treeAvailable
+			if locked {
+				node.Status = treeLocked
+			}
+		}
+		for _, child := range childIDs[id] {
+			node.Children = append(node.Children, build(child, locked))
+		}
+		sortProjectNodes(node.Children)
+		return node
+	}
+
+	var roots []projectNode
+	for id, p := range byID {
+		if !isChild[id] {
+			roots = append(roots, build(id, !unlocked[p.Tier]))
+		}
+	}
+	sortProjectNodes(roots)
+	return roots
+}
+
+// sortProjectNodes sorts nodes by tier, then name
+func sortProjectNodes(nodes []projectNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Tier != nodes[j].Tier {
+			return nodes[i].Tier < nodes[j].Tier
+		}
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+}
+
+// findProjectNode returns the node of a project anywhere in the tree
+func findProjectNode(nodes []projectNode, id int) *projectNode {
+	for i := range nodes {
+		if nodes[i].ID == id {
+			return &nodes[i]
+		}
+		if found := findProjectNode(nodes[i].Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// projectTreeIcon returns the marker of a project status
+func projectTreeIcon(status string) string {
+	switch status {
+	case treeValidated:
+		return "✅"
+	case treeInProgress:
+		return "🚧"
+	case treeFailed:
+		return "❌"
+	case treeLocked:
+		return "🔒"
+	default:
+		return "⬜"
+	}
+}
+
+func printProjectTree(cursus string, roots []projectNode) {
+	fmt.Printf("🌳 %s\n", cursus)
+	if len(roots) == 0 {
+		fmt.Println("\nNo projects.")
+		return
+	}
+
+	counts := make(map[string]int)
+	for i, root := range roots {
+		if i == 0 || roots[i-1].Tier != root.Tier {
+			fmt.Printf("\nRank %02d\n", root.Tier)
+		}
+		last := i+1 == len(roots) || roots[i+1].Tier != root.Tier
+		printProjectNode(root, "", last, counts)
+	}
+	fmt.Printf("\n%d validated, %d in progress, %d failed, %d available, %d locked\n",
+		counts[treeValidated], counts[treeInProgress], counts[treeFailed], counts[treeAvailable], counts[treeLocked])
+}
+
+// printProjectNode prints a node and its children with tree branches,
+// counting the statuses
+func printProjectNode(node projectNode, prefix string, last bool, counts map[string]int) {
+	branch, indent := "├── ", "│   "
+	if last {
+		branch, indent = "└── ", "    "
+	}
+	counts[node.Status]++
+	fmt.Printf("%s%s%s %s\n", prefix, branch, projectTreeIcon(node.Status), node.Name)
+	for i, child := range node.Children {
+		printProjectNode(child, prefix+indent, i+1 == len(node.Children), counts)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestProjectStatuses(t *testing.T) {
+	yes, no := true, false
+	old := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	projectUsers := []api.ProjectUser{
+		{Project: api.Project{ID: 1}, Status: "finished", Validated: &yes},
+		{Project: api.Project{ID: 2}, Status: "in_progress"},
+		{Project: api.Project{ID: 3}, Status: "finished", Validated: &no, UpdatedAt: old},
+		{Project: api.Project{ID: 3}, Status: "finished", Validated: &yes, UpdatedAt: old.Add(time.Hour)},
+		{Project: api.Project{ID: 4}, Status: "finished", Validated: &no},
+	}
+
+	got := projectStatuses(projectUsers)
+	want := map[int]string{1: treeValidated, 2: treeInProgress, 3: treeValidated, 4: treeFailed}
+	for id, status := range want {
+		if got[id] != status {
+			t.Errorf("status of project %d = %q, want %q", id, got[id], status)
+		}
+	}
+}
+
+func TestBuildProjectTree(t *testing.T) {
+	cppModules := api.Project{ID: 10, Name: "CPP Modules", Tier: 4,
+		Children: []api.Project{{ID: 12, Name: "CPP Module 01"}}}
+	projects := []api.Project{
+		{ID: 1, Name: "Libft", Tier: 0},
+		{ID: 2, Name: "get_next_line", Tier: 1},
+		{ID: 3, Name: "ft_printf", Tier: 1},
+		{ID: 4, Name: "so_long", Tier: 2},
+		cppModules,
+		{ID: 11, Name: "CPP Module 00", Tier: 4, Parent: &api.Project{ID: 10}},
+	}
+	statuses := map[int]string{1: treeValidated, 2: treeInProgress}
+
+	tree := buildProjectTree(projects, statuses)
+
+	var names []string
+	for _, n := range tree {
+		names = append(names, n.Name)
+	}
+	wantNames := []string{"Libft", "ft_printf", "get_next_line", "so_long", "CPP Modules"}
+	if len(names) != len(wantNames) {
+		t.Fatalf("roots = %v, want %v", names, wantNames)
+	}
+	for i := range wantNames {
+		if names[i] != wantNames[i] {
+			t.Errorf("root %d = %s, want %s", i, names[i], wantNames[i])
+		}
+	}
+
+	wantStatus := map[int]string{
+		1:  treeValidated,
+		2:  treeInProgress,
+		3:  treeAvailable, // rank 1 is unlocked by Libft
+		4:  treeLocked,    // nothing validated in rank 1
+		10: treeLocked,
+		11: treeLocked, // like its parent
+		12: treeLocked,
+	}
+	for id, status := range wantStatus {
+		node := findProjectNode(tree, id)
+		if node == nil {
+			t.Errorf("project %d missing from the tree", id)
+			continue
+		}
+		if node.Status != status {
+			t.Errorf("status of %s = %q, want %q", node.Name, node.Status, status)
+		}
+	}
+
+	cpp := findProjectNode(tree, 10)
+	if len(cpp.Children) != 2 || cpp.Children[0].Name != "CPP Module 00" || cpp.Children[1].Name != "CPP Module 01" {
+		t.Errorf("children of CPP Modules = %+v", cpp.Children)
+	}
+}
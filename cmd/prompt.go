@@ -0,0 +1,10 @@
+package cmd
+
+// selection describes a prompt choosing one of several options
+type selection struct {
+	Title       string
+	Description string
+	Options     []string
+	Default     int // index of the option selected at first
+	Height      int // options shown at once, 0 for all of them
+}
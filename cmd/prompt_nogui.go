@@ -0,0 +1,29 @@
+//go:build nogui
+
+package cmd
+
+import "errors"
+
+// promptsAvailable reports whether this build can prompt interactively;
+// builds with -tags nogui leave out the interactive stack
+const promptsAvailable = false
+
+// errPromptsUnavailable is returned by every prompt of a nogui build, where
+// flags and environment variables have to supply the answers
+var errPromptsUnavailable = errors.New("interactive prompts are not available in this build (built with -tags nogui) - pass the answer with flags such as --yes or --force")
+
+func confirmPrompt(title, description string) (bool, error) {
+	return false, errPromptsUnavailable
+}
+
+func selectPrompt(s selection) (int, error) {
+	return 0, errPromptsUnavailable
+}
+
+func multiSelectPrompt(title string, labels []string) ([]int, error) {
+	return nil, errPromptsUnavailable
+}
+
+func passwordPrompt(title string, validate func(string) error) (string, error) {
+	return "", errPromptsUnavailable
+}
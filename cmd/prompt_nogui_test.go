@@ -0,0 +1,42 @@
+//go:build nogui
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPromptsUnavailable(t *testing.T) {
+	tests := []struct {
+		name   string
+		prompt func() error
+	}{
+		{"confirm", func() error { _, err := confirmPrompt("Sure?", ""); return err }},
+		{"select", func() error { _, err := selectPrompt(selection{Title: "Pick", Options: []string{"a"}}); return err }},
+		{"multi-select", func() error { _, err := multiSelectPrompt("Pick", []string{"a"}); return err }},
+		{"password", func() error { _, err := passwordPrompt("Secret", nil); return err }},
+		{"browser", func() error { return runBrowser(browseTable{}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.prompt(); !errors.Is(err, errPromptsUnavailable) {
+				t.Errorf("error = %v, want errPromptsUnavailable", err)
+			}
+		})
+	}
+}
+
+func TestUseBrowserWithoutPrompts(t *testing.T) {
+	cmd := &cobra.Command{Use: "list"}
+	addInteractiveFlag(cmd)
+	if err := cmd.ParseFlags([]string{"--interactive"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if browse, err := useBrowser(cmd); browse || err == nil {
+		t.Errorf("useBrowser() = %v, %v; want an error", browse, err)
+	}
+}
@@ -0,0 +1,66 @@
+//go:build !nogui
+
+package cmd
+
+import "github.com/charmbracelet/huh"
+
+// promptsAvailable reports whether this build can prompt interactively;
+// builds with -tags nogui leave out the interactive stack
+const promptsAvailable = true
+
+// runForm runs huh fields as a single form, in accessible mode when enabled
+func runForm(fields ...huh.Field) error {
+	return huh.NewForm(huh.NewGroup(fields...)).
+		WithShowHelp(false).
+		WithAccessible(GetAccessible()).
+		Run()
+}
+
+// confirmPrompt asks a yes/no question
+func confirmPrompt(title, description string) (bool, error) {
+	confirmed := false
+	err := runForm(huh.NewConfirm().Title(title).Description(description).Value(&confirmed))
+	return confirmed, err
+}
+
+// selectPrompt asks for one option and returns its index
+func selectPrompt(s selection) (int, error) {
+	options := make([]huh.Option[int], len(s.Options))
+	for i, label := range s.Options {
+		options[i] = huh.NewOption(label, i)
+	}
+	choice := s.Default
+	field := huh.NewSelect[int]().Title(s.Title).Options(options...).Value(&choice)
+	if s.Description != "" {
+		field = field.Description(s.Description)
+	}
+	if s.Height > 0 {
+		field = field.Height(s.Height)
+	}
+	err := runForm(field)
+	return choice, err
+}
+
+// multiSelectPrompt asks for any number of options, all of them selected
+// at first, and returns the indices of the ones kept
+func multiSelectPrompt(title string, labels []string) ([]int, error) {
+	options := make([]huh.Option[int], len(labels))
+	for i, label := range labels {
+		options[i] = huh.NewOption(label, i).Selected(true)
+	}
+	var chosen []int
+	err := runForm(huh.NewMultiSelect[int]().Title(title).Options(options...).Value(&chosen))
+	return chosen, err
+}
+
+// passwordPrompt asks for a secret without echoing it; validate, when not
+// nil, rejects answers until it returns nil
+func passwordPrompt(title string, validate func(string) error) (string, error) {
+	var value string
+	field := huh.NewInput().Title(title).EchoMode(huh.EchoModePassword).Value(&value)
+	if validate != nil {
+		field = field.Validate(validate)
+	}
+	err := runForm(field)
+	return value, err
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// mutatingCommands are the commands refused in read-only mode. Requests
+// they would send are refused by the client anyway; failing early keeps
+// them from prompting or doing local work first.
+var mutatingCommands = map[string]bool{
+	"event subscribe":    true,
+	"event unsubscribe":  true,
+	"project cleanup":    true,
+	"project register":   true,
+	"project unregister": true,
+	"slot create":        true,
+	"slot delete":        true,
+}
+
+// readOnlyEnabled reports whether read-only mode is on, either via
+// T42_READONLY or the readonly config setting. The environment can only
+// turn it on, so a script cannot lift the setting of the config file.
+func readOnlyEnabled() bool {
+	if env := os.Getenv("T42_READONLY"); env == "1" || env == "true" {
+		return true
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return false
+	}
+	return cfg.ReadOnly
+}
+
+// readOnlyOptions returns the client options of read-only mode
+func readOnlyOptions() []api.ClientOption {
+	if !readOnlyEnabled() {
+		return nil
+	}
+	return []api.ClientOption{api.WithReadOnly()}
+}
+
+// checkReadOnly refuses mutating commands in read-only mode
+func checkReadOnly(cmd *cobra.Command) error {
+	if !mutatingCommands[usageCommandPath(cmd)] || !readOnlyEnabled() {
+		return nil
+	}
+	return fmt.Errorf("'t42 %s' changes data and is disabled in read-only mode (readonly in the config file)", usageCommandPath(cmd))
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		readonly string
+		config   bool
+		cmd      *cobra.Command
+		wantErr  bool
+	}{
+		{"mutating command", "1", false, slotCreateCmd, true},
+		{"event subscribe", "1", false, eventSubscribeCmd, true},
+		{"read command", "1", false, slotListCmd, false},
+		{"mutating command when off", "0", false, projectCleanupCmd, false},
+		{"config setting", "", true, slotDeleteCmd, true},
+		{"environment cannot lift config setting", "0", true, slotCreateCmd, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+			cfg := config.DefaultConfig()
+			cfg.ReadOnly = tt.config
+			if err := config.SaveConfig(cfg); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv("T42_READONLY", tt.readonly)
+			if err := checkReadOnly(tt.cmd); (err != nil) != tt.wantErr {
+				t.Errorf("checkReadOnly(%q) error = %v, wantErr %v", tt.cmd.CommandPath(), err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMutatingCommandsExist(t *testing.T) {
+	for path := range mutatingCommands {
+		target, _, err := rootCmd.Find(strings.Fields(path))
+		if err != nil || usageCommandPath(target) != path {
+			t.Errorf("mutating command %q does not exist", path)
+		}
+	}
+}
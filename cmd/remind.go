@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+	"github.com/naokiiida/t42-cli/internal/remind"
+)
+
+// remindJobName identifies the scheduled 't42 notify check' job
+const remindJobName = "notify"
+
+var remindCmd = &cobra.Command{
+	Use:   "remind",
+	Short: "Schedule daily reminder checks",
+	Long: `Schedule 't42 notify check' to run daily from your system scheduler, so
+blackhole and evaluation reminders arrive without keeping t42 running.
+
+The scheduler is picked automatically: a systemd user timer on Linux,
+a launchd agent on macOS, or a crontab entry elsewhere.`,
+}
+
+var remindInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Run 't42 notify check' daily",
+	Long: `Install a daily job running 't42 notify check' in your system scheduler.
+Installing again replaces the job, e.g. to change the time.
+
+Examples:
+  t42 remind install
+  t42 remind install --at 19:30
+  t42 remind install --scheduler cron`,
+	Args: cobra.NoArgs,
+	RunE: runRemindInstall,
+}
+
+var remindListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled reminder jobs",
+	Args:  cobra.NoArgs,
+	RunE:  runRemindList,
+}
+
+var remindRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a scheduled reminder job",
+	Args:  cobra.NoArgs,
+	RunE:  runRemindRemove,
+}
+
+func init() {
+	remindCmd.AddCommand(remindInstallCmd)
+	remindCmd.AddCommand(remindListCmd)
+	remindCmd.AddCommand(remindRemoveCmd)
+	rootCmd.AddCommand(remindCmd)
+
+	remindInstallCmd.Flags().String("at", "08:00", "Time of day to run the check (HH:MM)")
+	remindInstallCmd.Flags().String("scheduler", "", "Scheduler to use: systemd, launchd or cron (default: detected)")
+	remindRemoveCmd.Flags().String("scheduler", "", "Only remove the job from this scheduler")
+	remindRemoveCmd.Flags().String("job", remindJobName, "Job to remove: notify (the check) or digest")
+}
+
+func runRemindInstall(cmd *cobra.Command, args []string) error {
+	at, _ := cmd.Flags().GetString("at")
+	schedulerName, _ := cmd.Flags().GetString("scheduler")
+
+	hour, minute, err := remind.ParseTime(at)
+	if err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the t42 executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	env, err := remind.DefaultEnv()
+	if err != nil {
+		return err
+	}
+	var scheduler remind.Scheduler
+	if schedulerName != "" {
+		scheduler, err = remind.New(schedulerName, env)
+	} else {
+		scheduler, err = remind.Detect(runtime.GOOS, env)
+	}
+	if err != nil {
+		return err
+	}
+
+	job := remind.Job{
+		Name:    remindJobName,
+		Command: []string{executable, "notify", "check"},
+		Hour:    hour,
+		Minute:  minute,
+	}
+	location, err := scheduler.Install(job)
+	if err != nil {
+		return fmt.Errorf("failed to install reminder job: %w", err)
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "success", Value: true},
+		output.Field{Key: "scheduler", Value: scheduler.Name()},
+		output.Field{Key: "location", Value: location},
+		output.Field{Key: "at", Value: fmt.Sprintf("%02d:%02d", hour, minute)},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("⏰ Scheduled 't42 notify check' daily at %02d:%02d (%s: %s)\n", hour, minute, scheduler.Name(), location)
+	if scheduler.Name() == "cron" {
+		fmt.Println("   Note: cron jobs may not reach your desktop session; the check still records and prints reminders.")
+	}
+	return nil
+}
+
+func runRemindList(cmd *cobra.Command, args []string) error {
+	env, err := remind.DefaultEnv()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]remind.Entry, 0)
+	for _, scheduler := range remind.Available(runtime.GOOS, env) {
+		found, err := scheduler.List()
+		if err != nil {
+			if GetVerbose() {
+				fmt.Fprintf(os.Stderr, "Failed to list %s jobs: %v\n", scheduler.Name(), err)
+			}
+			continue
+		}
+		entries = append(entries, found...)
+	}
+
+	if done, err := emitOutput(output.Field{Key: "jobs", Value: entries}); done || err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No reminder jobs scheduled. Use 't42 remind install' to add one.")
+		return nil
+	}
+	for _, entry := range entries {
+		fmt.Printf("⏰ %-8s %-10s %s\n", entry.Scheduler, entry.Name, entry.Schedule)
+		fmt.Printf("   %s\n", entry.Command)
+		if entry.Location != "crontab" {
+			fmt.Printf("   %s\n", entry.Location)
+		}
+	}
+	return nil
+}
+
+func runRemindRemove(cmd *cobra.Command, args []string) error {
+	schedulerName, _ := cmd.Flags().GetString("scheduler")
+	jobName, _ := cmd.Flags().GetString("job")
+	if jobName != remindJobName && jobName != digestJobName {
+		return fmt.Errorf("invalid --job %q (use %s or %s)", jobName, remindJobName, digestJobName)
+	}
+
+	env, err := remind.DefaultEnv()
+	if err != nil {
+		return err
+	}
+	schedulers := remind.Available(runtime.GOOS, env)
+	if schedulerName != "" {
+		scheduler, err := remind.New(schedulerName, env)
+		if err != nil {
+			return err
+		}
+		schedulers = []remind.Scheduler{scheduler}
+	}
+
+	var removedFrom []string
+	for _, scheduler := range schedulers {
+		removed, err := scheduler.Remove(jobName)
+		if err != nil {
+			return fmt.Errorf("failed to remove %s job: %w", scheduler.Name(), err)
+		}
+		if removed {
+			removedFrom = append(removedFrom, scheduler.Name())
+		}
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "success", Value: true},
+		output.Field{Key: "removed_from", Value: removedFrom},
+	); done || err != nil {
+		return err
+	}
+
+	if len(removedFrom) == 0 {
+		fmt.Println("No reminder job was scheduled.")
+		return nil
+	}
+	for _, name := range removedFrom {
+		fmt.Printf("🗑️  Removed the reminder job from %s\n", name)
+	}
+	return nil
+}
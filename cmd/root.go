@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/naokiiida/t42-cli/internal/api"
 	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/output"
+	"github.com/naokiiida/t42-cli/internal/ratelimit"
 	"github.com/spf13/cobra"
 )
 
@@ -17,8 +23,19 @@ var (
 	date    = "unknown"
 
 	// Global flags
-	jsonOutput bool
-	verbose    bool
+	jsonOutput       bool
+	outputFormatFlag string
+	verbose          bool
+	accessible       bool
+	noColor          bool
+	outputProfile    string
+	noCache          bool
+	cacheTTL         time.Duration
+	asApp            bool
+
+	// ansiSupported is false on legacy Windows consoles, which print
+	// escape sequences and emoji as garbage
+	ansiSupported = true
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -38,21 +55,102 @@ Examples:
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applySelectedOutputProfile(cmd.Flags()); err != nil {
+			return err
+		}
+		if err := resolveOutputFormat(cmd); err != nil {
+			return err
+		}
+		if cacheTTL < 0 {
+			return fmt.Errorf("--cache-ttl must not be negative")
+		}
+		if err := checkReadOnly(cmd); err != nil {
+			return err
+		}
+		recordCommandUsage(cmd)
+
+		// Plain output is also the only readable output on consoles
+		// without ANSI support
+		if GetPlainOutput() {
+			return startAccessibleOutput()
+		}
+		return nil
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return checkOutSink(usageCommandPath(cmd))
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	ansiSupported = enableVirtualTerminal()
+	args := os.Args[1:]
+	if cfg, err := config.LoadConfig(); err == nil && len(cfg.Aliases) > 0 {
+		expanded, ok, err := expandAlias(args, cfg.Aliases)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if ok {
+			args = expanded
+			rootCmd.SetArgs(args)
+		}
+	}
+	// Names no command answers to may be extensions
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") && !isCommandName(args[0]) {
+		if path, ok := findExtension(args[0]); ok {
+			code, err := runExtension(path, args[1:])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			os.Exit(code)
+		}
+	}
 	err := rootCmd.Execute()
-	if err != nil {
-		os.Exit(1)
+	printWarnings(os.Stderr)
+	if GetVerbose() {
+		printCallSummary(os.Stderr, apiCalls.Summary())
+	}
+	code := 0
+	var exitErr *exitCodeError
+	switch {
+	case errors.As(err, &exitErr):
+		code = exitErr.code
+	case err != nil:
+		printAPIErrorHint(os.Stderr, err)
+		code = 1
+	}
+	stopAccessibleOutput()
+	if code != 0 {
+		os.Exit(code)
 	}
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().StringVarP(&outputFormatFlag, "output", "o", "", "Output format: table, json, yaml, csv or tsv (default: default_format from the config file)")
+	_ = rootCmd.RegisterFlagCompletionFunc("output", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		names := make([]string, len(output.Formats))
+		for i, f := range output.Formats {
+			names[i] = string(f)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.PersistentFlags().StringVarP(&jqFilter, "jq", "q", "", "Filter the JSON output with a jq expression")
+	rootCmd.PersistentFlags().StringVarP(&templateFilter, "template", "t", "", "Format the JSON output with a Go template")
+	rootCmd.PersistentFlags().StringVar(&fieldsFlag, "fields", "", "Trim the JSON output to these comma separated dotted paths, e.g. login,cursus_users.level; the columns of CSV/TSV output")
+	rootCmd.PersistentFlags().StringVar(&outFile, "out", "", "Also write the full JSON output to this file, whatever --output shows")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVar(&accessible, "accessible", false, "Screen-reader friendly output: no emoji, colors or graphics, plain prompts (or set ACCESSIBLE=1)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Plain output without colors or emoji (or set NO_COLOR=1)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Refetch instead of using cached data (the cache is still refreshed)")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "Reuse cached data up to this age for every resource, e.g. 10m (see 't42 cache')")
+	rootCmd.PersistentFlags().BoolVar(&asApp, "as-app", false, "Authenticate as your 42 application (client credentials) instead of as you; commands about yourself do not work")
+	rootCmd.PersistentFlags().StringVar(&outputProfile, "profile-output", "", "Apply a named output profile from the config file (or set T42_OUTPUT_PROFILE)")
 
 	// Version flag (for convenience)
 	var versionFlag bool
@@ -80,19 +178,23 @@ var versionCmd = &cobra.Command{
 	Short: "Print the version information",
 	Long:  `Print the version, commit hash, and build date of t42-cli.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if jsonOutput {
-			fmt.Printf(`{"version":"%s","commit":"%s","date":"%s"}%s`, version, commit, date, "\n")
-		} else {
-			fmt.Printf("t42-cli version %s\n", version)
-			fmt.Printf("Commit: %s\n", commit)
-			fmt.Printf("Built: %s\n", date)
+		if done, _ := emitOutput(
+			output.Field{Key: "version", Value: version},
+			output.Field{Key: "commit", Value: commit},
+			output.Field{Key: "date", Value: date},
+		); done {
+			return
 		}
+		fmt.Printf("t42-cli version %s\n", version)
+		fmt.Printf("Commit: %s\n", commit)
+		fmt.Printf("Built: %s\n", date)
 	},
 }
 
-// GetJSONOutput returns the current state of the json flag
+// GetJSONOutput reports whether the command should write structured output
+// instead of tables: --json, or --output json, yaml, csv or tsv
 func GetJSONOutput() bool {
-	return jsonOutput
+	return GetOutputFormat() != output.FormatTable
 }
 
 // GetVerbose returns the current state of the verbose flag
@@ -100,8 +202,13 @@ func GetVerbose() bool {
 	return verbose
 }
 
-// NewAPIClient creates a new API client with automatic token refresh
+// NewAPIClient creates a new API client with automatic token refresh. With
+// --as-app it is authenticated as the application instead.
 func NewAPIClient() (*api.Client, error) {
+	if asApp {
+		return newAppClient(context.Background())
+	}
+
 	// Load credentials
 	credentials, err := config.LoadCredentials()
 	if err != nil {
@@ -120,9 +227,7 @@ func NewAPIClient() (*api.Client, error) {
 		}
 	}
 
-	// Create client with token refresher callback
-	client := api.NewClient(
-		credentials.AccessToken,
+	options := []api.ClientOption{
 		api.WithTokenRefresher(func() (string, error) {
 			// This callback will be called when the API returns 401
 			if err := RefreshTokenIfNeeded(); err != nil {
@@ -137,11 +242,125 @@ func NewAPIClient() (*api.Client, error) {
 
 			return newCreds.AccessToken, nil
 		}),
-	)
+	}
+
+	limiter, err := newRateLimiter()
+	if err != nil {
+		return nil, err
+	}
+	chaos, err := chaosOptions()
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, chaos...)
+	options = append(options, readOnlyOptions()...)
+	options = append(options, retryOptions()...)
+	options = append(options, warningOptions()...)
+	options = append(options, api.WithRateLimiter(limiter), api.WithCallRecorder(apiCalls), api.WithProjectIndex(cachedProjectIndex))
+	options = append(options, debugHTTPOptions()...)
+
+	// Serve rarely changing responses (campuses, cursuses, projects) from the cache
+	if store, err := openCache(); err == nil {
+		options = append(options, api.WithResponseCache(store, responseCachePolicy(store)))
+	} else if GetVerbose() {
+		fmt.Fprintf(os.Stderr, "Response cache disabled: %v\n", err)
+	}
+
+	// Create client with token refresher callback
+	client := api.NewClient(credentials.AccessToken, options...)
 
 	return client, nil
 }
 
+// newAppClient creates a client authenticated as the application with the
+// client_credentials grant. Application tokens can read data user tokens
+// cannot, such as project session rules and the correctors of evaluations.
+// The token saved by 't42 auth login --client-credentials' is reused, and
+// renewed when it expires.
+func newAppClient(ctx context.Context) (*api.Client, error) {
+	secrets := lazyAppSecrets(loadAppSecrets)
+	appToken, err := appAccessToken(ctx, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app token: %w", err)
+	}
+
+	limiter, err := newRateLimiter()
+	if err != nil {
+		return nil, err
+	}
+	chaos, err := chaosOptions()
+	if err != nil {
+		return nil, err
+	}
+	options := append(chaos, readOnlyOptions()...)
+	options = append(options, retryOptions()...)
+	options = append(options, warningOptions()...)
+	options = append(options,
+		api.WithTokenRefresher(func() (string, error) {
+			return renewAppToken(ctx, secrets)
+		}),
+		api.WithRateLimiter(limiter),
+		api.WithCallRecorder(apiCalls),
+		api.WithProjectIndex(cachedProjectIndex),
+	)
+	options = append(options, debugHTTPOptions()...)
+	return api.NewClient(appToken, options...), nil
+}
+
+// chaosWarning makes the T42_CHAOS warning appear once per run
+var chaosWarning sync.Once
+
+// chaosOptions returns the failure injection set by the hidden T42_CHAOS
+// variable, which maintainers and CI use to check that retries, rate
+// limiting and resumable scans hold up against a flaky API
+func chaosOptions() ([]api.ClientOption, error) {
+	value := os.Getenv(api.ChaosEnvVar)
+	if value == "" {
+		return nil, nil
+	}
+	chaos, err := api.ParseChaos(value)
+	if err != nil {
+		return nil, err
+	}
+	chaosWarning.Do(func() {
+		fmt.Fprintf(os.Stderr, "⚠️  %s=%s: %.0f%% of API requests fail on purpose\n", api.ChaosEnvVar, value, chaos.Rate*100)
+	})
+	return []api.ClientOption{api.WithChaos(chaos)}, nil
+}
+
+// newRateLimiter builds the client's rate limiter from the rate_limit
+// setting. With the shared rate limit on, the per-second budget lives in
+// the state directory so that concurrent t42 processes share it.
+func newRateLimiter() (ratelimit.Limiter, error) {
+	var limits config.RateLimit
+	if cfg, err := config.LoadConfig(); err == nil {
+		limits = cfg.RateLimit
+	}
+
+	var perSecond ratelimit.Limiter = ratelimit.NewBucket(limits.PerSecond, ratelimit.DefaultBurst)
+	if sharedRateLimitEnabled() {
+		stateDir, err := config.GetStateDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state directory: %w", err)
+		}
+		perSecond = ratelimit.NewSharedBucket(stateDir, limits.PerSecond, ratelimit.DefaultBurst)
+	}
+	return ratelimit.Chain(perSecond, ratelimit.NewHourlyBucket(limits.PerHour)), nil
+}
+
+// sharedRateLimitEnabled reports whether cross-process rate limiting is on,
+// either via T42_SHARED_RATE_LIMIT or the shared_rate_limit config setting
+func sharedRateLimitEnabled() bool {
+	if env := os.Getenv("T42_SHARED_RATE_LIMIT"); env != "" {
+		return env == "1" || env == "true"
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return false
+	}
+	return cfg.SharedRateLimit
+}
+
 // RequireAuth ensures the user is authenticated and returns an API client
 func RequireAuth(ctx context.Context) (*api.Client, error) {
 	client, err := NewAPIClient()
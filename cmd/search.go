@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/campus"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+const (
+	// projectIndexTTL is how long the local project index is reused;
+	// projects are added a few times a year
+	projectIndexTTL = 7 * 24 * time.Hour
+
+	// projectIndexCacheKey is the cache key of the project index; v2
+	// entries carry the tier, experience, estimated time and cursus
+	projectIndexCacheKey = "project/index/v2"
+
+	// projectIndexCursusID is the cursus whose projects are indexed (42cursus)
+	projectIndexCursusID = 21
+
+	// Intra pages opened by 't42 search --open'
+	userPageURLFormat    = "https://profile.intra.42.fr/users/%s"
+	projectPageURLFormat = "https://projects.intra.42.fr/projects/%s"
+)
+
+// searchTypes are the resource types searched, in display order
+var searchTypes = []string{"user", "project", "campus"}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search users, projects and campuses at once",
+	Long: `Search users (login and display name), projects (name and slug) and
+campuses (name, city and country) at the same time and show the best
+matches of each type.
+
+Projects and campuses are matched against local indexes (the cached
+42cursus project list, project aliases and the campus table) and the
+live API is only asked when they have no match. Users are always searched
+live.
+
+Each result is numbered: --open N opens result N on the intra, and --pick
+lets you choose a result and show or open it.
+
+Examples:
+  t42 search tokyo
+  t42 search minishell --type project
+  t42 search jdoe --open 1
+  t42 search philo --pick`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	addLimitFlag(searchCmd, 5, "Maximum results per type")
+	searchCmd.Flags().StringSlice("type", nil, "Only search these types: user, project, campus")
+	searchCmd.Flags().Int("open", 0, "Open result N in the browser")
+	searchCmd.Flags().Bool("pick", false, "Choose a result interactively to show or open")
+}
+
+// searchResult is one match of a search
+type searchResult struct {
+	Type   string `json:"type"`
+	ID     int    `json:"id"`
+	Key    string `json:"key"` // login, slug or campus ID, as accepted by the show commands
+	Title  string `json:"title"`
+	Detail string `json:"detail,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Source string `json:"source"` // "index" or "api"
+
+	score int
+}
+
+// searchGroup holds the results of one type
+type searchGroup struct {
+	Type    string         `json:"type"`
+	Results []searchResult `json:"results"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// projectIndexEntry is a project in the local project index
+type projectIndexEntry struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Slug         string   `json:"slug"`
+	Tier         int      `json:"tier"`
+	XP           *int     `json:"xp"` // experience at 100, nil when unknown
+	EstimateTime string   `json:"estimate_time,omitempty"`
+	Cursus       []string `json:"cursus,omitempty"`
+}
+
+// newProjectIndexEntry returns the index entry of a project, with the
+// experience and estimated time of its generic session in 42cursus, or
+// else in its first cursus
+func newProjectIndexEntry(p api.Project) projectIndexEntry {
+	entry := projectIndexEntry{ID: p.ID, Name: p.Name, Slug: p.Slug, Tier: p.Tier}
+	cursusID := projectIndexCursusID
+	inIndexCursus := false
+	for _, c := range p.Cursus {
+		entry.Cursus = append(entry.Cursus, c.Name)
+		inIndexCursus = inIndexCursus || c.ID == projectIndexCursusID
+	}
+	if !inIndexCursus && len(p.Cursus) > 0 {
+		cursusID = p.Cursus[0].ID
+	}
+	entry.XP = projectXP(&p, 0, cursusID)
+	for _, session := range p.ProjectSessions {
+		if session.EstimateTime == "" {
+			continue
+		}
+		if entry.EstimateTime == "" || (session.CursusID == cursusID && session.CampusID == 0) {
+			entry.EstimateTime = session.EstimateTime
+		}
+	}
+	return entry
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	limits, err := limitOptions(cmd)
+	if err != nil {
+		return err
+	}
+	limit := limits.Limit
+	types, _ := cmd.Flags().GetStringSlice("type")
+	openN, _ := cmd.Flags().GetInt("open")
+	pick, _ := cmd.Flags().GetBool("pick")
+
+	query := strings.TrimSpace(strings.Join(args, " "))
+	if query == "" {
+		return fmt.Errorf("search query must not be empty")
+	}
+	if limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	if len(types) == 0 {
+		types = searchTypes
+	}
+	for _, t := range types {
+		if !containsString(searchTypes, t) {
+			return fmt.Errorf("unknown type %q (use user, project or campus)", t)
+		}
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	searchers := map[string]func() ([]searchResult, error){
+		"user":    func() ([]searchResult, error) { return searchUsers(ctx, client, query) },
+		"project": func() ([]searchResult, error) { return searchProjects(ctx, client, query) },
+		"campus":  func() ([]searchResult, error) { return searchCampuses(ctx, client, query) },
+	}
+
+	// Search every type concurrently; one failing type does not hide the others
+	groups := make([]searchGroup, 0, len(types))
+	for _, t := range searchTypes {
+		if containsString(types, t) {
+			groups = append(groups, searchGroup{Type: t})
+		}
+	}
+	var wg sync.WaitGroup
+	for i := range groups {
+		wg.Add(1)
+		go func(group *searchGroup) {
+			defer wg.Done()
+			results, err := searchers[group.Type]()
+			if err != nil {
+				group.Error = err.Error()
+				return
+			}
+			group.Results = topResults(results, limit)
+		}(&groups[i])
+	}
+	wg.Wait()
+
+	numbered := flattenSearchGroups(groups)
+
+	if openN > 0 {
+		if openN > len(numbered) {
+			return fmt.Errorf("--open %d: only %d results", openN, len(numbered))
+		}
+		return openSearchResult(numbered[openN-1])
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "query", Value: query},
+		output.Field{Key: "groups", Value: groups},
+	); done || err != nil {
+		return err
+	}
+
+	printSearchGroups(groups)
+	if len(numbered) == 0 {
+		return nil
+	}
+	if pick {
+		return pickSearchResult(cmd, numbered)
+	}
+	fmt.Println("\nUse --open N to open a result, or --pick to choose one.")
+	return nil
+}
+
+// searchUsers searches logins and display names through the API
+func searchUsers(ctx context.Context, client *api.Client, query string) ([]searchResult, error) {
+	term := strings.ToLower(query)
+	users, _, err := client.ListUsers(ctx, &api.ListUsersOptions{
+		PerPage: 30,
+		Search:  map[string]string{"login": term},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	// Display names are searched as-is; not every API version supports
+	// it, so a failure only loses these matches
+	byName, _, nameErr := client.ListUsers(ctx, &api.ListUsersOptions{
+		PerPage: 30,
+		Search:  map[string]string{"displayname": query},
+	})
+	if nameErr == nil {
+		users = append(users, byName...)
+	} else {
+		warnf("search_incomplete", "display name search failed, only logins were searched: %v", nameErr)
+	}
+
+	seen := make(map[int]bool)
+	var results []searchResult
+	for _, u := range users {
+		if seen[u.ID] {
+			continue
+		}
+		seen[u.ID] = true
+		results = append(results, searchResult{
+			Type:   "user",
+			ID:     u.ID,
+			Key:    u.Login,
+			Title:  u.Login,
+			Detail: u.DisplayName,
+			URL:    fmt.Sprintf(userPageURLFormat, u.Login),
+			Source: "api",
+			score:  max(matchScore(query, u.Login), matchScore(query, u.DisplayName)),
+		})
+	}
+	return results, nil
+}
+
+// searchProjects searches the local project index and aliases, and the API
+// when they have no match
+func searchProjects(ctx context.Context, client *api.Client, query string) ([]searchResult, error) {
+	index, indexErr := loadProjectIndex(ctx, client)
+	if indexErr != nil {
+		warnf("project_index_unavailable", "project index unavailable, searching the API instead: %v", indexErr)
+	}
+
+	aliased := expandProjectSlug(query)
+	results := matchProjectIndex(index, query, aliased)
+	if len(results) > 0 {
+		return results, nil
+	}
+
+	projects, _, err := client.ListProjects(ctx, &api.ListProjectsOptions{
+		PerPage: 30,
+		Search:  map[string]string{"name": query},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search projects: %w", err)
+	}
+	for _, p := range projects {
+		results = append(results, projectSearchResult(newProjectIndexEntry(p), "api",
+			max(matchScore(query, p.Name), matchScore(query, p.Slug))))
+	}
+	return results, nil
+}
+
+// matchProjectIndex returns the indexed projects matching query; a project
+// named by an alias (aliased != query) ranks first
+func matchProjectIndex(index []projectIndexEntry, query, aliased string) []searchResult {
+	var results []searchResult
+	for _, p := range index {
+		score := max(matchScore(query, p.Name), matchScore(query, p.Slug),
+			matchScore(strings.ReplaceAll(query, " ", "_"), p.Slug))
+		if aliased != query && strings.EqualFold(aliased, p.Slug) {
+			score = 4
+		}
+		if score > 0 {
+			results = append(results, projectSearchResult(p, "index", score))
+		}
+	}
+	return results
+}
+
+func projectSearchResult(p projectIndexEntry, source string, score int) searchResult {
+	return searchResult{
+		Type:   "project",
+		ID:     p.ID,
+		Key:    p.Slug,
+		Title:  p.Name,
+		Detail: p.Slug,
+		URL:    fmt.Sprintf(projectPageURLFormat, p.Slug),
+		Source: source,
+		score:  score,
+	}
+}
+
+// loadProjectIndex returns the 42cursus projects, from the cache when fresh
+func loadProjectIndex(ctx context.Context, client *api.Client) ([]projectIndexEntry, error) {
+	store, err := openCache()
+	if err != nil {
+		return nil, err
+	}
+	var index []projectIndexEntry
+	if found, err := store.Get(projectIndexCacheKey, projectIndexTTL, &index); err == nil && found && len(index) > 0 {
+		return index, nil
+	}
+
+	projects, err := client.ProjectsPaginator(ctx, &api.ListProjectsOptions{CursusID: projectIndexCursusID}).All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, p := range projects {
+		index = append(index, newProjectIndexEntry(p))
+	}
+
+	if err := store.Set(projectIndexCacheKey, index); err != nil && GetVerbose() {
+		fmt.Fprintf(os.Stderr, "Failed to cache project index: %v\n", err)
+	}
+	return index, nil
+}
+
+// searchCampuses searches the cached campus table
+func searchCampuses(ctx context.Context, client *api.Client, query string) ([]searchResult, error) {
+	campuses, err := newCampusResolver(client).Campuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := campus.Search(campuses, query)
+	results := make([]searchResult, 0, len(matches))
+	for i, c := range matches {
+		detail := c.Country
+		if c.City != "" && !strings.EqualFold(c.City, c.Name) {
+			detail = c.City + ", " + c.Country
+		}
+		results = append(results, searchResult{
+			Type:   "campus",
+			ID:     c.ID,
+			Key:    strconv.Itoa(c.ID),
+			Title:  c.Name,
+			Detail: detail,
+			URL:    c.Website,
+			Source: "index",
+			score:  len(matches) - i, // already ranked
+		})
+	}
+	return results, nil
+}
+
+// matchScore rates how well value matches query: 3 for equal, 2 for a
+// prefix, 1 for a substring, 0 for no match (case-insensitive)
+func matchScore(query, value string) int {
+	q := strings.ToLower(strings.TrimSpace(query))
+	v := strings.ToLower(value)
+	switch {
+	case q == "" || v == "":
+		return 0
+	case v == q:
+		return 3
+	case strings.HasPrefix(v, q):
+		return 2
+	case strings.Contains(v, q):
+		return 1
+	}
+	return 0
+}
+
+// topResults sorts results by score, then title, and keeps the first limit
+func topResults(results []searchResult, limit int) []searchResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].Title < results[j].Title
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// flattenSearchGroups lists the results in display order, for numbering
+func flattenSearchGroups(groups []searchGroup) []searchResult {
+	var all []searchResult
+	for _, g := range groups {
+		all = append(all, g.Results...)
+	}
+	return all
+}
+
+func printSearchGroups(groups []searchGroup) {
+	icons := map[string]string{"user": "👤", "project": "📁", "campus": "🏫"}
+	titles := map[string]string{"user": "Users", "project": "Projects", "campus": "Campuses"}
+
+	n := 0
+	for _, g := range groups {
+		fmt.Printf("%s %s\n", icons[g.Type], titles[g.Type])
+		switch {
+		case g.Error != "":
+			fmt.Printf("   ⚠️  %s\n", g.Error)
+		case len(g.Results) == 0:
+			fmt.Println("   No matches")
+		}
+		for _, r := range g.Results {
+			n++
+			fmt.Printf("  %2d. %-25s %-30s %s\n", n, truncateString(r.Title, 25), truncateString(r.Detail, 30), showCommand(r))
+		}
+		fmt.Println()
+	}
+}
+
+// showCommand returns the t42 command showing a result
+func showCommand(r searchResult) string {
+	return fmt.Sprintf("t42 %s show %s", r.Type, r.Key)
+}
+
+func openSearchResult(r searchResult) error {
+	if r.URL == "" {
+		return fmt.Errorf("%s %s has no web page; run '%s'", r.Type, r.Title, showCommand(r))
+	}
+	if err := openBrowser(r.URL); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	if !GetJSONOutput() {
+		fmt.Printf("🌐 Opened %s\n", r.URL)
+	}
+	return nil
+}
+
+// pickSearchResult lets the user choose a result and show or open it
+func pickSearchResult(cmd *cobra.Command, results []searchResult) error {
+	labels := make([]string, len(results))
+	for i, r := range results {
+		labels[i] = fmt.Sprintf("%s %s %s", r.Type, r.Title, r.Detail)
+	}
+	choice, err := selectPrompt(selection{Title: "Result", Options: labels})
+	if err != nil {
+		return fmt.Errorf("failed to get selection: %w", err)
+	}
+	action, err := selectPrompt(selection{Title: "Action", Options: []string{"Show details", "Open in browser"}})
+	if err != nil {
+		return fmt.Errorf("failed to get selection: %w", err)
+	}
+
+	r := results[choice]
+	if action == 1 {
+		return openSearchResult(r)
+	}
+	fmt.Println()
+	switch r.Type {
+	case "user":
+		return runShowUser(showUserCmd, []string{r.Key})
+	case "project":
+		return runShowProject(showProjectCmd, []string{r.Key})
+	default:
+		return runShowCampus(showCampusCmd, []string{r.Key})
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
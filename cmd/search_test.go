@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestMatchScore(t *testing.T) {
+	tests := []struct {
+		query, value string
+		want         int
+	}{
+		{"libft", "libft", 3},
+		{"LIBFT", "Libft", 3},
+		{"mini", "minishell", 2},
+		{"shell", "minishell", 1},
+		{"push", "libft", 0},
+		{"", "libft", 0},
+		{"libft", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.value, func(t *testing.T) {
+			if got := matchScore(tt.query, tt.value); got != tt.want {
+				t.Errorf("matchScore(%q, %q) = %d, want %d", tt.query, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchProjectIndex(t *testing.T) {
+	index := []projectIndexEntry{
+		{ID: 1, Name: "Libft", Slug: "libft"},
+		{ID: 2, Name: "get_next_line", Slug: "get_next_line"},
+		{ID: 3, Name: "minishell", Slug: "minishell"},
+		{ID: 4, Name: "ft_transcendence", Slug: "ft_transcendence"},
+		{ID: 5, Name: "ft_printf", Slug: "ft_printf"},
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		aliased string
+		wantIDs []int
+	}{
+		{"exact name", "libft", "libft", []int{1}},
+		{"prefix", "ft_", "ft_", []int{5, 4}},
+		{"spaces match underscores", "get next", "get next", []int{2}},
+		{"alias ranks first", "tc", "ft_transcendence", []int{4}},
+		{"no match", "webserv", "webserv", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := topResults(matchProjectIndex(index, tt.query, tt.aliased), 5)
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("got %d results %+v, want %v", len(got), got, tt.wantIDs)
+			}
+			for i, id := range tt.wantIDs {
+				if got[i].ID != id || got[i].Source != "index" {
+					t.Errorf("result %d = %+v, want project %d from the index", i, got[i], id)
+				}
+			}
+		})
+	}
+}
+
+func TestTopResultsAndNumbering(t *testing.T) {
+	results := []searchResult{
+		{Title: "b", score: 1},
+		{Title: "a", score: 1},
+		{Title: "c", score: 3},
+	}
+	top := topResults(results, 2)
+	if len(top) != 2 || top[0].Title != "c" || top[1].Title != "a" {
+		t.Errorf("topResults() = %+v", top)
+	}
+
+	groups := []searchGroup{
+		{Type: "user", Results: []searchResult{{Key: "jdoe"}}},
+		{Type: "project", Error: "boom"},
+		{Type: "campus", Results: []searchResult{{Key: "26"}, {Key: "1"}}},
+	}
+	flat := flattenSearchGroups(groups)
+	if len(flat) != 3 || flat[0].Key != "jdoe" || flat[2].Key != "1" {
+		t.Errorf("flattenSearchGroups() = %+v", flat)
+	}
+	if got := showCommand(searchResult{Type: "campus", Key: "26"}); got != "t42 campus show 26" {
+		t.Errorf("showCommand() = %q", got)
+	}
+}
@@ -0,0 +1,469 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+	"github.com/naokiiida/t42-cli/internal/remind"
+)
+
+// slotGranularity is the size of the pieces the API splits slots into;
+// slots must start and end on a multiple of it
+const slotGranularity = 15 * time.Minute
+
+var slotCmd = &cobra.Command{
+	Use:     "slot",
+	Aliases: []string{"slots"},
+	Short:   "Manage your evaluation slots",
+	Long:    `View, open and remove the slots in which you are available to evaluate others.`,
+}
+
+var slotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your upcoming evaluation slots",
+	Long: `List your upcoming evaluation slots. The API stores slots as 15-minute
+pieces; adjacent pieces are shown as one slot, identified by the ID of
+its first piece.
+
+Examples:
+  t42 slot list
+  t42 slot list --days 30`,
+	Args: cobra.NoArgs,
+	RunE: runSlotList,
+}
+
+var slotCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Open evaluation slots",
+	Long: `Open an evaluation slot, or a series of them with --every.
+
+Times are local and must be on a quarter hour. --every takes "day",
+"weekday", "weekend" or a comma-separated list of days (mon,wed,fri) and
+repeats the slot for --weeks weeks starting at --date. Occurrences that
+have already begun are skipped.
+
+Examples:
+  t42 slot create --from 18:00 --to 20:00
+  t42 slot create --date 2024-06-03 --from 09:30 --to 11:00
+  t42 slot create --every day --from 18:00 --to 20:00 --weeks 2
+  t42 slot create --every mon,thu --from 14:00 --to 15:00 --weeks 4`,
+	Args: cobra.NoArgs,
+	RunE: runSlotCreate,
+}
+
+var slotDeleteCmd = &cobra.Command{
+	Use:   "delete <id>...",
+	Short: "Remove evaluation slots",
+	Long: `Remove evaluation slots by the IDs shown in 't42 slot list'. The whole
+slot containing the ID is removed; booked slots cannot be removed.
+
+Examples:
+  t42 slot delete 123456
+  t42 slot delete 123456 123789`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSlotDelete,
+}
+
+func init() {
+	slotCmd.AddCommand(slotListCmd)
+	slotCmd.AddCommand(slotCreateCmd)
+	slotCmd.AddCommand(slotDeleteCmd)
+	rootCmd.AddCommand(slotCmd)
+
+	slotListCmd.Flags().Int("days", 14, "Show slots starting within this many days")
+
+	slotCreateCmd.Flags().String("date", "", "First day, YYYY-MM-DD (default: today)")
+	slotCreateCmd.Flags().String("from", "", "Start time, HH:MM (required)")
+	slotCreateCmd.Flags().String("to", "", "End time, HH:MM (required)")
+	slotCreateCmd.Flags().String("every", "", "Repeat on: day, weekday, weekend or mon,tue,...")
+	slotCreateCmd.Flags().Int("weeks", 1, "Number of weeks to repeat for with --every")
+	_ = slotCreateCmd.MarkFlagRequired("from")
+	_ = slotCreateCmd.MarkFlagRequired("to")
+}
+
+// slotRange is a run of adjacent slot pieces with the same booking status
+type slotRange struct {
+	ID      int       `json:"id"` // ID of the first piece
+	IDs     []int     `json:"ids"`
+	BeginAt time.Time `json:"begin_at"`
+	EndAt   time.Time `json:"end_at"`
+	Booked  bool      `json:"booked"`
+}
+
+// slotPeriod is a slot to create
+type slotPeriod struct {
+	BeginAt time.Time `json:"begin_at"`
+	EndAt   time.Time `json:"end_at"`
+}
+
+func runSlotList(cmd *cobra.Command, args []string) error {
+	days, _ := cmd.Flags().GetInt("days")
+	if days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	now := time.Now()
+	slots, err := fetchMySlots(ctx, client, now, now.AddDate(0, 0, days))
+	if err != nil {
+		return err
+	}
+	ranges := mergeSlots(slots)
+
+	if done, err := emitOutput(
+		output.Field{Key: "slots", Value: ranges},
+		output.Field{Key: "count", Value: len(ranges)},
+	); done || err != nil {
+		return err
+	}
+
+	if len(ranges) == 0 {
+		fmt.Printf("No slots in the next %d days. Use 't42 slot create' to open one.\n", days)
+		return nil
+	}
+
+	fmt.Printf("%-10s %-22s %-8s %s\n", "ID", "WHEN", "LENGTH", "STATUS")
+	fmt.Println(strings.Repeat("-", 55))
+	for _, r := range ranges {
+		status := "🟢 free"
+		if r.Booked {
+			status = "📌 booked"
+		}
+		fmt.Printf("%-10d %-22s %-8s %s\n", r.ID, formatSlotWhen(r.BeginAt, r.EndAt), formatSlotLength(r.EndAt.Sub(r.BeginAt)), status)
+	}
+	fmt.Printf("\nTotal: %d slots\n", len(ranges))
+	return nil
+}
+
+func runSlotCreate(cmd *cobra.Command, args []string) error {
+	date, _ := cmd.Flags().GetString("date")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	every, _ := cmd.Flags().GetString("every")
+	weeks, _ := cmd.Flags().GetInt("weeks")
+
+	now := time.Now()
+	start := now
+	if date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --date %q (use YYYY-MM-DD)", date)
+		}
+		start = parsed
+	}
+	if every == "" && cmd.Flags().Changed("weeks") {
+		return fmt.Errorf("--weeks requires --every")
+	}
+
+	periods, err := slotOccurrences(start, from, to, every, weeks)
+	if err != nil {
+		return err
+	}
+
+	var upcoming, skipped []slotPeriod
+	for _, p := range periods {
+		if p.BeginAt.After(now) {
+			upcoming = append(upcoming, p)
+		} else {
+			skipped = append(skipped, p)
+		}
+	}
+	if len(upcoming) == 0 {
+		return fmt.Errorf("every requested slot has already begun")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+
+	var created []slotRange
+	var failures []string
+	for _, p := range upcoming {
+		slots, err := client.CreateSlot(ctx, me.ID, p.BeginAt, p.EndAt)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", formatSlotWhen(p.BeginAt, p.EndAt), err))
+			continue
+		}
+		created = append(created, mergeSlots(slots)...)
+	}
+	if len(created) > 0 {
+		invalidateOwnData()
+	}
+
+	if GetJSONOutput() {
+		if err := writeOutput(
+			output.Field{Key: "created", Value: created},
+			output.Field{Key: "skipped", Value: skipped},
+			output.Field{Key: "errors", Value: failures},
+		); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range created {
+			fmt.Printf("✅ Opened slot %d: %s\n", r.ID, formatSlotWhen(r.BeginAt, r.EndAt))
+		}
+		for _, p := range skipped {
+			fmt.Printf("⏭️  Skipped %s (already begun)\n", formatSlotWhen(p.BeginAt, p.EndAt))
+		}
+		for _, f := range failures {
+			fmt.Printf("❌ %s\n", f)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to create %d of %d slots", len(failures), len(upcoming))
+	}
+	return nil
+}
+
+func runSlotDelete(cmd *cobra.Command, args []string) error {
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid slot ID %q", arg)
+		}
+		ids[i] = id
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	now := time.Now()
+	slots, err := fetchMySlots(ctx, client, now.Add(-24*time.Hour), time.Time{})
+	if err != nil {
+		return err
+	}
+	ranges := mergeSlots(slots)
+
+	var deleted []slotRange
+	var deleteErr error
+	for _, id := range ids {
+		r := findSlotRange(ranges, id)
+		if r == nil {
+			// Not among the upcoming slots; let the API decide
+			r = &slotRange{ID: id, IDs: []int{id}}
+		}
+		if r.Booked {
+			deleteErr = fmt.Errorf("slot %d is booked and cannot be removed", id)
+			break
+		}
+		for _, pieceID := range r.IDs {
+			if err := client.DeleteSlot(ctx, pieceID); err != nil {
+				deleteErr = fmt.Errorf("failed to delete slot %d: %w", pieceID, err)
+				break
+			}
+		}
+		if deleteErr != nil {
+			break
+		}
+		deleted = append(deleted, *r)
+	}
+	if len(deleted) > 0 {
+		invalidateOwnData()
+	}
+
+	if GetJSONOutput() {
+		if err := writeOutput(output.Field{Key: "deleted", Value: deleted}); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range deleted {
+			if r.BeginAt.IsZero() {
+				fmt.Printf("🗑️  Removed slot %d\n", r.ID)
+			} else {
+				fmt.Printf("🗑️  Removed slot %d: %s\n", r.ID, formatSlotWhen(r.BeginAt, r.EndAt))
+			}
+		}
+	}
+	return deleteErr
+}
+
+// fetchMySlots returns all slot pieces of the logged-in user starting in
+// [since, until); a zero until leaves the range open
+func fetchMySlots(ctx context.Context, client *api.Client, since, until time.Time) ([]api.Slot, error) {
+	var all []api.Slot
+	err := api.FetchAll(ctx, api.DefaultPerPage,
+		func(ctx context.Context, page int) ([]api.Slot, *api.PaginationMeta, error) {
+			return client.ListMySlots(ctx, &api.ListSlotsOptions{
+				Page:    page,
+				PerPage: api.DefaultPerPage,
+				Since:   since,
+				Until:   until,
+			})
+		},
+		func(slots []api.Slot, _ *api.PaginationMeta) error {
+			all = append(all, slots...)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list your slots: %w", err)
+	}
+	return all, nil
+}
+
+// mergeSlots joins adjacent slot pieces with the same booking status
+func mergeSlots(slots []api.Slot) []slotRange {
+	sorted := append([]api.Slot(nil), slots...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].BeginAt.Before(sorted[j].BeginAt)
+	})
+
+	var ranges []slotRange
+	for _, s := range sorted {
+		if n := len(ranges); n > 0 {
+			last := &ranges[n-1]
+			if last.Booked == s.Booked && !s.BeginAt.After(last.EndAt) {
+				last.IDs = append(last.IDs, s.ID)
+				if s.EndAt.After(last.EndAt) {
+					last.EndAt = s.EndAt
+				}
+				continue
+			}
+		}
+		ranges = append(ranges, slotRange{ID: s.ID, IDs: []int{s.ID}, BeginAt: s.BeginAt, EndAt: s.EndAt, Booked: s.Booked})
+	}
+	return ranges
+}
+
+// findSlotRange returns the range containing the slot piece id
+func findSlotRange(ranges []slotRange, id int) *slotRange {
+	for i := range ranges {
+		for _, pieceID := range ranges[i].IDs {
+			if pieceID == id {
+				return &ranges[i]
+			}
+		}
+	}
+	return nil
+}
+
+// slotOccurrences returns the slots from..to on the days selected by every,
+// for the given number of weeks from start's date; without every, the single
+// slot on start's date
+func slotOccurrences(start time.Time, from, to, every string, weeks int) ([]slotPeriod, error) {
+	fromHour, fromMinute, err := remind.ParseTime(from)
+	if err != nil {
+		return nil, fmt.Errorf("--from: %w", err)
+	}
+	toHour, toMinute, err := remind.ParseTime(to)
+	if err != nil {
+		return nil, fmt.Errorf("--to: %w", err)
+	}
+	if time.Duration(fromMinute)*time.Minute%slotGranularity != 0 || time.Duration(toMinute)*time.Minute%slotGranularity != 0 {
+		return nil, fmt.Errorf("slots must start and end on a quarter hour (:00, :15, :30 or :45)")
+	}
+	if toHour*60+toMinute <= fromHour*60+fromMinute {
+		return nil, fmt.Errorf("--to must be after --from")
+	}
+
+	days := map[time.Weekday]bool{start.Weekday(): true}
+	span := 1
+	if every != "" {
+		if days, err = parseSlotDays(every); err != nil {
+			return nil, err
+		}
+		if weeks < 1 {
+			return nil, fmt.Errorf("--weeks must be at least 1")
+		}
+		span = 7 * weeks
+	}
+
+	var periods []slotPeriod
+	for i := 0; i < span; i++ {
+		day := start.AddDate(0, 0, i)
+		if !days[day.Weekday()] {
+			continue
+		}
+		y, m, d := day.Date()
+		periods = append(periods, slotPeriod{
+			BeginAt: time.Date(y, m, d, fromHour, fromMinute, 0, 0, start.Location()),
+			EndAt:   time.Date(y, m, d, toHour, toMinute, 0, 0, start.Location()),
+		})
+	}
+	return periods, nil
+}
+
+// parseSlotDays parses --every: "day", "weekday", "weekend" or a list of days
+func parseSlotDays(every string) (map[time.Weekday]bool, error) {
+	days := make(map[time.Weekday]bool)
+	add := func(weekdays ...time.Weekday) {
+		for _, d := range weekdays {
+			days[d] = true
+		}
+	}
+
+	for _, part := range strings.Split(strings.ToLower(every), ",") {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "day", "daily":
+			add(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+			continue
+		case "weekday", "weekdays":
+			add(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+			continue
+		case "weekend", "weekends":
+			add(time.Saturday, time.Sunday)
+			continue
+		}
+
+		found := false
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			name := strings.ToLower(d.String())
+			if len(part) >= 3 && strings.HasPrefix(name, part) {
+				add(d)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid --every %q (use day, weekday, weekend or days like mon,wed)", every)
+		}
+	}
+	return days, nil
+}
+
+// formatSlotWhen formats a slot as "Mon Jun 03 18:00-20:00" in local time
+func formatSlotWhen(begin, end time.Time) string {
+	begin, end = begin.Local(), end.Local()
+	if begin.YearDay() != end.YearDay() || begin.Year() != end.Year() {
+		return begin.Format("Mon Jan 02 15:04") + "-" + end.Format("Jan 02 15:04")
+	}
+	return begin.Format("Mon Jan 02 15:04") + "-" + end.Format("15:04")
+}
+
+// formatSlotLength formats a duration as "1h30" or "45m"
+func formatSlotLength(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%02d", hours, minutes)
+}
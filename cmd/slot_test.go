@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestMergeSlots(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2024, 6, 1, h, m, 0, 0, time.UTC) }
+	piece := func(id, h, m int, booked bool) api.Slot {
+		return api.Slot{ID: id, BeginAt: at(h, m), EndAt: at(h, m).Add(15 * time.Minute), Booked: booked}
+	}
+
+	slots := []api.Slot{
+		piece(4, 18, 45, true),
+		piece(1, 18, 0, false),
+		piece(2, 18, 15, false),
+		piece(3, 18, 30, true),
+		piece(9, 20, 0, false),
+	}
+	ranges := mergeSlots(slots)
+
+	if len(ranges) != 3 {
+		t.Fatalf("mergeSlots() = %+v, want 3 ranges", ranges)
+	}
+	if !reflect.DeepEqual(ranges[0].IDs, []int{1, 2}) || ranges[0].Booked || !ranges[0].EndAt.Equal(at(18, 30)) {
+		t.Errorf("first range = %+v, want free 18:00-18:30 of pieces 1 and 2", ranges[0])
+	}
+	if !reflect.DeepEqual(ranges[1].IDs, []int{3, 4}) || !ranges[1].Booked {
+		t.Errorf("second range = %+v, want booked pieces 3 and 4", ranges[1])
+	}
+	if ranges[2].ID != 9 {
+		t.Errorf("third range = %+v, want the separate piece 9", ranges[2])
+	}
+
+	if r := findSlotRange(ranges, 2); r == nil || r.ID != 1 {
+		t.Errorf("findSlotRange(2) = %+v, want range 1", r)
+	}
+	if r := findSlotRange(ranges, 42); r != nil {
+		t.Errorf("findSlotRange(42) = %+v, want nil", r)
+	}
+}
+
+func TestSlotOccurrences(t *testing.T) {
+	monday := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from, to string
+		every    string
+		weeks    int
+		wantDays []int // days of June
+		wantErr  bool
+	}{
+		{"single", "18:00", "20:00", "", 1, []int{3}, false},
+		{"every day for two weeks", "18:00", "20:00", "day", 2, []int{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, false},
+		{"weekdays", "09:00", "10:00", "weekday", 1, []int{3, 4, 5, 6, 7}, false},
+		{"listed days", "09:00", "10:00", "mon,thu", 2, []int{3, 6, 10, 13}, false},
+		{"weekend", "09:00", "10:00", "weekend", 1, []int{8, 9}, false},
+		{"not on a quarter hour", "18:10", "20:00", "", 1, nil, true},
+		{"ends before it starts", "20:00", "18:00", "", 1, nil, true},
+		{"unknown day", "18:00", "20:00", "someday", 1, nil, true},
+		{"zero weeks", "18:00", "20:00", "day", 0, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods, err := slotOccurrences(monday, tt.from, tt.to, tt.every, tt.weeks)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("slotOccurrences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var days []int
+			for _, p := range periods {
+				days = append(days, p.BeginAt.Day())
+			}
+			if !reflect.DeepEqual(days, tt.wantDays) {
+				t.Errorf("slotOccurrences() days = %v, want %v", days, tt.wantDays)
+			}
+			if len(periods) > 0 && periods[0].EndAt.Sub(periods[0].BeginAt) <= 0 {
+				t.Errorf("period %+v does not end after it begins", periods[0])
+			}
+		})
+	}
+}
+
+func TestFormatSlotLength(t *testing.T) {
+	tests := map[time.Duration]string{
+		45 * time.Minute: "45m",
+		2 * time.Hour:    "2h",
+		90 * time.Minute: "1h30",
+	}
+	for d, want := range tests {
+		if got := formatSlotLength(d); got != want {
+			t.Errorf("formatSlotLength(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestFetchMySlotsWithoutPaginationHeaders(t *testing.T) {
+	// A full first page and a short second one, without X-Total headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		count := map[int]int{1: api.DefaultPerPage, 2: 3}[page]
+		slots := make([]api.Slot, count)
+		for i := range slots {
+			slots[i].ID = page*1000 + i
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(slots)
+	}))
+	defer server.Close()
+
+	client := api.NewClient("test_token", api.WithBaseURL(server.URL), api.WithRateLimiter(nil))
+	slots, err := fetchMySlots(context.Background(), client, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("fetchMySlots() error = %v", err)
+	}
+	if want := api.DefaultPerPage + 3; len(slots) != want {
+		t.Errorf("fetchMySlots() returned %d slots, want %d", len(slots), want)
+	}
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Statistics about your use of t42",
+	Long: `Statistics t42 keeps about how you use it.
+
+Everything here is stored locally in the state directory and never sent
+over the network.`,
+}
+
+func init() {
+	// Add stats command to root
+	rootCmd.AddCommand(statsCmd)
+}
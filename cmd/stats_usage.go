@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+	"github.com/naokiiida/t42-cli/internal/state"
+	"github.com/naokiiida/t42-cli/internal/usage"
+)
+
+var statsUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show the commands and flags you use most",
+	Long: `Show the commands and flags you use most, and the campus and cursus
+you pick most often.
+
+Usage stats are off by default. Turn them on in the config file, or with
+T42_USAGE_STATS=1:
+
+  # config.yaml
+  usage_stats: true
+
+Only command names, flag names, and campus and cursus IDs are counted;
+never logins, search terms or other values. The counts stay in the state
+directory and are never sent anywhere. They power smart defaults, such as
+pre-selecting your most used campus when a command asks for one.
+
+Examples:
+  t42 stats usage
+  t42 stats usage --top 20
+  t42 stats usage --reset`,
+	Args: cobra.NoArgs,
+	RunE: runStatsUsage,
+}
+
+func init() {
+	statsCmd.AddCommand(statsUsageCmd)
+
+	statsUsageCmd.Flags().Int("top", 10, "Number of commands and flags to show")
+	statsUsageCmd.Flags().Bool("reset", false, "Delete the recorded usage stats")
+}
+
+func runStatsUsage(cmd *cobra.Command, args []string) error {
+	top, _ := cmd.Flags().GetInt("top")
+	reset, _ := cmd.Flags().GetBool("reset")
+
+	store, err := state.Open()
+	if err != nil {
+		return err
+	}
+
+	if reset {
+		if err := usage.Reset(store); err != nil {
+			return err
+		}
+		if done, err := emitOutput(output.Field{Key: "reset", Value: true}); done || err != nil {
+			return err
+		}
+		fmt.Println("🧹 Usage stats deleted")
+		return nil
+	}
+
+	stats, err := usage.Load(store, time.Now())
+	if err != nil {
+		return err
+	}
+	enabled := usageStatsEnabled()
+
+	commands := usage.Top(stats.Commands, top)
+	flags := usage.Top(stats.Flags, top)
+	values := make(map[string][]usage.Count, len(stats.Values))
+	for key, counts := range stats.Values {
+		values[key] = usage.Top(counts, 3)
+	}
+
+	if done, err := emitOutput(
+		output.Field{Key: "enabled", Value: enabled},
+		output.Field{Key: "since", Value: stats.Since},
+		output.Field{Key: "commands", Value: commands},
+		output.Field{Key: "flags", Value: flags},
+		output.Field{Key: "values", Value: values},
+	); done || err != nil {
+		return err
+	}
+
+	if !enabled {
+		fmt.Println("ℹ️  Usage stats are off - set usage_stats: true in the config file or T42_USAGE_STATS=1")
+	}
+	if len(stats.Commands) == 0 {
+		fmt.Println("📭 No usage recorded yet")
+		return nil
+	}
+
+	fmt.Printf("📊 Usage since %s\n", stats.Since.Local().Format("2006-01-02"))
+	printUsageCounts("Commands", commands)
+	printUsageCounts("Flags", flags)
+
+	if campusCounts := values["campus"]; len(campusCounts) > 0 {
+		fmt.Println("\nMost used campus IDs:")
+		for _, c := range campusCounts {
+			fmt.Printf("  %-10s %d\n", c.Name, c.Count)
+		}
+	}
+	if cursusCounts := values["cursus"]; len(cursusCounts) > 0 {
+		fmt.Println("\nMost used cursus IDs:")
+		for _, c := range cursusCounts {
+			fmt.Printf("  %-10s %d\n", c.Name, c.Count)
+		}
+	}
+	return nil
+}
+
+// printUsageCounts prints counts as a bar chart relative to the largest
+func printUsageCounts(title string, counts []usage.Count) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Printf("\n%s:\n", title)
+	largest := counts[0].Count
+	for _, c := range counts {
+		fmt.Printf("  %-32s %s %d\n", truncateString(c.Name, 32), renderBar(float64(c.Count)/float64(largest), 20), c.Count)
+	}
+}
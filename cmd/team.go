@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Team commands",
+	Long: `Inspect the teams of your group projects.
+
+A team is the group registered for one attempt at a project, with its
+members and its repository.`,
+}
+
+func init() {
+	// Add team command to root
+	rootCmd.AddCommand(teamCmd)
+}
@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var teamBlameCmd = &cobra.Command{
+	Use:   "blame [dir]",
+	Short: "Summarize each team member's contributions to a project repository",
+	Long: `Combine the commit history of a cloned team repository with the team
+members from the API, and print commits, lines added and removed, and the
+last activity of each member. Handy before the evaluation of group work.
+
+The team is found by matching the repository's origin remote against the
+repositories of your teams; pass --team-id for clones with another remote.
+Commits are attributed to a member when the author name or the part of the
+author email before the @ is their login. Map other identities with
+--author (repeatable).
+
+Examples:
+  t42 team blame
+  t42 team blame ~/42/minishell
+  t42 team blame --author 'Jane Doe=jdoe' --author jane@example.com=jdoe`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTeamBlame,
+}
+
+func init() {
+	teamCmd.AddCommand(teamBlameCmd)
+
+	teamBlameCmd.Flags().Int("team-id", 0, "Team to compare against (default: the team whose repository is the origin remote)")
+	teamBlameCmd.Flags().StringArray("author", nil, "Attribute a commit author to a member: 'name or email=login'")
+}
+
+// gitCommit is one non-merge commit with its line statistics
+type gitCommit struct {
+	Name    string
+	Email   string
+	Time    time.Time
+	Added   int
+	Deleted int
+}
+
+// contribution is the activity of one author in the repository; Member is
+// false for authors that could not be matched to a team member
+type contribution struct {
+	Login      string     `json:"login,omitempty"`
+	Author     string     `json:"author,omitempty"`
+	Member     bool       `json:"member"`
+	Commits    int        `json:"commits"`
+	Added      int        `json:"lines_added"`
+	Deleted    int        `json:"lines_deleted"`
+	Share      float64    `json:"share"`
+	LastCommit *time.Time `json:"last_commit"`
+}
+
+func runTeamBlame(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	teamID, _ := cmd.Flags().GetInt("team-id")
+	authorFlags, _ := cmd.Flags().GetStringArray("author")
+
+	aliases, err := parseAuthorAliases(authorFlags)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	team, err := findRepoTeam(ctx, client, dir, teamID)
+	if err != nil {
+		return err
+	}
+
+	logOutput, err := exec.Command("git", "-C", dir, "log", "--no-merges", "--numstat",
+		"--format=%x1e%an%x1f%ae%x1f%at").Output()
+	if err != nil {
+		return fmt.Errorf("failed to read the git history of %s: %w", dir, err)
+	}
+	commits, err := parseGitLog(string(logOutput))
+	if err != nil {
+		return err
+	}
+
+	contributions := summarizeContributions(commits, team.Users, aliases)
+	projectName := resolveProjectNames(ctx, client, []int{team.ProjectID})[team.ProjectID]
+
+	if done, err := emitOutput(
+		output.Field{Key: "team", Value: map[string]interface{}{
+			"id":      team.ID,
+			"name":    team.Name,
+			"project": projectName,
+			"status":  team.Status,
+		}},
+		output.Field{Key: "commits", Value: len(commits)},
+		output.Field{Key: "contributions", Value: contributions},
+	); done || err != nil {
+		return err
+	}
+
+	printContributions(team, projectName, contributions, time.Now())
+	return nil
+}
+
+// findRepoTeam returns the team with the given ID, or the team of the
+// current user whose repository is the origin remote of dir
+func findRepoTeam(ctx context.Context, client *api.Client, dir string, teamID int) (*api.Team, error) {
+	if teamID > 0 {
+		team, err := client.GetTeam(ctx, teamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team %d: %w", teamID, err)
+		}
+		return team, nil
+	}
+
+	remote, err := exec.Command("git", "-C", dir, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the origin remote of %s (is it a git clone?): %w", dir, err)
+	}
+	origin := strings.TrimSpace(string(remote))
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	var found *api.Team
+	for team, err := range client.UserTeamsPaginator(ctx, me.ID, &api.ListTeamsOptions{Sort: "-created_at"}).Items() {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list your teams: %w", err)
+		}
+		if sameRepository(origin, team) {
+			found = &team
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("none of your teams uses the repository %s; pass --team-id", origin)
+	}
+	return found, nil
+}
+
+// sameRepository reports whether a remote URL points to the team's
+// repository, whatever the transport (ssh, scp-like or https)
+func sameRepository(remote string, team api.Team) bool {
+	if team.RepoUUID != "" && strings.Contains(remote, team.RepoUUID) {
+		return true
+	}
+	return team.RepoURL != "" && normalizeRepoURL(remote) == normalizeRepoURL(team.RepoURL)
+}
+
+// normalizeRepoURL reduces a git URL to host/path
+func normalizeRepoURL(raw string) string {
+	u := strings.ToLower(strings.TrimSpace(raw))
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+	} else if i := strings.Index(u, ":"); i >= 0 {
+		// scp-like syntax: user@host:path
+		u = u[:i] + "/" + u[i+1:]
+	}
+	if i := strings.Index(u, "@"); i >= 0 && i < strings.Index(u+"/", "/") {
+		u = u[i+1:]
+	}
+	u = strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+	return u
+}
+
+// parseAuthorAliases parses --author values of the form identity=login
+func parseAuthorAliases(values []string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	for _, value := range values {
+		identity, login, ok := strings.Cut(value, "=")
+		identity, login = strings.TrimSpace(identity), strings.TrimSpace(login)
+		if !ok || identity == "" || login == "" {
+			return nil, fmt.Errorf("invalid --author %q (use 'name or email=login')", value)
+		}
+		aliases[strings.ToLower(identity)] = strings.ToLower(login)
+	}
+	return aliases, nil
+}
+
+// parseGitLog parses the output of git log --numstat with the format
+// %x1e%an%x1f%ae%x1f%at
+func parseGitLog(out string) ([]gitCommit, error) {
+	var commits []gitCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(record))
+		scanner.Scan()
+		header := strings.Split(scanner.Text(), "\x1f")
+		if len(header) != 3 {
+			return nil, fmt.Errorf("failed to parse git log: unexpected header %q", scanner.Text())
+		}
+		timestamp, err := strconv.ParseInt(header[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse git log: invalid time %q", header[2])
+		}
+		commit := gitCommit{Name: header[0], Email: header[1], Time: time.Unix(timestamp, 0)}
+
+		for scanner.Scan() {
+			fields := strings.SplitN(scanner.Text(), "\t", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			// Binary files show "-" instead of line counts
+			added, _ := strconv.Atoi(fields[0])
+			deleted, _ := strconv.Atoi(fields[1])
+			commit.Added += added
+			commit.Deleted += deleted
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// commitLogin returns the member login a commit is attributed to, or ""
+func commitLogin(commit gitCommit, members map[string]bool, aliases map[string]string) string {
+	name := strings.ToLower(commit.Name)
+	email := strings.ToLower(commit.Email)
+	for _, identity := range []string{email, name} {
+		if login, ok := aliases[identity]; ok {
+			return login
+		}
+	}
+
+	local, _, _ := strings.Cut(email, "@")
+	for _, candidate := range []string{local, name} {
+		if members[candidate] {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// summarizeContributions aggregates commits per team member, listing every
+// member (even without commits) followed by unmatched authors
+func summarizeContributions(commits []gitCommit, users []api.User, aliases map[string]string) []contribution {
+	members := make(map[string]bool)
+	byKey := make(map[string]*contribution)
+	var order []string
+	for _, u := range users {
+		login := strings.ToLower(u.Login)
+		members[login] = true
+		byKey[login] = &contribution{Login: u.Login, Member: true}
+		order = append(order, login)
+	}
+
+	totalLines := 0
+	for _, commit := range commits {
+		key := commitLogin(commit, members, aliases)
+		if key == "" {
+			key = "author:" + strings.ToLower(commit.Email)
+			if _, ok := byKey[key]; !ok {
+				byKey[key] = &contribution{Author: fmt.Sprintf("%s <%s>", commit.Name, commit.Email)}
+				order = append(order, key)
+			}
+		} else if _, ok := byKey[key]; !ok {
+			// An --author alias to someone outside the team
+			byKey[key] = &contribution{Login: key}
+			order = append(order, key)
+		}
+
+		c := byKey[key]
+		c.Commits++
+		c.Added += commit.Added
+		c.Deleted += commit.Deleted
+		if c.LastCommit == nil || commit.Time.After(*c.LastCommit) {
+			t := commit.Time
+			c.LastCommit = &t
+		}
+		totalLines += commit.Added + commit.Deleted
+	}
+
+	contributions := make([]contribution, 0, len(order))
+	for _, key := range order {
+		c := *byKey[key]
+		if totalLines > 0 {
+			c.Share = float64(c.Added+c.Deleted) / float64(totalLines)
+		}
+		contributions = append(contributions, c)
+	}
+
+	// Members first, the biggest contributions at the top
+	sort.SliceStable(contributions, func(i, j int) bool {
+		if contributions[i].Member != contributions[j].Member {
+			return contributions[i].Member
+		}
+		return contributions[i].Added+contributions[i].Deleted > contributions[j].Added+contributions[j].Deleted
+	})
+	return contributions
+}
+
+func printContributions(team *api.Team, projectName string, contributions []contribution, now time.Time) {
+	title := team.Name
+	if projectName != "" {
+		title += " (" + projectName + ")"
+	}
+	fmt.Printf("👥 Team: %s\n\n", title)
+
+	fmt.Printf("%-20s %-8s %-8s %-8s %-18s %s\n", "MEMBER", "COMMITS", "+LINES", "-LINES", "SHARE", "LAST COMMIT")
+	fmt.Printf("%s\n", strings.Repeat("-", 90))
+
+	var idle, unmatched []string
+	for _, c := range contributions {
+		name := c.Login
+		if !c.Member {
+			if c.Login == "" {
+				name = c.Author
+			}
+			unmatched = append(unmatched, name)
+		}
+
+		last := "-"
+		if c.LastCommit != nil {
+			last = fmt.Sprintf("%s (%s ago)", c.LastCommit.Local().Format("2006-01-02"), formatAge(now.Sub(*c.LastCommit)))
+		} else if c.Member {
+			idle = append(idle, c.Login)
+		}
+
+		share := fmt.Sprintf("%s %3.0f%%", renderBar(c.Share, 12), c.Share*100)
+		fmt.Printf("%-20s %-8d %-8s %-8s %-18s %s\n",
+			truncateString(name, 18), c.Commits, fmt.Sprintf("+%d", c.Added), fmt.Sprintf("-%d", c.Deleted), share, last)
+	}
+
+	for _, login := range idle {
+		fmt.Printf("\n⚠️  %s has no commits in this repository", login)
+	}
+	if len(idle) > 0 {
+		fmt.Println()
+	}
+	if len(unmatched) > 0 {
+		fmt.Printf("\n💡 %d author(s) are not team members; map them with --author 'name or email=login'\n", len(unmatched))
+	}
+}
+
+// formatAge renders a duration in days, or hours under a day
+func formatAge(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestParseGitLog(t *testing.T) {
+	out := "\x1eJane Doe\x1fjdoe@student.42tokyo.jp\x1f1717243200\n\n10\t2\tsrc/main.c\n-\t-\tlogo.png\n3\t0\tMakefile\n" +
+		"\x1ebob\x1fbob@example.com\x1f1717156800\n\n0\t5\tREADME.md\n" +
+		"\x1eempty\x1fe@example.com\x1f1717070400\n"
+
+	commits, err := parseGitLog(out)
+	if err != nil {
+		t.Fatalf("parseGitLog() error = %v", err)
+	}
+
+	want := []gitCommit{
+		{Name: "Jane Doe", Email: "jdoe@student.42tokyo.jp", Time: time.Unix(1717243200, 0), Added: 13, Deleted: 2},
+		{Name: "bob", Email: "bob@example.com", Time: time.Unix(1717156800, 0), Added: 0, Deleted: 5},
+		{Name: "empty", Email: "e@example.com", Time: time.Unix(1717070400, 0)},
+	}
+	if len(commits) != len(want) {
+		t.Fatalf("got %d commits, want %d", len(commits), len(want))
+	}
+	for i := range want {
+		if commits[i] != want[i] {
+			t.Errorf("commit %d = %+v, want %+v", i, commits[i], want[i])
+		}
+	}
+
+	if _, err := parseGitLog("\x1ebroken header\n"); err == nil {
+		t.Error("parseGitLog() accepted a malformed header")
+	}
+}
+
+func TestSummarizeContributions(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 12, 0, 0, 0, time.UTC) }
+	commits := []gitCommit{
+		{Name: "Jane Doe", Email: "jdoe@student.42tokyo.jp", Time: day(1), Added: 50, Deleted: 10},
+		{Name: "Jane Doe", Email: "jdoe@student.42tokyo.jp", Time: day(3), Added: 20},
+		{Name: "asmith", Email: "a@gmail.com", Time: day(2), Added: 10},
+		{Name: "Laptop", Email: "root@localhost", Time: day(2), Added: 5, Deleted: 5},
+		{Name: "Stranger", Email: "s@example.com", Time: day(4), Added: 0},
+	}
+	users := []api.User{{Login: "jdoe"}, {Login: "asmith"}, {Login: "idle"}}
+
+	tests := []struct {
+		name    string
+		aliases map[string]string
+		want    []contribution
+	}{
+		{
+			name: "matched by email and name",
+			want: []contribution{
+				{Login: "jdoe", Member: true, Commits: 2, Added: 70, Deleted: 10, Share: 0.8},
+				{Login: "asmith", Member: true, Commits: 1, Added: 10, Share: 0.1},
+				{Login: "idle", Member: true},
+				{Author: "Laptop <root@localhost>", Commits: 1, Added: 5, Deleted: 5, Share: 0.1},
+				{Author: "Stranger <s@example.com>", Commits: 1},
+			},
+		},
+		{
+			name:    "aliased author",
+			aliases: map[string]string{"root@localhost": "idle"},
+			want: []contribution{
+				{Login: "jdoe", Member: true, Commits: 2, Added: 70, Deleted: 10, Share: 0.8},
+				{Login: "asmith", Member: true, Commits: 1, Added: 10, Share: 0.1},
+				{Login: "idle", Member: true, Commits: 1, Added: 5, Deleted: 5, Share: 0.1},
+				{Author: "Stranger <s@example.com>", Commits: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizeContributions(commits, users, tt.aliases)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d contributions, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.Login != w.Login || g.Author != w.Author || g.Member != w.Member || g.Commits != w.Commits ||
+					g.Added != w.Added || g.Deleted != w.Deleted || g.Share < w.Share-0.001 || g.Share > w.Share+0.001 {
+					t.Errorf("contribution %d = %+v, want %+v", i, g, w)
+				}
+				if (g.LastCommit == nil) != (w.Commits == 0) {
+					t.Errorf("contribution %d last commit = %v with %d commits", i, g.LastCommit, g.Commits)
+				}
+			}
+			if got[0].LastCommit != nil && !got[0].LastCommit.Equal(day(3)) {
+				t.Errorf("last commit of jdoe = %v, want %v", got[0].LastCommit, day(3))
+			}
+		})
+	}
+}
+
+func TestSameRepository(t *testing.T) {
+	team := api.Team{
+		RepoURL:  "git@vogsphere-v2.42tokyo.jp:vogsphere/intra-uuid-1234-jdoe",
+		RepoUUID: "intra-uuid-1234",
+	}
+
+	tests := []struct {
+		name   string
+		remote string
+		team   api.Team
+		want   bool
+	}{
+		{name: "identical", remote: team.RepoURL, team: team, want: true},
+		{name: "ssh scheme with .git", remote: "ssh://git@vogsphere-v2.42tokyo.jp/vogsphere/intra-uuid-1234-jdoe.git", team: api.Team{RepoURL: team.RepoURL}, want: true},
+		{name: "uuid in a mirror", remote: "https://github.com/jdoe/intra-uuid-1234-jdoe", team: team, want: true},
+		{name: "other repository", remote: "git@github.com:jdoe/minishell.git", team: team, want: false},
+		{name: "team without repository", remote: "git@github.com:jdoe/minishell.git", team: api.Team{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameRepository(tt.remote, tt.team); got != tt.want {
+				t.Errorf("sameRepository(%q) = %v, want %v", tt.remote, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAuthorAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "name and email", values: []string{"Jane Doe=jdoe", " J@Example.com = JDoe "}, want: map[string]string{"jane doe": "jdoe", "j@example.com": "jdoe"}},
+		{name: "missing login", values: []string{"Jane Doe="}, wantErr: true},
+		{name: "missing separator", values: []string{"jdoe"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAuthorAliases(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAuthorAliases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("alias %q = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+	"github.com/naokiiida/t42-cli/internal/state"
+)
+
+// teamDeadlinesStateName is the state document holding the observed
+// deadlines of teams
+const teamDeadlinesStateName = "team_deadlines"
+
+var teamDeadlineCmd = &cobra.Command{
+	Use:   "deadline [dir]",
+	Short: "Show a team's deadline and the extensions or freezes applied to it",
+	Long: `Show when a team's project must be finished, and how far extensions or
+freezes have moved that deadline.
+
+The effective deadline is the team's terminating date. The regular one is
+computed from when the team was locked and the project session's duration;
+the difference is the time added by extensions and freezes. The API keeps
+no history of these changes, so every run records the deadline it sees in
+the state directory, and later runs list each change with the day it was
+first seen.
+
+The team is found like 't42 team blame' does: from the origin remote of
+a cloned repository, or with --team-id.
+
+Examples:
+  t42 team deadline
+  t42 team deadline ~/42/minishell
+  t42 team deadline --team-id 4242424`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTeamDeadline,
+}
+
+func init() {
+	teamCmd.AddCommand(teamDeadlineCmd)
+
+	teamDeadlineCmd.Flags().Int("team-id", 0, "Team to show (default: the team whose repository is the origin remote)")
+}
+
+// deadlineObservation is a deadline as seen by one run
+type deadlineObservation struct {
+	SeenAt   time.Time `json:"seen_at"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// teamDeadlineState maps team IDs to the distinct deadlines seen, oldest first
+type teamDeadlineState struct {
+	Teams map[string][]deadlineObservation `json:"teams"`
+}
+
+// deadlineChange is a move of the deadline between two runs
+type deadlineChange struct {
+	SeenAt time.Time     `json:"seen_at"`
+	From   time.Time     `json:"from"`
+	To     time.Time     `json:"to"`
+	Shift  time.Duration `json:"shift_ns"`
+}
+
+// teamDeadlineReport is the deadline view of a team
+type teamDeadlineReport struct {
+	TeamID    int              `json:"team_id"`
+	Team      string           `json:"team"`
+	Project   string           `json:"project,omitempty"`
+	Status    string           `json:"status"`
+	LockedAt  *time.Time       `json:"locked_at"`
+	Regular   *time.Time       `json:"regular_deadline"`
+	Effective *time.Time       `json:"effective_deadline"`
+	Extension time.Duration    `json:"extension_ns"`
+	Remaining time.Duration    `json:"remaining_ns"`
+	Changes   []deadlineChange `json:"changes"`
+}
+
+func runTeamDeadline(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	teamID, _ := cmd.Flags().GetInt("team-id")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	team, err := findRepoTeam(ctx, client, dir, teamID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report := teamDeadlineReport{
+		TeamID:    team.ID,
+		Team:      team.Name,
+		Project:   resolveProjectNames(ctx, client, []int{team.ProjectID})[team.ProjectID],
+		Status:    team.Status,
+		LockedAt:  team.LockedAt,
+		Effective: team.TerminatingAt,
+	}
+	if session := fetchTeamSession(ctx, team); session != nil {
+		report.Regular = regularDeadline(team, session)
+	}
+	if report.Regular != nil && report.Effective != nil {
+		report.Extension = report.Effective.Sub(*report.Regular)
+	}
+	if report.Effective != nil {
+		report.Remaining = report.Effective.Sub(now)
+	}
+
+	store, err := state.Open()
+	if err != nil {
+		return err
+	}
+	var history teamDeadlineState
+	if _, err := store.Load(teamDeadlinesStateName, &history); err != nil {
+		return err
+	}
+	if report.Effective != nil && observeDeadline(&history, team.ID, *report.Effective, now) {
+		if err := store.Save(teamDeadlinesStateName, history); err != nil {
+			return err
+		}
+	}
+	report.Changes = deadlineChanges(history.Teams[strconv.Itoa(team.ID)])
+
+	if done, err := emitOutput(output.Field{Key: "deadline", Value: report}); done || err != nil {
+		return err
+	}
+
+	printTeamDeadline(report)
+	return nil
+}
+
+// fetchTeamSession returns the project session of a team, or nil when it is
+// unknown. Sessions need an application token.
+func fetchTeamSession(ctx context.Context, team *api.Team) *api.ProjectSessionDetail {
+	if team.ProjectSessionID == 0 {
+		return nil
+	}
+	appClient, err := newAppClient(ctx)
+	if err != nil {
+		if GetVerbose() {
+			fmt.Fprintf(os.Stderr, "Regular deadline unavailable: %v\n", err)
+		}
+		return nil
+	}
+	session, err := appClient.GetProjectSessionDetail(ctx, team.ProjectSessionID)
+	if err != nil {
+		warnf("deadline_unavailable", "failed to get project session %d, the regular deadline is unknown: %v", team.ProjectSessionID, err)
+		return nil
+	}
+	return session
+}
+
+// regularDeadline returns when a team's time runs out without extensions:
+// the session's terminating_after days after the team was locked
+func regularDeadline(team *api.Team, session *api.ProjectSessionDetail) *time.Time {
+	if team.LockedAt == nil || session.TerminatingAfter == nil || *session.TerminatingAfter <= 0 {
+		return nil
+	}
+	deadline := team.LockedAt.AddDate(0, 0, *session.TerminatingAfter)
+	return &deadline
+}
+
+// observeDeadline records the deadline of a team if it differs from the
+// last one seen, and reports whether it did
+func observeDeadline(history *teamDeadlineState, teamID int, deadline, now time.Time) bool {
+	if history.Teams == nil {
+		history.Teams = make(map[string][]deadlineObservation)
+	}
+	key := strconv.Itoa(teamID)
+	seen := history.Teams[key]
+	if n := len(seen); n > 0 && seen[n-1].Deadline.Equal(deadline) {
+		return false
+	}
+	history.Teams[key] = append(seen, deadlineObservation{SeenAt: now, Deadline: deadline})
+	return true
+}
+
+// deadlineChanges turns the deadlines seen for a team into the moves
+// between them
+func deadlineChanges(seen []deadlineObservation) []deadlineChange {
+	var changes []deadlineChange
+	for i := 1; i < len(seen); i++ {
+		changes = append(changes, deadlineChange{
+			SeenAt: seen[i].SeenAt,
+			From:   seen[i-1].Deadline,
+			To:     seen[i].Deadline,
+			Shift:  seen[i].Deadline.Sub(seen[i-1].Deadline),
+		})
+	}
+	return changes
+}
+
+// formatDeadlineShift formats a deadline move as "+3d 4h" or "-1d"
+func formatDeadlineShift(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	if d < 24*time.Hour {
+		return sign + formatSlotLength(d.Truncate(time.Minute))
+	}
+	days, hours := int(d.Hours())/24, int(d.Hours())%24
+	if hours > 0 {
+		return fmt.Sprintf("%s%dd %dh", sign, days, hours)
+	}
+	return fmt.Sprintf("%s%dd", sign, days)
+}
+
+func printTeamDeadline(r teamDeadlineReport) {
+	title := r.Team
+	if r.Project != "" {
+		title += " (" + r.Project + ")"
+	}
+	fmt.Printf("⏳ Team: %s\n", title)
+	fmt.Println(strings.Repeat("=", 40))
+
+	const layout = "Mon Jan 02 2006 15:04"
+	fmt.Printf("Status:       %s\n", r.Status)
+	if r.LockedAt != nil {
+		fmt.Printf("Locked:       %s\n", r.LockedAt.Local().Format(layout))
+	}
+	if r.Regular != nil {
+		fmt.Printf("Regular:      %s\n", r.Regular.Local().Format(layout))
+	}
+	if r.Effective == nil {
+		fmt.Println("Deadline:     none set (the team is not locked yet, or the project has no deadline)")
+		return
+	}
+	fmt.Printf("Deadline:     %s\n", r.Effective.Local().Format(layout))
+
+	switch {
+	case r.Regular == nil:
+		fmt.Println("Extensions:   unknown (the project session is not available)")
+	case r.Extension > 0:
+		fmt.Printf("Extensions:   %s from extensions or freezes\n", formatDeadlineShift(r.Extension))
+	case r.Extension < 0:
+		fmt.Printf("Extensions:   none (%s earlier than the regular deadline)\n", formatDeadlineShift(r.Extension)[1:])
+	default:
+		fmt.Println("Extensions:   none")
+	}
+
+	if r.Remaining > 0 {
+		fmt.Printf("Remaining:    %s\n", strings.TrimPrefix(formatTimeUntil(r.Remaining), "in "))
+	} else {
+		fmt.Printf("Remaining:    ⚠️  passed %s ago\n", formatAge(-r.Remaining))
+	}
+
+	if len(r.Changes) > 0 {
+		fmt.Println("\nChanges seen:")
+		for _, c := range r.Changes {
+			fmt.Printf("  %s  %s → %s (%s)\n",
+				c.SeenAt.Local().Format("2006-01-02"),
+				c.From.Local().Format("Jan 02 15:04"),
+				c.To.Local().Format("Jan 02 15:04"),
+				formatDeadlineShift(c.Shift))
+		}
+	}
+}
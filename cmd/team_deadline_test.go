@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestRegularDeadline(t *testing.T) {
+	locked := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	days := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		team    api.Team
+		session api.ProjectSessionDetail
+		want    *time.Time
+	}{
+		{"locked with duration", api.Team{LockedAt: &locked}, api.ProjectSessionDetail{TerminatingAfter: days(28)}, ptrTime(locked.AddDate(0, 0, 28))},
+		{"not locked", api.Team{}, api.ProjectSessionDetail{TerminatingAfter: days(28)}, nil},
+		{"no duration", api.Team{LockedAt: &locked}, api.ProjectSessionDetail{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := regularDeadline(&tt.team, &tt.session)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("regularDeadline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func TestObserveDeadline(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC) }
+	var history teamDeadlineState
+
+	if !observeDeadline(&history, 7, day(29), day(2)) {
+		t.Error("first observation was not recorded")
+	}
+	if observeDeadline(&history, 7, day(29), day(3)) {
+		t.Error("unchanged deadline was recorded again")
+	}
+	if !observeDeadline(&history, 7, day(31), day(10)) {
+		t.Error("extended deadline was not recorded")
+	}
+	observeDeadline(&history, 8, day(20), day(10))
+
+	changes := deadlineChanges(history.Teams["7"])
+	if len(changes) != 1 {
+		t.Fatalf("deadlineChanges() = %+v, want one change", changes)
+	}
+	c := changes[0]
+	if !c.SeenAt.Equal(day(10)) || !c.From.Equal(day(29)) || !c.To.Equal(day(31)) || c.Shift != 48*time.Hour {
+		t.Errorf("change = %+v, want day 29 -> 31 (+48h) seen on day 10", c)
+	}
+	if got := deadlineChanges(history.Teams["8"]); len(got) != 0 {
+		t.Errorf("deadlineChanges() of a single observation = %+v, want none", got)
+	}
+}
+
+func TestFormatDeadlineShift(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{72 * time.Hour, "+3d"},
+		{76 * time.Hour, "+3d 4h"},
+		{-24 * time.Hour, "-1d"},
+		{90 * time.Minute, "+1h30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatDeadlineShift(tt.d); got != tt.want {
+				t.Errorf("formatDeadlineShift(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var teamListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your project teams",
+	Long: `List the teams you have been part of, newest first, with their project,
+status, final mark and members.
+
+Examples:
+  t42 team list
+  t42 team list --project webserv
+  t42 team list --json`,
+	Args: cobra.NoArgs,
+	RunE: runTeamList,
+}
+
+func init() {
+	teamCmd.AddCommand(teamListCmd)
+
+	teamListCmd.Flags().String("project", "", "Only list the teams of this project (slug)")
+	registerColumns(teamListCmd, "teams",
+		output.Column{Header: "id", Query: ".id"},
+		output.Column{Header: "project", Query: ".project"},
+		output.Column{Header: "name", Query: ".name"},
+		output.Column{Header: "status", Query: ".status"},
+		output.Column{Header: "final_mark", Query: ".final_mark"},
+		output.Column{Header: "members", Query: "[.users[].login] | join(\" \")"},
+	)
+}
+
+// teamSummary is a team with the name of its project
+type teamSummary struct {
+	api.Team
+	Project string `json:"project"`
+}
+
+func runTeamList(cmd *cobra.Command, args []string) error {
+	projectFilter, _ := cmd.Flags().GetString("project")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	projectID := 0
+	if projectFilter != "" {
+		slug := expandProjectSlug(projectFilter)
+		project, err := client.GetProjectBySlug(ctx, slug)
+		if err != nil {
+			return fmt.Errorf("failed to get project %s: %w", slug, err)
+		}
+		projectID = project.ID
+	}
+
+	allTeams, err := client.UserTeamsPaginator(ctx, me.ID, &api.ListTeamsOptions{Sort: "-created_at"}).All()
+	if err != nil {
+		return fmt.Errorf("failed to list your teams: %w", err)
+	}
+	var teams []api.Team
+	for _, team := range allTeams {
+		if projectID == 0 || team.ProjectID == projectID {
+			teams = append(teams, team)
+		}
+	}
+
+	summaries := summarizeTeams(ctx, client, teams)
+
+	if done, err := emitOutput(output.Field{Key: "teams", Value: summaries}); done || err != nil {
+		return err
+	}
+
+	printTeamList(summaries)
+	return nil
+}
+
+// summarizeTeams adds the project names to teams
+func summarizeTeams(ctx context.Context, client *api.Client, teams []api.Team) []teamSummary {
+	var ids []int
+	for _, team := range teams {
+		ids = append(ids, team.ProjectID)
+	}
+	names := resolveProjectNames(ctx, client, ids)
+
+	summaries := make([]teamSummary, len(teams))
+	for i, team := range teams {
+		summaries[i] = teamSummary{Team: team, Project: names[team.ProjectID]}
+		if summaries[i].Project == "" {
+			summaries[i].Project = "project " + strconv.Itoa(team.ProjectID)
+		}
+	}
+	return summaries
+}
+
+// teamMembers returns the logins of a team's members
+func teamMembers(team api.Team) []string {
+	logins := make([]string, len(team.Users))
+	for i, u := range team.Users {
+		logins[i] = u.Login
+	}
+	return logins
+}
+
+// formatTeamMark formats a team's final mark and whether it validated
+func formatTeamMark(team api.Team) string {
+	if team.FinalMark == nil {
+		return "-"
+	}
+	mark := strconv.Itoa(*team.FinalMark)
+	if team.Validated != nil {
+		if *team.Validated {
+			mark += " ✅"
+		} else {
+			mark += " ❌"
+		}
+	}
+	return mark
+}
+
+func printTeamList(teams []teamSummary) {
+	if len(teams) == 0 {
+		fmt.Println("No teams found.")
+		return
+	}
+
+	fmt.Printf("%-9s %-24s %-22s %-22s %-8s %s\n", "ID", "PROJECT", "TEAM", "STATUS", "MARK", "MEMBERS")
+	fmt.Println(strings.Repeat("-", 110))
+	for _, t := range teams {
+		fmt.Printf("%-9d %-24s %-22s %-22s %-8s %s\n",
+			t.ID, truncateString(t.Project, 22), truncateString(t.Name, 20), truncateString(t.Status, 20),
+			formatTeamMark(t.Team), strings.Join(teamMembers(t.Team), ", "))
+	}
+	fmt.Printf("\n👥 %d team(s)\n", len(teams))
+}
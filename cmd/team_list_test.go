@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestFormatTeamMark(t *testing.T) {
+	mark, zero := 115, 0
+	yes, no := true, false
+	tests := []struct {
+		name string
+		team api.Team
+		want string
+	}{
+		{name: "not marked", team: api.Team{}, want: "-"},
+		{name: "validated", team: api.Team{FinalMark: &mark, Validated: &yes}, want: "115 ✅"},
+		{name: "failed", team: api.Team{FinalMark: &zero, Validated: &no}, want: "0 ❌"},
+		{name: "marked without validation", team: api.Team{FinalMark: &mark}, want: "115"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatTeamMark(tt.team); got != tt.want {
+				t.Errorf("formatTeamMark() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+var teamShowCmd = &cobra.Command{
+	Use:   "show [team-id]",
+	Short: "Show a team's members, repository, dates and mark",
+	Long: `Show a team: its members, repository URL, when it was locked and closed,
+its deadline and its final mark.
+
+The team is the one with the given ID, your latest team for --project,
+or the team whose repository is the origin remote of the current
+directory.
+
+Examples:
+  t42 team show 4242424
+  t42 team show --project ft_transcendence
+  t42 team show --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTeamShow,
+}
+
+func init() {
+	teamCmd.AddCommand(teamShowCmd)
+
+	teamShowCmd.Flags().String("project", "", "Show your latest team for this project (slug)")
+}
+
+func runTeamShow(cmd *cobra.Command, args []string) error {
+	projectFilter, _ := cmd.Flags().GetString("project")
+	teamID := 0
+	if len(args) > 0 {
+		if projectFilter != "" {
+			return fmt.Errorf("give either a team ID or --project, not both")
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid team ID %q", args[0])
+		}
+		teamID = id
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	team, err := findTeam(ctx, client, teamID, projectFilter)
+	if err != nil {
+		return err
+	}
+	summary := summarizeTeams(ctx, client, []api.Team{*team})[0]
+
+	if done, err := emitOutputValue(summary); done || err != nil {
+		return err
+	}
+
+	printTeamDetails(summary, time.Now())
+	return nil
+}
+
+// findTeam returns the team with the given ID, your latest team for a
+// project, or the team of the current directory's repository
+func findTeam(ctx context.Context, client *api.Client, teamID int, projectSlug string) (*api.Team, error) {
+	if projectSlug == "" {
+		return findRepoTeam(ctx, client, ".", teamID)
+	}
+
+	slug := expandProjectSlug(projectSlug)
+	project, err := client.GetProjectBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", slug, err)
+	}
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	return findProjectTeam(ctx, client, me.ID, project.ID)
+}
+
+func printTeamDetails(t teamSummary, now time.Time) {
+	fmt.Printf("👥 Team: %s (%s)\n", t.Name, t.Project)
+	fmt.Println(strings.Repeat("=", 40))
+
+	const layout = "Mon Jan 02 2006 15:04"
+	fmt.Printf("ID:           %d\n", t.ID)
+	fmt.Printf("Status:       %s\n", t.Status)
+	fmt.Printf("Members:      %s\n", strings.Join(teamMembers(t.Team), ", "))
+	if t.RepoURL != "" {
+		fmt.Printf("Repository:   %s\n", t.RepoURL)
+	}
+	fmt.Printf("Created:      %s\n", t.CreatedAt.Local().Format(layout))
+	if t.LockedAt != nil {
+		fmt.Printf("Locked:       %s\n", t.LockedAt.Local().Format(layout))
+	} else {
+		fmt.Println("Locked:       not yet")
+	}
+	if t.TerminatingAt != nil {
+		deadline := t.TerminatingAt.Local().Format(layout)
+		if remaining := t.TerminatingAt.Sub(now); remaining > 0 && !t.Closed {
+			deadline += " (" + formatTimeUntil(remaining) + ")"
+		}
+		fmt.Printf("Deadline:     %s\n", deadline)
+	}
+	if t.ClosedAt != nil {
+		fmt.Printf("Closed:       %s\n", t.ClosedAt.Local().Format(layout))
+	}
+	fmt.Printf("Final mark:   %s\n", formatTeamMark(t.Team))
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunTeamShowArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		project string
+		wantErr string
+	}{
+		{name: "invalid team ID", args: []string{"abc"}, wantErr: "invalid team ID"},
+		{name: "negative team ID", args: []string{"-3"}, wantErr: "invalid team ID"},
+		{name: "team ID and project", args: []string{"42"}, project: "webserv", wantErr: "not both"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := teamShowCmd.Flags().Set("project", tt.project); err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = teamShowCmd.Flags().Set("project", "") }()
+
+			err := runTeamShow(teamShowCmd, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runTeamShow() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
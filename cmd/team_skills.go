@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// teamSkillsWorkers bounds the profiles fetched concurrently
+const teamSkillsWorkers = 4
+
+var teamSkillsCmd = &cobra.Command{
+	Use:   "skills <project>",
+	Short: "Compare a team's cursus skills with those of students who validated a project",
+	Long: `Average the cursus skills of a team and compare them with the skills
+typically reached by students who validated the project, highlighting the
+skills where the team falls short.
+
+The team is your latest team for the project, or the logins listed in
+--logins-file (one per line, # starts a comment) to look at a whole cohort.
+The typical levels are the average current skills of the --sample students
+who validated the project most recently. A skill is a gap when the team's
+average is at least --min-gap levels below the typical one.
+
+Examples:
+  t42 team skills webserv
+  t42 team skills ft_transcendence --logins-file cohort.txt
+  t42 team skills minishell --sample 40 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTeamSkills,
+}
+
+func init() {
+	teamCmd.AddCommand(teamSkillsCmd)
+
+	teamSkillsCmd.Flags().String("logins-file", "", "File listing the logins to compare, one per line (default: your team for the project)")
+	teamSkillsCmd.Flags().Int("sample", 20, "Number of students who validated the project to take the typical levels from")
+	teamSkillsCmd.Flags().Float64("min-gap", 1, "Flag skills where the team is at least this many levels below the typical level")
+	teamSkillsCmd.Flags().Int("cursus-id", 0, "Cursus whose skills are compared (default: your current cursus)")
+}
+
+// memberSkills is the skills of one member in the compared cursus
+type memberSkills struct {
+	Login  string             `json:"login"`
+	Skills map[string]float64 `json:"skills"`
+}
+
+// skillComparison compares the team's level in one skill with the typical one
+type skillComparison struct {
+	Name        string  `json:"name"`
+	TeamAverage float64 `json:"team_average"`
+	Typical     float64 `json:"typical"`
+	Difference  float64 `json:"difference"`
+	Gap         bool    `json:"gap"`
+	Weakest     string  `json:"weakest,omitempty"`
+}
+
+func runTeamSkills(cmd *cobra.Command, args []string) error {
+	projectSlug := expandProjectSlug(args[0])
+	loginsFile, _ := cmd.Flags().GetString("logins-file")
+	sample, _ := cmd.Flags().GetInt("sample")
+	minGap, _ := cmd.Flags().GetFloat64("min-gap")
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+	if sample < 1 {
+		return fmt.Errorf("--sample must be at least 1")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	project, err := client.GetProjectBySlug(ctx, projectSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get project %s: %w", projectSlug, err)
+	}
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get your profile: %w", err)
+	}
+	if cursusID == 0 {
+		cursusUser := findCursusUser(me.CursusUsers, 0)
+		if cursusUser == nil {
+			return fmt.Errorf("you have no cursus - use --cursus-id")
+		}
+		cursusID = cursusUser.Cursus.ID
+	}
+
+	var logins []string
+	teamName := ""
+	if loginsFile != "" {
+		if logins, err = readLoginsFile(loginsFile); err != nil {
+			return err
+		}
+		teamName = loginsFile
+	} else {
+		team, err := findProjectTeam(ctx, client, me.ID, project.ID)
+		if err != nil {
+			return err
+		}
+		teamName = team.Name
+		for _, u := range team.Users {
+			logins = append(logins, u.Login)
+		}
+	}
+	if len(logins) == 0 {
+		return fmt.Errorf("no logins to compare")
+	}
+
+	members, err := fetchMemberSkills(ctx, client, logins, cursusID)
+	if err != nil {
+		return err
+	}
+	validators, err := fetchValidatorLogins(ctx, client, project.ID, cursusID, sample)
+	if err != nil {
+		return err
+	}
+	typical, err := fetchMemberSkills(ctx, client, validators, cursusID)
+	if err != nil {
+		return err
+	}
+	comparisons := compareSkills(members, typical, minGap)
+
+	if done, err := emitOutput(
+		output.Field{Key: "project", Value: project.Slug},
+		output.Field{Key: "team", Value: teamName},
+		output.Field{Key: "cursus_id", Value: cursusID},
+		output.Field{Key: "members", Value: members},
+		output.Field{Key: "sample_size", Value: len(typical)},
+		output.Field{Key: "skills", Value: comparisons},
+	); done || err != nil {
+		return err
+	}
+
+	printTeamSkills(project.Name, teamName, members, len(typical), comparisons)
+	return nil
+}
+
+// readLoginsFile reads one login per line, skipping blank lines and
+// # comments. Only the first column of CSV and TSV lines counts, and a
+// "login" header is skipped, so a CSV export of logins can be read back.
+func readLoginsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open logins file: %w", err)
+	}
+	defer f.Close()
+
+	var logins []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		login, _, _ := strings.Cut(line, ",")
+		login, _, _ = strings.Cut(login, "\t")
+		login = strings.ToLower(strings.TrimSpace(login))
+		if login != "" && login != "login" && !seen[login] {
+			seen[login] = true
+			logins = append(logins, login)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read logins file: %w", err)
+	}
+	return logins, nil
+}
+
+// errNoProjectTeam is returned by findProjectTeam for a project the user
+// has no team for
+var errNoProjectTeam = errors.New("you have no team for this project")
+
+// findProjectTeam returns the latest team of a user for a project
+func findProjectTeam(ctx context.Context, client *api.Client, userID, projectID int) (*api.Team, error) {
+	for team, err := range client.UserTeamsPaginator(ctx, userID, &api.ListTeamsOptions{Sort: "-created_at"}).Items() {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list your teams: %w", err)
+		}
+		if team.ProjectID == projectID {
+			return &team, nil
+		}
+	}
+	return nil, errNoProjectTeam
+}
+
+// fetchValidatorLogins returns up to n students of a cursus who validated
+// a project, most recent first
+func fetchValidatorLogins(ctx context.Context, client *api.Client, projectID, cursusID, n int) ([]string, error) {
+	var logins []string
+	validations := client.ProjectsUsersPaginator(ctx, &api.ListProjectsUsersOptions{
+		ProjectID: projectID,
+		CursusID:  cursusID,
+		Status:    "finished",
+		Sort:      "-marked_at",
+	})
+	for pu, err := range validations.Items() {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list students who validated the project: %w", err)
+		}
+		if pu.Validated != nil && *pu.Validated && pu.User.Login != "" {
+			logins = append(logins, pu.User.Login)
+			if len(logins) == n {
+				break
+			}
+		}
+	}
+	if len(logins) == 0 {
+		return nil, fmt.Errorf("no student has validated this project in cursus %d yet", cursusID)
+	}
+	return logins, nil
+}
+
+// fetchMemberSkills fetches the cursus skills of users, skipping the ones
+// who are not in the cursus
+func fetchMemberSkills(ctx context.Context, client *api.Client, logins []string, cursusID int) ([]memberSkills, error) {
+	type result struct {
+		member *memberSkills
+		err    error
+	}
+	results := runWorkers(logins, teamSkillsWorkers, func(login string) result {
+		user, err := client.GetUserByLogin(ctx, login)
+		if err != nil {
+			return result{err: fmt.Errorf("failed to get user %s: %w", login, err)}
+		}
+		cursusUser := findCursusUser(user.CursusUsers, cursusID)
+		if cursusUser == nil {
+			return result{}
+		}
+		member := &memberSkills{Login: user.Login, Skills: make(map[string]float64)}
+		for _, s := range cursusUser.Skills {
+			member.Skills[s.Name] = s.Level
+		}
+		return result{member: member}
+	})
+
+	var members []memberSkills
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		if r.member != nil {
+			members = append(members, *r.member)
+		}
+	}
+	return members, nil
+}
+
+// compareSkills averages the skills of the team and of the typical
+// students, sorted with the largest gaps first. A member without a skill
+// counts as level 0 in it.
+func compareSkills(team, typical []memberSkills, minGap float64) []skillComparison {
+	names := make(map[string]bool)
+	for _, group := range [][]memberSkills{team, typical} {
+		for _, m := range group {
+			for name := range m.Skills {
+				names[name] = true
+			}
+		}
+	}
+
+	comparisons := make([]skillComparison, 0, len(names))
+	for name := range names {
+		c := skillComparison{
+			Name:        name,
+			TeamAverage: averageSkill(team, name),
+			Typical:     averageSkill(typical, name),
+		}
+		c.Difference = c.TeamAverage - c.Typical
+		c.Gap = len(team) > 0 && -c.Difference >= minGap
+		weakest := -1.0
+		for _, m := range team {
+			if level := m.Skills[name]; weakest < 0 || level < weakest {
+				weakest, c.Weakest = level, m.Login
+			}
+		}
+		comparisons = append(comparisons, c)
+	}
+
+	sort.Slice(comparisons, func(i, j int) bool {
+		if comparisons[i].Difference != comparisons[j].Difference {
+			return comparisons[i].Difference < comparisons[j].Difference
+		}
+		return comparisons[i].Name < comparisons[j].Name
+	})
+	return comparisons
+}
+
+// averageSkill returns the average level of a group in a skill
+func averageSkill(group []memberSkills, name string) float64 {
+	if len(group) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, m := range group {
+		total += m.Skills[name]
+	}
+	return total / float64(len(group))
+}
+
+func printTeamSkills(projectName, teamName string, members []memberSkills, sampleSize int, comparisons []skillComparison) {
+	logins := make([]string, len(members))
+	for i, m := range members {
+		logins[i] = m.Login
+	}
+	fmt.Printf("🧠 Skills of %s for %s\n", teamName, projectName)
+	fmt.Printf("Members: %s\n", strings.Join(logins, ", "))
+	fmt.Printf("Typical: %d students who validated the project\n\n", sampleSize)
+	if len(members) == 0 {
+		fmt.Println("None of the members is in this cursus.")
+		return
+	}
+
+	// Bars are relative to the highest level shown
+	top := 0.0
+	for _, c := range comparisons {
+		top = max(top, c.TeamAverage, c.Typical)
+	}
+	fraction := func(level float64) float64 {
+		if top == 0 {
+			return 0
+		}
+		return level / top
+	}
+
+	fmt.Printf("%-28s %6s %-12s %7s %7s\n", "SKILL", "TEAM", "", "TYPICAL", "DIFF")
+	fmt.Println(strings.Repeat("-", 66))
+	gaps := 0
+	for _, c := range comparisons {
+		line := fmt.Sprintf("%-28s %6.2f %-12s %7.2f %+7.2f",
+			truncateString(c.Name, 28), c.TeamAverage, renderBar(fraction(c.TeamAverage), 12), c.Typical, c.Difference)
+		if c.Gap {
+			gaps++
+			line += fmt.Sprintf("  ⚠️  gap (weakest: %s)", c.Weakest)
+		}
+		fmt.Println(line)
+	}
+
+	if gaps == 0 {
+		fmt.Println("\n✅ No skill gap compared with students who validated the project.")
+	} else {
+		fmt.Printf("\n⚠️  %d skill gap(s) compared with students who validated the project.\n", gaps)
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompareSkills(t *testing.T) {
+	team := []memberSkills{
+		{Login: "alice", Skills: map[string]float64{"Web": 2, "Unix": 8}},
+		{Login: "bob", Skills: map[string]float64{"Web": 4, "Unix": 6, "Rigor": 5}},
+	}
+	typical := []memberSkills{
+		{Login: "carol", Skills: map[string]float64{"Web": 6, "Unix": 6, "Rigor": 4}},
+		{Login: "dave", Skills: map[string]float64{"Web": 6, "Unix": 8, "Rigor": 2}},
+	}
+
+	got := compareSkills(team, typical, 1)
+	want := []skillComparison{
+		{Name: "Web", TeamAverage: 3, Typical: 6, Difference: -3, Gap: true, Weakest: "alice"},
+		{Name: "Rigor", TeamAverage: 2.5, Typical: 3, Difference: -0.5, Weakest: "alice"},
+		{Name: "Unix", TeamAverage: 7, Typical: 7, Difference: 0, Weakest: "bob"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("compareSkills() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	t.Run("no members", func(t *testing.T) {
+		for _, c := range compareSkills(nil, typical, 1) {
+			if c.Gap {
+				t.Errorf("compareSkills() without members flagged a gap in %s", c.Name)
+			}
+		}
+	})
+}
+
+func TestReadLoginsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cohort.txt")
+	content := "# cohort of march\nalice\n\n  Bob  # pair with alice\nalice\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readLoginsFile(path)
+	if err != nil {
+		t.Fatalf("readLoginsFile() error = %v", err)
+	}
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readLoginsFile() = %v, want %v", got, want)
+	}
+
+	csvPath := filepath.Join(t.TempDir(), "candidates.csv")
+	if err := os.WriteFile(csvPath, []byte("login,email,level\ncarol,carol@example.com,7.1\ndave\t6\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = readLoginsFile(csvPath)
+	if err != nil {
+		t.Fatalf("readLoginsFile() error = %v", err)
+	}
+	if want := []string{"carol", "dave"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readLoginsFile() of CSV = %v, want %v", got, want)
+	}
+
+	if _, err := readLoginsFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("readLoginsFile() of a missing file succeeded")
+	}
+}
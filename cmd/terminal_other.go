@@ -0,0 +1,9 @@
+//go:build !windows
+
+package cmd
+
+// enableVirtualTerminal is a no-op: terminals on other platforms process
+// ANSI escape sequences natively
+func enableVirtualTerminal() bool {
+	return true
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	// Query strings contain "&", which cmd.exe would treat as a separator
+	url := "https://api.intra.42.fr/oauth/authorize?client_id=abc&redirect_uri=http%3A%2F%2F127.0.0.1%3A8080%2Fcallback&response_type=code"
+
+	tests := []struct {
+		goos     string
+		wantName string
+		wantArgs []string
+	}{
+		{"windows", "rundll32", []string{"url.dll,FileProtocolHandler", url}},
+		{"darwin", "open", []string{url}},
+		{"linux", "xdg-open", []string{url}},
+		{"freebsd", "xdg-open", []string{url}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args := browserCommand(tt.goos, url)
+			if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("browserCommand() = %s %v, want %s %v", name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal turns on ANSI escape sequence processing for the
+// console attached to stdout and stderr. It returns false when a console
+// does not support it (conhost before Windows 10), in which case escape
+// sequences would be printed as garbage.
+func enableVirtualTerminal() bool {
+	supported := true
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		handle := windows.Handle(f.Fd())
+
+		var mode uint32
+		if err := windows.GetConsoleMode(handle, &mode); err != nil {
+			// Not a console (redirected to a file or pipe): nothing to enable
+			continue
+		}
+		if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+			continue
+		}
+		if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+			supported = false
+		}
+	}
+	return supported
+}
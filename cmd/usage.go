@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+	"github.com/naokiiida/t42-cli/internal/state"
+	"github.com/naokiiida/t42-cli/internal/usage"
+)
+
+// usageValueFlags are the flags whose values are counted, by usage key.
+// Only settings that say nothing about other people are recorded: never
+// logins, search terms or free text.
+var usageValueFlags = map[string]string{
+	"cursus-id": "cursus",
+	"cursus":    "cursus",
+}
+
+// usageStatsEnabled reports whether local usage stats are on, either via
+// T42_USAGE_STATS or the usage_stats config setting
+func usageStatsEnabled() bool {
+	if env := os.Getenv("T42_USAGE_STATS"); env != "" {
+		return env == "1" || env == "true"
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return false
+	}
+	return cfg.UsageStats
+}
+
+// usageCommandPath returns the command path without the program name,
+// e.g. "user list"
+func usageCommandPath(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// recordCommandUsage counts a run of cmd and the flags set on it when
+// usage stats are enabled
+func recordCommandUsage(cmd *cobra.Command) {
+	if cmd == cmd.Root() || !usageStatsEnabled() {
+		return
+	}
+
+	var flags []string
+	values := map[string]string{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		flags = append(flags, f.Name)
+		if key, ok := usageValueFlags[f.Name]; ok && f.Value.String() != "0" {
+			values[key] = f.Value.String()
+		}
+	})
+
+	updateUsageStats(func(stats *usage.Stats) {
+		stats.RecordCommand(usageCommandPath(cmd), flags)
+		for key, value := range values {
+			stats.RecordValue(key, value)
+		}
+	})
+}
+
+// recordUsageValue counts one use of value for the setting key, such as
+// the ID of a resolved campus, when usage stats are enabled
+func recordUsageValue(key, value string) {
+	if !usageStatsEnabled() {
+		return
+	}
+	updateUsageStats(func(stats *usage.Stats) {
+		stats.RecordValue(key, value)
+	})
+}
+
+// updateUsageStats loads, changes and saves the usage stats. Failures only
+// show with --verbose: stats must never get in the way of a command.
+func updateUsageStats(update func(*usage.Stats)) {
+	err := func() error {
+		store, err := state.Open()
+		if err != nil {
+			return err
+		}
+		stats, err := usage.Load(store, time.Now())
+		if err != nil {
+			return err
+		}
+		update(stats)
+		return usage.Save(store, stats)
+	}()
+	if err != nil && GetVerbose() {
+		fmt.Fprintf(os.Stderr, "Failed to record usage stats: %v\n", err)
+	}
+}
+
+// preferredUsageValue returns the most used value of the setting key, or
+// "" when usage stats are off or nothing was recorded
+func preferredUsageValue(key string) string {
+	if !usageStatsEnabled() {
+		return ""
+	}
+	store, err := state.Open()
+	if err != nil {
+		return ""
+	}
+	stats, err := usage.Load(store, time.Now())
+	if err != nil {
+		return ""
+	}
+	value, _ := stats.TopValue(key)
+	return value
+}
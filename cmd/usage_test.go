@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/state"
+	"github.com/naokiiida/t42-cli/internal/usage"
+)
+
+func TestRecordCommandUsage(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	root := &cobra.Command{Use: "t42"}
+	parent := &cobra.Command{Use: "user"}
+	list := &cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}
+	list.Flags().Int("cursus-id", 0, "")
+	list.Flags().String("login", "", "")
+	root.AddCommand(parent)
+	parent.AddCommand(list)
+	if err := list.Flags().Parse([]string{"--cursus-id", "21", "--login", "someone"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Setenv("T42_USAGE_STATS", "0")
+		recordCommandUsage(list)
+		if got := loadUsageStats(t); len(got.Commands) != 0 {
+			t.Errorf("recorded %v with usage stats off", got.Commands)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Setenv("T42_USAGE_STATS", "1")
+		recordCommandUsage(list)
+		got := loadUsageStats(t)
+		if got.Commands["user list"] != 1 {
+			t.Errorf("Commands = %v, want user list counted", got.Commands)
+		}
+		if got.Flags["user list --cursus-id"] != 1 || got.Flags["user list --login"] != 1 {
+			t.Errorf("Flags = %v, want --cursus-id and --login counted", got.Flags)
+		}
+		if got.Values["cursus"]["21"] != 1 {
+			t.Errorf("Values = %v, want cursus 21 counted", got.Values)
+		}
+		if _, ok := got.Values["login"]; ok {
+			t.Error("recorded the value of --login")
+		}
+		if got := preferredUsageValue("cursus"); got != "21" {
+			t.Errorf("preferredUsageValue(cursus) = %q, want 21", got)
+		}
+	})
+}
+
+func loadUsageStats(t *testing.T) *usage.Stats {
+	t.Helper()
+	store, err := state.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := usage.Load(store, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stats
+}
+
+func TestPreferredCampusIndex(t *testing.T) {
+	campuses := []api.Campus{{ID: 1, Name: "Paris"}, {ID: 26, Name: "Tokyo"}}
+
+	tests := []struct {
+		name string
+		id   string
+		want int
+	}{
+		{"listed", "26", 1},
+		{"not listed", "99", 0},
+		{"no preference", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preferredCampusIndex(campuses, tt.id); got != tt.want {
+				t.Errorf("preferredCampusIndex(%q) = %d, want %d", tt.id, got, tt.want)
+			}
+		})
+	}
+}
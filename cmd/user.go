@@ -2,8 +2,9 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"iter"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
 )
 
 var userCmd = &cobra.Command{
@@ -29,6 +31,12 @@ var listUsersCmd = &cobra.Command{
 	Short: "List users",
 	Long: `List users from the 42 API with filtering options.
 
+Without --campus, --campus-id or --cursus-id, default_campus and
+default_cursus_id from the config file are used when set, else the users of
+your own primary campus in your current cursus are listed (the campus only
+with --alumni or --non-alumni, which the cursus endpoint cannot filter).
+--global lists the users of every campus instead, in the API's order.
+
 You can filter users by:
   - Campus location (--campus or --campus-id)
   - Cursus (--cursus-id)
@@ -40,12 +48,11 @@ You can filter users by:
   - Online status (--online)
 
 Pagination:
-  When using client-side filters (--online, --min-projects, --blackhole-status),
-  the command automatically fetches multiple API pages until --limit results are
-  found. This ensures you get the expected number of results regardless of how
-  sparse the matching users are across pages.
-
-  Without client-side filters, traditional --page pagination is used.
+  --limit is the number of users shown, counted after client-side filters
+  (--online, --min-projects, --blackhole-status). The command fetches as many
+  API pages as it takes to find them, however sparse the matching users are.
+  --per-page only sets the size of each API request, and --page the page
+  to start from.
 
 Examples:
   # List users from a specific campus
@@ -61,7 +68,13 @@ Examples:
   t42 user list --campus tokyo --cursus-id 21
 
   # List online users from Tokyo campus (progressive fetch)
-  t42 user list --campus tokyo --online --limit 10`,
+  t42 user list --campus tokyo --online --limit 10
+
+  # List every user of a campus, streamed page by page
+  t42 user list --campus tokyo --all
+
+  # Search every campus instead of your own
+  t42 user list --global --staff`,
 	RunE: runListUsers,
 }
 
@@ -70,9 +83,17 @@ var showUserCmd = &cobra.Command{
 	Short: "Show user details",
 	Long: `Show detailed information about a specific user.
 
-You can specify a user by their login name (e.g., 'jdoe').`,
+You can specify a user by their login name (e.g., 'jdoe').
+
+Use --full to assemble a complete profile page: cursus levels, skill bars,
+recently validated projects, logtime this month, blackhole, titles, and
+coalition. The extra API calls run concurrently and the assembled profile
+is cached for a few minutes.
+
+--watch redraws the details periodically, e.g. to see when a friend logs in
+at the cluster: t42 user show jdoe --watch=1m`,
 	Args: cobra.ExactArgs(1),
-	RunE: runShowUser,
+	RunE: watchable(runShowUser),
 }
 
 func init() {
@@ -84,12 +105,18 @@ func init() {
 	rootCmd.AddCommand(userCmd)
 
 	// List command flags
-	listUsersCmd.Flags().IntP("limit", "l", 20, "Maximum number of users to display (auto-fetches pages when using client-side filters)")
-	listUsersCmd.Flags().IntP("page", "p", 1, "Page number (ignored when using client-side filters like --online)")
-	listUsersCmd.Flags().Int("per-page", 100, "Number of users to fetch per API request")
-	listUsersCmd.Flags().Int("campus-id", 0, "Filter by campus ID")
-	listUsersCmd.Flags().String("campus", "", "Filter by campus name (e.g., 'tokyo', 'paris')")
-	listUsersCmd.Flags().Int("cursus-id", 0, "Filter by cursus ID (default: 21 for 42cursus)")
+	addLimitFlag(listUsersCmd, 20, "Maximum number of users to display, after filtering (0 for all)")
+	listUsersCmd.Flags().IntP("page", "p", 1, "API page to start fetching from")
+	addPerPageFlag(listUsersCmd, api.DefaultPerPage)
+	listUsersCmd.Flags().Bool("all", false, "Fetch every page and print users as they arrive (ignores --limit and --page)")
+	listUsersCmd.MarkFlagsMutuallyExclusive("all", "limit")
+	listUsersCmd.MarkFlagsMutuallyExclusive("all", "page")
+	addCampusFlags(listUsersCmd)
+	listUsersCmd.Flags().Int("cursus-id", 0, "Filter by cursus ID (default: default_cursus_id from the config file, else your current cursus)")
+	listUsersCmd.Flags().Bool("global", false, "List users of every campus and cursus instead of your own")
+	listUsersCmd.MarkFlagsMutuallyExclusive("global", "campus")
+	listUsersCmd.MarkFlagsMutuallyExclusive("global", "campus-id")
+	listUsersCmd.MarkFlagsMutuallyExclusive("global", "cursus-id")
 	listUsersCmd.Flags().StringP("sort", "s", "", "Sort by field (login, created_at, updated_at)")
 	listUsersCmd.Flags().Bool("active", false, "Filter active users only")
 	listUsersCmd.Flags().Bool("inactive", false, "Filter inactive users only")
@@ -102,6 +129,19 @@ func init() {
 	listUsersCmd.Flags().Float64("min-level", 0, "Filter users with minimum cursus level")
 	listUsersCmd.Flags().Float64("max-level", 0, "Filter users with maximum cursus level")
 	listUsersCmd.Flags().Bool("online", false, "Filter online users only (currently logged in at a cluster)")
+	addInteractiveFlag(listUsersCmd)
+	listUsersCmd.MarkFlagsMutuallyExclusive("all", "interactive")
+	registerColumns(listUsersCmd, "users",
+		output.Column{Header: "login", Query: ".login"},
+		output.Column{Header: "name", Query: ".displayname"},
+		output.Column{Header: "campus", Query: ".campus[0].city"},
+		output.Column{Header: "level", Query: "[.cursus_users[].level] | max"},
+		output.Column{Header: "location", Query: ".location"},
+	)
+
+	// Show command flags
+	showUserCmd.Flags().Bool("full", false, "Show the complete profile (skills, recent projects, logtime, coalition)")
+	addWatchModeFlag(showUserCmd)
 }
 
 func runListUsers(cmd *cobra.Command, args []string) error {
@@ -114,11 +154,10 @@ func runListUsers(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
 	// Get flags
-	limit, _ := cmd.Flags().GetInt("limit")
-	page, _ := cmd.Flags().GetInt("page")
-	perPage, _ := cmd.Flags().GetInt("per-page")
-	campusID, _ := cmd.Flags().GetInt("campus-id")
-	campusName, _ := cmd.Flags().GetString("campus")
+	limits, err := limitOptions(cmd)
+	if err != nil {
+		return err
+	}
 	cursusID, _ := cmd.Flags().GetInt("cursus-id")
 	sort, _ := cmd.Flags().GetString("sort")
 	active, _ := cmd.Flags().GetBool("active")
@@ -132,60 +171,45 @@ func runListUsers(cmd *cobra.Command, args []string) error {
 	minLevel, _ := cmd.Flags().GetFloat64("min-level")
 	maxLevel, _ := cmd.Flags().GetFloat64("max-level")
 	online, _ := cmd.Flags().GetBool("online")
+	all, _ := cmd.Flags().GetBool("all")
+	global, _ := cmd.Flags().GetBool("global")
 
-	// Track resolved campus for embedding into cursus_users results
-	var resolvedCampus *api.Campus
-
-	// Resolve campus name to campus ID if provided
-	if campusName != "" {
-		campuses, err := client.ListCampuses(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to list campuses: %w", err)
-		}
+	browse, err := useBrowser(cmd)
+	if err != nil {
+		return err
+	}
 
-		campusNameLower := strings.ToLower(campusName)
-		for i := range campuses {
-			if strings.ToLower(campuses[i].Name) == campusNameLower ||
-				strings.ToLower(campuses[i].City) == campusNameLower {
-				campusID = campuses[i].ID
-				resolvedCampus = &campuses[i]
-				break
-			}
+	// Resolve --campus/--campus-id or default_campus; the campus is also
+	// embedded into cursus_users results
+	var resolvedCampus *api.Campus
+	if !global {
+		if resolvedCampus, err = resolveCampusOrDefault(ctx, cmd, client); err != nil {
+			return err
 		}
-
-		if campusID == 0 {
-			// Build list of available campus names for error message
-			var campusOptions []string
-			for _, campus := range campuses {
-				label := campus.Name
-				cityLower := strings.ToLower(campus.City)
-				nameLower := strings.ToLower(campus.Name)
-				if campus.City != "" && cityLower != nameLower {
-					label = fmt.Sprintf("%s (%s)", campus.Name, campus.City)
-				}
-				campusOptions = append(campusOptions, label)
-			}
-			// Show first 10 options to avoid overwhelming output
-			if len(campusOptions) > 10 {
-				return fmt.Errorf("campus %q not found. Some available campuses: %s, ... (use --campus-id for full list)",
-					campusName, strings.Join(campusOptions[:10], ", "))
-			}
-			return fmt.Errorf("campus %q not found. Available campuses: %s",
-				campusName, strings.Join(campusOptions, ", "))
+		// The cursus endpoint cannot filter alumni: a default cursus would
+		// only get in the way
+		if !alumni && !nonAlumni {
+			cursusID = cursusIDOrDefault(cmd, 0)
 		}
 	}
 
-	// If campus-id was specified directly (without --campus), resolve the campus info
-	if campusID > 0 && resolvedCampus == nil {
-		campuses, err := client.ListCampuses(ctx)
-		if err == nil {
-			for i := range campuses {
-				if campuses[i].ID == campusID {
-					resolvedCampus = &campuses[i]
-					break
-				}
-			}
+	// The raw /v2/users listing mixes every campus in no useful order:
+	// without a scope, assume the user's own
+	if !global && resolvedCampus == nil && cursusID == 0 {
+		me, err := client.GetMe(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get your profile: %w", err)
 		}
+		var cursusName string
+		resolvedCampus, cursusID, cursusName = defaultUserListScope(me, alumni || nonAlumni)
+		if resolvedCampus == nil && cursusID == 0 {
+			return fmt.Errorf("you have no campus or cursus - use --campus, --cursus-id or --global")
+		}
+		fmt.Fprintf(os.Stderr, "🔎 Listing users of %s (use --global for every campus)\n", describeUserListScope(resolvedCampus, cursusName))
+	}
+	campusID := 0
+	if resolvedCampus != nil {
+		campusID = resolvedCampus.ID
 	}
 
 	// Validate mutually exclusive flags
@@ -203,8 +227,8 @@ func runListUsers(cmd *cobra.Command, args []string) error {
 
 	// Build options
 	opts := &api.ListUsersOptions{
-		Page:           page,
-		PerPage:        perPage,
+		Page:           limits.Page,
+		PerPage:        limits.PerPage,
 		FilterCampusID: campusID,
 		FilterCursusID: cursusID,
 		Sort:           sort,
@@ -245,10 +269,9 @@ func runListUsers(cmd *cobra.Command, args []string) error {
 		online:          online,
 	}
 
-	// List users - use cursus_users endpoint when cursus filtering is needed for full data
-	var filteredUsers []api.User
-	var meta *api.PaginationMeta
-	var totalFetched int
+	if all {
+		return listAllUsers(ctx, client, campusID, resolvedCampus, opts, criteria)
+	}
 
 	// Use ListCursusUsers when cursusID is specified.
 	// This endpoint provides full cursus data (level, blackhole, grade) needed for:
@@ -256,133 +279,196 @@ func runListUsers(cmd *cobra.Command, args []string) error {
 	// - Blackhole status filtering (--blackhole-status)
 	// Without cursusID, we fall back to basic user endpoints where these filters
 	// have limited effect since cursus data may be incomplete.
-
-	if criteria.hasClientSideFilters() {
-		// Progressive fetch: keep fetching pages until we have enough filtered results
-		currentPage := 1
-		for len(filteredUsers) < limit {
-			var users []api.User
-			var pageMeta *api.PaginationMeta
-
-			if cursusID > 0 {
-				cursusOpts := &api.ListCursusUsersOptions{
-					Page:         currentPage,
-					PerPage:      perPage,
-					CampusID:     campusID,
-					Sort:         sort,
-					FilterActive: opts.FilterActive,
-					MinLevel:     minLevel,
-					MaxLevel:     maxLevel,
-				}
-				cursusUsers, cursusMeta, fetchErr := client.ListCursusUsers(ctx, cursusID, cursusOpts)
-				if fetchErr != nil {
-					return fmt.Errorf("failed to list cursus users: %w", fetchErr)
-				}
-				users = convertCursusUsersToUsers(cursusUsers, cursusID, resolvedCampus)
-				pageMeta = cursusMeta
-			} else if campusID > 0 {
-				opts.Page = currentPage
-				opts.PerPage = perPage
-				var fetchErr error
-				users, pageMeta, fetchErr = client.ListCampusUsers(ctx, campusID, opts)
-				if fetchErr != nil {
-					return fmt.Errorf("failed to list users: %w", fetchErr)
-				}
-			} else {
-				opts.Page = currentPage
-				opts.PerPage = perPage
-				var fetchErr error
-				users, pageMeta, fetchErr = client.ListUsers(ctx, opts)
-				if fetchErr != nil {
-					return fmt.Errorf("failed to list users: %w", fetchErr)
-				}
-			}
-
-			totalFetched += len(users)
-			meta = pageMeta
-
-			// Apply client-side filters
-			filtered := filterUsers(users, criteria)
-			filteredUsers = append(filteredUsers, filtered...)
-
-			// Stop if no more pages
-			if len(users) < perPage || (meta != nil && currentPage >= meta.TotalPages) {
-				break
-			}
-			currentPage++
-		}
-
-		// Trim to limit
-		if len(filteredUsers) > limit {
-			filteredUsers = filteredUsers[:limit]
-		}
-	} else {
-		// Single page fetch (no client-side filters)
-		var users []api.User
-
+	fetch := func(ctx context.Context, page int) ([]api.User, *api.PaginationMeta, error) {
 		if cursusID > 0 {
 			cursusOpts := &api.ListCursusUsersOptions{
 				Page:         page,
-				PerPage:      perPage,
+				PerPage:      limits.PerPage,
 				CampusID:     campusID,
 				Sort:         sort,
 				FilterActive: opts.FilterActive,
 				MinLevel:     minLevel,
 				MaxLevel:     maxLevel,
 			}
-			cursusUsers, cursusMeta, fetchErr := client.ListCursusUsers(ctx, cursusID, cursusOpts)
-			if fetchErr != nil {
-				return fmt.Errorf("failed to list cursus users: %w", fetchErr)
-			}
-			users = convertCursusUsersToUsers(cursusUsers, cursusID, resolvedCampus)
-			meta = cursusMeta
-		} else if campusID > 0 {
-			var fetchErr error
-			users, meta, fetchErr = client.ListCampusUsers(ctx, campusID, opts)
-			if fetchErr != nil {
-				return fmt.Errorf("failed to list users: %w", fetchErr)
+			cursusUsers, cursusMeta, err := client.ListCursusUsers(ctx, cursusID, cursusOpts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list cursus users: %w", err)
 			}
+			return convertCursusUsersToUsers(cursusUsers, cursusID, resolvedCampus), cursusMeta, nil
+		}
+
+		opts.Page = page
+		var users []api.User
+		var meta *api.PaginationMeta
+		var err error
+		if campusID > 0 {
+			users, meta, err = client.ListCampusUsers(ctx, campusID, opts)
 		} else {
-			var fetchErr error
-			users, meta, fetchErr = client.ListUsers(ctx, opts)
-			if fetchErr != nil {
-				return fmt.Errorf("failed to list users: %w", fetchErr)
-			}
+			users, meta, err = client.ListUsers(ctx, opts)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list users: %w", err)
 		}
+		return users, meta, nil
+	}
 
-		totalFetched = len(users)
-		filteredUsers = filterUsers(users, criteria)
+	// Pages are fetched until --limit users pass the client-side filters
+	result, err := api.FetchLimit(ctx, limits, fetch, func(users []api.User) []api.User {
+		return filterUsers(users, criteria)
+	})
+	if err != nil {
+		return err
 	}
+	filteredUsers, meta, totalFetched := result.Items, result.Meta, result.Fetched
 
-	if GetJSONOutput() {
-		filterInfo := map[string]interface{}{
-			"filtered_count": len(filteredUsers),
-			"total_fetched":  totalFetched,
-			"limit":          limit,
+	filterInfo := map[string]interface{}{
+		"filtered_count": len(filteredUsers),
+		"total_fetched":  totalFetched,
+		"limit":          limits.Limit,
+		"per_page":       limits.PerPage,
+		"more":           result.More,
+	}
+	if criteria.hasClientSideFilters() {
+		filterInfo["mode"] = "progressive_fetch"
+		filterInfo["note"] = "Progressive fetch used: fetched multiple pages until limit reached"
+	} else {
+		filterInfo["mode"] = "server_side"
+		filterInfo["note"] = "meta reflects the last page fetched"
+	}
+	// Stream the user list instead of encoding it in one buffer: large
+	// campuses produce result sets of several megabytes
+	done, err := emitOutput(
+		output.Field{Key: "filter_info", Value: filterInfo},
+		output.Field{Key: "meta", Value: meta},
+		output.Field{Key: "users", Value: filteredUsers},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	if !done {
+		// Don't show PROJECTS column when using cursus_users endpoint (no project data available)
+		showProjects := cursusID == 0
+		if browse {
+			return browseUsers(client, filteredUsers, cursusID, showProjects)
 		}
-		if criteria.hasClientSideFilters() {
-			filterInfo["mode"] = "progressive_fetch"
-			filterInfo["note"] = "Progressive fetch used: fetched multiple pages until limit reached"
-		} else {
-			filterInfo["mode"] = "single_page"
-			filterInfo["note"] = "meta reflects server-side pagination"
+		printUsersTableWithMode(filteredUsers, meta, cursusID, showProjects, criteria.hasClientSideFilters(), totalFetched, limits.Limit, result.More)
+	}
+
+	return nil
+}
+
+// listAllUsers iterates over every user and prints each one as it arrives,
+// so memory stays bounded by a single page however large the campus is;
+// client-side filters are applied on the fly
+func listAllUsers(ctx context.Context, client *api.Client, campusID int, campus *api.Campus, opts *api.ListUsersOptions, criteria filterCriteria) error {
+	cursusID := criteria.cursusID
+	showProjects := cursusID == 0
+
+	var users iter.Seq2[api.User, error]
+	switch {
+	case cursusID > 0:
+		cursusOpts := &api.ListCursusUsersOptions{
+			PerPage:      opts.PerPage,
+			CampusID:     campusID,
+			Sort:         opts.Sort,
+			FilterActive: opts.FilterActive,
+			MinLevel:     criteria.minLevel,
+			MaxLevel:     criteria.maxLevel,
 		}
-		output := map[string]interface{}{
-			"users":       filteredUsers,
-			"meta":        meta,
-			"filter_info": filterInfo,
+		users = func(yield func(api.User, error) bool) {
+			for cu, err := range client.ListCursusUsersIter(ctx, cursusID, cursusOpts) {
+				var user api.User
+				if err == nil {
+					user = convertCursusUsersToUsers([]api.CursusUser{cu}, cursusID, campus)[0]
+				}
+				if !yield(user, err) {
+					return
+				}
+			}
 		}
-		jsonData, err := json.MarshalIndent(output, "", "  ")
+	case campusID > 0:
+		users = client.ListCampusUsersIter(ctx, campusID, opts)
+	default:
+		users = client.ListUsersIter(ctx, opts)
+	}
+
+	// Unfiltered JSON is streamed as users arrive; other structured output,
+	// and the --out file, are written once every page is fetched
+	var stream *output.JSONStream
+	var collected []api.User
+	if GetOutputFormat() == output.FormatJSON && !outputTrimmed() && outFile == "" {
+		stream = output.NewJSONStream(os.Stdout)
+		if err := stream.BeginArray("users"); err != nil {
+			return err
+		}
+	}
+
+	totalFetched, shown := 0, 0
+	for user, err := range users {
 		if err != nil {
-			return fmt.Errorf("failed to marshal JSON output: %w", err)
+			return fmt.Errorf("failed to list users: %w", err)
 		}
-		fmt.Println(string(jsonData))
-	} else {
-		// Don't show PROJECTS column when using cursus_users endpoint (no project data available)
-		showProjects := cursusID == 0
-		printUsersTableWithMode(filteredUsers, meta, cursusID, showProjects, criteria.hasClientSideFilters(), totalFetched, limit)
+		totalFetched++
+		if GetVerbose() && opts.PerPage > 0 && totalFetched%opts.PerPage == 0 {
+			fmt.Fprintf(os.Stderr, "Fetched %d users\n", totalFetched)
+		}
+		if len(filterUsers([]api.User{user}, criteria)) == 0 {
+			continue
+		}
+
+		if stream != nil {
+			if err := stream.Element(user); err != nil {
+				return err
+			}
+		} else if collectOutput() {
+			collected = append(collected, user)
+		}
+		if stream == nil && !GetJSONOutput() {
+			if shown == 0 {
+				printUsersTableHeader(showProjects)
+			}
+			printUserRow(user, cursusID, showProjects)
+		}
+		shown++
 	}
 
+	filterInfo := map[string]interface{}{
+		"filtered_count": shown,
+		"total_fetched":  totalFetched,
+		"mode":           "all",
+		"note":           "All pages fetched",
+	}
+	if stream != nil {
+		if err := stream.EndArray(); err != nil {
+			return err
+		}
+		if err := stream.Field("filter_info", filterInfo); err != nil {
+			return err
+		}
+		if warnings := commandWarnings.List(); len(warnings) > 0 {
+			if err := stream.Field("warnings", warnings); err != nil {
+				return err
+			}
+			commandWarnings.Reset()
+		}
+		return stream.Close()
+	}
+	if done, err := emitOutput(
+		output.Field{Key: "users", Value: collected},
+		output.Field{Key: "filter_info", Value: filterInfo},
+	); done || err != nil {
+		return err
+	}
+
+	if shown == 0 {
+		fmt.Println("No users found.")
+		return nil
+	}
+	if criteria.hasClientSideFilters() {
+		fmt.Printf("\n📊 Showing %d users (fetched %d, filtered by client-side criteria)\n", shown, totalFetched)
+	} else {
+		fmt.Printf("\n📊 Showing all %d users\n", shown)
+	}
 	return nil
 }
 
@@ -397,21 +483,20 @@ func runShowUser(cmd *cobra.Command, args []string) error {
 
 	ctx := context.Background()
 
+	if full, _ := cmd.Flags().GetBool("full"); full {
+		return runShowUserFull(ctx, client, login)
+	}
+
 	// Get user by login
 	user, err := client.GetUserByLogin(ctx, login)
 	if err != nil {
 		return fmt.Errorf("failed to get user '%s': %w", login, err)
 	}
 
-	if GetJSONOutput() {
-		jsonData, err := json.MarshalIndent(user, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to marshal user to JSON: %w", err)
-		}
-		fmt.Println(string(jsonData))
-	} else {
-		printUserDetails(user)
+	if done, err := emitOutputValue(user); done || err != nil {
+		return err
 	}
+	printUserDetails(user)
 
 	return nil
 }
@@ -532,6 +617,33 @@ func countCompletedProjects(projectUsers []api.ProjectUser) int {
 	return count
 }
 
+// defaultUserListScope returns the campus and cursus user list assumes for
+// me: the primary campus and the current cursus, or only the campus when
+// campusOnly is set. The cursus name is returned for the announcement.
+func defaultUserListScope(me *api.User, campusOnly bool) (*api.Campus, int, string) {
+	campus := primaryCampus(me)
+	if campusOnly {
+		return campus, 0, ""
+	}
+	if cu := findCursusUser(me.CursusUsers, 0); cu != nil {
+		return campus, cu.Cursus.ID, cu.Cursus.Name
+	}
+	return campus, 0, ""
+}
+
+// describeUserListScope describes an assumed user list scope, e.g.
+// "your campus (Tokyo) in 42cursus"
+func describeUserListScope(campus *api.Campus, cursusName string) string {
+	scope := "every campus"
+	if campus != nil {
+		scope = fmt.Sprintf("your campus (%s)", campus.Name)
+	}
+	if cursusName != "" {
+		scope += " in " + cursusName
+	}
+	return scope
+}
+
 func findCursusUser(cursusUsers []api.CursusUser, cursusID int) *api.CursusUser {
 	if cursusID == 0 && len(cursusUsers) > 0 {
 		// If no cursus ID specified, use the most recent active cursus
@@ -584,13 +696,31 @@ func validateAlumniFlagCompatibility(alumni, nonAlumni bool, cursusID int) error
 	return nil
 }
 
-func printUsersTableWithMode(users []api.User, meta *api.PaginationMeta, cursusID int, showProjects bool, progressiveMode bool, totalFetched int, limit int) {
+func printUsersTableWithMode(users []api.User, meta *api.PaginationMeta, cursusID int, showProjects bool, progressiveMode bool, totalFetched int, limit int, more bool) {
 	if len(users) == 0 {
 		fmt.Println("No users found.")
 		return
 	}
 
-	// Header - adjust columns based on whether projects data is available
+	printUsersTableHeader(showProjects)
+	for _, user := range users {
+		printUserRow(user, cursusID, showProjects)
+	}
+
+	// Pagination/fetch info
+	if progressiveMode {
+		fmt.Printf("\n📊 Showing %d users (fetched %d, filtered by client-side criteria)\n", len(users), totalFetched)
+	} else if meta != nil && meta.TotalCount > 0 {
+		fmt.Printf("\n📊 Showing %d of %d users\n", len(users), meta.TotalCount)
+	}
+	if more {
+		fmt.Println(moreResultsHint(limit))
+	}
+}
+
+// printUsersTableHeader prints the column headers of the user table; the
+// PROJECTS column is only shown when project data is available
+func printUsersTableHeader(showProjects bool) {
 	if showProjects {
 		fmt.Printf("%-20s %-30s %-15s %-10s %-10s %s\n",
 			"LOGIN", "NAME", "CAMPUS", "LEVEL", "PROJECTS", "BLACKHOLE")
@@ -600,60 +730,84 @@ func printUsersTableWithMode(users []api.User, meta *api.PaginationMeta, cursusI
 			"LOGIN", "NAME", "CAMPUS", "LEVEL", "BLACKHOLE")
 		fmt.Printf("%s\n", strings.Repeat("-", 90))
 	}
+}
 
-	// Users
-	for _, user := range users {
-		login := truncateString(user.Login, 18)
-		displayName := truncateString(user.DisplayName, 28)
+// printUserRow prints one row of the user table
+func printUserRow(user api.User, cursusID int, showProjects bool) {
+	cells := userRowCells(user, cursusID, showProjects)
+	cells[0], cells[1], cells[2] = truncateString(cells[0], 18), truncateString(cells[1], 28), truncateString(cells[2], 13)
 
-		campus := "N/A"
-		if len(user.Campus) > 0 {
-			campus = truncateString(user.Campus[0].City, 13)
-		}
+	if showProjects {
+		fmt.Printf("%-20s %-30s %-15s %-10s %-10s %s\n",
+			cells[0], cells[1], cells[2], cells[3], cells[4], cells[5])
+	} else {
+		fmt.Printf("%-20s %-30s %-15s %-10s %s\n",
+			cells[0], cells[1], cells[2], cells[3], cells[4])
+	}
+}
 
-		level := "N/A"
-		blackhole := "N/A"
+// userRowCells returns the cells of a user table row, in the order of
+// printUsersTableHeader
+func userRowCells(user api.User, cursusID int, showProjects bool) []string {
+	campus := "N/A"
+	if len(user.Campus) > 0 {
+		campus = user.Campus[0].City
+	}
 
-		// Find cursus user
-		cursusUser := findCursusUser(user.CursusUsers, cursusID)
-		if cursusUser != nil {
-			level = fmt.Sprintf("%.2f", cursusUser.Level)
+	level := "N/A"
+	blackhole := "N/A"
 
-			if cursusUser.BlackholedAt != nil {
-				daysUntil := int(time.Until(*cursusUser.BlackholedAt).Hours() / 24)
-				if daysUntil > 0 {
-					blackhole = fmt.Sprintf("%dd", daysUntil)
-				} else {
-					blackhole = "BH'd"
-				}
+	// Find cursus user
+	cursusUser := findCursusUser(user.CursusUsers, cursusID)
+	if cursusUser != nil {
+		level = fmt.Sprintf("%.2f", cursusUser.Level)
+
+		if cursusUser.BlackholedAt != nil {
+			daysUntil := int(time.Until(*cursusUser.BlackholedAt).Hours() / 24)
+			if daysUntil > 0 {
+				blackhole = fmt.Sprintf("%dd", daysUntil)
 			} else {
-				blackhole = "-"
+				blackhole = "BH'd"
 			}
-		}
-
-		if showProjects {
-			projectCount := strconv.Itoa(countCompletedProjects(user.ProjectsUsers))
-			fmt.Printf("%-20s %-30s %-15s %-10s %-10s %s\n",
-				login, displayName, campus, level, projectCount, blackhole)
 		} else {
-			fmt.Printf("%-20s %-30s %-15s %-10s %s\n",
-				login, displayName, campus, level, blackhole)
+			blackhole = "-"
 		}
 	}
 
-	// Pagination/fetch info
-	if progressiveMode {
-		fmt.Printf("\n📊 Showing %d users (fetched %d, filtered by client-side criteria)\n", len(users), totalFetched)
-		if len(users) >= limit && meta != nil && meta.TotalCount > totalFetched {
-			fmt.Printf("   Use --limit %d to see more results\n", limit*2)
-		}
-	} else if meta != nil {
-		fmt.Printf("\n📄 Page %d of %d (%d total users, showing %d)\n",
-			meta.Page, meta.TotalPages, meta.TotalCount, len(users))
-		if meta.Page < meta.TotalPages {
-			fmt.Printf("   Use --page %d to see the next page\n", meta.Page+1)
-		}
+	if showProjects {
+		projectCount := strconv.Itoa(countCompletedProjects(user.ProjectsUsers))
+		return []string{user.Login, user.DisplayName, campus, level, projectCount, blackhole}
 	}
+	return []string{user.Login, user.DisplayName, campus, level, blackhole}
+}
+
+// browseUsers opens the interactive browser on a list of users
+func browseUsers(client *api.Client, users []api.User, cursusID int, showProjects bool) error {
+	columns := []string{"LOGIN", "NAME", "CAMPUS", "LEVEL", "BLACKHOLE"}
+	if showProjects {
+		columns = []string{"LOGIN", "NAME", "CAMPUS", "LEVEL", "PROJECTS", "BLACKHOLE"}
+	}
+	rows := make([][]string, len(users))
+	for i, user := range users {
+		rows[i] = userRowCells(user, cursusID, showProjects)
+	}
+
+	return runBrowser(browseTable{
+		Title:   "👥 Users",
+		Columns: columns,
+		Rows:    rows,
+		Show: func(row int) error {
+			user, err := client.GetUserByLogin(context.Background(), users[row].Login)
+			if err != nil {
+				return fmt.Errorf("failed to get user '%s': %w", users[row].Login, err)
+			}
+			printUserDetails(user)
+			return nil
+		},
+		URL: func(row int) string {
+			return fmt.Sprintf(userPageURLFormat, users[row].Login)
+		},
+	})
 }
 
 func printUserDetails(user *api.User) {
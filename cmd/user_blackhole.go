@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+const (
+	// blackholeBatchSize is the number of user IDs sent in one filter[user_id]
+	blackholeBatchSize = 50
+
+	// blackholeLocationPages bounds the location pages fetched per batch when
+	// looking for each user's last session
+	blackholeLocationPages = 3
+)
+
+var blackholeListCmd = &cobra.Command{
+	Use:   "blackhole-list",
+	Short: "List users of a campus approaching their blackhole",
+	Long: `Early-warning report for campus staff: every user of a campus whose
+blackhole date falls within the next --days days, most urgent first, with
+their level, last location and in-progress projects.
+
+The report is built to spare the API quota: blackhole dates are filtered
+server-side, and projects and locations are fetched for batches of users
+instead of one request per user.
+
+Examples:
+  t42 user blackhole-list --campus tokyo
+  t42 user blackhole-list --campus tokyo --days 14
+  t42 user blackhole-list --campus tokyo --csv > blackhole.csv`,
+	RunE: runBlackholeList,
+}
+
+// blackholeEntry is one user in the blackhole report
+type blackholeEntry struct {
+	UserID       int        `json:"user_id"`
+	Login        string     `json:"login"`
+	DisplayName  string     `json:"display_name"`
+	Level        float64    `json:"level"`
+	BlackholedAt time.Time  `json:"blackholed_at"`
+	DaysLeft     int        `json:"days_left"`
+	Online       bool       `json:"online"`
+	LastLocation string     `json:"last_location,omitempty"`
+	LastSeen     *time.Time `json:"last_seen,omitempty"`
+	InProgress   []string   `json:"in_progress"`
+}
+
+func init() {
+	userCmd.AddCommand(blackholeListCmd)
+
+	addCampusFlags(blackholeListCmd)
+	blackholeListCmd.Flags().Int("cursus-id", 21, "Cursus ID (21 for 42cursus)")
+	blackholeListCmd.Flags().Int("days", 30, "Include blackhole dates within this many days")
+	blackholeListCmd.Flags().Bool("csv", false, "Output as CSV")
+}
+
+func runBlackholeList(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+	days, _ := cmd.Flags().GetInt("days")
+	asCSV, _ := cmd.Flags().GetBool("csv")
+
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	if asCSV && GetJSONOutput() {
+		return fmt.Errorf("--csv and --json are mutually exclusive")
+	}
+
+	// Create API client with automatic token refresh
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	campus, err := requireCampus(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cursusUsers, requests, err := fetchUpcomingBlackholes(ctx, client, cursusID, campus.ID, now, days)
+	if err != nil {
+		return err
+	}
+	entries := buildBlackholeEntries(cursusUsers, now, days)
+
+	userIDs := make([]int, len(entries))
+	for i, entry := range entries {
+		userIDs[i] = entry.UserID
+	}
+
+	projects, n, err := fetchInProgressProjects(ctx, client, userIDs, cursusID)
+	if err != nil {
+		return err
+	}
+	requests += n
+
+	// Users logged in right now already carry their location
+	var offline []int
+	for _, entry := range entries {
+		if !entry.Online {
+			offline = append(offline, entry.UserID)
+		}
+	}
+	lastLocations, n, err := fetchLastLocations(ctx, client, offline)
+	if err != nil {
+		return err
+	}
+	requests += n
+
+	for i := range entries {
+		entries[i].InProgress = projects[entries[i].UserID]
+		if entries[i].InProgress == nil {
+			entries[i].InProgress = []string{}
+		}
+		if location, ok := lastLocations[entries[i].UserID]; ok {
+			entries[i].LastLocation = location.Host
+			lastSeen := location.BeginAt
+			if location.EndAt != nil {
+				lastSeen = *location.EndAt
+			}
+			entries[i].LastSeen = &lastSeen
+		}
+	}
+
+	if asCSV {
+		return writeBlackholeCSV(os.Stdout, entries)
+	}
+	done, err := emitOutput(
+		output.Field{Key: "campus", Value: campus.Name},
+		output.Field{Key: "days", Value: days},
+		output.Field{Key: "count", Value: len(entries)},
+		output.Field{Key: "users", Value: entries},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	if !done {
+		printBlackholeList(entries, campus.Name, days, requests, now)
+	}
+
+	return nil
+}
+
+// fetchUpcomingBlackholes returns the cursus users of a campus whose blackhole
+// date is within days of now, along with the number of API requests made
+func fetchUpcomingBlackholes(ctx context.Context, client *api.Client, cursusID, campusID int, now time.Time, days int) ([]api.CursusUser, int, error) {
+	var all []api.CursusUser
+	requests := 0
+
+	pages := client.CursusUsersPaginator(ctx, cursusID, &api.ListCursusUsersOptions{
+		CampusID:        campusID,
+		Sort:            "blackholed_at",
+		BlackholedSince: now,
+		BlackholedUntil: now.AddDate(0, 0, days),
+	})
+	for pages.HasNext() {
+		cursusUsers, err := pages.Next()
+		requests++
+		if err != nil {
+			return nil, requests, fmt.Errorf("failed to list cursus users: %w", err)
+		}
+		all = append(all, cursusUsers...)
+	}
+
+	return all, requests, nil
+}
+
+// buildBlackholeEntries keeps the cursus users whose blackhole is upcoming
+// and sorts them by urgency. The server-side range already filters dates;
+// checking again keeps the report correct if the range is ignored.
+func buildBlackholeEntries(cursusUsers []api.CursusUser, now time.Time, days int) []blackholeEntry {
+	seen := make(map[int]bool)
+	entries := make([]blackholeEntry, 0, len(cursusUsers))
+
+	for i := range cursusUsers {
+		cu := &cursusUsers[i]
+		if !matchesBlackholeStatus(cu, "upcoming", days, now) || seen[cu.User.ID] {
+			continue
+		}
+		seen[cu.User.ID] = true
+
+		entry := blackholeEntry{
+			UserID:       cu.User.ID,
+			Login:        cu.User.Login,
+			DisplayName:  cu.User.DisplayName,
+			Level:        cu.Level,
+			BlackholedAt: *cu.BlackholedAt,
+			DaysLeft:     int(cu.BlackholedAt.Sub(now).Hours() / 24),
+			Online:       cu.User.Location != "",
+		}
+		if entry.Online {
+			entry.LastLocation = cu.User.Location
+			entry.LastSeen = &now
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].BlackholedAt.Equal(entries[j].BlackholedAt) {
+			return entries[i].BlackholedAt.Before(entries[j].BlackholedAt)
+		}
+		return entries[i].Login < entries[j].Login
+	})
+	return entries
+}
+
+// fetchInProgressProjects returns the slugs of the in-progress projects of
+// each user, fetched for batches of users at a time
+func fetchInProgressProjects(ctx context.Context, client *api.Client, userIDs []int, cursusID int) (map[int][]string, int, error) {
+	projects := make(map[int][]string)
+	requests := 0
+
+	for start := 0; start < len(userIDs); start += blackholeBatchSize {
+		batch := userIDs[start:min(start+blackholeBatchSize, len(userIDs))]
+
+		pages := client.ProjectsUsersPaginator(ctx, &api.ListProjectsUsersOptions{
+			UserIDs:  batch,
+			Status:   "in_progress",
+			CursusID: cursusID,
+		})
+		for pages.HasNext() {
+			projectUsers, err := pages.Next()
+			requests++
+			if err != nil {
+				return nil, requests, fmt.Errorf("failed to list in-progress projects: %w", err)
+			}
+			for _, pu := range projectUsers {
+				projects[pu.User.ID] = append(projects[pu.User.ID], pu.Project.Slug)
+			}
+		}
+	}
+
+	for id := range projects {
+		sort.Strings(projects[id])
+	}
+	return projects, requests, nil
+}
+
+// fetchLastLocations returns the most recent workstation session of each
+// user. Sessions are listed newest first for a batch of users, so a few
+// pages usually cover everyone in the batch.
+func fetchLastLocations(ctx context.Context, client *api.Client, userIDs []int) (map[int]api.Location, int, error) {
+	locations := make(map[int]api.Location)
+	requests := 0
+
+	for start := 0; start < len(userIDs); start += blackholeBatchSize {
+		batch := userIDs[start:min(start+blackholeBatchSize, len(userIDs))]
+
+		pages := api.NewPaginator(ctx, api.DefaultPerPage, func(ctx context.Context, page int) ([]api.Location, *api.PaginationMeta, error) {
+			return client.ListLocations(ctx, batch, &api.ListLocationsOptions{
+				Page:    page,
+				PerPage: api.DefaultPerPage,
+				Sort:    "-begin_at",
+			})
+		})
+		for pages.HasNext() && pages.Page() < blackholeLocationPages {
+			pageLocations, err := pages.Next()
+			requests++
+			if err != nil {
+				return nil, requests, fmt.Errorf("failed to list locations: %w", err)
+			}
+			mergeLastLocations(locations, pageLocations)
+
+			if allLocated(locations, batch) {
+				break
+			}
+		}
+	}
+
+	return locations, requests, nil
+}
+
+// mergeLastLocations records the newest session of each user in locations
+func mergeLastLocations(locations map[int]api.Location, sessions []api.Location) {
+	for _, session := range sessions {
+		if current, ok := locations[session.User.ID]; !ok || session.BeginAt.After(current.BeginAt) {
+			locations[session.User.ID] = session
+		}
+	}
+}
+
+// allLocated reports whether every user ID has a recorded location
+func allLocated(locations map[int]api.Location, userIDs []int) bool {
+	for _, id := range userIDs {
+		if _, ok := locations[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// blackholeUrgency returns the marker for the number of days left
+func blackholeUrgency(daysLeft int) string {
+	switch {
+	case daysLeft <= 7:
+		return "🔴"
+	case daysLeft <= 14:
+		return "🟠"
+	default:
+		return "🟡"
+	}
+}
+
+// formatLastSeen describes when a user was last at a workstation
+func formatLastSeen(entry blackholeEntry, now time.Time) string {
+	switch {
+	case entry.Online:
+		return entry.LastLocation + " (now)"
+	case entry.LastSeen == nil:
+		return "-"
+	}
+
+	ago := now.Sub(*entry.LastSeen)
+	var when string
+	switch {
+	case ago < time.Hour:
+		when = fmt.Sprintf("%dm ago", int(ago.Minutes()))
+	case ago < 24*time.Hour:
+		when = fmt.Sprintf("%dh ago", int(ago.Hours()))
+	default:
+		when = fmt.Sprintf("%dd ago", int(ago.Hours()/24))
+	}
+	return fmt.Sprintf("%s (%s)", entry.LastLocation, when)
+}
+
+func printBlackholeList(entries []blackholeEntry, campus string, days, requests int, now time.Time) {
+	fmt.Printf("🕳️  Blackhole within %d days at %s\n\n", days, campus)
+
+	if len(entries) == 0 {
+		fmt.Println("No users are approaching their blackhole. 🎉")
+		return
+	}
+
+	fmt.Printf("   %-15s %-6s %-11s %-6s %-26s %s\n", "LOGIN", "DAYS", "BLACKHOLE", "LEVEL", "LAST SEEN", "IN PROGRESS")
+	fmt.Printf("%s\n", strings.Repeat("-", 100))
+
+	for _, entry := range entries {
+		inProgress := "-"
+		if len(entry.InProgress) > 0 {
+			inProgress = strings.Join(entry.InProgress, ", ")
+		}
+		fmt.Printf("%s %-15s %-6d %-11s %-6.2f %-26s %s\n",
+			blackholeUrgency(entry.DaysLeft),
+			truncateString(entry.Login, 15),
+			entry.DaysLeft,
+			entry.BlackholedAt.Local().Format("2006-01-02"),
+			entry.Level,
+			truncateString(formatLastSeen(entry, now), 26),
+			truncateString(inProgress, 30))
+	}
+
+	fmt.Printf("\n📊 %d users (%d API requests)\n", len(entries), requests)
+}
+
+// writeBlackholeCSV writes the report as CSV with a header row
+func writeBlackholeCSV(w io.Writer, entries []blackholeEntry) error {
+	cw := csv.NewWriter(w)
+	header := []string{"login", "display_name", "user_id", "level", "blackholed_at", "days_left", "online", "last_location", "last_seen", "in_progress"}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, entry := range entries {
+		lastSeen := ""
+		if entry.LastSeen != nil {
+			lastSeen = entry.LastSeen.UTC().Format(time.RFC3339)
+		}
+		record := []string{
+			entry.Login,
+			entry.DisplayName,
+			strconv.Itoa(entry.UserID),
+			strconv.FormatFloat(entry.Level, 'f', 2, 64),
+			entry.BlackholedAt.UTC().Format(time.RFC3339),
+			strconv.Itoa(entry.DaysLeft),
+			strconv.FormatBool(entry.Online),
+			entry.LastLocation,
+			lastSeen,
+			strings.Join(entry.InProgress, ";"),
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
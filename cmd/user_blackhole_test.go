@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestBuildBlackholeEntries(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		bh := now.AddDate(0, 0, days)
+		return &bh
+	}
+	cursusUser := func(id int, login, location string, blackhole *time.Time) api.CursusUser {
+		return api.CursusUser{
+			Level:        float64(id),
+			BlackholedAt: blackhole,
+			User:         api.User{ID: id, Login: login, Location: location},
+		}
+	}
+
+	cursusUsers := []api.CursusUser{
+		cursusUser(1, "later", "", at(20)),
+		cursusUser(2, "soon", "c1r1s1", at(3)),
+		cursusUser(3, "tooFar", "", at(60)),
+		cursusUser(4, "past", "", at(-2)),
+		cursusUser(5, "none", "", nil),
+		cursusUser(2, "soon", "c1r1s1", at(3)), // duplicate across pages
+	}
+
+	entries := buildBlackholeEntries(cursusUsers, now, 30)
+
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2: %+v", len(entries), entries)
+	}
+	if entries[0].Login != "soon" || entries[1].Login != "later" {
+		t.Errorf("entries not sorted by urgency: %s, %s", entries[0].Login, entries[1].Login)
+	}
+	if entries[0].DaysLeft != 3 {
+		t.Errorf("DaysLeft = %d, want 3", entries[0].DaysLeft)
+	}
+	if !entries[0].Online || entries[0].LastLocation != "c1r1s1" {
+		t.Errorf("online user should carry its current location, got %+v", entries[0])
+	}
+	if entries[1].Online || entries[1].LastSeen != nil {
+		t.Errorf("offline user should have no location yet, got %+v", entries[1])
+	}
+}
+
+func TestMergeLastLocations(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	session := func(userID int, host string, hoursAgo int) api.Location {
+		return api.Location{Host: host, BeginAt: base.Add(-time.Duration(hoursAgo) * time.Hour), User: api.User{ID: userID}}
+	}
+
+	locations := make(map[int]api.Location)
+	mergeLastLocations(locations, []api.Location{session(1, "new", 1), session(1, "old", 48), session(2, "b", 5)})
+	mergeLastLocations(locations, []api.Location{session(2, "older", 10)})
+
+	tests := []struct {
+		userID int
+		want   string
+	}{
+		{1, "new"},
+		{2, "b"},
+	}
+	for _, tt := range tests {
+		if got := locations[tt.userID].Host; got != tt.want {
+			t.Errorf("user %d last location = %q, want %q", tt.userID, got, tt.want)
+		}
+	}
+
+	if !allLocated(locations, []int{1, 2}) {
+		t.Error("allLocated() = false, want true")
+	}
+	if allLocated(locations, []int{1, 3}) {
+		t.Error("allLocated() = true for a user without sessions")
+	}
+}
+
+func TestFetchLastLocationsWithoutPaginationHeaders(t *testing.T) {
+	// User 1 fills the first page; user 2 only shows up on the second, and
+	// no X-Total headers tell how many pages there are
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		var sessions []api.Location
+		switch page {
+		case 1:
+			for i := 0; i < api.DefaultPerPage; i++ {
+				sessions = append(sessions, api.Location{ID: i, User: api.User{ID: 1}})
+			}
+		case 2:
+			sessions = []api.Location{{ID: 1000, Host: "c1r1s1", User: api.User{ID: 2}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(sessions)
+	}))
+	defer server.Close()
+
+	client := api.NewClient("test_token", api.WithBaseURL(server.URL), api.WithRateLimiter(nil))
+	locations, requests, err := fetchLastLocations(context.Background(), client, []int{1, 2})
+	if err != nil {
+		t.Fatalf("fetchLastLocations() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("fetchLastLocations() made %d requests, want 2", requests)
+	}
+	if got := locations[2].Host; got != "c1r1s1" {
+		t.Errorf("user 2 last location = %q, want c1r1s1", got)
+	}
+}
+
+func TestFormatLastSeen(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	seen := func(d time.Duration) *time.Time {
+		ts := now.Add(-d)
+		return &ts
+	}
+
+	tests := []struct {
+		name  string
+		entry blackholeEntry
+		want  string
+	}{
+		{"online", blackholeEntry{Online: true, LastLocation: "c1r1s1"}, "c1r1s1 (now)"},
+		{"never seen", blackholeEntry{}, "-"},
+		{"minutes", blackholeEntry{LastLocation: "c2", LastSeen: seen(30 * time.Minute)}, "c2 (30m ago)"},
+		{"hours", blackholeEntry{LastLocation: "c2", LastSeen: seen(5 * time.Hour)}, "c2 (5h ago)"},
+		{"days", blackholeEntry{LastLocation: "c2", LastSeen: seen(72 * time.Hour)}, "c2 (3d ago)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatLastSeen(tt.entry, now); got != tt.want {
+				t.Errorf("formatLastSeen() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteBlackholeCSV(t *testing.T) {
+	lastSeen := time.Date(2024, 5, 30, 18, 0, 0, 0, time.UTC)
+	entries := []blackholeEntry{
+		{
+			UserID:       7,
+			Login:        "jdoe",
+			DisplayName:  "Doe, John",
+			Level:        4.5,
+			BlackholedAt: time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC),
+			DaysLeft:     3,
+			LastLocation: "c1r2s3",
+			LastSeen:     &lastSeen,
+			InProgress:   []string{"minishell", "philosophers"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeBlackholeCSV(&buf, entries); err != nil {
+		t.Fatalf("writeBlackholeCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want header + 1", len(records))
+	}
+
+	want := []string{"jdoe", "Doe, John", "7", "4.50", "2024-06-04T00:00:00Z", "3", "false", "c1r2s3", "2024-05-30T18:00:00Z", "minishell;philosophers"}
+	for i, field := range want {
+		if records[1][i] != field {
+			t.Errorf("column %s = %q, want %q", records[0][i], records[1][i], field)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var userCompareCmd = &cobra.Command{
+	Use:   "compare <login1> <login2>",
+	Short: "Compare two users side by side",
+	Long: `Compare two users side by side: level, validated projects, correction
+points, wallet, logtime and skills, with the difference. Projects only one
+of them validated are listed, which helps to choose teammates.
+
+Both users are compared in the first user's current cursus, or the one
+given with --cursus-id. --since sets the logtime period: a number of days
+or weeks such as 30d or 4w, or a date (YYYY-MM-DD).
+
+Examples:
+  t42 user compare alice bob
+  t42 user compare alice bob --since 4w
+  t42 user compare alice bob --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runUserCompare,
+}
+
+func init() {
+	userCmd.AddCommand(userCompareCmd)
+
+	userCompareCmd.Flags().Int("cursus-id", 0, "Cursus ID (default: the first user's current cursus)")
+	userCompareCmd.Flags().String("since", "30d", "Start of the logtime period: days (30d), weeks (4w) or a date (YYYY-MM-DD)")
+}
+
+// comparedUser is what 't42 user compare' shows of one user
+type comparedUser struct {
+	Login             string   `json:"login"`
+	Level             float64  `json:"level"`
+	ValidatedProjects []string `json:"validated_projects"`
+	CorrectionPoints  int      `json:"correction_points"`
+	Wallet            int      `json:"wallet"`
+	LogtimeSeconds    int64    `json:"logtime_seconds"`
+}
+
+func runUserCompare(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+
+	now := time.Now()
+	since, err := parseSince(sinceFlag, now)
+	if err != nil {
+		return err
+	}
+	if args[0] == args[1] {
+		return fmt.Errorf("give two different logins")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var users [2]*api.User
+	for i, login := range args {
+		users[i], err = client.GetUserByLogin(ctx, login)
+		if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", login, err)
+		}
+	}
+
+	first := findCursusUser(users[0].CursusUsers, cursusID)
+	if first == nil {
+		if cursusID != 0 {
+			return fmt.Errorf("%s is not in cursus %d", users[0].Login, cursusID)
+		}
+		return fmt.Errorf("%s has no cursus", users[0].Login)
+	}
+	cursus := first.Cursus
+	second := findCursusUser(users[1].CursusUsers, cursus.ID)
+	if second == nil {
+		return fmt.Errorf("%s is not in %s", users[1].Login, cursus.Name)
+	}
+
+	var compared [2]comparedUser
+	for i, cu := range []*api.CursusUser{first, second} {
+		locations, err := fetchAllUserLocations(ctx, client, users[i].ID, since, now)
+		if err != nil {
+			return fmt.Errorf("failed to list locations of %s: %w", users[i].Login, err)
+		}
+		compared[i] = comparedUser{
+			Login:             users[i].Login,
+			Level:             cu.Level,
+			ValidatedProjects: validatedProjects(users[i].ProjectsUsers, cursus.ID),
+			CorrectionPoints:  users[i].CorrectionPoint,
+			Wallet:            users[i].Wallet,
+			LogtimeSeconds:    int64(sumLogtime(locations, since, now).Seconds()),
+		}
+	}
+	skills := diffSkills(first.Skills, second.Skills)
+
+	if done, err := emitOutput(
+		output.Field{Key: "users", Value: compared},
+		output.Field{Key: "cursus", Value: cursus.Name},
+		output.Field{Key: "logtime_since", Value: since},
+		output.Field{Key: "skills", Value: skills},
+	); done || err != nil {
+		return err
+	}
+
+	printUserComparison(compared, cursus.Name, since)
+	fmt.Println()
+	printSkillDiffs(compared[0].Login, compared[1].Login, cursus.Name, skills)
+	return nil
+}
+
+// validatedProjects returns the names of the projects of a cursus a user
+// validated, sorted
+func validatedProjects(projectUsers []api.ProjectUser, cursusID int) []string {
+	var names []string
+	for _, pu := range projectUsers {
+		if pu.Validated == nil || !*pu.Validated {
+			continue
+		}
+		if len(pu.CursusIds) > 0 && !slices.Contains(pu.CursusIds, cursusID) {
+			continue
+		}
+		names = append(names, pu.Project.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// onlyIn returns the names of a that are not in b; both are sorted
+func onlyIn(a, b []string) []string {
+	var names []string
+	for _, name := range a {
+		if _, found := slices.BinarySearch(b, name); !found {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// formatHoursDiff formats a duration difference as "+12h30m" or "-3h05m"
+func formatHoursDiff(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatHours(-d)
+	}
+	return "+" + formatHours(d)
+}
+
+func printUserComparison(users [2]comparedUser, cursus string, since time.Time) {
+	a, b := users[0], users[1]
+	fmt.Printf("⚖️  %s vs %s in %s\n\n", a.Login, b.Login, cursus)
+
+	row := func(label, left, right, diff string) {
+		fmt.Printf("%-24s %14s %14s %10s\n", label, left, right, diff)
+	}
+	row("", truncateString(a.Login, 14), truncateString(b.Login, 14), "DIFF")
+	fmt.Println(strings.Repeat("-", 65))
+	row("Level", fmt.Sprintf("%.2f", a.Level), fmt.Sprintf("%.2f", b.Level), fmt.Sprintf("%+.2f", a.Level-b.Level))
+	row("Validated projects", fmt.Sprint(len(a.ValidatedProjects)), fmt.Sprint(len(b.ValidatedProjects)),
+		fmt.Sprintf("%+d", len(a.ValidatedProjects)-len(b.ValidatedProjects)))
+	row("Correction points", fmt.Sprint(a.CorrectionPoints), fmt.Sprint(b.CorrectionPoints),
+		fmt.Sprintf("%+d", a.CorrectionPoints-b.CorrectionPoints))
+	row("Wallet", fmt.Sprint(a.Wallet), fmt.Sprint(b.Wallet), fmt.Sprintf("%+d", a.Wallet-b.Wallet))
+	logtimeA := time.Duration(a.LogtimeSeconds) * time.Second
+	logtimeB := time.Duration(b.LogtimeSeconds) * time.Second
+	row("Logtime since "+since.Format("Jan 02"), formatHours(logtimeA), formatHours(logtimeB), formatHoursDiff(logtimeA-logtimeB))
+
+	for _, pair := range [][2]comparedUser{{a, b}, {b, a}} {
+		if only := onlyIn(pair[0].ValidatedProjects, pair[1].ValidatedProjects); len(only) > 0 {
+			fmt.Printf("\n✅ Validated by %s only: %s\n", pair[0].Login, strings.Join(only, ", "))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestValidatedProjects(t *testing.T) {
+	yes, no := true, false
+	projectUsers := []api.ProjectUser{
+		{Validated: &yes, CursusIds: []int{21}, Project: api.Project{Name: "minishell"}},
+		{Validated: &no, CursusIds: []int{21}, Project: api.Project{Name: "cub3d"}},
+		{Validated: &yes, Project: api.Project{Name: "libft"}},
+		{Validated: &yes, CursusIds: []int{9}, Project: api.Project{Name: "c-piscine-shell-00"}},
+		{Project: api.Project{Name: "push_swap"}},
+	}
+
+	want := []string{"libft", "minishell"}
+	if got := validatedProjects(projectUsers, 21); !reflect.DeepEqual(got, want) {
+		t.Errorf("validatedProjects() = %v, want %v", got, want)
+	}
+}
+
+func TestOnlyIn(t *testing.T) {
+	a := []string{"cub3d", "libft", "minishell"}
+	b := []string{"libft", "push_swap"}
+
+	if got, want := onlyIn(a, b), []string{"cub3d", "minishell"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("onlyIn(a, b) = %v, want %v", got, want)
+	}
+	if got, want := onlyIn(b, a), []string{"push_swap"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("onlyIn(b, a) = %v, want %v", got, want)
+	}
+}
+
+func TestFormatHoursDiff(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{12*time.Hour + 30*time.Minute, "+12h30m"},
+		{-(3*time.Hour + 5*time.Minute), "-3h05m"},
+		{0, "+0h00m"},
+	}
+	for _, tt := range tests {
+		if got := formatHoursDiff(tt.d); got != tt.want {
+			t.Errorf("formatHoursDiff(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
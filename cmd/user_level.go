@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// levelBarWidth is the width of the progress bar to the next level
+const levelBarWidth = 30
+
+var userLevelCmd = &cobra.Command{
+	Use:   "level [login]",
+	Short: "Show level, XP progress and the level projected from projects in progress",
+	Long: `Show a user's level in their current cursus, or the one given with
+--cursus-id: the XP they have, the XP left to the next level, and the level
+they would reach once their projects in progress are validated.
+
+Projects count with the experience the intra gives for a mark of 100, taken
+from the project session of the user's campus when it has its own. Bonus
+marks above 100 give more.
+
+Without a login, your own level is shown.
+
+Examples:
+  t42 user level
+  t42 user level jdoe
+  t42 user level --cursus-id 9 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUserLevel,
+}
+
+func init() {
+	userCmd.AddCommand(userLevelCmd)
+
+	userLevelCmd.Flags().Int("cursus-id", 0, "Cursus ID (default: the user's current cursus)")
+}
+
+// levelProject is a project in progress and the experience it gives
+type levelProject struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	XP     *int   `json:"xp"` // nil when unknown
+}
+
+// levelProjection is the level reached once the projects in progress are
+// validated
+type levelProjection struct {
+	XP    int     `json:"xp"`
+	Level float64 `json:"level"`
+	Gain  float64 `json:"gain"`
+}
+
+func runUserLevel(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	user, err := resolveUserArg(ctx, client, args)
+	if err != nil {
+		return err
+	}
+	cu := findCursusUser(user.CursusUsers, cursusID)
+	if cu == nil {
+		if cursusID != 0 {
+			return fmt.Errorf("%s is not in cursus %d", user.Login, cursusID)
+		}
+		return fmt.Errorf("%s has no cursus", user.Login)
+	}
+
+	levels, err := client.ListCursusLevels(ctx, cu.Cursus.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get the levels of cursus %d: %w", cu.Cursus.ID, err)
+	}
+	position := positionInLevels(levels, cu.Level)
+	if position == nil {
+		return fmt.Errorf("level %.2f is outside the levels of %s", cu.Level, cu.Cursus.Name)
+	}
+
+	campusID := 0
+	if campus := primaryCampus(user); campus != nil {
+		campusID = campus.ID
+	}
+	projects := inProgressProjects(user.ProjectsUsers, cu.Cursus.ID)
+	for i := range projects {
+		project, err := client.GetProject(ctx, projects[i].ID)
+		if err != nil {
+			warnf("project_xp_unknown", "failed to get the experience of %s: %v", projects[i].Name, err)
+			continue
+		}
+		projects[i].XP = projectXP(project, campusID, cu.Cursus.ID)
+	}
+	projection := projectLevel(levels, position, projects)
+
+	if done, err := emitOutput(
+		output.Field{Key: "login", Value: user.Login},
+		output.Field{Key: "cursus_id", Value: cu.Cursus.ID},
+		output.Field{Key: "cursus", Value: cu.Cursus.Name},
+		output.Field{Key: "level", Value: position},
+		output.Field{Key: "in_progress", Value: projects},
+		output.Field{Key: "projected", Value: projection},
+	); done || err != nil {
+		return err
+	}
+
+	printUserLevel(user.Login, cu.Cursus.Name, position, projects, projection)
+	return nil
+}
+
+// inProgressProjects returns the projects of a cursus a user is working on
+func inProgressProjects(projectUsers []api.ProjectUser, cursusID int) []levelProject {
+	var projects []levelProject
+	for _, pu := range projectUsers {
+		switch pu.Status {
+		case "in_progress", "waiting_for_correction", "creating_group", "searching_a_group":
+		default:
+			continue
+		}
+		if len(pu.CursusIds) > 0 && !slices.Contains(pu.CursusIds, cursusID) {
+			continue
+		}
+		projects = append(projects, levelProject{ID: pu.Project.ID, Name: pu.Project.Name, Status: pu.Status})
+	}
+	return projects
+}
+
+// projectXP returns the experience a project gives at 100: that of its
+// session on the campus and cursus, else of its session on the cursus,
+// else the project's own. It returns nil when none is known.
+func projectXP(project *api.Project, campusID, cursusID int) *int {
+	var cursusXP *int
+	for _, session := range project.ProjectSessions {
+		if session.Difficulty == nil || session.CursusID != cursusID {
+			continue
+		}
+		if session.CampusID == campusID {
+			return session.Difficulty
+		}
+		if cursusXP == nil && session.CampusID == 0 {
+			cursusXP = session.Difficulty
+		}
+	}
+	if cursusXP != nil {
+		return cursusXP
+	}
+	if project.Difficulty > 0 {
+		xp := project.Difficulty
+		return &xp
+	}
+	return nil
+}
+
+// levelFromXP converts experience points into a fractional level, the
+// inverse of levelXP
+func levelFromXP(levels []api.Level, xp int) float64 {
+	var level float64
+	for i, l := range levels {
+		if l.XP > xp {
+			break
+		}
+		level = float64(l.Lvl)
+		if i+1 < len(levels) && levels[i+1].XP > xp {
+			level += float64(xp-l.XP) / float64(levels[i+1].XP-l.XP)
+		}
+	}
+	return level
+}
+
+// projectLevel returns the level reached from position once the projects
+// with a known experience are validated at 100
+func projectLevel(levels []api.Level, position *levelPosition, projects []levelProject) levelProjection {
+	xp := position.XP
+	for _, p := range projects {
+		if p.XP != nil {
+			xp += *p.XP
+		}
+	}
+	level := levelFromXP(levels, xp)
+	// Rounded like the intra shows levels, so that no gain shows as +0.00
+	gain := math.Round((level-position.Level)*100) / 100
+	return levelProjection{XP: xp, Level: level, Gain: gain}
+}
+
+// printUserLevel prints the level report of a user
+func printUserLevel(login, cursus string, p *levelPosition, projects []levelProject, projection levelProjection) {
+	fmt.Printf("📈 %s in %s\n\n", login, cursus)
+
+	fraction := p.Level - math.Floor(p.Level)
+	if p.MaxReached {
+		fmt.Printf("Level %.2f  %s  highest level\n", p.Level, renderBar(1, levelBarWidth))
+		fmt.Printf("XP    %d\n", p.XP)
+	} else {
+		fmt.Printf("Level %.2f  %s  %d%% to %d\n", p.Level, renderBar(fraction, levelBarWidth),
+			int(math.Round(fraction*100)), p.NextLevel)
+		fmt.Printf("XP    %d  (%d XP left to level %d at %d)\n", p.XP, p.XPToNext, p.NextLevel, p.NextXP)
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("\nNo projects in progress.")
+		return
+	}
+	fmt.Println("\n🚧 In progress")
+	for _, project := range projects {
+		xp := "? XP"
+		if project.XP != nil {
+			xp = fmt.Sprintf("%d XP", *project.XP)
+		}
+		fmt.Printf("  %-30s %-24s %10s\n", truncateString(project.Name, 30), strings.ReplaceAll(project.Status, "_", " "), xp)
+	}
+	fmt.Printf("\n🔮 Projected: level %.2f (%+.2f) once validated at 100\n", projection.Level, projection.Gain)
+}
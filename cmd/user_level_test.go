@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestLevelFromXP(t *testing.T) {
+	tests := []struct {
+		xp   int
+		want float64
+	}{
+		{0, 0},
+		{231, 0.5},
+		{462, 1},
+		{1575, 1.5},
+		{5885, 3},
+		{9000, 3}, // beyond the highest threshold
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.xp), func(t *testing.T) {
+			if got := levelFromXP(testLevels, tt.xp); math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("levelFromXP(%d) = %v, want %v", tt.xp, got, tt.want)
+			}
+			if tt.xp <= 5885 {
+				// levelXP is the inverse
+				if back, _ := levelXP(testLevels, levelFromXP(testLevels, tt.xp)); back != tt.xp {
+					t.Errorf("levelXP(levelFromXP(%d)) = %d", tt.xp, back)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectXP(t *testing.T) {
+	tests := []struct {
+		name    string
+		project api.Project
+		want    *int
+	}{
+		{
+			name: "campus session",
+			project: api.Project{Difficulty: 1000, ProjectSessions: []api.ProjectSession{
+				{CursusID: 21, Difficulty: intPtr(2100)},
+				{CursusID: 21, CampusID: 26, Difficulty: intPtr(2500)},
+			}},
+			want: intPtr(2500),
+		},
+		{
+			name: "cursus session",
+			project: api.Project{Difficulty: 1000, ProjectSessions: []api.ProjectSession{
+				{CursusID: 21, CampusID: 1, Difficulty: intPtr(3000)},
+				{CursusID: 21, Difficulty: intPtr(2100)},
+			}},
+			want: intPtr(2100),
+		},
+		{
+			name: "project",
+			project: api.Project{Difficulty: 1000, ProjectSessions: []api.ProjectSession{
+				{CursusID: 9, Difficulty: intPtr(50)},
+			}},
+			want: intPtr(1000),
+		},
+		{name: "unknown", project: api.Project{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := projectXP(&tt.project, 26, 21)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("projectXP() = %v, want %v", derefInt(got), derefInt(tt.want))
+			}
+		})
+	}
+}
+
+func TestInProgressProjects(t *testing.T) {
+	projectUsers := []api.ProjectUser{
+		{Status: "in_progress", CursusIds: []int{21}, Project: api.Project{ID: 1, Name: "minishell"}},
+		{Status: "finished", CursusIds: []int{21}, Project: api.Project{ID: 2, Name: "libft"}},
+		{Status: "waiting_for_correction", Project: api.Project{ID: 3, Name: "cub3d"}},
+		{Status: "in_progress", CursusIds: []int{9}, Project: api.Project{ID: 4, Name: "c-piscine-shell-00"}},
+	}
+
+	got := inProgressProjects(projectUsers, 21)
+	if len(got) != 2 || got[0].Name != "minishell" || got[1].Name != "cub3d" {
+		t.Errorf("inProgressProjects() = %+v, want minishell and cub3d", got)
+	}
+}
+
+func TestProjectLevel(t *testing.T) {
+	position := positionInLevels(testLevels, 1.5)
+	projects := []levelProject{
+		{Name: "minishell", XP: intPtr(2688 - 1575)},
+		{Name: "unknown"},
+	}
+
+	got := projectLevel(testLevels, position, projects)
+	if got.XP != 2688 || math.Abs(got.Level-2) > 0.001 || got.Gain != 0.5 {
+		t.Errorf("projectLevel() = %+v, want level 2 at 2688 XP, +0.5", got)
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// logtimeBarWidth is the width of the bars of the daily chart
+const logtimeBarWidth = 30
+
+var userLogtimeCmd = &cobra.Command{
+	Use:   "logtime [login]",
+	Short: "Show hours spent at the cluster per day and per week",
+	Long: `Show how long a user was logged in at the campus workstations, per day
+with a bar chart and per week (weeks start on Monday), to keep track of
+attendance requirements.
+
+--since takes a number of days or weeks such as 30d or 4w, or a date
+(YYYY-MM-DD). Without a login, your own logtime is shown.
+
+Examples:
+  t42 user logtime
+  t42 user logtime jdoe --since 2w
+  t42 user logtime jdoe --since 2024-09-01 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUserLogtime,
+}
+
+func init() {
+	userCmd.AddCommand(userLogtimeCmd)
+
+	userLogtimeCmd.Flags().String("since", "30d", "Start of the report: days (30d), weeks (4w) or a date (YYYY-MM-DD)")
+}
+
+// logtimeDay is the time logged in during one local day
+type logtimeDay struct {
+	Date    time.Time     `json:"date"`
+	Logtime time.Duration `json:"-"`
+	Seconds int64         `json:"seconds"`
+}
+
+// logtimeWeek is the time logged in during one week starting on Monday
+type logtimeWeek struct {
+	Start   time.Time     `json:"start"`
+	Logtime time.Duration `json:"-"`
+	Seconds int64         `json:"seconds"`
+}
+
+func runUserLogtime(cmd *cobra.Command, args []string) error {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+
+	now := time.Now()
+	since, err := parseSince(sinceFlag, now)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	user, err := resolveUserArg(ctx, client, args)
+	if err != nil {
+		return err
+	}
+
+	locations, err := fetchAllUserLocations(ctx, client, user.ID, since, now)
+	if err != nil {
+		return fmt.Errorf("failed to list locations: %w", err)
+	}
+
+	days := dailyLogtime(locations, since, now)
+	weeks := weeklyLogtime(days)
+	total := sumLogtime(locations, since, now)
+
+	if done, err := emitOutput(
+		output.Field{Key: "login", Value: user.Login},
+		output.Field{Key: "since", Value: since},
+		output.Field{Key: "total_seconds", Value: int64(total.Seconds())},
+		output.Field{Key: "days", Value: days},
+		output.Field{Key: "weeks", Value: weeks},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("⏱️  Logtime of %s since %s\n\n", user.Login, since.Format("Mon Jan 02 2006"))
+
+	var longest time.Duration
+	for _, d := range days {
+		longest = max(longest, d.Logtime)
+	}
+	for _, d := range days {
+		fraction := 0.0
+		if longest > 0 {
+			fraction = float64(d.Logtime) / float64(longest)
+		}
+		fmt.Printf("%s  %s %s\n", d.Date.Format("Mon Jan 02"), renderBar(fraction, logtimeBarWidth), formatHours(d.Logtime))
+	}
+
+	fmt.Println("\nWeeks:")
+	for _, w := range weeks {
+		fmt.Printf("  %s  %s\n", w.Start.Format("Jan 02"), formatHours(w.Logtime))
+	}
+
+	average := total / time.Duration(len(days))
+	fmt.Printf("\nTotal: %s over %d days (%s per day)\n", formatHours(total), len(days), formatHours(average))
+	return nil
+}
+
+// resolveUserArg returns the user whose login is the optional argument,
+// or the current user
+func resolveUserArg(ctx context.Context, client *api.Client, args []string) (*api.User, error) {
+	if len(args) == 0 {
+		me, err := client.GetMe(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get your profile: %w", err)
+		}
+		return me, nil
+	}
+	user, err := client.GetUserByLogin(ctx, args[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user %s: %w", args[0], err)
+	}
+	return user, nil
+}
+
+// parseSince parses a start of report given as days ("30d"), weeks ("4w")
+// or a date, and returns local midnight of that day
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if n := len(value); n > 1 && (value[n-1] == 'd' || value[n-1] == 'w') {
+		count, err := strconv.Atoi(value[:n-1])
+		if err != nil || count < 1 {
+			return time.Time{}, fmt.Errorf("invalid --since %q (use 30d, 4w or YYYY-MM-DD)", value)
+		}
+		if value[n-1] == 'w' {
+			count *= 7
+		}
+		return today.AddDate(0, 0, 1-count), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use 30d, 4w or YYYY-MM-DD)", value)
+	}
+	if date.After(now) {
+		return time.Time{}, fmt.Errorf("--since %s is in the future", value)
+	}
+	return date, nil
+}
+
+// dailyLogtime splits sessions into the local days from since to until,
+// counting sessions that span midnight on both days
+func dailyLogtime(locations []api.Location, since, until time.Time) []logtimeDay {
+	var days []logtimeDay
+	for day := since; day.Before(until); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		if end.After(until) {
+			end = until
+		}
+		logtime := sumLogtime(locations, day, end)
+		days = append(days, logtimeDay{Date: day, Logtime: logtime, Seconds: int64(logtime.Seconds())})
+	}
+	return days
+}
+
+// weeklyLogtime sums days into weeks starting on Monday
+func weeklyLogtime(days []logtimeDay) []logtimeWeek {
+	var weeks []logtimeWeek
+	for _, d := range days {
+		offset := (int(d.Date.Weekday()) + 6) % 7 // days since Monday
+		start := d.Date.AddDate(0, 0, -offset)
+		if n := len(weeks); n > 0 && weeks[n-1].Start.Equal(start) {
+			weeks[n-1].Logtime += d.Logtime
+		} else {
+			weeks = append(weeks, logtimeWeek{Start: start, Logtime: d.Logtime})
+		}
+	}
+	for i := range weeks {
+		weeks[i].Seconds = int64(weeks[i].Logtime.Seconds())
+	}
+	return weeks
+}
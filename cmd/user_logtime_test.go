@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 6, 12, 15, 30, 0, 0, time.UTC) // a Wednesday
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"1d", time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC), false},
+		{"30d", time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC), false},
+		{"2w", time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC), false},
+		{"2024-06-01", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), false},
+		{"0d", time.Time{}, true},
+		{"xd", time.Time{}, true},
+		{"2024-07-01", time.Time{}, true},
+		{"yesterday", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyAndWeeklyLogtime(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC) }
+	end := func(day, hour int) *time.Time { e := at(day, hour); return &e }
+
+	since, until := at(9, 0), at(12, 10) // Sunday to Wednesday morning
+	locations := []api.Location{
+		{BeginAt: at(9, 20), EndAt: end(10, 2)}, // spans midnight: 4h + 2h
+		{BeginAt: at(10, 9), EndAt: end(10, 12)},
+		{BeginAt: at(12, 8)}, // still running
+	}
+
+	days := dailyLogtime(locations, since, until)
+	want := []time.Duration{4 * time.Hour, 5 * time.Hour, 0, 2 * time.Hour}
+	if len(days) != len(want) {
+		t.Fatalf("dailyLogtime() returned %d days, want %d", len(days), len(want))
+	}
+	for i, d := range days {
+		if d.Logtime != want[i] {
+			t.Errorf("day %s logtime = %v, want %v", d.Date.Format("Jan 02"), d.Logtime, want[i])
+		}
+	}
+
+	weeks := weeklyLogtime(days)
+	if len(weeks) != 2 {
+		t.Fatalf("weeklyLogtime() = %+v, want 2 weeks", weeks)
+	}
+	if !weeks[0].Start.Equal(at(3, 0)) || weeks[0].Logtime != 4*time.Hour {
+		t.Errorf("first week = %+v, want Jun 03 with 4h", weeks[0])
+	}
+	if !weeks[1].Start.Equal(at(10, 0)) || weeks[1].Seconds != int64((7*time.Hour).Seconds()) {
+		t.Errorf("second week = %+v, want Jun 10 with 7h", weeks[1])
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+var userPointsCmd = &cobra.Command{
+	Use:   "points [login]",
+	Short: "Show how correction points were gained and spent",
+	Long: `Show the history of a user's correction points, newest first, with the
+balance after each change. --reason keeps the changes whose reason
+contains the given text, such as "defense" or "pool".
+
+Without a login, your own history is shown.
+
+Examples:
+  t42 user points
+  t42 user points jdoe --limit 50
+  t42 user points --reason defense`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUserPoints,
+}
+
+func init() {
+	userCmd.AddCommand(userPointsCmd)
+
+	userPointsCmd.Flags().String("reason", "", "Only show changes whose reason contains this text (case-insensitive)")
+	userPointsCmd.Flags().IntP("limit", "l", 20, "Maximum number of changes to show (0 for all)")
+}
+
+// pointsSummary totals the changes of correction points
+type pointsSummary struct {
+	Gained int `json:"gained"`
+	Lost   int `json:"lost"`
+}
+
+func runUserPoints(cmd *cobra.Command, args []string) error {
+	reason, _ := cmd.Flags().GetString("reason")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	user, err := resolveUserArg(ctx, client, args)
+	if err != nil {
+		return err
+	}
+
+	historics, err := fetchCorrectionPointHistorics(ctx, client, user.ID)
+	if err != nil {
+		return err
+	}
+	historics = filterPointsByReason(historics, reason)
+	if limit > 0 && len(historics) > limit {
+		historics = historics[:limit]
+	}
+	summary := summarizePoints(historics)
+
+	if done, err := emitOutput(
+		output.Field{Key: "login", Value: user.Login},
+		output.Field{Key: "balance", Value: user.CorrectionPoint},
+		output.Field{Key: "historics", Value: historics},
+		output.Field{Key: "summary", Value: summary},
+	); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("⚡ Correction points of %s (balance: %d)\n\n", user.Login, user.CorrectionPoint)
+	if len(historics) == 0 {
+		if reason != "" {
+			fmt.Printf("No changes matching %q.\n", reason)
+		} else {
+			fmt.Println("No changes.")
+		}
+		return nil
+	}
+
+	fmt.Printf("%-17s %7s %8s  %s\n", "DATE", "CHANGE", "BALANCE", "REASON")
+	fmt.Println(strings.Repeat("-", 80))
+	for _, h := range historics {
+		fmt.Printf("%-17s %7s %8d  %s\n",
+			h.CreatedAt.Local().Format("Jan 02 2006 15:04"),
+			fmt.Sprintf("%+d", h.Sum),
+			h.Total,
+			truncateString(h.Reason, 44),
+		)
+	}
+	fmt.Printf("\n%d changes: %+d gained, %d spent\n", len(historics), summary.Gained, summary.Lost)
+	return nil
+}
+
+// fetchCorrectionPointHistorics fetches every change of a user's
+// correction points, newest first
+func fetchCorrectionPointHistorics(ctx context.Context, client *api.Client, userID int) ([]api.CorrectionPointHistoric, error) {
+	var all []api.CorrectionPointHistoric
+	err := api.FetchAll(ctx, api.DefaultPerPage,
+		func(ctx context.Context, page int) ([]api.CorrectionPointHistoric, *api.PaginationMeta, error) {
+			return client.ListCorrectionPointHistorics(ctx, userID, &api.ListCorrectionPointHistoricsOptions{
+				Page:    page,
+				PerPage: api.DefaultPerPage,
+				Sort:    "-created_at",
+			})
+		},
+		func(historics []api.CorrectionPointHistoric, _ *api.PaginationMeta) error {
+			all = append(all, historics...)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list correction point history: %w", err)
+	}
+	return all, nil
+}
+
+// filterPointsByReason keeps the changes whose reason contains text,
+// ignoring case; empty text keeps them all
+func filterPointsByReason(historics []api.CorrectionPointHistoric, text string) []api.CorrectionPointHistoric {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return historics
+	}
+	var matched []api.CorrectionPointHistoric
+	for _, h := range historics {
+		if strings.Contains(strings.ToLower(h.Reason), text) {
+			matched = append(matched, h)
+		}
+	}
+	return matched
+}
+
+// summarizePoints totals the points gained and spent by changes
+func summarizePoints(historics []api.CorrectionPointHistoric) pointsSummary {
+	var summary pointsSummary
+	for _, h := range historics {
+		if h.Sum > 0 {
+			summary.Gained += h.Sum
+		} else {
+			summary.Lost += h.Sum
+		}
+	}
+	return summary
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestFilterPointsByReason(t *testing.T) {
+	historics := []api.CorrectionPointHistoric{
+		{Sum: 1, Reason: "Earning after defense"},
+		{Sum: -1, Reason: "Defense plannification"},
+		{Sum: -2, Reason: "Provided points to the pool"},
+	}
+	tests := []struct {
+		reason string
+		want   int
+	}{
+		{"", 3},
+		{"defense", 2},
+		{"  POOL ", 1},
+		{"refund", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			if got := filterPointsByReason(historics, tt.reason); len(got) != tt.want {
+				t.Errorf("filterPointsByReason(%q) = %+v, want %d changes", tt.reason, got, tt.want)
+			}
+		})
+	}
+
+	if got := summarizePoints(historics); got.Gained != 1 || got.Lost != -3 {
+		t.Errorf("summarizePoints() = %+v, want 1 gained and -3 lost", got)
+	}
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+// profileCacheTTL is how long an assembled --full profile is reused before refetching
+const profileCacheTTL = 5 * time.Minute
+
+// maxSkillLevel is the scale used when rendering skill bars
+const maxSkillLevel = 20.0
+
+// userProfile is the combined view assembled by `user show --full`
+type userProfile struct {
+	User            *api.User         `json:"user"`
+	Coalitions      []api.Coalition   `json:"coalitions"`
+	LogtimeSeconds  int64             `json:"logtime_month_seconds"`
+	LogtimeSince    time.Time         `json:"logtime_since"`
+	RecentValidated []api.ProjectUser `json:"recent_validated"`
+	FetchedAt       time.Time         `json:"fetched_at"`
+	Warnings        []string          `json:"warnings,omitempty"`
+}
+
+// runShowUserFull fetches and prints the complete profile view for a user
+func runShowUserFull(ctx context.Context, client *api.Client, login string) error {
+	store, storeErr := openCache()
+	cacheKey := "profile/" + strings.ToLower(login)
+
+	var profile userProfile
+	cached := false
+	if storeErr == nil {
+		found, err := store.Get(cacheKey, profileCacheTTL, &profile)
+		if err != nil {
+			warnf("cache_unreadable", "ignoring the unreadable profile cache: %v", err)
+		}
+		cached = found
+	}
+
+	if !cached {
+		fetched, err := fetchUserProfile(ctx, client, login)
+		if err != nil {
+			return err
+		}
+		profile = *fetched
+
+		if storeErr == nil {
+			if err := store.Set(cacheKey, profile); err != nil && GetVerbose() {
+				fmt.Fprintf(os.Stderr, "Failed to cache profile: %v\n", err)
+			}
+		}
+	} else if GetVerbose() {
+		fmt.Fprintf(os.Stderr, "Using cached profile from %s\n", profile.FetchedAt.Format(time.RFC3339))
+	}
+
+	if done, err := emitOutputValue(profile); done || err != nil {
+		return err
+	}
+
+	printUserProfile(&profile)
+	return nil
+}
+
+// fetchUserProfile resolves the user, then fetches the secondary resources concurrently.
+// Failures of secondary resources are recorded as warnings instead of aborting the view.
+func fetchUserProfile(ctx context.Context, client *api.Client, login string) (*userProfile, error) {
+	user, err := client.GetUserByLogin(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user '%s': %w", login, err)
+	}
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	profile := &userProfile{
+		User:            user,
+		LogtimeSince:    monthStart,
+		RecentValidated: recentValidatedProjects(user.ProjectsUsers, 5),
+		FetchedAt:       now,
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		locations []api.Location
+	)
+
+	addWarning := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		profile.Warnings = append(profile.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		coalitions, err := client.ListUserCoalitions(ctx, user.ID)
+		if err != nil {
+			addWarning("coalitions unavailable: %v", err)
+			return
+		}
+		profile.Coalitions = coalitions
+	}()
+
+	go func() {
+		defer wg.Done()
+		fetched, err := fetchAllUserLocations(ctx, client, user.ID, monthStart, now)
+		if err != nil {
+			addWarning("logtime unavailable: %v", err)
+			return
+		}
+		locations = fetched
+	}()
+
+	wg.Wait()
+
+	profile.LogtimeSeconds = int64(sumLogtime(locations, monthStart, now).Seconds())
+
+	return profile, nil
+}
+
+// fetchAllUserLocations follows pagination to collect every session that began in [since, until]
+func fetchAllUserLocations(ctx context.Context, client *api.Client, userID int, since, until time.Time) ([]api.Location, error) {
+	var all []api.Location
+	err := api.FetchAll(ctx, api.DefaultPerPage,
+		func(ctx context.Context, page int) ([]api.Location, *api.PaginationMeta, error) {
+			return client.ListUserLocations(ctx, userID, &api.ListLocationsOptions{
+				Page:    page,
+				PerPage: api.DefaultPerPage,
+				Since:   since,
+				Until:   until,
+			})
+		},
+		func(locations []api.Location, _ *api.PaginationMeta) error {
+			all = append(all, locations...)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// sumLogtime totals the time spent in sessions, clipped to the [since, until] window.
+// Sessions without an end time are treated as still running until `until`.
+func sumLogtime(locations []api.Location, since, until time.Time) time.Duration {
+	var total time.Duration
+
+	for _, loc := range locations {
+		begin := loc.BeginAt
+		end := until
+		if loc.EndAt != nil && loc.EndAt.Before(until) {
+			end = *loc.EndAt
+		}
+		if begin.Before(since) {
+			begin = since
+		}
+		if end.After(begin) {
+			total += end.Sub(begin)
+		}
+	}
+
+	return total
+}
+
+// recentValidatedProjects returns up to n validated projects, most recently marked first
+func recentValidatedProjects(projectUsers []api.ProjectUser, n int) []api.ProjectUser {
+	validated := make([]api.ProjectUser, 0)
+	for _, pu := range projectUsers {
+		if pu.Validated != nil && *pu.Validated && pu.MarkedAt != nil {
+			validated = append(validated, pu)
+		}
+	}
+
+	sort.Slice(validated, func(i, j int) bool {
+		return validated[i].MarkedAt.After(*validated[j].MarkedAt)
+	})
+
+	if len(validated) > n {
+		validated = validated[:n]
+	}
+	return validated
+}
+
+// formatHours renders a duration as hours and minutes (e.g. "42h05m")
+func formatHours(d time.Duration) string {
+	minutes := int(d.Minutes())
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+func printUserProfile(profile *userProfile) {
+	user := profile.User
+
+	fmt.Printf("👤 %s (%s)\n", user.DisplayName, user.Login)
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📧 Email: %s\n", user.Email)
+	if len(user.Campus) > 0 {
+		fmt.Printf("🏫 Campus: %s (%s)\n", user.Campus[0].Name, user.Campus[0].City)
+	}
+	if user.Location != "" {
+		fmt.Printf("🖥️  Location: %s\n", user.Location)
+	}
+	fmt.Printf("⚡ Correction Points: %d   💰 Wallet: %d\n", user.CorrectionPoint, user.Wallet)
+
+	for _, coalition := range profile.Coalitions {
+		fmt.Printf("🛡️  Coalition: %s (score %d)\n", coalition.Name, coalition.Score)
+	}
+
+	if titles := selectedTitles(user); len(titles) > 0 {
+		fmt.Printf("🏅 Titles: %s\n", strings.Join(titles, ", "))
+	}
+
+	// Cursus levels and upcoming blackhole
+	if len(user.CursusUsers) > 0 {
+		fmt.Printf("\n📚 Cursus:\n")
+		for _, cu := range user.CursusUsers {
+			fmt.Printf("   • %-20s Level %.2f", truncateString(cu.Cursus.Name, 20), cu.Level)
+			if cu.BlackholedAt != nil && cu.EndAt == nil {
+				daysUntil := int(time.Until(*cu.BlackholedAt).Hours() / 24)
+				if daysUntil > 0 {
+					fmt.Printf("  🕳️  Blackhole in %d days (%s)", daysUntil, cu.BlackholedAt.Format("2006-01-02"))
+				}
+			}
+			fmt.Println()
+		}
+	}
+
+	// Skills of the main cursus
+	if cursusUser := findCursusUser(user.CursusUsers, 0); cursusUser != nil && len(cursusUser.Skills) > 0 {
+		fmt.Printf("\n🧠 Skills (%s):\n", cursusUser.Cursus.Name)
+		for _, skill := range cursusUser.Skills {
+			fmt.Printf("   %-28s %s %.2f\n",
+				truncateString(skill.Name, 28), renderBar(skill.Level/maxSkillLevel, 20), skill.Level)
+		}
+	}
+
+	if len(profile.RecentValidated) > 0 {
+		fmt.Printf("\n✅ Recently validated:\n")
+		for _, pu := range profile.RecentValidated {
+			mark := "-"
+			if pu.FinalMark != nil {
+				mark = fmt.Sprintf("%d", *pu.FinalMark)
+			}
+			fmt.Printf("   • %-30s %4s  %s\n", truncateString(pu.Project.Name, 30), mark, pu.MarkedAt.Format("2006-01-02"))
+		}
+	}
+
+	fmt.Printf("\n⏱️  Logtime since %s: %s\n", profile.LogtimeSince.Format("2006-01-02"), formatHours(time.Duration(profile.LogtimeSeconds)*time.Second))
+
+	for _, warning := range profile.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+}
+
+// selectedTitles returns the user's title names, marking the selected one
+func selectedTitles(user *api.User) []string {
+	selected := make(map[int]bool)
+	for _, tu := range user.TitlesUsers {
+		if tu.Selected {
+			selected[tu.TitleID] = true
+		}
+	}
+
+	names := make([]string, 0, len(user.Titles))
+	for _, title := range user.Titles {
+		name := strings.ReplaceAll(title.Name, "%login", user.Login)
+		if selected[title.ID] {
+			name += " *"
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestSumLogtime(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name      string
+		locations []api.Location
+		want      time.Duration
+	}{
+		{
+			name:      "no sessions",
+			locations: nil,
+			want:      0,
+		},
+		{
+			name: "closed sessions are summed",
+			locations: []api.Location{
+				{BeginAt: at(2, 9), EndAt: ptr(at(2, 12))},
+				{BeginAt: at(3, 14), EndAt: ptr(at(3, 18))},
+			},
+			want: 7 * time.Hour,
+		},
+		{
+			name: "session starting before window is clipped",
+			locations: []api.Location{
+				{BeginAt: since.Add(-2 * time.Hour), EndAt: ptr(since.Add(3 * time.Hour))},
+			},
+			want: 3 * time.Hour,
+		},
+		{
+			name: "open session runs until the end of the window",
+			locations: []api.Location{
+				{BeginAt: at(10, 10), EndAt: nil},
+			},
+			want: 2 * time.Hour,
+		},
+		{
+			name: "session ending after window is clipped",
+			locations: []api.Location{
+				{BeginAt: at(10, 11), EndAt: ptr(at(10, 20))},
+			},
+			want: time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sumLogtime(tt.locations, since, until)
+			if got != tt.want {
+				t.Errorf("sumLogtime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecentValidatedProjects(t *testing.T) {
+	now := time.Now()
+	yes, no := true, false
+	marked := func(daysAgo int) *time.Time {
+		t := now.AddDate(0, 0, -daysAgo)
+		return &t
+	}
+
+	projectUsers := []api.ProjectUser{
+		{Project: api.Project{Slug: "libft"}, Validated: &yes, MarkedAt: marked(300)},
+		{Project: api.Project{Slug: "minishell"}, Validated: &yes, MarkedAt: marked(30)},
+		{Project: api.Project{Slug: "cub3d"}, Validated: &no, MarkedAt: marked(10)},
+		{Project: api.Project{Slug: "webserv"}, Validated: nil, MarkedAt: nil},
+		{Project: api.Project{Slug: "push_swap"}, Validated: &yes, MarkedAt: marked(100)},
+	}
+
+	got := recentValidatedProjects(projectUsers, 2)
+	if len(got) != 2 {
+		t.Fatalf("recentValidatedProjects() returned %d projects, want 2", len(got))
+	}
+	if got[0].Project.Slug != "minishell" || got[1].Project.Slug != "push_swap" {
+		t.Errorf("recentValidatedProjects() = [%s %s], want [minishell push_swap]",
+			got[0].Project.Slug, got[1].Project.Slug)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// Quest slugs of the 42cursus common core
+const (
+	commonCoreQuest      = "common-core"
+	commonCoreRankPrefix = "common-core-rank-"
+)
+
+var userQuestsCmd = &cobra.Command{
+	Use:   "quests [login]",
+	Short: "Show a user's quests and their common core rank",
+	Long: `Show the quests of a user, such as the ranks of the 42cursus common core,
+with when each was started and validated, and the common core rank the
+user is on. These are the quests the inscription rules of projects check,
+as 't42 user eligible' and 't42 project next' do.
+
+Without a login, your own quests are shown.
+
+Examples:
+  t42 user quests
+  t42 user quests jdoe
+  t42 user quests --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUserQuests,
+}
+
+func init() {
+	userCmd.AddCommand(userQuestsCmd)
+}
+
+// commonCoreProgress is where a user stands in the common core
+type commonCoreProgress struct {
+	Rank      int  `json:"rank"` // the first rank not validated
+	Validated bool `json:"validated"`
+}
+
+func runUserQuests(cmd *cobra.Command, args []string) error {
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	user, err := resolveUserArg(ctx, client, args)
+	if err != nil {
+		return err
+	}
+	questUsers, err := client.ListUserQuestUsers(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get the quests of %s: %w", user.Login, err)
+	}
+	sortQuestUsers(questUsers)
+	progress := commonCoreRank(questUsers)
+
+	if done, err := emitOutput(
+		output.Field{Key: "quests", Value: questUsers},
+		output.Field{Key: "login", Value: user.Login},
+		output.Field{Key: "common_core", Value: progress},
+	); done || err != nil {
+		return err
+	}
+
+	printUserQuests(user.Login, questUsers, progress)
+	return nil
+}
+
+// sortQuestUsers sorts quests by validation date, the ones in progress
+// last, then by start
+func sortQuestUsers(questUsers []api.QuestUser) {
+	sort.SliceStable(questUsers, func(i, j int) bool {
+		a, b := questUsers[i].ValidatedAt, questUsers[j].ValidatedAt
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return questUsers[i].CreatedAt.Before(questUsers[j].CreatedAt)
+	})
+}
+
+// commonCoreRank returns the common core rank a user is on: the lowest
+// rank quest they have not validated, or the one after the highest when
+// they validated all they have. It returns nil for users without rank
+// quests, outside the 42cursus.
+func commonCoreRank(questUsers []api.QuestUser) *commonCoreProgress {
+	progress := &commonCoreProgress{Rank: -1}
+	lowestOpen := -1
+	for _, qu := range questUsers {
+		if qu.Quest.Slug == commonCoreQuest && qu.ValidatedAt != nil {
+			progress.Validated = true
+			continue
+		}
+		suffix, ok := strings.CutPrefix(qu.Quest.Slug, commonCoreRankPrefix)
+		if !ok {
+			continue
+		}
+		rank, err := strconv.Atoi(suffix)
+		if err != nil {
+			continue
+		}
+		if qu.ValidatedAt != nil {
+			progress.Rank = max(progress.Rank, rank+1)
+		} else if lowestOpen < 0 || rank < lowestOpen {
+			lowestOpen = rank
+		}
+	}
+	if lowestOpen >= 0 {
+		progress.Rank = lowestOpen
+	}
+	if progress.Rank < 0 && !progress.Validated {
+		return nil
+	}
+	return progress
+}
+
+func printUserQuests(login string, questUsers []api.QuestUser, progress *commonCoreProgress) {
+	fmt.Printf("🎯 Quests of %s\n\n", login)
+	switch {
+	case progress == nil:
+	case progress.Validated:
+		fmt.Println("Common core: validated")
+	default:
+		fmt.Printf("Common core: rank %02d\n", progress.Rank)
+	}
+
+	if len(questUsers) == 0 {
+		fmt.Println("No quests.")
+		return
+	}
+	if progress != nil {
+		fmt.Println()
+	}
+	fmt.Printf("%-32s %-28s %-12s %s\n", "QUEST", "SLUG", "STARTED", "VALIDATED")
+	fmt.Println(strings.Repeat("-", 86))
+	for _, qu := range questUsers {
+		validated := "in progress"
+		if qu.ValidatedAt != nil {
+			validated = qu.ValidatedAt.Local().Format("2006-01-02")
+		}
+		fmt.Printf("%-32s %-28s %-12s %s\n", truncateString(qu.Quest.Name, 32), truncateString(qu.Quest.Slug, 28),
+			qu.CreatedAt.Local().Format("2006-01-02"), validated)
+	}
+}
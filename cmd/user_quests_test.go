@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestCommonCoreRank(t *testing.T) {
+	validated := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	quest := func(slug string, done bool) api.QuestUser {
+		qu := api.QuestUser{Quest: api.Quest{Slug: slug}}
+		if done {
+			qu.ValidatedAt = &validated
+		}
+		return qu
+	}
+
+	tests := []struct {
+		name   string
+		quests []api.QuestUser
+		want   *commonCoreProgress
+	}{
+		{"no quests", nil, nil},
+		{"piscine quests only", []api.QuestUser{quest("piscine-c", true)}, nil},
+		{"rank in progress", []api.QuestUser{
+			quest("common-core-rank-00", true),
+			quest("common-core-rank-01", true),
+			quest("common-core-rank-02", false),
+		}, &commonCoreProgress{Rank: 2}},
+		{"next rank not started", []api.QuestUser{
+			quest("common-core-rank-01", true),
+			quest("common-core-rank-00", true),
+		}, &commonCoreProgress{Rank: 2}},
+		{"common core validated", []api.QuestUser{
+			quest("common-core-rank-06", true),
+			quest("common-core", true),
+		}, &commonCoreProgress{Rank: 7, Validated: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := commonCoreRank(tt.quests)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("commonCoreRank() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortQuestUsers(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	validated := func(d int) *time.Time { v := day(d); return &v }
+	questUsers := []api.QuestUser{
+		{Quest: api.Quest{Slug: "open-late"}, CreatedAt: day(9)},
+		{Quest: api.Quest{Slug: "second"}, CreatedAt: day(2), ValidatedAt: validated(8)},
+		{Quest: api.Quest{Slug: "open-early"}, CreatedAt: day(3)},
+		{Quest: api.Quest{Slug: "first"}, CreatedAt: day(1), ValidatedAt: validated(4)},
+	}
+	sortQuestUsers(questUsers)
+
+	want := []string{"first", "second", "open-early", "open-late"}
+	for i, slug := range want {
+		if questUsers[i].Quest.Slug != slug {
+			t.Errorf("quest %d = %s, want %s", i, questUsers[i].Quest.Slug, slug)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// skillBarWidth is the width of the skill level bars
+const skillBarWidth = 20
+
+var userSkillsCmd = &cobra.Command{
+	Use:   "skills [login]",
+	Short: "Show a user's skills, or compare them with another user's",
+	Long: `Show the skills of a user in their current cursus, or the one given with
+--cursus-id (Unix, Algorithms & AI, Web, ...), highest first, with a bar
+scaled to level 20.
+
+--compare puts another user's skills in the same cursus next to them, with
+the difference, sorted from where the first user is furthest ahead to where
+they are furthest behind.
+
+Without a login, your own skills are shown.
+
+Examples:
+  t42 user skills
+  t42 user skills jdoe
+  t42 user skills --compare jdoe
+  t42 user skills alice --compare bob -o csv`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUserSkills,
+}
+
+func init() {
+	userCmd.AddCommand(userSkillsCmd)
+
+	userSkillsCmd.Flags().Int("cursus-id", 0, "Cursus ID (default: the user's current cursus)")
+	userSkillsCmd.Flags().String("compare", "", "Compare with the skills of this login")
+}
+
+// skillDiff is the level of two users in one skill
+type skillDiff struct {
+	Name       string  `json:"name"`
+	Level      float64 `json:"level"`
+	Other      float64 `json:"other_level"`
+	Difference float64 `json:"difference"`
+}
+
+func runUserSkills(cmd *cobra.Command, args []string) error {
+	cursusID, _ := cmd.Flags().GetInt("cursus-id")
+	compare, _ := cmd.Flags().GetString("compare")
+
+	client, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	user, err := resolveUserArg(ctx, client, args)
+	if err != nil {
+		return err
+	}
+	cu := findCursusUser(user.CursusUsers, cursusID)
+	if cu == nil {
+		if cursusID != 0 {
+			return fmt.Errorf("%s is not in cursus %d", user.Login, cursusID)
+		}
+		return fmt.Errorf("%s has no cursus", user.Login)
+	}
+	skills := sortedSkills(cu.Skills)
+
+	if compare == "" {
+		if done, err := emitOutput(
+			output.Field{Key: "skills", Value: skills},
+			output.Field{Key: "login", Value: user.Login},
+			output.Field{Key: "cursus", Value: cu.Cursus.Name},
+		); done || err != nil {
+			return err
+		}
+		printUserSkills(user.Login, cu.Cursus.Name, skills)
+		return nil
+	}
+
+	other, err := client.GetUserByLogin(ctx, compare)
+	if err != nil {
+		return fmt.Errorf("failed to get user %s: %w", compare, err)
+	}
+	// Both users in the same cursus, the first user's
+	otherCU := findCursusUser(other.CursusUsers, cu.Cursus.ID)
+	if otherCU == nil {
+		return fmt.Errorf("%s is not in %s", other.Login, cu.Cursus.Name)
+	}
+	diffs := diffSkills(cu.Skills, otherCU.Skills)
+
+	if done, err := emitOutput(
+		output.Field{Key: "skills", Value: diffs},
+		output.Field{Key: "login", Value: user.Login},
+		output.Field{Key: "other_login", Value: other.Login},
+		output.Field{Key: "cursus", Value: cu.Cursus.Name},
+	); done || err != nil {
+		return err
+	}
+	printSkillDiffs(user.Login, other.Login, cu.Cursus.Name, diffs)
+	return nil
+}
+
+// sortedSkills returns skills from the highest level to the lowest
+func sortedSkills(skills []api.Skill) []api.Skill {
+	sorted := append([]api.Skill(nil), skills...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Level != sorted[j].Level {
+			return sorted[i].Level > sorted[j].Level
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// diffSkills pairs the skills of two users, a skill one of them lacks
+// counting as level 0, sorted by difference, largest lead first
+func diffSkills(skills, other []api.Skill) []skillDiff {
+	byName := make(map[string]*skillDiff)
+	var diffs []*skillDiff
+	get := func(name string) *skillDiff {
+		d, ok := byName[name]
+		if !ok {
+			d = &skillDiff{Name: name}
+			byName[name] = d
+			diffs = append(diffs, d)
+		}
+		return d
+	}
+	for _, s := range skills {
+		get(s.Name).Level = s.Level
+	}
+	for _, s := range other {
+		get(s.Name).Other = s.Level
+	}
+
+	result := make([]skillDiff, len(diffs))
+	for i, d := range diffs {
+		d.Difference = d.Level - d.Other
+		result[i] = *d
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Difference != result[j].Difference {
+			return result[i].Difference > result[j].Difference
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func printUserSkills(login, cursus string, skills []api.Skill) {
+	fmt.Printf("🧠 Skills of %s in %s\n\n", login, cursus)
+	if len(skills) == 0 {
+		fmt.Println("No skills yet.")
+		return
+	}
+	fmt.Printf("%-28s %6s  %s\n", "SKILL", "LEVEL", "")
+	fmt.Println(strings.Repeat("-", 58))
+	for _, s := range skills {
+		fmt.Printf("%-28s %6.2f  %s\n", truncateString(s.Name, 28), s.Level, renderBar(s.Level/maxSkillLevel, skillBarWidth))
+	}
+}
+
+func printSkillDiffs(login, other, cursus string, diffs []skillDiff) {
+	fmt.Printf("🧠 Skills of %s and %s in %s\n\n", login, other, cursus)
+	if len(diffs) == 0 {
+		fmt.Println("Neither has skills yet.")
+		return
+	}
+
+	// Bars of both users are scaled alike
+	fmt.Printf("%-28s %-27s %-27s %7s\n", "SKILL", truncateString(login, 27), truncateString(other, 27), "DIFF")
+	fmt.Println(strings.Repeat("-", 92))
+	for _, d := range diffs {
+		fmt.Printf("%-28s %6.2f %s %6.2f %s %+7.2f\n", truncateString(d.Name, 28),
+			d.Level, renderBar(d.Level/maxSkillLevel, skillBarWidth),
+			d.Other, renderBar(d.Other/maxSkillLevel, skillBarWidth),
+			d.Difference)
+	}
+}
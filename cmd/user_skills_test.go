@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+)
+
+func TestSortedSkills(t *testing.T) {
+	skills := []api.Skill{
+		{Name: "Web", Level: 2.5},
+		{Name: "Unix", Level: 7.1},
+		{Name: "Algorithms & AI", Level: 2.5},
+	}
+
+	got := sortedSkills(skills)
+	want := []string{"Unix", "Algorithms & AI", "Web"}
+	for i, s := range got {
+		if s.Name != want[i] {
+			t.Errorf("sortedSkills()[%d] = %s, want %s", i, s.Name, want[i])
+		}
+	}
+	if skills[0].Name != "Web" {
+		t.Error("sortedSkills() sorted its argument")
+	}
+}
+
+func TestDiffSkills(t *testing.T) {
+	mine := []api.Skill{
+		{Name: "Unix", Level: 7},
+		{Name: "Web", Level: 2},
+		{Name: "Rigor", Level: 5},
+	}
+	theirs := []api.Skill{
+		{Name: "Web", Level: 6},
+		{Name: "Unix", Level: 4},
+		{Name: "Graphics", Level: 1},
+	}
+
+	want := []skillDiff{
+		{Name: "Rigor", Level: 5, Other: 0, Difference: 5},
+		{Name: "Unix", Level: 7, Other: 4, Difference: 3},
+		{Name: "Graphics", Level: 0, Other: 1, Difference: -1},
+		{Name: "Web", Level: 2, Other: 6, Difference: -4},
+	}
+	if got := diffSkills(mine, theirs); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffSkills() = %+v, want %+v", got, want)
+	}
+}
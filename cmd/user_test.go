@@ -600,3 +600,45 @@ func TestFilterUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultUserListScope(t *testing.T) {
+	ended := time.Now().AddDate(-1, 0, 0)
+	me := &api.User{
+		Campus:      []api.Campus{{ID: 1, Name: "Paris"}, {ID: 26, Name: "Tokyo"}},
+		CampusUsers: []api.CampusUser{{CampusID: 1}, {CampusID: 26, IsPrimary: true}},
+		CursusUsers: []api.CursusUser{
+			{Cursus: api.Cursus{ID: 9, Name: "C Piscine"}, EndAt: &ended},
+			{Cursus: api.Cursus{ID: 21, Name: "42cursus"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		user       *api.User
+		campusOnly bool
+		wantCampus int
+		wantCursus int
+		wantScope  string
+	}{
+		{"primary campus and current cursus", me, false, 26, 21, "your campus (Tokyo) in 42cursus"},
+		{"campus only for alumni filters", me, true, 26, 0, "your campus (Tokyo)"},
+		{"no cursus", &api.User{Campus: []api.Campus{{ID: 1, Name: "Paris"}}}, false, 1, 0, "your campus (Paris)"},
+		{"no campus", &api.User{CursusUsers: me.CursusUsers}, false, 0, 21, "every campus in 42cursus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campus, cursusID, cursusName := defaultUserListScope(tt.user, tt.campusOnly)
+			campusID := 0
+			if campus != nil {
+				campusID = campus.ID
+			}
+			if campusID != tt.wantCampus || cursusID != tt.wantCursus {
+				t.Errorf("defaultUserListScope() = campus %d, cursus %d, want %d, %d", campusID, cursusID, tt.wantCampus, tt.wantCursus)
+			}
+			if got := describeUserListScope(campus, cursusName); got != tt.wantScope {
+				t.Errorf("describeUserListScope() = %q, want %q", got, tt.wantScope)
+			}
+		})
+	}
+}
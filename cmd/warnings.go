@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+// commandWarnings collects the non-fatal problems of the running command,
+// such as users skipped because they could not be fetched. They are added
+// to its JSON or YAML output under "warnings", and printed to stderr when
+// that output does not show them, so they never end up among table rows or
+// piped data.
+var commandWarnings output.Warnings
+
+// warnf records a warning of the running command. code names the kind of
+// problem for scripts, e.g. "user_skipped".
+func warnf(code, format string, args ...interface{}) {
+	commandWarnings.Add(code, fmt.Sprintf(format, args...))
+}
+
+// warningOptions passes the problems the API client works around to the
+// command's warnings
+func warningOptions() []api.ClientOption {
+	return []api.ClientOption{api.WithWarnings(commandWarnings.Add)}
+}
+
+// withWarnings appends the warnings recorded so far to the fields of a
+// JSON object
+func withWarnings(fields []output.Field) []output.Field {
+	warnings := commandWarnings.List()
+	if len(warnings) == 0 {
+		return fields
+	}
+	return append(fields[:len(fields):len(fields)], output.Field{Key: "warnings", Value: warnings})
+}
+
+// warningsShownInOutput reports whether the structured output written to
+// stdout carries the warnings: JSON and YAML do, unless --jq or --template
+// reshapes them or --fields leaves them out; CSV and TSV rows do not
+func warningsShownInOutput() bool {
+	format := GetOutputFormat()
+	if outputFields != nil && !outputFields.Has("warnings") {
+		return false
+	}
+	return (format == output.FormatJSON || format == output.FormatYAML) && !outputFiltered()
+}
+
+// printWarnings prints the warnings not shown in the output to w, and
+// forgets them
+func printWarnings(w io.Writer) {
+	for _, warning := range commandWarnings.List() {
+		fmt.Fprintf(w, "⚠️  %s\n", warning.Message)
+	}
+	commandWarnings.Reset()
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+func TestWarningsInOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     output.Format
+		wantInJSON bool
+		wantStderr string
+	}{
+		{name: "json", format: output.FormatJSON, wantInJSON: true},
+		{name: "csv", format: output.FormatCSV, wantStderr: "⚠️  skipped bob: failed to get user\n"},
+		{name: "table", format: output.FormatTable, wantStderr: "⚠️  skipped bob: failed to get user\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFormat = tt.format
+			defer func() {
+				outputFormat = ""
+				commandWarnings.Reset()
+			}()
+			warnf("user_skipped", "skipped %s: failed to get user", "bob")
+
+			stdout := captureStdout(t, func() {
+				if _, err := emitOutput(output.Field{Key: "users", Value: []map[string]string{{"login": "alice"}}}); err != nil {
+					t.Fatalf("emitOutput() error = %v", err)
+				}
+			})
+
+			var doc struct {
+				Warnings []output.Warning `json:"warnings"`
+			}
+			if tt.format == output.FormatJSON {
+				if err := json.Unmarshal([]byte(stdout), &doc); err != nil {
+					t.Fatalf("invalid JSON output %q: %v", stdout, err)
+				}
+			}
+			if got := len(doc.Warnings) == 1 && doc.Warnings[0].Code == "user_skipped"; got != tt.wantInJSON {
+				t.Errorf("warnings in the JSON output = %v, want them: %v", doc.Warnings, tt.wantInJSON)
+			}
+
+			var stderr bytes.Buffer
+			printWarnings(&stderr)
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+// captureStdout returns what run writes to os.Stdout
+func captureStdout(t *testing.T, run func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+	run()
+	_ = w.Close()
+	return string(<-done)
+}
@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+const (
+	// watchMinInterval keeps watch loops well inside the API rate limit
+	watchMinInterval = 5 * time.Second
+
+	// watchDefaultInterval is the default time between two checks
+	watchDefaultInterval = 30 * time.Second
+)
+
+// watchableCommands are the read-only commands watch may repeat, with the
+// flags that would make them change something or never exit
+var watchableCommands = map[string][]string{
+	"announcements":       {"--open", "--mark-read"},
+	"api":                 nil, // GET requests only, see apiArgsReadOnly
+	"auth status":         nil,
+	"blackhole":           {"--warn-days"},
+	"cache status":        nil,
+	"campus list":         nil,
+	"campus show":         nil,
+	"campus stats":        nil,
+	"coalition list":      nil,
+	"coalition rank":      nil,
+	"coalition show":      nil,
+	"cursus levels":       nil,
+	"eval absences":       nil,
+	"eval audit":          nil,
+	"eval list":           {"--watch"},
+	"eval show":           nil,
+	"event list":          nil,
+	"event show":          nil,
+	"location list":       {"--watch"},
+	"location show":       nil,
+	"presence":            nil,
+	"project feedback":    nil,
+	"project feedbacks":   nil,
+	"project list":        nil,
+	"project next":        nil,
+	"project search":      nil,
+	"project show":        nil,
+	"project tree":        nil,
+	"remind list":         nil,
+	"search":              {"--open", "--pick"},
+	"slot list":           nil,
+	"stats usage":         {"--reset"},
+	"team blame":          nil,
+	"team deadline":       nil,
+	"team list":           nil,
+	"team show":           nil,
+	"team skills":         nil,
+	"user blackhole-list": nil,
+	"user compare":        nil,
+	"user eligible":       {"--checkpoint"},
+	"user level":          nil,
+	"user list":           nil,
+	"user logtime":        nil,
+	"user points":         nil,
+	"user quests":         nil,
+	"user skills":         nil,
+	"user show":           {"--watch"},
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <command> --until <condition>",
+	Short: "Repeat a command until a condition on its output holds",
+	Long: `Run a read-only t42 command repeatedly until a jq condition over its
+JSON output is true, then exit 0. This turns "wait until X happens" into a
+single line of shell script.
+
+The command is given without the leading "t42", either as one quoted
+argument or as separate arguments after "--". It runs with --json, and the
+condition holds when the last value the expression produces is neither
+false nor null (like jq -e). With --json, the output that satisfied the
+condition is printed, filtered by --jq or --template when given.
+
+watch exits with an error when --timeout passes first, or when the command
+fails --max-failures times in a row.
+
+Examples:
+  t42 watch 'project show libft' --until '.teams[0].status == "finished"'
+  t42 watch 'api /v2/me/slots' --until 'length > 0' --interval 1m
+  t42 watch --until '.location != null' --timeout 2h -- user show jdoe`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().String("until", "", "jq condition over the command's JSON output (required)")
+	watchCmd.Flags().Duration("interval", watchDefaultInterval, "Time between checks (at least 5s)")
+	watchCmd.Flags().Duration("timeout", 0, "Give up after this long (0 waits forever)")
+	watchCmd.Flags().Int("max-failures", 3, "Give up after this many failed runs in a row")
+	_ = watchCmd.MarkFlagRequired("until")
+}
+
+// watcher repeats a command until its output satisfies a condition
+type watcher struct {
+	run         func(ctx context.Context) ([]byte, error)
+	condition   *gojq.Code
+	interval    time.Duration
+	timeout     time.Duration
+	maxFailures int
+
+	// progress reports each check that did not end the watch
+	progress func(check int, err error)
+}
+
+// watchResult describes the check that satisfied the condition
+type watchResult struct {
+	Output  []byte
+	Checks  int
+	Elapsed time.Duration
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	until, _ := cmd.Flags().GetString("until")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	maxFailures, _ := cmd.Flags().GetInt("max-failures")
+
+	if interval < watchMinInterval {
+		return fmt.Errorf("--interval must be at least %s", watchMinInterval)
+	}
+	if maxFailures < 1 {
+		return fmt.Errorf("--max-failures must be at least 1")
+	}
+
+	condition, err := output.ParseJQ(until)
+	if err != nil {
+		return fmt.Errorf("invalid --until condition: %w", err)
+	}
+
+	commandArgs, err := watchCommandArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := checkWatchable(commandArgs); err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the t42 executable: %w", err)
+	}
+
+	w := &watcher{
+		run: func(ctx context.Context) ([]byte, error) {
+			return runT42JSON(ctx, executable, commandArgs)
+		},
+		condition:   condition,
+		interval:    interval,
+		timeout:     timeout,
+		maxFailures: maxFailures,
+		progress: func(check int, err error) {
+			if GetJSONOutput() {
+				return
+			}
+			now := time.Now().Format("15:04:05")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  [%s] check %d failed: %v\n", now, check, err)
+				return
+			}
+			fmt.Fprintf(os.Stderr, "⏳ [%s] check %d: condition not met, next check in %s\n", now, check, interval)
+		},
+	}
+
+	result, err := w.wait(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutSink(func(w io.Writer) error {
+		_, err := w.Write(result.Output)
+		return err
+	}); err != nil {
+		return err
+	}
+	if GetJSONOutput() {
+		return writeFilteredJSON(os.Stdout, result.Output)
+	}
+	fmt.Printf("✅ Condition met after %d check(s) (%s)\n", result.Checks, result.Elapsed.Truncate(time.Second))
+	return nil
+}
+
+// wait runs the command until the condition holds, the timeout passes or
+// the command fails too often in a row
+func (w *watcher) wait(ctx context.Context) (*watchResult, error) {
+	start := time.Now()
+	if w.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+	}
+
+	failures := 0
+	for check := 1; ; check++ {
+		output, err := w.run(ctx)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("condition not met within %s (%d checks)", w.timeout, check)
+		}
+
+		if err == nil {
+			var met bool
+			if met, err = conditionMet(w.condition, output); err == nil && met {
+				return &watchResult{Output: output, Checks: check, Elapsed: time.Since(start)}, nil
+			}
+		}
+		if err != nil {
+			failures++
+			if failures >= w.maxFailures {
+				return nil, fmt.Errorf("giving up after %d failed checks: %w", failures, err)
+			}
+		} else {
+			failures = 0
+		}
+		if w.progress != nil {
+			w.progress(check, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("condition not met within %s (%d checks)", w.timeout, check)
+		case <-time.After(w.interval):
+		}
+	}
+}
+
+// conditionMet evaluates condition over output; like jq -e, it holds when
+// the last result is truthy
+func conditionMet(condition *gojq.Code, data []byte) (bool, error) {
+	results, err := output.RunJQJSON(condition, data)
+	if err != nil {
+		return false, err
+	}
+	if len(results) == 0 {
+		return false, nil
+	}
+	last := results[len(results)-1]
+	return last != nil && last != false, nil
+}
+
+// runT42JSON runs t42 with args and --json and returns its standard output
+func runT42JSON(ctx context.Context, executable string, args []string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, executable, append(append([]string{}, args...), "--json")...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, lastLine(msg))
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// watchCommandArgs returns the command to watch: a single argument is split
+// like a shell would, and a leading "t42" is dropped
+func watchCommandArgs(args []string) ([]string, error) {
+	if len(args) == 1 {
+		split, err := splitCommandLine(args[0])
+		if err != nil {
+			return nil, err
+		}
+		args = split
+	}
+	if len(args) > 0 && strings.TrimSuffix(filepath.Base(args[0]), ".exe") == "t42" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no command to watch")
+	}
+	return args, nil
+}
+
+// checkWatchable refuses commands that are not known to be read-only, since
+// watch may run them many times
+func checkWatchable(args []string) error {
+	target, rest, err := rootCmd.Find(args)
+	if err != nil || target == rootCmd {
+		return fmt.Errorf("unknown command %q", strings.Join(args, " "))
+	}
+
+	path := strings.TrimPrefix(target.CommandPath(), rootCmd.Name()+" ")
+	rejected, ok := watchableCommands[path]
+	if !ok {
+		return fmt.Errorf("'t42 %s' is not a read-only command and cannot be watched", path)
+	}
+	for _, arg := range rest {
+		for _, flag := range rejected {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return fmt.Errorf("'t42 %s %s' cannot be watched", path, flag)
+			}
+		}
+	}
+	if target == apiCmd && !apiArgsReadOnly(rest) {
+		return fmt.Errorf("only GET requests of 't42 api' can be watched")
+	}
+	return nil
+}
+
+// apiArgsReadOnly reports whether 't42 api' arguments make a GET request
+func apiArgsReadOnly(args []string) bool {
+	var positional []string
+	hasFields := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-F" || arg == "-f" || arg == "--field" || arg == "--raw-field":
+			hasFields = true
+			i++ // skip the value
+		case strings.HasPrefix(arg, "-F") || strings.HasPrefix(arg, "-f") ||
+			strings.HasPrefix(arg, "--field=") || strings.HasPrefix(arg, "--raw-field="):
+			hasFields = true
+		case arg == "-q" || arg == "--jq" || arg == "-t" || arg == "--template":
+			i++
+		case strings.HasPrefix(arg, "-"):
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 2 {
+		return strings.EqualFold(positional[0], http.MethodGet)
+	}
+	return !hasFields
+}
+
+// splitCommandLine splits s into arguments, honouring single quotes, double
+// quotes and backslash escapes as a POSIX shell does
+func splitCommandLine(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+				i++
+				current.WriteRune(runes[i])
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, s)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// watchModeMaxFailures stops --watch after this many failed refreshes in a
+// row, e.g. when the token expired
+const watchModeMaxFailures = 3
+
+// addWatchModeFlag adds --watch to a read-only command whose RunE is wrapped
+// with watchable. A bare --watch refreshes every watchDefaultInterval.
+func addWatchModeFlag(cmd *cobra.Command) {
+	cmd.Flags().Duration("watch", 0, "Refresh the output periodically, like watch(1) (e.g. --watch=1m; default 30s)")
+	cmd.Flags().Lookup("watch").NoOptDefVal = watchDefaultInterval.String()
+}
+
+// watchable wraps run so that with --watch it is run again every interval,
+// redrawing the screen each time, until Ctrl-C. With --json or when stdout
+// is not a terminal, each refresh is printed after the previous one.
+func watchable(run func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("watch")
+		if interval == 0 {
+			return run(cmd, args)
+		}
+		if interval < watchMinInterval {
+			return fmt.Errorf("--watch interval must be at least %s", watchMinInterval)
+		}
+
+		redraw := !GetJSONOutput() && isTerminal(os.Stdout)
+		return watchLoop(context.Background(), os.Stdout, watchLoopOptions{
+			interval: interval,
+			redraw:   redraw,
+			title:    "t42 " + strings.Join(os.Args[1:], " "),
+			now:      time.Now,
+		}, func() error {
+			defer printWarnings(os.Stderr)
+			return run(cmd, args)
+		})
+	}
+}
+
+// watchLoopOptions control watchLoop
+type watchLoopOptions struct {
+	interval time.Duration
+	redraw   bool   // clear the screen and print a header before each run
+	title    string // the command line shown in the header
+	now      func() time.Time
+}
+
+// watchLoop calls run every interval until ctx is done or run fails
+// watchModeMaxFailures times in a row. A failure is reported on stderr and
+// retried at the next refresh.
+func watchLoop(ctx context.Context, w io.Writer, opts watchLoopOptions, run func() error) error {
+	failures := 0
+	for {
+		if opts.redraw {
+			fmt.Fprint(w, clearScreen)
+			fmt.Fprintf(w, "Every %s: %s    %s\n\n", opts.interval, opts.title, opts.now().Format("Mon Jan 2 15:04:05"))
+		}
+
+		if err := run(); err != nil {
+			failures++
+			if failures >= watchModeMaxFailures {
+				return fmt.Errorf("giving up after %d failed refreshes: %w", failures, err)
+			}
+			fmt.Fprintf(os.Stderr, "⚠️  [%s] refresh failed: %v\n", opts.now().Format("15:04:05"), err)
+		} else {
+			failures = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.interval):
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchLoop(t *testing.T) {
+	now := func() time.Time { return time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC) }
+	boom := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		results   []error // returned by the successive runs
+		redraw    bool
+		wantRuns  int
+		wantErr   bool
+		wantClear int
+	}{
+		{name: "runs until cancelled", results: []error{nil, nil, nil}, wantRuns: 3},
+		{name: "redraws each run", results: []error{nil, nil}, redraw: true, wantRuns: 2, wantClear: 2},
+		{name: "survives a few failures", results: []error{boom, boom, nil, boom}, wantRuns: 4},
+		{name: "gives up after failures in a row", results: []error{nil, boom, boom, boom, nil}, wantRuns: 4, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var out bytes.Buffer
+			runs := 0
+			err := watchLoop(ctx, &out, watchLoopOptions{
+				interval: time.Millisecond,
+				redraw:   tt.redraw,
+				title:    "t42 eval list --watch",
+				now:      now,
+			}, func() error {
+				err := tt.results[runs]
+				runs++
+				if runs == len(tt.results) {
+					cancel()
+				}
+				return err
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("watchLoop() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("watchLoop() ran %d times, want %d", runs, tt.wantRuns)
+			}
+			if got := strings.Count(out.String(), clearScreen); got != tt.wantClear {
+				t.Errorf("screen cleared %d times, want %d", got, tt.wantClear)
+			}
+			if tt.redraw && !strings.Contains(out.String(), "Every 1ms: t42 eval list --watch    Fri Oct 16 09:30:00\n") {
+				t.Errorf("missing header in %q", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/output"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"project show libft", []string{"project", "show", "libft"}, false},
+		{"  user   list ", []string{"user", "list"}, false},
+		{`api /v2/users -f 'filter[login]=a b'`, []string{"api", "/v2/users", "-f", "filter[login]=a b"}, false},
+		{`say "a \"b\" c" d\ e`, []string{"say", `a "b" c`, "d e"}, false},
+		{`empty ''`, []string{"empty", ""}, false},
+		{`open 'quote`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := splitCommandLine(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitCommandLine(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitCommandLine(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckWatchable(t *testing.T) {
+	tests := []struct {
+		args    string
+		wantErr bool
+	}{
+		{"project show libft", false},
+		{"t42 user show jdoe", false},
+		{"api /v2/me", false},
+		{"api GET /v2/users -f filter[login]=jdoe", false},
+		{"announcements --unread", false},
+		{"api /v2/slots -F slot[user_id]=1", true},
+		{"api DELETE /v2/slots/1", true},
+		{"announcements --mark-read", true},
+		{"auth logout", true},
+		{"project clone libft", true},
+		{"watch 'user list' --until true", true},
+		{"user show jdoe --watch", true},
+		{"eval list --watch=1m", true},
+		{"no-such-command", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			args, err := watchCommandArgs([]string{tt.args})
+			if err == nil {
+				err = checkWatchable(args)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkWatchable(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWatcherWait(t *testing.T) {
+	condition, err := output.ParseJQ(`.status == "finished"`)
+	if err != nil {
+		t.Fatalf("ParseJQ() error = %v", err)
+	}
+
+	t.Run("until the condition holds", func(t *testing.T) {
+		outputs := []string{`{"status":"waiting"}`, `{"status":"in_progress"}`, `{"status":"finished"}`}
+		calls := 0
+		w := &watcher{
+			run: func(context.Context) ([]byte, error) {
+				calls++
+				return []byte(outputs[calls-1]), nil
+			},
+			condition:   condition,
+			interval:    time.Millisecond,
+			maxFailures: 3,
+		}
+		result, err := w.wait(context.Background())
+		if err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+		if result.Checks != 3 || !strings.Contains(string(result.Output), "finished") {
+			t.Errorf("wait() = %+v", result)
+		}
+	})
+
+	t.Run("consecutive failures", func(t *testing.T) {
+		calls := 0
+		w := &watcher{
+			run: func(context.Context) ([]byte, error) {
+				calls++
+				if calls == 2 {
+					return []byte(`{"status":"waiting"}`), nil
+				}
+				return nil, errors.New("boom")
+			},
+			condition:   condition,
+			interval:    time.Millisecond,
+			maxFailures: 2,
+		}
+		if _, err := w.wait(context.Background()); err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Fatalf("wait() error = %v, want boom", err)
+		}
+		// A success in between resets the count
+		if calls != 4 {
+			t.Errorf("command ran %d times, want 4", calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		w := &watcher{
+			run: func(context.Context) ([]byte, error) {
+				return []byte(`{"status":"waiting"}`), nil
+			},
+			condition:   condition,
+			interval:    5 * time.Millisecond,
+			timeout:     20 * time.Millisecond,
+			maxFailures: 1,
+		}
+		if _, err := w.wait(context.Background()); err == nil || !strings.Contains(err.Error(), "not met within") {
+			t.Errorf("wait() error = %v, want timeout", err)
+		}
+	})
+}
+
+func TestConditionMet(t *testing.T) {
+	tests := []struct {
+		expr   string
+		output string
+		want   bool
+	}{
+		{`length > 0`, `[1]`, true},
+		{`length > 0`, `[]`, false},
+		{`.location`, `{"location":null}`, false},
+		{`.[] | .done`, `[{"done":false},{"done":true}]`, true},
+		{`.[] | .done`, `[]`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr+" "+tt.output, func(t *testing.T) {
+			query, err := output.ParseJQ(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseJQ() error = %v", err)
+			}
+			got, err := conditionMet(query, []byte(tt.output))
+			if err != nil || got != tt.want {
+				t.Errorf("conditionMet() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
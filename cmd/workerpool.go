@@ -0,0 +1,28 @@
+package cmd
+
+import "sync"
+
+// runWorkers calls fn on every item with at most workers calls in flight
+// and returns the results in item order. The API client's rate limiter is
+// shared by all workers, so more workers hide latency without exceeding
+// the request quota.
+func runWorkers[T, R any](items []T, workers int, fn func(T) R) []R {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]R, len(items))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, workers)
+	for i, item := range items {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i] = fn(item)
+		}(i, item)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunWorkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   int
+		workers int
+	}{
+		{name: "more items than workers", items: 20, workers: 4},
+		{name: "single worker", items: 5, workers: 1},
+		{name: "invalid worker count", items: 3, workers: 0},
+		{name: "no items", items: 0, workers: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]int, tt.items)
+			for i := range items {
+				items[i] = i
+			}
+
+			var inFlight, maxInFlight int32
+			results := runWorkers(items, tt.workers, func(n int) int {
+				current := atomic.AddInt32(&inFlight, 1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return n * n
+			})
+
+			if len(results) != tt.items {
+				t.Fatalf("got %d results, want %d", len(results), tt.items)
+			}
+			for i, got := range results {
+				if got != i*i {
+					t.Errorf("results[%d] = %d, want %d", i, got, i*i)
+				}
+			}
+			limit := int32(tt.workers)
+			if limit < 1 {
+				limit = 1
+			}
+			if maxInFlight > limit {
+				t.Errorf("%d calls in flight, want at most %d", maxInFlight, limit)
+			}
+		})
+	}
+}
@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/naokiiida/t42-cli/internal/ratelimit"
 )
 
 const (
@@ -27,7 +32,8 @@ const (
 	// MaxRetries is the maximum number of retries for failed requests
 	MaxRetries = 3
 
-	// RetryDelay is the delay between retries
+	// RetryDelay is the base delay of the retry backoff, doubled with each
+	// retry; a Retry-After or rate limit header takes precedence
 	RetryDelay = 1 * time.Second
 )
 
@@ -36,8 +42,18 @@ type Client struct {
 	baseURL        string
 	httpClient     *http.Client
 	token          string
+	tokenMu        sync.RWMutex // Guards token when the client is shared between goroutines
 	userAgent      string
 	tokenRefresher func() (string, error) // Optional callback to refresh the token
+	refreshMu      sync.Mutex             // Serializes token refreshes between goroutines
+	limiter        ratelimit.Limiter      // Throttle applied before every request attempt; the API quota by default
+	responseCache  ResponseCache          // Optional cache of GET responses, see WithResponseCache
+	cachePolicy    CachePolicy
+	recorder       *CallRecorder              // Optional request statistics, see WithCallRecorder
+	readOnly       bool                       // Refuse requests other than GET, see WithReadOnly
+	projectIndex   ProjectIndex               // Optional projects to fuzzy-match, see WithProjectIndex
+	retryObserver  func(Retry)                // Optional, called before each retry, see WithRetryObserver
+	warn           func(code, message string) // Optional, receives non-fatal problems, see WithWarnings
 }
 
 // ClientOption represents a client configuration option
@@ -71,6 +87,54 @@ func WithTokenRefresher(refresher func() (string, error)) ClientOption {
 	}
 }
 
+// WithRateLimiter replaces the built-in rate limiter with one that every
+// request attempt must pass before being sent; nil disables throttling
+func WithRateLimiter(limiter ratelimit.Limiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithRateLimits sets the limits of the built-in rate limiter, for
+// applications whose quota differs from the default 2 requests per second
+// and 1200 per hour
+func WithRateLimits(perSecond float64, perHour int) ClientOption {
+	return func(c *Client) {
+		c.limiter = ratelimit.Chain(
+			ratelimit.NewBucket(perSecond, ratelimit.DefaultBurst),
+			ratelimit.NewHourlyBucket(perHour))
+	}
+}
+
+// ErrReadOnly is returned for requests that would change data on a
+// read-only client
+var ErrReadOnly = errors.New("read-only mode")
+
+// WithReadOnly makes the client refuse every request but GET and HEAD
+// before it is sent, so that a token used by a dashboard or a bot cannot
+// book slots or change registrations even if the script using it does
+func WithReadOnly() ClientOption {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// WithWarnings passes the problems the client works around to warn instead
+// of dropping them, e.g. a list page without pagination headers. code names
+// the kind of problem, such as "pagination_headers_missing".
+func WithWarnings(warn func(code, message string)) ClientOption {
+	return func(c *Client) {
+		c.warn = warn
+	}
+}
+
+// warnf reports a problem the client works around, see WithWarnings
+func (c *Client) warnf(code, format string, args ...interface{}) {
+	if c.warn != nil {
+		c.warn(code, fmt.Sprintf(format, args...))
+	}
+}
+
 // NewClient creates a new 42 API client with the given access token
 func NewClient(token string, options ...ClientOption) *Client {
 	client := &Client{
@@ -80,6 +144,7 @@ func NewClient(token string, options ...ClientOption) *Client {
 		},
 		token:     token,
 		userAgent: "t42-cli/1.0",
+		limiter:   ratelimit.NewDefault(),
 	}
 
 	// Apply options
@@ -92,7 +157,19 @@ func NewClient(token string, options ...ClientOption) *Client {
 
 // makeRequest performs an HTTP request with authentication and error handling
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	if c.readOnly && method != http.MethodGet && method != http.MethodHead {
+		return nil, fmt.Errorf("%w: refusing %s %s", ErrReadOnly, method, endpoint)
+	}
+	if method == http.MethodGet && c.responseCache != nil {
+		return c.cachedRequest(ctx, endpoint)
+	}
+	return c.sendRequest(ctx, method, endpoint, body)
+}
+
+// sendRequest sends a request, refreshing the token once on 401 Unauthorized
+func (c *Client) sendRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
 	// Try request with current token
+	usedToken := c.GetToken()
 	resp, err := c.doRequest(ctx, method, endpoint, body)
 	if err != nil {
 		return nil, err
@@ -104,14 +181,20 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 			fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
 		}
 
-		// Attempt to refresh the token
-		newToken, refreshErr := c.tokenRefresher()
-		if refreshErr != nil {
-			return nil, fmt.Errorf("token refresh failed: %w", refreshErr)
-		}
+		// Concurrent requests failing together refresh only once; the
+		// others retry with the token the first one obtained
+		c.refreshMu.Lock()
+		if c.GetToken() == usedToken {
+			newToken, refreshErr := c.tokenRefresher()
+			if refreshErr != nil {
+				c.refreshMu.Unlock()
+				return nil, fmt.Errorf("%w: token refresh failed: %w", ErrUnauthorized, refreshErr)
+			}
 
-		// Update the client's token
-		c.token = newToken
+			// Update the client's token
+			c.setToken(newToken)
+		}
+		c.refreshMu.Unlock()
 
 		// Retry the request with the new token
 		resp, err = c.doRequest(ctx, method, endpoint, body)
@@ -145,7 +228,7 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 	}
 
 	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Authorization", "Bearer "+c.GetToken())
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Accept", "application/json")
 
@@ -156,6 +239,7 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 	// Perform request with retries
 	var resp *http.Response
 	var lastErr error
+	var wait time.Duration
 
 	for attempt := 0; attempt <= MaxRetries; attempt++ {
 		if attempt > 0 {
@@ -163,17 +247,38 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(RetryDelay * time.Duration(attempt)):
+			case <-time.After(wait):
+			}
+			// The previous attempt consumed the body
+			if req.GetBody != nil {
+				if req.Body, err = req.GetBody(); err != nil {
+					return nil, fmt.Errorf("failed to rewind request body: %w", err)
+				}
+			}
+		}
+
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limiter: %w", err)
 			}
 		}
 
-		resp, lastErr = c.httpClient.Do(req)
+		resp, lastErr = c.httpClient.Do(req.WithContext(withAttempt(ctx, attempt+1)))
 		if lastErr != nil {
+			if attempt < MaxRetries {
+				wait, _ = c.beforeRetry(req, nil, lastErr, attempt+1)
+			}
 			continue // Retry on network errors
 		}
 
-		// Check if we should retry based on status code
-		if resp.StatusCode >= 500 || resp.StatusCode == 429 {
+		// Check if we should retry based on status code; the last
+		// response, or one the server wants us to wait too long
+		// after, is returned as it is
+		if (resp.StatusCode >= 500 || resp.StatusCode == 429) && attempt < MaxRetries {
+			var retry bool
+			if wait, retry = c.beforeRetry(req, resp, nil, attempt+1); !retry {
+				break
+			}
 			if err := resp.Body.Close(); err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
 			}
@@ -191,6 +296,36 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 	return resp, nil
 }
 
+// beforeRetry works out the wait before retry number retry (1 for the
+// first) after a failed attempt, and reports the retry to the observer and
+// the call recorder. It returns false when the server asked for too long a
+// wait to retry.
+func (c *Client) beforeRetry(req *http.Request, resp *http.Response, err error, retry int) (time.Duration, bool) {
+	wait, reason, ok := retryWait(resp, retry, time.Now())
+	if !ok {
+		return 0, false
+	}
+
+	event := Retry{
+		Method:   req.Method,
+		Endpoint: normalizeEndpoint(req.URL.Path),
+		Attempt:  retry + 1,
+		Err:      err,
+		Wait:     wait,
+		Reason:   reason,
+	}
+	if resp != nil {
+		event.Status = resp.StatusCode
+	}
+	if c.recorder != nil {
+		c.recorder.RecordRetry(event)
+	}
+	if c.retryObserver != nil {
+		c.retryObserver(event)
+	}
+	return wait, true
+}
+
 // handleResponse processes an HTTP response and unmarshals JSON data
 func (c *Client) handleResponse(resp *http.Response, target interface{}) error {
 	defer func() {
@@ -206,18 +341,7 @@ func (c *Client) handleResponse(resp *http.Response, target interface{}) error {
 
 	// Check for API errors
 	if resp.StatusCode >= 400 {
-		var apiError ErrorResponse
-		if err := json.Unmarshal(body, &apiError); err != nil {
-			// If we can't parse the error response, return a generic error
-			return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-		}
-
-		// Set status code if not present in the error response
-		if apiError.Status == 0 {
-			apiError.Status = resp.StatusCode
-		}
-
-		return fmt.Errorf("API error (status %d): %s", apiError.Status, apiError.Message)
+		return responseError(resp, body)
 	}
 
 	// Parse successful response
@@ -230,6 +354,61 @@ func (c *Client) handleResponse(resp *http.Response, target interface{}) error {
 	return nil
 }
 
+// RawResponse is the undecoded response of a Passthrough request
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Meta       *PaginationMeta
+}
+
+// Passthrough sends a request to an arbitrary endpoint (e.g. "/v2/cursus")
+// and returns the response body undecoded. The query is appended to the
+// endpoint. Status codes >= 400 are reported as an error, and the raw
+// response is returned alongside it so callers can still show the body.
+func (c *Client) Passthrough(ctx context.Context, method, endpoint string, query url.Values, body interface{}) (*RawResponse, error) {
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(endpoint, "?") {
+			separator = "&"
+		}
+		endpoint += separator + query.Encode()
+	}
+
+	resp, err := c.makeRequest(ctx, strings.ToUpper(method), endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
+		}
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Count the items of list responses for the pagination metadata
+	var items []json.RawMessage
+	count := 0
+	if json.Unmarshal(data, &items) == nil {
+		count = len(items)
+	}
+
+	raw := &RawResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       data,
+		Meta:       c.extractPaginationMeta(resp, count),
+	}
+	if resp.StatusCode >= 400 {
+		return raw, responseError(resp, data)
+	}
+	return raw, nil
+}
+
 // GetMe returns information about the authenticated user
 func (c *Client) GetMe(ctx context.Context) (*User, error) {
 	resp, err := c.makeRequest(ctx, "GET", "/v2/me", nil)
@@ -283,6 +462,8 @@ type ListProjectsOptions struct {
 	PerPage  int
 	CursusID int
 	Sort     string
+	// Search matches a substring of the given fields (search[name]=...)
+	Search map[string]string
 }
 
 // ListProjects returns a list of projects with optional filtering
@@ -291,41 +472,20 @@ func (c *Client) ListProjects(ctx context.Context, opts *ListProjectsOptions) ([
 		opts = &ListProjectsOptions{}
 	}
 
-	// Set defaults
-	if opts.PerPage == 0 {
-		opts.PerPage = DefaultPerPage
-	}
-	if opts.Page == 0 {
-		opts.Page = 1
-	}
-
-	// Build query parameters
-	params := url.Values{}
-	params.Set("page", strconv.Itoa(opts.Page))
-	params.Set("per_page", strconv.Itoa(opts.PerPage))
+	params := pageQuery(&opts.Page, &opts.PerPage)
 
 	if opts.CursusID > 0 {
 		params.Set("filter[cursus_id]", strconv.Itoa(opts.CursusID))
 	}
+	for field, term := range opts.Search {
+		params.Set("search["+field+"]", term)
+	}
 	if opts.Sort != "" {
 		params.Set("sort", opts.Sort)
 	}
 
 	endpoint := "/v2/projects?" + params.Encode()
-	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var projects []Project
-	if err := c.handleResponse(resp, &projects); err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination metadata from headers
-	meta := c.extractPaginationMeta(resp, len(projects))
-
-	return projects, meta, nil
+	return getPage[Project](ctx, c, endpoint)
 }
 
 // GetProject returns information about a specific project by ID
@@ -346,6 +506,19 @@ func (c *Client) GetProject(ctx context.Context, projectID int) (*Project, error
 
 // GetProjectBySlug returns information about a specific project by slug
 func (c *Client) GetProjectBySlug(ctx context.Context, slug string) (*Project, error) {
+	project, err := c.findProjectBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, &ProjectNotFoundError{Input: slug}
+	}
+	return project, nil
+}
+
+// findProjectBySlug returns the project with the exact slug, or nil when
+// there is none
+func (c *Client) findProjectBySlug(ctx context.Context, slug string) (*Project, error) {
 	// Search for project by slug using the projects endpoint with filter
 	params := url.Values{}
 	params.Set("filter[slug]", slug)
@@ -363,7 +536,7 @@ func (c *Client) GetProjectBySlug(ctx context.Context, slug string) (*Project, e
 	}
 
 	if len(projects) == 0 {
-		return nil, fmt.Errorf("project with slug '%s' not found", slug)
+		return nil, nil
 	}
 
 	return &projects[0], nil
@@ -382,71 +555,63 @@ func (c *Client) ListUserProjects(ctx context.Context, userID int, opts *ListUse
 		opts = &ListUserProjectsOptions{}
 	}
 
-	// Set defaults
-	if opts.PerPage == 0 {
-		opts.PerPage = DefaultPerPage
-	}
-	if opts.Page == 0 {
-		opts.Page = 1
-	}
-
-	// Build query parameters
-	params := url.Values{}
-	params.Set("page", strconv.Itoa(opts.Page))
-	params.Set("per_page", strconv.Itoa(opts.PerPage))
+	params := pageQuery(&opts.Page, &opts.PerPage)
 
 	if opts.Sort != "" {
 		params.Set("sort", opts.Sort)
 	}
 
 	endpoint := fmt.Sprintf("/v2/users/%d/projects_users?%s", userID, params.Encode())
-	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return getPage[ProjectUser](ctx, c, endpoint)
+}
 
-	var projectUsers []ProjectUser
-	if err := c.handleResponse(resp, &projectUsers); err != nil {
-		return nil, nil, err
+// ListProjectsUsersOptions represents options for listing project users
+// across several users at once
+type ListProjectsUsersOptions struct {
+	Page      int
+	PerPage   int
+	Sort      string
+	UserIDs   []int  // filter[user_id], batched in one request
+	Status    string // filter[status], e.g. "in_progress"
+	CursusID  int    // filter[cursus]
+	ProjectID int    // filter[project_id]
+}
+
+// ListProjectsUsers returns project users matching the options. Filtering by
+// several user IDs costs one request instead of one per user.
+func (c *Client) ListProjectsUsers(ctx context.Context, opts *ListProjectsUsersOptions) ([]ProjectUser, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListProjectsUsersOptions{}
 	}
 
-	// Extract pagination metadata from headers
-	meta := c.extractPaginationMeta(resp, len(projectUsers))
+	params := pageQuery(&opts.Page, &opts.PerPage)
+
+	if len(opts.UserIDs) > 0 {
+		params.Set("filter[user_id]", joinIDs(opts.UserIDs))
+	}
+	if opts.Status != "" {
+		params.Set("filter[status]", opts.Status)
+	}
+	if opts.CursusID > 0 {
+		params.Set("filter[cursus]", strconv.Itoa(opts.CursusID))
+	}
+	if opts.ProjectID > 0 {
+		params.Set("filter[project_id]", strconv.Itoa(opts.ProjectID))
+	}
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
 
-	return projectUsers, meta, nil
+	endpoint := "/v2/projects_users?" + params.Encode()
+	return getPage[ProjectUser](ctx, c, endpoint)
 }
 
 // ListCampuses returns a list of all campuses (handles pagination automatically)
 func (c *Client) ListCampuses(ctx context.Context) ([]Campus, error) {
-	var allCampuses []Campus
-	page := 1
-	perPage := 100
-
-	for {
-		params := url.Values{}
-		params.Set("page", strconv.Itoa(page))
-		params.Set("per_page", strconv.Itoa(perPage))
-
-		resp, err := c.makeRequest(ctx, "GET", "/v2/campus?"+params.Encode(), nil)
-		if err != nil {
-			return nil, err
-		}
-
-		var campuses []Campus
-		if err := c.handleResponse(resp, &campuses); err != nil {
-			return nil, err
-		}
-
-		allCampuses = append(allCampuses, campuses...)
-
-		// If we got fewer than perPage, we've reached the last page
-		if len(campuses) < perPage {
-			break
-		}
-		page++
-	}
-
-	return allCampuses, nil
+	return NewPaginator(ctx, DefaultPerPage, func(ctx context.Context, page int) ([]Campus, *PaginationMeta, error) {
+		perPage := DefaultPerPage
+		return getPage[Campus](ctx, c, "/v2/campus?"+pageQuery(&page, &perPage).Encode())
+	}).All()
 }
 
 // ListCursuses returns a list of cursuses
@@ -464,6 +629,28 @@ func (c *Client) ListCursuses(ctx context.Context) ([]Cursus, error) {
 	return cursuses, nil
 }
 
+// ListCursusLevels returns the experience thresholds of a cursus's levels,
+// lowest level first
+func (c *Client) ListCursusLevels(ctx context.Context, cursusID int) ([]Level, error) {
+	params := url.Values{}
+	params.Set("per_page", "100")
+	params.Set("sort", "lvl")
+
+	endpoint := fmt.Sprintf("/v2/cursus/%d/levels?%s", cursusID, params.Encode())
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var levels []Level
+	if err := c.handleResponse(resp, &levels); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Lvl < levels[j].Lvl })
+	return levels, nil
+}
+
 // extractPaginationMeta extracts pagination metadata from response headers
 func (c *Client) extractPaginationMeta(resp *http.Response, count int) *PaginationMeta {
 	meta := &PaginationMeta{
@@ -525,11 +712,52 @@ func (c *Client) GetProjectUser(ctx context.Context, projectUserID int) (*Projec
 	return &projectUser, nil
 }
 
+// CreateProjectUser registers a user to a project, or starts a retry when
+// a previous attempt is finished
+func (c *Client) CreateProjectUser(ctx context.Context, projectID, userID int) (*ProjectUser, error) {
+	body := map[string]interface{}{
+		"projects_user": map[string]interface{}{
+			"project_id": projectID,
+			"user_id":    userID,
+		},
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", fmt.Sprintf("/v2/projects/%d/projects_users", projectID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var registration ProjectUser
+	if err := c.handleResponse(resp, &registration); err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+// DeleteProjectUser unregisters a user from a project by removing the
+// registration
+func (c *Client) DeleteProjectUser(ctx context.Context, projectUserID int) error {
+	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/v2/projects_users/%d", projectUserID), nil)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
 // GetToken returns the current access token
 func (c *Client) GetToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
 	return c.token
 }
 
+// setToken replaces the access token used for subsequent requests
+func (c *Client) setToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.token = token
+}
+
 // ListUsersOptions represents options for listing users
 type ListUsersOptions struct {
 	Page    int
@@ -542,6 +770,8 @@ type ListUsersOptions struct {
 	FilterActive   *bool
 	FilterStaff    *bool
 	FilterAlumni   *bool
+	// Search matches a substring of the given fields (search[login]=...)
+	Search map[string]string
 }
 
 // ListUsers returns a list of users with optional filtering
@@ -550,18 +780,7 @@ func (c *Client) ListUsers(ctx context.Context, opts *ListUsersOptions) ([]User,
 		opts = &ListUsersOptions{}
 	}
 
-	// Set defaults
-	if opts.PerPage == 0 {
-		opts.PerPage = DefaultPerPage
-	}
-	if opts.Page == 0 {
-		opts.Page = 1
-	}
-
-	// Build query parameters
-	params := url.Values{}
-	params.Set("page", strconv.Itoa(opts.Page))
-	params.Set("per_page", strconv.Itoa(opts.PerPage))
+	params := pageQuery(&opts.Page, &opts.PerPage)
 
 	if opts.FilterCampusID > 0 {
 		params.Set("filter[campus_id]", strconv.Itoa(opts.FilterCampusID))
@@ -581,25 +800,15 @@ func (c *Client) ListUsers(ctx context.Context, opts *ListUsersOptions) ([]User,
 	if opts.FilterAlumni != nil {
 		params.Set("filter[alumni?]", strconv.FormatBool(*opts.FilterAlumni))
 	}
+	for field, term := range opts.Search {
+		params.Set("search["+field+"]", term)
+	}
 	if opts.Sort != "" {
 		params.Set("sort", opts.Sort)
 	}
 
 	endpoint := "/v2/users?" + params.Encode()
-	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var users []User
-	if err := c.handleResponse(resp, &users); err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination metadata from headers
-	meta := c.extractPaginationMeta(resp, len(users))
-
-	return users, meta, nil
+	return getPage[User](ctx, c, endpoint)
 }
 
 // ListCursusUsersOptions represents options for listing cursus users
@@ -612,29 +821,25 @@ type ListCursusUsersOptions struct {
 	FilterActive *bool
 	MinLevel     float64 // For range[level] filtering (server-side)
 	MaxLevel     float64 // For range[level] filtering (server-side)
+	// BlackholedSince and BlackholedUntil restrict results to blackhole dates
+	// within the range (server-side)
+	BlackholedSince time.Time
+	BlackholedUntil time.Time
 }
 
 // ListCursusUsers returns a list of cursus users with full data (level, blackhole, etc.)
-// This endpoint provides more detailed data than ListCampusUsers
+// This endpoint provides more detailed data than ListCampusUsers. A cursusID
+// of 0 lists the users of every cursus.
 func (c *Client) ListCursusUsers(ctx context.Context, cursusID int, opts *ListCursusUsersOptions) ([]CursusUser, *PaginationMeta, error) {
 	if opts == nil {
 		opts = &ListCursusUsersOptions{}
 	}
 
-	// Set defaults
-	if opts.PerPage == 0 {
-		opts.PerPage = DefaultPerPage
-	}
-	if opts.Page == 0 {
-		opts.Page = 1
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	if cursusID > 0 {
+		params.Set("filter[cursus_id]", strconv.Itoa(cursusID))
 	}
 
-	// Build query parameters
-	params := url.Values{}
-	params.Set("page", strconv.Itoa(opts.Page))
-	params.Set("per_page", strconv.Itoa(opts.PerPage))
-	params.Set("filter[cursus_id]", strconv.Itoa(cursusID))
-
 	if opts.CampusID > 0 {
 		params.Set("filter[campus_id]", strconv.Itoa(opts.CampusID))
 	}
@@ -656,22 +861,12 @@ func (c *Client) ListCursusUsers(ctx context.Context, cursusID int, opts *ListCu
 		}
 		params.Set("range[level]", minStr+","+maxStr)
 	}
-
-	endpoint := "/v2/cursus_users?" + params.Encode()
-	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var cursusUsers []CursusUser
-	if err := c.handleResponse(resp, &cursusUsers); err != nil {
-		return nil, nil, err
+	if !opts.BlackholedSince.IsZero() || !opts.BlackholedUntil.IsZero() {
+		params.Set("range[blackholed_at]", formatTimeRange(opts.BlackholedSince, opts.BlackholedUntil))
 	}
 
-	// Extract pagination metadata from headers
-	meta := c.extractPaginationMeta(resp, len(cursusUsers))
-
-	return cursusUsers, meta, nil
+	endpoint := "/v2/cursus_users?" + params.Encode()
+	return getPage[CursusUser](ctx, c, endpoint)
 }
 
 // ListCampusUsers returns a list of users from a specific campus
@@ -680,18 +875,7 @@ func (c *Client) ListCampusUsers(ctx context.Context, campusID int, opts *ListUs
 		opts = &ListUsersOptions{}
 	}
 
-	// Set defaults
-	if opts.PerPage == 0 {
-		opts.PerPage = DefaultPerPage
-	}
-	if opts.Page == 0 {
-		opts.Page = 1
-	}
-
-	// Build query parameters
-	params := url.Values{}
-	params.Set("page", strconv.Itoa(opts.Page))
-	params.Set("per_page", strconv.Itoa(opts.PerPage))
+	params := pageQuery(&opts.Page, &opts.PerPage)
 
 	if opts.FilterCursusID > 0 {
 		params.Set("filter[cursus_id]", strconv.Itoa(opts.FilterCursusID))
@@ -713,20 +897,7 @@ func (c *Client) ListCampusUsers(ctx context.Context, campusID int, opts *ListUs
 	}
 
 	endpoint := fmt.Sprintf("/v2/campus/%d/users?%s", campusID, params.Encode())
-	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var users []User
-	if err := c.handleResponse(resp, &users); err != nil {
-		return nil, nil, err
-	}
-
-	// Extract pagination metadata from headers
-	meta := c.extractPaginationMeta(resp, len(users))
-
-	return users, meta, nil
+	return getPage[User](ctx, c, endpoint)
 }
 
 // GetProjectSessionDetail returns full project session detail including rules
@@ -782,11 +953,602 @@ func (c *Client) ListUserQuestUsers(ctx context.Context, userID int) ([]QuestUse
 	return questUsers, nil
 }
 
+// ListQuestsUsersOptions represents options for listing quest completion
+// records across users
+type ListQuestsUsersOptions struct {
+	Page    int
+	PerPage int
+	UserIDs []int // filter[user_id]: the records of these users only
+}
+
+// ListQuestsUsers returns quest completion records of several users at
+// once, each with its user, so that checking the quests of a page of users
+// takes a request or two instead of one per user
+func (c *Client) ListQuestsUsers(ctx context.Context, opts *ListQuestsUsersOptions) ([]QuestUser, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListQuestsUsersOptions{}
+	}
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	if len(opts.UserIDs) > 0 {
+		ids := make([]string, len(opts.UserIDs))
+		for i, id := range opts.UserIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		params.Set("filter[user_id]", strings.Join(ids, ","))
+	}
+
+	endpoint := "/v2/quests_users?" + params.Encode()
+	return getPage[QuestUser](ctx, c, endpoint)
+}
+
+// ListLocationsOptions represents options for listing locations
+type ListLocationsOptions struct {
+	Page    int
+	PerPage int
+	Sort    string
+	// Since and Until restrict results to sessions that began within the range
+	Since time.Time
+	Until time.Time
+	// Active restricts results to sessions that have not ended
+	Active bool
+}
+
+// ListUserLocations returns workstation sessions for a specific user
+func (c *Client) ListUserLocations(ctx context.Context, userID int, opts *ListLocationsOptions) ([]Location, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListLocationsOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if !opts.Since.IsZero() || !opts.Until.IsZero() {
+		params.Set("range[begin_at]", formatTimeRange(opts.Since, opts.Until))
+	}
+	if opts.Active {
+		params.Set("filter[active]", "true")
+	}
+
+	endpoint := fmt.Sprintf("/v2/users/%d/locations?%s", userID, params.Encode())
+	return getPage[Location](ctx, c, endpoint)
+}
+
+// ListLocations returns workstation sessions of several users in one request
+func (c *Client) ListLocations(ctx context.Context, userIDs []int, opts *ListLocationsOptions) ([]Location, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListLocationsOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+
+	if len(userIDs) > 0 {
+		params.Set("filter[user_id]", joinIDs(userIDs))
+	}
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if !opts.Since.IsZero() || !opts.Until.IsZero() {
+		params.Set("range[begin_at]", formatTimeRange(opts.Since, opts.Until))
+	}
+	if opts.Active {
+		params.Set("filter[active]", "true")
+	}
+
+	endpoint := "/v2/locations?" + params.Encode()
+	return getPage[Location](ctx, c, endpoint)
+}
+
+// ListCampusLocations returns workstation sessions at a campus
+func (c *Client) ListCampusLocations(ctx context.Context, campusID int, opts *ListLocationsOptions) ([]Location, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListLocationsOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if !opts.Since.IsZero() || !opts.Until.IsZero() {
+		params.Set("range[begin_at]", formatTimeRange(opts.Since, opts.Until))
+	}
+	if opts.Active {
+		params.Set("filter[active]", "true")
+	}
+
+	endpoint := fmt.Sprintf("/v2/campus/%d/locations?%s", campusID, params.Encode())
+	return getPage[Location](ctx, c, endpoint)
+}
+
+// ListCorrectionPointHistoricsOptions represents options for listing
+// correction point changes
+type ListCorrectionPointHistoricsOptions struct {
+	Page    int
+	PerPage int
+	Sort    string
+}
+
+// ListCorrectionPointHistorics returns the changes of a user's correction points
+func (c *Client) ListCorrectionPointHistorics(ctx context.Context, userID int, opts *ListCorrectionPointHistoricsOptions) ([]CorrectionPointHistoric, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListCorrectionPointHistoricsOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+
+	endpoint := fmt.Sprintf("/v2/users/%d/correction_point_historics?%s", userID, params.Encode())
+	return getPage[CorrectionPointHistoric](ctx, c, endpoint)
+}
+
+// ListAnnouncementsOptions represents options for listing announcements
+type ListAnnouncementsOptions struct {
+	Page     int
+	PerPage  int
+	CampusID int
+	// Since restricts results to announcements created after it
+	Since time.Time
+}
+
+// ListAnnouncements returns campus announcements, newest first
+func (c *Client) ListAnnouncements(ctx context.Context, opts *ListAnnouncementsOptions) ([]Announcement, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListAnnouncementsOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	params.Set("sort", "-created_at")
+
+	if opts.CampusID > 0 {
+		params.Set("filter[campus_id]", strconv.Itoa(opts.CampusID))
+	}
+	if !opts.Since.IsZero() {
+		params.Set("range[created_at]", formatTimeRange(opts.Since, time.Time{}))
+	}
+
+	endpoint := "/v2/announcements?" + params.Encode()
+	return getPage[Announcement](ctx, c, endpoint)
+}
+
+// ListUserCoalitions returns the coalitions a user belongs to
+func (c *Client) ListUserCoalitions(ctx context.Context, userID int) ([]Coalition, error) {
+	endpoint := fmt.Sprintf("/v2/users/%d/coalitions", userID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var coalitions []Coalition
+	if err := c.handleResponse(resp, &coalitions); err != nil {
+		return nil, err
+	}
+
+	return coalitions, nil
+}
+
+// GetCoalition retrieves a coalition by ID
+func (c *Client) GetCoalition(ctx context.Context, coalitionID int) (*Coalition, error) {
+	resp, err := c.makeRequest(ctx, "GET", fmt.Sprintf("/v2/coalitions/%d", coalitionID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var coalition Coalition
+	if err := c.handleResponse(resp, &coalition); err != nil {
+		return nil, err
+	}
+	return &coalition, nil
+}
+
+// ListBlocsOptions represents options for listing coalition blocs
+type ListBlocsOptions struct {
+	CampusID int
+	CursusID int
+}
+
+// ListBlocs returns the coalition blocs, with their coalitions, filtered by
+// campus and cursus
+func (c *Client) ListBlocs(ctx context.Context, opts *ListBlocsOptions) ([]Bloc, error) {
+	if opts == nil {
+		opts = &ListBlocsOptions{}
+	}
+
+	params := url.Values{}
+	params.Set("per_page", strconv.Itoa(DefaultPerPage))
+	if opts.CampusID > 0 {
+		params.Set("filter[campus_id]", strconv.Itoa(opts.CampusID))
+	}
+	if opts.CursusID > 0 {
+		params.Set("filter[cursus_id]", strconv.Itoa(opts.CursusID))
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "/v2/blocs?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocs []Bloc
+	if err := c.handleResponse(resp, &blocs); err != nil {
+		return nil, err
+	}
+	return blocs, nil
+}
+
+// ListCoalitionsUsersOptions represents options for listing coalition
+// memberships
+type ListCoalitionsUsersOptions struct {
+	Page        int
+	PerPage     int
+	Sort        string
+	CoalitionID int
+	UserID      int
+}
+
+// ListCoalitionsUsers returns coalition memberships with their points,
+// filtered by coalition and user
+func (c *Client) ListCoalitionsUsers(ctx context.Context, opts *ListCoalitionsUsersOptions) ([]CoalitionsUser, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListCoalitionsUsersOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if opts.CoalitionID > 0 {
+		params.Set("filter[coalition_id]", strconv.Itoa(opts.CoalitionID))
+	}
+	if opts.UserID > 0 {
+		params.Set("filter[user_id]", strconv.Itoa(opts.UserID))
+	}
+
+	return getPage[CoalitionsUser](ctx, c, "/v2/coalitions_users?"+params.Encode())
+}
+
+// ListScaleTeamsOptions represents options for listing scale teams (evaluations)
+type ListScaleTeamsOptions struct {
+	Page     int
+	PerPage  int
+	Sort     string
+	CampusID int
+	Filled   *bool // Only evaluations that have (or have not) been filled in
+	Future   *bool // Only evaluations scheduled in the future (or in the past)
+	// Since and Until restrict results to evaluations scheduled within the range
+	Since time.Time
+	Until time.Time
+}
+
+// encode builds the query string for a scale team listing
+func (opts *ListScaleTeamsOptions) encode() url.Values {
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if opts.CampusID > 0 {
+		params.Set("filter[campus_id]", strconv.Itoa(opts.CampusID))
+	}
+	if opts.Filled != nil {
+		params.Set("filter[filled]", strconv.FormatBool(*opts.Filled))
+	}
+	if opts.Future != nil {
+		params.Set("filter[future]", strconv.FormatBool(*opts.Future))
+	}
+	if !opts.Since.IsZero() || !opts.Until.IsZero() {
+		params.Set("range[begin_at]", formatTimeRange(opts.Since, opts.Until))
+	}
+	return params
+}
+
+// ScaleTeamRole selects evaluations by the user's role in them
+type ScaleTeamRole string
+
+const (
+	// ScaleTeamAnyRole lists evaluations where the user is corrector or corrected
+	ScaleTeamAnyRole ScaleTeamRole = ""
+	// ScaleTeamAsCorrector lists evaluations the user performed
+	ScaleTeamAsCorrector ScaleTeamRole = "as_corrector"
+	// ScaleTeamAsCorrected lists evaluations of the user's teams
+	ScaleTeamAsCorrected ScaleTeamRole = "as_corrected"
+)
+
+// ListScaleTeams returns evaluations across all users, e.g. of a campus
+// with CampusID. Correctors are only visible with an application token.
+func (c *Client) ListScaleTeams(ctx context.Context, opts *ListScaleTeamsOptions) ([]ScaleTeam, *PaginationMeta, error) {
+	return c.listScaleTeams(ctx, "/v2/scale_teams", opts)
+}
+
+// ListMyScaleTeams returns the evaluations of the logged-in user, both as
+// corrector and as corrected
+func (c *Client) ListMyScaleTeams(ctx context.Context, opts *ListScaleTeamsOptions) ([]ScaleTeam, *PaginationMeta, error) {
+	return c.listScaleTeams(ctx, "/v2/me/scale_teams", opts)
+}
+
+// GetScaleTeam returns a single evaluation by ID
+func (c *Client) GetScaleTeam(ctx context.Context, scaleTeamID int) (*ScaleTeam, error) {
+	endpoint := fmt.Sprintf("/v2/scale_teams/%d", scaleTeamID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var scaleTeam ScaleTeam
+	if err := c.handleResponse(resp, &scaleTeam); err != nil {
+		return nil, err
+	}
+
+	return &scaleTeam, nil
+}
+
+// ListProjectScaleTeams returns evaluations done on a specific project
+func (c *Client) ListProjectScaleTeams(ctx context.Context, projectID int, opts *ListScaleTeamsOptions) ([]ScaleTeam, *PaginationMeta, error) {
+	return c.listScaleTeams(ctx, fmt.Sprintf("/v2/projects/%d/scale_teams", projectID), opts)
+}
+
+// ListUserScaleTeams returns evaluations a user took part in with the given role
+func (c *Client) ListUserScaleTeams(ctx context.Context, userID int, role ScaleTeamRole, opts *ListScaleTeamsOptions) ([]ScaleTeam, *PaginationMeta, error) {
+	path := fmt.Sprintf("/v2/users/%d/scale_teams", userID)
+	if role != ScaleTeamAnyRole {
+		path += "/" + string(role)
+	}
+	return c.listScaleTeams(ctx, path, opts)
+}
+
+// listScaleTeams fetches one page of scale teams from a listing endpoint
+func (c *Client) listScaleTeams(ctx context.Context, path string, opts *ListScaleTeamsOptions) ([]ScaleTeam, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListScaleTeamsOptions{}
+	}
+
+	return getPage[ScaleTeam](ctx, c, path+"?"+opts.encode().Encode())
+}
+
+// ListTeamsOptions represents options for listing teams
+type ListTeamsOptions struct {
+	Page    int
+	PerPage int
+	Sort    string
+	// Since restricts results to teams created after this time
+	Since time.Time
+}
+
+// ListUserTeams returns the teams a user belongs to
+func (c *Client) ListUserTeams(ctx context.Context, userID int, opts *ListTeamsOptions) ([]Team, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListTeamsOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if !opts.Since.IsZero() {
+		params.Set("range[created_at]", formatTimeRange(opts.Since, time.Time{}))
+	}
+
+	endpoint := fmt.Sprintf("/v2/users/%d/teams?%s", userID, params.Encode())
+	return getPage[Team](ctx, c, endpoint)
+}
+
+// GetTeam returns a team with its members
+func (c *Client) GetTeam(ctx context.Context, teamID int) (*Team, error) {
+	endpoint := fmt.Sprintf("/v2/teams/%d", teamID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var team Team
+	if err := c.handleResponse(resp, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+// ListSlotsOptions represents options for listing evaluation slots
+type ListSlotsOptions struct {
+	Page    int
+	PerPage int
+	// Since and Until restrict results to slots starting within the range
+	Since time.Time
+	Until time.Time
+}
+
+// ListMySlots returns the evaluation slots of the logged-in user. The API
+// splits slots into 15-minute pieces, each with its own ID.
+func (c *Client) ListMySlots(ctx context.Context, opts *ListSlotsOptions) ([]Slot, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListSlotsOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	params.Set("sort", "begin_at")
+	if !opts.Since.IsZero() || !opts.Until.IsZero() {
+		params.Set("range[begin_at]", formatTimeRange(opts.Since, opts.Until))
+	}
+
+	return getPage[Slot](ctx, c, "/v2/me/slots?"+params.Encode())
+}
+
+// CreateSlot opens an evaluation slot for the user between beginAt and
+// endAt and returns the 15-minute pieces the API created
+func (c *Client) CreateSlot(ctx context.Context, userID int, beginAt, endAt time.Time) ([]Slot, error) {
+	body := map[string]interface{}{
+		"slot": map[string]interface{}{
+			"user_id":  userID,
+			"begin_at": beginAt.UTC().Format(time.RFC3339),
+			"end_at":   endAt.UTC().Format(time.RFC3339),
+		},
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", "/v2/slots", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []Slot
+	if err := c.handleResponse(resp, &slots); err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+// DeleteSlot removes an evaluation slot
+func (c *Client) DeleteSlot(ctx context.Context, slotID int) error {
+	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/v2/slots/%d", slotID), nil)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// ListEventsOptions represents options for listing events
+type ListEventsOptions struct {
+	Page    int
+	PerPage int
+	// Kind restricts results to one kind of event (e.g. hackathon)
+	Kind string
+	// Since and Until restrict results to events ending after Since and
+	// beginning before Until
+	Since time.Time
+	Until time.Time
+}
+
+// ListCampusEvents returns the events of a campus, soonest first
+func (c *Client) ListCampusEvents(ctx context.Context, campusID int, opts *ListEventsOptions) ([]Event, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListEventsOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	params.Set("sort", "begin_at")
+	if opts.Kind != "" {
+		params.Set("filter[kind]", opts.Kind)
+	}
+	if !opts.Since.IsZero() {
+		params.Set("range[end_at]", formatTimeRange(opts.Since, time.Time{}))
+	}
+	if !opts.Until.IsZero() {
+		params.Set("range[begin_at]", formatTimeRange(time.Time{}, opts.Until))
+	}
+
+	endpoint := fmt.Sprintf("/v2/campus/%d/events?%s", campusID, params.Encode())
+	return getPage[Event](ctx, c, endpoint)
+}
+
+// GetEvent retrieves an event by ID
+func (c *Client) GetEvent(ctx context.Context, eventID int) (*Event, error) {
+	resp, err := c.makeRequest(ctx, "GET", fmt.Sprintf("/v2/events/%d", eventID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := c.handleResponse(resp, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// ListEventsUsersOptions represents options for listing event registrations
+type ListEventsUsersOptions struct {
+	Page    int
+	PerPage int
+	EventID int
+	UserID  int
+}
+
+// ListEventsUsers returns event registrations, filtered by event and user
+func (c *Client) ListEventsUsers(ctx context.Context, opts *ListEventsUsersOptions) ([]EventsUser, *PaginationMeta, error) {
+	if opts == nil {
+		opts = &ListEventsUsersOptions{}
+	}
+
+	params := pageQuery(&opts.Page, &opts.PerPage)
+	if opts.EventID > 0 {
+		params.Set("filter[event_id]", strconv.Itoa(opts.EventID))
+	}
+	if opts.UserID > 0 {
+		params.Set("filter[user_id]", strconv.Itoa(opts.UserID))
+	}
+
+	return getPage[EventsUser](ctx, c, "/v2/events_users?"+params.Encode())
+}
+
+// CreateEventsUser registers a user to an event
+func (c *Client) CreateEventsUser(ctx context.Context, eventID, userID int) (*EventsUser, error) {
+	body := map[string]interface{}{
+		"events_user": map[string]interface{}{
+			"event_id": eventID,
+			"user_id":  userID,
+		},
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", "/v2/events_users", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var registration EventsUser
+	if err := c.handleResponse(resp, &registration); err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+// DeleteEventsUser cancels an event registration
+func (c *Client) DeleteEventsUser(ctx context.Context, eventsUserID int) error {
+	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/v2/events_users/%d", eventsUserID), nil)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// formatTimeRange formats a range[...] query value; a zero bound is left open
+func formatTimeRange(since, until time.Time) string {
+	var minStr, maxStr string
+	if !since.IsZero() {
+		minStr = since.UTC().Format(time.RFC3339)
+	}
+	if !until.IsZero() {
+		maxStr = until.UTC().Format(time.RFC3339)
+	}
+	return minStr + "," + maxStr
+}
+
+// joinIDs formats IDs as a comma-separated filter[...] value
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
+
 // GetClientCredentialsToken obtains an access token using the client_credentials grant type.
 // This token has application-level access, which is needed for endpoints like project_sessions
 // that are not accessible with user-scoped tokens.
 func GetClientCredentialsToken(ctx context.Context, clientID, clientSecret string) (string, error) {
-	tokenURL := DefaultBaseURL + "/oauth/token"
+	token, err := GetClientCredentials(ctx, clientID, clientSecret)
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// GetClientCredentials is GetClientCredentialsToken returning the whole
+// token response, with its lifetime
+func GetClientCredentials(ctx context.Context, clientID, clientSecret string) (*Token, error) {
+	return getClientCredentials(ctx, DefaultBaseURL+"/oauth/token", clientID, clientSecret)
+}
+
+func getClientCredentials(ctx context.Context, tokenURL, clientID, clientSecret string) (*Token, error) {
 
 	body := map[string]string{
 		"grant_type":    "client_credentials",
@@ -795,18 +1557,18 @@ func GetClientCredentialsToken(ctx context.Context, clientID, clientSecret strin
 	}
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal token request: %w", err)
+		return nil, fmt.Errorf("failed to marshal token request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create token request: %w", err)
+		return nil, fmt.Errorf("failed to create token request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("token request failed: %w", err)
+		return nil, fmt.Errorf("token request failed: %w", err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -816,17 +1578,17 @@ func GetClientCredentialsToken(ctx context.Context, clientID, clientSecret strin
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read token response: %w", err)
+		return nil, fmt.Errorf("failed to read token response: %w", err)
 	}
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("token request failed (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("token request failed: %w", responseError(resp, respBody))
 	}
 
 	var tokenResp Token
 	if err := json.Unmarshal(respBody, &tokenResp); err != nil {
-		return "", fmt.Errorf("failed to parse token response: %w", err)
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
 	}
 
-	return tokenResp.AccessToken, nil
+	return &tokenResp, nil
 }
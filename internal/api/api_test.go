@@ -2,10 +2,18 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/naokiiida/t42-cli/internal/cache"
 	"github.com/naokiiida/t42-cli/internal/config"
 )
 
@@ -28,6 +36,10 @@ func TestNewClient(t *testing.T) {
 	if client.userAgent != "t42-cli/1.0" {
 		t.Errorf("Expected user agent 't42-cli/1.0', got %s", client.userAgent)
 	}
+
+	if client.limiter == nil {
+		t.Error("Expected the built-in rate limiter")
+	}
 }
 
 func TestNewClientWithOptions(t *testing.T) {
@@ -395,4 +407,909 @@ func TestContextCancellation(t *testing.T) {
 			t.Logf("Error with cancelled context: %v", err)
 		}
 	})
-}
\ No newline at end of file
+}
+func TestScaleTeamUnmarshalHiddenUsers(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		wantCorrector  string
+		wantCorrecteds int
+	}{
+		{
+			name:           "visible users",
+			data:           `{"id":1,"corrector":{"id":2,"login":"eval"},"correcteds":[{"id":3,"login":"a"},{"id":4,"login":"b"}],"truant":{}}`,
+			wantCorrector:  "eval",
+			wantCorrecteds: 2,
+		},
+		{
+			name:           "invisible users",
+			data:           `{"id":1,"corrector":"invisible","correcteds":"invisible","truant":null}`,
+			wantCorrector:  "",
+			wantCorrecteds: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var st ScaleTeam
+			if err := json.Unmarshal([]byte(tt.data), &st); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if st.ID != 1 {
+				t.Errorf("ID = %d, want 1", st.ID)
+			}
+			gotCorrector := ""
+			if st.Corrector != nil {
+				gotCorrector = st.Corrector.Login
+			}
+			if gotCorrector != tt.wantCorrector {
+				t.Errorf("Corrector = %q, want %q", gotCorrector, tt.wantCorrector)
+			}
+			if len(st.Correcteds) != tt.wantCorrecteds {
+				t.Errorf("len(Correcteds) = %d, want %d", len(st.Correcteds), tt.wantCorrecteds)
+			}
+			if st.Truant != nil {
+				t.Errorf("Truant = %+v, want nil", st.Truant)
+			}
+		})
+	}
+}
+
+func TestPassthrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/cursus":
+			if r.URL.Query().Get("filter[kind]") != "main" || r.URL.Query().Get("page[size]") != "2" {
+				t.Errorf("unexpected query %q", r.URL.RawQuery)
+			}
+			w.Header().Set("X-Total", "3")
+			w.Header().Set("X-Per-Page", "2")
+			_, _ = w.Write([]byte(`[{"id":21},{"id":9}]`))
+		case "/v2/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Not Found","message":"no such route"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	ctx := context.Background()
+
+	t.Run("list response", func(t *testing.T) {
+		resp, err := client.Passthrough(ctx, "get", "/v2/cursus?filter[kind]=main", map[string][]string{"page[size]": {"2"}}, nil)
+		if err != nil {
+			t.Fatalf("Passthrough() error = %v", err)
+		}
+		if string(resp.Body) != `[{"id":21},{"id":9}]` {
+			t.Errorf("Body = %s", resp.Body)
+		}
+		if resp.Meta.Count != 2 || resp.Meta.TotalPages != 2 {
+			t.Errorf("Meta = %+v, want count 2 of 2 pages", resp.Meta)
+		}
+	})
+
+	t.Run("API error keeps the body", func(t *testing.T) {
+		resp, err := client.Passthrough(ctx, "GET", "/v2/missing", nil, nil)
+		if err == nil {
+			t.Fatal("Passthrough() error = nil, want API error")
+		}
+		if resp == nil || resp.StatusCode != http.StatusNotFound || len(resp.Body) == 0 {
+			t.Errorf("Passthrough() response = %+v, want the 404 body", resp)
+		}
+	})
+}
+
+func TestSlots(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/me/slots":
+			if r.URL.Query().Get("range[begin_at]") == "" {
+				t.Errorf("missing range[begin_at] in %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[
+				{"id":1,"begin_at":"2024-06-01T18:00:00Z","end_at":"2024-06-01T18:15:00Z","scale_team":null,"user":"invisible"},
+				{"id":2,"begin_at":"2024-06-01T18:15:00Z","end_at":"2024-06-01T18:30:00Z","scale_team":"invisible"},
+				{"id":3,"begin_at":"2024-06-01T18:30:00Z","end_at":"2024-06-01T18:45:00Z","scale_team":{"id":9,"begin_at":"2024-06-01T18:30:00Z"}}
+			]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/slots":
+			var body map[string]map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("invalid body: %v", err)
+			}
+			if body["slot"]["begin_at"] != "2024-06-01T18:00:00Z" || body["slot"]["user_id"] != float64(42) {
+				t.Errorf("unexpected slot body %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`[{"id":5,"begin_at":"2024-06-01T18:00:00Z","end_at":"2024-06-01T18:15:00Z","scale_team":null}]`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/slots/5":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	ctx := context.Background()
+	begin := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+
+	slots, _, err := client.ListMySlots(ctx, &ListSlotsOptions{Since: begin})
+	if err != nil {
+		t.Fatalf("ListMySlots() error = %v", err)
+	}
+	if len(slots) != 3 || slots[0].Booked || !slots[1].Booked || !slots[2].Booked {
+		t.Fatalf("ListMySlots() = %+v, want free, hidden booked, booked", slots)
+	}
+	if slots[1].ScaleTeam != nil || slots[2].ScaleTeam == nil || slots[2].ScaleTeam.ID != 9 {
+		t.Errorf("scale teams = %v, %v", slots[1].ScaleTeam, slots[2].ScaleTeam)
+	}
+
+	created, err := client.CreateSlot(ctx, 42, begin, begin.Add(time.Hour))
+	if err != nil || len(created) != 1 || created[0].ID != 5 {
+		t.Fatalf("CreateSlot() = %+v, %v", created, err)
+	}
+	if err := client.DeleteSlot(ctx, 5); err != nil {
+		t.Errorf("DeleteSlot() error = %v", err)
+	}
+	if err := client.DeleteSlot(ctx, 6); err == nil {
+		t.Error("DeleteSlot() of a missing slot succeeded")
+	}
+}
+
+func TestEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/campus/26/events":
+			if got := r.URL.Query().Get("filter[kind]"); got != "hackathon" {
+				t.Errorf("filter[kind] = %q, want hackathon", got)
+			}
+			if r.URL.Query().Get("range[end_at]") == "" {
+				t.Errorf("missing range[end_at] in %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":7,"name":"Hack Day","kind":"hackathon","max_people":null,"nbr_subscribers":12,"begin_at":"2024-06-01T09:00:00Z","end_at":"2024-06-02T18:00:00Z"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/events_users":
+			if r.URL.Query().Get("filter[event_id]") != "7" || r.URL.Query().Get("filter[user_id]") != "42" {
+				t.Errorf("unexpected filters %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":99,"event_id":7,"user_id":42}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/events_users":
+			var body map[string]map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("invalid body: %v", err)
+			}
+			if body["events_user"]["event_id"] != float64(7) || body["events_user"]["user_id"] != float64(42) {
+				t.Errorf("unexpected events_user body %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":99,"event_id":7,"user_id":42}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/events_users/99":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	ctx := context.Background()
+
+	events, _, err := client.ListCampusEvents(ctx, 26, &ListEventsOptions{Kind: "hackathon", Since: time.Now()})
+	if err != nil {
+		t.Fatalf("ListCampusEvents() error = %v", err)
+	}
+	if len(events) != 1 || events[0].ID != 7 || events[0].MaxPeople != nil || events[0].NbrSubscribers != 12 {
+		t.Fatalf("ListCampusEvents() = %+v", events)
+	}
+
+	registrations, _, err := client.ListEventsUsers(ctx, &ListEventsUsersOptions{EventID: 7, UserID: 42})
+	if err != nil || len(registrations) != 1 || registrations[0].ID != 99 {
+		t.Fatalf("ListEventsUsers() = %+v, %v", registrations, err)
+	}
+
+	created, err := client.CreateEventsUser(ctx, 7, 42)
+	if err != nil || created.ID != 99 {
+		t.Fatalf("CreateEventsUser() = %+v, %v", created, err)
+	}
+	if err := client.DeleteEventsUser(ctx, 99); err != nil {
+		t.Errorf("DeleteEventsUser() error = %v", err)
+	}
+	if _, err := client.GetEvent(ctx, 8); err == nil {
+		t.Error("GetEvent() of a missing event succeeded")
+	}
+}
+
+func TestListCampusLocations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/campus/26/locations" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.URL.Query().Get("filter[active]"); got != "true" {
+			t.Errorf("filter[active] = %q, want true", got)
+		}
+		_, _ = w.Write([]byte(`[{"id":5,"host":"c1r2p3","begin_at":"2024-06-01T09:00:00Z","end_at":null,"campus_id":26,"user":{"id":42,"login":"jdoe"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	locations, _, err := client.ListCampusLocations(context.Background(), 26, &ListLocationsOptions{Active: true})
+	if err != nil {
+		t.Fatalf("ListCampusLocations() error = %v", err)
+	}
+	if len(locations) != 1 || locations[0].Host != "c1r2p3" || locations[0].EndAt != nil || locations[0].User.Login != "jdoe" {
+		t.Errorf("ListCampusLocations() = %+v", locations)
+	}
+	if _, _, err := client.ListCampusLocations(context.Background(), 27, nil); err == nil {
+		t.Error("ListCampusLocations() of a missing campus succeeded")
+	}
+}
+
+func TestCoalitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/blocs":
+			if r.URL.Query().Get("filter[campus_id]") != "26" || r.URL.Query().Get("filter[cursus_id]") != "21" {
+				t.Errorf("unexpected bloc filters %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":3,"campus_id":26,"cursus_id":21,"coalitions":[{"id":1,"name":"Air","score":900},{"id":2,"name":"Water","score":1200}]}]`))
+		case "/v2/coalitions_users":
+			if r.URL.Query().Get("filter[user_id]") != "42" || r.URL.Query().Get("sort") != "-score" {
+				t.Errorf("unexpected coalitions_users query %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":7,"coalition_id":2,"user_id":42,"score":120,"rank":15}]`))
+		case "/v2/coalitions/2":
+			_, _ = w.Write([]byte(`{"id":2,"name":"Water","slug":"water","score":1200,"user_id":5}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	ctx := context.Background()
+
+	blocs, err := client.ListBlocs(ctx, &ListBlocsOptions{CampusID: 26, CursusID: 21})
+	if err != nil || len(blocs) != 1 || len(blocs[0].Coalitions) != 2 || blocs[0].Coalitions[1].Score != 1200 {
+		t.Fatalf("ListBlocs() = %+v, %v", blocs, err)
+	}
+
+	memberships, _, err := client.ListCoalitionsUsers(ctx, &ListCoalitionsUsersOptions{UserID: 42, Sort: "-score"})
+	if err != nil || len(memberships) != 1 || memberships[0].Score != 120 || memberships[0].Rank != 15 {
+		t.Fatalf("ListCoalitionsUsers() = %+v, %v", memberships, err)
+	}
+
+	coalition, err := client.GetCoalition(ctx, 2)
+	if err != nil || coalition.Slug != "water" {
+		t.Fatalf("GetCoalition() = %+v, %v", coalition, err)
+	}
+}
+
+func TestListCursusLevels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/cursus/21/levels" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":2,"lvl":1,"xp":462,"cursus_id":21},{"id":1,"lvl":0,"xp":0,"cursus_id":21}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	levels, err := client.ListCursusLevels(context.Background(), 21)
+	if err != nil {
+		t.Fatalf("ListCursusLevels() error = %v", err)
+	}
+	if len(levels) != 2 || levels[0].Lvl != 0 || levels[1].XP != 462 {
+		t.Errorf("ListCursusLevels() = %+v, want levels 0 and 1 in order", levels)
+	}
+}
+
+func TestListCorrectionPointHistorics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/users/42/correction_point_historics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.URL.Query().Get("sort"); got != "-created_at" {
+			t.Errorf("sort = %q, want -created_at", got)
+		}
+		_, _ = w.Write([]byte(`[{"id":3,"scale_team_id":77,"total":5,"sum":1,"reason":"Defense plannification","created_at":"2024-06-01T09:00:00Z"},{"id":2,"scale_team_id":null,"total":4,"sum":-1,"reason":"Earning after defense","created_at":"2024-05-30T09:00:00Z"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	historics, _, err := client.ListCorrectionPointHistorics(context.Background(), 42, &ListCorrectionPointHistoricsOptions{Sort: "-created_at"})
+	if err != nil {
+		t.Fatalf("ListCorrectionPointHistorics() error = %v", err)
+	}
+	if len(historics) != 2 || historics[0].Total != 5 || historics[1].Sum != -1 || historics[1].ScaleTeamID != nil {
+		t.Errorf("ListCorrectionPointHistorics() = %+v", historics)
+	}
+}
+
+func TestListCursusUsersFilters(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/cursus_users" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		queries = append(queries, r.URL.Query())
+		_, _ = w.Write([]byte(`[{"id":1,"level":4.2,"cursus":{"id":21},"user":{"login":"jdoe"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	for _, cursusID := range []int{21, 0} {
+		if _, _, err := client.ListCursusUsers(context.Background(), cursusID, &ListCursusUsersOptions{CampusID: 26}); err != nil {
+			t.Fatalf("ListCursusUsers(%d) error = %v", cursusID, err)
+		}
+	}
+	if got := queries[0].Get("filter[cursus_id]"); got != "21" {
+		t.Errorf("filter[cursus_id] = %q, want 21", got)
+	}
+	if queries[1].Has("filter[cursus_id]") || queries[1].Get("filter[campus_id]") != "26" {
+		t.Errorf("query of every cursus = %v, want only the campus filter", queries[1])
+	}
+}
+
+func TestListProjectsUsersFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/v2/projects_users" || query.Get("filter[project_id]") != "1332" || query.Get("filter[status]") != "finished" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`[{"id":1,"validated":true,"user":{"login":"jdoe"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	projectUsers, _, err := client.ListProjectsUsers(context.Background(), &ListProjectsUsersOptions{ProjectID: 1332, Status: "finished"})
+	if err != nil {
+		t.Fatalf("ListProjectsUsers() error = %v", err)
+	}
+	if len(projectUsers) != 1 || projectUsers[0].User.Login != "jdoe" {
+		t.Errorf("ListProjectsUsers() = %+v", projectUsers)
+	}
+}
+
+func TestListQuestsUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/quests_users" || r.URL.Query().Get("filter[user_id]") != "1,2,3" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`[{"id":10,"quest":{"slug":"common-core-rank-01"},"user":{"id":2,"login":"jdoe"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	questUsers, _, err := client.ListQuestsUsers(context.Background(), &ListQuestsUsersOptions{UserIDs: []int{1, 2, 3}})
+	if err != nil {
+		t.Fatalf("ListQuestsUsers() error = %v", err)
+	}
+	if len(questUsers) != 1 || questUsers[0].User == nil || questUsers[0].User.ID != 2 {
+		t.Errorf("ListQuestsUsers() = %+v", questUsers)
+	}
+}
+
+func TestCreateProjectUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/projects/1314/projects_users" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		if body["projects_user"]["project_id"] != float64(1314) || body["projects_user"]["user_id"] != float64(42) {
+			t.Errorf("unexpected projects_user body %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":7,"occurrence":1,"status":"creating_group","project":{"id":1314,"slug":"libft"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	registration, err := client.CreateProjectUser(context.Background(), 1314, 42)
+	if err != nil {
+		t.Fatalf("CreateProjectUser() error = %v", err)
+	}
+	if registration.ID != 7 || registration.Occurrence != 1 || registration.Project.Slug != "libft" {
+		t.Errorf("CreateProjectUser() = %+v", registration)
+	}
+	if _, err := client.CreateProjectUser(context.Background(), 1315, 42); err == nil {
+		t.Error("CreateProjectUser() of a missing project succeeded")
+	}
+}
+
+func TestDeleteProjectUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && r.URL.Path == "/v2/projects_users/12" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	if err := client.DeleteProjectUser(context.Background(), 12); err != nil {
+		t.Errorf("DeleteProjectUser() error = %v", err)
+	}
+	if err := client.DeleteProjectUser(context.Background(), 13); err == nil {
+		t.Error("DeleteProjectUser() of a missing registration succeeded")
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil), WithReadOnly())
+	ctx := context.Background()
+
+	if _, err := client.Passthrough(ctx, "GET", "/v2/cursus", nil, nil); err != nil {
+		t.Errorf("Passthrough(GET) error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"passthrough POST", func() error {
+			_, err := client.Passthrough(ctx, "post", "/v2/slots", nil, map[string]interface{}{"slot": nil})
+			return err
+		}},
+		{"create slot", func() error {
+			_, err := client.CreateSlot(ctx, 42, time.Now(), time.Now().Add(time.Hour))
+			return err
+		}},
+		{"delete slot", func() error { return client.DeleteSlot(ctx, 5) }},
+		{"delete project user", func() error { return client.DeleteProjectUser(ctx, 12) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, ErrReadOnly) {
+				t.Errorf("error = %v, want ErrReadOnly", err)
+			}
+		})
+	}
+
+	if len(requests) != 1 || requests[0] != "GET /v2/cursus" {
+		t.Errorf("requests sent = %v, want only the GET", requests)
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/v2/cursus":
+			w.Header().Set("X-Total", "2")
+			w.Header().Set("X-Per-Page", "1")
+			_, _ = w.Write([]byte(`[{"id":21,"name":"42cursus"}]`))
+		case "/v2/me":
+			_, _ = w.Write([]byte(`{"id":1,"login":"me"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	policy := func(endpoint string) (string, time.Duration) {
+		if strings.HasPrefix(endpoint, "/v2/cursus") {
+			return "cursus", time.Hour
+		}
+		return "response", 0
+	}
+	store := cache.New(t.TempDir())
+	client := NewClient("test_token", WithBaseURL(server.URL), WithResponseCache(store, policy))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		raw, err := client.Passthrough(ctx, http.MethodGet, "/v2/cursus", nil, nil)
+		if err != nil {
+			t.Fatalf("Passthrough() error = %v", err)
+		}
+		if raw.Meta.TotalPages != 2 {
+			t.Errorf("call %d: TotalPages = %d, want 2 from cached headers", i, raw.Meta.TotalPages)
+		}
+		if _, err := client.GetMe(ctx); err != nil {
+			t.Fatalf("GetMe() error = %v", err)
+		}
+	}
+	if _, err := client.Passthrough(ctx, http.MethodGet, "/v2/missing", nil, nil); err == nil {
+		t.Error("Passthrough() of a missing endpoint succeeded")
+	}
+
+	if requests["/v2/cursus"] != 1 {
+		t.Errorf("cursus was requested %d times, want 1", requests["/v2/cursus"])
+	}
+	if requests["/v2/me"] != 2 {
+		t.Errorf("me was requested %d times, want 2 (not cached)", requests["/v2/me"])
+	}
+
+	bypass := NewClient("test_token", WithBaseURL(server.URL), WithResponseCache(cache.New(store.Dir(), cache.WithBypass()), policy))
+	if _, err := bypass.ListCursuses(ctx); err != nil {
+		t.Fatalf("ListCursuses() error = %v", err)
+	}
+	if requests["/v2/cursus"] != 2 {
+		t.Errorf("bypassed cache served cursus, requests = %d", requests["/v2/cursus"])
+	}
+}
+
+func TestCallRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/cursus":
+			_, _ = w.Write([]byte(`[{"id":21,"name":"42cursus"}]`))
+		case "/v2/users/42", "/v2/users/43":
+			_, _ = w.Write([]byte(`{"id":42,"login":"user"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	policy := func(endpoint string) (string, time.Duration) {
+		if strings.HasPrefix(endpoint, "/v2/cursus") {
+			return "cursus", time.Hour
+		}
+		return "response", 0
+	}
+	recorder := NewCallRecorder()
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil),
+		WithResponseCache(cache.New(t.TempDir()), policy), WithCallRecorder(recorder))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListCursuses(ctx); err != nil {
+			t.Fatalf("ListCursuses() error = %v", err)
+		}
+	}
+	for _, id := range []int{42, 43} {
+		if _, err := client.GetUser(ctx, id); err != nil {
+			t.Fatalf("GetUser() error = %v", err)
+		}
+	}
+	if _, err := client.GetUser(ctx, 44); err == nil {
+		t.Error("GetUser() of a missing user succeeded")
+	}
+
+	calls := recorder.Calls()
+	if len(calls) != 5 {
+		t.Fatalf("recorded %d calls, want 5: %+v", len(calls), calls)
+	}
+	if calls[0].Cached || !calls[1].Cached {
+		t.Errorf("Cached = %v, %v, want the second cursus call served from the cache", calls[0].Cached, calls[1].Cached)
+	}
+	if calls[0].Bytes != int64(len(`[{"id":21,"name":"42cursus"}]`)) || calls[1].Bytes != calls[0].Bytes {
+		t.Errorf("cursus Bytes = %d, %d", calls[0].Bytes, calls[1].Bytes)
+	}
+	if calls[4].Status != http.StatusNotFound {
+		t.Errorf("missing user Status = %d, want 404", calls[4].Status)
+	}
+
+	summary := recorder.Summary()
+	if summary.Count != 5 || summary.Cached != 1 {
+		t.Errorf("Summary() Count = %d, Cached = %d, want 5 and 1", summary.Count, summary.Cached)
+	}
+	var users *EndpointCost
+	for i := range summary.Endpoints {
+		if summary.Endpoints[i].Endpoint == "/v2/users/:id" {
+			users = &summary.Endpoints[i]
+		}
+	}
+	if users == nil || users.Count != 3 {
+		t.Errorf("Summary() Endpoints = %+v, want the user lookups grouped under /v2/users/:id", summary.Endpoints)
+	}
+}
+
+func TestCallSummaryPercentiles(t *testing.T) {
+	recorder := NewCallRecorder()
+	for i := 1; i <= 20; i++ {
+		recorder.Record(CallStat{Method: "GET", Endpoint: "/v2/me", Bytes: 100, Latency: time.Duration(i) * time.Millisecond})
+	}
+	recorder.Record(CallStat{Method: "GET", Endpoint: "/v2/cursus", Bytes: 50, Cached: true})
+
+	summary := recorder.Summary()
+	if summary.P50 != 10*time.Millisecond || summary.P95 != 19*time.Millisecond {
+		t.Errorf("P50, P95 = %v, %v, want 10ms and 19ms", summary.P50, summary.P95)
+	}
+	if summary.Bytes != 2050 {
+		t.Errorf("Bytes = %d, want 2050", summary.Bytes)
+	}
+	if empty := NewCallRecorder().Summary(); empty.Count != 0 || empty.P95 != 0 {
+		t.Errorf("empty Summary() = %+v", empty)
+	}
+}
+
+func TestNormalizeEndpoint(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v2/me", "/v2/me"},
+		{"/v2/users/42", "/v2/users/:id"},
+		{"/v2/users/42/projects_users", "/v2/users/:id/projects_users"},
+		{"/v2/cursus/21/12345", "/v2/cursus/:id/:id"},
+		{"/v2/projects/libft", "/v2/projects/libft"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := normalizeEndpoint(tt.path); got != tt.want {
+				t.Errorf("normalizeEndpoint(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchAllProjects(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.Header().Set("X-Total", "5")
+		w.Header().Set("X-Per-Page", "2")
+		w.Header().Set("X-Page", page)
+		switch page {
+		case "1":
+			_, _ = w.Write([]byte(`[{"id":1},{"id":2}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"id":3},{"id":4}]`))
+		default:
+			_, _ = w.Write([]byte(`[{"id":5}]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		stopAfter int
+		wantIDs   []int
+		wantPages string
+	}{
+		{name: "all pages", wantIDs: []int{1, 2, 3, 4, 5}, wantPages: "1,2,3"},
+		{name: "stopped early", stopAfter: 2, wantIDs: []int{1, 2, 3, 4}, wantPages: "1,2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages = nil
+			var ids []int
+			calls := 0
+			err := client.FetchAllProjects(ctx, &ListProjectsOptions{PerPage: 2}, func(projects []Project, meta *PaginationMeta) error {
+				calls++
+				if meta.TotalPages != 3 {
+					t.Errorf("TotalPages = %d, want 3", meta.TotalPages)
+				}
+				for _, p := range projects {
+					ids = append(ids, p.ID)
+				}
+				if calls == tt.stopAfter {
+					return ErrStopFetching
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("FetchAllProjects() error = %v", err)
+			}
+			if got := strings.Join(pages, ","); got != tt.wantPages {
+				t.Errorf("requested pages %s, want %s", got, tt.wantPages)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestListUsersIter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Total", "4")
+		w.Header().Set("X-Per-Page", "2")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`[{"id":1,"login":"a"},{"id":2,"login":"b"}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"id":3,"login":"c"},{"id":4,"login":"d"}]`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		stopAt       string
+		wantLogins   string
+		wantRequests int
+	}{
+		{name: "all users", wantLogins: "a,b,c,d", wantRequests: 2},
+		{name: "break after first page", stopAt: "b", wantLogins: "a,b", wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			var logins []string
+			for user, err := range client.ListUsersIter(ctx, &ListUsersOptions{PerPage: 2}) {
+				if err != nil {
+					t.Fatalf("ListUsersIter() error = %v", err)
+				}
+				logins = append(logins, user.Login)
+				if user.Login == tt.stopAt {
+					break
+				}
+			}
+			if got := strings.Join(logins, ","); got != tt.wantLogins {
+				t.Errorf("logins = %s, want %s", got, tt.wantLogins)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+
+	t.Run("error ends iteration", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		var errs int
+		for _, err := range client.ListUsersIter(cancelled, &ListUsersOptions{PerPage: 2}) {
+			if err == nil {
+				t.Fatal("ListUsersIter() yielded a user with a cancelled context")
+			}
+			errs++
+		}
+		if errs != 1 {
+			t.Errorf("got %d errors, want 1", errs)
+		}
+	})
+}
+
+
+func TestFetchLimit(t *testing.T) {
+	// Seven items over pages of 3: ids 1-3, 4-6 and 7
+	var requested []int
+	fetch := func(ctx context.Context, page int) ([]int, *PaginationMeta, error) {
+		requested = append(requested, page)
+		var items []int
+		for id := (page-1)*3 + 1; id <= min(page*3, 7); id++ {
+			items = append(items, id)
+		}
+		return items, &PaginationMeta{Page: page, TotalPages: 3, TotalCount: 7}, nil
+	}
+	even := func(items []int) []int {
+		var kept []int
+		for _, id := range items {
+			if id%2 == 0 {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	}
+
+	tests := []struct {
+		name        string
+		opts        LimitOptions
+		filter      func([]int) []int
+		wantItems   []int
+		wantPages   []int
+		wantFetched int
+		wantMore    bool
+	}{
+		{name: "limit within a page", opts: LimitOptions{PerPage: 3, Limit: 2}, wantItems: []int{1, 2}, wantPages: []int{1}, wantFetched: 3, wantMore: true},
+		{name: "limit across pages", opts: LimitOptions{PerPage: 3, Limit: 4}, wantItems: []int{1, 2, 3, 4}, wantPages: []int{1, 2}, wantFetched: 6, wantMore: true},
+		{name: "filter fetches more pages", opts: LimitOptions{PerPage: 3, Limit: 3}, filter: even, wantItems: []int{2, 4, 6}, wantPages: []int{1, 2}, wantFetched: 6, wantMore: true},
+		{name: "limit reached on the last page", opts: LimitOptions{PerPage: 3, Limit: 7}, wantItems: []int{1, 2, 3, 4, 5, 6, 7}, wantPages: []int{1, 2, 3}, wantFetched: 7, wantMore: false},
+		{name: "no limit", opts: LimitOptions{PerPage: 3}, filter: even, wantItems: []int{2, 4, 6}, wantPages: []int{1, 2, 3}, wantFetched: 7, wantMore: false},
+		{name: "start page", opts: LimitOptions{Page: 2, PerPage: 3, Limit: 5}, wantItems: []int{4, 5, 6, 7}, wantPages: []int{2, 3}, wantFetched: 4, wantMore: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = nil
+			got, err := FetchLimit(context.Background(), tt.opts, fetch, tt.filter)
+			if err != nil {
+				t.Fatalf("FetchLimit() error = %v", err)
+			}
+			if fmt.Sprint(got.Items) != fmt.Sprint(tt.wantItems) {
+				t.Errorf("items = %v, want %v", got.Items, tt.wantItems)
+			}
+			if fmt.Sprint(requested) != fmt.Sprint(tt.wantPages) {
+				t.Errorf("requested pages %v, want %v", requested, tt.wantPages)
+			}
+			if got.Fetched != tt.wantFetched {
+				t.Errorf("fetched = %d, want %d", got.Fetched, tt.wantFetched)
+			}
+			if got.More != tt.wantMore {
+				t.Errorf("more = %v, want %v", got.More, tt.wantMore)
+			}
+		})
+	}
+
+	t.Run("negative limit", func(t *testing.T) {
+		if _, err := FetchLimit(context.Background(), LimitOptions{Limit: -1}, fetch, nil); err == nil {
+			t.Error("FetchLimit() error = nil, want an error")
+		}
+	})
+}
+
+func TestClockSkew(t *testing.T) {
+	sent := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		date     string
+		received time.Time
+		want     time.Duration
+		wantOK   bool
+	}{
+		{name: "in sync", date: "Sat, 01 Jun 2024 12:00:01 GMT", received: sent.Add(2 * time.Second), want: 0, wantOK: true},
+		{name: "server ahead", date: "Sat, 01 Jun 2024 14:00:00 GMT", received: sent, want: 2 * time.Hour, wantOK: true},
+		{name: "server behind", date: "Sat, 01 Jun 2024 11:55:00 GMT", received: sent, want: -5 * time.Minute, wantOK: true},
+		{name: "missing header", received: sent},
+		{name: "invalid header", date: "yesterday", received: sent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.date != "" {
+				header.Set("Date", tt.date)
+			}
+			got, ok := ClockSkew(header, sent, tt.received)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ClockSkew() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGetClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode token request: %v", err)
+		}
+		if body["grant_type"] != "client_credentials" || body["client_id"] != "uid" || body["client_secret"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"app","token_type":"bearer","expires_in":7200,"scope":"public","created_at":1700000000}`))
+	}))
+	defer server.Close()
+
+	token, err := getClientCredentials(context.Background(), server.URL, "uid", "secret")
+	if err != nil {
+		t.Fatalf("getClientCredentials() error = %v", err)
+	}
+	if token.AccessToken != "app" || token.ExpiresIn != 7200 || token.CreatedAt != 1700000000 {
+		t.Errorf("getClientCredentials() = %+v", token)
+	}
+
+	if _, err := getClientCredentials(context.Background(), server.URL, "uid", "wrong"); err == nil {
+		t.Error("getClientCredentials() with a wrong secret succeeded")
+	}
+}
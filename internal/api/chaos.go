@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChaosEnvVar enables failure injection, for testing how commands behave
+// when the API is flaky. See ParseChaos for its format.
+const ChaosEnvVar = "T42_CHAOS"
+
+// Fault is a failure ChaosTransport can inject
+type Fault string
+
+const (
+	// FaultRateLimited answers 429 Too Many Requests
+	FaultRateLimited Fault = "429"
+	// FaultServerError answers 500 Internal Server Error
+	FaultServerError Fault = "500"
+	// FaultTimeout fails the request with a timeout, without a response
+	FaultTimeout Fault = "timeout"
+)
+
+// AllFaults are the faults injected when none are chosen
+var AllFaults = []Fault{FaultRateLimited, FaultServerError, FaultTimeout}
+
+// Chaos configures failure injection
+type Chaos struct {
+	// Rate is the probability that a request fails, from 0 to 1
+	Rate float64
+	// Faults are picked from at random for each failed request
+	Faults []Fault
+	// Seed makes the failures reproducible; 0 picks a random seed
+	Seed int64
+}
+
+// ParseChaos parses a T42_CHAOS value: a failure rate, optionally followed
+// by comma-separated options, e.g. "0.2" or "0.3,faults=429+timeout,seed=7".
+func ParseChaos(value string) (*Chaos, error) {
+	parts := strings.Split(value, ",")
+	rate, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return nil, fmt.Errorf("invalid %s rate %q: want a number from 0 to 1", ChaosEnvVar, parts[0])
+	}
+	chaos := &Chaos{Rate: rate, Faults: AllFaults}
+
+	for _, part := range parts[1:] {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid %s option %q: want key=value", ChaosEnvVar, part)
+		}
+		switch key {
+		case "faults":
+			chaos.Faults = nil
+			for _, name := range strings.Split(val, "+") {
+				fault := Fault(strings.TrimSpace(name))
+				if fault != FaultRateLimited && fault != FaultServerError && fault != FaultTimeout {
+					return nil, fmt.Errorf("invalid %s fault %q: want 429, 500 or timeout", ChaosEnvVar, name)
+				}
+				chaos.Faults = append(chaos.Faults, fault)
+			}
+		case "seed":
+			seed, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s seed %q", ChaosEnvVar, val)
+			}
+			chaos.Seed = seed
+		default:
+			return nil, fmt.Errorf("unknown %s option %q", ChaosEnvVar, key)
+		}
+	}
+	return chaos, nil
+}
+
+// WithChaos makes the client fail requests at random as configured, before
+// they reach the network. Retries, rate limiting and token refreshes see
+// the injected failures like real ones.
+func WithChaos(chaos *Chaos) ClientOption {
+	return func(c *Client) {
+		base := c.httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		c.httpClient.Transport = NewChaosTransport(base, chaos)
+	}
+}
+
+// ChaosTransport is a RoundTripper injecting failures in front of another
+type ChaosTransport struct {
+	base  http.RoundTripper
+	chaos Chaos
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewChaosTransport wraps base with failure injection
+func NewChaosTransport(base http.RoundTripper, chaos *Chaos) *ChaosTransport {
+	seed := chaos.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &ChaosTransport{base: base, chaos: *chaos, rand: rand.New(rand.NewSource(seed))}
+}
+
+// RoundTrip sends the request, or fails it with one of the faults
+func (t *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault, inject := t.pick()
+	if !inject {
+		return t.base.RoundTrip(req)
+	}
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	switch fault {
+	case FaultTimeout:
+		return nil, chaosTimeoutError{}
+	case FaultRateLimited:
+		resp := chaosResponse(req, http.StatusTooManyRequests, `{"error":"Too Many Requests","message":"injected by T42_CHAOS"}`)
+		resp.Header.Set("Retry-After", "1")
+		return resp, nil
+	default:
+		return chaosResponse(req, http.StatusInternalServerError, `{"error":"Internal Server Error","message":"injected by T42_CHAOS"}`), nil
+	}
+}
+
+// pick draws whether the next request fails, and how
+func (t *ChaosTransport) pick() (Fault, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.chaos.Faults) == 0 || t.rand.Float64() >= t.chaos.Rate {
+		return "", false
+	}
+	return t.chaos.Faults[t.rand.Intn(len(t.chaos.Faults))], true
+}
+
+// chaosResponse builds an injected JSON error response
+func chaosResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// chaosTimeoutError is an injected timeout; like a real one, it is a
+// net.Error whose Timeout method reports true
+type chaosTimeoutError struct{}
+
+func (chaosTimeoutError) Error() string   { return "injected timeout (T42_CHAOS)" }
+func (chaosTimeoutError) Timeout() bool   { return true }
+func (chaosTimeoutError) Temporary() bool { return true }
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseChaos(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *Chaos
+		wantErr bool
+	}{
+		{name: "rate", value: "0.2", want: &Chaos{Rate: 0.2, Faults: AllFaults}},
+		{name: "faults and seed", value: "1,faults=429+timeout,seed=7", want: &Chaos{Rate: 1, Faults: []Fault{FaultRateLimited, FaultTimeout}, Seed: 7}},
+		{name: "rate above 1", value: "1.5", wantErr: true},
+		{name: "not a rate", value: "on", wantErr: true},
+		{name: "unknown fault", value: "0.5,faults=404", wantErr: true},
+		{name: "unknown option", value: "0.5,delay=1s", wantErr: true},
+		{name: "option without value", value: "0.5,seed", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChaos(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseChaos() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseChaos() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChaosTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		chaos      Chaos
+		wantStatus int
+		wantErr    bool
+	}{
+		{name: "no failures", chaos: Chaos{Rate: 0, Faults: AllFaults}, wantStatus: http.StatusOK},
+		{name: "rate limited", chaos: Chaos{Rate: 1, Faults: []Fault{FaultRateLimited}}, wantStatus: http.StatusTooManyRequests},
+		{name: "server error", chaos: Chaos{Rate: 1, Faults: []Fault{FaultServerError}}, wantStatus: http.StatusInternalServerError},
+		{name: "timeout", chaos: Chaos{Rate: 1, Faults: []Fault{FaultTimeout}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &http.Client{Transport: NewChaosTransport(http.DefaultTransport, &tt.chaos)}
+			resp, err := client.Get(server.URL)
+			if tt.wantErr {
+				var netErr net.Error
+				if !errors.As(err, &netErr) || !netErr.Timeout() {
+					t.Fatalf("Get() error = %v, want a timeout", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestChaosTransportSeed(t *testing.T) {
+	// The same seed fails the same requests
+	draws := func() []bool {
+		transport := NewChaosTransport(http.DefaultTransport, &Chaos{Rate: 0.5, Faults: AllFaults, Seed: 42})
+		var failed []bool
+		for i := 0; i < 20; i++ {
+			_, inject := transport.pick()
+			failed = append(failed, inject)
+		}
+		return failed
+	}
+	if first, second := draws(), draws(); !reflect.DeepEqual(first, second) {
+		t.Errorf("seeded draws differ: %v and %v", first, second)
+	}
+}
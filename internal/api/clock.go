@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClockSkew estimates how far the server clock is ahead of the local clock
+// (negative when behind) from the Date header of a response. sent and
+// received bracket the request, whose midpoint is taken as the local time
+// the header was written. ok is false when the header is missing or invalid.
+func ClockSkew(header http.Header, sent, received time.Time) (skew time.Duration, ok bool) {
+	date := header.Get("Date")
+	if date == "" {
+		return 0, false
+	}
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return 0, false
+	}
+
+	local := sent.Add(received.Sub(sent) / 2)
+	// The header only has a resolution of one second
+	return serverTime.Sub(local).Round(time.Second), true
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces secrets in the debug log
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are the headers whose value never appears in the debug log
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// sensitiveParams are the query parameters whose value never appears in
+// the debug log
+var sensitiveParams = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"code":          true,
+	"code_verifier": true,
+}
+
+// attemptKey carries the attempt number of a request to the transports
+type attemptKey struct{}
+
+// withAttempt records in ctx that the request is attempt number attempt,
+// 1 for the first
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// requestAttempt returns the attempt number of a request, 1 if unknown
+func requestAttempt(req *http.Request) int {
+	if attempt, ok := req.Context().Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// WithHTTPDebug logs every request the client sends to w: method, URL,
+// attempt, status, latency with its connection timings, and the headers of
+// both sides, with the token and other secrets redacted. It is meant to be
+// pasted into bug reports about the API.
+func WithHTTPDebug(w io.Writer) ClientOption {
+	return func(c *Client) {
+		base := c.httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		c.httpClient.Transport = &debugTransport{base: base, w: w}
+	}
+}
+
+// debugTransport logs the round trips of another RoundTripper
+type debugTransport struct {
+	base http.RoundTripper
+	mu   sync.Mutex // keeps the lines of concurrent requests together
+	w    io.Writer
+}
+
+// connTimings are the connection phases of a round trip
+type connTimings struct {
+	mu                               sync.Mutex
+	dnsStart, connectStart, tlsStart time.Time
+	dns, connect, tls                time.Duration
+	firstByte                        time.Time
+	reused                           bool
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timings := &connTimings{}
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			timings.mu.Lock()
+			timings.reused = info.Reused
+			timings.mu.Unlock()
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			timings.mu.Lock()
+			timings.dnsStart = time.Now()
+			timings.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			timings.mu.Lock()
+			timings.dns = time.Since(timings.dnsStart)
+			timings.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			timings.mu.Lock()
+			timings.connectStart = time.Now()
+			timings.mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			timings.mu.Lock()
+			timings.connect = time.Since(timings.connectStart)
+			timings.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			timings.mu.Lock()
+			timings.tlsStart = time.Now()
+			timings.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			timings.mu.Lock()
+			timings.tls = time.Since(timings.tlsStart)
+			timings.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			timings.mu.Lock()
+			timings.firstByte = time.Now()
+			timings.mu.Unlock()
+		},
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	latency := time.Since(start)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "→ %s %s", req.Method, redactURL(req.URL))
+	if attempt := requestAttempt(req); attempt > 1 {
+		fmt.Fprintf(&b, " (attempt %d/%d)", attempt, MaxRetries+1)
+	}
+	b.WriteString("\n")
+	writeHeaders(&b, "→ ", req.Header)
+
+	if err != nil {
+		fmt.Fprintf(&b, "← error after %s: %v\n", formatDebugDuration(latency), err)
+	} else {
+		fmt.Fprintf(&b, "← %s in %s%s\n", resp.Status, formatDebugDuration(latency), timings.summary(start))
+		writeHeaders(&b, "← ", resp.Header)
+	}
+
+	t.mu.Lock()
+	fmt.Fprint(t.w, b.String())
+	t.mu.Unlock()
+	return resp, err
+}
+
+// summary describes the connection phases, e.g. " (dns 4ms, connect 12ms,
+// tls 30ms, first byte 85ms)"
+func (t *connTimings) summary(start time.Time) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var parts []string
+	if t.reused {
+		parts = append(parts, "reused connection")
+	}
+	for _, phase := range []struct {
+		name string
+		d    time.Duration
+	}{{"dns", t.dns}, {"connect", t.connect}, {"tls", t.tls}} {
+		if phase.d > 0 {
+			parts = append(parts, phase.name+" "+formatDebugDuration(phase.d))
+		}
+	}
+	if !t.firstByte.IsZero() {
+		parts = append(parts, "first byte "+formatDebugDuration(t.firstByte.Sub(start)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// writeHeaders writes headers sorted by name, one per line, with the
+// sensitive ones redacted
+func writeHeaders(b *strings.Builder, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redactHeader(value)
+		}
+		fmt.Fprintf(b, "%s  %s: %s\n", prefix, name, value)
+	}
+}
+
+// redactHeader hides a header value, keeping the auth scheme, e.g.
+// "Bearer [REDACTED]"
+func redactHeader(value string) string {
+	if scheme, _, ok := strings.Cut(value, " "); ok && !strings.Contains(scheme, "=") {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+// redactURL returns u with the values of sensitive query parameters
+// hidden, leaving the rest of the query as it was sent
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	params := strings.Split(u.RawQuery, "&")
+	for i, param := range params {
+		name, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil && sensitiveParams[strings.ToLower(unescaped)] {
+			params[i] = name + "=" + redacted
+		}
+	}
+	clean := *u
+	clean.RawQuery = strings.Join(params, "&")
+	return clean.String()
+}
+
+// formatDebugDuration rounds d for the debug log
+func formatDebugDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHTTPDebug(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t"})
+		w.Header().Set("X-Hourly-RateLimit-Remaining", "1199")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	client := NewClient("super-secret-token", WithBaseURL(server.URL), WithRateLimiter(nil), WithHTTPDebug(&log))
+	if _, err := client.Passthrough(context.Background(), "GET", "/v2/me?access_token=leaked&page=2", nil, nil); err != nil {
+		t.Fatalf("Passthrough() error = %v", err)
+	}
+
+	got := log.String()
+	for _, want := range []string{
+		"→ GET " + server.URL + "/v2/me?access_token=[REDACTED]&page=2\n",
+		"→ GET " + server.URL + "/v2/me?access_token=[REDACTED]&page=2 (attempt 2/4)\n",
+		"→   Authorization: Bearer [REDACTED]\n",
+		"← 503 Service Unavailable in ",
+		"← 200 OK in ",
+		"←   Set-Cookie: [REDACTED]\n",
+		"←   X-Hourly-Ratelimit-Remaining: 1199\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("debug log is missing %q:\n%s", want, got)
+		}
+	}
+	for _, secret := range []string{"super-secret-token", "leaked", "s3cr3t"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("debug log leaks %q:\n%s", secret, got)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://api.intra.42.fr/v2/me", "https://api.intra.42.fr/v2/me"},
+		{"https://api.intra.42.fr/v2/users?filter%5Blogin%5D=jdoe", "https://api.intra.42.fr/v2/users?filter%5Blogin%5D=jdoe"},
+		{"https://api.intra.42.fr/oauth/token?code=abc&client_secret=xyz&state=1", "https://api.intra.42.fr/oauth/token?code=[REDACTED]&client_secret=[REDACTED]&state=1"},
+		{"https://api.intra.42.fr/v2/me?Access_Token=abc", "https://api.intra.42.fr/v2/me?Access_Token=[REDACTED]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			u, err := url.Parse(tt.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := redactURL(u); got != tt.want {
+				t.Errorf("redactURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Kinds of API errors, to test with errors.Is; an *Error of the matching
+// status is each of them
+var (
+	// ErrNotFound is a 404 Not Found, or a lookup that matched nothing
+	ErrNotFound = errors.New("not found")
+
+	// ErrUnauthorized is a 401 Unauthorized: the token is missing, expired
+	// or revoked, and refreshing it did not help
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden is a 403 Forbidden: the token is valid but lacks the
+	// scope or the rights for the request
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrRateLimited is a 429 Too Many Requests still failing after the
+	// retries, or one the server asked to wait too long after
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServer is a 5xx status still failing after the retries
+	ErrServer = errors.New("server error")
+)
+
+// Error is a failed API response
+type Error struct {
+	StatusCode int
+	Message    string        // the message of the API, or the raw body
+	Body       []byte        // the raw response body
+	RetryAfter time.Duration // how long the server asked to wait, 0 if it did not
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether the error is of the kind target, so that e.g.
+// errors.Is(err, ErrNotFound) holds for a 404
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// StatusCode returns the status of the API response that caused err, or 0
+// if err is not an API error
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// responseError converts a failed API response and its body into an *Error
+func responseError(resp *http.Response, body []byte) error {
+	apiErr := &Error{StatusCode: resp.StatusCode, Message: string(body), Body: body}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait, _, ok := serverWait(resp.Header, time.Now()); ok {
+			apiErr.RetryAfter = wait
+		}
+	}
+
+	var errorResponse ErrorResponse
+	if err := json.Unmarshal(body, &errorResponse); err != nil {
+		// Not JSON: keep the raw body as the message
+		return apiErr
+	}
+	switch {
+	case errorResponse.Message != "":
+		apiErr.Message = errorResponse.Message
+	case errorResponse.ErrorDescription != "":
+		apiErr.Message = errorResponse.Error + " - " + errorResponse.ErrorDescription
+	case errorResponse.Error != "":
+		apiErr.Message = errorResponse.Error
+	}
+	return apiErr
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestErrorKinds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found","status":404}`))
+		case "/v2/private":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_token","error_description":"The access token expired"}`))
+		case "/v2/admin":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Forbidden"}`))
+		case "/v2/busy":
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/v2/broken":
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("<html>Bad Gateway</html>"))
+		case "/v2/projects":
+			_, _ = w.Write([]byte(`[]`))
+		case "/v2/invalid":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Validation failed"}`))
+		}
+	}))
+	defer server.Close()
+
+	// Keep the retries of the 502 short
+	original := jitter
+	jitter = func() float64 { return 0 }
+	defer func() { jitter = original }()
+
+	client := NewClient("token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	get := func(client *Client, endpoint string) error {
+		resp, err := client.makeRequest(context.Background(), "GET", endpoint, nil)
+		if err != nil {
+			return err
+		}
+		return client.handleResponse(resp, nil)
+	}
+
+	tests := []struct {
+		endpoint    string
+		wantKind    error
+		wantStatus  int
+		wantMessage string
+	}{
+		{"/v2/missing", ErrNotFound, http.StatusNotFound, "API error (status 404): Not Found"},
+		{"/v2/private", ErrUnauthorized, http.StatusUnauthorized, "API error (status 401): invalid_token - The access token expired"},
+		{"/v2/admin", ErrForbidden, http.StatusForbidden, "API error (status 403): Forbidden"},
+		{"/v2/busy", ErrRateLimited, http.StatusTooManyRequests, "API error (status 429): "},
+		{"/v2/broken", ErrServer, http.StatusBadGateway, "API error (status 502): <html>Bad Gateway</html>"},
+		{"/v2/invalid", nil, http.StatusUnprocessableEntity, "API error (status 422): Validation failed"},
+	}
+	kinds := []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited, ErrServer}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			err := get(client, tt.endpoint)
+			if err == nil {
+				t.Fatal("error = nil, want an API error")
+			}
+			for _, kind := range kinds {
+				if got, want := errors.Is(err, kind), kind == tt.wantKind; got != want {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", err, kind, got, want)
+				}
+			}
+			if got := StatusCode(err); got != tt.wantStatus {
+				t.Errorf("StatusCode() = %d, want %d", got, tt.wantStatus)
+			}
+			if err.Error() != tt.wantMessage {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantMessage)
+			}
+		})
+	}
+
+	t.Run("retry after", func(t *testing.T) {
+		err := get(client, "/v2/busy")
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Hour {
+			t.Errorf("error = %#v, want an *Error with RetryAfter 1h", err)
+		}
+	})
+
+	t.Run("project not found", func(t *testing.T) {
+		_, err := client.GetProjectBySlug(context.Background(), "missing")
+		var notFound *ProjectNotFoundError
+		if !errors.Is(err, ErrNotFound) || !errors.As(err, &notFound) {
+			t.Errorf("GetProjectBySlug() error = %v, want a *ProjectNotFoundError", err)
+		}
+	})
+
+	t.Run("failed token refresh", func(t *testing.T) {
+		refreshing := NewClient("token", WithBaseURL(server.URL), WithRateLimiter(nil),
+			WithTokenRefresher(func() (string, error) { return "", errors.New("refresh token revoked") }))
+		err := get(refreshing, "/v2/private")
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("error = %v, want ErrUnauthorized", err)
+		}
+	})
+}
+
+func TestStatusCodeOfOtherErrors(t *testing.T) {
+	if got := StatusCode(errors.New("network down")); got != 0 {
+		t.Errorf("StatusCode() = %d, want 0", got)
+	}
+	if got := StatusCode(nil); got != 0 {
+		t.Errorf("StatusCode(nil) = %d, want 0", got)
+	}
+}
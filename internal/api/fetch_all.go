@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrStopFetching can be returned by a page callback to end FetchAll early
+// without an error
+var ErrStopFetching = errors.New("stop fetching")
+
+// PageFunc fetches one page of a paginated endpoint
+type PageFunc[T any] func(ctx context.Context, page int) ([]T, *PaginationMeta, error)
+
+// FetchAll fetches every page of a paginated endpoint, starting at page 1,
+// and hands each page to onPage as soon as it arrives. It stops after the
+// last page according to the X-Total-Pages header, or after a page shorter
+// than perPage when the header is missing. The client's rate limiter paces
+// the requests.
+func FetchAll[T any](ctx context.Context, perPage int, fetch PageFunc[T], onPage func(items []T, meta *PaginationMeta) error) error {
+	return fetchAll(NewPaginator(ctx, perPage, fetch), onPage)
+}
+
+// fetchAll hands each remaining page of p to onPage
+func fetchAll[T any](p *Paginator[T], onPage func(items []T, meta *PaginationMeta) error) error {
+	for pg, err := range p.pages() {
+		if err != nil {
+			return err
+		}
+		if err := onPage(pg.items, pg.meta); err != nil {
+			if errors.Is(err, ErrStopFetching) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// LimitOptions bounds FetchLimit. Limit counts results, after filtering,
+// while PerPage only sets the size of each request, so the two are
+// independent: a small PerPage makes more requests for the same results.
+type LimitOptions struct {
+	Page    int // first page to fetch, 1 when zero
+	PerPage int // items per request, DefaultPerPage when zero
+	Limit   int // results to collect, 0 for every page
+}
+
+// Limited is what FetchLimit collected
+type Limited[T any] struct {
+	Items   []T             // at most Limit results
+	Meta    *PaginationMeta // meta of the last page fetched
+	Fetched int             // items fetched before filtering
+	More    bool            // results were left over or pages left unfetched
+}
+
+// FetchLimit fetches pages of a paginated endpoint until opts.Limit items
+// pass filter or the pages run out, so a command shows the number of
+// results asked for however sparse the matches are. filter gets each page
+// and returns the items to keep; nil keeps every item.
+func FetchLimit[T any](ctx context.Context, opts LimitOptions, fetch PageFunc[T], filter func([]T) []T) (*Limited[T], error) {
+	if opts.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative, got %d", opts.Limit)
+	}
+
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+
+	result := &Limited[T]{}
+	for p, err := range pagesFrom(ctx, opts.Page, perPage, fetch) {
+		if err != nil {
+			return nil, err
+		}
+		result.Meta = p.meta
+		result.Fetched += len(p.items)
+
+		kept := p.items
+		if filter != nil {
+			kept = filter(kept)
+		}
+		result.Items = append(result.Items, kept...)
+
+		if opts.Limit > 0 && len(result.Items) >= opts.Limit {
+			// Stopping here leaves the following pages unfetched, unless
+			// this was the last one
+			lastPage := len(p.items) < perPage || (p.meta != nil && p.meta.TotalPages > 0 && p.number >= p.meta.TotalPages)
+			result.More = len(result.Items) > opts.Limit || !lastPage
+			result.Items = result.Items[:opts.Limit]
+			break
+		}
+	}
+	return result, nil
+}
+
+// FetchAllProjects lists every project matching opts, page by page
+func (c *Client) FetchAllProjects(ctx context.Context, opts *ListProjectsOptions, onPage func([]Project, *PaginationMeta) error) error {
+	return fetchAll(c.ProjectsPaginator(ctx, opts), onPage)
+}
+
+// FetchAllUserProjects lists every project of a user, page by page
+func (c *Client) FetchAllUserProjects(ctx context.Context, userID int, opts *ListUserProjectsOptions, onPage func([]ProjectUser, *PaginationMeta) error) error {
+	return fetchAll(c.UserProjectsPaginator(ctx, userID, opts), onPage)
+}
+
+// FetchAllUsers lists every user matching opts, page by page
+func (c *Client) FetchAllUsers(ctx context.Context, opts *ListUsersOptions, onPage func([]User, *PaginationMeta) error) error {
+	return fetchAll(c.UsersPaginator(ctx, opts), onPage)
+}
+
+// FetchAllCampusUsers lists every user of a campus, page by page
+func (c *Client) FetchAllCampusUsers(ctx context.Context, campusID int, opts *ListUsersOptions, onPage func([]User, *PaginationMeta) error) error {
+	return fetchAll(c.CampusUsersPaginator(ctx, campusID, opts), onPage)
+}
+
+// FetchAllCursusUsers lists every cursus user matching opts, page by page
+func (c *Client) FetchAllCursusUsers(ctx context.Context, cursusID int, opts *ListCursusUsersOptions, onPage func([]CursusUser, *PaginationMeta) error) error {
+	return fetchAll(c.CursusUsersPaginator(ctx, cursusID, opts), onPage)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// page is one fetched page of a paginated endpoint
+type page[T any] struct {
+	number int
+	items  []T
+	meta   *PaginationMeta
+}
+
+// pages yields the pages of a paginated endpoint one at a time, fetching
+// the next page only when the consumer asks for it. After an error nothing
+// more is yielded.
+func pages[T any](ctx context.Context, perPage int, fetch PageFunc[T]) iter.Seq2[page[T], error] {
+	return NewPaginator(ctx, perPage, fetch).pages()
+}
+
+// pagesFrom is pages starting at page first instead of page 1
+func pagesFrom[T any](ctx context.Context, first, perPage int, fetch PageFunc[T]) iter.Seq2[page[T], error] {
+	return NewPaginator(ctx, perPage, fetch).From(first).pages()
+}
+
+// Iterate yields the items of a paginated endpoint one at a time. Pages are
+// fetched lazily, so only one page is held in memory and stopping the loop
+// early skips the remaining requests. An error is yielded once, with the
+// zero item, and ends the iteration.
+func Iterate[T any](ctx context.Context, perPage int, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return NewPaginator(ctx, perPage, fetch).Items()
+}
+
+// ListProjectsIter iterates over every project matching opts
+func (c *Client) ListProjectsIter(ctx context.Context, opts *ListProjectsOptions) iter.Seq2[Project, error] {
+	return c.ProjectsPaginator(ctx, opts).Items()
+}
+
+// ListUserProjectsIter iterates over every project of a user
+func (c *Client) ListUserProjectsIter(ctx context.Context, userID int, opts *ListUserProjectsOptions) iter.Seq2[ProjectUser, error] {
+	return c.UserProjectsPaginator(ctx, userID, opts).Items()
+}
+
+// ListUsersIter iterates over every user matching opts
+func (c *Client) ListUsersIter(ctx context.Context, opts *ListUsersOptions) iter.Seq2[User, error] {
+	return c.UsersPaginator(ctx, opts).Items()
+}
+
+// ListCampusUsersIter iterates over every user of a campus
+func (c *Client) ListCampusUsersIter(ctx context.Context, campusID int, opts *ListUsersOptions) iter.Seq2[User, error] {
+	return c.CampusUsersPaginator(ctx, campusID, opts).Items()
+}
+
+// ListCursusUsersIter iterates over every cursus user matching opts
+func (c *Client) ListCursusUsersIter(ctx context.Context, cursusID int, opts *ListCursusUsersOptions) iter.Seq2[CursusUser, error] {
+	return c.CursusUsersPaginator(ctx, cursusID, opts).Items()
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CallStat is one API request as seen by a CallRecorder
+type CallStat struct {
+	Method   string
+	Endpoint string // path with numeric IDs replaced by ":id", without query
+	Status   int    // 0 when the request failed before a response
+	Bytes    int64  // response body size
+	Latency  time.Duration
+	Cached   bool // served from the response cache, not the network
+}
+
+// CallRecorder collects the requests of one or more clients; it is safe
+// for concurrent use
+type CallRecorder struct {
+	mu      sync.Mutex
+	calls   []CallStat
+	retries []Retry
+}
+
+// NewCallRecorder creates an empty recorder
+func NewCallRecorder() *CallRecorder {
+	return &CallRecorder{}
+}
+
+// Record adds a request
+func (r *CallRecorder) Record(stat CallStat) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, stat)
+}
+
+// RecordRetry adds a retry
+func (r *CallRecorder) RecordRetry(retry Retry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries = append(r.retries, retry)
+}
+
+// Retries returns the recorded retries in order
+func (r *CallRecorder) Retries() []Retry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Retry(nil), r.retries...)
+}
+
+// Calls returns the recorded requests in order
+func (r *CallRecorder) Calls() []CallStat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]CallStat(nil), r.calls...)
+}
+
+// EndpointCost is the traffic of one endpoint
+type EndpointCost struct {
+	Method   string
+	Endpoint string
+	Count    int
+	Bytes    int64
+	Latency  time.Duration // total
+}
+
+// CallSummary sums up recorded requests. Latency percentiles only cover
+// requests that went over the network.
+type CallSummary struct {
+	Count     int
+	Cached    int
+	Bytes     int64
+	P50       time.Duration
+	P95       time.Duration
+	Endpoints []EndpointCost // most total latency first
+	Retries   int
+	RetryWait time.Duration // total wait before retries
+}
+
+// Summary sums up the recorded requests
+func (r *CallRecorder) Summary() CallSummary {
+	calls := r.Calls()
+	summary := CallSummary{Count: len(calls)}
+	for _, retry := range r.Retries() {
+		summary.Retries++
+		summary.RetryWait += retry.Wait
+	}
+
+	var latencies []time.Duration
+	byEndpoint := make(map[string]*EndpointCost)
+	for _, call := range calls {
+		summary.Bytes += call.Bytes
+		if call.Cached {
+			summary.Cached++
+			continue
+		}
+		latencies = append(latencies, call.Latency)
+
+		key := call.Method + " " + call.Endpoint
+		cost, ok := byEndpoint[key]
+		if !ok {
+			cost = &EndpointCost{Method: call.Method, Endpoint: call.Endpoint}
+			byEndpoint[key] = cost
+		}
+		cost.Count++
+		cost.Bytes += call.Bytes
+		cost.Latency += call.Latency
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	summary.P50 = percentile(latencies, 50)
+	summary.P95 = percentile(latencies, 95)
+
+	for _, cost := range byEndpoint {
+		summary.Endpoints = append(summary.Endpoints, *cost)
+	}
+	sort.Slice(summary.Endpoints, func(i, j int) bool {
+		a, b := summary.Endpoints[i], summary.Endpoints[j]
+		if a.Latency != b.Latency {
+			return a.Latency > b.Latency
+		}
+		return a.Method+a.Endpoint < b.Method+b.Endpoint
+	})
+	return summary
+}
+
+// percentile returns the nearest-rank percentile p of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// WithCallRecorder records the size and latency of every request the
+// client sends, and of responses served from the response cache
+func WithCallRecorder(recorder *CallRecorder) ClientOption {
+	return func(c *Client) {
+		c.recorder = recorder
+		base := c.httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		c.httpClient.Transport = &recordingTransport{base: base, recorder: recorder}
+	}
+}
+
+// recordingTransport records every round trip once its body is closed
+type recordingTransport struct {
+	base     http.RoundTripper
+	recorder *CallRecorder
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	stat := CallStat{Method: req.Method, Endpoint: normalizeEndpoint(req.URL.Path)}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		stat.Latency = time.Since(start)
+		t.recorder.Record(stat)
+		return nil, err
+	}
+
+	stat.Status = resp.StatusCode
+	resp.Body = &countingBody{ReadCloser: resp.Body, onClose: func(n int64) {
+		stat.Bytes = n
+		stat.Latency = time.Since(start)
+		t.recorder.Record(stat)
+	}}
+	return resp, nil
+}
+
+// countingBody counts the bytes read from a response body and reports
+// them once when closed
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	once    sync.Once
+	onClose func(n int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onClose(b.n) })
+	return err
+}
+
+// numericSegment matches the numeric IDs in an endpoint path
+var numericSegment = regexp.MustCompile(`/\d+(/|$)`)
+
+// normalizeEndpoint replaces the numeric IDs of a path with ":id" so that
+// requests for different resources of the same kind add up
+func normalizeEndpoint(path string) string {
+	// Replace twice: adjacent IDs share the slash between them
+	path = numericSegment.ReplaceAllString(path, "/:id$1")
+	return numericSegment.ReplaceAllString(path, "/:id$1")
+}
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Paginator fetches the pages of a list endpoint one at a time, on demand.
+// Next returns the following page until HasNext is false: after the last
+// page according to the X-Total-Pages header, after a page shorter than the
+// page size when the header is missing, or after an error.
+//
+//	p := client.ProjectsPaginator(ctx, &api.ListProjectsOptions{CursusID: 21})
+//	for p.HasNext() {
+//		projects, err := p.Next()
+//		...
+//	}
+type Paginator[T any] struct {
+	ctx     context.Context
+	fetch   PageFunc[T]
+	perPage int
+	next    int // number of the next page
+	done    bool
+	meta    *PaginationMeta
+}
+
+// NewPaginator returns a Paginator over the pages fetch returns, from page
+// 1, with perPage items per page (DefaultPerPage when zero)
+func NewPaginator[T any](ctx context.Context, perPage int, fetch PageFunc[T]) *Paginator[T] {
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	return &Paginator[T]{ctx: ctx, fetch: fetch, perPage: perPage, next: 1}
+}
+
+// From makes the paginator start at page first instead of page 1; it has
+// no effect once a page was fetched
+func (p *Paginator[T]) From(first int) *Paginator[T] {
+	if first > 1 && p.meta == nil && !p.done {
+		p.next = first
+	}
+	return p
+}
+
+// HasNext reports whether Next may return more items
+func (p *Paginator[T]) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page. It returns nil once the pages are exhausted.
+// After an error, nothing more is fetched.
+func (p *Paginator[T]) Next() ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	items, meta, err := p.fetch(p.ctx, p.next)
+	if err != nil {
+		p.done = true
+		return nil, err
+	}
+	p.meta = meta
+	if len(items) < p.perPage || (meta != nil && meta.TotalPages > 0 && p.next >= meta.TotalPages) {
+		p.done = true
+	}
+	p.next++
+	return items, nil
+}
+
+// All fetches the remaining pages and returns their items together
+func (p *Paginator[T]) All() ([]T, error) {
+	var all []T
+	for p.HasNext() {
+		items, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// Items yields the remaining items one at a time, fetching each page when
+// the consumer reaches it. An error is yielded once, with the zero item,
+// and ends the iteration.
+func (p *Paginator[T]) Items() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for pg, err := range p.pages() {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range pg.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Page returns the number of the last page fetched, 0 before the first
+func (p *Paginator[T]) Page() int {
+	return p.next - 1
+}
+
+// PerPage returns the page size
+func (p *Paginator[T]) PerPage() int {
+	return p.perPage
+}
+
+// Meta returns the pagination metadata of the last page fetched
+func (p *Paginator[T]) Meta() *PaginationMeta {
+	return p.meta
+}
+
+// pages yields the remaining pages with their number and metadata
+func (p *Paginator[T]) pages() iter.Seq2[page[T], error] {
+	return func(yield func(page[T], error) bool) {
+		for p.HasNext() {
+			items, err := p.Next()
+			if err != nil {
+				yield(page[T]{}, err)
+				return
+			}
+			if !yield(page[T]{number: p.Page(), items: items, meta: p.meta}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// pagedOptions are list options with a page and a page size
+type pagedOptions[O any] interface {
+	*O
+	paging() (page, perPage *int)
+}
+
+// paginate returns a Paginator over list with a copy of opts, so that the
+// caller's options are left untouched. It starts at opts.Page if set.
+func paginate[O any, P pagedOptions[O], T any](ctx context.Context, opts P, list func(context.Context, P) ([]T, *PaginationMeta, error)) *Paginator[T] {
+	pageOpts := P(new(O))
+	if opts != nil {
+		*pageOpts = *opts
+	}
+	page, perPage := pageOpts.paging()
+	if *perPage == 0 {
+		*perPage = DefaultPerPage
+	}
+	first := *page
+	return NewPaginator(ctx, *perPage, func(ctx context.Context, n int) ([]T, *PaginationMeta, error) {
+		*page = n
+		return list(ctx, pageOpts)
+	}).From(first)
+}
+
+// pageQuery returns the query of one page of a list endpoint, setting the
+// defaults of page (1) and perPage (DefaultPerPage) in place
+func pageQuery(page, perPage *int) url.Values {
+	if *perPage == 0 {
+		*perPage = DefaultPerPage
+	}
+	if *page == 0 {
+		*page = 1
+	}
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(*page))
+	params.Set("per_page", strconv.Itoa(*perPage))
+	return params
+}
+
+// getPage fetches one page of a list endpoint, with its pagination metadata
+func getPage[T any](ctx context.Context, c *Client, endpoint string) ([]T, *PaginationMeta, error) {
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []T
+	if err := c.handleResponse(resp, &items); err != nil {
+		return nil, nil, err
+	}
+
+	// Extract pagination metadata from headers
+	meta := c.extractPaginationMeta(resp, len(items))
+	if meta.TotalCount == 0 && meta.TotalPages == 0 && len(items) > 0 {
+		// Paging goes on until a short page, which costs an extra request
+		path, _, _ := strings.Cut(endpoint, "?")
+		c.warnf("pagination_headers_missing", "%s returned no X-Total or X-Total-Pages header; the page count is unknown", path)
+	}
+	return items, meta, nil
+}
+
+func (o *ListProjectsOptions) paging() (*int, *int)      { return &o.Page, &o.PerPage }
+func (o *ListUserProjectsOptions) paging() (*int, *int)  { return &o.Page, &o.PerPage }
+func (o *ListUsersOptions) paging() (*int, *int)         { return &o.Page, &o.PerPage }
+func (o *ListCursusUsersOptions) paging() (*int, *int)   { return &o.Page, &o.PerPage }
+func (o *ListTeamsOptions) paging() (*int, *int)         { return &o.Page, &o.PerPage }
+func (o *ListScaleTeamsOptions) paging() (*int, *int)    { return &o.Page, &o.PerPage }
+func (o *ListProjectsUsersOptions) paging() (*int, *int) { return &o.Page, &o.PerPage }
+
+// ProjectsPaginator pages through the projects matching opts
+func (c *Client) ProjectsPaginator(ctx context.Context, opts *ListProjectsOptions) *Paginator[Project] {
+	return paginate(ctx, opts, c.ListProjects)
+}
+
+// UserProjectsPaginator pages through the projects of a user
+func (c *Client) UserProjectsPaginator(ctx context.Context, userID int, opts *ListUserProjectsOptions) *Paginator[ProjectUser] {
+	return paginate(ctx, opts, func(ctx context.Context, opts *ListUserProjectsOptions) ([]ProjectUser, *PaginationMeta, error) {
+		return c.ListUserProjects(ctx, userID, opts)
+	})
+}
+
+// ProjectsUsersPaginator pages through the project registrations matching
+// opts, across users
+func (c *Client) ProjectsUsersPaginator(ctx context.Context, opts *ListProjectsUsersOptions) *Paginator[ProjectUser] {
+	return paginate(ctx, opts, c.ListProjectsUsers)
+}
+
+// UsersPaginator pages through the users matching opts
+func (c *Client) UsersPaginator(ctx context.Context, opts *ListUsersOptions) *Paginator[User] {
+	return paginate(ctx, opts, c.ListUsers)
+}
+
+// CampusUsersPaginator pages through the users of a campus
+func (c *Client) CampusUsersPaginator(ctx context.Context, campusID int, opts *ListUsersOptions) *Paginator[User] {
+	return paginate(ctx, opts, func(ctx context.Context, opts *ListUsersOptions) ([]User, *PaginationMeta, error) {
+		return c.ListCampusUsers(ctx, campusID, opts)
+	})
+}
+
+// CursusUsersPaginator pages through the cursus users matching opts
+func (c *Client) CursusUsersPaginator(ctx context.Context, cursusID int, opts *ListCursusUsersOptions) *Paginator[CursusUser] {
+	return paginate(ctx, opts, func(ctx context.Context, opts *ListCursusUsersOptions) ([]CursusUser, *PaginationMeta, error) {
+		return c.ListCursusUsers(ctx, cursusID, opts)
+	})
+}
+
+// UserTeamsPaginator pages through the teams of a user
+func (c *Client) UserTeamsPaginator(ctx context.Context, userID int, opts *ListTeamsOptions) *Paginator[Team] {
+	return paginate(ctx, opts, func(ctx context.Context, opts *ListTeamsOptions) ([]Team, *PaginationMeta, error) {
+		return c.ListUserTeams(ctx, userID, opts)
+	})
+}
+
+// MyScaleTeamsPaginator pages through the evaluations of the logged-in user
+func (c *Client) MyScaleTeamsPaginator(ctx context.Context, opts *ListScaleTeamsOptions) *Paginator[ScaleTeam] {
+	return paginate(ctx, opts, c.ListMyScaleTeams)
+}
+
+// ScaleTeamsPaginator pages through the evaluations matching opts
+func (c *Client) ScaleTeamsPaginator(ctx context.Context, opts *ListScaleTeamsOptions) *Paginator[ScaleTeam] {
+	return paginate(ctx, opts, c.ListScaleTeams)
+}
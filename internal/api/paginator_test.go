@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// numbersPages serves total numbers, perPage per page, with the total
+// pages in the metadata when withMeta is set
+func numbersPages(total, perPage int, withMeta bool, calls *[]int) PageFunc[int] {
+	return func(ctx context.Context, page int) ([]int, *PaginationMeta, error) {
+		*calls = append(*calls, page)
+		var items []int
+		for n := (page-1)*perPage + 1; n <= min(page*perPage, total); n++ {
+			items = append(items, n)
+		}
+		if !withMeta {
+			return items, nil, nil
+		}
+		return items, &PaginationMeta{Page: page, PerPage: perPage, TotalPages: (total + perPage - 1) / perPage}, nil
+	}
+}
+
+func TestPaginator(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		withMeta  bool
+		from      int
+		wantItems int
+		wantCalls []int
+	}{
+		{name: "stops on a short page", total: 3, wantItems: 3, wantCalls: []int{1, 2}},
+		{name: "stops on an empty page", total: 4, wantItems: 4, wantCalls: []int{1, 2, 3}},
+		{name: "stops on the last page of the metadata", total: 4, withMeta: true, wantItems: 4, wantCalls: []int{1, 2}},
+		{name: "starts from a later page", total: 3, from: 2, wantItems: 1, wantCalls: []int{2}},
+		{name: "no items", total: 0, wantItems: 0, wantCalls: []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []int
+			p := NewPaginator(context.Background(), 2, numbersPages(tt.total, 2, tt.withMeta, &calls)).From(tt.from)
+			items, err := p.All()
+			if err != nil {
+				t.Fatalf("All() error = %v", err)
+			}
+			if len(items) != tt.wantItems {
+				t.Errorf("All() returned %d items, want %d", len(items), tt.wantItems)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("fetched pages %v, want %v", calls, tt.wantCalls)
+			}
+			if p.HasNext() {
+				t.Error("HasNext() = true after All()")
+			}
+			if items, err := p.Next(); items != nil || err != nil {
+				t.Errorf("Next() after the last page = %v, %v, want nil, nil", items, err)
+			}
+		})
+	}
+}
+
+func TestPaginatorNext(t *testing.T) {
+	var calls []int
+	p := NewPaginator(context.Background(), 2, numbersPages(3, 2, true, &calls))
+
+	if p.Page() != 0 {
+		t.Errorf("Page() before the first page = %d, want 0", p.Page())
+	}
+	first, err := p.Next()
+	if err != nil || !reflect.DeepEqual(first, []int{1, 2}) {
+		t.Fatalf("first Next() = %v, %v, want [1 2]", first, err)
+	}
+	if p.Page() != 1 || p.Meta() == nil || p.Meta().TotalPages != 2 {
+		t.Errorf("after the first page, Page() = %d, Meta() = %+v", p.Page(), p.Meta())
+	}
+	if !p.HasNext() {
+		t.Fatal("HasNext() = false after the first of two pages")
+	}
+	second, err := p.Next()
+	if err != nil || !reflect.DeepEqual(second, []int{3}) {
+		t.Fatalf("second Next() = %v, %v, want [3]", second, err)
+	}
+	if p.HasNext() {
+		t.Error("HasNext() = true after the last page")
+	}
+}
+
+func TestPaginatorError(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	p := NewPaginator(context.Background(), 2, func(ctx context.Context, page int) ([]int, *PaginationMeta, error) {
+		calls++
+		if page == 2 {
+			return nil, nil, boom
+		}
+		return []int{1, 2}, nil, nil
+	})
+
+	var got []int
+	var gotErr error
+	for n, err := range p.Items() {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		got = append(got, n)
+	}
+	if !errors.Is(gotErr, boom) {
+		t.Errorf("Items() error = %v, want %v", gotErr, boom)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Items() yielded %v before the error, want [1 2]", got)
+	}
+	if p.HasNext() || calls != 2 {
+		t.Errorf("after the error, HasNext() = %v with %d calls, want false with 2", p.HasNext(), calls)
+	}
+}
+
+func TestPaginatorItemsStopsEarly(t *testing.T) {
+	var calls []int
+	p := NewPaginator(context.Background(), 2, numbersPages(10, 2, false, &calls))
+	for n, err := range p.Items() {
+		if err != nil {
+			t.Fatalf("Items() error = %v", err)
+		}
+		if n == 3 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(calls, []int{1, 2}) {
+		t.Errorf("fetched pages %v, want [1 2]", calls)
+	}
+}
+
+func TestProjectsPaginator(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("page")+"/"+r.URL.Query().Get("per_page")+"/"+r.URL.Query().Get("filter[cursus_id]"))
+		w.Header().Set("X-Total-Pages", "2")
+		_, _ = fmt.Fprintf(w, `[{"id": %s}, {"id": 0}]`, r.URL.Query().Get("page"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil))
+	opts := &ListProjectsOptions{CursusID: 21, PerPage: 2}
+	projects, err := client.ProjectsPaginator(context.Background(), opts).All()
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(projects) != 4 {
+		t.Errorf("All() returned %d projects, want 4", len(projects))
+	}
+	if want := []string{"1/2/21", "2/2/21"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %v, want %v", queries, want)
+	}
+	if opts.Page != 0 {
+		t.Errorf("caller's options were modified: Page = %d", opts.Page)
+	}
+}
+
+func TestPaginatorWarnsWithoutHeaders(t *testing.T) {
+	withHeaders := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if withHeaders {
+			w.Header().Set("X-Total", "1")
+		}
+		_, _ = fmt.Fprint(w, `[{"id": 1}]`)
+	}))
+	defer server.Close()
+
+	var warnings []string
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(nil),
+		WithWarnings(func(code, message string) {
+			warnings = append(warnings, code+": "+message)
+		}))
+
+	if _, err := client.ProjectsPaginator(context.Background(), nil).All(); err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("warnings with pagination headers = %v", warnings)
+	}
+
+	withHeaders = false
+	if _, err := client.ProjectsPaginator(context.Background(), nil).All(); err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "pagination_headers_missing: /v2/projects ") {
+		t.Errorf("warnings without pagination headers = %v", warnings)
+	}
+}
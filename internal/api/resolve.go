@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// resolveSearchSize is the number of projects asked from the name search
+	resolveSearchSize = 30
+
+	// minConfidence is the lowest confidence of a fuzzy candidate
+	minConfidence = 0.5
+
+	// acceptConfidence is the confidence a single best candidate needs to
+	// be taken without asking
+	acceptConfidence = 0.65
+
+	// confidenceMargin is how far ahead of the runner-up the best candidate
+	// must be to be taken without asking
+	confidenceMargin = 0.1
+
+	// maxCandidates is the number of candidates kept in a resolution
+	maxCandidates = 5
+)
+
+// ProjectIndex returns the projects ResolveProjectSlug fuzzy-matches input
+// against, typically a cached list of the cursus projects
+type ProjectIndex func(ctx context.Context, client *Client) ([]Project, error)
+
+// WithProjectIndex sets the project index used by ResolveProjectSlug when
+// neither the slug nor the name search finds the project
+func WithProjectIndex(index ProjectIndex) ClientOption {
+	return func(c *Client) {
+		c.projectIndex = index
+	}
+}
+
+// ProjectMatch is a project a slug resolution found, with how confident it
+// is that the project is the one meant, from 0 to 1
+type ProjectMatch struct {
+	Project    Project `json:"project"`
+	Confidence float64 `json:"confidence"`
+	Source     string  `json:"source"` // "slug", "name" or "index"
+}
+
+// ProjectResolution is the outcome of ResolveProjectSlug
+type ProjectResolution struct {
+	Input      string         `json:"input"`
+	Candidates []ProjectMatch `json:"candidates"` // best first
+}
+
+// ProjectNotFoundError is returned when no project matches an input
+type ProjectNotFoundError struct {
+	Input string
+}
+
+func (e *ProjectNotFoundError) Error() string {
+	return fmt.Sprintf("project %q not found (run 't42 search %s --type project' to look for it)", e.Input, e.Input)
+}
+
+// Is makes a *ProjectNotFoundError an ErrNotFound
+func (e *ProjectNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// AmbiguousProjectError is returned when an input matches several
+// projects about as well
+type AmbiguousProjectError struct {
+	Input      string
+	Candidates []ProjectMatch
+}
+
+func (e *AmbiguousProjectError) Error() string {
+	names := make([]string, len(e.Candidates))
+	for i, m := range e.Candidates {
+		names[i] = fmt.Sprintf("%s (%s)", m.Project.Name, m.Project.Slug)
+	}
+	return fmt.Sprintf("project %q is ambiguous: did you mean %s? (use the project slug)", e.Input, strings.Join(names, ", "))
+}
+
+// Project returns the project the input resolved to: an exact match, or a
+// confident candidate clearly ahead of the others. Otherwise it returns a
+// *ProjectNotFoundError or an *AmbiguousProjectError.
+func (r *ProjectResolution) Project() (*Project, error) {
+	if len(r.Candidates) == 0 {
+		return nil, &ProjectNotFoundError{Input: r.Input}
+	}
+	best := r.Candidates[0]
+	if best.Confidence >= 1 {
+		return &best.Project, nil
+	}
+	if best.Confidence >= acceptConfidence &&
+		(len(r.Candidates) == 1 || best.Confidence-r.Candidates[1].Confidence >= confidenceMargin) {
+		return &best.Project, nil
+	}
+	return nil, &AmbiguousProjectError{Input: r.Input, Candidates: r.Candidates}
+}
+
+// ResolveProjectSlug finds the projects input may refer to. An exact slug
+// wins outright; otherwise the projects whose name matches the input are
+// searched, and then the project index (see WithProjectIndex) is
+// fuzzy-matched. Candidates are scored on their name and slug.
+func (c *Client) ResolveProjectSlug(ctx context.Context, input string) (*ProjectResolution, error) {
+	resolution := &ProjectResolution{Input: input}
+	query := strings.TrimSpace(input)
+	if query == "" {
+		return resolution, nil
+	}
+
+	project, err := c.findProjectBySlug(ctx, strings.ToLower(query))
+	if err != nil {
+		return nil, err
+	}
+	if project != nil {
+		resolution.Candidates = []ProjectMatch{{Project: *project, Confidence: 1, Source: "slug"}}
+		return resolution, nil
+	}
+
+	byID := make(map[int]ProjectMatch)
+	add := func(projects []Project, source string) {
+		for _, p := range projects {
+			confidence := projectConfidence(query, p)
+			if confidence < minConfidence {
+				continue
+			}
+			if existing, ok := byID[p.ID]; !ok || confidence > existing.Confidence {
+				byID[p.ID] = ProjectMatch{Project: p, Confidence: confidence, Source: source}
+			}
+		}
+	}
+
+	projects, _, err := c.ListProjects(ctx, &ListProjectsOptions{
+		PerPage: resolveSearchSize,
+		Search:  map[string]string{"name": query},
+	})
+	if err != nil {
+		return nil, err
+	}
+	add(projects, "name")
+
+	if c.projectIndex != nil && !hasExactMatch(byID) {
+		// The index only adds candidates: it failing leaves the search ones
+		if index, err := c.projectIndex(ctx, c); err == nil {
+			add(index, "index")
+		}
+	}
+
+	for _, m := range byID {
+		resolution.Candidates = append(resolution.Candidates, m)
+	}
+	sort.Slice(resolution.Candidates, func(i, j int) bool {
+		a, b := resolution.Candidates[i], resolution.Candidates[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		return a.Project.Name < b.Project.Name
+	})
+	if len(resolution.Candidates) > maxCandidates {
+		resolution.Candidates = resolution.Candidates[:maxCandidates]
+	}
+	return resolution, nil
+}
+
+// hasExactMatch reports whether a candidate is certain
+func hasExactMatch(matches map[int]ProjectMatch) bool {
+	for _, m := range matches {
+		if m.Confidence >= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// projectConfidence rates how well input names project, from its name and
+// slug: 1 for an exact name, then prefixes and substrings, then the edit
+// distance for typos
+func projectConfidence(input string, project Project) float64 {
+	if strings.EqualFold(strings.TrimSpace(input), project.Name) {
+		return 1
+	}
+	return max(similarity(input, project.Name), similarity(input, project.Slug))
+}
+
+// similarity compares two identifiers ignoring case and separators, so that
+// "Get Next Line", "get-next-line" and "get_next_line" are equal
+func similarity(a, b string) float64 {
+	a, b = normalizeIdentifier(a), normalizeIdentifier(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	ratio := float64(len(a)) / float64(len(b))
+	switch {
+	case a == b:
+		return 0.95
+	case strings.HasPrefix(b, a):
+		return 0.6 + 0.3*ratio
+	case strings.Contains(b, a):
+		return 0.5 + 0.3*ratio
+	}
+	distance := levenshtein(a, b)
+	return 0.9 * (1 - float64(distance)/float64(max(len(a), len(b))))
+}
+
+// normalizeIdentifier lowercases s and keeps only its letters and digits
+func normalizeIdentifier(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
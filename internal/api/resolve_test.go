@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolveProjectSlug(t *testing.T) {
+	catalog := []Project{
+		{ID: 1, Name: "Libft", Slug: "libft"},
+		{ID: 2, Name: "get_next_line", Slug: "get_next_line"},
+		{ID: 3, Name: "ft_printf", Slug: "ft_printf"},
+		{ID: 4, Name: "minishell", Slug: "minishell"},
+		{ID: 5, Name: "minitalk", Slug: "minitalk"},
+		{ID: 6, Name: "Philosophers", Slug: "philosophers"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var found []Project
+		if slug := r.URL.Query().Get("filter[slug]"); slug != "" {
+			for _, p := range catalog {
+				if p.Slug == slug {
+					found = append(found, p)
+				}
+			}
+		} else if name := r.URL.Query().Get("search[name]"); name != "" {
+			for _, p := range catalog {
+				if strings.Contains(strings.ToLower(p.Name), strings.ToLower(name)) {
+					found = append(found, p)
+				}
+			}
+		}
+		if found == nil {
+			found = []Project{}
+		}
+		_ = json.NewEncoder(w).Encode(found)
+	}))
+	defer server.Close()
+
+	indexCalls := 0
+	client := NewClient("token", WithBaseURL(server.URL), WithRateLimiter(nil),
+		WithProjectIndex(func(ctx context.Context, c *Client) ([]Project, error) {
+			indexCalls++
+			return catalog, nil
+		}))
+
+	tests := []struct {
+		name       string
+		input      string
+		wantSlug   string
+		wantSource string
+		wantErr    interface{}
+	}{
+		{"exact slug", "libft", "libft", "slug", nil},
+		{"slug case", "LIBFT", "libft", "slug", nil},
+		{"exact name", "Philosophers", "philosophers", "slug", nil},
+		{"name search", "philo", "philosophers", "name", nil},
+		{"separators", "get next line", "get_next_line", "index", nil},
+		{"typo", "minishel", "minishell", "name", nil},
+		{"typo not in search", "ft_prinft", "ft_printf", "index", nil},
+		{"ambiguous", "mini", "", "", &AmbiguousProjectError{}},
+		{"not found", "webserv", "", "", &ProjectNotFoundError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, err := client.ResolveProjectSlug(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("ResolveProjectSlug() error = %v", err)
+			}
+			project, err := resolution.Project()
+			switch want := tt.wantErr.(type) {
+			case *AmbiguousProjectError:
+				if !errors.As(err, &want) || len(want.Candidates) < 2 {
+					t.Fatalf("Project() error = %v, want an ambiguous project", err)
+				}
+				return
+			case *ProjectNotFoundError:
+				if !errors.As(err, &want) {
+					t.Fatalf("Project() error = %v, want project not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Project() error = %v", err)
+			}
+			if project.Slug != tt.wantSlug {
+				t.Errorf("Project() = %s, want %s", project.Slug, tt.wantSlug)
+			}
+			if got := resolution.Candidates[0].Source; got != tt.wantSource {
+				t.Errorf("source = %s, want %s", got, tt.wantSource)
+			}
+		})
+	}
+	if indexCalls == 0 {
+		t.Error("the project index was never used")
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{"get next line", "get_next_line", 0.95, 0.95},
+		{"philo", "philosophers", 0.7, 0.8},
+		{"minishel", "minishell", 0.85, 0.9},
+		{"pipex", "webserv", 0, 0.5},
+		{"", "libft", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			got := similarity(tt.a, tt.b)
+			if got < tt.min || got > tt.max {
+				t.Errorf("similarity(%q, %q) = %.2f, want between %.2f and %.2f", tt.a, tt.b, got, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ResponseCache stores the bodies of successful GET responses;
+// *cache.Store implements it
+type ResponseCache interface {
+	Get(key string, ttl time.Duration, target interface{}) (bool, error)
+	Set(key string, value interface{}) error
+}
+
+// CachePolicy returns the cache resource and TTL of a GET endpoint (path and
+// query); responses of endpoints with a zero TTL are not cached
+type CachePolicy func(endpoint string) (resource string, ttl time.Duration)
+
+// cachedHeaders are the response headers kept with a cached body, so that
+// pagination metadata survives the cache
+var cachedHeaders = []string{"Content-Type", "X-Total", "X-Page", "X-Per-Page", "X-Total-Pages"}
+
+// cachedResponse is a response as stored in the cache
+type cachedResponse struct {
+	StatusCode int               `json:"status"`
+	Header     map[string]string `json:"header"`
+	Body       string            `json:"body"`
+}
+
+// WithResponseCache serves GET requests from cache when policy gives their
+// endpoint a TTL, and stores successful responses of those endpoints
+func WithResponseCache(cache ResponseCache, policy CachePolicy) ClientOption {
+	return func(c *Client) {
+		c.responseCache = cache
+		c.cachePolicy = policy
+	}
+}
+
+// cachedRequest performs a GET request through the response cache
+func (c *Client) cachedRequest(ctx context.Context, endpoint string) (*http.Response, error) {
+	resource, ttl := c.cachePolicy(endpoint)
+	if ttl <= 0 {
+		return c.sendRequest(ctx, http.MethodGet, endpoint, nil)
+	}
+	key := resource + "/GET " + c.baseURL + endpoint
+
+	var cached cachedResponse
+	found, err := c.responseCache.Get(key, ttl, &cached)
+	if err != nil {
+		path, _, _ := strings.Cut(endpoint, "?")
+		c.warnf("cache_unreadable", "ignoring the unreadable cached response of %s: %v", path, err)
+	}
+	if err == nil && found {
+		header := make(http.Header, len(cached.Header))
+		for name, value := range cached.Header {
+			header.Set(name, value)
+		}
+		if c.recorder != nil {
+			path, _, _ := strings.Cut(endpoint, "?")
+			c.recorder.Record(CallStat{
+				Method:   http.MethodGet,
+				Endpoint: normalizeEndpoint(path),
+				Status:   cached.StatusCode,
+				Bytes:    int64(len(cached.Body)),
+				Cached:   true,
+			})
+		}
+		return &http.Response{
+			StatusCode: cached.StatusCode,
+			Header:     header,
+			Body:       io.NopCloser(bytes.NewReader([]byte(cached.Body))),
+		}, nil
+	}
+
+	resp, err := c.sendRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	closeErr := resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if closeErr != nil {
+		return nil, closeErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	cached = cachedResponse{StatusCode: resp.StatusCode, Header: make(map[string]string), Body: string(body)}
+	for _, name := range cachedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			cached.Header[name] = value
+		}
+	}
+	// Caching is best effort: a failed write only costs a request next time
+	_ = c.responseCache.Set(key, cached)
+
+	return resp, nil
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxRetryWait caps how long a retry waits; when the server asks for
+	// longer (e.g. the hourly quota is spent), its response is returned
+	// instead of waiting
+	MaxRetryWait = 60 * time.Second
+
+	// maxServerJitter is the most added to a wait the server asked for, so
+	// that clients told the same time do not all come back at once
+	maxServerJitter = 250 * time.Millisecond
+)
+
+// Rate limit headers of the 42 API, and the generic ones of other servers
+const (
+	headerRetryAfter         = "Retry-After"
+	headerSecondlyRemaining  = "X-Secondly-RateLimit-Remaining"
+	headerHourlyRemaining    = "X-Hourly-RateLimit-Remaining"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// jitter returns a random fraction in [0, 1); tests replace it
+var jitter = rand.Float64
+
+// Retry is a retried request attempt, reported to the retry observer
+type Retry struct {
+	Method   string
+	Endpoint string        // path with numeric IDs replaced by ":id", without query
+	Attempt  int           // the attempt about to be made, 2 for the first retry
+	Status   int           // status of the failed attempt, 0 for a network error
+	Err      error         // network error of the failed attempt
+	Wait     time.Duration // how long the client waits before the attempt
+	Reason   string        // what the wait is based on, e.g. "Retry-After"
+}
+
+// WithRetryObserver calls observe before every retry, e.g. to show the
+// retries of a command with --verbose
+func WithRetryObserver(observe func(Retry)) ClientOption {
+	return func(c *Client) {
+		c.retryObserver = observe
+	}
+}
+
+// retryWait returns how long to wait before retry number retry (1 for the
+// first) after resp, nil for a network error, and why. A server asking for
+// more than MaxRetryWait gets ok false: retrying would not help.
+//
+// The server's word comes first: Retry-After, then a spent quota in the rate
+// limit headers. Otherwise the wait doubles from RetryDelay with each retry,
+// between half of it and all of it at random.
+func retryWait(resp *http.Response, retry int, now time.Time) (wait time.Duration, reason string, ok bool) {
+	if resp != nil {
+		if wait, reason, found := serverWait(resp.Header, now); found {
+			if wait > MaxRetryWait {
+				return wait, reason, false
+			}
+			return wait + time.Duration(jitter()*float64(maxServerJitter)), reason, true
+		}
+	}
+
+	backoff := RetryDelay << (retry - 1)
+	backoff = backoff/2 + time.Duration(jitter()*float64(backoff/2))
+	return min(backoff, MaxRetryWait), "backoff", true
+}
+
+// serverWait reads how long the server wants the client to wait from the
+// response headers
+func serverWait(header http.Header, now time.Time) (time.Duration, string, bool) {
+	if value := strings.TrimSpace(header.Get(headerRetryAfter)); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, headerRetryAfter, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(at.Sub(now), 0), headerRetryAfter, true
+		}
+	}
+
+	if header.Get(headerRateLimitRemaining) == "0" {
+		if value := header.Get(headerRateLimitReset); value != "" {
+			if reset, err := strconv.ParseInt(value, 10, 64); err == nil {
+				// Either a Unix time or a number of seconds
+				if reset > 1e9 {
+					return max(time.Unix(reset, 0).Sub(now), 0), headerRateLimitReset, true
+				}
+				return time.Duration(reset) * time.Second, headerRateLimitReset, true
+			}
+		}
+	}
+
+	// The 42 API counts requests per second and per hour
+	if header.Get(headerHourlyRemaining) == "0" {
+		return now.Truncate(time.Hour).Add(time.Hour).Sub(now), "hourly quota spent", true
+	}
+	if header.Get(headerSecondlyRemaining) == "0" {
+		return time.Second, "secondly quota spent", true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryWait(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 59, 30, 0, time.UTC)
+	original := jitter
+	jitter = func() float64 { return 0 }
+	defer func() { jitter = original }()
+
+	response := func(headers map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name       string
+		resp       *http.Response
+		retry      int
+		wantWait   time.Duration
+		wantReason string
+		wantOK     bool
+	}{
+		{"network error", nil, 1, RetryDelay / 2, "backoff", true},
+		{"backoff doubles", response(nil), 3, 2 * RetryDelay, "backoff", true},
+		{"retry-after seconds", response(map[string]string{"Retry-After": "5"}), 1, 5 * time.Second, "Retry-After", true},
+		{"retry-after date", response(map[string]string{"Retry-After": now.Add(10 * time.Second).Format(http.TimeFormat)}), 1, 10 * time.Second, "Retry-After", true},
+		{"retry-after too long", response(map[string]string{"Retry-After": "3600"}), 1, time.Hour, "Retry-After", false},
+		{"secondly quota", response(map[string]string{"X-Secondly-RateLimit-Remaining": "0", "X-Hourly-RateLimit-Remaining": "900"}), 1, time.Second, "secondly quota spent", true},
+		{"hourly quota", response(map[string]string{"X-Hourly-RateLimit-Remaining": "0"}), 1, 30 * time.Second, "hourly quota spent", true},
+		{"reset delta", response(map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "7"}), 1, 7 * time.Second, "X-RateLimit-Reset", true},
+		{"reset time", response(map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1717239590"}), 1, 20 * time.Second, "X-RateLimit-Reset", true},
+		{"quota left", response(map[string]string{"X-Secondly-RateLimit-Remaining": "1"}), 2, RetryDelay, "backoff", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, reason, ok := retryWait(tt.resp, tt.retry, now)
+			if wait != tt.wantWait || reason != tt.wantReason || ok != tt.wantOK {
+				t.Errorf("retryWait() = %s, %q, %v, want %s, %q, %v", wait, reason, ok, tt.wantWait, tt.wantReason, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsServer(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch r.URL.Path {
+		case "/v2/flaky":
+			if attempts == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case "/v2/spent":
+			w.Header().Set("Retry-After", "7200")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	var retries []Retry
+	recorder := NewCallRecorder()
+	client := NewClient("token", WithBaseURL(server.URL), WithRateLimiter(nil), WithCallRecorder(recorder),
+		WithRetryObserver(func(r Retry) { retries = append(retries, r) }))
+
+	if _, err := client.Passthrough(context.Background(), "GET", "/v2/flaky", nil, nil); err != nil {
+		t.Fatalf("Passthrough() error = %v", err)
+	}
+	if len(retries) != 1 || retries[0].Reason != "Retry-After" || retries[0].Status != http.StatusTooManyRequests || retries[0].Attempt != 2 {
+		t.Errorf("retries = %+v, want one Retry-After retry of a 429", retries)
+	}
+	if got := recorder.Summary().Retries; got != 1 {
+		t.Errorf("recorded %d retries, want 1", got)
+	}
+
+	// A wait beyond MaxRetryWait is not worth it: the 429 comes back at once
+	attempts = 0
+	start := time.Now()
+	raw, err := client.Passthrough(context.Background(), "GET", "/v2/spent", nil, nil)
+	if err == nil || raw == nil || raw.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Passthrough() error = %v, want the 429", err)
+	}
+	if attempts != 1 || time.Since(start) > time.Second {
+		t.Errorf("made %d attempts in %s, want 1 right away", attempts, time.Since(start))
+	}
+}
@@ -1,6 +1,9 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Token represents the OAuth2 token response from 42 API
 type Token struct {
@@ -86,6 +89,7 @@ type Project struct {
 	Children     []Project     `json:"children"`
 	Objectives   []string      `json:"objectives"`
 	Tier         int           `json:"tier"`
+	Difficulty   int           `json:"difficulty"` // Experience given by a validation at 100
 	Attachment   *Attachment   `json:"attachment"`
 	CreatedAt    time.Time     `json:"created_at"`
 	UpdatedAt    time.Time     `json:"updated_at"`
@@ -149,6 +153,14 @@ type Cursus struct {
 	Kind      string    `json:"kind"`
 }
 
+// Level is the experience needed to reach a level of a cursus
+type Level struct {
+	ID       int `json:"id"`
+	Lvl      int `json:"lvl"`
+	XP       int `json:"xp"`
+	CursusID int `json:"cursus_id"`
+}
+
 // CursusUser represents a user's cursus information
 type CursusUser struct {
 	ID         int       `json:"id"`
@@ -308,6 +320,7 @@ type ProjectSession struct {
 	CreatedAt         time.Time   `json:"created_at"`
 	UpdatedAt         time.Time   `json:"updated_at"`
 	MaxPeople         *int        `json:"max_people"`
+	Difficulty        *int        `json:"difficulty"` // Experience given by this session, when it differs
 	IsSubscriptable   bool        `json:"is_subscriptable"`
 	Scales            []Scale     `json:"scales"`
 	Uploads           []Upload    `json:"uploads"`
@@ -406,6 +419,7 @@ type QuestUser struct {
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	Quest       Quest      `json:"quest"`
+	User        *User      `json:"user,omitempty"` // set by /v2/quests_users
 }
 
 // PaginationMeta represents pagination metadata
@@ -431,4 +445,246 @@ type Links struct {
 type APIResponse[T any] struct {
 	Data []T             `json:"data,omitempty"`
 	Meta *PaginationMeta `json:"meta,omitempty"`
-}
\ No newline at end of file
+}
+// Location represents a user's session at a campus workstation
+type Location struct {
+	ID       int        `json:"id"`
+	BeginAt  time.Time  `json:"begin_at"`
+	EndAt    *time.Time `json:"end_at"`
+	Primary  bool       `json:"primary"`
+	Host     string     `json:"host"`
+	CampusID int        `json:"campus_id"`
+	User     User       `json:"user"`
+}
+
+// CorrectionPointHistoric is a change of a user's correction points
+type CorrectionPointHistoric struct {
+	ID          int       `json:"id"`
+	ScaleTeamID *int      `json:"scale_team_id"`
+	Total       int       `json:"total"` // Balance after the change
+	Sum         int       `json:"sum"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Announcement is a news item published by a campus on the intra
+type Announcement struct {
+	ID        int        `json:"id"`
+	Title     string     `json:"title"`
+	Author    string     `json:"author"`
+	Kind      string     `json:"kind"`
+	Text      string     `json:"text"`
+	Link      string     `json:"link"`
+	CampusID  int        `json:"campus_id"`
+	ExpireAt  *time.Time `json:"expire_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Coalition represents a coalition competing inside a bloc
+type Coalition struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	ImageURL string `json:"image_url"`
+	CoverURL string `json:"cover_url"`
+	Color    string `json:"color"`
+	Score    int    `json:"score"`
+	UserID   int    `json:"user_id"`
+}
+
+// Bloc is the group of coalitions competing against each other at a campus
+// for one cursus
+type Bloc struct {
+	ID         int         `json:"id"`
+	CampusID   int         `json:"campus_id"`
+	CursusID   int         `json:"cursus_id"`
+	SquadSize  *int        `json:"squad_size"`
+	Coalitions []Coalition `json:"coalitions"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// CoalitionsUser is the membership of a user in a coalition, with the
+// points the user earned for it
+type CoalitionsUser struct {
+	ID          int       `json:"id"`
+	CoalitionID int       `json:"coalition_id"`
+	UserID      int       `json:"user_id"`
+	Score       int       `json:"score"`
+	Rank        int       `json:"rank"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Flag represents the outcome flag set on an evaluation (e.g. "Ok", "Cheat")
+type Flag struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Positive bool   `json:"positive"`
+	Icon     string `json:"icon"`
+}
+
+// Feedback represents a feedback entry left on an evaluation
+type Feedback struct {
+	ID               int       `json:"id"`
+	User             User      `json:"user"`
+	FeedbackableID   int       `json:"feedbackable_id"`
+	FeedbackableType string    `json:"feedbackable_type"`
+	Comment          string    `json:"comment"`
+	Rating           *int      `json:"rating"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// ScaleTeam represents an evaluation (defense) of a team by a corrector
+type ScaleTeam struct {
+	ID             int        `json:"id"`
+	ScaleID        int        `json:"scale_id"`
+	Comment        string     `json:"comment"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Feedback       string     `json:"feedback"`
+	FeedbackRating *int       `json:"feedback_rating"`
+	FinalMark      *int       `json:"final_mark"`
+	BeginAt        time.Time  `json:"begin_at"`
+	FilledAt       *time.Time `json:"filled_at"`
+	Flag           *Flag      `json:"flag"`
+	Scale          Scale      `json:"scale"`
+	Team           Team       `json:"team"`
+	Feedbacks      []Feedback `json:"feedbacks"`
+	Truant         *User      `json:"truant"`
+
+	// Corrector and Correcteds are nil when the API hides them (e.g. "invisible"
+	// for evaluations that have not happened yet)
+	Corrector  *User  `json:"corrector"`
+	Correcteds []User `json:"correcteds"`
+}
+
+// UnmarshalJSON decodes a scale team, tolerating the placeholder strings the
+// API returns instead of user objects for hidden correctors and correcteds
+func (s *ScaleTeam) UnmarshalJSON(data []byte) error {
+	type scaleTeamAlias ScaleTeam
+	aux := struct {
+		*scaleTeamAlias
+		Corrector  json.RawMessage `json:"corrector"`
+		Correcteds json.RawMessage `json:"correcteds"`
+		Truant     json.RawMessage `json:"truant"`
+	}{scaleTeamAlias: (*scaleTeamAlias)(s)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	s.Corrector = nil
+	if isJSONObject(aux.Corrector) {
+		var corrector User
+		if err := json.Unmarshal(aux.Corrector, &corrector); err != nil {
+			return err
+		}
+		s.Corrector = &corrector
+	}
+
+	s.Truant = nil
+	if isJSONObject(aux.Truant) {
+		var truant User
+		if err := json.Unmarshal(aux.Truant, &truant); err != nil {
+			return err
+		}
+		if truant.ID != 0 || truant.Login != "" {
+			s.Truant = &truant
+		}
+	}
+
+	s.Correcteds = nil
+	if len(aux.Correcteds) > 0 && aux.Correcteds[0] == '[' {
+		if err := json.Unmarshal(aux.Correcteds, &s.Correcteds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Slot is a period in which a user is available to evaluate others
+type Slot struct {
+	ID      int       `json:"id"`
+	BeginAt time.Time `json:"begin_at"`
+	EndAt   time.Time `json:"end_at"`
+	User    *User     `json:"user,omitempty"`
+
+	// Booked is set once an evaluation was scheduled in the slot. The API
+	// hides who booked it until shortly before, so ScaleTeam may stay nil.
+	Booked    bool       `json:"booked"`
+	ScaleTeam *ScaleTeam `json:"scale_team,omitempty"`
+}
+
+// Event is a campus event such as a conference, a workshop or a hackathon
+type Event struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	Kind        string `json:"kind"`
+	// MaxPeople is nil when the number of attendees is not limited
+	MaxPeople      *int      `json:"max_people"`
+	NbrSubscribers int       `json:"nbr_subscribers"`
+	BeginAt        time.Time `json:"begin_at"`
+	EndAt          time.Time `json:"end_at"`
+	CampusIDs      []int     `json:"campus_ids"`
+	CursusIDs      []int     `json:"cursus_ids"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// EventsUser is the registration of a user to an event
+type EventsUser struct {
+	ID      int    `json:"id"`
+	EventID int    `json:"event_id"`
+	UserID  int    `json:"user_id"`
+	User    *User  `json:"user,omitempty"`
+	Event   *Event `json:"event,omitempty"`
+}
+
+// UnmarshalJSON decodes a slot, whose scale_team is null when free, the
+// string "invisible" when booked by someone hidden, or a scale team
+func (s *Slot) UnmarshalJSON(data []byte) error {
+	type slotAlias Slot
+	aux := struct {
+		*slotAlias
+		ScaleTeam json.RawMessage `json:"scale_team"`
+		User      json.RawMessage `json:"user"`
+	}{slotAlias: (*slotAlias)(s)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	s.ScaleTeam = nil
+	if len(aux.ScaleTeam) > 0 && string(aux.ScaleTeam) != "null" {
+		s.Booked = true
+	}
+	if isJSONObject(aux.ScaleTeam) {
+		var scaleTeam ScaleTeam
+		if err := json.Unmarshal(aux.ScaleTeam, &scaleTeam); err != nil {
+			return err
+		}
+		s.ScaleTeam = &scaleTeam
+	}
+
+	s.User = nil
+	if isJSONObject(aux.User) {
+		var user User
+		if err := json.Unmarshal(aux.User, &user); err != nil {
+			return err
+		}
+		s.User = &user
+	}
+
+	return nil
+}
+
+// isJSONObject reports whether raw holds a JSON object (as opposed to null or a string)
+func isJSONObject(raw json.RawMessage) bool {
+	return len(raw) > 0 && raw[0] == '{'
+}
@@ -0,0 +1,389 @@
+// Package cache provides a small on-disk key/value store with per-entry expiry.
+// It is used to avoid repeating expensive API calls within a short time window.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// entry is the on-disk representation of a cached value
+type entry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"stored_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// statsFileName holds the hit/miss counters; it cannot collide with entry
+// files, which are named after a hex digest
+const statsFileName = "stats.json"
+
+// Store is a directory-backed cache where every key is stored in its own file
+type Store struct {
+	dir    string
+	ttls   map[string]time.Duration
+	bypass bool
+}
+
+// Option configures a Store
+type Option func(*Store)
+
+// WithTTLs overrides the TTL passed to Get for the given resources (the
+// part of a key before the first "/", e.g. "campus" for "campus/table")
+func WithTTLs(ttls map[string]time.Duration) Option {
+	return func(s *Store) {
+		for resource, ttl := range ttls {
+			s.ttls[resource] = ttl
+		}
+	}
+}
+
+// WithBypass makes every Get miss while Set still stores, so a run
+// refetches everything and refreshes the cache on the way
+func WithBypass() Option {
+	return func(s *Store) {
+		s.bypass = true
+	}
+}
+
+// New creates a cache store rooted at the given directory
+func New(dir string, opts ...Option) *Store {
+	s := &Store{dir: dir, ttls: make(map[string]time.Duration)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Open creates a cache store in the application's cache directory
+func Open(opts ...Option) (*Store, error) {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return New(dir, opts...), nil
+}
+
+// Resource returns the resource a key belongs to: the part before the first "/"
+func Resource(key string) string {
+	resource, _, _ := strings.Cut(key, "/")
+	return resource
+}
+
+// TTL returns the effective TTL of a resource given its default
+func (s *Store) TTL(resource string, fallback time.Duration) time.Duration {
+	if ttl, ok := s.ttls[resource]; ok {
+		return ttl
+	}
+	return fallback
+}
+
+// Dir returns the directory backing the store
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// path returns the file path used to store the given key
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get loads the value stored under key into target.
+// It returns false when the key is missing or older than ttl (or the TTL
+// configured for the key's resource). Hits and misses are counted for Status.
+func (s *Store) Get(key string, ttl time.Duration, target interface{}) (bool, error) {
+	if s.bypass {
+		return false, nil
+	}
+	found, err := s.get(key, s.TTL(Resource(key), ttl), target)
+	s.recordLookup(Resource(key), found)
+	return found, err
+}
+
+func (s *Store) get(key string, ttl time.Duration, target interface{}) (bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false, fmt.Errorf("failed to parse cache entry: %w", err)
+	}
+
+	if e.Key != key || time.Since(e.StoredAt) > ttl {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(e.Data, target); err != nil {
+		return false, fmt.Errorf("failed to decode cached value: %w", err)
+	}
+
+	return true, nil
+}
+
+// Set stores value under key, replacing any previous entry
+func (s *Store) Set(key string, value interface{}) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache value: %w", err)
+	}
+
+	data, err := json.Marshal(entry{Key: key, StoredAt: time.Now(), Data: raw})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial entry
+	tmp, err := os.CreateTemp(s.dir, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		if closeErr := tmp.Close(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close cache file: %v\n", closeErr)
+		}
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close cache file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the entry stored under key, if any
+func (s *Store) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete cache entry: %w", err)
+	}
+	return nil
+}
+
+// lookupStats counts cache hits and misses of one resource
+type lookupStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// recordLookup adds a hit or miss to the persistent counters. Counting is
+// best effort: failures are ignored and concurrent processes may lose updates.
+func (s *Store) recordLookup(resource string, hit bool) {
+	stats := s.loadStats()
+	counts := stats[resource]
+	if hit {
+		counts.Hits++
+	} else {
+		counts.Misses++
+	}
+	stats[resource] = counts
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(s.dir, ".stats-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, statsFileName)); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// loadStats reads the hit/miss counters; missing or unreadable counters start at zero
+func (s *Store) loadStats() map[string]lookupStats {
+	stats := make(map[string]lookupStats)
+	data, err := os.ReadFile(filepath.Join(s.dir, statsFileName))
+	if err != nil {
+		return stats
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return make(map[string]lookupStats)
+	}
+	return stats
+}
+
+// ResourceStatus describes the cached entries of one resource
+type ResourceStatus struct {
+	Resource string    `json:"resource"`
+	Entries  int       `json:"entries"`
+	Bytes    int64     `json:"bytes"`
+	Oldest   time.Time `json:"oldest,omitempty"`
+	Newest   time.Time `json:"newest,omitempty"`
+	Hits     int64     `json:"hits"`
+	Misses   int64     `json:"misses"`
+}
+
+// HitRate returns the fraction of lookups served from the cache
+func (r ResourceStatus) HitRate() float64 {
+	total := r.Hits + r.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(r.Hits) / float64(total)
+}
+
+// Status summarizes the store per resource, sorted by resource name
+func (s *Store) Status() ([]ResourceStatus, error) {
+	byResource := make(map[string]*ResourceStatus)
+	status := func(resource string) *ResourceStatus {
+		if byResource[resource] == nil {
+			byResource[resource] = &ResourceStatus{Resource: resource}
+		}
+		return byResource[resource]
+	}
+
+	err := s.walk(func(path string, e entry, size int64) error {
+		rs := status(Resource(e.Key))
+		rs.Entries++
+		rs.Bytes += size
+		if rs.Oldest.IsZero() || e.StoredAt.Before(rs.Oldest) {
+			rs.Oldest = e.StoredAt
+		}
+		if e.StoredAt.After(rs.Newest) {
+			rs.Newest = e.StoredAt
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for resource, counts := range s.loadStats() {
+		rs := status(resource)
+		rs.Hits = counts.Hits
+		rs.Misses = counts.Misses
+	}
+
+	result := make([]ResourceStatus, 0, len(byResource))
+	for _, rs := range byResource {
+		result = append(result, *rs)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Resource < result[j].Resource
+	})
+	return result, nil
+}
+
+// Invalidate deletes every entry belonging to one of the given resources and
+// returns the number of entries removed
+func (s *Store) Invalidate(resources ...string) (int, error) {
+	wanted := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		wanted[resource] = true
+	}
+
+	removed := 0
+	err := s.walk(func(path string, e entry, size int64) error {
+		if !wanted[Resource(e.Key)] {
+			return nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete cache entry: %w", err)
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+// Clear deletes every entry, leftover temporary file and the hit/miss
+// counters, and returns the number of entries removed
+func (s *Store) Clear() (int, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	removed := 0
+	for _, file := range files {
+		name := file.Name()
+		entryFile := isEntryFile(name)
+		if file.IsDir() || !(entryFile || name == statsFileName ||
+			strings.HasPrefix(name, ".entry-") || strings.HasPrefix(name, ".stats-")) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to delete cache file: %w", err)
+		}
+		if entryFile {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// walk calls fn for every readable entry in the store
+func (s *Store) walk(fn func(path string, e entry, size int64) error) error {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !isEntryFile(name) {
+			continue
+		}
+		path := filepath.Join(s.dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var e entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue
+		}
+		if err := fn(path, e, int64(len(data))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isEntryFile reports whether name is an entry file (a hex digest + ".json")
+func isEntryFile(name string) bool {
+	digest, ok := strings.CutSuffix(name, ".json")
+	if !ok || len(digest) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(digest)
+	return err == nil
+}
@@ -0,0 +1,193 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreGetSet(t *testing.T) {
+	store := New(t.TempDir())
+
+	type payload struct {
+		Login string  `json:"login"`
+		Level float64 `json:"level"`
+	}
+
+	t.Run("missing key returns false", func(t *testing.T) {
+		var got payload
+		found, err := store.Get("missing", time.Minute, &got)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if found {
+			t.Error("Get() found = true, want false")
+		}
+	})
+
+	t.Run("stored value round-trips", func(t *testing.T) {
+		want := payload{Login: "jdoe", Level: 7.42}
+		if err := store.Set("profile/jdoe", want); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+
+		var got payload
+		found, err := store.Get("profile/jdoe", time.Minute, &got)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if !found {
+			t.Fatal("Get() found = false, want true")
+		}
+		if got != want {
+			t.Errorf("Get() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("expired entry is ignored", func(t *testing.T) {
+		if err := store.Set("short", payload{Login: "old"}); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+
+		var got payload
+		found, err := store.Get("short", 0, &got)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if found {
+			t.Error("Get() with zero TTL found = true, want false")
+		}
+	})
+
+	t.Run("deleted entry is gone", func(t *testing.T) {
+		if err := store.Set("gone", payload{Login: "x"}); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if err := store.Delete("gone"); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := store.Delete("gone"); err != nil {
+			t.Errorf("Delete() of missing key error = %v", err)
+		}
+
+		var got payload
+		found, _ := store.Get("gone", time.Minute, &got)
+		if found {
+			t.Error("Get() after Delete() found = true, want false")
+		}
+	})
+}
+
+func TestStoreTTLOverride(t *testing.T) {
+	store := New(t.TempDir(), WithTTLs(map[string]time.Duration{"campus": 0}))
+
+	if err := store.Set("campus/table", []int{1}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := store.Set("profile/jdoe", "x"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"campus/table", false}, // the zero override wins over the caller's TTL
+		{"profile/jdoe", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			var got interface{}
+			found, err := store.Get(tt.key, time.Hour, &got)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if found != tt.want {
+				t.Errorf("Get() found = %v, want %v", found, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreStatusAndInvalidate(t *testing.T) {
+	store := New(t.TempDir())
+
+	for _, key := range []string{"campus/table", "profile/a", "profile/b"} {
+		if err := store.Set(key, key); err != nil {
+			t.Fatalf("Set(%q) error = %v", key, err)
+		}
+	}
+	var got string
+	_, _ = store.Get("profile/a", time.Hour, &got)
+	_, _ = store.Get("profile/missing", time.Hour, &got)
+	_, _ = store.Get("profile/b", time.Hour, &got)
+
+	statuses, err := store.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].Resource != "campus" || statuses[1].Resource != "profile" {
+		t.Fatalf("Status() = %+v, want campus and profile", statuses)
+	}
+	profile := statuses[1]
+	if profile.Entries != 2 || profile.Bytes == 0 {
+		t.Errorf("profile entries = %d, bytes = %d, want 2 entries", profile.Entries, profile.Bytes)
+	}
+	if profile.Hits != 2 || profile.Misses != 1 {
+		t.Errorf("profile hits = %d, misses = %d, want 2 and 1", profile.Hits, profile.Misses)
+	}
+	if rate := profile.HitRate(); rate < 0.66 || rate > 0.67 {
+		t.Errorf("HitRate() = %v, want 2/3", rate)
+	}
+
+	removed, err := store.Invalidate("profile")
+	if err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Invalidate() removed %d entries, want 2", removed)
+	}
+	if found, _ := store.Get("profile/a", time.Hour, &got); found {
+		t.Error("Get() after Invalidate() found = true, want false")
+	}
+	if found, _ := store.Get("campus/table", time.Hour, &got); !found {
+		t.Error("Invalidate() removed an entry of another resource")
+	}
+}
+
+func TestStoreBypassAndClear(t *testing.T) {
+	dir := t.TempDir()
+	store := New(dir)
+	if err := store.Set("campus/table", "cached"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	bypass := New(dir, WithBypass())
+	var got string
+	if found, _ := bypass.Get("campus/table", time.Hour, &got); found {
+		t.Error("Get() with bypass found = true, want false")
+	}
+	if err := bypass.Set("campus/table", "fresh"); err != nil {
+		t.Fatalf("Set() with bypass error = %v", err)
+	}
+	if found, _ := store.Get("campus/table", time.Hour, &got); !found || got != "fresh" {
+		t.Errorf("Get() after bypassed Set() = %q, %v, want fresh", got, found)
+	}
+
+	if err := store.Set("response/x", 1); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	removed, err := store.Clear()
+	if err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Clear() removed %d entries, want 2", removed)
+	}
+	statuses, err := store.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(statuses) != 0 {
+		t.Errorf("Status() after Clear() = %+v, want nothing", statuses)
+	}
+}
@@ -0,0 +1,299 @@
+// Package campus resolves user-supplied campus references (IDs, names,
+// cities and aliases such as "42tokyo") to 42 campuses, so every command
+// accepts the same spellings and reports the same errors.
+package campus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/cache"
+)
+
+const (
+	// TableTTL is how long the campus table is reused before being refetched.
+	// Campuses are created or renamed rarely, so a day is plenty fresh.
+	TableTTL = 24 * time.Hour
+
+	// tableCacheKey is the cache key of the campus table
+	tableCacheKey = "campus/table"
+
+	// maxSuggestions caps the number of campuses listed in a not-found error
+	maxSuggestions = 5
+)
+
+// Lister fetches the full list of campuses
+type Lister interface {
+	ListCampuses(ctx context.Context) ([]api.Campus, error)
+}
+
+// NotFoundError is returned when no campus matches a query
+type NotFoundError struct {
+	Query       string
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("campus %q not found", e.Query)
+	if len(e.Suggestions) > 0 {
+		msg += "; did you mean: " + strings.Join(e.Suggestions, ", ") + "?"
+	}
+	return msg + " (run 't42 campus list' to see all campuses)"
+}
+
+// AmbiguousError is returned when a query matches several campuses
+type AmbiguousError struct {
+	Query   string
+	Matches []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("campus %q is ambiguous: %s (use the campus ID or a more specific name)",
+		e.Query, strings.Join(e.Matches, ", "))
+}
+
+// Resolver maps campus references to campuses using a cached campus table
+type Resolver struct {
+	lister  Lister
+	store   *cache.Store
+	aliases map[string]string
+}
+
+// Option configures a Resolver
+type Option func(*Resolver)
+
+// WithCache stores the campus table in the given cache
+func WithCache(store *cache.Store) Option {
+	return func(r *Resolver) {
+		r.store = store
+	}
+}
+
+// WithAliases adds user-defined aliases mapping a short name to a campus
+// name, city or ID (e.g. "home" -> "tokyo")
+func WithAliases(aliases map[string]string) Option {
+	return func(r *Resolver) {
+		for alias, target := range aliases {
+			r.aliases[normalize(alias)] = target
+		}
+	}
+}
+
+// NewResolver creates a resolver fetching campuses through lister
+func NewResolver(lister Lister, opts ...Option) *Resolver {
+	r := &Resolver{
+		lister:  lister,
+		aliases: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Campuses returns the campus table, from the cache when it is fresh
+func (r *Resolver) Campuses(ctx context.Context) ([]api.Campus, error) {
+	var campuses []api.Campus
+	if r.store != nil {
+		found, err := r.store.Get(tableCacheKey, TableTTL, &campuses)
+		if err == nil && found && len(campuses) > 0 {
+			return campuses, nil
+		}
+	}
+
+	campuses, err := r.lister.ListCampuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campuses: %w", err)
+	}
+
+	if r.store != nil {
+		if err := r.store.Set(tableCacheKey, campuses); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to cache campus table: %v\n", err)
+		}
+	}
+
+	return campuses, nil
+}
+
+// Resolve finds the campus referenced by query (an ID, name, city or alias)
+func (r *Resolver) Resolve(ctx context.Context, query string) (*api.Campus, error) {
+	campuses, err := r.Campuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Match(campuses, r.aliases, query)
+}
+
+// ResolveID finds the campus with the given ID
+func (r *Resolver) ResolveID(ctx context.Context, id int) (*api.Campus, error) {
+	campuses, err := r.Campuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range campuses {
+		if campuses[i].ID == id {
+			return &campuses[i], nil
+		}
+	}
+	return nil, &NotFoundError{Query: strconv.Itoa(id)}
+}
+
+// Match finds the campus referenced by query in campuses. Aliases keys must
+// already be normalized. Lookups are tried in order: numeric ID, alias, exact
+// name, exact city, then a unique name or city prefix.
+func Match(campuses []api.Campus, aliases map[string]string, query string) (*api.Campus, error) {
+	trimmed := strings.TrimSpace(query)
+	if trimmed == "" {
+		return nil, fmt.Errorf("campus must not be empty")
+	}
+
+	if id, err := strconv.Atoi(trimmed); err == nil {
+		for i := range campuses {
+			if campuses[i].ID == id {
+				return &campuses[i], nil
+			}
+		}
+		return nil, &NotFoundError{Query: query}
+	}
+
+	key := normalize(trimmed)
+
+	// Aliases point at another reference; they are not followed recursively
+	if target, ok := aliases[key]; ok {
+		campus, err := Match(campuses, nil, target)
+		if err != nil {
+			return nil, fmt.Errorf("campus alias %q -> %q: %w", trimmed, target, err)
+		}
+		return campus, nil
+	}
+
+	matchers := []func(c *api.Campus) bool{
+		func(c *api.Campus) bool { return normalize(c.Name) == key },
+		func(c *api.Campus) bool { return normalize(c.City) == key },
+		func(c *api.Campus) bool {
+			return strings.HasPrefix(normalize(c.Name), key) || strings.HasPrefix(normalize(c.City), key)
+		},
+	}
+
+	for _, matches := range matchers {
+		var found []*api.Campus
+		for i := range campuses {
+			if matches(&campuses[i]) {
+				found = append(found, &campuses[i])
+			}
+		}
+		switch {
+		case len(found) == 1:
+			return found[0], nil
+		case len(found) > 1:
+			return nil, &AmbiguousError{Query: query, Matches: labels(found)}
+		}
+	}
+
+	return nil, &NotFoundError{Query: query, Suggestions: suggest(campuses, key)}
+}
+
+// Search returns the campuses whose name, city or country contains query,
+// best matches first: exact names and cities, then prefixes, then the rest
+func Search(campuses []api.Campus, query string) []api.Campus {
+	key := normalize(query)
+	if key == "" {
+		return nil
+	}
+
+	type scored struct {
+		campus api.Campus
+		score  int
+	}
+	var found []scored
+	for _, c := range campuses {
+		score := 0
+		for _, field := range []string{c.Name, c.City, c.Country} {
+			value := normalize(field)
+			switch {
+			case value == "":
+			case value == key:
+				score = max(score, 3)
+			case strings.HasPrefix(value, key):
+				score = max(score, 2)
+			case strings.Contains(value, key):
+				score = max(score, 1)
+			}
+		}
+		if score > 0 {
+			found = append(found, scored{campus: c, score: score})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].score != found[j].score {
+			return found[i].score > found[j].score
+		}
+		return found[i].campus.Name < found[j].campus.Name
+	})
+	result := make([]api.Campus, len(found))
+	for i, f := range found {
+		result[i] = f.campus
+	}
+	return result
+}
+
+// Label formats a campus as "Name (City)", omitting the city when redundant
+func Label(c *api.Campus) string {
+	if c.City != "" && normalize(c.City) != normalize(c.Name) {
+		return fmt.Sprintf("%s (%s)", c.Name, c.City)
+	}
+	return c.Name
+}
+
+// normalize lowercases s, drops everything but letters and digits, and strips
+// a leading "42" so that "42 Tokyo", "42tokyo" and "tokyo" compare equal
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	normalized := b.String()
+	if trimmed := strings.TrimPrefix(normalized, "42"); trimmed != "" {
+		return trimmed
+	}
+	return normalized
+}
+
+// labels returns sorted labels of the given campuses
+func labels(campuses []*api.Campus) []string {
+	result := make([]string, 0, len(campuses))
+	for _, c := range campuses {
+		result = append(result, fmt.Sprintf("%s [%d]", Label(c), c.ID))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// suggest returns campuses whose name or city contains key, or that key contains
+func suggest(campuses []api.Campus, key string) []string {
+	var found []*api.Campus
+	for i := range campuses {
+		name := normalize(campuses[i].Name)
+		city := normalize(campuses[i].City)
+		if strings.Contains(name, key) || strings.Contains(city, key) ||
+			(name != "" && strings.Contains(key, name)) || (city != "" && strings.Contains(key, city)) {
+			found = append(found, &campuses[i])
+		}
+	}
+
+	result := labels(found)
+	if len(result) > maxSuggestions {
+		result = result[:maxSuggestions]
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package campus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/api"
+	"github.com/naokiiida/t42-cli/internal/cache"
+)
+
+var testCampuses = []api.Campus{
+	{ID: 1, Name: "Paris", City: "Paris"},
+	{ID: 26, Name: "Tokyo", City: "Minato-ku"},
+	{ID: 44, Name: "Heilbronn", City: "Heilbronn"},
+	{ID: 39, Name: "Berlin", City: "Berlin"},
+	{ID: 51, Name: "Barcelona", City: "Barcelona"},
+}
+
+func TestMatch(t *testing.T) {
+	aliases := map[string]string{normalize("home"): "tokyo", normalize("hq"): "1"}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantID    int
+		wantError interface{}
+	}{
+		{"numeric ID", "26", 26, nil},
+		{"name", "tokyo", 26, nil},
+		{"name is case insensitive", "TOKYO", 26, nil},
+		{"42 prefix", "42tokyo", 26, nil},
+		{"42 prefix with space", "42 Tokyo", 26, nil},
+		{"city", "minato-ku", 26, nil},
+		{"city without punctuation", "minatoku", 26, nil},
+		{"alias to name", "home", 26, nil},
+		{"alias to ID", "HQ", 1, nil},
+		{"unique prefix", "heil", 44, nil},
+		{"ambiguous prefix", "b", 0, &AmbiguousError{}},
+		{"unknown name", "atlantis", 0, &NotFoundError{}},
+		{"unknown ID", "9999", 0, &NotFoundError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campus, err := Match(testCampuses, aliases, tt.query)
+
+			switch tt.wantError.(type) {
+			case *AmbiguousError:
+				var target *AmbiguousError
+				if !errors.As(err, &target) {
+					t.Fatalf("Match(%q) error = %v, want AmbiguousError", tt.query, err)
+				}
+				return
+			case *NotFoundError:
+				var target *NotFoundError
+				if !errors.As(err, &target) {
+					t.Fatalf("Match(%q) error = %v, want NotFoundError", tt.query, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Match(%q) error = %v", tt.query, err)
+			}
+			if campus.ID != tt.wantID {
+				t.Errorf("Match(%q) = %d, want %d", tt.query, campus.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestNotFoundSuggestions(t *testing.T) {
+	_, err := Match(testCampuses, nil, "42 Paris Innovation")
+
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Match() error = %v, want NotFoundError", err)
+	}
+	if len(notFound.Suggestions) != 1 || notFound.Suggestions[0] != "Paris [1]" {
+		t.Errorf("Suggestions = %v, want [Paris [1]]", notFound.Suggestions)
+	}
+}
+
+type countingLister struct {
+	calls int
+}
+
+func (l *countingLister) ListCampuses(ctx context.Context) ([]api.Campus, error) {
+	l.calls++
+	return testCampuses, nil
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"berlin", []int{39}},
+		{"42 Barcelona", []int{51}},
+		{"b", []int{51, 39, 44}}, // prefixes before substrings
+		{"r", []int{51, 39, 44, 1}},
+		{"minato", []int{26}},
+		{"atlantis", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := Search(testCampuses, tt.query)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) returned %d campuses, want %d", tt.query, len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("Search(%q)[%d] = %d, want %d", tt.query, i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestResolverCachesTable(t *testing.T) {
+	store := cache.New(t.TempDir())
+	lister := &countingLister{}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		// A fresh resolver per iteration models separate command invocations
+		resolver := NewResolver(lister, WithCache(store))
+		campus, err := resolver.Resolve(ctx, "paris")
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+		if campus.ID != 1 {
+			t.Errorf("Resolve() = %d, want 1", campus.ID)
+		}
+	}
+
+	if lister.calls != 1 {
+		t.Errorf("ListCampuses() called %d times, want 1", lister.calls)
+	}
+}
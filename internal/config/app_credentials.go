@@ -0,0 +1,175 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/naokiiida/t42-cli/internal/keyring"
+)
+
+// appKeyringSuffix sets the keyring entry of the application token apart
+// from the user's
+const appKeyringSuffix = "#app"
+
+// errAppCredentialsInSession refuses to keep an application token in a
+// shared-machine session, which only holds the user's credentials
+var errAppCredentialsInSession = errors.New("application tokens are not stored in a shared-machine session")
+
+// LoadAppCredentials loads the application token saved by
+// 't42 auth login --client-credentials', from the same backend as the
+// user's credentials
+func LoadAppCredentials() (*Credentials, error) {
+	credentials, _, err := loadAppCredentials()
+	return credentials, err
+}
+
+// StoredAppCredentialsBackend returns where the application token is
+// stored, "keyring" or "file", or "" when there is none
+func StoredAppCredentialsBackend() string {
+	_, backend, err := loadAppCredentials()
+	if err != nil {
+		return ""
+	}
+	return backend
+}
+
+// HasAppCredentials reports whether an application token is stored, valid
+// or not
+func HasAppCredentials() bool {
+	return StoredAppCredentialsBackend() != ""
+}
+
+// SaveAppCredentials saves the application token to the configured
+// backend, like SaveCredentials
+func SaveAppCredentials(credentials *Credentials) error {
+	data, err := json.MarshalIndent(credentials, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials to JSON: %w", err)
+	}
+
+	backend, err := GetCredentialsBackend()
+	if err != nil {
+		return err
+	}
+	switch backend {
+	case CredentialsBackendFile:
+		return saveAppCredentialsFile(data)
+	case CredentialsBackendSession:
+		return errAppCredentialsInSession
+	}
+
+	account, err := appKeyringAccount()
+	if err != nil {
+		return err
+	}
+	keyringErr := systemKeyring.Set(keyringService, account, string(data))
+	if keyringErr == nil {
+		return deleteAppCredentialsFile()
+	}
+	if backend == CredentialsBackendKeyring {
+		return fmt.Errorf("failed to save the application token to the system keyring: %w", keyringErr)
+	}
+	_ = systemKeyring.Delete(keyringService, account)
+	return saveAppCredentialsFile(data)
+}
+
+// DeleteAppCredentials removes the application token from the system
+// keyring and the application token file
+func DeleteAppCredentials() error {
+	backend, err := GetCredentialsBackend()
+	if err != nil {
+		return err
+	}
+	switch backend {
+	case CredentialsBackendSession:
+		return nil
+	case CredentialsBackendFile:
+		return deleteAppCredentialsFile()
+	}
+	account, err := appKeyringAccount()
+	if err != nil {
+		return err
+	}
+	err = systemKeyring.Delete(keyringService, account)
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) && !errors.Is(err, keyring.ErrUnavailable) {
+		return fmt.Errorf("failed to delete the application token from the system keyring: %w", err)
+	}
+	return deleteAppCredentialsFile()
+}
+
+func loadAppCredentials() (*Credentials, string, error) {
+	backend, err := GetCredentialsBackend()
+	if err != nil {
+		return nil, "", err
+	}
+	if backend == CredentialsBackendSession {
+		return nil, "", errAppCredentialsInSession
+	}
+
+	if backend != CredentialsBackendFile {
+		account, err := appKeyringAccount()
+		if err != nil {
+			return nil, "", err
+		}
+		secret, err := systemKeyring.Get(keyringService, account)
+		switch {
+		case err == nil:
+			credentials, err := parseCredentials([]byte(secret))
+			return credentials, CredentialsBackendKeyring, err
+		case backend == CredentialsBackendKeyring && errors.Is(err, keyring.ErrNotFound):
+			return nil, "", fmt.Errorf("application token not found in the system keyring")
+		case backend == CredentialsBackendKeyring:
+			return nil, "", fmt.Errorf("failed to read the application token from the system keyring: %w", err)
+		}
+	}
+
+	path, err := GetAppCredentialsFilePath()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get application token file path: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("application token file not found at %s", path)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read application token file: %w", err)
+	}
+	credentials, err := parseCredentials(data)
+	return credentials, CredentialsBackendFile, err
+}
+
+// appKeyringAccount names the keyring entry of the application token
+func appKeyringAccount() (string, error) {
+	account, err := keyringAccount()
+	if err != nil {
+		return "", err
+	}
+	return account + appKeyringSuffix, nil
+}
+
+func saveAppCredentialsFile(data []byte) error {
+	if err := EnsureConfigDir(); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	path, err := GetAppCredentialsFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get application token file path: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write application token file: %w", err)
+	}
+	return nil
+}
+
+func deleteAppCredentialsFile() error {
+	path, err := GetAppCredentialsFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get application token file path: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete application token file: %w", err)
+	}
+	return nil
+}
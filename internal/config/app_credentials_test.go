@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/keyring"
+)
+
+func TestAppCredentials(t *testing.T) {
+	user := &Credentials{AccessToken: "user-token", ExpiresIn: 7200}
+	app := &Credentials{AccessToken: "app-token", ExpiresIn: 7200}
+
+	tests := []struct {
+		name    string
+		backend string
+		keyring keyring.Keyring
+		want    string
+	}{
+		{"keyring", CredentialsBackendAuto, keyring.NewMemory(), CredentialsBackendKeyring},
+		{"file", CredentialsBackendFile, nil, CredentialsBackendFile},
+		{"auto without keyring", CredentialsBackendAuto, nil, CredentialsBackendFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ConfigDirEnvVar, t.TempDir())
+			t.Setenv(CredentialsBackendEnvVar, tt.backend)
+			if tt.keyring != nil {
+				useKeyring(t, tt.keyring)
+			}
+
+			if HasAppCredentials() {
+				t.Fatal("HasAppCredentials() before saving")
+			}
+			if err := SaveCredentials(user); err != nil {
+				t.Fatalf("SaveCredentials() error = %v", err)
+			}
+			if err := SaveAppCredentials(app); err != nil {
+				t.Fatalf("SaveAppCredentials() error = %v", err)
+			}
+
+			loaded, err := LoadAppCredentials()
+			if err != nil || loaded.AccessToken != "app-token" {
+				t.Fatalf("LoadAppCredentials() = %+v, %v", loaded, err)
+			}
+			if got := StoredAppCredentialsBackend(); got != tt.want {
+				t.Errorf("StoredAppCredentialsBackend() = %q, want %q", got, tt.want)
+			}
+			if loaded, err := LoadCredentials(); err != nil || loaded.AccessToken != "user-token" {
+				t.Errorf("LoadCredentials() = %+v, %v; the user token was overwritten", loaded, err)
+			}
+
+			if err := DeleteAppCredentials(); err != nil {
+				t.Fatalf("DeleteAppCredentials() error = %v", err)
+			}
+			if HasAppCredentials() {
+				t.Error("HasAppCredentials() after DeleteAppCredentials()")
+			}
+			if _, err := LoadCredentials(); err != nil {
+				t.Errorf("LoadCredentials() error = %v after DeleteAppCredentials()", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// BundleFormat identifies encrypted bundle files
+	BundleFormat = "t42-bundle"
+
+	// BundleVersion is the current bundle format version
+	BundleVersion = 1
+
+	// passphraseKDF names the key derivation function recorded in
+	// bundles and encrypted credentials
+	passphraseKDF = "pbkdf2-sha256"
+
+	// MinPassphraseLength is the shortest passphrase accepted for export
+	MinPassphraseLength = 8
+)
+
+// passphraseIterations is the PBKDF2 iteration count used for new bundles
+// and encrypted credentials (a variable so tests can lower it)
+var passphraseIterations = 600000
+
+// maxPassphraseIterations bounds the iteration count read from a file, ten
+// times the one t42 writes, so a crafted file cannot hang the derivation
+const maxPassphraseIterations = 6000000
+
+// ErrWrongPassphrase is returned when a bundle cannot be decrypted, which
+// is almost always caused by a mistyped passphrase
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted bundle")
+
+// Bundle is a portable snapshot of the credentials and settings of one
+// machine, used to move a login to another machine
+type Bundle struct {
+	ExportedAt  time.Time    `json:"exported_at"`
+	Credentials *Credentials `json:"credentials"`
+	ConfigYAML  string       `json:"config_yaml,omitempty"` // raw config.yaml contents
+	SecretsEnv  string       `json:"secrets_env,omitempty"` // raw secrets.env contents
+}
+
+// passphraseEnvelope is the on-disk envelope of data encrypted with a
+// passphrase: an encrypted Bundle or encrypted credentials
+type passphraseEnvelope struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// NewBundle snapshots the current credentials, config file and, when
+// includeSecrets is set, the OAuth2 client secrets file
+func NewBundle(includeSecrets bool) (*Bundle, error) {
+	credentials, err := LoadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		ExportedAt:  time.Now().UTC(),
+		Credentials: credentials,
+	}
+
+	configPath, err := GetConfigFilePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config file path: %w", err)
+	}
+	if data, err := os.ReadFile(configPath); err == nil {
+		bundle.ConfigYAML = string(data)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if includeSecrets {
+		secretsPath, err := GetSecretsFilePath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secrets file path: %w", err)
+		}
+		if data, err := os.ReadFile(secretsPath); err == nil {
+			bundle.SecretsEnv = string(data)
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read secrets file: %w", err)
+		}
+	}
+
+	return bundle, nil
+}
+
+// Apply writes the bundle's credentials and files to this machine's config
+// directory, replacing existing ones
+func (b *Bundle) Apply() error {
+	if b.Credentials == nil || b.Credentials.AccessToken == "" {
+		return fmt.Errorf("bundle does not contain credentials")
+	}
+
+	if err := SaveCredentials(b.Credentials); err != nil {
+		return err
+	}
+
+	if b.ConfigYAML != "" {
+		configPath, err := GetConfigFilePath()
+		if err != nil {
+			return fmt.Errorf("failed to get config file path: %w", err)
+		}
+		if err := os.WriteFile(configPath, []byte(b.ConfigYAML), 0600); err != nil {
+			return fmt.Errorf("failed to write config file: %w", err)
+		}
+	}
+
+	if b.SecretsEnv != "" {
+		secretsPath, err := GetSecretsFilePath()
+		if err != nil {
+			return fmt.Errorf("failed to get secrets file path: %w", err)
+		}
+		if err := os.WriteFile(secretsPath, []byte(b.SecretsEnv), 0600); err != nil {
+			return fmt.Errorf("failed to write secrets file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CommandSettings lists the settings of the bundle's config file that run
+// commands through the shell, such as secret_command, so an import can
+// point them out
+func (b *Bundle) CommandSettings() []string {
+	if b.ConfigYAML == "" {
+		return nil
+	}
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(b.ConfigYAML), &cfg); err != nil {
+		return nil
+	}
+	var settings []string
+	if cfg.SecretCommand.ClientID != "" {
+		settings = append(settings, "secret_command.client_id: "+cfg.SecretCommand.ClientID)
+	}
+	if cfg.SecretCommand.ClientSecret != "" {
+		settings = append(settings, "secret_command.client_secret: "+cfg.SecretCommand.ClientSecret)
+	}
+	if cfg.PostCloneHook != "" {
+		settings = append(settings, "post_clone_hook: "+cfg.PostCloneHook)
+	}
+	return settings
+}
+
+// LoginBackup holds what Bundle.Apply replaces, so that an import whose
+// credentials turn out to be unusable can be undone
+type LoginBackup struct {
+	credentials *Credentials      // nil when none could be loaded
+	files       map[string][]byte // contents by path, nil when missing
+	credsPath   string            // the credentials file among files
+}
+
+// BackupLogin saves the current credentials, config file and secrets file
+func BackupLogin() (*LoginBackup, error) {
+	backup := &LoginBackup{files: make(map[string][]byte)}
+	if credentials, err := LoadCredentials(); err == nil {
+		backup.credentials = credentials
+	}
+
+	for _, getPath := range []func() (string, error){GetConfigFilePath, GetSecretsFilePath, GetCredentialsFilePath} {
+		path, err := getPath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get path to back up: %w", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		backup.files[path] = data
+		backup.credsPath = path // the credentials file comes last
+	}
+	return backup, nil
+}
+
+// Restore puts the backed up login back: the config and secrets files
+// first, so the credentials go to the backend they came from, then the
+// credentials and their file as they were
+func (b *LoginBackup) Restore() error {
+	for path, data := range b.files {
+		if path != b.credsPath {
+			if err := restoreFile(path, data); err != nil {
+				return err
+			}
+		}
+	}
+	if b.credentials != nil {
+		if err := SaveCredentials(b.credentials); err != nil {
+			return err
+		}
+	} else if err := DeleteCredentials(); err != nil {
+		return err
+	}
+	return restoreFile(b.credsPath, b.files[b.credsPath])
+}
+
+// restoreFile writes data back to path, or removes path when data is nil
+func restoreFile(path string, data []byte) error {
+	if data == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to restore %s: %w", path, err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", path, err)
+	}
+	return nil
+}
+
+// EncryptBundle serializes and encrypts a bundle with AES-256-GCM using a
+// key derived from passphrase
+func EncryptBundle(bundle *Bundle, passphrase string) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLength {
+		return nil, fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLength)
+	}
+
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	envelope := passphraseEnvelope{
+		Format:     BundleFormat,
+		Version:    BundleVersion,
+		KDF:        passphraseKDF,
+		Iterations: passphraseIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(envelope.Salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := passphraseCipher(passphrase, envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(envelope.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	envelope.Ciphertext = gcm.Seal(nil, envelope.Nonce, plaintext, bundleAAD(envelope.Version))
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal encrypted bundle: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// DecryptBundle decrypts a bundle produced by EncryptBundle
+func DecryptBundle(data []byte, passphrase string) (*Bundle, error) {
+	var envelope passphraseEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Format != BundleFormat {
+		return nil, fmt.Errorf("not a t42 bundle")
+	}
+	if envelope.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d", envelope.Version)
+	}
+	if err := envelope.checkKDF("bundle"); err != nil {
+		return nil, err
+	}
+
+	gcm, err := passphraseCipher(passphrase, envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Nonce) != gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+
+	plaintext, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, bundleAAD(envelope.Version))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(plaintext, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	return &bundle, nil
+}
+
+// checkKDF refuses key derivations t42 does not write, and iteration
+// counts above maxPassphraseIterations; what names the encrypted data
+func (e *passphraseEnvelope) checkKDF(what string) error {
+	if e.KDF != passphraseKDF || e.Iterations <= 0 {
+		return fmt.Errorf("unsupported %s key derivation %q", what, e.KDF)
+	}
+	if e.Iterations > maxPassphraseIterations {
+		return fmt.Errorf("%s key derivation asks for %d iterations, more than the %d allowed", what, e.Iterations, maxPassphraseIterations)
+	}
+	return nil
+}
+
+// passphraseCipher derives a key from passphrase and returns an AES-GCM
+// cipher for it
+func passphraseCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+// bundleAAD binds the ciphertext to the bundle format and version
+func bundleAAD(version int) []byte {
+	return []byte(fmt.Sprintf("%s/v%d", BundleFormat, version))
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBundleEncryption(t *testing.T) {
+	// Keep key derivation cheap in tests
+	original := passphraseIterations
+	passphraseIterations = 1000
+	defer func() { passphraseIterations = original }()
+
+	bundle := &Bundle{
+		ExportedAt: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+		Credentials: &Credentials{
+			AccessToken:  "access",
+			RefreshToken: "refresh",
+			ExpiresIn:    7200,
+			CreatedAt:    1700000000,
+		},
+		ConfigYAML: "default_format: json\n",
+		SecretsEnv: "FT_UID=uid\nFT_SECRET=secret\n",
+	}
+
+	encrypted, err := EncryptBundle(bundle, "correct horse")
+	if err != nil {
+		t.Fatalf("EncryptBundle() error = %v", err)
+	}
+	if strings.Contains(string(encrypted), "refresh") || strings.Contains(string(encrypted), "FT_SECRET") {
+		t.Fatal("encrypted bundle contains plaintext secrets")
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		wantErr    error
+	}{
+		{name: "correct passphrase", data: encrypted, passphrase: "correct horse"},
+		{name: "wrong passphrase", data: encrypted, passphrase: "wrong horse", wantErr: ErrWrongPassphrase},
+		{name: "not a bundle", data: []byte(`{"access_token":"x"}`), passphrase: "correct horse", wantErr: errors.New("not a t42 bundle")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecryptBundle(tt.data, tt.passphrase)
+			if tt.wantErr != nil {
+				if err == nil || (errors.Is(tt.wantErr, ErrWrongPassphrase) && !errors.Is(err, ErrWrongPassphrase)) {
+					t.Fatalf("DecryptBundle() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecryptBundle() error = %v", err)
+			}
+			if *got.Credentials != *bundle.Credentials || got.ConfigYAML != bundle.ConfigYAML ||
+				got.SecretsEnv != bundle.SecretsEnv || !got.ExportedAt.Equal(bundle.ExportedAt) {
+				t.Errorf("DecryptBundle() = %+v, want %+v", got, bundle)
+			}
+		})
+	}
+
+	t.Run("iteration count capped", func(t *testing.T) {
+		var envelope map[string]interface{}
+		if err := json.Unmarshal(encrypted, &envelope); err != nil {
+			t.Fatal(err)
+		}
+		envelope["iterations"] = 1 << 31
+		crafted, err := json.Marshal(envelope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = DecryptBundle(crafted, "correct horse")
+		if err == nil || !strings.Contains(err.Error(), "iterations") {
+			t.Errorf("DecryptBundle() error = %v, want the iteration count refused", err)
+		}
+	})
+
+	t.Run("short passphrase rejected", func(t *testing.T) {
+		if _, err := EncryptBundle(bundle, "short"); err == nil {
+			t.Error("EncryptBundle() with short passphrase should error")
+		}
+	})
+}
+
+func TestLoginBackup(t *testing.T) {
+	useBackupDir := func(t *testing.T) {
+		t.Setenv(ConfigDirEnvVar, t.TempDir())
+		t.Setenv(CredentialsBackendEnvVar, CredentialsBackendFile)
+	}
+	imported := &Bundle{
+		Credentials: &Credentials{AccessToken: "revoked", RefreshToken: "revoked"},
+		ConfigYAML:  "default_format: yaml\n",
+		SecretsEnv:  "FT_UID=other\n",
+	}
+
+	t.Run("restores the previous login", func(t *testing.T) {
+		useBackupDir(t)
+		previous := &Credentials{AccessToken: "working", RefreshToken: "refresh"}
+		if err := SaveCredentials(previous); err != nil {
+			t.Fatal(err)
+		}
+		cfg := DefaultConfig()
+		cfg.DefaultFormat = "json"
+		if err := SaveConfig(cfg); err != nil {
+			t.Fatal(err)
+		}
+
+		backup, err := BackupLogin()
+		if err != nil {
+			t.Fatalf("BackupLogin() error = %v", err)
+		}
+		if err := imported.Apply(); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if err := backup.Restore(); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+
+		if got, err := LoadCredentials(); err != nil || got.AccessToken != "working" {
+			t.Errorf("credentials after Restore() = %+v, %v; want the previous ones", got, err)
+		}
+		if got, err := LoadConfig(); err != nil || got.DefaultFormat != "json" {
+			t.Errorf("config after Restore() = %+v, %v; want the previous one", got, err)
+		}
+		secretsPath, _ := GetSecretsFilePath()
+		if _, err := os.Stat(secretsPath); !os.IsNotExist(err) {
+			t.Errorf("imported secrets file left behind: %v", err)
+		}
+	})
+
+	t.Run("removes credentials there were none of", func(t *testing.T) {
+		useBackupDir(t)
+		backup, err := BackupLogin()
+		if err != nil {
+			t.Fatalf("BackupLogin() error = %v", err)
+		}
+		if err := imported.Apply(); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if err := backup.Restore(); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+		if got, err := LoadCredentials(); err == nil {
+			t.Errorf("credentials after Restore() = %+v, want none", got)
+		}
+	})
+}
+
+func TestBundleCommandSettings(t *testing.T) {
+	bundle := &Bundle{ConfigYAML: "secret_command:\n  client_secret: pass show 42/secret\npost_clone_hook: make setup\n"}
+	got := bundle.CommandSettings()
+	want := []string{"secret_command.client_secret: pass show 42/secret", "post_clone_hook: make setup"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("CommandSettings() = %q, want %q", got, want)
+	}
+	if got := (&Bundle{ConfigYAML: "default_format: json\n"}).CommandSettings(); len(got) != 0 {
+		t.Errorf("CommandSettings() = %q, want none", got)
+	}
+}
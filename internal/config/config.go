@@ -2,12 +2,15 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/joho/godotenv"
 	"gopkg.in/yaml.v3"
+
+	"github.com/naokiiida/t42-cli/internal/keyring"
 )
 
 // Credentials represents the OAuth2 token response from 42 API
@@ -19,15 +22,122 @@ type Credentials struct {
 	Scope            string `json:"scope"`
 	CreatedAt        int64  `json:"created_at"`
 	SecretValidUntil int64  `json:"secret_valid_until,omitempty"`
+	// ClockSkew is how many seconds the API server clock was ahead of the
+	// local clock when the token was issued (negative when behind)
+	ClockSkew int64 `json:"clock_skew,omitempty"`
+	// RequestedScope is the scope asked for at login, to tell when the
+	// token was granted less
+	RequestedScope string `json:"requested_scope,omitempty"`
 }
 
 // Config represents user preferences and settings
 type Config struct {
-	DefaultFormat string `yaml:"default_format,omitempty"` // "table" or "json"
+	DefaultFormat string `yaml:"default_format,omitempty"` // "table", "json", "yaml", "csv" or "tsv"
 	Interactive   bool   `yaml:"interactive"`              // Enable interactive prompts
 	APIBaseURL    string `yaml:"api_base_url,omitempty"`   // Custom API base URL
+
+	// Pager is the command long output such as guides is piped through
+	// in a terminal, instead of $PAGER; "cat" turns paging off
+	Pager string `yaml:"pager,omitempty"`
+
+	// DefaultCampus is the campus (name, city, ID or alias) and
+	// DefaultCursusID the cursus that user list, user eligible, event list
+	// and location list use when not given one
+	DefaultCampus   string `yaml:"default_campus,omitempty"`
+	DefaultCursusID int    `yaml:"default_cursus_id,omitempty"`
+
+	// SharedRateLimit coordinates the request budget between concurrently
+	// running t42 processes through a lock-file token bucket in the state dir
+	SharedRateLimit bool `yaml:"shared_rate_limit,omitempty"`
+
+	// RateLimit overrides the request quota the client throttles itself to,
+	// for applications granted more than the default 2/s and 1200/h
+	RateLimit RateLimit `yaml:"rate_limit,omitempty"`
+
+	// CampusAliases maps short names to a campus name, city or ID,
+	// accepted anywhere a --campus flag is (e.g. home: tokyo)
+	CampusAliases map[string]string `yaml:"campus_aliases,omitempty"`
+
+	// ProjectAliases maps shorthands to project slugs (e.g. gnl: get_next_line);
+	// they take precedence over the built-in shorthands
+	ProjectAliases map[string]string `yaml:"project_aliases,omitempty"`
+
+	// Aliases map command names to the t42 arguments they expand to
+	// (e.g. bh: user list --blackhole-status upcoming), see 't42 alias'
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+
+	// SecretCommand sources the OAuth2 client ID and secret from commands
+	// (e.g. a password manager) instead of a secrets file
+	SecretCommand SecretCommand `yaml:"secret_command,omitempty"`
+
+	// CacheTTL overrides how long cached data of a resource is reused,
+	// as Go durations (e.g. campus: 48h, profile: 1m)
+	CacheTTL map[string]string `yaml:"cache_ttl,omitempty"`
+
+	// CredentialsBackend selects where OAuth2 tokens are stored: "keyring",
+	// "file", or "auto" (keyring when available, file otherwise)
+	CredentialsBackend string `yaml:"credentials_backend,omitempty"`
+
+	// PostCloneHook is a shell command run in a fresh clone by project clone
+	// and clone-mine; it gets the path and slug as T42_CLONE_PATH and
+	// T42_PROJECT_SLUG (and as $1 and $2 on Unix)
+	PostCloneHook string `yaml:"post_clone_hook,omitempty"`
+
+	// OutputProfiles are named sets of output preferences selected with
+	// --profile-output (e.g. script: {json: true, no_color: true})
+	OutputProfiles map[string]OutputProfile `yaml:"profiles,omitempty"`
+
+	// UsageStats turns on the local count of the commands and flags you
+	// use (see 't42 stats usage'); nothing is sent over the network
+	UsageStats bool `yaml:"usage_stats,omitempty"`
+
+	// LogtimeGoal is the weekly logtime 't42 presence' measures progress
+	// against, as a Go duration (e.g. 30h)
+	LogtimeGoal string `yaml:"logtime_goal,omitempty"`
+
+	// ReadOnly refuses every request and command that would change data
+	// on the intra, for tokens used by dashboards and bots
+	ReadOnly bool `yaml:"readonly,omitempty"`
+
+	// EncryptCredentials encrypts the stored credentials with a passphrase
+	// (from T42_PASSPHRASE or a prompt), for machines with shared admins
+	EncryptCredentials bool `yaml:"encrypt_credentials,omitempty"`
+
+	// Notify sets the thresholds and channels of 't42 notify daemon'
+	Notify NotifySettings `yaml:"notify,omitempty"`
 }
 
+// NotifySettings configure 't42 notify daemon'. Zero fields keep the
+// defaults, and the daemon's flags override them.
+type NotifySettings struct {
+	Interval         string `yaml:"interval,omitempty"`          // time between checks, as a Go duration (e.g. 5m)
+	BlackholeDays    int    `yaml:"blackhole_days,omitempty"`    // remind when the blackhole is this close
+	EvaluationWithin string `yaml:"evaluation_within,omitempty"` // remind of evaluations starting within this duration
+	Desktop          *bool  `yaml:"desktop,omitempty"`           // desktop notifications, on by default
+	Webhook          string `yaml:"webhook,omitempty"`           // also post reminders to this URL
+	WebhookFormat    string `yaml:"webhook_format,omitempty"`    // "auto", "slack", "discord" or "json"
+}
+
+// RateLimit is a request quota; zero fields keep the API defaults
+type RateLimit struct {
+	PerSecond float64 `yaml:"per_second,omitempty"`
+	PerHour   int     `yaml:"per_hour,omitempty"`
+}
+
+// OutputProfile is a named set of output preferences. Unset fields leave
+// the corresponding flag at its default.
+type OutputProfile struct {
+	Format     string `yaml:"format,omitempty"` // "table", "json", "yaml", "csv" or "tsv"
+	JSON       *bool  `yaml:"json,omitempty"`
+	NoColor    *bool  `yaml:"no_color,omitempty"`
+	Verbose    *bool  `yaml:"verbose,omitempty"`
+	Accessible *bool  `yaml:"accessible,omitempty"`
+}
+
+// DefaultRedirectURL is the local callback the OAuth2 client is registered
+// with unless REDIRECT_URL says otherwise
+const DefaultRedirectURL = "http://127.0.0.1:8080/callback"
+
 // DevelopmentSecrets represents the development environment variables
 type DevelopmentSecrets struct {
 	ClientID     string
@@ -35,6 +145,21 @@ type DevelopmentSecrets struct {
 	RedirectURL  string
 }
 
+// RedirectURLFromEnv returns REDIRECT_URL, or DefaultRedirectURL when unset
+func RedirectURLFromEnv() string {
+	if redirectURL := os.Getenv("REDIRECT_URL"); redirectURL != "" {
+		return redirectURL
+	}
+	return DefaultRedirectURL
+}
+
+// IsPublic reports whether the client has no secret: a public client,
+// such as one embedded in a distributed binary, proves itself with PKCE
+// alone
+func (s *DevelopmentSecrets) IsPublic() bool {
+	return s.ClientSecret == ""
+}
+
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
@@ -44,8 +169,147 @@ func DefaultConfig() *Config {
 	}
 }
 
-// LoadCredentials loads the OAuth2 credentials from the credentials file
+// LoadCredentials loads the OAuth2 credentials from the configured backend:
+// the system keyring, the credentials file, or (by default) whichever of
+// the two holds them
 func LoadCredentials() (*Credentials, error) {
+	credentials, _, err := loadCredentials()
+	return credentials, err
+}
+
+// SaveCredentials saves the OAuth2 credentials to the configured backend.
+// In "auto" mode they go to the system keyring and fall back to the
+// credentials file when no keyring is available. With encrypt_credentials
+// they are encrypted first, except in a shared-machine session.
+func SaveCredentials(credentials *Credentials) error {
+	data, err := json.MarshalIndent(credentials, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials to JSON: %w", err)
+	}
+
+	backend, err := GetCredentialsBackend()
+	if err != nil {
+		return err
+	}
+	if backend != CredentialsBackendSession {
+		encrypt, err := CredentialsEncryptionEnabled()
+		if err != nil {
+			return err
+		}
+		if encrypt {
+			if data, err = encryptCredentials(data); err != nil {
+				return fmt.Errorf("failed to encrypt credentials: %w", err)
+			}
+		}
+	}
+	switch backend {
+	case CredentialsBackendFile:
+		return saveCredentialsFile(data)
+	case CredentialsBackendSession:
+		session, err := ActiveSession()
+		if err != nil {
+			return err
+		}
+		return session.save(data)
+	}
+
+	account, err := keyringAccount()
+	if err != nil {
+		return err
+	}
+	keyringErr := systemKeyring.Set(keyringService, account, string(data))
+	if keyringErr == nil {
+		// Don't leave a stale plaintext copy behind
+		return deleteCredentialsFile()
+	}
+	if backend == CredentialsBackendKeyring {
+		return fmt.Errorf("failed to save credentials to the system keyring: %w", keyringErr)
+	}
+
+	// Auto: fall back to the file, dropping any older keyring entry so it
+	// cannot shadow the new credentials
+	_ = systemKeyring.Delete(keyringService, account)
+	return saveCredentialsFile(data)
+}
+
+// DeleteCredentials removes the credentials from the system keyring and the
+// credentials file. In a shared-machine session it wipes the session's
+// credentials instead, keeping the session.
+func DeleteCredentials() error {
+	backend, err := GetCredentialsBackend()
+	if err != nil {
+		return err
+	}
+	if backend == CredentialsBackendSession {
+		session, err := ActiveSession()
+		if err != nil {
+			return err
+		}
+		if _, err := session.State(); errors.Is(err, ErrSessionEnded) {
+			return nil
+		}
+		return session.Start()
+	}
+	if backend != CredentialsBackendFile {
+		account, err := keyringAccount()
+		if err != nil {
+			return err
+		}
+		err = systemKeyring.Delete(keyringService, account)
+		if err != nil && !errors.Is(err, keyring.ErrNotFound) && !errors.Is(err, keyring.ErrUnavailable) {
+			return fmt.Errorf("failed to delete credentials from the system keyring: %w", err)
+		}
+	}
+	return deleteCredentialsFile()
+}
+
+// StoredCredentialsBackend returns where the current credentials are stored,
+// "keyring", "file" or "session", or "" when there are none
+func StoredCredentialsBackend() string {
+	_, backend, err := loadCredentials()
+	if err != nil {
+		return ""
+	}
+	return backend
+}
+
+func loadCredentials() (*Credentials, string, error) {
+	backend, err := GetCredentialsBackend()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if backend == CredentialsBackendSession {
+		session, err := ActiveSession()
+		if err != nil {
+			return nil, "", err
+		}
+		credentials, err := session.load()
+		return credentials, CredentialsBackendSession, err
+	}
+
+	if backend != CredentialsBackendFile {
+		account, err := keyringAccount()
+		if err != nil {
+			return nil, "", err
+		}
+		secret, err := systemKeyring.Get(keyringService, account)
+		switch {
+		case err == nil:
+			credentials, err := parseCredentials([]byte(secret))
+			return credentials, CredentialsBackendKeyring, err
+		case backend == CredentialsBackendKeyring && errors.Is(err, keyring.ErrNotFound):
+			return nil, "", fmt.Errorf("credentials not found in the system keyring")
+		case backend == CredentialsBackendKeyring:
+			return nil, "", fmt.Errorf("failed to read credentials from the system keyring: %w", err)
+		}
+	}
+
+	credentials, err := loadCredentialsFile()
+	return credentials, CredentialsBackendFile, err
+}
+
+func loadCredentialsFile() (*Credentials, error) {
 	credentialsPath, err := GetCredentialsFilePath()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials file path: %w", err)
@@ -62,17 +326,25 @@ func LoadCredentials() (*Credentials, error) {
 		return nil, fmt.Errorf("failed to read credentials file: %w", err)
 	}
 
-	// Parse JSON
+	return parseCredentials(data)
+}
+
+func parseCredentials(data []byte) (*Credentials, error) {
+	if isEncryptedCredentials(data) {
+		var err error
+		if data, err = decryptCredentials(data); err != nil {
+			return nil, err
+		}
+	}
+
 	var credentials Credentials
 	if err := json.Unmarshal(data, &credentials); err != nil {
 		return nil, fmt.Errorf("failed to parse credentials JSON: %w", err)
 	}
-
 	return &credentials, nil
 }
 
-// SaveCredentials saves the OAuth2 credentials to the credentials file with secure permissions
-func SaveCredentials(credentials *Credentials) error {
+func saveCredentialsFile(data []byte) error {
 	// Ensure config directory exists
 	if err := EnsureConfigDir(); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
@@ -83,12 +355,6 @@ func SaveCredentials(credentials *Credentials) error {
 		return fmt.Errorf("failed to get credentials file path: %w", err)
 	}
 
-	// Marshal to JSON with proper indentation
-	data, err := json.MarshalIndent(credentials, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal credentials to JSON: %w", err)
-	}
-
 	// Write file with secure permissions (0600 = read/write for user only)
 	if err := os.WriteFile(credentialsPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write credentials file: %w", err)
@@ -97,8 +363,7 @@ func SaveCredentials(credentials *Credentials) error {
 	return nil
 }
 
-// DeleteCredentials removes the credentials file
-func DeleteCredentials() error {
+func deleteCredentialsFile() error {
 	credentialsPath, err := GetCredentialsFilePath()
 	if err != nil {
 		return fmt.Errorf("failed to get credentials file path: %w", err)
@@ -198,7 +463,7 @@ func LoadDevelopmentSecrets() (*DevelopmentSecrets, error) {
 	secrets := &DevelopmentSecrets{
 		ClientID:     os.Getenv("FT_UID"),
 		ClientSecret: os.Getenv("FT_SECRET"),
-		RedirectURL:  os.Getenv("REDIRECT_URL"),
+		RedirectURL:  RedirectURLFromEnv(),
 	}
 
 	// Validate required fields
@@ -209,11 +474,6 @@ func LoadDevelopmentSecrets() (*DevelopmentSecrets, error) {
 		return nil, fmt.Errorf("FT_SECRET not found in %s", envPath)
 	}
 
-	// RedirectURL is optional for some flows
-	if secrets.RedirectURL == "" {
-		secrets.RedirectURL = "http://127.0.0.1:8080/callback"
-	}
-
 	return secrets, nil
 }
 
@@ -238,7 +498,7 @@ func LoadSecretsFromConfigDir() (*DevelopmentSecrets, error) {
 	secrets := &DevelopmentSecrets{
 		ClientID:     os.Getenv("FT_UID"),
 		ClientSecret: os.Getenv("FT_SECRET"),
-		RedirectURL:  os.Getenv("REDIRECT_URL"),
+		RedirectURL:  RedirectURLFromEnv(),
 	}
 
 	// Validate required fields
@@ -249,11 +509,6 @@ func LoadSecretsFromConfigDir() (*DevelopmentSecrets, error) {
 		return nil, fmt.Errorf("FT_SECRET not found in %s", secretsPath)
 	}
 
-	// RedirectURL is optional for some flows
-	if secrets.RedirectURL == "" {
-		secrets.RedirectURL = "http://127.0.0.1:8080/callback"
-	}
-
 	return secrets, nil
 }
 
@@ -290,9 +545,11 @@ func IsTokenValid(credentials *Credentials) bool {
 	return expiresAt.After(time.Now().Add(1 * time.Minute))
 }
 
-// GetTokenExpiryTime returns when the token will expire
+// GetTokenExpiryTime returns when the token will expire, by the local clock.
+// CreatedAt is server time, so the recorded clock skew is taken out.
 func GetTokenExpiryTime(credentials *Credentials) time.Time {
-	return time.Unix(credentials.CreatedAt, 0).Add(time.Duration(credentials.ExpiresIn) * time.Second)
+	expiresAt := time.Unix(credentials.CreatedAt, 0).Add(time.Duration(credentials.ExpiresIn) * time.Second)
+	return expiresAt.Add(-time.Duration(credentials.ClockSkew) * time.Second)
 }
 
 // NeedsRefresh checks if the token should be refreshed (expires in less than 5 minutes)
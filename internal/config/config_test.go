@@ -52,6 +52,20 @@ func TestGetConfigDir(t *testing.T) {
 			t.Errorf("GetConfigDir() should end with %v, got %v", AppName, configDir)
 		}
 	})
+
+	// Test explicit override, which takes precedence over development mode
+	t.Run("config dir override", func(t *testing.T) {
+		t.Setenv("T42_ENV", "development")
+		t.Setenv(ConfigDirEnvVar, "/tmp/t42-override")
+
+		configDir, err := GetConfigDir()
+		if err != nil {
+			t.Fatalf("GetConfigDir() error = %v", err)
+		}
+		if configDir != "/tmp/t42-override" {
+			t.Errorf("GetConfigDir() = %v, want /tmp/t42-override", configDir)
+		}
+	})
 }
 
 func TestGetConfigFilePath(t *testing.T) {
@@ -616,3 +630,36 @@ FT_SECRET=test_client_secret`
 		}
 	})
 }
+
+func TestTokenExpiryWithClockSkew(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		createdAt time.Time // server time
+		skew      time.Duration
+		wantValid bool
+	}{
+		{name: "no skew", createdAt: now, wantValid: true},
+		{name: "local clock two hours ahead", createdAt: now.Add(-2 * time.Hour), skew: -2 * time.Hour, wantValid: true},
+		{name: "local clock two hours behind", createdAt: now.Add(2 * time.Hour), skew: 2 * time.Hour, wantValid: true},
+		{name: "expired despite skew", createdAt: now.Add(-4 * time.Hour), skew: -time.Hour, wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credentials := &Credentials{
+				AccessToken: "token",
+				ExpiresIn:   7200,
+				CreatedAt:   tt.createdAt.Unix(),
+				ClockSkew:   int64(tt.skew.Seconds()),
+			}
+			if got := IsTokenValid(credentials); got != tt.wantValid {
+				t.Errorf("IsTokenValid() = %v, want %v", got, tt.wantValid)
+			}
+			if tt.wantValid && NeedsRefresh(credentials) {
+				t.Error("NeedsRefresh() = true for a fresh token")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/naokiiida/t42-cli/internal/keyring"
+)
+
+const (
+	// CredentialsBackendAuto stores credentials in the system keyring when
+	// one is available and in the credentials file otherwise
+	CredentialsBackendAuto = "auto"
+
+	// CredentialsBackendKeyring always uses the system keyring
+	CredentialsBackendKeyring = "keyring"
+
+	// CredentialsBackendFile always uses the plaintext credentials file
+	CredentialsBackendFile = "file"
+
+	// CredentialsBackendEnvVar overrides the credentials_backend setting
+	CredentialsBackendEnvVar = "T42_CREDENTIALS_BACKEND"
+
+	// keyringService is the service name of keyring entries
+	keyringService = AppName
+)
+
+// systemKeyring is the keyring used by the keyring backend; tests replace it
+var systemKeyring keyring.Keyring = keyring.System()
+
+// GetCredentialsBackend returns the configured credentials backend.
+// An active shared-machine session (T42_SESSION) always uses the session
+// backend; otherwise T42_CREDENTIALS_BACKEND takes precedence over
+// credentials_backend in the config file, and the default is "auto".
+func GetCredentialsBackend() (string, error) {
+	if os.Getenv(SessionEnvVar) != "" {
+		return CredentialsBackendSession, nil
+	}
+
+	backend := os.Getenv(CredentialsBackendEnvVar)
+	source := CredentialsBackendEnvVar
+	if backend == "" {
+		cfg, err := LoadConfig()
+		if err != nil {
+			return "", err
+		}
+		backend = cfg.CredentialsBackend
+		source = "credentials_backend"
+	}
+
+	switch backend {
+	case "":
+		return CredentialsBackendAuto, nil
+	case CredentialsBackendAuto, CredentialsBackendKeyring, CredentialsBackendFile:
+		return backend, nil
+	}
+	return "", fmt.Errorf("invalid %s %q (use auto, keyring or file)", source, backend)
+}
+
+// keyringAccount names the keyring entry after the config directory, so
+// separate config directories (T42_CONFIG_DIR) keep separate credentials
+func keyringAccount() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return dir, nil
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/naokiiida/t42-cli/internal/keyring"
+)
+
+// unavailableKeyring behaves like a system without a keyring
+type unavailableKeyring struct{}
+
+func (unavailableKeyring) Get(service, account string) (string, error) {
+	return "", keyring.ErrUnavailable
+}
+func (unavailableKeyring) Set(service, account, secret string) error { return keyring.ErrUnavailable }
+func (unavailableKeyring) Delete(service, account string) error      { return keyring.ErrUnavailable }
+
+func TestMain(m *testing.M) {
+	// Never touch the developer's real keyring; tests that exercise the
+	// keyring backend swap in an in-memory one
+	systemKeyring = unavailableKeyring{}
+	os.Exit(m.Run())
+}
+
+func useKeyring(t *testing.T, k keyring.Keyring) {
+	t.Helper()
+	previous := systemKeyring
+	systemKeyring = k
+	t.Cleanup(func() { systemKeyring = previous })
+}
+
+func credentialsFileExists(t *testing.T) bool {
+	t.Helper()
+	path, err := GetCredentialsFilePath()
+	if err != nil {
+		t.Fatalf("GetCredentialsFilePath() error = %v", err)
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+func TestGetCredentialsBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		config  string
+		want    string
+		wantErr bool
+	}{
+		{"default", "", "", CredentialsBackendAuto, false},
+		{"config", "", "file", CredentialsBackendFile, false},
+		{"env overrides config", "keyring", "file", CredentialsBackendKeyring, false},
+		{"invalid env", "vault", "", "", true},
+		{"invalid config", "", "plaintext", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ConfigDirEnvVar, t.TempDir())
+			t.Setenv(CredentialsBackendEnvVar, tt.env)
+			if tt.config != "" {
+				if err := SaveConfig(&Config{CredentialsBackend: tt.config}); err != nil {
+					t.Fatalf("SaveConfig() error = %v", err)
+				}
+			}
+
+			got, err := GetCredentialsBackend()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetCredentialsBackend() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetCredentialsBackend() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCredentialsBackends(t *testing.T) {
+	creds := &Credentials{AccessToken: "token", TokenType: "bearer", ExpiresIn: 7200}
+
+	t.Run("auto prefers the keyring and drops the file", func(t *testing.T) {
+		t.Setenv(ConfigDirEnvVar, t.TempDir())
+		t.Setenv(CredentialsBackendEnvVar, "")
+		useKeyring(t, keyring.NewMemory())
+
+		if err := saveCredentialsFile([]byte(`{"access_token":"old"}`)); err != nil {
+			t.Fatalf("saveCredentialsFile() error = %v", err)
+		}
+		if err := SaveCredentials(creds); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		if credentialsFileExists(t) {
+			t.Error("plaintext credentials file was kept after saving to the keyring")
+		}
+		loaded, err := LoadCredentials()
+		if err != nil || loaded.AccessToken != "token" {
+			t.Fatalf("LoadCredentials() = %+v, %v", loaded, err)
+		}
+		if got := StoredCredentialsBackend(); got != CredentialsBackendKeyring {
+			t.Errorf("StoredCredentialsBackend() = %q, want keyring", got)
+		}
+
+		if err := DeleteCredentials(); err != nil {
+			t.Fatalf("DeleteCredentials() error = %v", err)
+		}
+		if _, err := LoadCredentials(); err == nil {
+			t.Error("LoadCredentials() succeeded after DeleteCredentials()")
+		}
+	})
+
+	t.Run("auto falls back to the file", func(t *testing.T) {
+		t.Setenv(ConfigDirEnvVar, t.TempDir())
+		t.Setenv(CredentialsBackendEnvVar, "")
+
+		if err := SaveCredentials(creds); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		if !credentialsFileExists(t) {
+			t.Error("credentials file not written without a keyring")
+		}
+		if got := StoredCredentialsBackend(); got != CredentialsBackendFile {
+			t.Errorf("StoredCredentialsBackend() = %q, want file", got)
+		}
+	})
+
+	t.Run("file backend ignores the keyring", func(t *testing.T) {
+		t.Setenv(ConfigDirEnvVar, t.TempDir())
+		t.Setenv(CredentialsBackendEnvVar, CredentialsBackendFile)
+		memory := keyring.NewMemory()
+		useKeyring(t, memory)
+
+		if err := SaveCredentials(creds); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		account, _ := keyringAccount()
+		if _, err := memory.Get(keyringService, account); err == nil {
+			t.Error("file backend wrote to the keyring")
+		}
+		if !credentialsFileExists(t) {
+			t.Error("credentials file not written")
+		}
+	})
+
+	t.Run("keyring backend requires a keyring", func(t *testing.T) {
+		t.Setenv(ConfigDirEnvVar, t.TempDir())
+		t.Setenv(CredentialsBackendEnvVar, CredentialsBackendKeyring)
+
+		if err := SaveCredentials(creds); err == nil {
+			t.Error("SaveCredentials() succeeded without a keyring")
+		}
+		if credentialsFileExists(t) {
+			t.Error("keyring backend fell back to the file")
+		}
+	})
+
+	t.Run("config directories keep separate entries", func(t *testing.T) {
+		t.Setenv(CredentialsBackendEnvVar, CredentialsBackendKeyring)
+		useKeyring(t, keyring.NewMemory())
+
+		t.Setenv(ConfigDirEnvVar, t.TempDir())
+		if err := SaveCredentials(creds); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		t.Setenv(ConfigDirEnvVar, t.TempDir())
+		if _, err := LoadCredentials(); err == nil {
+			t.Error("credentials leaked into another config directory")
+		}
+	})
+}
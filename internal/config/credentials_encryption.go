@@ -0,0 +1,173 @@
+package config
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	// CredentialsFormat identifies encrypted credentials
+	CredentialsFormat = "t42-credentials"
+
+	// credentialsVersion is the current encrypted credentials version
+	credentialsVersion = 1
+
+	// PassphraseEnvVar supplies the passphrase of encrypted credentials
+	// without a prompt, e.g. for scheduled jobs
+	PassphraseEnvVar = "T42_PASSPHRASE"
+)
+
+// ErrPassphraseRequired is returned when encrypted credentials are needed
+// but there is no passphrase to decrypt them with
+var ErrPassphraseRequired = errors.New("credentials are encrypted - set " + PassphraseEnvVar + " or run t42 in a terminal to enter the passphrase")
+
+// ErrWrongCredentialsPassphrase is returned when encrypted credentials
+// cannot be decrypted with the passphrase given
+var ErrWrongCredentialsPassphrase = errors.New("wrong passphrase for the encrypted credentials")
+
+// PassphrasePrompt asks for the passphrase of encrypted credentials when
+// T42_PASSPHRASE is not set; nil (the default) means no one can be asked
+var PassphrasePrompt func() (string, error)
+
+// credentialsKey is the key of the encrypted credentials, derived at most
+// once per process: saving refreshed credentials reuses it
+var credentialsKey *passphraseKey
+
+// passphraseKey is a key derived from a passphrase, with the salt and
+// iteration count it was derived with
+type passphraseKey struct {
+	salt       []byte
+	iterations int
+	gcm        cipher.AEAD
+}
+
+// CredentialsEncryptionEnabled reports whether credentials are saved
+// encrypted (encrypt_credentials in the config file)
+func CredentialsEncryptionEnabled() (bool, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return false, err
+	}
+	return cfg.EncryptCredentials, nil
+}
+
+// SetCredentialsPassphrase sets the passphrase the credentials are
+// encrypted with from now on, with a new salt
+func SetCredentialsPassphrase(passphrase string) error {
+	if len(passphrase) < MinPassphraseLength {
+		return fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLength)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	gcm, err := passphraseCipher(passphrase, salt, passphraseIterations)
+	if err != nil {
+		return err
+	}
+	credentialsKey = &passphraseKey{salt: salt, iterations: passphraseIterations, gcm: gcm}
+	return nil
+}
+
+// credentialsPassphrase returns the passphrase from T42_PASSPHRASE or the
+// prompt
+func credentialsPassphrase() (string, error) {
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	if PassphrasePrompt == nil {
+		return "", ErrPassphraseRequired
+	}
+	return PassphrasePrompt()
+}
+
+// isEncryptedCredentials reports whether stored credentials data is
+// encrypted rather than plain JSON credentials
+func isEncryptedCredentials(data []byte) bool {
+	if !bytes.Contains(data, []byte(CredentialsFormat)) {
+		return false
+	}
+	var envelope passphraseEnvelope
+	return json.Unmarshal(data, &envelope) == nil && envelope.Format == CredentialsFormat
+}
+
+// encryptCredentials encrypts serialized credentials with the current
+// key, asking for the passphrase when there is none yet
+func encryptCredentials(plaintext []byte) ([]byte, error) {
+	if credentialsKey == nil {
+		passphrase, err := credentialsPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		if err := SetCredentialsPassphrase(passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	envelope := passphraseEnvelope{
+		Format:     CredentialsFormat,
+		Version:    credentialsVersion,
+		KDF:        passphraseKDF,
+		Iterations: credentialsKey.iterations,
+		Salt:       credentialsKey.salt,
+		Nonce:      make([]byte, credentialsKey.gcm.NonceSize()),
+	}
+	if _, err := rand.Read(envelope.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	envelope.Ciphertext = credentialsKey.gcm.Seal(nil, envelope.Nonce, plaintext, credentialsAAD(envelope.Version))
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal encrypted credentials: %w", err)
+	}
+	return data, nil
+}
+
+// decryptCredentials decrypts credentials encrypted by encryptCredentials,
+// asking for the passphrase unless the key is already known
+func decryptCredentials(data []byte) ([]byte, error) {
+	var envelope passphraseEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Format != CredentialsFormat {
+		return nil, fmt.Errorf("not encrypted t42 credentials")
+	}
+	if envelope.Version != credentialsVersion {
+		return nil, fmt.Errorf("unsupported encrypted credentials version %d", envelope.Version)
+	}
+	if err := envelope.checkKDF("credentials"); err != nil {
+		return nil, err
+	}
+
+	key := credentialsKey
+	if key == nil || key.iterations != envelope.Iterations || !bytes.Equal(key.salt, envelope.Salt) {
+		passphrase, err := credentialsPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		gcm, err := passphraseCipher(passphrase, envelope.Salt, envelope.Iterations)
+		if err != nil {
+			return nil, err
+		}
+		key = &passphraseKey{salt: envelope.Salt, iterations: envelope.Iterations, gcm: gcm}
+	}
+	if len(envelope.Nonce) != key.gcm.NonceSize() {
+		return nil, ErrWrongCredentialsPassphrase
+	}
+
+	plaintext, err := key.gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, credentialsAAD(envelope.Version))
+	if err != nil {
+		return nil, ErrWrongCredentialsPassphrase
+	}
+	credentialsKey = key
+	return plaintext, nil
+}
+
+// credentialsAAD binds the ciphertext to the credentials format and version
+func credentialsAAD(version int) []byte {
+	return []byte(fmt.Sprintf("%s/v%d", CredentialsFormat, version))
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// useCredentialsEncryption turns on encrypt_credentials in a fresh config
+// directory, with cheap key derivation and no cached key
+func useCredentialsEncryption(t *testing.T) {
+	t.Helper()
+	t.Setenv(ConfigDirEnvVar, t.TempDir())
+	t.Setenv(CredentialsBackendEnvVar, CredentialsBackendFile)
+
+	originalIterations, originalKey, originalPrompt := passphraseIterations, credentialsKey, PassphrasePrompt
+	passphraseIterations = 1000
+	credentialsKey = nil
+	PassphrasePrompt = nil
+	t.Cleanup(func() {
+		passphraseIterations, credentialsKey, PassphrasePrompt = originalIterations, originalKey, originalPrompt
+	})
+
+	cfg := DefaultConfig()
+	cfg.EncryptCredentials = true
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+}
+
+func TestEncryptedCredentials(t *testing.T) {
+	credentials := &Credentials{AccessToken: "access-secret", RefreshToken: "refresh-secret", ExpiresIn: 7200}
+
+	t.Run("round trip", func(t *testing.T) {
+		useCredentialsEncryption(t)
+		t.Setenv(PassphraseEnvVar, "correct horse")
+
+		if err := SaveCredentials(credentials); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		path, _ := GetCredentialsFilePath()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "access-secret") || !strings.Contains(string(data), CredentialsFormat) {
+			t.Fatalf("credentials file is not encrypted:\n%s", data)
+		}
+
+		// A new process has no key yet
+		credentialsKey = nil
+		loaded, err := LoadCredentials()
+		if err != nil {
+			t.Fatalf("LoadCredentials() error = %v", err)
+		}
+		if loaded.AccessToken != "access-secret" || loaded.RefreshToken != "refresh-secret" {
+			t.Errorf("LoadCredentials() = %+v", loaded)
+		}
+	})
+
+	t.Run("key reused after decryption", func(t *testing.T) {
+		useCredentialsEncryption(t)
+		prompts := 0
+		PassphrasePrompt = func() (string, error) {
+			prompts++
+			return "correct horse", nil
+		}
+
+		if err := SaveCredentials(credentials); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		credentialsKey = nil
+		if _, err := LoadCredentials(); err != nil {
+			t.Fatalf("LoadCredentials() error = %v", err)
+		}
+		// Saving refreshed credentials and loading them again asks no more
+		if err := SaveCredentials(credentials); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		if _, err := LoadCredentials(); err != nil {
+			t.Fatalf("LoadCredentials() error = %v", err)
+		}
+		if prompts != 2 {
+			t.Errorf("asked for the passphrase %d times, want 2 (once per process)", prompts)
+		}
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		useCredentialsEncryption(t)
+		t.Setenv(PassphraseEnvVar, "correct horse")
+		if err := SaveCredentials(credentials); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+
+		credentialsKey = nil
+		t.Setenv(PassphraseEnvVar, "battery staple")
+		if _, err := LoadCredentials(); !errors.Is(err, ErrWrongCredentialsPassphrase) {
+			t.Errorf("LoadCredentials() error = %v, want ErrWrongCredentialsPassphrase", err)
+		}
+	})
+
+	t.Run("iteration count capped", func(t *testing.T) {
+		useCredentialsEncryption(t)
+		t.Setenv(PassphraseEnvVar, "correct horse")
+		if err := SaveCredentials(credentials); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+
+		path, _ := GetCredentialsFilePath()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var envelope map[string]interface{}
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			t.Fatal(err)
+		}
+		envelope["iterations"] = 1 << 31
+		if data, err = json.Marshal(envelope); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		credentialsKey = nil
+		if _, err := LoadCredentials(); err == nil || !strings.Contains(err.Error(), "iterations") {
+			t.Errorf("LoadCredentials() error = %v, want the iteration count refused", err)
+		}
+	})
+
+	t.Run("no passphrase", func(t *testing.T) {
+		useCredentialsEncryption(t)
+		t.Setenv(PassphraseEnvVar, "")
+		if err := SaveCredentials(credentials); !errors.Is(err, ErrPassphraseRequired) {
+			t.Errorf("SaveCredentials() error = %v, want ErrPassphraseRequired", err)
+		}
+	})
+
+	t.Run("plain credentials still load", func(t *testing.T) {
+		useCredentialsEncryption(t)
+		cfg := DefaultConfig()
+		if err := SaveConfig(cfg); err != nil {
+			t.Fatal(err)
+		}
+		if err := SaveCredentials(credentials); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		cfg.EncryptCredentials = true
+		if err := SaveConfig(cfg); err != nil {
+			t.Fatal(err)
+		}
+		if loaded, err := LoadCredentials(); err != nil || loaded.AccessToken != "access-secret" {
+			t.Errorf("LoadCredentials() = %+v, %v", loaded, err)
+		}
+	})
+
+	t.Run("short passphrase", func(t *testing.T) {
+		useCredentialsEncryption(t)
+		if err := SetCredentialsPassphrase("short"); err == nil {
+			t.Error("SetCredentialsPassphrase() accepted a short passphrase")
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Entry is a single value of the config file under its dotted key, such as
+// "rate_limit.per_hour" or "campus_aliases.home"
+type Entry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Keys lists the dotted keys of the config file holding a single value, in
+// the order of the file. Maps are listed as "<key>.*".
+func Keys() []string {
+	var keys []string
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			name := yamlName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			ft := t.Field(i).Type
+			switch {
+			case ft.Kind() == reflect.Struct:
+				walk(ft, prefix+name+".")
+			case ft.Kind() == reflect.Map && ft.Elem().Kind() == reflect.Struct:
+				walk(ft.Elem(), prefix+name+".*.")
+			case ft.Kind() == reflect.Map:
+				keys = append(keys, prefix+name+".*")
+			default:
+				keys = append(keys, prefix+name)
+			}
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+	return keys
+}
+
+// Get returns the value of a key as written in the config file. A key
+// holding several values, such as "rate_limit", returns them all.
+func (c *Config) Get(key string) ([]Entry, error) {
+	v, err := lookupKey(reflect.ValueOf(c).Elem(), splitKey(key), key)
+	if err != nil {
+		return nil, err
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if isScalar(v.Type()) {
+		return []Entry{{Key: key, Value: formatValue(v)}}, nil
+	}
+	return flatten(v, key+"."), nil
+}
+
+// Entries returns the values set in the config, in the order of the file
+// and maps sorted by key
+func (c *Config) Entries() []Entry {
+	return flatten(reflect.ValueOf(c).Elem(), "")
+}
+
+// Set parses value into the type of a key and sets it
+func (c *Config) Set(key, value string) error {
+	return updateKey(reflect.ValueOf(c).Elem(), splitKey(key), key, func(v reflect.Value) error {
+		if !isScalar(v.Type()) {
+			return fmt.Errorf("%s holds several values; set one of its keys", key)
+		}
+		return parseValue(v, value, key)
+	})
+}
+
+// Unset clears a key, so its default applies again
+func (c *Config) Unset(key string) error {
+	return updateKey(reflect.ValueOf(c).Elem(), splitKey(key), key, nil)
+}
+
+func splitKey(key string) []string {
+	return strings.Split(strings.TrimSpace(key), ".")
+}
+
+// yamlName returns the key of a struct field in the config file
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// structField returns the field of a struct value stored under name
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		if yamlName(v.Type().Field(i)) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// lookupKey returns the value at a key, or an invalid value for a map entry
+// that is not set
+func lookupKey(v reflect.Value, segments []string, key string) (reflect.Value, error) {
+	for _, s := range segments {
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := structField(v, s)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("unknown config key %q", key)
+			}
+			v = field
+		case reflect.Map:
+			v = v.MapIndex(reflect.ValueOf(s))
+			if !v.IsValid() {
+				return v, nil
+			}
+		default:
+			return reflect.Value{}, fmt.Errorf("unknown config key %q", key)
+		}
+	}
+	return v, nil
+}
+
+// updateKey calls set on the value at a key, or clears it when set is nil.
+// Map entries are created as needed, and deleted when cleared.
+func updateKey(v reflect.Value, segments []string, key string, set func(reflect.Value) error) error {
+	if len(segments) == 0 {
+		if set == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		return set(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		field, ok := structField(v, segments[0])
+		if !ok {
+			return fmt.Errorf("unknown config key %q", key)
+		}
+		return updateKey(field, segments[1:], key, set)
+	case reflect.Map:
+		if segments[0] == "" {
+			return fmt.Errorf("invalid config key %q", key)
+		}
+		name := reflect.ValueOf(segments[0])
+		if set == nil && len(segments) == 1 {
+			if v.Len() > 0 {
+				v.SetMapIndex(name, reflect.Value{})
+			}
+			return nil
+		}
+		// Map values are not addressable: update a copy and store it back
+		entry := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(name); existing.IsValid() {
+			entry.Set(existing)
+		} else if set == nil {
+			return nil
+		}
+		if err := updateKey(entry, segments[1:], key, set); err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		v.SetMapIndex(name, entry)
+		return nil
+	}
+	return fmt.Errorf("unknown config key %q", key)
+}
+
+// isScalar reports whether a config value is written as a single value
+func isScalar(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseValue parses s into a scalar config value
+func parseValue(v reflect.Value, s, key string) error {
+	if v.Kind() == reflect.Ptr {
+		p := reflect.New(v.Type().Elem())
+		if err := parseValue(p.Elem(), s, key); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false", key)
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("%s must be a whole number", key)
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be a number", key)
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+// formatValue formats a scalar config value as it is written in the file
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return v.String()
+}
+
+// flatten returns the set scalar values below v under their dotted keys
+func flatten(v reflect.Value, prefix string) []Entry {
+	var entries []Entry
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			name := yamlName(v.Type().Field(i))
+			if name == "" {
+				continue
+			}
+			field := v.Field(i)
+			if isScalar(field.Type()) {
+				if !field.IsZero() {
+					entries = append(entries, Entry{Key: prefix + name, Value: formatValue(field)})
+				}
+				continue
+			}
+			entries = append(entries, flatten(field, prefix+name+".")...)
+		}
+	case reflect.Map:
+		names := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			names = append(names, k.String())
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := v.MapIndex(reflect.ValueOf(name))
+			if isScalar(value.Type()) {
+				entries = append(entries, Entry{Key: prefix + name, Value: formatValue(value)})
+				continue
+			}
+			entries = append(entries, flatten(value, prefix+name+".")...)
+		}
+	}
+	return entries
+}
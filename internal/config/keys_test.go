@@ -0,0 +1,115 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigKeys(t *testing.T) {
+	t.Run("set and get", func(t *testing.T) {
+		tests := []struct {
+			key     string
+			value   string
+			want    []Entry
+			wantErr bool
+		}{
+			{key: "default_format", value: "yaml", want: []Entry{{Key: "default_format", Value: "yaml"}}},
+			{key: "pager", value: "less -R", want: []Entry{{Key: "pager", Value: "less -R"}}},
+			{key: "readonly", value: "true", want: []Entry{{Key: "readonly", Value: "true"}}},
+			{key: "readonly", value: "maybe", wantErr: true},
+			{key: "rate_limit.per_hour", value: "2400", want: []Entry{{Key: "rate_limit.per_hour", Value: "2400"}}},
+			{key: "rate_limit.per_second", value: "fast", wantErr: true},
+			{key: "campus_aliases.home", value: "tokyo", want: []Entry{{Key: "campus_aliases.home", Value: "tokyo"}}},
+			{key: "profiles.script.json", value: "true", want: []Entry{{Key: "profiles.script.json", Value: "true"}}},
+			{key: "notify.desktop", value: "false", want: []Entry{{Key: "notify.desktop", Value: "false"}}},
+			{key: "rate_limit", value: "3", wantErr: true},
+			{key: "no_such_key", value: "x", wantErr: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+				cfg := DefaultConfig()
+				err := cfg.Set(tt.key, tt.value)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+				got, err := cfg.Get(tt.key)
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Get() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("get several values", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.RateLimit = RateLimit{PerSecond: 4, PerHour: 2400}
+		got, err := cfg.Get("rate_limit")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Entry{{Key: "rate_limit.per_second", Value: "4"}, {Key: "rate_limit.per_hour", Value: "2400"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Get() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.CampusAliases = map[string]string{"home": "tokyo", "work": "paris"}
+		cfg.LogtimeGoal = "30h"
+		cfg.Pager = "less"
+		for _, key := range []string{"campus_aliases.home", "logtime_goal", "pager", "project_aliases.gnl", "profiles.script.json"} {
+			if err := cfg.Unset(key); err != nil {
+				t.Fatalf("Unset(%s) error = %v", key, err)
+			}
+		}
+		if _, ok := cfg.CampusAliases["home"]; ok || cfg.CampusAliases["work"] != "paris" {
+			t.Errorf("campus_aliases = %v, want only work", cfg.CampusAliases)
+		}
+		if cfg.LogtimeGoal != "" {
+			t.Errorf("logtime_goal = %q after Unset", cfg.LogtimeGoal)
+		}
+		if cfg.Pager != "" {
+			t.Errorf("pager = %q after Unset", cfg.Pager)
+		}
+		if cfg.OutputProfiles != nil {
+			t.Errorf("profiles = %v, want none created by Unset", cfg.OutputProfiles)
+		}
+	})
+
+	t.Run("entries", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.CampusAliases = map[string]string{"work": "paris", "home": "tokyo"}
+		got := cfg.Entries()
+		want := []Entry{
+			{Key: "default_format", Value: "table"},
+			{Key: "interactive", Value: "true"},
+			{Key: "api_base_url", Value: "https://api.intra.42.fr"},
+			{Key: "campus_aliases.home", Value: "tokyo"},
+			{Key: "campus_aliases.work", Value: "paris"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Entries() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		keys := Keys()
+		for _, want := range []string{"default_format", "pager", "rate_limit.per_hour", "campus_aliases.*", "profiles.*.format", "notify.webhook"} {
+			found := false
+			for _, k := range keys {
+				found = found || k == want
+			}
+			if !found {
+				t.Errorf("Keys() = %v, missing %s", keys, want)
+			}
+		}
+	})
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 )
 
 const (
@@ -15,6 +17,9 @@ const (
 	// CredentialsFileName is the name of the credentials file
 	CredentialsFileName = "credentials.json"
 
+	// AppCredentialsFileName is the name of the application token file
+	AppCredentialsFileName = "app_credentials.json"
+
 	// SecretsFileName is the name of the OAuth2 client secrets file
 	SecretsFileName = "secrets.env"
 
@@ -23,26 +28,147 @@ const (
 
 	// EnvFileName is the name of the environment file for development
 	EnvFileName = ".env"
+
+	// CacheDirName is the name of the cache subdirectory used in development mode
+	CacheDirName = "cache"
+
+	// StateDirName is the name of the state subdirectory for runtime data
+	// (rate limit buckets, checkpoints) that is neither config nor cache
+	StateDirName = "state"
+
+	// ExtensionsDirName is the name of the config subdirectory extensions
+	// are installed in
+	ExtensionsDirName = "extensions"
+
+	// ConfigDirEnvVar overrides the configuration directory
+	ConfigDirEnvVar = "T42_CONFIG_DIR"
 )
 
 // GetConfigDir returns the OS-specific configuration directory for the application.
-// If T42_ENV is set to "development", it returns the local secret directory.
+// T42_CONFIG_DIR overrides it; if T42_ENV is set to "development", it returns
+// the local secret directory.
 func GetConfigDir() (string, error) {
-	if os.Getenv("T42_ENV") == "development" {
+	return configDirFor(runtime.GOOS, os.Getenv)
+}
+
+// GetCacheDir returns the OS-specific cache directory for the application.
+// If T42_ENV is set to "development", it returns a cache directory inside the
+// local secret directory.
+func GetCacheDir() (string, error) {
+	return cacheDirFor(runtime.GOOS, os.Getenv)
+}
+
+// GetStateDir returns the directory for persistent runtime state.
+// It respects $XDG_STATE_HOME and defaults to ~/.local/state/t42 on Linux/BSD;
+// on macOS and Windows a "state" subdirectory of the config directory is used.
+func GetStateDir() (string, error) {
+	return stateDirFor(runtime.GOOS, os.Getenv)
+}
+
+// The *DirFor functions resolve directories for an explicit platform and
+// environment, so that the Windows and macOS layouts are tested on every
+// platform. They follow os.UserConfigDir and os.UserCacheDir.
+
+func configDirFor(goos string, getenv func(string) string) (string, error) {
+	if dir := getenv(ConfigDirEnvVar); dir != "" {
+		return dir, nil
+	}
+
+	if getenv("T42_ENV") == "development" {
 		// For development, use the local secret directory
 		return SecretDirName, nil
 	}
-	
-	// Get the OS-specific user config directory
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return "", err
+
+	var configDir string
+	switch goos {
+	case "windows":
+		// Roaming profile, e.g. C:\Users\<name>\AppData\Roaming
+		configDir = getenv("AppData")
+		if configDir == "" {
+			return "", errors.New("%AppData% is not defined")
+		}
+	case "darwin", "ios":
+		home := getenv("HOME")
+		if home == "" {
+			return "", errors.New("$HOME is not defined")
+		}
+		configDir = filepath.Join(home, "Library", "Application Support")
+	default:
+		configDir = getenv("XDG_CONFIG_HOME")
+		if configDir == "" {
+			home := getenv("HOME")
+			if home == "" {
+				return "", errors.New("neither $XDG_CONFIG_HOME nor $HOME are defined")
+			}
+			configDir = filepath.Join(home, ".config")
+		} else if !filepath.IsAbs(configDir) {
+			return "", errors.New("path in $XDG_CONFIG_HOME is relative")
+		}
 	}
-	
+
 	// Return the app-specific subdirectory
 	return filepath.Join(configDir, AppName), nil
 }
 
+func cacheDirFor(goos string, getenv func(string) string) (string, error) {
+	if getenv("T42_ENV") == "development" {
+		return filepath.Join(SecretDirName, CacheDirName), nil
+	}
+
+	var cacheDir string
+	switch goos {
+	case "windows":
+		// Local (non-roaming) profile: caches must not follow the user around
+		cacheDir = getenv("LocalAppData")
+		if cacheDir == "" {
+			return "", errors.New("%LocalAppData% is not defined")
+		}
+	case "darwin", "ios":
+		home := getenv("HOME")
+		if home == "" {
+			return "", errors.New("$HOME is not defined")
+		}
+		cacheDir = filepath.Join(home, "Library", "Caches")
+	default:
+		cacheDir = getenv("XDG_CACHE_HOME")
+		if cacheDir == "" {
+			home := getenv("HOME")
+			if home == "" {
+				return "", errors.New("neither $XDG_CACHE_HOME nor $HOME are defined")
+			}
+			cacheDir = filepath.Join(home, ".cache")
+		} else if !filepath.IsAbs(cacheDir) {
+			return "", errors.New("path in $XDG_CACHE_HOME is relative")
+		}
+	}
+
+	return filepath.Join(cacheDir, AppName), nil
+}
+
+func stateDirFor(goos string, getenv func(string) string) (string, error) {
+	if getenv("T42_ENV") == "development" {
+		return filepath.Join(SecretDirName, StateDirName), nil
+	}
+
+	if stateHome := getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, AppName), nil
+	}
+
+	if goos != "darwin" && goos != "windows" {
+		home := getenv("HOME")
+		if home == "" {
+			return "", errors.New("$HOME is not defined")
+		}
+		return filepath.Join(home, ".local", "state", AppName), nil
+	}
+
+	configDir, err := configDirFor(goos, getenv)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, StateDirName), nil
+}
+
 // GetConfigFilePath returns the full path to the user configuration file
 func GetConfigFilePath() (string, error) {
 	configDir, err := GetConfigDir()
@@ -52,6 +178,16 @@ func GetConfigFilePath() (string, error) {
 	return filepath.Join(configDir, ConfigFileName), nil
 }
 
+// GetExtensionsDir returns the directory 't42 extension install' clones
+// extensions into
+func GetExtensionsDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, ExtensionsDirName), nil
+}
+
 // GetCredentialsFilePath returns the full path to the credentials file
 func GetCredentialsFilePath() (string, error) {
 	configDir, err := GetConfigDir()
@@ -61,6 +197,16 @@ func GetCredentialsFilePath() (string, error) {
 	return filepath.Join(configDir, CredentialsFileName), nil
 }
 
+// GetAppCredentialsFilePath returns the full path to the application token
+// file
+func GetAppCredentialsFilePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, AppCredentialsFileName), nil
+}
+
 // GetSecretsFilePath returns the full path to the OAuth2 client secrets file
 // in the user's config directory (for deployed/production use)
 func GetSecretsFilePath() (string, error) {
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// envMap returns a getenv function backed by a map
+func envMap(env map[string]string) func(string) string {
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+func TestPlatformDirs(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		env       map[string]string
+		wantConf  string
+		wantCache string
+		wantState string
+	}{
+		{
+			name:      "windows uses APPDATA and LOCALAPPDATA",
+			goos:      "windows",
+			env:       map[string]string{"AppData": `C:\Users\me\AppData\Roaming`, "LocalAppData": `C:\Users\me\AppData\Local`},
+			wantConf:  filepath.Join(`C:\Users\me\AppData\Roaming`, AppName),
+			wantCache: filepath.Join(`C:\Users\me\AppData\Local`, AppName),
+			wantState: filepath.Join(`C:\Users\me\AppData\Roaming`, AppName, StateDirName),
+		},
+		{
+			name:      "macOS uses Library",
+			goos:      "darwin",
+			env:       map[string]string{"HOME": "/Users/me"},
+			wantConf:  filepath.Join("/Users/me", "Library", "Application Support", AppName),
+			wantCache: filepath.Join("/Users/me", "Library", "Caches", AppName),
+			wantState: filepath.Join("/Users/me", "Library", "Application Support", AppName, StateDirName),
+		},
+		{
+			name:      "linux defaults",
+			goos:      "linux",
+			env:       map[string]string{"HOME": "/home/me"},
+			wantConf:  filepath.Join("/home/me", ".config", AppName),
+			wantCache: filepath.Join("/home/me", ".cache", AppName),
+			wantState: filepath.Join("/home/me", ".local", "state", AppName),
+		},
+		{
+			name:      "linux XDG variables",
+			goos:      "linux",
+			env:       map[string]string{"HOME": "/home/me", "XDG_CONFIG_HOME": "/xdg/config", "XDG_CACHE_HOME": "/xdg/cache", "XDG_STATE_HOME": "/xdg/state"},
+			wantConf:  filepath.Join("/xdg/config", AppName),
+			wantCache: filepath.Join("/xdg/cache", AppName),
+			wantState: filepath.Join("/xdg/state", AppName),
+		},
+		{
+			name:      "development mode on windows",
+			goos:      "windows",
+			env:       map[string]string{"T42_ENV": "development"},
+			wantConf:  SecretDirName,
+			wantCache: filepath.Join(SecretDirName, CacheDirName),
+			wantState: filepath.Join(SecretDirName, StateDirName),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := envMap(tt.env)
+
+			if got, err := configDirFor(tt.goos, getenv); err != nil || got != tt.wantConf {
+				t.Errorf("configDirFor() = %q, %v, want %q", got, err, tt.wantConf)
+			}
+			if got, err := cacheDirFor(tt.goos, getenv); err != nil || got != tt.wantCache {
+				t.Errorf("cacheDirFor() = %q, %v, want %q", got, err, tt.wantCache)
+			}
+			if got, err := stateDirFor(tt.goos, getenv); err != nil || got != tt.wantState {
+				t.Errorf("stateDirFor() = %q, %v, want %q", got, err, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestPlatformDirsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+	}{
+		{"windows without APPDATA", "windows", map[string]string{}},
+		{"macOS without HOME", "darwin", map[string]string{}},
+		{"linux without HOME", "linux", map[string]string{}},
+		{"relative XDG_CONFIG_HOME", "linux", map[string]string{"HOME": "/home/me", "XDG_CONFIG_HOME": "config"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := configDirFor(tt.goos, envMap(tt.env)); err == nil {
+				t.Errorf("configDirFor() = %q, want an error", got)
+			}
+		})
+	}
+}
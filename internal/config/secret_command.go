@@ -0,0 +1,95 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// SecretCommandTimeout bounds each secret command; password managers may
+// prompt for a passphrase, so it is generous
+const SecretCommandTimeout = 2 * time.Minute
+
+// SecretCommand holds shell commands printing the OAuth2 client ID and
+// secret, so they can come from a password manager instead of a plaintext
+// file, e.g.:
+//
+//	secret_command:
+//	  client_id: pass show 42/uid
+//	  client_secret: op read op://Private/42/secret
+type SecretCommand struct {
+	ClientID     string `yaml:"client_id,omitempty"`
+	ClientSecret string `yaml:"client_secret,omitempty"`
+}
+
+// IsSet reports whether any secret command is configured
+func (c SecretCommand) IsSet() bool {
+	return c.ClientID != "" || c.ClientSecret != ""
+}
+
+// LoadSecretsFromCommand runs the configured commands and returns their
+// output as client secrets. The commands run only when called, i.e. when a
+// token is actually requested.
+func LoadSecretsFromCommand(ctx context.Context, command SecretCommand) (*DevelopmentSecrets, error) {
+	if command.ClientID == "" || command.ClientSecret == "" {
+		return nil, fmt.Errorf("secret_command needs both client_id and client_secret")
+	}
+
+	clientID, err := runSecretCommand(ctx, command.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("secret_command client_id: %w", err)
+	}
+	clientSecret, err := runSecretCommand(ctx, command.ClientSecret)
+	if err != nil {
+		return nil, fmt.Errorf("secret_command client_secret: %w", err)
+	}
+
+	return &DevelopmentSecrets{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  RedirectURLFromEnv(),
+	}, nil
+}
+
+// runSecretCommand runs command through the platform shell and returns the
+// first line of its output. Stdin and stderr stay attached to the terminal
+// so that tools like pass or op can prompt for unlocking.
+func runSecretCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, SecretCommandTimeout)
+	defer cancel()
+
+	name, args := shellCommand(runtime.GOOS, command)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("command timed out after %s", SecretCommandTimeout)
+		}
+		return "", fmt.Errorf("command failed: %w", err)
+	}
+
+	// pass stores metadata after the first line; only the first line is the secret
+	value, _, _ := strings.Cut(stdout.String(), "\n")
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("command printed nothing")
+	}
+	return value, nil
+}
+
+// shellCommand returns the shell invocation running command on goos
+func shellCommand(goos, command string) (string, []string) {
+	if goos == "windows" {
+		return "cmd", []string{"/C", command}
+	}
+	return "sh", []string{"-c", command}
+}
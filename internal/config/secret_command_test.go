@@ -0,0 +1,108 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestLoadSecretsFromCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands below use POSIX sh syntax")
+	}
+
+	tests := []struct {
+		name       string
+		command    SecretCommand
+		wantID     string
+		wantSecret string
+		wantErr    bool
+	}{
+		{
+			name:       "first line of output, trimmed",
+			command:    SecretCommand{ClientID: "echo uid-123", ClientSecret: "printf '  s3cret  \\nurl: https://intra\\n'"},
+			wantID:     "uid-123",
+			wantSecret: "s3cret",
+		},
+		{
+			name:    "failing command",
+			command: SecretCommand{ClientID: "echo uid", ClientSecret: "exit 3"},
+			wantErr: true,
+		},
+		{
+			name:    "empty output",
+			command: SecretCommand{ClientID: "true", ClientSecret: "echo secret"},
+			wantErr: true,
+		},
+		{
+			name:    "missing secret command",
+			command: SecretCommand{ClientID: "echo uid"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets, err := LoadSecretsFromCommand(context.Background(), tt.command)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("LoadSecretsFromCommand() = %+v, want an error", secrets)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadSecretsFromCommand() error = %v", err)
+			}
+			if secrets.ClientID != tt.wantID || secrets.ClientSecret != tt.wantSecret {
+				t.Errorf("got %q/%q, want %q/%q", secrets.ClientID, secrets.ClientSecret, tt.wantID, tt.wantSecret)
+			}
+		})
+	}
+}
+
+func TestLoadSecretsFromCommandRedirectURL(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands below use POSIX sh syntax")
+	}
+	command := SecretCommand{ClientID: "echo uid", ClientSecret: "echo secret"}
+
+	t.Setenv("REDIRECT_URL", "")
+	secrets, err := LoadSecretsFromCommand(context.Background(), command)
+	if err != nil {
+		t.Fatalf("LoadSecretsFromCommand() error = %v", err)
+	}
+	if secrets.RedirectURL != DefaultRedirectURL {
+		t.Errorf("RedirectURL = %q, want %q", secrets.RedirectURL, DefaultRedirectURL)
+	}
+
+	t.Setenv("REDIRECT_URL", "http://localhost:4242/cb")
+	secrets, err = LoadSecretsFromCommand(context.Background(), command)
+	if err != nil {
+		t.Fatalf("LoadSecretsFromCommand() error = %v", err)
+	}
+	if secrets.RedirectURL != "http://localhost:4242/cb" {
+		t.Errorf("RedirectURL = %q, want REDIRECT_URL", secrets.RedirectURL)
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	tests := []struct {
+		goos     string
+		wantName string
+		wantArgs []string
+	}{
+		{"linux", "sh", []string{"-c", "pass show 42/uid"}},
+		{"darwin", "sh", []string{"-c", "pass show 42/uid"}},
+		{"windows", "cmd", []string{"/C", "pass show 42/uid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args := shellCommand(tt.goos, "pass show 42/uid")
+			if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("shellCommand() = %s %v, want %s %v", name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
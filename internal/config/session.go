@@ -0,0 +1,212 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// CredentialsBackendSession keeps credentials in a session-scoped
+	// temporary file while a shared-machine session is active
+	CredentialsBackendSession = "session"
+
+	// SessionEnvVar holds the ID of the active shared-machine session
+	SessionEnvVar = "T42_SESSION"
+
+	// SessionIdleTimeoutEnvVar holds how long a shared-machine session may
+	// stay unused before its credentials are wiped, as a Go duration
+	SessionIdleTimeoutEnvVar = "T42_SESSION_IDLE_TIMEOUT"
+
+	// DefaultSessionIdleTimeout is the idle timeout when none is set
+	DefaultSessionIdleTimeout = 30 * time.Minute
+)
+
+// Session is a shared-machine session: credentials live in a temporary
+// file that is wiped when the shell exits or the session is left idle
+type Session struct {
+	ID string
+	// IdleTimeout is how long the credentials may go unused; 0 disables it
+	IdleTimeout time.Duration
+}
+
+// SessionState describes the credentials file of a session
+type SessionState struct {
+	Path     string
+	LastUsed time.Time
+	// LoggedIn reports whether the session holds credentials
+	LoggedIn bool
+}
+
+// ErrSessionEnded is returned for a session whose file has been removed
+var ErrSessionEnded = errors.New("the shared-machine session has ended")
+
+// NewSessionID returns a random session ID
+func NewSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ActiveSession returns the shared-machine session of the environment,
+// or nil when there is none
+func ActiveSession() (*Session, error) {
+	id := os.Getenv(SessionEnvVar)
+	if id == "" {
+		return nil, nil
+	}
+	if _, err := hex.DecodeString(id); err != nil || len(id) != 32 {
+		return nil, fmt.Errorf("invalid %s %q", SessionEnvVar, id)
+	}
+
+	session := &Session{ID: id, IdleTimeout: DefaultSessionIdleTimeout}
+	if value := os.Getenv(SessionIdleTimeoutEnvVar); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("invalid %s %q", SessionIdleTimeoutEnvVar, value)
+		}
+		session.IdleTimeout = timeout
+	}
+	return session, nil
+}
+
+// GetSessionDir returns the directory of session files: $XDG_RUNTIME_DIR,
+// which is in memory and removed at logout on most Linux systems, or a
+// private directory in the temporary directory otherwise
+func GetSessionDir() (string, error) {
+	var dir string
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		dir = filepath.Join(runtimeDir, AppName)
+	} else {
+		dir = filepath.Join(os.TempDir(), AppName+"-"+strconv.Itoa(os.Getuid()))
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create session directory: %w", err)
+	}
+	// The temporary directory is shared by every user of the machine:
+	// refuse a directory someone else could read or swap out
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to check session directory: %w", err)
+	}
+	if !info.IsDir() || info.Mode().Perm()&0077 != 0 {
+		return "", fmt.Errorf("session directory %s is not private to you", dir)
+	}
+	return dir, nil
+}
+
+// Path returns the credentials file of the session
+func (s *Session) Path() (string, error) {
+	dir, err := GetSessionDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, s.ID+".json"), nil
+}
+
+// Start creates the session's file, without credentials
+func (s *Session) Start() error {
+	return s.write([]byte("{}"))
+}
+
+// End wipes the session's credentials and removes its file
+func (s *Session) End() error {
+	path, err := s.Path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove session file: %w", err)
+	}
+	return nil
+}
+
+// State returns the state of the session's credentials file
+func (s *Session) State() (*SessionState, error) {
+	path, err := s.Path()
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, ErrSessionEnded
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+	// An empty session holds "{}"
+	return &SessionState{Path: path, LastUsed: info.ModTime(), LoggedIn: info.Size() > 2}, nil
+}
+
+// Idle reports whether the session has been unused for longer than its
+// idle timeout
+func (s *Session) Idle(state *SessionState, now time.Time) bool {
+	return s.IdleTimeout > 0 && now.Sub(state.LastUsed) > s.IdleTimeout
+}
+
+// ExpireIfIdle wipes the credentials of a session left idle for too long,
+// keeping the session itself. It reports whether they were wiped.
+func (s *Session) ExpireIfIdle(now time.Time) (bool, error) {
+	state, err := s.State()
+	if err != nil {
+		return false, err
+	}
+	if !state.LoggedIn || !s.Idle(state, now) {
+		return false, nil
+	}
+	return true, s.Start()
+}
+
+// save stores credentials in the session; an ended session is not revived
+func (s *Session) save(data []byte) error {
+	if _, err := s.State(); err != nil {
+		return err
+	}
+	return s.write(data)
+}
+
+// load reads the session's credentials and marks the session as used
+func (s *Session) load() (*Credentials, error) {
+	wiped, err := s.ExpireIfIdle(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if wiped {
+		return nil, fmt.Errorf("the shared-machine session was idle for more than %s and its credentials were wiped", s.IdleTimeout)
+	}
+
+	state, err := s.State()
+	if err != nil {
+		return nil, err
+	}
+	if !state.LoggedIn {
+		return nil, fmt.Errorf("not logged in in this shared-machine session")
+	}
+	data, err := os.ReadFile(state.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+	now := time.Now()
+	_ = os.Chtimes(state.Path, now, now)
+	return parseCredentials(data)
+}
+
+// write replaces the session's file, creating it private to the user
+func (s *Session) write(data []byte) error {
+	path, err := s.Path()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	return nil
+}
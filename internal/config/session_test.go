@@ -0,0 +1,170 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/keyring"
+)
+
+func TestActiveSession(t *testing.T) {
+	id := "0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name        string
+		id          string
+		idleTimeout string
+		want        *Session
+		wantErr     bool
+	}{
+		{name: "no session"},
+		{name: "default idle timeout", id: id, want: &Session{ID: id, IdleTimeout: DefaultSessionIdleTimeout}},
+		{name: "idle timeout", id: id, idleTimeout: "5m", want: &Session{ID: id, IdleTimeout: 5 * time.Minute}},
+		{name: "no idle timeout", id: id, idleTimeout: "0", want: &Session{ID: id}},
+		{name: "invalid id", id: "../credentials", wantErr: true},
+		{name: "invalid idle timeout", id: id, idleTimeout: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(SessionEnvVar, tt.id)
+			t.Setenv(SessionIdleTimeoutEnvVar, tt.idleTimeout)
+
+			got, err := ActiveSession()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ActiveSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ActiveSession() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// startTestSession starts a session in a temporary runtime directory
+func startTestSession(t *testing.T, idleTimeout string) *Session {
+	t.Helper()
+	t.Setenv(ConfigDirEnvVar, t.TempDir())
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	id, err := NewSessionID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(SessionEnvVar, id)
+	t.Setenv(SessionIdleTimeoutEnvVar, idleTimeout)
+
+	session, err := ActiveSession()
+	if err != nil {
+		t.Fatalf("ActiveSession() error = %v", err)
+	}
+	if err := session.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	return session
+}
+
+func TestSessionCredentials(t *testing.T) {
+	creds := &Credentials{AccessToken: "token", TokenType: "bearer", ExpiresIn: 7200}
+
+	t.Run("credentials stay in the session", func(t *testing.T) {
+		session := startTestSession(t, "")
+		memory := keyring.NewMemory()
+		useKeyring(t, memory)
+
+		if _, err := LoadCredentials(); err == nil {
+			t.Error("LoadCredentials() succeeded in a new session")
+		}
+		if err := SaveCredentials(creds); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		if credentialsFileExists(t) {
+			t.Error("session credentials were written to the credentials file")
+		}
+		account, _ := keyringAccount()
+		if _, err := memory.Get(keyringService, account); err == nil {
+			t.Error("session credentials were written to the keyring")
+		}
+		loaded, err := LoadCredentials()
+		if err != nil || loaded.AccessToken != "token" {
+			t.Fatalf("LoadCredentials() = %+v, %v", loaded, err)
+		}
+		if got := StoredCredentialsBackend(); got != CredentialsBackendSession {
+			t.Errorf("StoredCredentialsBackend() = %q, want session", got)
+		}
+
+		path, _ := session.Path()
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+			t.Errorf("session file mode = %v, %v, want 0600", info.Mode().Perm(), err)
+		}
+	})
+
+	t.Run("logout keeps the session", func(t *testing.T) {
+		session := startTestSession(t, "")
+		if err := SaveCredentials(creds); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		if err := DeleteCredentials(); err != nil {
+			t.Fatalf("DeleteCredentials() error = %v", err)
+		}
+		state, err := session.State()
+		if err != nil || state.LoggedIn {
+			t.Fatalf("State() = %+v, %v, want a session without credentials", state, err)
+		}
+		if err := SaveCredentials(creds); err != nil {
+			t.Errorf("SaveCredentials() after logout error = %v", err)
+		}
+	})
+
+	t.Run("an ended session is not revived", func(t *testing.T) {
+		session := startTestSession(t, "")
+		if err := session.End(); err != nil {
+			t.Fatalf("End() error = %v", err)
+		}
+		if err := SaveCredentials(creds); !errors.Is(err, ErrSessionEnded) {
+			t.Errorf("SaveCredentials() error = %v, want ErrSessionEnded", err)
+		}
+		if err := DeleteCredentials(); err != nil {
+			t.Errorf("DeleteCredentials() error = %v", err)
+		}
+	})
+
+	t.Run("idle credentials are wiped", func(t *testing.T) {
+		session := startTestSession(t, "10m")
+		if err := SaveCredentials(creds); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		path, _ := session.Path()
+		old := time.Now().Add(-11 * time.Minute)
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := LoadCredentials(); err == nil {
+			t.Error("LoadCredentials() succeeded after the idle timeout")
+		}
+		if state, err := session.State(); err != nil || state.LoggedIn {
+			t.Errorf("State() = %+v, %v, want a session without credentials", state, err)
+		}
+	})
+
+	t.Run("use keeps the session alive", func(t *testing.T) {
+		session := startTestSession(t, "10m")
+		if err := SaveCredentials(creds); err != nil {
+			t.Fatalf("SaveCredentials() error = %v", err)
+		}
+		path, _ := session.Path()
+		old := time.Now().Add(-9 * time.Minute)
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := LoadCredentials(); err != nil {
+			t.Fatalf("LoadCredentials() error = %v", err)
+		}
+		wiped, err := session.ExpireIfIdle(time.Now().Add(5 * time.Minute))
+		if err != nil || wiped {
+			t.Errorf("ExpireIfIdle() = %v, %v, want the session kept after use", wiped, err)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+// Package guides holds the long-form guides embedded in the binary, so
+// that the full reference is available offline with 't42 help guides'.
+package guides
+
+import (
+	"embed"
+	"sort"
+	"strings"
+)
+
+//go:embed *.md
+var files embed.FS
+
+// Guide is one embedded guide, written in Markdown
+type Guide struct {
+	// Name is the topic given to 't42 help guides'
+	Name string
+	// Title is the first heading of the guide
+	Title string
+	// Summary is the first paragraph of the guide
+	Summary string
+	// Content is the Markdown source of the guide
+	Content string
+}
+
+// List returns every guide, by name
+func List() []Guide {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		return nil
+	}
+	guides := make([]Guide, 0, len(entries))
+	for _, entry := range entries {
+		if guide, ok := Get(strings.TrimSuffix(entry.Name(), ".md")); ok {
+			guides = append(guides, guide)
+		}
+	}
+	sort.Slice(guides, func(i, j int) bool { return guides[i].Name < guides[j].Name })
+	return guides
+}
+
+// Get returns the guide of a topic
+func Get(name string) (Guide, bool) {
+	data, err := files.ReadFile(name + ".md")
+	if err != nil {
+		return Guide{}, false
+	}
+	guide := Guide{Name: name, Title: name, Content: string(data)}
+
+	var paragraph []string
+	for _, line := range strings.Split(guide.Content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "# ") && guide.Title == name:
+			guide.Title = strings.TrimPrefix(line, "# ")
+		case line == "" || strings.HasPrefix(line, "#"):
+			if len(paragraph) > 0 {
+				guide.Summary = strings.Join(paragraph, " ")
+				return guide, true
+			}
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	guide.Summary = strings.Join(paragraph, " ")
+	return guide, true
+}
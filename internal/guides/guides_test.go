@@ -0,0 +1,85 @@
+package guides
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	list := List()
+	want := []string{"auth", "quota", "scripting"}
+	if len(list) != len(want) {
+		t.Fatalf("List() returned %d guides, want %d", len(list), len(want))
+	}
+	for i, name := range want {
+		g := list[i]
+		if g.Name != name {
+			t.Errorf("List()[%d].Name = %q, want %q", i, g.Name, name)
+		}
+		if g.Title == "" || g.Title == g.Name || g.Summary == "" {
+			t.Errorf("guide %q has no title or summary: %+v", g.Name, g)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	g, ok := Get("quota")
+	if !ok {
+		t.Fatal("Get(quota) not found")
+	}
+	if g.Title != "Rate Limits and Quota" {
+		t.Errorf("Title = %q", g.Title)
+	}
+	if g.Summary != "How t42 spends the request budget of your 42 application." {
+		t.Errorf("Summary = %q", g.Summary)
+	}
+	if _, ok := Get("missing"); ok {
+		t.Error("Get(missing) found a guide")
+	}
+}
+
+const sample = "# Title\n\nIntro with `code` in it.\n\n## Section\n\n- first item\n  continued\n- second\n\n```\n.hidden -x\n```\n"
+
+func TestRender(t *testing.T) {
+	got := Render(sample, 80, false)
+	want := "TITLE\n\n  Intro with code in it.\n\nSection\n\n  • first item continued\n  • second\n\n    .hidden -x\n"
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderWraps(t *testing.T) {
+	got := Render(strings.Repeat("word ", 30), 40, false)
+	for _, line := range strings.Split(strings.TrimSpace(got), "\n") {
+		if len(line) > 40 {
+			t.Errorf("line longer than 40: %q", line)
+		}
+	}
+}
+
+func TestRoff(t *testing.T) {
+	got := Roff(sample)
+	want := ".PP\nIntro with \\fBcode\\fP in it.\n.SH SECTION\n.IP \\(bu 4\nfirst item\ncontinued\n.IP \\(bu 4\nsecond\n.PP\n.RS 4\n.nf\n\\&.hidden \\-x\n.fi\n.RE\n"
+	if got != want {
+		t.Errorf("Roff() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRoffEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"dashes", "--json", `\-\-json`},
+		{"backslash", `a\b`, `a\eb`},
+		{"leading dot", ".env\n'quoted", "\\&.env\n\\&'quoted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RoffEscape(tt.in); got != tt.want {
+				t.Errorf("RoffEscape(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
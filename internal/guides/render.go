@@ -0,0 +1,177 @@
+package guides
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true).Underline(true)
+	headingStyle = lipgloss.NewStyle().Bold(true)
+	codeStyle    = lipgloss.NewStyle().Faint(true)
+)
+
+// block is a run of Markdown lines rendered together
+type block struct {
+	kind  string // "title", "heading", "code", "item", "paragraph"
+	lines []string
+}
+
+// parse splits the small Markdown subset the guides use into blocks:
+// headings, fenced code, list items and paragraphs
+func parse(markdown string) []block {
+	var blocks []block
+	var current *block
+	flush := func() {
+		if current != nil {
+			blocks = append(blocks, *current)
+			current = nil
+		}
+	}
+
+	inCode := false
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			if !inCode {
+				current = &block{kind: "code"}
+			}
+			inCode = !inCode
+		case inCode:
+			current.lines = append(current.lines, line)
+		case strings.HasPrefix(trimmed, "# "):
+			flush()
+			blocks = append(blocks, block{kind: "title", lines: []string{strings.TrimPrefix(trimmed, "# ")}})
+		case strings.HasPrefix(trimmed, "## "):
+			flush()
+			blocks = append(blocks, block{kind: "heading", lines: []string{strings.TrimPrefix(trimmed, "## ")}})
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "- ") || isNumbered(trimmed):
+			flush()
+			current = &block{kind: "item", lines: []string{trimmed}}
+		default:
+			if current == nil {
+				current = &block{kind: "paragraph"}
+			}
+			current.lines = append(current.lines, trimmed)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// replaceCode replaces the backticks around inline code with open and
+// close
+func replaceCode(text, open, close string) string {
+	parts := strings.Split(text, "`")
+	if len(parts)%2 == 0 {
+		return text
+	}
+	var b strings.Builder
+	for i, part := range parts {
+		if i%2 == 1 {
+			part = open + part + close
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// isNumbered reports whether a line starts a numbered list item
+func isNumbered(line string) bool {
+	digits := len(line) - len(strings.TrimLeft(line, "0123456789"))
+	return digits > 0 && strings.HasPrefix(line[digits:], ". ")
+}
+
+// Render formats a guide for the terminal, wrapped to width. When styled
+// is set, glamour renders it; without it, and in nogui builds, it is
+// written as text with the headings and code styled when styled is set.
+func Render(markdown string, width int, styled bool) string {
+	if styled {
+		if out, err := renderStyled(markdown, width); err == nil {
+			return out
+		}
+	}
+	return renderText(markdown, width, styled)
+}
+
+// renderText formats the Markdown subset the guides use as text, wrapped
+// to width
+func renderText(markdown string, width int, styled bool) string {
+	style := func(s lipgloss.Style, text string) string {
+		if !styled {
+			return text
+		}
+		return s.Render(text)
+	}
+
+	var b strings.Builder
+	previous := ""
+	for _, blk := range parse(markdown) {
+		// List items follow each other without a blank line
+		if previous != "" && !(previous == "item" && blk.kind == "item") {
+			b.WriteString("\n")
+		}
+		previous = blk.kind
+		switch blk.kind {
+		case "title":
+			b.WriteString(style(titleStyle, strings.ToUpper(blk.lines[0])) + "\n")
+		case "heading":
+			b.WriteString(style(headingStyle, blk.lines[0]) + "\n")
+		case "code":
+			for _, line := range blk.lines {
+				b.WriteString("    " + style(codeStyle, line) + "\n")
+			}
+		case "item":
+			marker, text, _ := strings.Cut(blk.lines[0], " ")
+			if marker == "-" {
+				marker = "•"
+			}
+			text = replaceCode(strings.Join(append([]string{text}, blk.lines[1:]...), " "), "", "")
+			b.WriteString(indent(wrap(text, width-4), "  "+marker+" ", "    ") + "\n")
+		default:
+			text := replaceCode(strings.Join(blk.lines, " "), "", "")
+			b.WriteString(indent(wrap(text, width-2), "  ", "  ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// wrap breaks text into lines of at most width characters, except for
+// words longer than that
+func wrap(text string, width int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) > width:
+			lines = append(lines, line)
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// indent joins lines, prefixing the first one with first and the others
+// with rest
+func indent(lines []string, first, rest string) string {
+	for i := range lines {
+		if i == 0 {
+			lines[i] = first + lines[i]
+		} else {
+			lines[i] = rest + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
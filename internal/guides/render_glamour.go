@@ -0,0 +1,20 @@
+//go:build !nogui
+
+package guides
+
+import (
+	"github.com/charmbracelet/glamour"
+)
+
+// renderStyled renders Markdown for the terminal with glamour, in the
+// style matching its background
+func renderStyled(markdown string, width int) (string, error) {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithAutoStyle(),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return "", err
+	}
+	return renderer.Render(markdown)
+}
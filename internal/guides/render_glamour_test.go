@@ -0,0 +1,35 @@
+//go:build !nogui
+
+package guides
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderStyled(t *testing.T) {
+	markdown := "# Limits\n\n| Budget | Requests |\n|---|---|\n| per second | 2 |\n\n- first\n  - nested\n"
+	got := Render(markdown, 60, true)
+
+	var row, first, nested string
+	for _, line := range strings.Split(got, "\n") {
+		switch {
+		case strings.Contains(line, "per second"):
+			row = line
+		case strings.Contains(line, "first"):
+			first = line
+		case strings.Contains(line, "nested"):
+			nested = line
+		}
+		if len([]rune(line)) > 60 {
+			t.Errorf("line longer than 60: %q", line)
+		}
+	}
+	if !strings.Contains(row, "|") || !strings.Contains(row, "2") {
+		t.Errorf("table row not rendered as a row: %q", row)
+	}
+	indentOf := func(s string) int { return len(s) - len(strings.TrimLeft(s, " ")) }
+	if first == "" || nested == "" || indentOf(nested) <= indentOf(first) {
+		t.Errorf("nested item not indented under its parent:\n%q\n%q", first, nested)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build nogui
+
+package guides
+
+import "errors"
+
+// renderStyled is unavailable without the interactive stack, so guides
+// are written as text
+func renderStyled(markdown string, width int) (string, error) {
+	return "", errors.New("styled rendering is not built in")
+}
@@ -0,0 +1,11 @@
+//go:build nogui
+
+package guides
+
+import "testing"
+
+func TestRenderStyledFallsBackToText(t *testing.T) {
+	if got, want := Render(sample, 80, true), renderText(sample, 80, true); got != want {
+		t.Errorf("Render() =\n%s\nwant the text rendering\n%s", got, want)
+	}
+}
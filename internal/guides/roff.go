@@ -0,0 +1,51 @@
+package guides
+
+import (
+	"strings"
+)
+
+// RoffEscape escapes text for a roff document: backslashes, and dots and
+// quotes at the start of a line, which roff reads as requests
+func RoffEscape(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\e`)
+	text = strings.ReplaceAll(text, "-", `\-`)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Roff converts a guide to the body of a man page. The title is left out,
+// since man pages carry it in their header.
+func Roff(markdown string) string {
+	var b strings.Builder
+	for _, blk := range parse(markdown) {
+		switch blk.kind {
+		case "title":
+			continue
+		case "heading":
+			b.WriteString(".SH " + RoffEscape(strings.ToUpper(blk.lines[0])) + "\n")
+		case "code":
+			b.WriteString(".PP\n.RS 4\n.nf\n")
+			for _, line := range blk.lines {
+				b.WriteString(RoffEscape(line) + "\n")
+			}
+			b.WriteString(".fi\n.RE\n")
+		case "item":
+			marker, text, _ := strings.Cut(blk.lines[0], " ")
+			if marker == "-" {
+				marker = `\(bu`
+			}
+			b.WriteString(".IP " + marker + " 4\n")
+			text = RoffEscape(strings.Join(append([]string{text}, blk.lines[1:]...), "\n"))
+			b.WriteString(replaceCode(text, `\fB`, `\fP`) + "\n")
+		default:
+			text := RoffEscape(strings.Join(blk.lines, "\n"))
+			b.WriteString(".PP\n" + replaceCode(text, `\fB`, `\fP`) + "\n")
+		}
+	}
+	return b.String()
+}
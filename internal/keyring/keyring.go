@@ -0,0 +1,70 @@
+// Package keyring stores secrets in the operating system's credential
+// store: the macOS Keychain, the Windows Credential Manager, or a Secret
+// Service provider (GNOME Keyring, KWallet) through secret-tool elsewhere.
+package keyring
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrNotFound is returned when no secret is stored for the service and account
+	ErrNotFound = errors.New("secret not found in keyring")
+
+	// ErrUnavailable is returned when the system has no usable keyring
+	ErrUnavailable = errors.New("no system keyring available")
+)
+
+// Keyring stores one secret per service and account
+type Keyring interface {
+	Get(service, account string) (string, error)
+	Set(service, account, secret string) error
+	Delete(service, account string) error
+}
+
+// System returns the keyring of the current platform. Its methods return
+// ErrUnavailable when the platform's keyring tool or service is missing.
+func System() Keyring {
+	return platformKeyring()
+}
+
+// Memory is an in-process keyring, used in tests and as a stand-in when
+// secrets must not reach the system keyring
+type Memory struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+// NewMemory creates an empty in-memory keyring
+func NewMemory() *Memory {
+	return &Memory{secrets: make(map[string]string)}
+}
+
+func (m *Memory) Get(service, account string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, ok := m.secrets[service+"\x00"+account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (m *Memory) Set(service, account, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secrets[service+"\x00"+account] = secret
+	return nil
+}
+
+func (m *Memory) Delete(service, account string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := service + "\x00" + account
+	if _, ok := m.secrets[key]; !ok {
+		return ErrNotFound
+	}
+	delete(m.secrets, key)
+	return nil
+}
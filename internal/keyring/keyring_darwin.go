@@ -0,0 +1,75 @@
+package keyring
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityNotFound is the exit code of security(1) for a missing item
+const securityNotFound = 44
+
+// keychain stores secrets as generic passwords with security(1)
+type keychain struct{}
+
+func platformKeyring() Keyring {
+	return keychain{}
+}
+
+func (keychain) available() bool {
+	_, err := exec.LookPath("security")
+	return err == nil
+}
+
+func (k keychain) Get(service, account string) (string, error) {
+	if !k.available() {
+		return "", ErrUnavailable
+	}
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to read from keychain: %w", err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func (k keychain) Set(service, account, secret string) error {
+	if !k.available() {
+		return ErrUnavailable
+	}
+	// Pass the secret through stdin (security -i) in hex, so it never
+	// appears in the process list
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+		securityQuote(service), securityQuote(account), hex.EncodeToString([]byte(secret)))
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(command)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to write to keychain: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (k keychain) Delete(service, account string) error {
+	if !k.available() {
+		return ErrUnavailable
+	}
+	if err := exec.Command("security", "delete-generic-password", "-s", service, "-a", account).Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete from keychain: %w", err)
+	}
+	return nil
+}
+
+// securityQuote quotes an argument for security's interactive mode
+func securityQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,29 @@
+package keyring
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	k := NewMemory()
+
+	if _, err := k.Get("t42", "a"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() on empty keyring error = %v, want ErrNotFound", err)
+	}
+	if err := k.Set("t42", "a", "secret"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, err := k.Get("t42", "a"); err != nil || got != "secret" {
+		t.Errorf("Get() = %q, %v, want secret", got, err)
+	}
+	if _, err := k.Get("t42", "b"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of another account error = %v, want ErrNotFound", err)
+	}
+	if err := k.Delete("t42", "a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := k.Delete("t42", "a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete() error = %v, want ErrNotFound", err)
+	}
+}
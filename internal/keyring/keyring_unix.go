@@ -0,0 +1,68 @@
+//go:build !darwin && !windows
+
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretService stores secrets through secret-tool (libsecret), which talks
+// to GNOME Keyring, KWallet or any other Secret Service provider
+type secretService struct{}
+
+func platformKeyring() Keyring {
+	return secretService{}
+}
+
+func (secretService) available() bool {
+	_, err := exec.LookPath("secret-tool")
+	return err == nil
+}
+
+func (s secretService) Get(service, account string) (string, error) {
+	if !s.available() {
+		return "", ErrUnavailable
+	}
+	var stderr strings.Builder
+	cmd := exec.Command("secret-tool", "lookup", "service", service, "account", account)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// lookup exits 1 without output for a missing item; anything on
+		// stderr means the Secret Service itself is unreachable
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.TrimSpace(stderr.String()) == "" {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("%w: %s", ErrUnavailable, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+func (s secretService) Set(service, account, secret string) error {
+	if !s.available() {
+		return ErrUnavailable
+	}
+	cmd := exec.Command("secret-tool", "store", "--label=t42 ("+account+")", "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnavailable, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (s secretService) Delete(service, account string) error {
+	if !s.available() {
+		return ErrUnavailable
+	}
+	if _, err := s.Get(service, account); err != nil {
+		return err
+	}
+	if out, err := exec.Command("secret-tool", "clear", "service", service, "account", account).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to delete from keyring: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
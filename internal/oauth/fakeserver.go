@@ -0,0 +1,299 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fake authorization server defaults, used by 't42 auth login --simulate'
+const (
+	FakeClientID = "t42-simulated-client"
+	FakeUserID   = 42
+	FakeLogin    = "simulator"
+	FakeEmail    = "simulator@student.42.fr"
+
+	fakeTokenLifetime = 7200 // seconds, like the 42 API
+)
+
+// FakeServer is a minimal local OAuth2 authorization server that mimics the
+// 42 intra endpoints closely enough to run the whole login flow offline:
+// the authorization endpoint, the token endpoint (with PKCE validation) and
+// /v2/me for the resulting access token.
+type FakeServer struct {
+	ClientID string
+	// ClientSecret is required at the token endpoint; clear it to act as
+	// a public client
+	ClientSecret string
+
+	listener net.Listener
+	server   *http.Server
+
+	mu     sync.Mutex
+	codes  map[string]authorizationGrant
+	tokens map[string]bool
+}
+
+// authorizationGrant is what the fake server remembers about an issued code
+type authorizationGrant struct {
+	redirectURI   string
+	codeChallenge string
+}
+
+// NewFakeServer starts a fake authorization server on a random loopback port
+func NewFakeServer() (*FakeServer, error) {
+	secret, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client secret: %w", err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start fake authorization server: %w", err)
+	}
+
+	s := &FakeServer{
+		ClientID:     FakeClientID,
+		ClientSecret: secret,
+		listener:     ln,
+		codes:        make(map[string]authorizationGrant),
+		tokens:       make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", s.handleAuthorize)
+	mux.HandleFunc("/oauth/token", s.handleToken)
+	mux.HandleFunc("/v2/me", s.handleMe)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		_ = s.server.Serve(ln)
+	}()
+
+	return s, nil
+}
+
+// URL returns the base URL of the server (also usable as API base URL)
+func (s *FakeServer) URL() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+// AuthorizeURL returns the authorization endpoint URL
+func (s *FakeServer) AuthorizeURL() string {
+	return s.URL() + "/oauth/authorize"
+}
+
+// TokenURL returns the token endpoint URL
+func (s *FakeServer) TokenURL() string {
+	return s.URL() + "/oauth/token"
+}
+
+// Close stops the server
+func (s *FakeServer) Close() error {
+	return s.server.Close()
+}
+
+// handleAuthorize plays the part of the user approving the application: the
+// request is validated and immediately redirected back with a code
+func (s *FakeServer) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeOAuthError(w, http.StatusMethodNotAllowed, "invalid_request", "authorization requests must use GET")
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Get("client_id") != s.ClientID {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "unknown client_id")
+		return
+	}
+
+	redirectURI := query.Get("redirect_uri")
+	target, err := url.Parse(redirectURI)
+	if err != nil || target.Scheme != "http" || !isLoopbackHost(target.Hostname()) {
+		// Never redirect to an unvalidated URI, report the error directly
+		writeOAuthError(w, http.StatusBadRequest, "invalid_redirect_uri", "redirect_uri must be an http loopback URL")
+		return
+	}
+
+	redirectError := func(code, description string) {
+		params := target.Query()
+		params.Set("error", code)
+		params.Set("error_description", description)
+		params.Set("state", query.Get("state"))
+		target.RawQuery = params.Encode()
+		http.Redirect(w, r, target.String(), http.StatusFound)
+	}
+
+	switch {
+	case query.Get("response_type") != "code":
+		redirectError("unsupported_response_type", "response_type must be code")
+		return
+	case query.Get("state") == "":
+		redirectError("invalid_request", "state is required")
+		return
+	case query.Get("code_challenge") == "":
+		redirectError("invalid_request", "code_challenge is required")
+		return
+	case query.Get("code_challenge_method") != "S256":
+		redirectError("invalid_request", "code_challenge_method must be S256")
+		return
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		redirectError("server_error", "failed to generate authorization code")
+		return
+	}
+
+	s.mu.Lock()
+	s.codes[code] = authorizationGrant{
+		redirectURI:   redirectURI,
+		codeChallenge: query.Get("code_challenge"),
+	}
+	s.mu.Unlock()
+
+	params := target.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	target.RawQuery = params.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// handleToken exchanges an authorization code for an access token
+func (s *FakeServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeOAuthError(w, http.StatusMethodNotAllowed, "invalid_request", "token requests must use POST")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "malformed form body")
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	}
+	// A public client (no ClientSecret) is authenticated by PKCE alone
+	if r.PostForm.Get("client_id") != s.ClientID ||
+		(s.ClientSecret != "" && subtle.ConstantTimeCompare([]byte(r.PostForm.Get("client_secret")), []byte(s.ClientSecret)) != 1) {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "client authentication failed")
+		return
+	}
+
+	// Codes are single use, whatever the outcome of the exchange
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	grant, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+
+	switch {
+	case !ok:
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "unknown or already used authorization code")
+		return
+	case r.PostForm.Get("redirect_uri") != grant.redirectURI:
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "redirect_uri does not match the authorization request")
+		return
+	case !VerifyCodeChallenge(r.PostForm.Get("code_verifier"), grant.codeChallenge):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "code_verifier does not match code_challenge")
+		return
+	}
+
+	accessToken, err := randomToken()
+	if err != nil {
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", "failed to generate access token")
+		return
+	}
+	refreshToken, err := randomToken()
+	if err != nil {
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", "failed to generate refresh token")
+		return
+	}
+
+	s.mu.Lock()
+	s.tokens[accessToken] = true
+	s.mu.Unlock()
+
+	now := time.Now()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":       accessToken,
+		"token_type":         "bearer",
+		"expires_in":         fakeTokenLifetime,
+		"refresh_token":      refreshToken,
+		"scope":              "public",
+		"created_at":         now.Unix(),
+		"secret_valid_until": now.Add(30 * 24 * time.Hour).Unix(),
+	})
+}
+
+// handleMe returns the simulated user for a token issued by this server
+func (s *FakeServer) handleMe(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.Lock()
+	valid := s.tokens[token]
+	s.mu.Unlock()
+
+	if !valid {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_token", "the access token is invalid")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":          FakeUserID,
+		"login":       FakeLogin,
+		"email":       FakeEmail,
+		"displayname": "t42 Simulator",
+	})
+}
+
+// VerifyCodeChallenge reports whether verifier matches an S256 code
+// challenge (RFC 7636 Section 4.6)
+func VerifyCodeChallenge(verifier, challenge string) bool {
+	if verifier == "" || challenge == "" {
+		return false
+	}
+	expected := generateCodeChallenge(verifier)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// isLoopbackHost reports whether host names the local machine
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// randomToken returns a random URL-safe string
+func randomToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// writeOAuthError writes an RFC 6749 style error response
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,291 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// noRedirectClient returns redirects instead of following them
+var noRedirectClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func authorize(t *testing.T, s *FakeServer, params url.Values) *url.URL {
+	t.Helper()
+	resp, err := noRedirectClient.Get(s.AuthorizeURL() + "?" + params.Encode())
+	if err != nil {
+		t.Fatalf("authorize request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d, want %d", resp.StatusCode, http.StatusFound)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid redirect location: %v", err)
+	}
+	return location
+}
+
+func exchange(t *testing.T, s *FakeServer, form url.Values) (int, map[string]interface{}) {
+	t.Helper()
+	resp, err := http.PostForm(s.TokenURL(), form)
+	if err != nil {
+		t.Fatalf("token request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("invalid token response: %v", err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestFakeServerLoginFlow(t *testing.T) {
+	s, err := NewFakeServer()
+	if err != nil {
+		t.Fatalf("NewFakeServer() error = %v", err)
+	}
+	defer s.Close()
+
+	pkce, err := GeneratePKCEParams()
+	if err != nil {
+		t.Fatalf("GeneratePKCEParams() error = %v", err)
+	}
+	redirectURI := "http://127.0.0.1:8080/callback"
+
+	location := authorize(t, s, url.Values{
+		"client_id":             {s.ClientID},
+		"redirect_uri":          {redirectURI},
+		"response_type":         {"code"},
+		"state":                 {"xyz"},
+		"code_challenge":        {pkce.CodeChallenge},
+		"code_challenge_method": {"S256"},
+	})
+	if got := location.Query().Get("state"); got != "xyz" {
+		t.Errorf("redirect state = %q, want %q", got, "xyz")
+	}
+	code := location.Query().Get("code")
+	if code == "" {
+		t.Fatalf("redirect has no code: %s", location)
+	}
+
+	validForm := func() url.Values {
+		return url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {s.ClientID},
+			"client_secret": {s.ClientSecret},
+			"code":          {code},
+			"redirect_uri":  {redirectURI},
+			"code_verifier": {pkce.CodeVerifier},
+		}
+	}
+
+	// Wrong client secrets are rejected before the code is consumed
+	form := validForm()
+	form.Set("client_secret", "wrong")
+	if status, _ := exchange(t, s, form); status != http.StatusUnauthorized {
+		t.Errorf("wrong secret status = %d, want %d", status, http.StatusUnauthorized)
+	}
+
+	status, body := exchange(t, s, validForm())
+	if status != http.StatusOK {
+		t.Fatalf("token status = %d, body = %v", status, body)
+	}
+	token, _ := body["access_token"].(string)
+	if token == "" {
+		t.Fatalf("token response has no access_token: %v", body)
+	}
+
+	// Codes are single use
+	if status, _ := exchange(t, s, validForm()); status != http.StatusBadRequest {
+		t.Errorf("reused code status = %d, want %d", status, http.StatusBadRequest)
+	}
+
+	req, _ := http.NewRequest("GET", s.URL()+"/v2/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("/v2/me request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("/v2/me status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestFakeServerRejectsBadExchange(t *testing.T) {
+	s, err := NewFakeServer()
+	if err != nil {
+		t.Fatalf("NewFakeServer() error = %v", err)
+	}
+	defer s.Close()
+
+	redirectURI := "http://127.0.0.1:8080/callback"
+
+	tests := []struct {
+		name   string
+		mutate func(url.Values)
+	}{
+		{"wrong verifier", func(f url.Values) { f.Set("code_verifier", "not-the-verifier") }},
+		{"missing verifier", func(f url.Values) { f.Del("code_verifier") }},
+		{"different redirect", func(f url.Values) { f.Set("redirect_uri", "http://127.0.0.1:9999/callback") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkce, _ := GeneratePKCEParams()
+			location := authorize(t, s, url.Values{
+				"client_id":             {s.ClientID},
+				"redirect_uri":          {redirectURI},
+				"response_type":         {"code"},
+				"state":                 {"s"},
+				"code_challenge":        {pkce.CodeChallenge},
+				"code_challenge_method": {"S256"},
+			})
+
+			form := url.Values{
+				"grant_type":    {"authorization_code"},
+				"client_id":     {s.ClientID},
+				"client_secret": {s.ClientSecret},
+				"code":          {location.Query().Get("code")},
+				"redirect_uri":  {redirectURI},
+				"code_verifier": {pkce.CodeVerifier},
+			}
+			tt.mutate(form)
+
+			status, body := exchange(t, s, form)
+			if status != http.StatusBadRequest || body["error"] != "invalid_grant" {
+				t.Errorf("exchange = %d %v, want 400 invalid_grant", status, body)
+			}
+		})
+	}
+}
+
+func TestFakeServerPublicClient(t *testing.T) {
+	s, err := NewFakeServer()
+	if err != nil {
+		t.Fatalf("NewFakeServer() error = %v", err)
+	}
+	defer s.Close()
+	s.ClientSecret = ""
+
+	redirectURI := "http://127.0.0.1:8080/callback"
+	tests := []struct {
+		name       string
+		verifier   bool
+		wantStatus int
+	}{
+		{name: "PKCE alone", verifier: true, wantStatus: http.StatusOK},
+		{name: "no verifier", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkce, _ := GeneratePKCEParams()
+			location := authorize(t, s, url.Values{
+				"client_id":             {s.ClientID},
+				"redirect_uri":          {redirectURI},
+				"response_type":         {"code"},
+				"state":                 {"s"},
+				"code_challenge":        {pkce.CodeChallenge},
+				"code_challenge_method": {"S256"},
+			})
+
+			form := url.Values{
+				"grant_type":   {"authorization_code"},
+				"client_id":    {s.ClientID},
+				"code":         {location.Query().Get("code")},
+				"redirect_uri": {redirectURI},
+			}
+			if tt.verifier {
+				form.Set("code_verifier", pkce.CodeVerifier)
+			}
+
+			if status, body := exchange(t, s, form); status != tt.wantStatus {
+				t.Errorf("exchange = %d %v, want %d", status, body, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestFakeServerAuthorizeValidation(t *testing.T) {
+	s, err := NewFakeServer()
+	if err != nil {
+		t.Fatalf("NewFakeServer() error = %v", err)
+	}
+	defer s.Close()
+
+	base := func() url.Values {
+		return url.Values{
+			"client_id":             {s.ClientID},
+			"redirect_uri":          {"http://localhost:8080/callback"},
+			"response_type":         {"code"},
+			"state":                 {"s"},
+			"code_challenge":        {"challenge"},
+			"code_challenge_method": {"S256"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mutate     func(url.Values)
+		wantStatus int
+		wantError  string
+	}{
+		{"unknown client", func(p url.Values) { p.Set("client_id", "other") }, http.StatusUnauthorized, ""},
+		{"remote redirect", func(p url.Values) { p.Set("redirect_uri", "http://example.com/callback") }, http.StatusBadRequest, ""},
+		{"missing state", func(p url.Values) { p.Del("state") }, http.StatusFound, "invalid_request"},
+		{"plain challenge", func(p url.Values) { p.Set("code_challenge_method", "plain") }, http.StatusFound, "invalid_request"},
+		{"token response type", func(p url.Values) { p.Set("response_type", "token") }, http.StatusFound, "unsupported_response_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := base()
+			tt.mutate(params)
+
+			resp, err := noRedirectClient.Get(s.AuthorizeURL() + "?" + params.Encode())
+			if err != nil {
+				t.Fatalf("authorize request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantError != "" && !strings.Contains(resp.Header.Get("Location"), "error="+tt.wantError) {
+				t.Errorf("Location = %q, want error=%s", resp.Header.Get("Location"), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	pkce, _ := GeneratePKCEParams()
+
+	tests := []struct {
+		name      string
+		verifier  string
+		challenge string
+		want      bool
+	}{
+		{"matching", pkce.CodeVerifier, pkce.CodeChallenge, true},
+		{"wrong verifier", pkce.CodeVerifier + "x", pkce.CodeChallenge, false},
+		{"plain challenge", pkce.CodeVerifier, pkce.CodeVerifier, false},
+		{"empty verifier", "", pkce.CodeChallenge, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyCodeChallenge(tt.verifier, tt.challenge); got != tt.want {
+				t.Errorf("VerifyCodeChallenge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Fields is a selection of dotted paths, such as "login" or
+// "cursus_users.level", that JSON output is trimmed to. Arrays along a path
+// are crossed: "cursus_users.level" keeps the level of every cursus user.
+type Fields struct {
+	paths [][]string
+	tree  fieldTree
+}
+
+// fieldTree holds the selected keys of an object; a nil subtree selects the
+// whole value
+type fieldTree map[string]fieldTree
+
+// ParseFields parses a comma separated list of dotted paths
+func ParseFields(list string) (*Fields, error) {
+	f := &Fields{tree: fieldTree{}}
+	for _, path := range strings.Split(list, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		segments := strings.Split(path, ".")
+		for _, s := range segments {
+			if s == "" {
+				return nil, fmt.Errorf("invalid field %q", path)
+			}
+		}
+		f.paths = append(f.paths, segments)
+		f.tree.add(segments)
+	}
+	if len(f.paths) == 0 {
+		return nil, fmt.Errorf("no fields given")
+	}
+	return f, nil
+}
+
+// add selects a path; a path selecting a whole value wins over the longer
+// ones below it
+func (t fieldTree) add(segments []string) {
+	sub, ok := t[segments[0]]
+	if len(segments) == 1 {
+		t[segments[0]] = nil
+		return
+	}
+	if ok && sub == nil {
+		return
+	}
+	if !ok {
+		sub = fieldTree{}
+		t[segments[0]] = sub
+	}
+	sub.add(segments[1:])
+}
+
+// Paths returns the selected paths in the order given
+func (f *Fields) Paths() []string {
+	paths := make([]string, len(f.paths))
+	for i, segments := range f.paths {
+		paths[i] = strings.Join(segments, ".")
+	}
+	return paths
+}
+
+// Has reports whether a top-level key is selected
+func (f *Fields) Has(key string) bool {
+	_, ok := f.tree[key]
+	return ok
+}
+
+// TrimJSON returns an encoded JSON document with only the selected paths,
+// indented. Keys keep their order, and numbers their exact spelling.
+func (f *Fields) TrimJSON(data []byte) ([]byte, error) {
+	trimmed, err := trimJSON(bytes.TrimSpace(data), f.tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select fields: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, trimmed, "", indent); err != nil {
+		return nil, fmt.Errorf("failed to select fields: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// trimJSON keeps the keys of tree in the objects of data, crossing arrays.
+// Values a path goes past, such as strings, are kept as they are.
+func trimJSON(data json.RawMessage, tree fieldTree) (json.RawMessage, error) {
+	if tree == nil || len(data) == 0 {
+		return data, nil
+	}
+
+	switch data[0] {
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		first := true
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := tok.(string)
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			sub, ok := tree[key]
+			if !ok {
+				continue
+			}
+			if value, err = trimJSON(value, sub); err != nil {
+				return nil, err
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			encodedKey, _ := json.Marshal(key)
+			buf.Write(encodedKey)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	case '[':
+		var elements []json.RawMessage
+		if err := json.Unmarshal(data, &elements); err != nil {
+			return nil, err
+		}
+		for i, element := range elements {
+			trimmed, err := trimJSON(element, tree)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = trimmed
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, element := range elements {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(element)
+		}
+		buf.WriteByte(']')
+		return buf.Bytes(), nil
+	default:
+		return data, nil
+	}
+}
+
+// Columns returns the CSV/TSV columns of the selected paths. A path naming
+// one of named, such as a command's registered columns, is that column;
+// others are read along the path from each row.
+func (f *Fields) Columns(named []Column) []Column {
+	columns := make([]Column, len(f.paths))
+	for i, segments := range f.paths {
+		path := strings.Join(segments, ".")
+		columns[i] = Column{Header: path, Path: segments}
+		for _, c := range named {
+			if c.Header == path {
+				columns[i] = c
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// pathValues returns the values at a path in value, crossing arrays
+func pathValues(value interface{}, segments []string) []interface{} {
+	if arr, ok := value.([]interface{}); ok {
+		var values []interface{}
+		for _, element := range arr {
+			values = append(values, pathValues(element, segments)...)
+		}
+		return values
+	}
+	if len(segments) == 0 {
+		return []interface{}{value}
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	child, ok := obj[segments[0]]
+	if !ok {
+		return []interface{}{nil}
+	}
+	return pathValues(child, segments[1:])
+}
@@ -0,0 +1,155 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "paths in order", list: "login, cursus_users.level,id", want: []string{"login", "cursus_users.level", "id"}},
+		{name: "empty entries skipped", list: "login,,", want: []string{"login"}},
+		{name: "empty segment", list: "cursus_users..level", wantErr: true},
+		{name: "no fields", list: " , ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFields(tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := f.Paths()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Paths() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Paths() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTrimJSON(t *testing.T) {
+	doc := `{"id": 1234567, "login": "jdoe", "email": "jdoe@example.com",
+		"cursus_users": [{"level": 7.5, "cursus": {"id": 21, "name": "42cursus"}}, {"level": 0.1, "cursus": {"id": 9, "name": "C Piscine"}}],
+		"campus": [{"id": 26, "name": "Tokyo"}], "titles": []}`
+
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{
+			name:   "keys keep the document order",
+			fields: "login,id",
+			want:   "{\n  \"id\": 1234567,\n  \"login\": \"jdoe\"\n}\n",
+		},
+		{
+			name:   "paths cross arrays",
+			fields: "cursus_users.level,cursus_users.cursus.name",
+			want: `{
+  "cursus_users": [
+    {
+      "level": 7.5,
+      "cursus": {
+        "name": "42cursus"
+      }
+    },
+    {
+      "level": 0.1,
+      "cursus": {
+        "name": "C Piscine"
+      }
+    }
+  ]
+}
+`,
+		},
+		{
+			name:   "a whole value wins over paths below it",
+			fields: "campus.name,campus",
+			want:   "{\n  \"campus\": [\n    {\n      \"id\": 26,\n      \"name\": \"Tokyo\"\n    }\n  ]\n}\n",
+		},
+		{
+			name:   "missing paths are left out",
+			fields: "login,location,login.first",
+			want:   "{\n  \"login\": \"jdoe\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFields(tt.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := f.TrimJSON([]byte(doc))
+			if err != nil {
+				t.Fatalf("TrimJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("TrimJSON() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldsColumns(t *testing.T) {
+	fields := []Field{
+		{Key: "users", Value: []map[string]interface{}{
+			{"login": "jdoe", "campus": []map[string]string{{"name": "Tokyo"}, {"name": "Paris"}}},
+			{"login": "asmith", "campus": []map[string]string{}},
+		}},
+	}
+	named := []Column{{Header: "name", Query: ".login | ascii_upcase"}}
+
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{
+			name:   "paths from the rows",
+			fields: "login,campus.name",
+			want:   "login,campus.name\njdoe,Tokyo;Paris\nasmith,\n",
+		},
+		{
+			name:   "paths may name the row field",
+			fields: "users.login",
+			want:   "users.login\njdoe\nasmith\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFields(tt.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			columns := &Columns{Field: "users", Columns: f.Columns(named)}
+			var buf bytes.Buffer
+			if err := Write(&buf, FormatCSV, columns, fields...); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Write() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+
+	f, _ := ParseFields("name")
+	if got := f.Columns(named); got[0].Query != named[0].Query {
+		t.Errorf("Columns() = %v, want the named column", got)
+	}
+}
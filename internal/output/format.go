@@ -0,0 +1,295 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatCSV   Format = "csv"
+	FormatTSV   Format = "tsv"
+)
+
+// Formats lists the output formats, for flag help and completion
+var Formats = []Format{FormatTable, FormatJSON, FormatYAML, FormatCSV, FormatTSV}
+
+// ParseFormat parses an output format name; "yml" is accepted for YAML
+func ParseFormat(name string) (Format, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "yml" {
+		return FormatYAML, nil
+	}
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q (use %s)", name, formatNames())
+}
+
+func formatNames() string {
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Column is a CSV/TSV column: a header and the jq query extracting the
+// cell from each row, such as ".login" or ".cursus_users[0].level". A
+// column with a Path, from --fields, reads it instead of running a query.
+type Column struct {
+	Header string
+	Query  string
+	Path   []string
+}
+
+// Columns describes the rows of a command's CSV/TSV output: the elements
+// of the array in Field, one column each
+type Columns struct {
+	Field   string
+	Columns []Column
+}
+
+// Write writes fields as a top-level object in format. Table output is
+// rendered by commands themselves, so it is written as JSON here.
+// columns may be nil; CSV and TSV then use the first array field and the
+// scalar keys of its first element.
+func Write(w io.Writer, format Format, columns *Columns, fields ...Field) error {
+	if format == FormatJSON || format == FormatTable || format == "" {
+		return WriteJSON(w, fields...)
+	}
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, fields...); err != nil {
+		return err
+	}
+	return render(w, format, columns, buf.Bytes(), true)
+}
+
+// WriteValue writes a single value in format, like Write does for fields.
+// Without columns, CSV and TSV write an object as a single row.
+func WriteValue(w io.Writer, format Format, columns *Columns, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", indent)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	if format == FormatJSON || format == FormatTable || format == "" {
+		_, err := fmt.Fprintln(w, string(data))
+		return err
+	}
+	return render(w, format, columns, data, false)
+}
+
+// WriteData writes an encoded JSON document in format, like Write, such as
+// output trimmed by Fields
+func WriteData(w io.Writer, format Format, data []byte) error {
+	if format == FormatJSON || format == FormatTable || format == "" {
+		_, err := w.Write(data)
+		return err
+	}
+	return render(w, format, nil, data, true)
+}
+
+// render converts encoded JSON into YAML, CSV or TSV. With findRows, CSV
+// and TSV rows are the elements of the first array field of an object.
+func render(w io.Writer, format Format, columns *Columns, data []byte, findRows bool) error {
+	// YAML is a superset of JSON: decoding into a node keeps the key order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to decode output: %w", err)
+	}
+
+	switch format {
+	case FormatYAML:
+		resetStyle(&doc)
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return fmt.Errorf("failed to write YAML: %w", err)
+		}
+		return enc.Close()
+	case FormatCSV, FormatTSV:
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("failed to decode output: %w", err)
+		}
+		comma := ','
+		if format == FormatTSV {
+			comma = '\t'
+		}
+		return writeRecords(w, comma, columns, &doc, value, findRows)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// resetStyle drops the flow style JSON nodes decode with, so the YAML is
+// written in block style
+func resetStyle(n *yaml.Node) {
+	n.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+	for _, child := range n.Content {
+		resetStyle(child)
+	}
+}
+
+// writeRecords writes the rows of an output as delimited records with a
+// header line
+func writeRecords(w io.Writer, comma rune, columns *Columns, doc *yaml.Node, value interface{}, findRows bool) error {
+	rows, rowNode, rowField, err := selectRows(columns, doc, value, findRows)
+	if err != nil {
+		return err
+	}
+
+	var cols []Column
+	if columns != nil && len(columns.Columns) > 0 {
+		cols = append(cols, columns.Columns...)
+	} else {
+		cols = scalarColumns(rowNode)
+	}
+	queries := make([]*gojq.Code, len(cols))
+	header := make([]string, len(cols))
+	for i, c := range cols {
+		header[i] = c.Header
+		if c.Path != nil {
+			// "users.login" names the login of each row of users
+			if len(c.Path) > 1 && c.Path[0] == rowField {
+				cols[i].Path = c.Path[1:]
+			}
+			continue
+		}
+		q, err := ParseJQ(c.Query)
+		if err != nil {
+			return fmt.Errorf("invalid column %q: %w", c.Header, err)
+		}
+		queries[i] = q
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	record := make([]string, len(cols))
+	for _, row := range rows {
+		for i, q := range queries {
+			if q == nil {
+				record[i] = cellString(pathValues(row, cols[i].Path))
+				continue
+			}
+			results, err := RunJQ(q, row)
+			if err != nil {
+				return fmt.Errorf("failed to compute column %q: %w", cols[i].Header, err)
+			}
+			record[i] = cellString(results)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// selectRows returns the values written as rows, the node of the first
+// one to derive columns from and the field they are in: the elements of
+// the configured field, else of the first array field when findRows is
+// set, else the output itself as a single row
+func selectRows(columns *Columns, doc *yaml.Node, value interface{}, findRows bool) ([]interface{}, *yaml.Node, string, error) {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	if arr, ok := value.([]interface{}); ok {
+		return arr, firstChild(root), "", nil
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok || root.Kind != yaml.MappingNode {
+		return []interface{}{value}, nil, "", nil
+	}
+
+	field := ""
+	if columns != nil {
+		field = columns.Field
+	}
+	if field == "" && !findRows {
+		return []interface{}{value}, root, "", nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, node := root.Content[i].Value, root.Content[i+1]
+		if field != "" && key != field {
+			continue
+		}
+		if node.Kind != yaml.SequenceNode {
+			if field != "" {
+				return nil, nil, "", fmt.Errorf("field %q is not a list", field)
+			}
+			continue
+		}
+		arr, _ := obj[key].([]interface{})
+		return arr, firstChild(node), key, nil
+	}
+	if field != "" {
+		return nil, nil, "", fmt.Errorf("no %q field in the output", field)
+	}
+	return []interface{}{value}, root, "", nil
+}
+
+// firstChild returns the first element of a sequence node, or nil
+func firstChild(n *yaml.Node) *yaml.Node {
+	if n == nil || n.Kind != yaml.SequenceNode || len(n.Content) == 0 {
+		return nil
+	}
+	return n.Content[0]
+}
+
+// scalarColumns returns a column per scalar key of an object, in order
+func scalarColumns(n *yaml.Node) []Column {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return []Column{{Header: "value", Query: "."}}
+	}
+	var cols []Column
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i+1].Kind == yaml.ScalarNode {
+			key := n.Content[i].Value
+			cols = append(cols, Column{Header: key, Query: fmt.Sprintf(".[%s]", strconv.Quote(key))})
+		}
+	}
+	return cols
+}
+
+// cellString formats the results of a column query as a cell: strings
+// raw, null empty, other values as compact JSON, several results joined
+// by semicolons
+func cellString(results []interface{}) string {
+	parts := make([]string, 0, len(results))
+	for _, r := range results {
+		switch v := r.(type) {
+		case nil:
+			parts = append(parts, "")
+		case string:
+			parts = append(parts, v)
+		case float64:
+			parts = append(parts, strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			parts = append(parts, strconv.FormatBool(v))
+		default:
+			data, _ := json.Marshal(v)
+			parts = append(parts, string(data))
+		}
+	}
+	return strings.Join(parts, ";")
+}
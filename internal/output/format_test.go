@@ -0,0 +1,145 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{name: "table", want: FormatTable},
+		{name: "JSON", want: FormatJSON},
+		{name: "yml", want: FormatYAML},
+		{name: " tsv ", want: FormatTSV},
+		{name: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFormat(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type formatProject struct {
+	Slug  string  `json:"slug"`
+	Mark  *int    `json:"final_mark"`
+	Level float64 `json:"level"`
+	Team  struct {
+		Name string `json:"name"`
+	} `json:"team"`
+}
+
+func TestWrite(t *testing.T) {
+	mark := 125
+	projects := []formatProject{
+		{Slug: "libft", Mark: &mark, Level: 1.5},
+		{Slug: "ft_printf, v2", Level: 2},
+	}
+	projects[0].Team.Name = "jdoe's group"
+	fields := []Field{
+		{Key: "meta", Value: map[string]int{"page": 1}},
+		{Key: "projects", Value: projects},
+	}
+
+	tests := []struct {
+		name    string
+		format  Format
+		columns *Columns
+		want    string
+	}{
+		{
+			name:   "csv derives columns from the first array",
+			format: FormatCSV,
+			want:   "slug,final_mark,level\nlibft,125,1.5\n\"ft_printf, v2\",,2\n",
+		},
+		{
+			name:   "tsv",
+			format: FormatTSV,
+			want:   "slug\tfinal_mark\tlevel\nlibft\t125\t1.5\nft_printf, v2\t\t2\n",
+		},
+		{
+			name:   "registered columns",
+			format: FormatCSV,
+			columns: &Columns{Field: "projects", Columns: []Column{
+				{Header: "project", Query: ".slug"},
+				{Header: "team", Query: ".team.name"},
+			}},
+			want: "project,team\nlibft,jdoe's group\n\"ft_printf, v2\",\n",
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			want: `meta:
+  page: 1
+projects:
+  - slug: libft
+    final_mark: 125
+    level: 1.5
+    team:
+      name: jdoe's group
+  - slug: ft_printf, v2
+    final_mark: null
+    level: 2
+    team:
+      name: ""
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, tt.columns, fields...); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Write() =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteUnknownField(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, FormatCSV, &Columns{Field: "users"}, Field{Key: "projects", Value: []int{1}})
+	if err == nil {
+		t.Error("Write() error = nil, want an error for a missing field")
+	}
+}
+
+func TestWriteValue(t *testing.T) {
+	value := map[string]interface{}{"login": "jdoe", "level": 4.2, "tags": []string{"a"}}
+
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{name: "json", format: FormatJSON, want: "{\n  \"level\": 4.2,\n  \"login\": \"jdoe\",\n  \"tags\": [\n    \"a\"\n  ]\n}\n"},
+		{name: "csv writes an object as one row", format: FormatCSV, want: "level,login\n4.2,jdoe\n"},
+		{name: "yaml", format: FormatYAML, want: "level: 4.2\nlogin: jdoe\ntags:\n  - a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteValue(&buf, tt.format, nil, value); err != nil {
+				t.Fatalf("WriteValue() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("WriteValue() =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
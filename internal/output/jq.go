@@ -0,0 +1,67 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/itchyny/gojq"
+)
+
+// ParseJQ compiles a jq expression. The full jq language is available,
+// through gojq, including $ENV and env.
+func ParseJQ(src string) (*gojq.Code, error) {
+	query, err := gojq.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return gojq.Compile(query, gojq.WithEnvironLoader(os.Environ))
+}
+
+// RunJQ evaluates a compiled jq expression against a generic JSON value,
+// as decoded by encoding/json, and returns all its outputs
+func RunJQ(code *gojq.Code, input interface{}) ([]interface{}, error) {
+	var results []interface{}
+	iter := code.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return results, nil
+		}
+		if err, ok := v.(error); ok {
+			return nil, err
+		}
+		results = append(results, v)
+	}
+}
+
+// RunJQJSON decodes data and evaluates a compiled jq expression against it
+func RunJQJSON(code *gojq.Code, data []byte) ([]interface{}, error) {
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("jq: input is not valid JSON: %w", err)
+	}
+	return RunJQ(code, input)
+}
+
+// WriteJQ prints each jq result on its own line: strings raw, other values
+// as compact JSON, like jq -r
+func WriteJQ(w io.Writer, results []interface{}) error {
+	for _, result := range results {
+		if s, ok := result.(string); ok {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to encode jq result: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, string(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const testInput = `{
+  "login": "jdoe",
+  "level": 7.5,
+  "campus": [{"id": 26, "name": "Tokyo"}, {"id": 1, "name": "Paris"}],
+  "projects": [
+    {"slug": "libft", "status": "finished", "final_mark": 125},
+    {"slug": "minishell", "status": "in_progress", "final_mark": null},
+    {"slug": "push_swap", "status": "finished", "final_mark": 84}
+  ],
+  "tags": ["a", "b"],
+  "odd-key": true
+}`
+
+func TestRunJQ(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string // JSON array of all outputs
+	}{
+		{".tags", `[["a","b"]]`},
+		{".login", `["jdoe"]`},
+		{".campus[0].name", `["Tokyo"]`},
+		{".campus[-1].id", `[1]`},
+		{".campus[5]", `[null]`},
+		{".missing.deeper", `[null]`},
+		{`."odd-key"`, `[true]`},
+		{`.["login"]`, `["jdoe"]`},
+		{".campus[].name", `["Tokyo","Paris"]`},
+		{".campus | length", `[2]`},
+		{".login, .level", `["jdoe",7.5]`},
+		{`.projects[] | select(.status == "finished") | .slug`, `["libft","push_swap"]`},
+		{`[.projects[] | .final_mark // 0]`, `[[125,0,84]]`},
+		{`.projects | map(.slug) | join(",")`, `["libft,minishell,push_swap"]`},
+		{`.projects | map(select(.final_mark != null).final_mark) | add`, `[209]`},
+		{`{login, lvl: .level}`, `[{"login":"jdoe","lvl":7.5}]`},
+		{`.level > 7 and .login == "jdoe"`, `[true]`},
+		{`.level < 7 or (.tags | contains(["b"]))`, `[true]`},
+		{`.tags | not`, `[false]`},
+		{`if .level >= 10 then "high" elif .level >= 5 then "mid" else "low" end`, `["mid"]`},
+		{`.level * 2 - 1`, `[14]`},
+		{`-.level`, `[-7.5]`},
+		{`.login | test("^j") and startswith("jd")`, `[true]`},
+		{`.projects | sort_by(.final_mark) | .[0].slug`, `["minishell"]`},
+		{`.campus | map(.id) | max`, `[26]`},
+		{`keys`, `[["campus","level","login","odd-key","projects","tags"]]`},
+		{`has("login"), has("nope")`, `[true,false]`},
+		{`.login.id?`, `[]`},
+		{`[.tags[] | ascii_upcase]`, `[["A","B"]]`},
+		{`[.. | select(type == "number")] | length`, `[5]`},
+		{`reduce .projects[] as $p (0; . + ($p.final_mark // 0))`, `[209]`},
+		{`def double: . * 2; .level | double`, `[15]`},
+		{`.tags | @csv`, `["\"a\",\"b\""]`},
+		{`[limit(2; .projects[].slug)]`, `[["libft","minishell"]]`},
+		{`.projects | group_by(.status) | map(length)`, `[[2,1]]`},
+		{`$ENV.T42_JQ_TEST`, `["set"]`},
+	}
+
+	var input interface{}
+	if err := json.Unmarshal([]byte(testInput), &input); err != nil {
+		t.Fatalf("invalid test input: %v", err)
+	}
+
+	t.Setenv("T42_JQ_TEST", "set")
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			q, err := ParseJQ(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseJQ(%q) error = %v", tt.expr, err)
+			}
+			got, err := RunJQ(q, input)
+			if err != nil {
+				t.Fatalf("RunJQ() error = %v", err)
+			}
+			if got == nil {
+				got = []interface{}{}
+			}
+			data, _ := json.Marshal(got)
+			if string(data) != tt.want {
+				t.Errorf("RunJQ(%q) = %s, want %s", tt.expr, data, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJQErrors(t *testing.T) {
+	tests := []string{
+		".a |",
+		"(.a",
+		"nosuchfunc",
+		"select(.a; .b)",
+		`"unterminated`,
+		".a then",
+		"{(.a)}",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseJQ(expr); err == nil {
+				t.Errorf("ParseJQ(%q) succeeded, want error", expr)
+			}
+		})
+	}
+}
+
+func TestRunJQErrors(t *testing.T) {
+	tests := []string{
+		`.login | keys`,
+		`.level + "x"`,
+		`.level / 0`,
+		`.login[]`,
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			q, err := ParseJQ(expr)
+			if err != nil {
+				t.Fatalf("ParseJQ() error = %v", err)
+			}
+			if _, err := RunJQJSON(q, []byte(testInput)); err == nil {
+				t.Errorf("RunJQJSON(%q) succeeded, want error", expr)
+			}
+		})
+	}
+}
+
+func TestWriteJQ(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJQ(&buf, []interface{}{"raw", 3.0, map[string]interface{}{"a": nil}}); err != nil {
+		t.Fatalf("WriteJQ() error = %v", err)
+	}
+	want := "raw\n3\n{\"a\":null}\n"
+	if buf.String() != want {
+		t.Errorf("WriteJQ() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,212 @@
+// Package output renders command results for the terminal and for scripts.
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+const (
+	// indent is the indentation unit, matching json.MarshalIndent(v, "", "  ")
+	indent = "  "
+
+	// bufferSize is the size of the write buffer in front of the destination
+	bufferSize = 32 * 1024
+)
+
+// Field is a key/value pair of a top-level JSON object
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// JSONStream writes an indented JSON object incrementally. Array fields are
+// written one element at a time, so large result sets never exist as a single
+// encoded buffer. The output is identical to json.MarshalIndent(v, "", "  ")
+// of an object with the same fields in the same order.
+type JSONStream struct {
+	w        *bufio.Writer
+	scratch  bytes.Buffer
+	fieldEnc *json.Encoder
+	elemEnc  *json.Encoder
+	fields   int
+	elements int
+	inArray  bool
+	err      error
+}
+
+// NewJSONStream starts a JSON object on w
+func NewJSONStream(w io.Writer) *JSONStream {
+	s := &JSONStream{w: bufio.NewWriterSize(w, bufferSize)}
+
+	// Encoders reuse their internal buffers across calls, so encoding each
+	// element costs little beyond the element itself
+	s.fieldEnc = json.NewEncoder(&s.scratch)
+	s.fieldEnc.SetIndent(indent, indent)
+	s.elemEnc = json.NewEncoder(&s.scratch)
+	s.elemEnc.SetIndent(indent+indent, indent)
+
+	s.write("{")
+	return s
+}
+
+// Field writes a complete key/value pair
+func (s *JSONStream) Field(key string, value interface{}) error {
+	if s.inArray {
+		return fmt.Errorf("cannot write field %q inside an array", key)
+	}
+	if err := s.key(key); err != nil {
+		return err
+	}
+	if err := s.encode(s.fieldEnc, value); err != nil {
+		return fmt.Errorf("failed to encode field %q: %w", key, err)
+	}
+	return s.err
+}
+
+// BeginArray starts an array field whose elements are added with Element
+func (s *JSONStream) BeginArray(key string) error {
+	if s.inArray {
+		return fmt.Errorf("cannot start array %q inside an array", key)
+	}
+	if err := s.key(key); err != nil {
+		return err
+	}
+	s.write("[")
+	s.inArray = true
+	s.elements = 0
+	return s.err
+}
+
+// Element appends a value to the array started with BeginArray
+func (s *JSONStream) Element(value interface{}) error {
+	if !s.inArray {
+		return fmt.Errorf("no array started")
+	}
+	if s.elements > 0 {
+		s.write(",")
+	}
+	s.write("\n" + indent + indent)
+	if err := s.encode(s.elemEnc, value); err != nil {
+		return fmt.Errorf("failed to encode array element: %w", err)
+	}
+	s.elements++
+	return s.err
+}
+
+// EndArray closes the array started with BeginArray
+func (s *JSONStream) EndArray() error {
+	if !s.inArray {
+		return fmt.Errorf("no array started")
+	}
+	if s.elements > 0 {
+		s.write("\n" + indent)
+	}
+	s.write("]")
+	s.inArray = false
+	return s.err
+}
+
+// Close ends the object and flushes buffered output
+func (s *JSONStream) Close() error {
+	if s.inArray {
+		if err := s.EndArray(); err != nil {
+			return err
+		}
+	}
+	if s.fields > 0 {
+		s.write("\n")
+	}
+	s.write("}\n")
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
+
+// key writes the separator and the quoted key of the next field
+func (s *JSONStream) key(key string) error {
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode key %q: %w", key, err)
+	}
+	if s.fields > 0 {
+		s.write(",")
+	}
+	s.write("\n" + indent + string(encoded) + ": ")
+	s.fields++
+	return s.err
+}
+
+// encode encodes value with enc and copies it, minus the encoder's trailing
+// newline, to the output
+func (s *JSONStream) encode(enc *json.Encoder, value interface{}) error {
+	s.scratch.Reset()
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+	if s.err != nil {
+		return nil
+	}
+	if _, err := s.w.Write(bytes.TrimSuffix(s.scratch.Bytes(), []byte("\n"))); err != nil {
+		s.err = fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
+
+// write records the first write error; later writes become no-ops
+func (s *JSONStream) write(str string) {
+	if s.err != nil {
+		return
+	}
+	if _, err := s.w.WriteString(str); err != nil {
+		s.err = fmt.Errorf("failed to write JSON output: %w", err)
+	}
+}
+
+// WriteJSON writes an indented JSON object with the given fields to w.
+// Slice and array values are streamed element by element.
+func WriteJSON(w io.Writer, fields ...Field) error {
+	s := NewJSONStream(w)
+
+	for _, field := range fields {
+		rv := reflect.ValueOf(field.Value)
+		isList := rv.Kind() == reflect.Array || (rv.Kind() == reflect.Slice && !rv.IsNil())
+
+		// []byte marshals as a base64 string, not as an array
+		if !isList || rv.Type().Elem().Kind() == reflect.Uint8 {
+			if err := s.Field(field.Key, field.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.BeginArray(field.Key); err != nil {
+			return err
+		}
+		for i := 0; i < rv.Len(); i++ {
+			// Pass slice elements by address: it avoids copying each element
+			// into an interface, and encoding/json also treats slice elements
+			// as addressable when marshaling the whole slice
+			elem := rv.Index(i)
+			if elem.CanAddr() {
+				elem = elem.Addr()
+			}
+			if err := s.Element(elem.Interface()); err != nil {
+				return err
+			}
+		}
+		if err := s.EndArray(); err != nil {
+			return err
+		}
+	}
+
+	return s.Close()
+}
@@ -0,0 +1,145 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+type testUser struct {
+	ID        int       `json:"id"`
+	Login     string    `json:"login"`
+	Email     string    `json:"email"`
+	Level     float64   `json:"level"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func makeUsers(n int) []testUser {
+	users := make([]testUser, n)
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := range users {
+		users[i] = testUser{
+			ID:        i,
+			Login:     fmt.Sprintf("user%05d", i),
+			Email:     fmt.Sprintf("user%05d@student.42tokyo.jp", i),
+			Level:     float64(i%21) + 0.42,
+			Tags:      []string{"a", "<b>"},
+			CreatedAt: created,
+		}
+	}
+	return users
+}
+
+func TestWriteJSONMatchesMarshalIndent(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []testUser
+	}{
+		{"several elements", makeUsers(3)},
+		{"empty slice", []testUser{}},
+		{"nil slice", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := map[string]int{"page": 1, "per_page": 100}
+
+			// Keys in alphabetical order, as json.MarshalIndent sorts map keys
+			var got bytes.Buffer
+			if err := WriteJSON(&got, Field{"count", len(tt.users)}, Field{"meta", meta}, Field{"users", tt.users}); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+
+			want, err := json.MarshalIndent(map[string]interface{}{
+				"count": len(tt.users),
+				"meta":  meta,
+				"users": tt.users,
+			}, "", "  ")
+			if err != nil {
+				t.Fatalf("MarshalIndent() error = %v", err)
+			}
+
+			if got.String() != string(want)+"\n" {
+				t.Errorf("WriteJSON() output differs from MarshalIndent\ngot:\n%s\nwant:\n%s", got.String(), want)
+			}
+		})
+	}
+}
+
+func TestJSONStreamIncremental(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewJSONStream(&buf)
+
+	if err := s.Element(1); err == nil {
+		t.Error("Element() before BeginArray() should error")
+	}
+	if err := s.BeginArray("items"); err != nil {
+		t.Fatalf("BeginArray() error = %v", err)
+	}
+	for page := 0; page < 2; page++ {
+		for i := 0; i < 2; i++ {
+			if err := s.Element(page*2 + i); err != nil {
+				t.Fatalf("Element() error = %v", err)
+			}
+		}
+	}
+	if err := s.Field("late", true); err == nil {
+		t.Error("Field() inside an array should error")
+	}
+	if err := s.EndArray(); err != nil {
+		t.Fatalf("EndArray() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var decoded struct {
+		Items []int `json:"items"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(decoded.Items) != 4 || decoded.Items[3] != 3 {
+		t.Errorf("Items = %v, want [0 1 2 3]", decoded.Items)
+	}
+}
+
+// The benchmarks below compare peak allocations when printing a large result
+// set. MarshalIndent builds the whole document in memory; WriteJSON only ever
+// holds one encoded element plus a fixed-size write buffer. Compare B/op with:
+//
+//	go test ./internal/output -bench=LargeResult -benchmem
+
+const benchmarkUsers = 20000
+
+func BenchmarkLargeResultMarshalIndent(b *testing.B) {
+	users := makeUsers(benchmarkUsers)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		data, err := json.MarshalIndent(map[string]interface{}{"users": users}, "", "  ")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := io.Discard.Write(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLargeResultWriteJSON(b *testing.B) {
+	users := makeUsers(benchmarkUsers)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := WriteJSON(io.Discard, Field{"users", users}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package output
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// plainReplacements maps glyphs that carry meaning to ASCII equivalents.
+// Every other emoji is dropped together with the spaces following it.
+var plainReplacements = map[rune]string{
+	'⚠': "Warning: ",
+	'█': "#",
+	'░': "-",
+	'•': "*",
+	'→': "->",
+	'←': "<-",
+	'…': "...",
+	'─': "-",
+	'━': "-",
+	'═': "=",
+	'│': "|",
+	'┃': "|",
+	'║': "|",
+}
+
+// PlainWriter rewrites text for screen readers and terminals without emoji
+// or color support: emoji are dropped, ANSI escape sequences are removed and
+// bar, bullet and box-drawing glyphs are replaced by ASCII.
+type PlainWriter struct {
+	w          io.Writer
+	pending    []byte // incomplete UTF-8 sequence or escape sequence from the last write
+	skipSpaces bool   // drop spaces that separated a removed emoji from the text
+}
+
+// NewPlainWriter creates a PlainWriter writing to w
+func NewPlainWriter(w io.Writer) *PlainWriter {
+	return &PlainWriter{w: w}
+}
+
+// Write converts p and writes the result. Sequences split across writes are
+// buffered until complete, so the writer is safe to use on streamed output.
+func (p *PlainWriter) Write(b []byte) (int, error) {
+	data := append(p.pending, b...)
+	p.pending = nil
+
+	var out strings.Builder
+	for i := 0; i < len(data); {
+		// ANSI escape sequence: ESC [ parameters final-byte
+		if data[i] == 0x1b {
+			end := escapeEnd(data[i:])
+			if end < 0 {
+				p.pending = append([]byte(nil), data[i:]...)
+				break
+			}
+			i += end
+			continue
+		}
+
+		if !utf8.FullRune(data[i:]) {
+			p.pending = append([]byte(nil), data[i:]...)
+			break
+		}
+		r, size := utf8.DecodeRune(data[i:])
+		i += size
+
+		if p.skipSpaces && r == ' ' {
+			continue
+		}
+		p.skipSpaces = false
+
+		if replacement, ok := plainReplacements[r]; ok {
+			out.WriteString(replacement)
+			p.skipSpaces = strings.HasSuffix(replacement, " ")
+			continue
+		}
+		if r >= 0x2500 && r <= 0x257F {
+			out.WriteByte('+')
+			continue
+		}
+		if isEmoji(r) {
+			p.skipSpaces = true
+			continue
+		}
+		out.WriteRune(r)
+	}
+
+	if _, err := io.WriteString(p.w, out.String()); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// PlainText applies the PlainWriter conversion to a string
+func PlainText(s string) string {
+	var sb strings.Builder
+	pw := NewPlainWriter(&sb)
+	if _, err := pw.Write([]byte(s)); err != nil {
+		return s
+	}
+	return sb.String()
+}
+
+// escapeEnd returns the length of the escape sequence at the start of data,
+// or -1 when the sequence is incomplete
+func escapeEnd(data []byte) int {
+	if len(data) < 2 {
+		return -1
+	}
+	if data[1] != '[' {
+		// Two-byte escape (e.g. ESC c)
+		return 2
+	}
+	for j := 2; j < len(data); j++ {
+		if data[j] >= 0x40 && data[j] <= 0x7E {
+			return j + 1
+		}
+	}
+	return -1
+}
+
+// isEmoji reports whether r is a pictograph, symbol or emoji modifier that
+// screen readers announce verbosely or plain terminals cannot render
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, transport, supplemental symbols
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // miscellaneous technical (⏰, ⏱)
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // arrows and stars (⬆, ⭐)
+		return true
+	case r == 0xFE0F || r == 0xFE0E || r == 0x200D: // variation selectors, zero-width joiner
+		return true
+	case r == 0x2139 || r == 0x24C2 || r == 0x3030 || r == 0x303D: // ℹ Ⓜ 〰 〽
+		return true
+	}
+	return false
+}
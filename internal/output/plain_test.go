@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"emoji prefix and spacing are dropped", "✅ Successfully logged out!\n", "Successfully logged out!\n"},
+		{"emoji with variation selector", "🖥️  Location: e1r2p3\n", "Location: e1r2p3\n"},
+		{"emoji inside a line", "⏰ Token status: ❌ EXPIRED\n", "Token status: EXPIRED\n"},
+		{"warning keeps its meaning", "⚠️  logtime unavailable\n", "Warning: logtime unavailable\n"},
+		{"bars become ASCII", "[███░░]", "[###--]"},
+		{"arrows and bullets become ASCII", "   • gnl → get_next_line", "   * gnl -> get_next_line"},
+		{"box drawing becomes ASCII", "┌──┐", "+--+"},
+		{"ANSI colors are removed", "\x1b[1;32mready\x1b[0m", "ready"},
+		{"plain text is unchanged", "LOGIN    LEVEL\njdoe     7.42\n", "LOGIN    LEVEL\njdoe     7.42\n"},
+		{"accented letters are kept", "São Paulo", "São Paulo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlainText(tt.input); got != tt.want {
+				t.Errorf("PlainText(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainWriterSplitWrites(t *testing.T) {
+	input := []byte("\x1b[31m✅ done █\x1b[0m\n")
+
+	// Feed the writer one byte at a time to split every multi-byte sequence
+	var buf bytes.Buffer
+	pw := NewPlainWriter(&buf)
+	for i := range input {
+		if _, err := pw.Write(input[i : i+1]); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if got, want := buf.String(), "done #\n"; got != want {
+		t.Errorf("split writes produced %q, want %q", got, want)
+	}
+}
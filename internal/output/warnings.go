@@ -0,0 +1,93 @@
+package output
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MaxWarningsPerCode is how many warnings of one code are kept; the rest
+// are summed up in a last warning of that code
+const MaxWarningsPerCode = 5
+
+// Warning is a non-fatal problem met while producing a command's output,
+// such as a user skipped because it could not be fetched
+type Warning struct {
+	Code    string `json:"code"`    // the kind of problem, for scripts
+	Message string `json:"message"` // what happened, for people
+}
+
+// Warnings collects warnings, leaving out duplicates. It is safe for
+// concurrent use; the zero value is ready to use.
+type Warnings struct {
+	mu      sync.Mutex
+	list    []Warning
+	seen    map[Warning]bool
+	perCode map[string]int
+	dropped map[string]int
+}
+
+// Add records a warning
+func (w *Warnings) Add(code, message string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	warning := Warning{Code: code, Message: message}
+	if w.seen[warning] {
+		return
+	}
+	if w.seen == nil {
+		w.seen = make(map[Warning]bool)
+		w.perCode = make(map[string]int)
+		w.dropped = make(map[string]int)
+	}
+	w.seen[warning] = true
+
+	if w.perCode[code] >= MaxWarningsPerCode {
+		w.dropped[code]++
+		return
+	}
+	w.perCode[code]++
+	w.list = append(w.list, warning)
+}
+
+// List returns the warnings in the order they were added, followed by one
+// for each code that had more than MaxWarningsPerCode
+func (w *Warnings) List() []Warning {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	list := append([]Warning(nil), w.list...)
+	for _, warning := range w.list {
+		if n := w.dropped[warning.Code]; n > 0 && w.isLastOfCode(warning) {
+			list = append(list, Warning{
+				Code:    warning.Code,
+				Message: fmt.Sprintf("...and %d more %s warnings", n, warning.Code),
+			})
+		}
+	}
+	return list
+}
+
+// isLastOfCode reports whether warning is the last kept one of its code
+func (w *Warnings) isLastOfCode(warning Warning) bool {
+	for i := len(w.list) - 1; i >= 0; i-- {
+		if w.list[i].Code == warning.Code {
+			return w.list[i] == warning
+		}
+	}
+	return false
+}
+
+// Len returns the number of warnings added, duplicates aside
+func (w *Warnings) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.seen)
+}
+
+// Reset forgets every warning
+func (w *Warnings) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.list, w.seen, w.perCode, w.dropped = nil, nil, nil, nil
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWarnings(t *testing.T) {
+	var w Warnings
+	w.Add("user_skipped", "skipped alice")
+	w.Add("pagination_headers_missing", "no X-Total on /v2/users")
+	w.Add("user_skipped", "skipped alice")
+	for i := 0; i < MaxWarningsPerCode+2; i++ {
+		w.Add("user_skipped", fmt.Sprintf("skipped user%d", i))
+	}
+
+	if got, want := w.Len(), MaxWarningsPerCode+4; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+
+	want := []Warning{
+		{"user_skipped", "skipped alice"},
+		{"pagination_headers_missing", "no X-Total on /v2/users"},
+	}
+	for i := 0; i < MaxWarningsPerCode-1; i++ {
+		want = append(want, Warning{"user_skipped", fmt.Sprintf("skipped user%d", i)})
+	}
+	want = append(want, Warning{"user_skipped", "...and 3 more user_skipped warnings"})
+	if got := w.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+
+	w.Reset()
+	if got := w.List(); len(got) != 0 || w.Len() != 0 {
+		t.Errorf("after Reset() List() = %v, Len() = %d", got, w.Len())
+	}
+	w.Add("user_skipped", "skipped alice")
+	if got := w.List(); len(got) != 1 {
+		t.Errorf("after Reset() a warning seen before is dropped: %v", got)
+	}
+}
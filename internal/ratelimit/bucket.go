@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Bucket is an in-process token bucket. It is safe for concurrent use, so
+// a client shared between goroutines draws from a single budget.
+type Bucket struct {
+	mu        sync.Mutex
+	perSecond float64
+	burst     float64
+	tokens    float64
+	updatedAt time.Time
+	now       func() time.Time
+}
+
+// NewBucket creates a full bucket that refills at perSecond tokens per
+// second and holds at most burst tokens
+func NewBucket(perSecond float64, burst int) *Bucket {
+	if perSecond <= 0 {
+		perSecond = DefaultPerSecond
+	}
+	if burst <= 0 {
+		burst = DefaultBurst
+	}
+	return &Bucket{
+		perSecond: perSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		now:       time.Now,
+	}
+}
+
+// NewHourlyBucket creates a bucket allowing perHour requests per hour,
+// all of which may be spent at once
+func NewHourlyBucket(perHour int) *Bucket {
+	if perHour <= 0 {
+		perHour = DefaultPerHour
+	}
+	return NewBucket(float64(perHour)/3600, perHour)
+}
+
+// Wait blocks until a token is available in the bucket
+func (b *Bucket) Wait(ctx context.Context) error {
+	for {
+		wait := b.take()
+		if wait == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// take tries to consume a token and returns how long to wait before retrying
+// when none is available (zero means a token was consumed)
+func (b *Bucket) take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.updatedAt.IsZero() {
+		if elapsed := now.Sub(b.updatedAt).Seconds(); elapsed > 0 {
+			b.tokens += elapsed * b.perSecond
+		}
+	}
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.updatedAt = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.perSecond * float64(time.Second))
+}
+
+// chain is a Limiter that passes every limiter in turn
+type chain []Limiter
+
+// Chain combines limiters, e.g. a per-second and a per-hour bucket; a
+// request may be issued once all of them allow it. Nil limiters are skipped.
+func Chain(limiters ...Limiter) Limiter {
+	var c chain
+	for _, l := range limiters {
+		if l != nil {
+			c = append(c, l)
+		}
+	}
+	return c
+}
+
+// Wait blocks until every limiter of the chain allows a request
+func (c chain) Wait(ctx context.Context) error {
+	for _, l := range c {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBucketTake(t *testing.T) {
+	tests := []struct {
+		name      string
+		perSecond float64
+		burst     int
+		advance   []time.Duration // clock advance before each take
+		want      []time.Duration
+	}{
+		{
+			name:      "burst then refill interval",
+			perSecond: 2, burst: 2,
+			advance: []time.Duration{0, 0, 0},
+			want:    []time.Duration{0, 0, 500 * time.Millisecond},
+		},
+		{
+			name:      "refilled after waiting",
+			perSecond: 2, burst: 1,
+			advance: []time.Duration{0, 0, 500 * time.Millisecond},
+			want:    []time.Duration{0, 500 * time.Millisecond, 0},
+		},
+		{
+			name:      "refill capped at burst",
+			perSecond: 10, burst: 1,
+			advance: []time.Duration{0, time.Hour, 0},
+			want:    []time.Duration{0, 0, 100 * time.Millisecond},
+		},
+		{
+			name:      "hourly quota",
+			perSecond: 1200.0 / 3600, burst: 2,
+			advance: []time.Duration{0, 0, 0},
+			want:    []time.Duration{0, 0, 3 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+			b := NewBucket(tt.perSecond, tt.burst)
+			b.now = func() time.Time { return now }
+
+			for i, advance := range tt.advance {
+				now = now.Add(advance)
+				got := b.take()
+				if diff := got - tt.want[i]; diff < -time.Millisecond || diff > time.Millisecond {
+					t.Errorf("take #%d = %v, want %v", i+1, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChainWaitsForEveryLimiter(t *testing.T) {
+	ctx := context.Background()
+	fast := NewBucket(1000, 5)
+	slow := NewBucket(0.1, 1)
+	limiter := Chain(fast, nil, slow)
+
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	// The slow bucket is empty now, so the chain must block
+	cancelled, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(cancelled); err == nil {
+		t.Error("Wait() succeeded while the slow limiter was empty")
+	}
+}
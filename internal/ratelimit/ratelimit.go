@@ -0,0 +1,28 @@
+// Package ratelimit throttles requests to the 42 API so the CLI stays within
+// the per-application quota instead of relying on retries after a 429.
+package ratelimit
+
+import "context"
+
+const (
+	// DefaultPerSecond is the 42 API's per-application request rate
+	DefaultPerSecond = 2.0
+
+	// DefaultBurst is the number of requests that may be issued back to back
+	DefaultBurst = 2
+
+	// DefaultPerHour is the 42 API's per-application hourly request quota
+	DefaultPerHour = 1200
+)
+
+// Limiter blocks until a request may be issued
+type Limiter interface {
+	// Wait blocks until a request is allowed or ctx is done
+	Wait(ctx context.Context) error
+}
+
+// NewDefault returns the limiter of the 42 API's default quota: 2 requests
+// per second and 1200 per hour
+func NewDefault() Limiter {
+	return Chain(NewBucket(DefaultPerSecond, DefaultBurst), NewHourlyBucket(DefaultPerHour))
+}
@@ -0,0 +1,168 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// sharedStateFile holds the bucket shared by every t42 process
+	sharedStateFile = "ratelimit.json"
+
+	// sharedLockFile carries the advisory lock guarding sharedStateFile
+	// against concurrent updates; the file itself is never removed
+	sharedLockFile = "ratelimit.lock"
+
+	// lockPollInterval is the delay between attempts to acquire the lock
+	lockPollInterval = 5 * time.Millisecond
+)
+
+// bucketState is the on-disk representation of the shared token bucket
+type bucketState struct {
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SharedBucket is a token bucket persisted in a state directory so that
+// concurrently running t42 processes draw from the same request budget.
+// Access to the state file is serialized with an OS advisory lock, which the
+// kernel releases when a holder dies, so a crashed process never leaves a
+// stale lock behind.
+type SharedBucket struct {
+	dir       string
+	perSecond float64
+	burst     float64
+}
+
+// NewSharedBucket creates a bucket stored in dir that refills at perSecond
+// tokens per second and holds at most burst tokens
+func NewSharedBucket(dir string, perSecond float64, burst int) *SharedBucket {
+	if perSecond <= 0 {
+		perSecond = DefaultPerSecond
+	}
+	if burst <= 0 {
+		burst = DefaultBurst
+	}
+	return &SharedBucket{dir: dir, perSecond: perSecond, burst: float64(burst)}
+}
+
+// Wait blocks until a token is available in the shared bucket
+func (b *SharedBucket) Wait(ctx context.Context) error {
+	if err := os.MkdirAll(b.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create rate limit directory: %w", err)
+	}
+
+	for {
+		wait, err := b.take(ctx)
+		if err != nil {
+			return err
+		}
+		if wait == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// take tries to consume a token and returns how long to wait before retrying
+// when none is available (zero means a token was consumed)
+func (b *SharedBucket) take(ctx context.Context) (time.Duration, error) {
+	unlock, err := b.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	state := b.load()
+	now := time.Now()
+
+	// Refill based on the time elapsed since the last update by any process
+	elapsed := now.Sub(state.UpdatedAt).Seconds()
+	if elapsed > 0 {
+		state.Tokens += elapsed * b.perSecond
+	}
+	if state.Tokens > b.burst {
+		state.Tokens = b.burst
+	}
+	state.UpdatedAt = now
+
+	var wait time.Duration
+	if state.Tokens >= 1 {
+		state.Tokens--
+	} else {
+		wait = time.Duration((1 - state.Tokens) / b.perSecond * float64(time.Second))
+	}
+
+	if err := b.save(state); err != nil {
+		return 0, err
+	}
+	return wait, nil
+}
+
+// load reads the bucket state, starting from a full bucket when it is missing or unreadable
+func (b *SharedBucket) load() bucketState {
+	data, err := os.ReadFile(filepath.Join(b.dir, sharedStateFile))
+	if err != nil {
+		return bucketState{Tokens: b.burst, UpdatedAt: time.Now()}
+	}
+
+	var state bucketState
+	if err := json.Unmarshal(data, &state); err != nil || state.UpdatedAt.IsZero() {
+		return bucketState{Tokens: b.burst, UpdatedAt: time.Now()}
+	}
+	return state
+}
+
+// save writes the bucket state; callers must hold the lock
+func (b *SharedBucket) save(state bucketState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rate limit state: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(b.dir, sharedStateFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to write rate limit state: %w", err)
+	}
+	return nil
+}
+
+// lock acquires the exclusive advisory lock and returns a function releasing it
+func (b *SharedBucket) lock(ctx context.Context) (func(), error) {
+	f, err := os.OpenFile(filepath.Join(b.dir, sharedLockFile), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rate limit lock: %w", err)
+	}
+
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to acquire rate limit lock: %w", err)
+		}
+		if locked {
+			return func() {
+				if err := unlockFile(f); err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to release rate limit lock: %v\n", err)
+				}
+				if err := f.Close(); err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to close rate limit lock: %v\n", err)
+				}
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			f.Close()
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
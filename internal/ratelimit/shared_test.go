@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSharedBucketBurst(t *testing.T) {
+	bucket := NewSharedBucket(t.TempDir(), 1, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := bucket.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("burst of 3 took %v, want near-immediate", elapsed)
+	}
+}
+
+func TestSharedBucketSharedBetweenInstances(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// Two buckets over the same directory model two separate processes
+	first := NewSharedBucket(dir, 20, 1)
+	second := NewSharedBucket(dir, 20, 1)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for _, bucket := range []*SharedBucket{first, second} {
+		wg.Add(1)
+		go func(b *SharedBucket) {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				if err := b.Wait(ctx); err != nil {
+					t.Errorf("Wait() error = %v", err)
+					return
+				}
+			}
+		}(bucket)
+	}
+	wg.Wait()
+
+	// 6 requests at 20/s with a burst of 1 need at least 5 refill intervals
+	if elapsed := time.Since(start); elapsed < 225*time.Millisecond {
+		t.Errorf("6 shared requests took %v, want at least 250ms", elapsed)
+	}
+}
+
+func TestSharedBucketContextCancelled(t *testing.T) {
+	bucket := NewSharedBucket(t.TempDir(), 0.1, 1)
+
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := bucket.Wait(ctx); err == nil {
+		t.Error("Wait() with exhausted bucket and expiring context should error")
+	}
+}
+
+func TestSharedBucketStaleLock(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, sharedLockFile)
+
+	if err := os.WriteFile(lockPath, nil, 0600); err != nil {
+		t.Fatalf("failed to create lock: %v", err)
+	}
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatalf("failed to age lock: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := NewSharedBucket(dir, 10, 1).Wait(ctx); err != nil {
+		t.Errorf("Wait() with stale lock error = %v", err)
+	}
+}
+
+func TestSharedBucketStaleLockConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, sharedLockFile)
+
+	// A lock file left behind by a crashed process must not stop anyone, and
+	// must not let two waiters in at once
+	if err := os.WriteFile(lockPath, nil, 0600); err != nil {
+		t.Fatalf("failed to create lock: %v", err)
+	}
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatalf("failed to age lock: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var holders, maxHolders int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A bucket per goroutine models a separate process
+			bucket := NewSharedBucket(dir, 10, 1)
+			for j := 0; j < 5; j++ {
+				unlock, err := bucket.lock(ctx)
+				if err != nil {
+					t.Errorf("lock() error = %v", err)
+					return
+				}
+				n := atomic.AddInt32(&holders, 1)
+				for {
+					m := atomic.LoadInt32(&maxHolders)
+					if n <= m || atomic.CompareAndSwapInt32(&maxHolders, m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&holders, -1)
+				unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxHolders != 1 {
+		t.Errorf("lock held by %d waiters at once, want 1", maxHolders)
+	}
+	if _, err := os.Stat(lockPath); err != nil {
+		t.Errorf("lock file removed on release: %v", err)
+	}
+}
+
+func TestSharedBucketHeldLockNotBroken(t *testing.T) {
+	dir := t.TempDir()
+	unlock, err := NewSharedBucket(dir, 10, 1).lock(context.Background())
+	if err != nil {
+		t.Fatalf("lock() error = %v", err)
+	}
+	defer unlock()
+
+	// However long the holder keeps it, another waiter only gets in once it is released
+	lockPath := filepath.Join(dir, sharedLockFile)
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatalf("failed to age lock: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := NewSharedBucket(dir, 10, 1).lock(ctx); err == nil {
+		t.Error("lock() acquired a lock still held by another waiter")
+	}
+}
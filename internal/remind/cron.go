@@ -0,0 +1,122 @@
+package remind
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cronMarker tags crontab lines written by t42, followed by the job name
+const cronMarker = "# t42:"
+
+// cronScheduler installs jobs in the user's crontab
+type cronScheduler struct {
+	env Env
+}
+
+func (s *cronScheduler) Name() string {
+	return "cron"
+}
+
+// read returns the current crontab, empty when the user has none
+func (s *cronScheduler) read() (string, error) {
+	out, err := s.env.Run("", "crontab", "-l")
+	if err != nil {
+		if strings.Contains(out, "no crontab") {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read crontab: %w: %s", err, strings.TrimSpace(out))
+	}
+	return out, nil
+}
+
+func (s *cronScheduler) write(crontab string) error {
+	if out, err := s.env.Run(crontab, "crontab", "-"); err != nil {
+		return fmt.Errorf("failed to write crontab: %w: %s", err, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// withoutJob returns the crontab lines that do not belong to the job
+func withoutJob(crontab, name string) ([]string, bool) {
+	var kept []string
+	found := false
+	for _, line := range strings.Split(strings.TrimRight(crontab, "\n"), "\n") {
+		if strings.HasSuffix(strings.TrimSpace(line), cronMarker+name) {
+			found = true
+			continue
+		}
+		if line != "" || len(kept) > 0 {
+			kept = append(kept, line)
+		}
+	}
+	return kept, found
+}
+
+func (s *cronScheduler) Install(job Job) (string, error) {
+	if err := job.Validate(); err != nil {
+		return "", err
+	}
+	crontab, err := s.read()
+	if err != nil {
+		return "", err
+	}
+
+	lines, _ := withoutJob(crontab, job.Name)
+	lines = append(lines, fmt.Sprintf("%d %d * * * %s %s%s", job.Minute, job.Hour, shellQuote(job.Command), cronMarker, job.Name))
+	if err := s.write(strings.Join(lines, "\n") + "\n"); err != nil {
+		return "", err
+	}
+	return "crontab", nil
+}
+
+func (s *cronScheduler) Remove(name string) (bool, error) {
+	crontab, err := s.read()
+	if err != nil {
+		return false, err
+	}
+	lines, found := withoutJob(crontab, name)
+	if !found {
+		return false, nil
+	}
+
+	updated := strings.Join(lines, "\n")
+	if updated != "" {
+		updated += "\n"
+	}
+	return true, s.write(updated)
+}
+
+func (s *cronScheduler) List() ([]Entry, error) {
+	crontab, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return parseCronEntries(crontab), nil
+}
+
+// parseCronEntries returns the t42 jobs of a crontab
+func parseCronEntries(crontab string) []Entry {
+	var entries []Entry
+	for _, line := range strings.Split(crontab, "\n") {
+		line = strings.TrimSpace(line)
+		idx := strings.LastIndex(line, cronMarker)
+		if idx < 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry := Entry{Scheduler: "cron", Name: line[idx+len(cronMarker):], Location: "crontab"}
+
+		fields := strings.Fields(line[:idx])
+		if len(fields) < 6 {
+			continue
+		}
+		var minute, hour int
+		if _, err := fmt.Sscanf(fields[0]+" "+fields[1], "%d %d", &minute, &hour); err == nil && fields[2] == "*" && fields[3] == "*" && fields[4] == "*" {
+			entry.Schedule = dailySchedule(hour, minute)
+		} else {
+			entry.Schedule = strings.Join(fields[:5], " ")
+		}
+		entry.Command = strings.Join(fields[5:], " ")
+		entries = append(entries, entry)
+	}
+	return entries
+}
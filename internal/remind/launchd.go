@@ -0,0 +1,150 @@
+package remind
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// launchdLabelPrefix prefixes the labels of t42 launch agents
+const launchdLabelPrefix = "io.github.naokiiida.t42."
+
+// launchdScheduler installs jobs as launchd user agents
+type launchdScheduler struct {
+	env Env
+}
+
+func (s *launchdScheduler) Name() string {
+	return "launchd"
+}
+
+func (s *launchdScheduler) agentDir() string {
+	return filepath.Join(s.env.Home, "Library", "LaunchAgents")
+}
+
+func (s *launchdScheduler) plistPath(name string) string {
+	return filepath.Join(s.agentDir(), launchdLabelPrefix+name+".plist")
+}
+
+func (s *launchdScheduler) Install(job Job) (string, error) {
+	if err := job.Validate(); err != nil {
+		return "", err
+	}
+
+	var args strings.Builder
+	for _, arg := range job.Command {
+		args.WriteString("\t\t<string>")
+		if err := xml.EscapeText(&args, []byte(arg)); err != nil {
+			return "", fmt.Errorf("failed to encode launch agent: %w", err)
+		}
+		args.WriteString("</string>\n")
+	}
+
+	plist := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s%s</string>
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+	<key>StartCalendarInterval</key>
+	<dict>
+		<key>Hour</key>
+		<integer>%d</integer>
+		<key>Minute</key>
+		<integer>%d</integer>
+	</dict>
+</dict>
+</plist>
+`, launchdLabelPrefix, job.Name, args.String(), job.Hour, job.Minute)
+
+	path := s.plistPath(job.Name)
+
+	// A loaded agent keeps its old definition until it is unloaded
+	_, _ = s.env.Run("", "launchctl", "unload", path)
+
+	if err := writeFile(path, []byte(plist)); err != nil {
+		return "", err
+	}
+	if out, err := s.env.Run("", "launchctl", "load", "-w", path); err != nil {
+		return "", fmt.Errorf("launchctl load failed: %w: %s", err, strings.TrimSpace(out))
+	}
+	return path, nil
+}
+
+func (s *launchdScheduler) Remove(name string) (bool, error) {
+	path := s.plistPath(name)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return false, nil
+	}
+	_, _ = s.env.Run("", "launchctl", "unload", "-w", path)
+	if err := os.Remove(path); err != nil {
+		return false, fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return true, nil
+}
+
+var (
+	plistHourPattern   = regexp.MustCompile(`<key>Hour</key>\s*<integer>(\d+)</integer>`)
+	plistMinutePattern = regexp.MustCompile(`<key>Minute</key>\s*<integer>(\d+)</integer>`)
+	plistArgsPattern   = regexp.MustCompile(`(?s)<key>ProgramArguments</key>\s*<array>(.*?)</array>`)
+	plistStringPattern = regexp.MustCompile(`<string>(.*?)</string>`)
+)
+
+func (s *launchdScheduler) List() ([]Entry, error) {
+	paths, err := filepath.Glob(filepath.Join(s.agentDir(), launchdLabelPrefix+"*.plist"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list launch agents: %w", err)
+	}
+
+	var entries []Entry
+	for _, path := range paths {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), launchdLabelPrefix), ".plist")
+		entry := Entry{Scheduler: s.Name(), Name: name, Location: path}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			entries = append(entries, entry)
+			continue
+		}
+		plist := string(data)
+
+		hour, hourOK := plistInt(plistHourPattern, plist)
+		minute, minuteOK := plistInt(plistMinutePattern, plist)
+		if hourOK && minuteOK {
+			entry.Schedule = dailySchedule(hour, minute)
+		}
+		if m := plistArgsPattern.FindStringSubmatch(plist); m != nil {
+			var args []string
+			for _, arg := range plistStringPattern.FindAllStringSubmatch(m[1], -1) {
+				args = append(args, xmlUnescape(arg[1]))
+			}
+			entry.Command = shellQuote(args)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func plistInt(pattern *regexp.Regexp, plist string) (int, bool) {
+	m := pattern.FindStringSubmatch(plist)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	return n, err == nil
+}
+
+func xmlUnescape(s string) string {
+	var out string
+	if err := xml.Unmarshal([]byte("<s>"+s+"</s>"), &out); err != nil {
+		return s
+	}
+	return out
+}
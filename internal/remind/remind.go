@@ -0,0 +1,193 @@
+// Package remind installs daily jobs running t42 in the user's own system
+// scheduler (systemd user timers, launchd agents or cron), so reminders
+// work without keeping a daemon running.
+package remind
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Job is a command run once a day
+type Job struct {
+	Name    string   // short identifier, e.g. "notify"
+	Command []string // executable (absolute path) and arguments
+	Hour    int
+	Minute  int
+}
+
+// Validate checks that the job can be scheduled
+func (j Job) Validate() error {
+	if j.Name == "" || strings.ContainsAny(j.Name, " /\\.") {
+		return fmt.Errorf("invalid job name %q", j.Name)
+	}
+	if len(j.Command) == 0 || !filepath.IsAbs(j.Command[0]) {
+		return fmt.Errorf("job command must start with an absolute path")
+	}
+	if j.Hour < 0 || j.Hour > 23 || j.Minute < 0 || j.Minute > 59 {
+		return fmt.Errorf("invalid time %02d:%02d", j.Hour, j.Minute)
+	}
+	return nil
+}
+
+// Entry is an installed job as found in a scheduler
+type Entry struct {
+	Scheduler string `json:"scheduler"`
+	Name      string `json:"name"`
+	Schedule  string `json:"schedule"` // "daily at HH:MM"
+	Command   string `json:"command"`
+	Location  string `json:"location"` // file or crontab holding the job
+}
+
+// Scheduler installs, lists and removes jobs in one system scheduler
+type Scheduler interface {
+	// Name returns the scheduler name: "systemd", "launchd" or "cron"
+	Name() string
+	// Install adds or replaces the job and returns where it was written
+	Install(job Job) (string, error)
+	// Remove deletes the job and reports whether it was installed
+	Remove(name string) (bool, error)
+	// List returns the t42 jobs installed in the scheduler
+	List() ([]Entry, error)
+}
+
+// Runner runs an external command with the given standard input and returns
+// its combined output
+type Runner func(stdin string, name string, args ...string) (string, error)
+
+// Env is the part of the environment the schedulers depend on
+type Env struct {
+	Home       string // home directory
+	ConfigHome string // XDG config directory (systemd units)
+	Run        Runner
+	LookPath   func(file string) (string, error)
+}
+
+// DefaultEnv returns the environment of the current user
+func DefaultEnv() (Env, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Env{}, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+	return Env{
+		Home:       home,
+		ConfigHome: configHome,
+		Run:        runCommand,
+		LookPath:   exec.LookPath,
+	}, nil
+}
+
+func runCommand(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return out.String(), err
+}
+
+// New returns the scheduler with the given name
+func New(name string, env Env) (Scheduler, error) {
+	switch name {
+	case "systemd":
+		return &systemdScheduler{env: env}, nil
+	case "launchd":
+		return &launchdScheduler{env: env}, nil
+	case "cron":
+		return &cronScheduler{env: env}, nil
+	}
+	return nil, fmt.Errorf("unknown scheduler %q (use systemd, launchd or cron)", name)
+}
+
+// Available returns the schedulers usable on goos, preferred first
+func Available(goos string, env Env) []Scheduler {
+	var schedulers []Scheduler
+	has := func(file string) bool {
+		_, err := env.LookPath(file)
+		return err == nil
+	}
+
+	switch goos {
+	case "darwin":
+		if has("launchctl") {
+			schedulers = append(schedulers, &launchdScheduler{env: env})
+		}
+	case "windows":
+		return nil
+	default:
+		if has("systemctl") && systemdUserRunning(env) {
+			schedulers = append(schedulers, &systemdScheduler{env: env})
+		}
+	}
+	if has("crontab") {
+		schedulers = append(schedulers, &cronScheduler{env: env})
+	}
+	return schedulers
+}
+
+// Detect returns the preferred scheduler on goos
+func Detect(goos string, env Env) (Scheduler, error) {
+	schedulers := Available(goos, env)
+	if len(schedulers) == 0 {
+		if goos == "windows" {
+			return nil, fmt.Errorf("no supported scheduler on Windows - create a daily task with: schtasks /Create /SC DAILY /TN t42-notify /TR \"t42 notify check\" /ST 08:00")
+		}
+		return nil, fmt.Errorf("no supported scheduler found (systemd, launchd or cron)")
+	}
+	return schedulers[0], nil
+}
+
+// ParseTime parses a time of day in HH:MM format
+func ParseTime(s string) (hour, minute int, err error) {
+	if _, err := fmt.Sscanf(s, "%d:%d", &hour, &minute); err != nil || len(s) < 4 || len(s) > 5 {
+		return 0, 0, fmt.Errorf("invalid time %q (use HH:MM, e.g. 08:00)", s)
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time %q (use HH:MM, e.g. 08:00)", s)
+	}
+	return hour, minute, nil
+}
+
+func dailySchedule(hour, minute int) string {
+	return fmt.Sprintf("daily at %02d:%02d", hour, minute)
+}
+
+// shellQuote quotes args for a POSIX shell
+func shellQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`*?[]#~=%;&|<>(){}!") {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// writeFile writes data atomically, creating the parent directory
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package remind
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSystem records commands and keeps a crontab in memory
+type fakeSystem struct {
+	commands []string
+	crontab  string
+	hasTab   bool
+}
+
+func (f *fakeSystem) run(stdin string, name string, args ...string) (string, error) {
+	f.commands = append(f.commands, strings.Join(append([]string{name}, args...), " "))
+	if name == "crontab" {
+		if len(args) == 1 && args[0] == "-l" {
+			if !f.hasTab {
+				return "no crontab for user\n", fmt.Errorf("exit status 1")
+			}
+			return f.crontab, nil
+		}
+		f.crontab = stdin
+		f.hasTab = true
+	}
+	if name == "systemctl" && len(args) > 1 && args[1] == "is-system-running" {
+		return "running\n", nil
+	}
+	return "", nil
+}
+
+func testEnv(t *testing.T, sys *fakeSystem) Env {
+	home := t.TempDir()
+	return Env{
+		Home:       home,
+		ConfigHome: filepath.Join(home, ".config"),
+		Run:        sys.run,
+		LookPath:   func(file string) (string, error) { return "/usr/bin/" + file, nil },
+	}
+}
+
+var testJob = Job{Name: "notify", Command: []string{"/opt/t 42/t42", "notify", "check"}, Hour: 8, Minute: 5}
+
+func TestSchedulersRoundTrip(t *testing.T) {
+	for _, name := range []string{"systemd", "launchd", "cron"} {
+		t.Run(name, func(t *testing.T) {
+			sys := &fakeSystem{}
+			scheduler, err := New(name, testEnv(t, sys))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			// Installing twice must replace, not duplicate, the job
+			for i := 0; i < 2; i++ {
+				if _, err := scheduler.Install(testJob); err != nil {
+					t.Fatalf("Install() error = %v", err)
+				}
+			}
+
+			entries, err := scheduler.List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("List() = %+v, want one entry", entries)
+			}
+			entry := entries[0]
+			if entry.Name != "notify" || entry.Schedule != "daily at 08:05" {
+				t.Errorf("entry = %+v, want notify daily at 08:05", entry)
+			}
+			if !strings.Contains(entry.Command, "t 42/t42") || !strings.HasSuffix(entry.Command, "notify check") {
+				t.Errorf("entry command = %q", entry.Command)
+			}
+
+			removed, err := scheduler.Remove("notify")
+			if err != nil || !removed {
+				t.Fatalf("Remove() = %v, %v, want true", removed, err)
+			}
+			if removed, _ := scheduler.Remove("notify"); removed {
+				t.Error("second Remove() = true, want false")
+			}
+			if entries, _ := scheduler.List(); len(entries) != 0 {
+				t.Errorf("List() after Remove() = %+v", entries)
+			}
+		})
+	}
+}
+
+func TestCronKeepsOtherLines(t *testing.T) {
+	sys := &fakeSystem{crontab: "MAILTO=me\n0 1 * * * backup\n", hasTab: true}
+	scheduler, _ := New("cron", testEnv(t, sys))
+
+	if _, err := scheduler.Install(testJob); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	want := "MAILTO=me\n0 1 * * * backup\n5 8 * * * '/opt/t 42/t42' notify check # t42:notify\n"
+	if sys.crontab != want {
+		t.Errorf("crontab = %q, want %q", sys.crontab, want)
+	}
+
+	if _, err := scheduler.Remove("notify"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if sys.crontab != "MAILTO=me\n0 1 * * * backup\n" {
+		t.Errorf("crontab after Remove() = %q", sys.crontab)
+	}
+}
+
+func TestSystemdUnits(t *testing.T) {
+	sys := &fakeSystem{}
+	env := testEnv(t, sys)
+	scheduler, _ := New("systemd", env)
+
+	if _, err := scheduler.Install(testJob); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	timer, err := os.ReadFile(filepath.Join(env.ConfigHome, "systemd", "user", "t42-notify.timer"))
+	if err != nil {
+		t.Fatalf("timer not written: %v", err)
+	}
+	if !strings.Contains(string(timer), "OnCalendar=*-*-* 08:05:00") {
+		t.Errorf("timer = %s", timer)
+	}
+	service, _ := os.ReadFile(filepath.Join(env.ConfigHome, "systemd", "user", "t42-notify.service"))
+	if !strings.Contains(string(service), `ExecStart="/opt/t 42/t42" notify check`) {
+		t.Errorf("service = %s", service)
+	}
+	if got := sys.commands[len(sys.commands)-1]; got != "systemctl --user enable --now t42-notify.timer" {
+		t.Errorf("last command = %q", got)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		in           string
+		hour, minute int
+		wantErr      bool
+	}{
+		{"08:00", 8, 0, false},
+		{"7:30", 7, 30, false},
+		{"23:59", 23, 59, false},
+		{"24:00", 0, 0, true},
+		{"8", 0, 0, true},
+		{"08:60", 0, 0, true},
+		{"noon", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			hour, minute, err := ParseTime(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTime(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && (hour != tt.hour || minute != tt.minute) {
+				t.Errorf("ParseTime(%q) = %d:%d, want %d:%d", tt.in, hour, minute, tt.hour, tt.minute)
+			}
+		})
+	}
+}
+
+func TestJobValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     Job
+		wantErr bool
+	}{
+		{"valid", testJob, false},
+		{"relative command", Job{Name: "x", Command: []string{"t42"}}, true},
+		{"bad name", Job{Name: "a/b", Command: []string{"/t42"}}, true},
+		{"bad hour", Job{Name: "x", Command: []string{"/t42"}, Hour: 24}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.job.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package remind
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// systemdUnitPrefix prefixes the unit names of t42 jobs
+const systemdUnitPrefix = "t42-"
+
+// systemdScheduler installs jobs as systemd user timers
+type systemdScheduler struct {
+	env Env
+}
+
+func (s *systemdScheduler) Name() string {
+	return "systemd"
+}
+
+// systemdUserRunning reports whether a systemd user manager is available
+func systemdUserRunning(env Env) bool {
+	out, _ := env.Run("", "systemctl", "--user", "is-system-running")
+	switch strings.TrimSpace(out) {
+	case "running", "degraded", "starting", "initializing":
+		return true
+	}
+	return false
+}
+
+func (s *systemdScheduler) unitDir() string {
+	return filepath.Join(s.env.ConfigHome, "systemd", "user")
+}
+
+func (s *systemdScheduler) unitPath(name, suffix string) string {
+	return filepath.Join(s.unitDir(), systemdUnitPrefix+name+suffix)
+}
+
+func (s *systemdScheduler) Install(job Job) (string, error) {
+	if err := job.Validate(); err != nil {
+		return "", err
+	}
+
+	service := fmt.Sprintf(`[Unit]
+Description=t42 %[1]s check
+
+[Service]
+Type=oneshot
+ExecStart=%[2]s
+`, job.Name, systemdQuote(job.Command))
+
+	timer := fmt.Sprintf(`[Unit]
+Description=Run t42 %[1]s check daily
+
+[Timer]
+OnCalendar=*-*-* %02[2]d:%02[3]d:00
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`, job.Name, job.Hour, job.Minute)
+
+	timerPath := s.unitPath(job.Name, ".timer")
+	if err := writeFile(s.unitPath(job.Name, ".service"), []byte(service)); err != nil {
+		return "", err
+	}
+	if err := writeFile(timerPath, []byte(timer)); err != nil {
+		return "", err
+	}
+
+	if out, err := s.env.Run("", "systemctl", "--user", "daemon-reload"); err != nil {
+		return "", fmt.Errorf("systemctl daemon-reload failed: %w: %s", err, strings.TrimSpace(out))
+	}
+	unit := systemdUnitPrefix + job.Name + ".timer"
+	if out, err := s.env.Run("", "systemctl", "--user", "enable", "--now", unit); err != nil {
+		return "", fmt.Errorf("failed to enable %s: %w: %s", unit, err, strings.TrimSpace(out))
+	}
+	return timerPath, nil
+}
+
+func (s *systemdScheduler) Remove(name string) (bool, error) {
+	timerPath := s.unitPath(name, ".timer")
+	if _, err := os.Stat(timerPath); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	// Disabling fails when the timer was never enabled; removing the files is what counts
+	_, _ = s.env.Run("", "systemctl", "--user", "disable", "--now", systemdUnitPrefix+name+".timer")
+
+	for _, path := range []string{timerPath, s.unitPath(name, ".service")} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	if out, err := s.env.Run("", "systemctl", "--user", "daemon-reload"); err != nil {
+		return true, fmt.Errorf("systemctl daemon-reload failed: %w: %s", err, strings.TrimSpace(out))
+	}
+	return true, nil
+}
+
+func (s *systemdScheduler) List() ([]Entry, error) {
+	timers, err := filepath.Glob(filepath.Join(s.unitDir(), systemdUnitPrefix+"*.timer"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list systemd timers: %w", err)
+	}
+
+	var entries []Entry
+	for _, timerPath := range timers {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(timerPath), systemdUnitPrefix), ".timer")
+		entry := Entry{Scheduler: s.Name(), Name: name, Location: timerPath}
+
+		if data, err := os.ReadFile(timerPath); err == nil {
+			if calendar := unitValue(string(data), "OnCalendar"); calendar != "" {
+				var hour, minute int
+				if _, err := fmt.Sscanf(calendar, "*-*-* %d:%d", &hour, &minute); err == nil {
+					entry.Schedule = dailySchedule(hour, minute)
+				} else {
+					entry.Schedule = calendar
+				}
+			}
+		}
+		if data, err := os.ReadFile(s.unitPath(name, ".service")); err == nil {
+			entry.Command = unitValue(string(data), "ExecStart")
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// unitValue returns the value of the first key=value line of a unit file
+func unitValue(unit, key string) string {
+	for _, line := range strings.Split(unit, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), key+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// systemdQuote quotes args for an ExecStart line
+func systemdQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\"'\\$%;") {
+			quoted[i] = arg
+			continue
+		}
+		arg = strings.ReplaceAll(arg, `\`, `\\`)
+		arg = strings.ReplaceAll(arg, `"`, `\"`)
+		arg = strings.ReplaceAll(arg, "$", "$$")
+		arg = strings.ReplaceAll(arg, "%", "%%")
+		quoted[i] = `"` + arg + `"`
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,498 @@
+// Package sqlite writes SQLite database files in pure Go, without cgo or an
+// SQL engine. Tables are written once, in full: the result is an ordinary
+// database that the sqlite3 shell and any SQLite library can open, query
+// and change.
+//
+// The file format is described at https://www.sqlite.org/fileformat.html.
+package sqlite
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// pageSize is the size of every page of the database
+	pageSize = 4096
+
+	// fileHeaderSize is the size of the database header on page 1
+	fileHeaderSize = 100
+
+	// sqliteVersion is the SQLite version the file claims to be written by
+	sqliteVersion = 3045000
+
+	leafTablePage     = 0x0d
+	interiorTablePage = 0x05
+)
+
+// identifier matches the table and column names Write accepts
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Column is a column of a table
+type Column struct {
+	Name string
+	// Type is the declared type: INTEGER, REAL, TEXT or BLOB
+	Type string
+}
+
+// Table is a table and all of its rows
+type Table struct {
+	Name    string
+	Columns []Column
+	// Key names the INTEGER PRIMARY KEY column, whose values become the
+	// rowids and must be unique. Without one, rows are numbered in order.
+	Key string
+	// Rows hold one value per column: nil, bool, int, int64, float64,
+	// string or []byte
+	Rows [][]interface{}
+}
+
+// createSQL returns the CREATE TABLE statement of the table
+func (t *Table) createSQL() string {
+	columns := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		columns[i] = c.Name + " " + c.Type
+		if c.Name == t.Key {
+			columns[i] += " PRIMARY KEY"
+		}
+	}
+	return fmt.Sprintf("CREATE TABLE %s (%s)", t.Name, strings.Join(columns, ", "))
+}
+
+// validate checks the names and the shape of the rows of the table, and
+// returns the index of its key column, -1 without one
+func (t *Table) validate() (int, error) {
+	if !identifier.MatchString(t.Name) {
+		return 0, fmt.Errorf("invalid table name %q", t.Name)
+	}
+	key := -1
+	for i, c := range t.Columns {
+		if !identifier.MatchString(c.Name) {
+			return 0, fmt.Errorf("invalid column name %q in table %s", c.Name, t.Name)
+		}
+		if c.Name == t.Key {
+			if c.Type != "INTEGER" {
+				return 0, fmt.Errorf("key column %s.%s must be INTEGER", t.Name, c.Name)
+			}
+			key = i
+		}
+	}
+	if t.Key != "" && key < 0 {
+		return 0, fmt.Errorf("key column %q not found in table %s", t.Key, t.Name)
+	}
+	for _, row := range t.Rows {
+		if len(row) != len(t.Columns) {
+			return 0, fmt.Errorf("row of table %s has %d values for %d columns", t.Name, len(row), len(t.Columns))
+		}
+	}
+	return key, nil
+}
+
+// entry is a row of a b-tree: its rowid and its encoded record
+type entry struct {
+	rowid   int64
+	payload []byte
+}
+
+// entries encodes the rows of the table, ordered by rowid
+func (t *Table) entries() ([]entry, error) {
+	key, err := t.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]entry, len(t.Rows))
+	for i, row := range t.Rows {
+		rowid := int64(i + 1)
+		values := row
+		if key >= 0 {
+			id, ok := toInt64(row[key])
+			if !ok {
+				return nil, fmt.Errorf("key %s.%s of row %d is not an integer", t.Name, t.Key, i+1)
+			}
+			rowid = id
+			// The key is an alias of the rowid, stored as NULL in the record
+			values = append([]interface{}(nil), row...)
+			values[key] = nil
+		}
+		payload, err := encodeRecord(values)
+		if err != nil {
+			return nil, fmt.Errorf("row %d of table %s: %w", i+1, t.Name, err)
+		}
+		entries[i] = entry{rowid: rowid, payload: payload}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].rowid < entries[j].rowid })
+	for i := 1; i < len(entries); i++ {
+		if entries[i].rowid == entries[i-1].rowid {
+			return nil, fmt.Errorf("duplicate key %d in table %s", entries[i].rowid, t.Name)
+		}
+	}
+	return entries, nil
+}
+
+// Write writes a database holding the tables to w
+func Write(w io.Writer, tables []Table) error {
+	b := &builder{pages: [][]byte{nil}} // page 1 is written last
+
+	seen := make(map[string]bool)
+	schema := make([]entry, 0, len(tables))
+	for i := range tables {
+		t := &tables[i]
+		if seen[strings.ToLower(t.Name)] {
+			return fmt.Errorf("duplicate table %s", t.Name)
+		}
+		seen[strings.ToLower(t.Name)] = true
+
+		entries, err := t.entries()
+		if err != nil {
+			return err
+		}
+		root := b.buildTree(entries)
+		record, err := encodeRecord([]interface{}{"table", t.Name, t.Name, int64(root), t.createSQL()})
+		if err != nil {
+			return err
+		}
+		schema = append(schema, entry{rowid: int64(i + 1), payload: record})
+	}
+
+	// The schema table is rooted on page 1, after the file header
+	cells := make([][]byte, len(schema))
+	for i, e := range schema {
+		cells[i] = b.leafCell(e)
+	}
+	if !fits(fileHeaderSize+8, cells) {
+		return fmt.Errorf("too many tables for the first page")
+	}
+	page1 := buildPage(fileHeaderSize, leafTablePage, cells, 0)
+	writeFileHeader(page1, len(b.pages))
+	b.pages[0] = page1
+
+	for _, page := range b.pages {
+		if _, err := w.Write(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// builder allocates the pages of a database
+type builder struct {
+	pages [][]byte
+}
+
+// alloc adds an empty page and returns its number, counting from 1
+func (b *builder) alloc() int {
+	b.pages = append(b.pages, nil)
+	return len(b.pages)
+}
+
+// child is a page of a b-tree level with the largest rowid it holds
+type child struct {
+	page   int
+	maxKey int64
+}
+
+// buildTree writes the b-tree of a table and returns its root page
+func (b *builder) buildTree(entries []entry) int {
+	var level []child
+	var cells [][]byte
+	var maxKey int64
+	size := 8
+	flush := func() {
+		page := b.alloc()
+		b.pages[page-1] = buildPage(0, leafTablePage, cells, 0)
+		level = append(level, child{page: page, maxKey: maxKey})
+		cells, size = nil, 8
+	}
+
+	for _, e := range entries {
+		cell := b.leafCell(e)
+		if len(cells) > 0 && size+2+len(cell) > pageSize {
+			flush()
+		}
+		cells = append(cells, cell)
+		size += 2 + len(cell)
+		maxKey = e.rowid
+	}
+	if len(cells) > 0 || len(level) == 0 {
+		flush()
+	}
+
+	for len(level) > 1 {
+		level = b.interiorLevel(level)
+	}
+	return level[0].page
+}
+
+// interiorLevel writes the interior pages above a level of the b-tree and
+// returns them
+func (b *builder) interiorLevel(children []child) []child {
+	var groups [][]child
+	var group []child
+	size := 12
+	for _, c := range children {
+		// The last child of a page is its right-most pointer rather than a
+		// cell; the previous last one becomes a cell
+		if len(group) > 0 {
+			cell := 2 + 4 + varintLen(uint64(group[len(group)-1].maxKey))
+			if size+cell > pageSize {
+				groups = append(groups, group)
+				group, size = nil, 12
+			} else {
+				size += cell
+			}
+		}
+		group = append(group, c)
+	}
+	groups = append(groups, group)
+
+	// An interior page needs at least one cell besides its right-most
+	// pointer
+	if n := len(groups); n > 1 && len(groups[n-1]) == 1 {
+		previous := groups[n-2]
+		groups[n-1] = append([]child{previous[len(previous)-1]}, groups[n-1]...)
+		groups[n-2] = previous[:len(previous)-1]
+	}
+
+	level := make([]child, 0, len(groups))
+	for _, g := range groups {
+		cells := make([][]byte, 0, len(g)-1)
+		for _, c := range g[:len(g)-1] {
+			cell := make([]byte, 4, 4+9)
+			binary.BigEndian.PutUint32(cell, uint32(c.page))
+			cells = append(cells, append(cell, putVarint(uint64(c.maxKey))...))
+		}
+		last := g[len(g)-1]
+		page := b.alloc()
+		b.pages[page-1] = buildPage(0, interiorTablePage, cells, last.page)
+		level = append(level, child{page: page, maxKey: last.maxKey})
+	}
+	return level
+}
+
+// leafCell encodes a table leaf cell, spilling the end of a large payload
+// to overflow pages
+func (b *builder) leafCell(e entry) []byte {
+	cell := append(putVarint(uint64(len(e.payload))), putVarint(uint64(e.rowid))...)
+	local := localPayload(len(e.payload))
+	cell = append(cell, e.payload[:local]...)
+	if local == len(e.payload) {
+		return cell
+	}
+
+	rest := e.payload[local:]
+	first := len(b.pages) + 1
+	for len(rest) > 0 {
+		n := min(len(rest), pageSize-4)
+		page := make([]byte, pageSize)
+		if n < len(rest) {
+			binary.BigEndian.PutUint32(page, uint32(len(b.pages)+2))
+		}
+		copy(page[4:], rest[:n])
+		b.pages = append(b.pages, page)
+		rest = rest[n:]
+	}
+	next := make([]byte, 4)
+	binary.BigEndian.PutUint32(next, uint32(first))
+	return append(cell, next...)
+}
+
+// localPayload returns how many bytes of a payload of size p are kept in a
+// table leaf cell, the rest going to overflow pages
+func localPayload(p int) int {
+	maxLocal := pageSize - 35
+	if p <= maxLocal {
+		return p
+	}
+	minLocal := (pageSize-12)*32/255 - 23
+	k := minLocal + (p-minLocal)%(pageSize-4)
+	if k <= maxLocal {
+		return k
+	}
+	return minLocal
+}
+
+// fits reports whether cells fit in a page whose b-tree header, and the
+// file header on page 1, take headerSize bytes
+func fits(headerSize int, cells [][]byte) bool {
+	size := headerSize + 2*len(cells)
+	for _, c := range cells {
+		size += len(c)
+	}
+	return size <= pageSize
+}
+
+// buildPage lays out a b-tree page: the header at offset, the cell pointers
+// after it and the cells at the end of the page
+func buildPage(offset int, kind byte, cells [][]byte, rightMost int) []byte {
+	page := make([]byte, pageSize)
+	page[offset] = kind
+	binary.BigEndian.PutUint16(page[offset+3:], uint16(len(cells)))
+	headerSize := 8
+	if kind == interiorTablePage {
+		binary.BigEndian.PutUint32(page[offset+8:], uint32(rightMost))
+		headerSize = 12
+	}
+
+	content := pageSize
+	for i, c := range cells {
+		content -= len(c)
+		copy(page[content:], c)
+		binary.BigEndian.PutUint16(page[offset+headerSize+2*i:], uint16(content))
+	}
+	binary.BigEndian.PutUint16(page[offset+5:], uint16(content))
+	return page
+}
+
+// writeFileHeader fills the database header at the start of page 1
+func writeFileHeader(page []byte, pageCount int) {
+	copy(page, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(page[16:], pageSize)
+	page[18] = 1                             // file format write version: legacy journal
+	page[19] = 1                             // file format read version
+	page[21] = 64                            // maximum embedded payload fraction
+	page[22] = 32                            // minimum embedded payload fraction
+	page[23] = 32                            // leaf payload fraction
+	binary.BigEndian.PutUint32(page[24:], 1) // file change counter
+	binary.BigEndian.PutUint32(page[28:], uint32(pageCount))
+	binary.BigEndian.PutUint32(page[40:], 1) // schema cookie
+	binary.BigEndian.PutUint32(page[44:], 4) // schema format
+	binary.BigEndian.PutUint32(page[56:], 1) // text encoding: UTF-8
+	binary.BigEndian.PutUint32(page[92:], 1) // version-valid-for, the change counter
+	binary.BigEndian.PutUint32(page[96:], sqliteVersion)
+}
+
+// encodeRecord encodes values in the record format
+func encodeRecord(values []interface{}) ([]byte, error) {
+	var types []uint64
+	var body []byte
+	for _, v := range values {
+		switch v := v.(type) {
+		case nil:
+			types = append(types, 0)
+		case bool:
+			if v {
+				types = append(types, 9)
+			} else {
+				types = append(types, 8)
+			}
+		case int, int64:
+			n, _ := toInt64(v)
+			serial, data := encodeInt(n)
+			types = append(types, serial)
+			body = append(body, data...)
+		case float64:
+			data := make([]byte, 8)
+			binary.BigEndian.PutUint64(data, math.Float64bits(v))
+			types = append(types, 7)
+			body = append(body, data...)
+		case string:
+			types = append(types, uint64(2*len(v)+13))
+			body = append(body, v...)
+		case []byte:
+			types = append(types, uint64(2*len(v)+12))
+			body = append(body, v...)
+		default:
+			return nil, fmt.Errorf("unsupported value of type %T", v)
+		}
+	}
+
+	typesSize := 0
+	for _, t := range types {
+		typesSize += varintLen(t)
+	}
+	// The header size counts its own varint
+	headerSize := typesSize + 1
+	for headerSize != typesSize+varintLen(uint64(headerSize)) {
+		headerSize = typesSize + varintLen(uint64(headerSize))
+	}
+
+	record := make([]byte, 0, headerSize+len(body))
+	record = append(record, putVarint(uint64(headerSize))...)
+	for _, t := range types {
+		record = append(record, putVarint(t)...)
+	}
+	return append(record, body...), nil
+}
+
+// encodeInt returns the serial type and big-endian bytes of an integer in
+// the smallest size that holds it
+func encodeInt(n int64) (uint64, []byte) {
+	var size int
+	var serial uint64
+	switch {
+	case n == 0:
+		return 8, nil
+	case n == 1:
+		return 9, nil
+	case n >= math.MinInt8 && n <= math.MaxInt8:
+		serial, size = 1, 1
+	case n >= math.MinInt16 && n <= math.MaxInt16:
+		serial, size = 2, 2
+	case n >= -1<<23 && n < 1<<23:
+		serial, size = 3, 3
+	case n >= math.MinInt32 && n <= math.MaxInt32:
+		serial, size = 4, 4
+	case n >= -1<<47 && n < 1<<47:
+		serial, size = 5, 6
+	default:
+		serial, size = 6, 8
+	}
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(n))
+	return serial, data[8-size:]
+}
+
+// putVarint encodes v as an SQLite varint: big-endian groups of 7 bits,
+// the ninth byte holding 8
+func putVarint(v uint64) []byte {
+	if v > 1<<56-1 {
+		buf := make([]byte, 9)
+		buf[8] = byte(v)
+		v >>= 8
+		for i := 7; i >= 0; i-- {
+			buf[i] = byte(v&0x7f) | 0x80
+			v >>= 7
+		}
+		return buf
+	}
+
+	var groups []byte
+	for {
+		groups = append(groups, byte(v&0x7f))
+		v >>= 7
+		if v == 0 {
+			break
+		}
+	}
+	buf := make([]byte, len(groups))
+	for i := range groups {
+		buf[i] = groups[len(groups)-1-i]
+		if i < len(groups)-1 {
+			buf[i] |= 0x80
+		}
+	}
+	return buf
+}
+
+// varintLen returns the size of the varint encoding of v
+func varintLen(v uint64) int {
+	return len(putVarint(v))
+}
+
+// toInt64 converts the integer types Write accepts
+func toInt64(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,422 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPutVarint(t *testing.T) {
+	tests := []struct {
+		name  string
+		value uint64
+		want  []byte
+	}{
+		{"zero", 0, []byte{0x00}},
+		{"one byte", 0x7f, []byte{0x7f}},
+		{"two bytes", 0x80, []byte{0x81, 0x00}},
+		{"two bytes max", 0x3fff, []byte{0xff, 0x7f}},
+		{"three bytes", 0x4000, []byte{0x81, 0x80, 0x00}},
+		{"eight bytes max", 1<<56 - 1, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}},
+		{"nine bytes", 1 << 56, []byte{0x80, 0xc0, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}},
+		{"max", math.MaxUint64, bytes.Repeat([]byte{0xff}, 9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := putVarint(tt.value)
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("putVarint(%#x) = % x, want % x", tt.value, got, tt.want)
+			}
+			if value, n := readVarint(got); value != tt.value || n != len(got) {
+				t.Errorf("readVarint() = %#x, %d, want %#x, %d", value, n, tt.value, len(got))
+			}
+		})
+	}
+}
+
+func TestEncodeInt(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      int64
+		wantSerial uint64
+		wantSize   int
+	}{
+		{"zero", 0, 8, 0},
+		{"one", 1, 9, 0},
+		{"small", 42, 1, 1},
+		{"negative", -1, 1, 1},
+		{"two bytes", 1000, 2, 2},
+		{"three bytes", 1 << 20, 3, 3},
+		{"four bytes", 1 << 30, 4, 4},
+		{"six bytes", -1 << 40, 5, 6},
+		{"eight bytes", 1 << 60, 6, 8},
+		{"min", math.MinInt64, 6, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serial, data := encodeInt(tt.value)
+			if serial != tt.wantSerial || len(data) != tt.wantSize {
+				t.Errorf("encodeInt(%d) = serial %d, %d bytes, want serial %d, %d bytes", tt.value, serial, len(data), tt.wantSerial, tt.wantSize)
+			}
+			if got := decodeValue(serial, data); got != tt.value {
+				t.Errorf("decoded %v, want %d", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestEncodeRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []interface{}
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:   "mixed values",
+			values: []interface{}{nil, 7, "hi", true, false},
+			want:   []byte{0x06, 0x00, 0x01, 0x11, 0x09, 0x08, 0x07, 'h', 'i'},
+		},
+		{
+			name:   "blob and float",
+			values: []interface{}{[]byte{0xca, 0xfe}, 1.5},
+			want:   []byte{0x03, 0x10, 0x07, 0xca, 0xfe, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:   "empty",
+			values: nil,
+			want:   []byte{0x01},
+		},
+		{
+			name:    "unsupported type",
+			values:  []interface{}{struct{}{}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeRecord(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encodeRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(got, tt.want) {
+				t.Errorf("encodeRecord() = % x, want % x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeRecordLongHeader(t *testing.T) {
+	// 100 text columns make a header longer than 127 bytes, whose size
+	// takes a two-byte varint
+	values := make([]interface{}, 100)
+	for i := range values {
+		values[i] = "value"
+	}
+	record, err := encodeRecord(values)
+	if err != nil {
+		t.Fatalf("encodeRecord() error = %v", err)
+	}
+	got := decodeRecord(record)
+	if !reflect.DeepEqual(got, values) {
+		t.Errorf("decodeRecord() = %v, want %v", got, values)
+	}
+}
+
+func TestLocalPayload(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want int
+	}{
+		{"small", 100, 100},
+		{"max local", 4061, 4061},
+		{"overflow keeps the minimum", 4062, 489},
+		{"large", 20000, 489 + (20000-489)%4092},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localPayload(tt.size); got != tt.want {
+				t.Errorf("localPayload(%d) = %d, want %d", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+// testTables returns tables spanning interior pages, an empty table and an
+// overflowing row
+func testTables() []Table {
+	var users [][]interface{}
+	for i := 3000; i > 0; i-- {
+		users = append(users, []interface{}{i, "login" + strings.Repeat("x", i%40), i%3 == 0, float64(i) / 4, nil})
+	}
+	long := strings.Repeat("a long corrector comment ", 1000)
+	return []Table{
+		{
+			Name:    "users",
+			Key:     "id",
+			Columns: []Column{{"id", "INTEGER"}, {"login", "TEXT"}, {"staff", "INTEGER"}, {"level", "REAL"}, {"note", "TEXT"}},
+			Rows:    users,
+		},
+		{Name: "empty", Columns: []Column{{"a", "TEXT"}}},
+		{
+			Name:    "comments",
+			Columns: []Column{{"comment", "TEXT"}, {"mark", "INTEGER"}},
+			Rows:    [][]interface{}{{long, 100}, {"short", int64(-42)}},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tables := testTables()
+	users := tables[0].Rows
+	long := tables[2].Rows[0][0].(string)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, tables); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	db := buf.Bytes()
+
+	if !bytes.HasPrefix(db, []byte("SQLite format 3\x00")) {
+		t.Fatalf("missing file header")
+	}
+	if len(db)%pageSize != 0 {
+		t.Fatalf("file size %d is not a multiple of the page size", len(db))
+	}
+	if got := int(binary.BigEndian.Uint32(db[28:])); got != len(db)/pageSize {
+		t.Errorf("header page count = %d, want %d", got, len(db)/pageSize)
+	}
+
+	schema := readTable(t, db, 1)
+	if len(schema) != len(tables) {
+		t.Fatalf("schema has %d rows, want %d", len(schema), len(tables))
+	}
+	wantSQL := "CREATE TABLE users (id INTEGER PRIMARY KEY, login TEXT, staff INTEGER, level REAL, note TEXT)"
+	if sql := schema[0].values[4]; sql != wantSQL {
+		t.Errorf("schema sql = %q, want %q", sql, wantSQL)
+	}
+
+	rows := readTable(t, db, int(schema[0].values[3].(int64)))
+	if len(rows) != len(users) {
+		t.Fatalf("users has %d rows, want %d", len(rows), len(users))
+	}
+	for i, row := range rows {
+		id := int64(i + 1)
+		if row.rowid != id {
+			t.Fatalf("row %d has rowid %d, want %d", i, row.rowid, id)
+		}
+		want := []interface{}{nil, "login" + strings.Repeat("x", int(id)%40), boolInt(id%3 == 0), float64(id) / 4, nil}
+		if !reflect.DeepEqual(row.values, want) {
+			t.Fatalf("row %d = %v, want %v", id, row.values, want)
+		}
+	}
+
+	if rows := readTable(t, db, int(schema[1].values[3].(int64))); len(rows) != 0 {
+		t.Errorf("empty table has %d rows", len(rows))
+	}
+
+	rows = readTable(t, db, int(schema[2].values[3].(int64)))
+	if len(rows) != 2 || rows[0].values[0] != long || rows[1].values[1] != int64(-42) {
+		t.Errorf("comments rows not read back")
+	}
+}
+
+func TestWriteReadBySQLite(t *testing.T) {
+	// The other tests read the file back with a reader mirroring the
+	// writer; the real sqlite3 catches a misunderstanding of the format
+	// they share
+	sqlite3, err := exec.LookPath("sqlite3")
+	if err != nil {
+		t.Skip("sqlite3 is not on PATH")
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, testTables()); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "test.db")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"PRAGMA integrity_check", "ok"},
+		{"SELECT COUNT(*), SUM(id), SUM(staff), SUM(level) FROM users", "3000|4501500|1000|1125375.0"},
+		{"SELECT login, staff, level, note IS NULL FROM users WHERE id = 42", "loginxx|1|10.5|1"},
+		{"SELECT COUNT(*) FROM empty", "0"},
+		{"SELECT length(comment), mark FROM comments ORDER BY mark", "5|-42\n25000|100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			out, err := exec.Command(sqlite3, "-batch", path, tt.query).CombinedOutput()
+			if err != nil {
+				t.Fatalf("sqlite3 error = %v: %s", err, out)
+			}
+			if got := strings.TrimSpace(string(out)); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tables []Table
+	}{
+		{"invalid table name", []Table{{Name: "drop table"}}},
+		{"invalid column name", []Table{{Name: "t", Columns: []Column{{"a b", "TEXT"}}}}},
+		{"duplicate table", []Table{{Name: "t"}, {Name: "T"}}},
+		{"missing key column", []Table{{Name: "t", Key: "id", Columns: []Column{{"a", "TEXT"}}}}},
+		{"text key", []Table{{Name: "t", Key: "id", Columns: []Column{{"id", "TEXT"}}}}},
+		{"row too short", []Table{{Name: "t", Columns: []Column{{"a", "TEXT"}}, Rows: [][]interface{}{{}}}}},
+		{"null key", []Table{{Name: "t", Key: "id", Columns: []Column{{"id", "INTEGER"}}, Rows: [][]interface{}{{nil}}}}},
+		{"duplicate key", []Table{{Name: "t", Key: "id", Columns: []Column{{"id", "INTEGER"}}, Rows: [][]interface{}{{1}, {1}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Write(&bytes.Buffer{}, tt.tables); err == nil {
+				t.Errorf("Write() error = nil, want an error")
+			}
+		})
+	}
+}
+
+// row is a row read back from a table b-tree
+type row struct {
+	rowid  int64
+	values []interface{}
+}
+
+// readTable walks the table b-tree rooted at a page and decodes its rows
+func readTable(t *testing.T, db []byte, root int) []row {
+	t.Helper()
+	page := db[(root-1)*pageSize : root*pageSize]
+	offset := 0
+	if root == 1 {
+		offset = fileHeaderSize
+	}
+	count := int(binary.BigEndian.Uint16(page[offset+3:]))
+
+	var rows []row
+	switch page[offset] {
+	case interiorTablePage:
+		for i := 0; i < count; i++ {
+			cell := int(binary.BigEndian.Uint16(page[offset+12+2*i:]))
+			rows = append(rows, readTable(t, db, int(binary.BigEndian.Uint32(page[cell:])))...)
+		}
+		rows = append(rows, readTable(t, db, int(binary.BigEndian.Uint32(page[offset+8:])))...)
+	case leafTablePage:
+		for i := 0; i < count; i++ {
+			cell := page[binary.BigEndian.Uint16(page[offset+8+2*i:]):]
+			size, n := readVarint(cell)
+			rowid, m := readVarint(cell[n:])
+			cell = cell[n+m:]
+			local := localPayload(int(size))
+			payload := append([]byte(nil), cell[:local]...)
+			if local < int(size) {
+				next := int(binary.BigEndian.Uint32(cell[local:]))
+				for next != 0 {
+					overflow := db[(next-1)*pageSize : next*pageSize]
+					n := min(int(size)-len(payload), pageSize-4)
+					payload = append(payload, overflow[4:4+n]...)
+					next = int(binary.BigEndian.Uint32(overflow))
+				}
+			}
+			rows = append(rows, row{rowid: int64(rowid), values: decodeRecord(payload)})
+		}
+	default:
+		t.Fatalf("page %d has type %#x", root, page[offset])
+	}
+	return rows
+}
+
+// readVarint decodes an SQLite varint and returns its size
+func readVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < 8; i++ {
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return v<<8 | uint64(b[8]), 9
+}
+
+// decodeRecord decodes a record, integers as int64
+func decodeRecord(record []byte) []interface{} {
+	headerSize, n := readVarint(record)
+	header := record[n:headerSize]
+	body := record[headerSize:]
+	var values []interface{}
+	for len(header) > 0 {
+		serial, n := readVarint(header)
+		header = header[n:]
+		size := serialSize(serial)
+		values = append(values, decodeValue(serial, body[:size]))
+		body = body[size:]
+	}
+	return values
+}
+
+// serialSize returns the size of the value of a serial type
+func serialSize(serial uint64) int {
+	switch {
+	case serial >= 12:
+		return int(serial-12) / 2
+	case serial == 7 || serial == 6:
+		return 8
+	case serial == 5:
+		return 6
+	case serial >= 1 && serial <= 4:
+		return int(serial)
+	}
+	return 0
+}
+
+// decodeValue decodes the value of a serial type
+func decodeValue(serial uint64, data []byte) interface{} {
+	switch {
+	case serial == 0:
+		return nil
+	case serial == 8:
+		return int64(0)
+	case serial == 9:
+		return int64(1)
+	case serial == 7:
+		return math.Float64frombits(binary.BigEndian.Uint64(data))
+	case serial >= 13 && serial%2 == 1:
+		return string(data)
+	case serial >= 12:
+		return data
+	}
+	n := int64(int8(data[0]))
+	for _, b := range data[1:] {
+		n = n<<8 | int64(b)
+	}
+	return n
+}
+
+// boolInt returns the integer SQLite stores for a boolean
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
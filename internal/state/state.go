@@ -0,0 +1,93 @@
+// Package state persists small JSON documents that t42 keeps between runs
+// (read markers, checkpoints) in the application's state directory.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/naokiiida/t42-cli/internal/config"
+)
+
+// Store is a directory of named JSON documents
+type Store struct {
+	dir string
+}
+
+// New creates a store rooted at the given directory
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Open creates a store in the application's state directory
+func Open() (*Store, error) {
+	dir, err := config.GetStateDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state directory: %w", err)
+	}
+	return New(dir), nil
+}
+
+// path returns the file backing the named document
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
+
+// Load decodes the named document into target. It returns false, leaving
+// target untouched, when the document does not exist yet.
+func (s *Store) Load(name string, target interface{}) (bool, error) {
+	data, err := os.ReadFile(s.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read %s state: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return false, fmt.Errorf("failed to parse %s state: %w", name, err)
+	}
+	return true, nil
+}
+
+// Save replaces the named document with value
+func (s *Store) Save(name string, value interface{}) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s state: %w", name, err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial document
+	tmp, err := os.CreateTemp(s.dir, "."+name+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create state file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		if closeErr := tmp.Close(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close state file: %v\n", closeErr)
+		}
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close state file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(name)); err != nil {
+		return fmt.Errorf("failed to store %s state: %w", name, err)
+	}
+	return nil
+}
+
+// Delete removes the named document, if any
+func (s *Store) Delete(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete %s state: %w", name, err)
+	}
+	return nil
+}
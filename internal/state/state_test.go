@@ -0,0 +1,40 @@
+package state
+
+import "testing"
+
+func TestStoreRoundTrip(t *testing.T) {
+	store := New(t.TempDir())
+
+	type readMarkers struct {
+		Read map[string]bool `json:"read"`
+	}
+
+	var got readMarkers
+	found, err := store.Load("markers", &got)
+	if err != nil || found {
+		t.Fatalf("Load() on empty store = %v, %v, want false, nil", found, err)
+	}
+
+	want := readMarkers{Read: map[string]bool{"42": true}}
+	if err := store.Save("markers", want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	found, err = store.Load("markers", &got)
+	if err != nil || !found {
+		t.Fatalf("Load() = %v, %v, want true, nil", found, err)
+	}
+	if !got.Read["42"] {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+
+	if err := store.Delete("markers"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if found, _ := store.Load("markers", &got); found {
+		t.Error("Load() after Delete() found the document")
+	}
+	if err := store.Delete("markers"); err != nil {
+		t.Errorf("Delete() of a missing document error = %v", err)
+	}
+}
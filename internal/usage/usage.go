@@ -0,0 +1,120 @@
+// Package usage keeps local, opt-in counts of the commands and flags a user
+// runs. Nothing is ever sent anywhere; the counts only power smart defaults
+// such as pre-selecting the most used campus in pickers.
+package usage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/state"
+)
+
+// stateName is the state document holding the counts
+const stateName = "usage"
+
+// Stats are the accumulated usage counts
+type Stats struct {
+	Since    time.Time      `json:"since"`
+	Commands map[string]int `json:"commands"`
+	// Flags counts flags per command, keyed "command --flag"
+	Flags map[string]int `json:"flags"`
+	// Values counts the values of a few non-personal settings, such as the
+	// campus and cursus IDs, keyed by setting then value
+	Values map[string]map[string]int `json:"values,omitempty"`
+}
+
+// Count is a name and how often it was used
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// New returns empty stats starting now
+func New(now time.Time) *Stats {
+	return &Stats{
+		Since:    now,
+		Commands: map[string]int{},
+		Flags:    map[string]int{},
+		Values:   map[string]map[string]int{},
+	}
+}
+
+// RecordCommand counts one run of command with the given flags set
+func (s *Stats) RecordCommand(command string, flags []string) {
+	s.Commands[command]++
+	for _, flag := range flags {
+		s.Flags[command+" --"+flag]++
+	}
+}
+
+// RecordValue counts one use of value for the setting key
+func (s *Stats) RecordValue(key, value string) {
+	if value == "" {
+		return
+	}
+	if s.Values[key] == nil {
+		s.Values[key] = map[string]int{}
+	}
+	s.Values[key][value]++
+}
+
+// TopValue returns the most used value of the setting key, or false when
+// none was recorded
+func (s *Stats) TopValue(key string) (string, bool) {
+	top := Top(s.Values[key], 1)
+	if len(top) == 0 {
+		return "", false
+	}
+	return top[0].Name, true
+}
+
+// Top returns the n largest counts, most used first and ties by name;
+// n <= 0 returns them all
+func Top(counts map[string]int, n int) []Count {
+	result := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, Count{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// Load reads the stats from store, returning empty stats starting now when
+// nothing was recorded yet
+func Load(store *state.Store, now time.Time) (*Stats, error) {
+	stats := New(now)
+	if _, err := store.Load(stateName, stats); err != nil {
+		return nil, fmt.Errorf("failed to load usage stats: %w", err)
+	}
+	// Documents written by older versions may lack some maps
+	if stats.Commands == nil {
+		stats.Commands = map[string]int{}
+	}
+	if stats.Flags == nil {
+		stats.Flags = map[string]int{}
+	}
+	if stats.Values == nil {
+		stats.Values = map[string]map[string]int{}
+	}
+	return stats, nil
+}
+
+// Save writes the stats to store
+func Save(store *state.Store, stats *Stats) error {
+	return store.Save(stateName, stats)
+}
+
+// Reset deletes the recorded stats
+func Reset(store *state.Store) error {
+	return store.Delete(stateName)
+}
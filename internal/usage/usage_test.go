@@ -0,0 +1,91 @@
+package usage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/naokiiida/t42-cli/internal/state"
+)
+
+func TestTop(t *testing.T) {
+	counts := map[string]int{"user show": 3, "project list": 5, "eval list": 3, "auth status": 1}
+
+	tests := []struct {
+		name string
+		n    int
+		want []Count
+	}{
+		{"top two", 2, []Count{{"project list", 5}, {"eval list", 3}}},
+		{"ties by name", 3, []Count{{"project list", 5}, {"eval list", 3}, {"user show", 3}}},
+		{"all", 0, []Count{{"project list", 5}, {"eval list", 3}, {"user show", 3}, {"auth status", 1}}},
+		{"more than recorded", 10, []Count{{"project list", 5}, {"eval list", 3}, {"user show", 3}, {"auth status", 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Top(counts, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Top(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordAndTopValue(t *testing.T) {
+	stats := New(time.Now())
+	if _, ok := stats.TopValue("campus"); ok {
+		t.Error("TopValue() on empty stats reported a value")
+	}
+
+	stats.RecordCommand("user list", []string{"campus", "limit"})
+	stats.RecordCommand("user list", []string{"campus"})
+	stats.RecordValue("campus", "26")
+	stats.RecordValue("campus", "1")
+	stats.RecordValue("campus", "26")
+	stats.RecordValue("campus", "")
+
+	if got := stats.Commands["user list"]; got != 2 {
+		t.Errorf("Commands[user list] = %d, want 2", got)
+	}
+	if got := stats.Flags["user list --campus"]; got != 2 {
+		t.Errorf("Flags[user list --campus] = %d, want 2", got)
+	}
+	if got, ok := stats.TopValue("campus"); !ok || got != "26" {
+		t.Errorf("TopValue(campus) = %q, %v, want 26, true", got, ok)
+	}
+	if got := len(stats.Values["campus"]); got != 2 {
+		t.Errorf("recorded %d campus values, want 2 (empty values are skipped)", got)
+	}
+}
+
+func TestLoadSaveReset(t *testing.T) {
+	store := state.New(t.TempDir())
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	stats, err := Load(store, now)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !stats.Since.Equal(now) || len(stats.Commands) != 0 {
+		t.Fatalf("Load() on empty store = %+v, want empty stats since %v", stats, now)
+	}
+
+	stats.RecordCommand("project list", nil)
+	if err := Save(store, stats); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(store, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.Since.Equal(now) || loaded.Commands["project list"] != 1 {
+		t.Errorf("Load() = %+v, want the saved stats", loaded)
+	}
+
+	if err := Reset(store); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if loaded, _ := Load(store, now); len(loaded.Commands) != 0 {
+		t.Errorf("Load() after Reset() = %+v, want empty stats", loaded)
+	}
+}